@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/infrastructure/container"
+	"github.com/zenfulcode/commercify/internal/infrastructure/database"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+)
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Define command line flags, defaulting to the settings of the reconciliation job
+	dryRunFlag := flag.Bool("dry-run", false, "Report discrepancies without fixing any")
+	staleFlag := flag.Int("stale-after", cfg.Payment.ReconcileStaleAfter, "Minutes an order awaits payment before it is reconciled")
+	lookbackFlag := flag.Int("lookback", cfg.Payment.ReconcileLookback, "Days back orders are reconciled")
+	jsonFlag := flag.Bool("json", false, "Print the report as JSON")
+	flag.Parse()
+
+	// Connect to database
+	db, err := database.NewPostgresConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	diContainer := container.NewContainer(cfg, db, logger.NewLogger())
+
+	report, err := diContainer.UseCases().ReconciliationUseCase().Reconcile(usecase.ReconcileInput{
+		StaleAfter: time.Duration(*staleFlag) * time.Minute,
+		Lookback:   time.Duration(*lookbackFlag) * 24 * time.Hour,
+		DryRun:     *dryRunFlag,
+	})
+	if err != nil {
+		log.Fatalf("Failed to reconcile payments: %v", err)
+	}
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to print report: %v", err)
+		}
+	} else {
+		printReport(report)
+	}
+
+	// Exit with a distinct status when discrepancies need review, so schedulers can alert on it
+	if len(report.Unresolved()) > 0 {
+		os.Exit(2)
+	}
+}
+
+// printReport prints the discrepancies of a report as a table
+func printReport(report *usecase.ReconciliationReport) {
+	fmt.Printf("Checked %d order(s): %d discrepancy(ies) fixed, %d to review\n",
+		report.Checked, len(report.Fixed()), len(report.Unresolved()))
+	if report.DryRun {
+		fmt.Println("Dry run, no discrepancies were fixed")
+	}
+	if len(report.Discrepancies) == 0 {
+		return
+	}
+
+	fmt.Println()
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ORDER\tSTATUS\tPROVIDER\tPAYMENT\tKIND\tRESOLUTION\tDETAIL")
+	for _, discrepancy := range report.Discrepancies {
+		resolution := "review"
+		if discrepancy.Fixed {
+			resolution = "fixed"
+		} else if discrepancy.Fixable && report.DryRun {
+			resolution = "fixable"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			discrepancy.OrderNumber,
+			discrepancy.OrderStatus,
+			discrepancy.Provider,
+			discrepancy.PaymentID,
+			discrepancy.Kind,
+			resolution,
+			discrepancy.Detail,
+		)
+	}
+	writer.Flush()
+}
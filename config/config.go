@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config holds all configuration for the application
@@ -17,14 +18,19 @@ type Config struct {
 	PayPal          PayPalConfig
 	MobilePay       MobilePayConfig
 	CORS            CORSConfig
+	ExchangeRate    ExchangeRateConfig
+	Tax             TaxConfig
+	Invoice         InvoiceConfig
+	Shipping        ShippingConfig
 	DefaultCurrency string // Default currency for the store
 }
 
 // ServerConfig holds server-specific configuration
 type ServerConfig struct {
-	Port         string
-	ReadTimeout  int
-	WriteTimeout int
+	Port              string
+	ReadTimeout       int
+	WriteTimeout      int
+	IdempotencyKeyTTL int // Hours responses are replayed to requests repeated with the same Idempotency-Key
 }
 
 // DatabaseConfig holds database-specific configuration
@@ -45,7 +51,11 @@ type AuthConfig struct {
 
 // PaymentConfig holds payment-specific configuration
 type PaymentConfig struct {
-	EnabledProviders []string // List of enabled payment providers
+	EnabledProviders          []string // List of enabled payment providers
+	ReconcileInterval         int      // Minutes between reconciling orders with the payment providers, 0 disables it
+	ReconcileStaleAfter       int      // Minutes an order awaits payment before it is reconciled
+	ReconcileLookback         int      // Days back orders are reconciled
+	AuthorizationWarningHours int      // Hours before an uncaptured authorization expires that it is warned about, 0 disables it
 }
 
 // EmailConfig holds email-specific configuration
@@ -58,6 +68,7 @@ type EmailConfig struct {
 	FromName     string
 	AdminEmail   string
 	Enabled      bool
+	Locale       string // Locale used to format amounts, e.g. da-DK
 }
 
 // StripeConfig holds Stripe-specific configuration
@@ -67,16 +78,22 @@ type StripeConfig struct {
 	WebhookSecret      string
 	PaymentDescription string
 	ReturnURL          string
+	CheckoutEnabled    bool // Card payments without card details go through a Stripe Checkout page
+	CardDetailsEnabled bool // Accept raw card numbers through the API
+	AuthorizationDays  int  // Days an uncaptured card payment stays authorized
 	Enabled            bool
 }
 
 // PayPalConfig holds PayPal-specific configuration
 type PayPalConfig struct {
-	ClientID     string
-	ClientSecret string
-	ReturnURL    string
-	Sandbox      bool
-	Enabled      bool
+	ClientID          string
+	ClientSecret      string
+	ReturnURL         string
+	WebhookID         string // Webhook ID used to verify PayPal webhook signatures
+	APIURL            string // PayPal API, empty for the sandbox or live default
+	AuthorizationDays int    // Days an uncaptured authorization can be captured
+	Sandbox           bool
+	Enabled           bool
 }
 
 // MobilePayConfig holds MobilePay-specific configuration
@@ -89,10 +106,56 @@ type MobilePayConfig struct {
 	WebhookURL           string
 	PaymentDescription   string
 	Market               string // NOK, DKK, EUR
+	AuthorizationDays    int    // Days an uncaptured payment stays reserved
 	Enabled              bool
 	IsTestMode           bool
 }
 
+// ExchangeRateConfig holds exchange rate synchronisation configuration
+type ExchangeRateConfig struct {
+	FeedURL          string  // ECB reference rates feed
+	SyncInterval     int     // Hours between synchronisations
+	MaxChangePercent float64 // Larger changes are held for admin confirmation
+	SyncEnabled      bool
+}
+
+// TaxConfig holds tax configuration
+type TaxConfig struct {
+	PricesIncludeTax bool   // Whether product prices and shipping costs include tax
+	StoreCountry     string // ISO code of the country the store ships from, enables the EU reverse charge
+	VATValidator     string // "format", "vies" or "fake"
+	VIESURL          string // VIES REST API
+}
+
+// InvoiceConfig holds invoice configuration
+type InvoiceConfig struct {
+	NumberPrefix     string // Prefix of invoice numbers, e.g. INV-
+	CreditNotePrefix string // Prefix of credit note numbers, e.g. CN-
+	SellerName       string // Legal name of the store printed on invoices
+	SellerAddress    string // Address printed on invoices, lines separated by semicolons
+	SellerVATID      string // VAT number printed on invoices
+}
+
+// ShippingConfig holds shipping configuration
+type ShippingConfig struct {
+	DimensionalWeightDivisor float64 // cm³ per kg couriers divide package volumes by, e.g. 5000
+	Carrier                  string  // Carrier for live rates and labels: "shippo", "fake" or empty for none
+	ShippoAPIKey             string
+	ShippoURL                string // Shippo API, empty for the default
+	SenderName               string // Sender printed on labels
+	SenderEmail              string
+	SenderPhone              string
+	SenderStreet             string
+	SenderCity               string
+	SenderPostalCode         string
+	SenderCountry            string
+	PickupPointProvider      string // Provider of pickup points: "fake" or empty for none
+	TrackingProvider         string // Provider of shipment tracking: "shippo", "fake" or empty for none
+	TrackingPollInterval     int    // Minutes between polls of shipped orders' tracking, 0 to rely on webhooks only
+	TrackingWebhookSecret    string // Token carriers send with tracking webhooks, webhooks are refused if empty
+	Timezone                 string // IANA time zone of order cut-off times, e.g. "Europe/Copenhagen"
+}
+
 // CORSConfig holds CORS-specific configuration
 type CORSConfig struct {
 	AllowedOrigins  []string
@@ -111,6 +174,11 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid SERVER_WRITE_TIMEOUT: %w", err)
 	}
 
+	idempotencyKeyTTL, err := strconv.Atoi(getEnv("SERVER_IDEMPOTENCY_KEY_TTL", "24"))
+	if err != nil || idempotencyKeyTTL <= 0 {
+		return nil, fmt.Errorf("invalid SERVER_IDEMPOTENCY_KEY_TTL: must be a positive number of hours")
+	}
+
 	tokenDuration, err := strconv.Atoi(getEnv("AUTH_TOKEN_DURATION", "24"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid AUTH_TOKEN_DURATION: %w", err)
@@ -131,6 +199,16 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid STRIPE_ENABLED: %w", err)
 	}
 
+	stripeCheckoutEnabled, err := strconv.ParseBool(getEnv("STRIPE_CHECKOUT_ENABLED", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STRIPE_CHECKOUT_ENABLED: %w", err)
+	}
+
+	stripeCardDetailsEnabled, err := strconv.ParseBool(getEnv("STRIPE_CARD_DETAILS_ENABLED", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STRIPE_CARD_DETAILS_ENABLED: %w", err)
+	}
+
 	paypalEnabled, err := strconv.ParseBool(getEnv("PAYPAL_ENABLED", "false"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid PAYPAL_ENABLED: %w", err)
@@ -151,6 +229,82 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid MOBILEPAY_TEST_MODE: %w", err)
 	}
 
+	exchangeRateSyncEnabled, err := strconv.ParseBool(getEnv("EXCHANGE_RATE_SYNC_ENABLED", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXCHANGE_RATE_SYNC_ENABLED: %w", err)
+	}
+
+	exchangeRateSyncInterval, err := strconv.Atoi(getEnv("EXCHANGE_RATE_SYNC_INTERVAL", "24"))
+	if err != nil || exchangeRateSyncInterval <= 0 {
+		return nil, fmt.Errorf("invalid EXCHANGE_RATE_SYNC_INTERVAL: must be a positive number of hours")
+	}
+
+	exchangeRateMaxChange, err := strconv.ParseFloat(getEnv("EXCHANGE_RATE_MAX_CHANGE_PERCENT", "20"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXCHANGE_RATE_MAX_CHANGE_PERCENT: %w", err)
+	}
+
+	taxPricesIncludeTax, err := strconv.ParseBool(getEnv("TAX_PRICES_INCLUDE_TAX", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TAX_PRICES_INCLUDE_TAX: %w", err)
+	}
+
+	dimensionalWeightDivisor, err := strconv.ParseFloat(getEnv("SHIPPING_DIMENSIONAL_WEIGHT_DIVISOR", "5000"), 64)
+	if err != nil || dimensionalWeightDivisor < 0 {
+		return nil, fmt.Errorf("invalid SHIPPING_DIMENSIONAL_WEIGHT_DIVISOR: must be a non-negative number")
+	}
+
+	trackingPollInterval, err := strconv.Atoi(getEnv("SHIPPING_TRACKING_POLL_INTERVAL", "60"))
+	if err != nil || trackingPollInterval < 0 {
+		return nil, fmt.Errorf("invalid SHIPPING_TRACKING_POLL_INTERVAL: must be a non-negative number of minutes")
+	}
+
+	shippingTimezone := getEnv("SHIPPING_TIMEZONE", "UTC")
+	if _, err := time.LoadLocation(shippingTimezone); err != nil {
+		return nil, fmt.Errorf("invalid SHIPPING_TIMEZONE: %w", err)
+	}
+
+	reconcileInterval, err := strconv.Atoi(getEnv("PAYMENT_RECONCILE_INTERVAL", "60"))
+	if err != nil || reconcileInterval < 0 {
+		return nil, fmt.Errorf("invalid PAYMENT_RECONCILE_INTERVAL: must be a non-negative number of minutes")
+	}
+
+	reconcileStaleAfter, err := strconv.Atoi(getEnv("PAYMENT_RECONCILE_STALE_AFTER", "30"))
+	if err != nil || reconcileStaleAfter < 0 {
+		return nil, fmt.Errorf("invalid PAYMENT_RECONCILE_STALE_AFTER: must be a non-negative number of minutes")
+	}
+
+	reconcileLookback, err := strconv.Atoi(getEnv("PAYMENT_RECONCILE_LOOKBACK", "30"))
+	if err != nil || reconcileLookback <= 0 {
+		return nil, fmt.Errorf("invalid PAYMENT_RECONCILE_LOOKBACK: must be a positive number of days")
+	}
+
+	authorizationWarningHours, err := strconv.Atoi(getEnv("PAYMENT_AUTHORIZATION_WARNING_HOURS", "24"))
+	if err != nil || authorizationWarningHours < 0 {
+		return nil, fmt.Errorf("invalid PAYMENT_AUTHORIZATION_WARNING_HOURS: must be a non-negative number of hours")
+	}
+
+	stripeAuthorizationDays, err := strconv.Atoi(getEnv("STRIPE_AUTHORIZATION_DAYS", "7"))
+	if err != nil || stripeAuthorizationDays <= 0 {
+		return nil, fmt.Errorf("invalid STRIPE_AUTHORIZATION_DAYS: must be a positive number of days")
+	}
+
+	paypalAuthorizationDays, err := strconv.Atoi(getEnv("PAYPAL_AUTHORIZATION_DAYS", "29"))
+	if err != nil || paypalAuthorizationDays <= 0 {
+		return nil, fmt.Errorf("invalid PAYPAL_AUTHORIZATION_DAYS: must be a positive number of days")
+	}
+
+	// Vipps reservations in Norway last 180 days, MobilePay card reservations 7
+	mobilePayMarket := getEnv("MOBILEPAY_MARKET", "NOK")
+	mobilePayDefaultAuthorizationDays := "7"
+	if mobilePayMarket == "NOK" {
+		mobilePayDefaultAuthorizationDays = "180"
+	}
+	mobilePayAuthorizationDays, err := strconv.Atoi(getEnv("MOBILEPAY_AUTHORIZATION_DAYS", mobilePayDefaultAuthorizationDays))
+	if err != nil || mobilePayAuthorizationDays <= 0 {
+		return nil, fmt.Errorf("invalid MOBILEPAY_AUTHORIZATION_DAYS: must be a positive number of days")
+	}
+
 	// Parse enabled payment providers
 	enabledProviders := []string{"mock"} // Always enable mock provider for testing
 	if stripeEnabled {
@@ -165,9 +319,10 @@ func LoadConfig() (*Config, error) {
 
 	return &Config{
 		Server: ServerConfig{
-			Port:         getEnv("SERVER_PORT", "6091"),
-			ReadTimeout:  readTimeout,
-			WriteTimeout: writeTimeout,
+			Port:              getEnv("SERVER_PORT", "6091"),
+			ReadTimeout:       readTimeout,
+			WriteTimeout:      writeTimeout,
+			IdempotencyKeyTTL: idempotencyKeyTTL,
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -182,7 +337,11 @@ func LoadConfig() (*Config, error) {
 			TokenDuration: tokenDuration,
 		},
 		Payment: PaymentConfig{
-			EnabledProviders: enabledProviders,
+			EnabledProviders:          enabledProviders,
+			ReconcileInterval:         reconcileInterval,
+			ReconcileStaleAfter:       reconcileStaleAfter,
+			ReconcileLookback:         reconcileLookback,
+			AuthorizationWarningHours: authorizationWarningHours,
 		},
 		Email: EmailConfig{
 			SMTPHost:     getEnv("EMAIL_SMTP_HOST", "smtp.example.com"),
@@ -193,6 +352,7 @@ func LoadConfig() (*Config, error) {
 			FromName:     getEnv("EMAIL_FROM_NAME", "Commercify Store"),
 			AdminEmail:   getEnv("EMAIL_ADMIN_ADDRESS", "admin@example.com"),
 			Enabled:      emailEnabled,
+			Locale:       getEnv("EMAIL_LOCALE", "en-US"),
 		},
 		Stripe: StripeConfig{
 			SecretKey:          getEnv("STRIPE_SECRET_KEY", ""),
@@ -200,14 +360,20 @@ func LoadConfig() (*Config, error) {
 			WebhookSecret:      getEnv("STRIPE_WEBHOOK_SECRET", ""),
 			PaymentDescription: getEnv("STRIPE_PAYMENT_DESCRIPTION", "Commercify Store Purchase"),
 			ReturnURL:          getEnv("RETURN_URL", ""),
+			CheckoutEnabled:    stripeCheckoutEnabled,
+			CardDetailsEnabled: stripeCardDetailsEnabled,
+			AuthorizationDays:  stripeAuthorizationDays,
 			Enabled:            stripeEnabled,
 		},
 		PayPal: PayPalConfig{
-			ClientID:     getEnv("PAYPAL_CLIENT_ID", ""),
-			ClientSecret: getEnv("PAYPAL_CLIENT_SECRET", ""),
-			ReturnURL:    getEnv("RETURN_URL", ""),
-			Sandbox:      paypalSandbox,
-			Enabled:      paypalEnabled,
+			ClientID:          getEnv("PAYPAL_CLIENT_ID", ""),
+			ClientSecret:      getEnv("PAYPAL_CLIENT_SECRET", ""),
+			ReturnURL:         getEnv("RETURN_URL", ""),
+			WebhookID:         getEnv("PAYPAL_WEBHOOK_ID", ""),
+			APIURL:            getEnv("PAYPAL_API_URL", ""),
+			AuthorizationDays: paypalAuthorizationDays,
+			Sandbox:           paypalSandbox,
+			Enabled:           paypalEnabled,
 		},
 		MobilePay: MobilePayConfig{
 			MerchantSerialNumber: getEnv("MOBILEPAY_MERCHANT_SERIAL_NUMBER", ""),
@@ -217,7 +383,8 @@ func LoadConfig() (*Config, error) {
 			ReturnURL:            getEnv("RETURN_URL", ""),
 			WebhookURL:           getEnv("MOBILEPAY_WEBHOOK_URL", ""),
 			PaymentDescription:   getEnv("MOBILEPAY_PAYMENT_DESCRIPTION", "Commercify Store Purchase"),
-			Market:               getEnv("MOBILEPAY_MARKET", "NOK"),
+			Market:               mobilePayMarket,
+			AuthorizationDays:    mobilePayAuthorizationDays,
 			Enabled:              mobilePayEnabled,
 			IsTestMode:           mobilePayTestMode,
 		},
@@ -225,6 +392,43 @@ func LoadConfig() (*Config, error) {
 			AllowedOrigins:  []string{"*"},
 			AllowAllOrigins: true,
 		},
+		ExchangeRate: ExchangeRateConfig{
+			FeedURL:          getEnv("EXCHANGE_RATE_FEED_URL", ""),
+			SyncInterval:     exchangeRateSyncInterval,
+			MaxChangePercent: exchangeRateMaxChange,
+			SyncEnabled:      exchangeRateSyncEnabled,
+		},
+		Tax: TaxConfig{
+			PricesIncludeTax: taxPricesIncludeTax,
+			StoreCountry:     getEnv("TAX_STORE_COUNTRY", ""),
+			VATValidator:     getEnv("TAX_VAT_VALIDATOR", "format"),
+			VIESURL:          getEnv("TAX_VIES_URL", ""),
+		},
+		Invoice: InvoiceConfig{
+			NumberPrefix:     getEnv("INVOICE_NUMBER_PREFIX", "INV-"),
+			CreditNotePrefix: getEnv("INVOICE_CREDIT_NOTE_PREFIX", "CN-"),
+			SellerName:       getEnv("INVOICE_SELLER_NAME", ""),
+			SellerAddress:    getEnv("INVOICE_SELLER_ADDRESS", ""),
+			SellerVATID:      getEnv("INVOICE_SELLER_VAT_ID", ""),
+		},
+		Shipping: ShippingConfig{
+			DimensionalWeightDivisor: dimensionalWeightDivisor,
+			Carrier:                  getEnv("SHIPPING_CARRIER", ""),
+			ShippoAPIKey:             getEnv("SHIPPING_SHIPPO_API_KEY", ""),
+			ShippoURL:                getEnv("SHIPPING_SHIPPO_URL", ""),
+			SenderName:               getEnv("SHIPPING_SENDER_NAME", ""),
+			SenderEmail:              getEnv("SHIPPING_SENDER_EMAIL", ""),
+			SenderPhone:              getEnv("SHIPPING_SENDER_PHONE", ""),
+			SenderStreet:             getEnv("SHIPPING_SENDER_STREET", ""),
+			SenderCity:               getEnv("SHIPPING_SENDER_CITY", ""),
+			SenderPostalCode:         getEnv("SHIPPING_SENDER_POSTAL_CODE", ""),
+			SenderCountry:            getEnv("SHIPPING_SENDER_COUNTRY", ""),
+			PickupPointProvider:      getEnv("SHIPPING_PICKUP_POINT_PROVIDER", ""),
+			TrackingProvider:         getEnv("SHIPPING_TRACKING_PROVIDER", ""),
+			TrackingPollInterval:     trackingPollInterval,
+			TrackingWebhookSecret:    getEnv("SHIPPING_TRACKING_WEBHOOK_SECRET", ""),
+			Timezone:                 shippingTimezone,
+		},
 		DefaultCurrency: getEnv("DEFAULT_CURRENCY", "USD"),
 	}, nil
 }
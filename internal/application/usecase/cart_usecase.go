@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"errors"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/repository"
@@ -9,15 +10,19 @@ import (
 
 // CartUseCase implements cart-related use cases
 type CartUseCase struct {
-	cartRepo    repository.CartRepository
-	productRepo repository.ProductRepository
+	cartRepo         repository.CartRepository
+	productRepo      repository.ProductRepository
+	discountUseCase  *DiscountUseCase
+	priceListUseCase *PriceListUseCase
 }
 
 // NewCartUseCase creates a new CartUseCase
-func NewCartUseCase(cartRepo repository.CartRepository, productRepo repository.ProductRepository) *CartUseCase {
+func NewCartUseCase(cartRepo repository.CartRepository, productRepo repository.ProductRepository, discountUseCase *DiscountUseCase, priceListUseCase *PriceListUseCase) *CartUseCase {
 	return &CartUseCase{
-		cartRepo:    cartRepo,
-		productRepo: productRepo,
+		cartRepo:         cartRepo,
+		productRepo:      productRepo,
+		discountUseCase:  discountUseCase,
+		priceListUseCase: priceListUseCase,
 	}
 }
 
@@ -344,6 +349,169 @@ func (uc *CartUseCase) ClearGuestCart(sessionID string) error {
 	return uc.cartRepo.Update(cart)
 }
 
+// ApplyDiscountToCart attaches a discount code to a user's cart
+func (uc *CartUseCase) ApplyDiscountToCart(userID uint, discountCode string) (*entity.Cart, error) {
+	// Get cart
+	cart, err := uc.GetOrCreateCart(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.applyDiscountCode(cart, discountCode)
+}
+
+// ApplyDiscountToGuestCart attaches a discount code to a guest's cart
+func (uc *CartUseCase) ApplyDiscountToGuestCart(sessionID string, discountCode string) (*entity.Cart, error) {
+	// Get cart
+	cart, err := uc.GetOrCreateGuestCart(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.applyDiscountCode(cart, discountCode)
+}
+
+// applyDiscountCode validates a discount code and stores it on the cart
+func (uc *CartUseCase) applyDiscountCode(cart *entity.Cart, discountCode string) (*entity.Cart, error) {
+	if uc.discountUseCase == nil {
+		return nil, errors.New("discounts are not available")
+	}
+
+	discount, err := uc.discountUseCase.GetDiscountByCode(discountCode)
+	if err != nil {
+		return nil, errors.New("invalid discount code")
+	}
+
+	if !discount.IsValid() {
+		return nil, errors.New("discount is not valid (expired, inactive, or usage limit reached)")
+	}
+
+	if err := cart.SetDiscountCode(discount.Code); err != nil {
+		return nil, err
+	}
+
+	// Update cart in repository
+	if err := uc.cartRepo.Update(cart); err != nil {
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// RemoveDiscountFromCart removes the discount code from a user's cart
+func (uc *CartUseCase) RemoveDiscountFromCart(userID uint) (*entity.Cart, error) {
+	// Get cart
+	cart, err := uc.cartRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.New("cart not found")
+	}
+
+	cart.RemoveDiscountCode()
+
+	// Update cart in repository
+	if err := uc.cartRepo.Update(cart); err != nil {
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// RemoveDiscountFromGuestCart removes the discount code from a guest's cart
+func (uc *CartUseCase) RemoveDiscountFromGuestCart(sessionID string) (*entity.Cart, error) {
+	// Get cart
+	cart, err := uc.cartRepo.GetBySessionID(sessionID)
+	if err != nil {
+		return nil, errors.New("cart not found")
+	}
+
+	cart.RemoveDiscountCode()
+
+	// Update cart in repository
+	if err := uc.cartRepo.Update(cart); err != nil {
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// CartLineSummary contains the pricing of a single cart item
+type CartLineSummary struct {
+	Price          int64 // stored in cents
+	Subtotal       int64 // stored in cents
+	DiscountAmount int64 // stored in cents
+}
+
+// CartSummary contains the pricing of a cart including its discount
+type CartSummary struct {
+	Lines          []CartLineSummary // in the order of the cart items
+	Currency       string            // Currency of the product prices
+	Subtotal       int64             // stored in cents
+	DiscountCode   string
+	DiscountAmount int64 // stored in cents
+	Total          int64 // stored in cents
+}
+
+// GetCartSummary prices the cart with current product prices, including the
+// customer group prices of the cart's user, and calculates its discount
+func (uc *CartUseCase) GetCartSummary(cart *entity.Cart) (*CartSummary, error) {
+	summary := &CartSummary{
+		Lines: make([]CartLineSummary, len(cart.Items)),
+	}
+
+	var pricing *CustomerPricing
+	if uc.priceListUseCase != nil {
+		var err error
+		pricing, err = uc.priceListUseCase.PricingForUser(cart.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	items := make([]entity.OrderItem, len(cart.Items))
+	for i, item := range cart.Items {
+		product, err := uc.productRepo.GetByIDWithVariants(item.ProductID)
+		if err != nil {
+			return nil, errors.New("product not found")
+		}
+
+		price := product.EffectivePrice(now)
+		if variant := product.GetVariantByID(item.ProductVariantID); variant != nil {
+			price = variant.EffectivePrice(now)
+		}
+		price = pricing.Price(product.CurrencyCode, item.ProductID, item.ProductVariantID, item.Quantity, price)
+
+		items[i] = entity.OrderItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     price,
+			Subtotal:  int64(item.Quantity) * price,
+		}
+
+		summary.Currency = product.CurrencyCode
+		summary.Lines[i].Price = price
+		summary.Lines[i].Subtotal = items[i].Subtotal
+		summary.Subtotal += items[i].Subtotal
+	}
+
+	// A code that no longer resolves simply gives no discount
+	if cart.DiscountCode != "" && uc.discountUseCase != nil {
+		summary.DiscountCode = cart.DiscountCode
+
+		lineDiscounts, err := uc.discountUseCase.CalculateLineDiscounts(cart.DiscountCode, items)
+		if err == nil {
+			summary.DiscountAmount = lineDiscounts.Amount
+			for i, amount := range lineDiscounts.Lines {
+				summary.Lines[i].DiscountAmount = amount
+			}
+		}
+	}
+
+	summary.Total = summary.Subtotal - summary.DiscountAmount
+
+	return summary, nil
+}
+
 // ConvertGuestCartToUserCart converts a guest cart to a user cart
 func (uc *CartUseCase) ConvertGuestCartToUserCart(sessionID string, userID uint) (*entity.Cart, error) {
 	return uc.cartRepo.ConvertGuestCartToUserCart(sessionID, userID)
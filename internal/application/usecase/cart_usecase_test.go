@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
@@ -24,7 +25,7 @@ func TestCartUseCase_GetOrCreateCart(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		result, err := cartUseCase.GetOrCreateCart(userID)
@@ -41,7 +42,7 @@ func TestCartUseCase_GetOrCreateCart(t *testing.T) {
 		productRepo := mock.NewMockProductRepository()
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		userID := uint(2)
@@ -71,7 +72,7 @@ func TestCartUseCase_GetOrCreateGuestCart(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		result, err := cartUseCase.GetOrCreateGuestCart(sessionID)
@@ -88,7 +89,7 @@ func TestCartUseCase_GetOrCreateGuestCart(t *testing.T) {
 		productRepo := mock.NewMockProductRepository()
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		sessionID := "new-session-456"
@@ -124,7 +125,7 @@ func TestCartUseCase_AddToCart(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		input := usecase.AddToCartInput{
@@ -146,7 +147,7 @@ func TestCartUseCase_AddToCart(t *testing.T) {
 		productRepo := mock.NewMockProductRepository()
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		userID := uint(1)
@@ -183,7 +184,7 @@ func TestCartUseCase_AddToCart(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		input := usecase.AddToCartInput{
@@ -231,7 +232,7 @@ func TestCartUseCase_AddToCartWithVariant(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		input := usecase.AddToCartInput{
@@ -270,7 +271,7 @@ func TestCartUseCase_AddToCartWithVariant(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		input := usecase.AddToCartInput{
@@ -317,7 +318,7 @@ func TestCartUseCase_AddToCartWithVariant(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		input := usecase.AddToCartInput{
@@ -380,7 +381,7 @@ func TestCartUseCase_AddMultipleVariantsToCart(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Add first variant
 		input1 := usecase.AddToCartInput{
@@ -465,7 +466,7 @@ func TestCartUseCase_MixedCartWithVariantsAndRegularProducts(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Add regular product
 		input1 := usecase.AddToCartInput{
@@ -524,7 +525,7 @@ func TestCartUseCase_RemoveFromCartWithVariant(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute - remove specific variant
 		productID := uint(1)
@@ -572,7 +573,7 @@ func TestCartUseCase_RemoveFromCartWithVariant(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute - try to remove non-existent variant
 		productID := uint(1)
@@ -620,7 +621,7 @@ func TestCartUseCase_AddToGuestCartWithVariant(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		input := usecase.AddToCartInput{
@@ -671,7 +672,7 @@ func TestCartUseCase_AddToGuestCartWithVariant(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		input := usecase.AddToCartInput{
@@ -730,7 +731,7 @@ func TestCartUseCase_UpdateGuestCartItemWithVariant(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		input := usecase.UpdateCartItemInput{
@@ -786,7 +787,7 @@ func TestCartUseCase_RemoveFromGuestCartWithVariant(t *testing.T) {
 		cartRepo.Create(cart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute - remove specific variant
 		productID := uint(1)
@@ -852,7 +853,7 @@ func TestCartUseCase_ConvertGuestCartToUserCartWithVariants(t *testing.T) {
 		cartRepo.Create(guestCart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		userID := uint(1)
@@ -929,7 +930,7 @@ func TestCartUseCase_MergingCartWithVariants(t *testing.T) {
 		cartRepo.Create(guestCart)
 
 		// Create use case with mocks
-		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo)
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, nil, nil)
 
 		// Execute
 		result, err := cartUseCase.ConvertGuestCartToUserCart(sessionID, userID)
@@ -965,3 +966,185 @@ func TestCartUseCase_MergingCartWithVariants(t *testing.T) {
 		assert.Equal(t, 1, itemQuantities["2"], "New product from guest cart should be added")
 	})
 }
+
+func TestCartUseCase_ApplyDiscountToCart(t *testing.T) {
+	t.Run("Apply valid discount code", func(t *testing.T) {
+		// Setup mocks
+		cartRepo := mock.NewMockCartRepository()
+		productRepo := mock.NewMockProductRepository()
+		discountRepo := mock.NewMockDiscountRepository()
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			productRepo,
+			mock.NewMockCategoryRepository(),
+			mock.NewMockOrderRepository(false),
+			nil,
+		)
+
+		// Create a test discount
+		discount, _ := entity.NewDiscount(
+			"BASKET10",
+			entity.DiscountTypeBasket,
+			entity.DiscountMethodPercentage,
+			10.0,
+			0,
+			0,
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discountRepo.Create(discount)
+
+		// Create use case with mocks
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, discountUseCase, nil)
+
+		// Execute
+		sessionID := "discount-session"
+		result, err := cartUseCase.ApplyDiscountToGuestCart(sessionID, "BASKET10")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "BASKET10", result.DiscountCode)
+
+		savedCart, _ := cartRepo.GetBySessionID(sessionID)
+		assert.Equal(t, "BASKET10", savedCart.DiscountCode)
+	})
+
+	t.Run("Apply invalid discount code", func(t *testing.T) {
+		// Setup mocks
+		cartRepo := mock.NewMockCartRepository()
+		productRepo := mock.NewMockProductRepository()
+		discountUseCase := usecase.NewDiscountUseCase(
+			mock.NewMockDiscountRepository(),
+			productRepo,
+			mock.NewMockCategoryRepository(),
+			mock.NewMockOrderRepository(false),
+			nil,
+		)
+
+		// Create use case with mocks
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, discountUseCase, nil)
+
+		// Execute
+		result, err := cartUseCase.ApplyDiscountToCart(1, "INVALID")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid discount code")
+	})
+}
+
+func TestCartUseCase_GetCartSummary(t *testing.T) {
+	t.Run("Summary with product discount", func(t *testing.T) {
+		// Setup mocks
+		cartRepo := mock.NewMockCartRepository()
+		productRepo := mock.NewMockProductRepository()
+		discountRepo := mock.NewMockDiscountRepository()
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			productRepo,
+			mock.NewMockCategoryRepository(),
+			mock.NewMockOrderRepository(false),
+			nil,
+		)
+
+		// Create test products
+		productRepo.Create(&entity.Product{ID: 1, Name: "Shirt", Price: 2000, Stock: 10})
+		productRepo.Create(&entity.Product{ID: 2, Name: "Hat", Price: 1000, Stock: 10})
+
+		// Create a 25% discount on the shirt only
+		discount, _ := entity.NewDiscount(
+			"SHIRT25",
+			entity.DiscountTypeProduct,
+			entity.DiscountMethodPercentage,
+			25.0,
+			0,
+			0,
+			[]uint{1},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discountRepo.Create(discount)
+
+		// Create a test cart
+		cart, _ := entity.NewCart(1)
+		cart.AddItem(1, 0, 2)
+		cart.AddItem(2, 0, 1)
+		cart.SetDiscountCode("SHIRT25")
+		cartRepo.Create(cart)
+
+		// Create use case with mocks
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, discountUseCase, nil)
+
+		// Execute
+		summary, err := cartUseCase.GetCartSummary(cart)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5000), summary.Subtotal)
+		assert.Equal(t, int64(1000), summary.DiscountAmount)
+		assert.Equal(t, int64(4000), summary.Total)
+		assert.Equal(t, int64(1000), summary.Lines[0].DiscountAmount)
+		assert.Equal(t, int64(0), summary.Lines[1].DiscountAmount)
+	})
+
+	t.Run("Summary with capped basket discount", func(t *testing.T) {
+		// Setup mocks
+		cartRepo := mock.NewMockCartRepository()
+		productRepo := mock.NewMockProductRepository()
+		discountRepo := mock.NewMockDiscountRepository()
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			productRepo,
+			mock.NewMockCategoryRepository(),
+			mock.NewMockOrderRepository(false),
+			nil,
+		)
+
+		// Create test products
+		productRepo.Create(&entity.Product{ID: 1, Name: "Shirt", Price: 3000, Stock: 10})
+		productRepo.Create(&entity.Product{ID: 2, Name: "Hat", Price: 1000, Stock: 10})
+
+		// Create a 50% basket discount capped at 10.00
+		discount, _ := entity.NewDiscount(
+			"HALF",
+			entity.DiscountTypeBasket,
+			entity.DiscountMethodPercentage,
+			50.0,
+			0,
+			1000,
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discountRepo.Create(discount)
+
+		// Create a test guest cart
+		cart, _ := entity.NewGuestCart("summary-session")
+		cart.AddItem(1, 0, 1)
+		cart.AddItem(2, 0, 1)
+		cart.SetDiscountCode("HALF")
+		cartRepo.Create(cart)
+
+		// Create use case with mocks
+		cartUseCase := usecase.NewCartUseCase(cartRepo, productRepo, discountUseCase, nil)
+
+		// Execute
+		summary, err := cartUseCase.GetCartSummary(cart)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4000), summary.Subtotal)
+		assert.Equal(t, int64(1000), summary.DiscountAmount)
+		assert.Equal(t, int64(3000), summary.Total)
+		assert.Equal(t, int64(750), summary.Lines[0].DiscountAmount)
+		assert.Equal(t, int64(250), summary.Lines[1].DiscountAmount)
+	})
+}
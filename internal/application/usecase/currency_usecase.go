@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"errors"
+	"log"
 	"strings"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
@@ -10,13 +11,15 @@ import (
 
 // CurrencyUseCase implements currency-related use cases
 type CurrencyUseCase struct {
-	currencyRepo repository.CurrencyRepository
+	currencyRepo    repository.CurrencyRepository
+	rateHistoryRepo repository.ExchangeRateHistoryRepository
 }
 
 // NewCurrencyUseCase creates a new CurrencyUseCase
-func NewCurrencyUseCase(currencyRepo repository.CurrencyRepository) *CurrencyUseCase {
+func NewCurrencyUseCase(currencyRepo repository.CurrencyRepository, rateHistoryRepo repository.ExchangeRateHistoryRepository) *CurrencyUseCase {
 	return &CurrencyUseCase{
-		currencyRepo: currencyRepo,
+		currencyRepo:    currencyRepo,
+		rateHistoryRepo: rateHistoryRepo,
 	}
 }
 
@@ -80,6 +83,7 @@ func (uc *CurrencyUseCase) UpdateCurrency(code string, input CurrencyInput) (*en
 		currency.Symbol = input.Symbol
 	}
 
+	previousRate := currency.ExchangeRate
 	if input.ExchangeRate > 0 {
 		if err := currency.SetExchangeRate(input.ExchangeRate); err != nil {
 			return nil, err
@@ -112,9 +116,35 @@ func (uc *CurrencyUseCase) UpdateCurrency(code string, input CurrencyInput) (*en
 		return nil, err
 	}
 
+	// Record manual rate changes in the rate history
+	if currency.ExchangeRate != previousRate {
+		uc.recordManualRate(currency, previousRate)
+	}
+
 	return currency, nil
 }
 
+// recordManualRate adds a rate set by an admin to the rate history
+func (uc *CurrencyUseCase) recordManualRate(currency *entity.Currency, previousRate float64) {
+	if uc.rateHistoryRepo == nil {
+		return
+	}
+
+	history, err := entity.NewExchangeRateHistory(
+		currency.Code,
+		currency.ExchangeRate,
+		previousRate,
+		entity.ExchangeRateSourceManual,
+		entity.ExchangeRateStatusApplied,
+	)
+	if err == nil {
+		err = uc.rateHistoryRepo.Create(history)
+	}
+	if err != nil {
+		log.Printf("Failed to record exchange rate history for %s: %v", currency.Code, err)
+	}
+}
+
 // DeleteCurrency deletes a currency
 func (uc *CurrencyUseCase) DeleteCurrency(code string) error {
 	return uc.currencyRepo.Delete(code)
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// DiscountReportUseCase implements discount performance reporting
+type DiscountReportUseCase struct {
+	discountRepo repository.DiscountRepository
+	reportRepo   repository.DiscountReportRepository
+}
+
+// NewDiscountReportUseCase creates a new DiscountReportUseCase
+func NewDiscountReportUseCase(
+	discountRepo repository.DiscountRepository,
+	reportRepo repository.DiscountReportRepository,
+) *DiscountReportUseCase {
+	return &DiscountReportUseCase{
+		discountRepo: discountRepo,
+		reportRepo:   reportRepo,
+	}
+}
+
+// DiscountReportInput contains the filters of a discount report
+type DiscountReportInput struct {
+	StartDate time.Time // inclusive
+	EndDate   time.Time // exclusive
+	Interval  entity.DiscountReportInterval
+}
+
+// DiscountReport contains the performance of one or more discount codes
+type DiscountReport struct {
+	Discounts                []*entity.Discount
+	Campaign                 string
+	StartDate                time.Time
+	EndDate                  time.Time
+	Interval                 entity.DiscountReportInterval
+	Redemptions              int
+	GrossRevenue             int64 // stored in cents
+	TotalDiscount            int64 // stored in cents
+	AverageOrderValueWith    int64 // stored in cents
+	AverageOrderValueWithout int64 // stored in cents
+	CancellationRate         float64
+	RefundRate               float64
+	RefundedAmount           int64 // stored in cents
+	RedemptionsOverTime      []entity.DiscountRedemptionPeriod
+}
+
+// GetDiscountReport reports the performance of a single discount
+func (uc *DiscountReportUseCase) GetDiscountReport(discountID uint, input DiscountReportInput) (*DiscountReport, error) {
+	discount, err := uc.discountRepo.GetByID(discountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildReport([]*entity.Discount{discount}, "", input)
+}
+
+// GetCampaignReport reports the combined performance of all discounts in a campaign
+func (uc *DiscountReportUseCase) GetCampaignReport(campaign string, input DiscountReportInput) (*DiscountReport, error) {
+	if campaign == "" {
+		return nil, errors.New("campaign is required")
+	}
+
+	discounts, err := uc.discountRepo.ListByCampaign(campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(discounts) == 0 {
+		return nil, errors.New("campaign not found")
+	}
+
+	return uc.buildReport(discounts, campaign, input)
+}
+
+// buildReport aggregates the orders of the given discounts
+func (uc *DiscountReportUseCase) buildReport(discounts []*entity.Discount, campaign string, input DiscountReportInput) (*DiscountReport, error) {
+	if input.Interval == "" {
+		input.Interval = entity.DiscountReportIntervalDay
+	}
+
+	if !input.Interval.IsValid() {
+		return nil, errors.New("invalid interval: must be day, week or month")
+	}
+
+	if !input.EndDate.After(input.StartDate) {
+		return nil, errors.New("end date must be after start date")
+	}
+
+	discountIDs := make([]uint, len(discounts))
+	for i, discount := range discounts {
+		discountIDs[i] = discount.ID
+	}
+
+	with, err := uc.reportRepo.GetOrderStats(discountIDs, input.StartDate, input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	without, err := uc.reportRepo.GetOrderStatsWithout(discountIDs, input.StartDate, input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := uc.reportRepo.GetRedemptionsByPeriod(discountIDs, input.StartDate, input.EndDate, input.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiscountReport{
+		Discounts:                discounts,
+		Campaign:                 campaign,
+		StartDate:                input.StartDate,
+		EndDate:                  input.EndDate,
+		Interval:                 input.Interval,
+		Redemptions:              with.OrderCount,
+		GrossRevenue:             with.GrossRevenue,
+		TotalDiscount:            with.DiscountAmount,
+		AverageOrderValueWith:    with.AverageOrderValue(),
+		AverageOrderValueWithout: without.AverageOrderValue(),
+		CancellationRate:         with.CancellationRate(),
+		RefundRate:               with.RefundRate(),
+		RefundedAmount:           with.RefundedAmount,
+		RedemptionsOverTime:      periods,
+	}, nil
+}
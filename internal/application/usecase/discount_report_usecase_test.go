@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func createReportDiscount(t *testing.T, discountRepo repository.DiscountRepository, code, campaign string) *entity.Discount {
+	discount, err := entity.NewDiscount(
+		code,
+		entity.DiscountTypeBasket,
+		entity.DiscountMethodPercentage,
+		10.0,
+		0,
+		0,
+		[]uint{},
+		[]uint{},
+		time.Now().Add(-24*time.Hour),
+		time.Now().Add(30*24*time.Hour),
+		0,
+	)
+	assert.NoError(t, err)
+	discount.Campaign = campaign
+	assert.NoError(t, discountRepo.Create(discount))
+	return discount
+}
+
+func TestDiscountReportUseCase_GetDiscountReport(t *testing.T) {
+	t.Run("Report discount performance", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		reportRepo := mock.NewMockDiscountReportRepository()
+		discount := createReportDiscount(t, discountRepo, "SUMMER10", "")
+
+		reportRepo.Stats = &entity.DiscountOrderStats{
+			OrderCount:      10,
+			PaidOrders:      8,
+			GrossRevenue:    80000,
+			DiscountAmount:  8000,
+			CancelledOrders: 2,
+			RefundedOrders:  2,
+			RefundedAmount:  15000,
+		}
+		reportRepo.StatsWithout = &entity.DiscountOrderStats{
+			OrderCount:   5,
+			PaidOrders:   4,
+			GrossRevenue: 24000,
+		}
+		reportRepo.Periods = []entity.DiscountRedemptionPeriod{
+			{PeriodStart: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Redemptions: 10},
+		}
+
+		reportUseCase := usecase.NewDiscountReportUseCase(discountRepo, reportRepo)
+
+		// Execute
+		report, err := reportUseCase.GetDiscountReport(discount.ID, usecase.DiscountReportInput{
+			StartDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{discount.ID}, reportRepo.DiscountIDs)
+		assert.Equal(t, entity.DiscountReportIntervalDay, report.Interval)
+		assert.Equal(t, 10, report.Redemptions)
+		assert.Equal(t, int64(80000), report.GrossRevenue)
+		assert.Equal(t, int64(8000), report.TotalDiscount)
+		assert.Equal(t, int64(10000), report.AverageOrderValueWith)
+		assert.Equal(t, int64(6000), report.AverageOrderValueWithout)
+		assert.InDelta(t, 0.2, report.CancellationRate, 0.0001)
+		assert.InDelta(t, 0.25, report.RefundRate, 0.0001)
+		assert.Len(t, report.RedemptionsOverTime, 1)
+	})
+
+	t.Run("Reject invalid interval", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		reportRepo := mock.NewMockDiscountReportRepository()
+		discount := createReportDiscount(t, discountRepo, "SUMMER10", "")
+
+		reportUseCase := usecase.NewDiscountReportUseCase(discountRepo, reportRepo)
+
+		// Execute
+		report, err := reportUseCase.GetDiscountReport(discount.ID, usecase.DiscountReportInput{
+			StartDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			Interval:  "hour",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, report)
+		assert.Contains(t, err.Error(), "invalid interval")
+	})
+}
+
+func TestDiscountReportUseCase_GetCampaignReport(t *testing.T) {
+	t.Run("Report combines all discounts in the campaign", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		reportRepo := mock.NewMockDiscountReportRepository()
+		first := createReportDiscount(t, discountRepo, "SUMMER10", "summer")
+		createReportDiscount(t, discountRepo, "WINTER10", "winter")
+		second := createReportDiscount(t, discountRepo, "SUMMER20", "summer")
+
+		reportUseCase := usecase.NewDiscountReportUseCase(discountRepo, reportRepo)
+
+		// Execute
+		report, err := reportUseCase.GetCampaignReport("summer", usecase.DiscountReportInput{
+			StartDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			Interval:  entity.DiscountReportIntervalWeek,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "summer", report.Campaign)
+		assert.Len(t, report.Discounts, 2)
+		assert.Equal(t, []uint{first.ID, second.ID}, reportRepo.DiscountIDs)
+	})
+
+	t.Run("Unknown campaign", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		reportRepo := mock.NewMockDiscountReportRepository()
+
+		reportUseCase := usecase.NewDiscountReportUseCase(discountRepo, reportRepo)
+
+		// Execute
+		report, err := reportUseCase.GetCampaignReport("unknown", usecase.DiscountReportInput{
+			StartDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, report)
+		assert.Contains(t, err.Error(), "campaign not found")
+	})
+}
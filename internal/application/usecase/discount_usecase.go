@@ -11,10 +11,11 @@ import (
 
 // DiscountUseCase implements discount-related use cases
 type DiscountUseCase struct {
-	discountRepo repository.DiscountRepository
-	productRepo  repository.ProductRepository
-	categoryRepo repository.CategoryRepository
-	orderRepo    repository.OrderRepository
+	discountRepo    repository.DiscountRepository
+	productRepo     repository.ProductRepository
+	categoryRepo    repository.CategoryRepository
+	orderRepo       repository.OrderRepository
+	shippingUseCase *ShippingUseCase
 }
 
 // NewDiscountUseCase creates a new DiscountUseCase
@@ -23,28 +24,33 @@ func NewDiscountUseCase(
 	productRepo repository.ProductRepository,
 	categoryRepo repository.CategoryRepository,
 	orderRepo repository.OrderRepository,
+	shippingUseCase *ShippingUseCase,
 ) *DiscountUseCase {
 	return &DiscountUseCase{
-		discountRepo: discountRepo,
-		productRepo:  productRepo,
-		categoryRepo: categoryRepo,
-		orderRepo:    orderRepo,
+		discountRepo:    discountRepo,
+		productRepo:     productRepo,
+		categoryRepo:    categoryRepo,
+		orderRepo:       orderRepo,
+		shippingUseCase: shippingUseCase,
 	}
 }
 
 // CreateDiscountInput contains the data needed to create a discount
 type CreateDiscountInput struct {
-	Code             string    `json:"code"`
-	Type             string    `json:"type"`
-	Method           string    `json:"method"`
-	Value            float64   `json:"value"`
-	MinOrderValue    float64   `json:"min_order_value"`
-	MaxDiscountValue float64   `json:"max_discount_value"`
-	ProductIDs       []uint    `json:"product_ids"`
-	CategoryIDs      []uint    `json:"category_ids"`
-	StartDate        time.Time `json:"start_date"`
-	EndDate          time.Time `json:"end_date"`
-	UsageLimit       int       `json:"usage_limit"`
+	Code              string    `json:"code"`
+	Type              string    `json:"type"`
+	Method            string    `json:"method"`
+	Value             float64   `json:"value"`
+	MinOrderValue     float64   `json:"min_order_value"`
+	MaxDiscountValue  float64   `json:"max_discount_value"`
+	ProductIDs        []uint    `json:"product_ids"`
+	CategoryIDs       []uint    `json:"category_ids"`
+	ShippingMethodIDs []uint    `json:"shipping_method_ids"`
+	ShippingZoneIDs   []uint    `json:"shipping_zone_ids"`
+	StartDate         time.Time `json:"start_date"`
+	EndDate           time.Time `json:"end_date"`
+	UsageLimit        int       `json:"usage_limit"`
+	Campaign          string    `json:"campaign"`
 }
 
 // CreateDiscount creates a new discount
@@ -56,6 +62,8 @@ func (uc *DiscountUseCase) CreateDiscount(input CreateDiscountInput) (*entity.Di
 		discountType = entity.DiscountTypeBasket
 	case string(entity.DiscountTypeProduct):
 		discountType = entity.DiscountTypeProduct
+	case string(entity.DiscountTypeShipping):
+		discountType = entity.DiscountTypeShipping
 	default:
 		return nil, errors.New("invalid discount type")
 	}
@@ -67,6 +75,8 @@ func (uc *DiscountUseCase) CreateDiscount(input CreateDiscountInput) (*entity.Di
 		discountMethod = entity.DiscountMethodFixed
 	case string(entity.DiscountMethodPercentage):
 		discountMethod = entity.DiscountMethodPercentage
+	case string(entity.DiscountMethodFreeShipping):
+		discountMethod = entity.DiscountMethodFreeShipping
 	default:
 		return nil, errors.New("invalid discount method")
 	}
@@ -97,6 +107,16 @@ func (uc *DiscountUseCase) CreateDiscount(input CreateDiscountInput) (*entity.Di
 		}
 	}
 
+	// Validate shipping restrictions
+	if len(input.ShippingMethodIDs) > 0 || len(input.ShippingZoneIDs) > 0 {
+		if discountType != entity.DiscountTypeShipping {
+			return nil, errors.New("shipping restrictions can only be used with shipping discounts")
+		}
+		if err := uc.validateShippingRestrictions(input.ShippingMethodIDs, input.ShippingZoneIDs); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create discount
 	discount, err := entity.NewDiscount(
 		input.Code,
@@ -115,6 +135,10 @@ func (uc *DiscountUseCase) CreateDiscount(input CreateDiscountInput) (*entity.Di
 		return nil, err
 	}
 
+	discount.ShippingMethodIDs = input.ShippingMethodIDs
+	discount.ShippingZoneIDs = input.ShippingZoneIDs
+	discount.Campaign = input.Campaign
+
 	// Save discount
 	if err := uc.discountRepo.Create(discount); err != nil {
 		return nil, err
@@ -135,18 +159,24 @@ func (uc *DiscountUseCase) GetDiscountByCode(code string) (*entity.Discount, err
 
 // UpdateDiscountInput contains the data needed to update a discount
 type UpdateDiscountInput struct {
-	Code             string    `json:"code"`
-	Type             string    `json:"type"`
-	Method           string    `json:"method"`
-	Value            float64   `json:"value"`
-	MinOrderValue    float64   `json:"min_order_value"`
-	MaxDiscountValue float64   `json:"max_discount_value"`
-	ProductIDs       []uint    `json:"product_ids"`
-	CategoryIDs      []uint    `json:"category_ids"`
-	StartDate        time.Time `json:"start_date"`
-	EndDate          time.Time `json:"end_date"`
-	UsageLimit       int       `json:"usage_limit"`
-	Active           bool      `json:"active"`
+	Code              string    `json:"code"`
+	Type              string    `json:"type"`
+	Method            string    `json:"method"`
+	Value             float64   `json:"value"`
+	MinOrderValue     float64   `json:"min_order_value"`
+	MaxDiscountValue  float64   `json:"max_discount_value"`
+	ProductIDs        []uint    `json:"product_ids"`
+	CategoryIDs       []uint    `json:"category_ids"`
+	ShippingMethodIDs []uint    `json:"shipping_method_ids"`
+	ShippingZoneIDs   []uint    `json:"shipping_zone_ids"`
+	StartDate         time.Time `json:"start_date"`
+	EndDate           time.Time `json:"end_date"`
+	UsageLimit        int       `json:"usage_limit"`
+	Campaign          string    `json:"campaign"`
+	Active            bool      `json:"active"`
+
+	ClearShippingRestrictions bool `json:"clear_shipping_restrictions"` // remove the shipping method and zone restrictions
+	ClearCampaign             bool `json:"clear_campaign"`              // detach the discount from its campaign
 }
 
 // UpdateDiscount updates a discount
@@ -164,6 +194,8 @@ func (uc *DiscountUseCase) UpdateDiscount(id uint, input UpdateDiscountInput) (*
 			discount.Type = entity.DiscountTypeBasket
 		case string(entity.DiscountTypeProduct):
 			discount.Type = entity.DiscountTypeProduct
+		case string(entity.DiscountTypeShipping):
+			discount.Type = entity.DiscountTypeShipping
 		default:
 			return nil, errors.New("invalid discount type")
 		}
@@ -176,6 +208,8 @@ func (uc *DiscountUseCase) UpdateDiscount(id uint, input UpdateDiscountInput) (*
 			discount.Method = entity.DiscountMethodFixed
 		case string(entity.DiscountMethodPercentage):
 			discount.Method = entity.DiscountMethodPercentage
+		case string(entity.DiscountMethodFreeShipping):
+			discount.Method = entity.DiscountMethodFreeShipping
 		default:
 			return nil, errors.New("invalid discount method")
 		}
@@ -225,6 +259,25 @@ func (uc *DiscountUseCase) UpdateDiscount(id uint, input UpdateDiscountInput) (*
 		discount.CategoryIDs = input.CategoryIDs
 	}
 
+	if input.ClearShippingRestrictions {
+		discount.ShippingMethodIDs = []uint{}
+		discount.ShippingZoneIDs = []uint{}
+	} else if len(input.ShippingMethodIDs) > 0 || len(input.ShippingZoneIDs) > 0 {
+		if err := uc.validateShippingRestrictions(input.ShippingMethodIDs, input.ShippingZoneIDs); err != nil {
+			return nil, err
+		}
+		discount.ShippingMethodIDs = input.ShippingMethodIDs
+		discount.ShippingZoneIDs = input.ShippingZoneIDs
+	}
+
+	if discount.Method == entity.DiscountMethodFreeShipping && discount.Type != entity.DiscountTypeShipping {
+		return nil, errors.New("free shipping method can only be used with shipping discounts")
+	}
+
+	if discount.Type != entity.DiscountTypeShipping && (len(discount.ShippingMethodIDs) > 0 || len(discount.ShippingZoneIDs) > 0) {
+		return nil, errors.New("shipping restrictions can only be used with shipping discounts")
+	}
+
 	if !input.StartDate.IsZero() {
 		discount.StartDate = input.StartDate
 	}
@@ -237,6 +290,12 @@ func (uc *DiscountUseCase) UpdateDiscount(id uint, input UpdateDiscountInput) (*
 		discount.UsageLimit = input.UsageLimit
 	}
 
+	if input.ClearCampaign {
+		discount.Campaign = ""
+	} else if input.Campaign != "" {
+		discount.Campaign = input.Campaign
+	}
+
 	discount.Active = input.Active
 	discount.UpdatedAt = time.Now()
 
@@ -281,34 +340,64 @@ type ApplyDiscountToOrderInput struct {
 
 // ApplyDiscountToOrder applies a discount to an order
 func (uc *DiscountUseCase) ApplyDiscountToOrder(input ApplyDiscountToOrderInput, order *entity.Order) (*entity.Order, error) {
+	discount, err := uc.applyDiscount(input.DiscountCode, order)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.orderRepo.Update(order); err != nil {
+		return nil, err
+	}
+
+	// Increment discount usage
+	if err := uc.discountRepo.IncrementUsage(discount.ID); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ApplyDiscountToNewOrder applies a discount code to an order that hasn't been saved yet.
+// The usage of the returned discount is counted with RecordDiscountUsage once the order is saved.
+func (uc *DiscountUseCase) ApplyDiscountToNewOrder(code string, order *entity.Order) (*entity.Discount, error) {
+	return uc.applyDiscount(code, order)
+}
+
+// RecordDiscountUsage counts a use of a discount applied to a new order
+func (uc *DiscountUseCase) RecordDiscountUsage(discount *entity.Discount) error {
+	return uc.discountRepo.IncrementUsage(discount.ID)
+}
+
+// applyDiscount applies a discount code to an order without saving it
+func (uc *DiscountUseCase) applyDiscount(code string, order *entity.Order) (*entity.Discount, error) {
 	// Get discount by code
-	discount, err := uc.discountRepo.GetByCode(input.DiscountCode)
+	discount, err := uc.discountRepo.GetByCode(code)
 	if err != nil {
 		return nil, errors.New("invalid discount code")
 	}
 
+	// Shipping discounts depend on the shipping zones of the order's address
+	var zoneIDs []uint
+	if discount.Type == entity.DiscountTypeShipping && uc.shippingUseCase != nil && len(discount.ShippingZoneIDs) > 0 {
+		zoneIDs, err = uc.shippingUseCase.GetZoneIDsForAddress(order.ShippingAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// For category-based discounts, we need to modify the ProductIDs to include products from those categories
 	if discount.Type == entity.DiscountTypeProduct && len(discount.CategoryIDs) > 0 {
-		// Create a map to track which items in the order need discounts
-		eligibleProducts := make(map[uint]bool)
-
-		// First add directly specified products
-		for _, productID := range discount.ProductIDs {
-			eligibleProducts[productID] = true
+		// Validate discount, its usage limit and the minimum order value
+		if !discount.IsValid() {
+			return nil, errors.New("discount is invalid or inactive")
 		}
-
-		// Then find products that belong to the specified categories
-		for _, categoryID := range discount.CategoryIDs {
-			// Get all products in this category
-			products, err := uc.productRepo.Search("", categoryID, 0, 0, 0, 1000)
-			if err == nil && len(products) > 0 {
-				// Add these products to our eligibility map
-				for _, product := range products {
-					eligibleProducts[product.ID] = true
-				}
-			}
+		if !discount.IsApplicableToOrder(order) {
+			return nil, errors.New("discount is not applicable to this order")
 		}
 
+		// Create a map to track which items in the order need discounts
+		eligibleProducts := uc.eligibleProducts(discount)
+
 		// Now calculate the discount based on eligible products
 		var discountAmount int64
 
@@ -317,8 +406,8 @@ func (uc *DiscountUseCase) ApplyDiscountToOrder(input ApplyDiscountToOrderInput,
 				itemTotal := int64(item.Quantity) * item.Price
 
 				if discount.Method == entity.DiscountMethodFixed {
-					// Apply fixed discount per item
-					itemDiscount := min(money.ToCents(discount.Value), itemTotal)
+					// Apply fixed discount per item, converted from the default currency
+					itemDiscount := min(order.FromBaseAmount(money.ToCents(discount.Value)), itemTotal)
 					discountAmount += itemDiscount
 				} else if discount.Method == entity.DiscountMethodPercentage {
 					// Apply percentage discount to the item
@@ -330,18 +419,23 @@ func (uc *DiscountUseCase) ApplyDiscountToOrder(input ApplyDiscountToOrderInput,
 		}
 
 		// Apply maximum discount cap if specified
-		if discount.MaxDiscountValue > 0 && discountAmount > discount.MaxDiscountValue {
-			discountAmount = discount.MaxDiscountValue
+		maxDiscount := order.FromBaseAmount(discount.MaxDiscountValue)
+		if maxDiscount > 0 && discountAmount > maxDiscount {
+			discountAmount = maxDiscount
 		}
 
 		// Ensure discount doesn't exceed order total
 		if discountAmount > order.TotalAmount {
 			discountAmount = order.TotalAmount
 		}
+		if discountAmount <= 0 {
+			return nil, errors.New("discount is not applicable to this order")
+		}
 
 		// Apply calculated discount amount
 		order.DiscountAmount = discountAmount
-		order.FinalAmount = order.TotalAmount - discountAmount
+		order.ShippingDiscountAmount = 0
+		order.RecalculateTotals()
 
 		// Record the applied discount
 		order.AppliedDiscount = &entity.AppliedDiscount{
@@ -353,19 +447,142 @@ func (uc *DiscountUseCase) ApplyDiscountToOrder(input ApplyDiscountToOrderInput,
 		order.UpdatedAt = time.Now()
 	} else {
 		// For non-category specific discounts, use the standard entity method
-		if err := order.ApplyDiscount(discount); err != nil {
+		if err := order.ApplyDiscount(discount, zoneIDs); err != nil {
 			return nil, err
 		}
 	}
 
-	uc.orderRepo.Update(order)
+	return discount, nil
+}
 
-	// Increment discount usage
-	if err := uc.discountRepo.IncrementUsage(discount.ID); err != nil {
-		return nil, err
+// eligibleProducts returns the products a product discount applies to,
+// including all products in the discount's categories
+func (uc *DiscountUseCase) eligibleProducts(discount *entity.Discount) map[uint]bool {
+	eligibleProducts := make(map[uint]bool)
+
+	// First add directly specified products
+	for _, productID := range discount.ProductIDs {
+		eligibleProducts[productID] = true
 	}
 
-	return order, nil
+	// Then find products that belong to the specified categories
+	for _, categoryID := range discount.CategoryIDs {
+		// Get all products in this category
+		products, err := uc.productRepo.Search("", categoryID, 0, 0, 0, 1000)
+		if err == nil && len(products) > 0 {
+			// Add these products to our eligibility map
+			for _, product := range products {
+				eligibleProducts[product.ID] = true
+			}
+		}
+	}
+
+	return eligibleProducts
+}
+
+// LineDiscounts contains the discount a code gives on a set of order lines
+type LineDiscounts struct {
+	Discount *entity.Discount
+	Amount   int64   // total discount in cents
+	Lines    []int64 // discount per line in cents, in the order of the input items
+}
+
+// CalculateLineDiscounts calculates the discount for a set of lines without applying it.
+// The total comes from Discount.CalculateDiscount and is distributed over the eligible lines.
+// Shipping discounts are not included, since they only apply once a shipping method is chosen.
+func (uc *DiscountUseCase) CalculateLineDiscounts(code string, items []entity.OrderItem) (*LineDiscounts, error) {
+	discount, err := uc.discountRepo.GetByCode(code)
+	if err != nil {
+		return nil, errors.New("invalid discount code")
+	}
+
+	result := &LineDiscounts{
+		Discount: discount,
+		Lines:    make([]int64, len(items)),
+	}
+
+	if discount.Type == entity.DiscountTypeShipping {
+		return result, nil
+	}
+
+	// Resolve category-based discounts to the products they cover
+	eligible := discount
+	if discount.Type == entity.DiscountTypeProduct && len(discount.CategoryIDs) > 0 {
+		expanded := *discount
+		expanded.ProductIDs = make([]uint, 0)
+		for productID := range uc.eligibleProducts(discount) {
+			expanded.ProductIDs = append(expanded.ProductIDs, productID)
+		}
+		eligible = &expanded
+	}
+
+	var totalAmount int64
+	for _, item := range items {
+		totalAmount += item.Subtotal
+	}
+
+	result.Amount = eligible.CalculateDiscount(&entity.Order{Items: items, TotalAmount: totalAmount})
+	if result.Amount == 0 {
+		return result, nil
+	}
+
+	// Weigh each line by what it would get on its own, so caps are spread proportionally
+	weights := make([]int64, len(items))
+	var totalWeight int64
+	lastEligible := -1
+	for i, item := range items {
+		switch eligible.Type {
+		case entity.DiscountTypeBasket:
+			weights[i] = item.Subtotal
+		case entity.DiscountTypeProduct:
+			weights[i] = eligible.CalculateDiscount(&entity.Order{Items: []entity.OrderItem{item}, TotalAmount: totalAmount})
+		}
+		if weights[i] > 0 {
+			totalWeight += weights[i]
+			lastEligible = i
+		}
+	}
+
+	if totalWeight == 0 {
+		return result, nil
+	}
+
+	// The last eligible line takes the rounding remainder
+	var allocated int64
+	for i := range items {
+		if weights[i] == 0 {
+			continue
+		}
+		if i == lastEligible {
+			result.Lines[i] = result.Amount - allocated
+			break
+		}
+		result.Lines[i] = result.Amount * weights[i] / totalWeight
+		allocated += result.Lines[i]
+	}
+
+	return result, nil
+}
+
+// validateShippingRestrictions checks that the shipping methods and zones of a shipping discount exist
+func (uc *DiscountUseCase) validateShippingRestrictions(methodIDs, zoneIDs []uint) error {
+	if uc.shippingUseCase == nil {
+		return nil
+	}
+
+	for _, methodID := range methodIDs {
+		if _, err := uc.shippingUseCase.GetShippingMethodByID(methodID); err != nil {
+			return errors.New("invalid shipping method ID: " + err.Error())
+		}
+	}
+
+	for _, zoneID := range zoneIDs {
+		if _, err := uc.shippingUseCase.GetShippingZoneByID(zoneID); err != nil {
+			return errors.New("invalid shipping zone ID: " + err.Error())
+		}
+	}
+
+	return nil
 }
 
 // RemoveDiscountFromOrder removes a discount from an order
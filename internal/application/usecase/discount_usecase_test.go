@@ -25,6 +25,7 @@ func TestDiscountUseCase_CreateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		now := time.Now()
@@ -81,6 +82,7 @@ func TestDiscountUseCase_CreateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		now := time.Now()
@@ -131,6 +133,7 @@ func TestDiscountUseCase_CreateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		now := time.Now()
@@ -192,6 +195,7 @@ func TestDiscountUseCase_CreateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		now := time.Now()
@@ -230,6 +234,7 @@ func TestDiscountUseCase_CreateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		now := time.Now()
@@ -270,6 +275,7 @@ func TestDiscountUseCase_CreateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		now := time.Now()
@@ -309,6 +315,7 @@ func TestDiscountUseCase_CreateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		now := time.Now()
@@ -334,6 +341,88 @@ func TestDiscountUseCase_CreateDiscount(t *testing.T) {
 		assert.Nil(t, discount)
 		assert.Contains(t, err.Error(), "invalid category ID")
 	})
+
+	t.Run("Create free shipping discount successfully", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		productRepo := mock.NewMockProductRepository()
+		categoryRepo := mock.NewMockCategoryRepository()
+		orderRepo := mock.NewMockOrderRepository(false)
+
+		// Create use case with mocks
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			productRepo,
+			categoryRepo,
+			orderRepo,
+			nil,
+		)
+
+		now := time.Now()
+		startDate := now.Add(-24 * time.Hour)
+		endDate := now.Add(30 * 24 * time.Hour)
+
+		// Create discount input
+		input := usecase.CreateDiscountInput{
+			Code:              "FREESHIP",
+			Type:              string(entity.DiscountTypeShipping),
+			Method:            string(entity.DiscountMethodFreeShipping),
+			MinOrderValue:     50.0,
+			ShippingMethodIDs: []uint{1},
+			StartDate:         startDate,
+			EndDate:           endDate,
+		}
+
+		// Execute
+		discount, err := discountUseCase.CreateDiscount(input)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, discount)
+		assert.Equal(t, entity.DiscountTypeShipping, discount.Type)
+		assert.Equal(t, entity.DiscountMethodFreeShipping, discount.Method)
+		assert.Equal(t, []uint{1}, discount.ShippingMethodIDs)
+	})
+
+	t.Run("Create basket discount with shipping restrictions", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		productRepo := mock.NewMockProductRepository()
+		categoryRepo := mock.NewMockCategoryRepository()
+		orderRepo := mock.NewMockOrderRepository(false)
+
+		// Create use case with mocks
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			productRepo,
+			categoryRepo,
+			orderRepo,
+			nil,
+		)
+
+		now := time.Now()
+		startDate := now.Add(-24 * time.Hour)
+		endDate := now.Add(30 * 24 * time.Hour)
+
+		// Create discount input
+		input := usecase.CreateDiscountInput{
+			Code:            "BASKETSHIP",
+			Type:            string(entity.DiscountTypeBasket),
+			Method:          string(entity.DiscountMethodFixed),
+			Value:           10.0,
+			ShippingZoneIDs: []uint{1},
+			StartDate:       startDate,
+			EndDate:         endDate,
+		}
+
+		// Execute
+		discount, err := discountUseCase.CreateDiscount(input)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, discount)
+		assert.Contains(t, err.Error(), "shipping restrictions can only be used with shipping discounts")
+	})
 }
 
 func TestDiscountUseCase_ProductSpecificDiscount(t *testing.T) {
@@ -364,6 +453,7 @@ func TestDiscountUseCase_ProductSpecificDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		now := time.Now()
@@ -462,12 +552,15 @@ func TestDiscountUseCase_ProductSpecificDiscount(t *testing.T) {
 			},
 		)
 
+		orderRepo.Create(order)
+
 		// Create use case with mocks
 		discountUseCase := usecase.NewDiscountUseCase(
 			discountRepo,
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Apply discount input
@@ -558,12 +651,15 @@ func TestDiscountUseCase_ProductSpecificDiscount(t *testing.T) {
 			},
 		)
 
+		orderRepo.Create(order)
+
 		// Create use case with mocks
 		discountUseCase := usecase.NewDiscountUseCase(
 			discountRepo,
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Apply discount input
@@ -654,12 +750,15 @@ func TestDiscountUseCase_ProductSpecificDiscount(t *testing.T) {
 			},
 		)
 
+		orderRepo.Create(order)
+
 		// Create use case with mocks
 		discountUseCase := usecase.NewDiscountUseCase(
 			discountRepo,
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Apply discount input
@@ -715,6 +814,7 @@ func TestDiscountUseCase_GetDiscountByID(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Execute
@@ -739,6 +839,7 @@ func TestDiscountUseCase_GetDiscountByID(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Execute with non-existent ID
@@ -780,6 +881,7 @@ func TestDiscountUseCase_GetDiscountByCode(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Execute
@@ -804,6 +906,7 @@ func TestDiscountUseCase_GetDiscountByCode(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Execute with non-existent code
@@ -845,6 +948,7 @@ func TestDiscountUseCase_UpdateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Update input
@@ -870,6 +974,86 @@ func TestDiscountUseCase_UpdateDiscount(t *testing.T) {
 		assert.Equal(t, input.Active, updatedDiscount.Active)
 	})
 
+	t.Run("Clear shipping restrictions", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+
+		discount, _ := entity.NewDiscount(
+			"FREESHIP",
+			entity.DiscountTypeShipping,
+			entity.DiscountMethodFreeShipping,
+			0,
+			0,
+			0,
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discount.ShippingMethodIDs = []uint{1}
+		discount.ShippingZoneIDs = []uint{2}
+		discountRepo.Create(discount)
+
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			mock.NewMockProductRepository(),
+			mock.NewMockCategoryRepository(),
+			mock.NewMockOrderRepository(false),
+			nil,
+		)
+
+		// Execute
+		updatedDiscount, err := discountUseCase.UpdateDiscount(discount.ID, usecase.UpdateDiscountInput{
+			ClearShippingRestrictions: true,
+			Active:                    true,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, updatedDiscount.ShippingMethodIDs)
+		assert.Empty(t, updatedDiscount.ShippingZoneIDs)
+	})
+
+	t.Run("Detach from campaign", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+
+		discount, _ := entity.NewDiscount(
+			"SUMMER10",
+			entity.DiscountTypeBasket,
+			entity.DiscountMethodPercentage,
+			10.0,
+			0,
+			0,
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discount.Campaign = "summer-sale"
+		discountRepo.Create(discount)
+
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			mock.NewMockProductRepository(),
+			mock.NewMockCategoryRepository(),
+			mock.NewMockOrderRepository(false),
+			nil,
+		)
+
+		// Execute
+		updatedDiscount, err := discountUseCase.UpdateDiscount(discount.ID, usecase.UpdateDiscountInput{
+			ClearCampaign: true,
+			Active:        true,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, updatedDiscount.Campaign)
+	})
+
 	t.Run("Update non-existent discount", func(t *testing.T) {
 		// Setup mocks
 		discountRepo := mock.NewMockDiscountRepository()
@@ -883,6 +1067,7 @@ func TestDiscountUseCase_UpdateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Update input
@@ -942,6 +1127,7 @@ func TestDiscountUseCase_UpdateDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Update input with duplicate code
@@ -989,6 +1175,7 @@ func TestDiscountUseCase_DeleteDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Execute
@@ -1031,6 +1218,7 @@ func TestDiscountUseCase_DeleteDiscount(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Execute
@@ -1079,6 +1267,7 @@ func TestDiscountUseCase_ListDiscounts(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Execute - first page
@@ -1150,12 +1339,15 @@ func TestDiscountUseCase_ApplyDiscountToOrder(t *testing.T) {
 			},
 		)
 
+		orderRepo.Create(order)
+
 		// Create use case with mocks
 		discountUseCase := usecase.NewDiscountUseCase(
 			discountRepo,
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Apply discount input
@@ -1178,6 +1370,70 @@ func TestDiscountUseCase_ApplyDiscountToOrder(t *testing.T) {
 		assert.Equal(t, money.ToCents(11.0), updatedOrder.AppliedDiscount.DiscountAmount)
 	})
 
+	t.Run("Fixed discount in the order currency", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		orderRepo := mock.NewMockOrderRepository(false)
+
+		// $10 off orders of at least $50, at most $8
+		discount, _ := entity.NewDiscount(
+			"TENOFF",
+			entity.DiscountTypeBasket,
+			entity.DiscountMethodFixed,
+			10.0,
+			money.ToCents(50.0),
+			money.ToCents(8.0),
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discountRepo.Create(discount)
+
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			mock.NewMockProductRepository(),
+			mock.NewMockCategoryRepository(),
+			orderRepo,
+			nil,
+		)
+
+		newOrder := func(total int64, rate float64) *entity.Order {
+			order, _ := entity.NewOrder(
+				1,
+				[]entity.OrderItem{{ProductID: 1, Quantity: 1, Price: total, Subtotal: total}},
+				entity.Address{Street: "123 Main St"},
+				entity.Address{Street: "123 Main St"},
+				entity.CustomerDetails{Email: "test@example.com", FullName: "John Doe"},
+			)
+			order.SetCurrency("JPY", rate)
+			orderRepo.Create(order)
+			return order
+		}
+
+		// Execute: ¥9000 is $60
+		order, err := discountUseCase.ApplyDiscountToOrder(usecase.ApplyDiscountToOrderInput{DiscountCode: "TENOFF"}, newOrder(9000, 150))
+
+		// Assert: capped at $8, which is ¥1200
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1200), order.DiscountAmount)
+		assert.Equal(t, int64(7800), order.FinalAmount)
+
+		// Execute: ¥6000 is $40, below the minimum order value
+		_, err = discountUseCase.ApplyDiscountToOrder(usecase.ApplyDiscountToOrderInput{DiscountCode: "TENOFF"}, newOrder(6000, 150))
+
+		// Assert
+		assert.Error(t, err)
+
+		// Execute: yen have no decimals, also at a rate of 1 ¥100 is $100
+		order, err = discountUseCase.ApplyDiscountToOrder(usecase.ApplyDiscountToOrderInput{DiscountCode: "TENOFF"}, newOrder(100, 1))
+
+		// Assert: capped at $8, which is ¥8
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8), order.DiscountAmount)
+	})
+
 	t.Run("Apply category-specific discount to order", func(t *testing.T) {
 		// Setup mocks
 		discountRepo := mock.NewMockDiscountRepository()
@@ -1259,12 +1515,15 @@ func TestDiscountUseCase_ApplyDiscountToOrder(t *testing.T) {
 			},
 		)
 
+		orderRepo.Create(order)
+
 		// Create use case with mocks
 		discountUseCase := usecase.NewDiscountUseCase(
 			discountRepo,
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Apply discount input
@@ -1290,6 +1549,63 @@ func TestDiscountUseCase_ApplyDiscountToOrder(t *testing.T) {
 		assert.Equal(t, money.ToCents(275.0), updatedOrder.AppliedDiscount.DiscountAmount)
 	})
 
+	t.Run("Category discount that can't be applied", func(t *testing.T) {
+		// Setup mocks
+		productRepo := mock.NewMockProductRepository()
+		productRepo.Create(&entity.Product{ID: 1, Name: "Phone", CategoryID: 1, Price: 10000})
+		productRepo.Create(&entity.Product{ID: 2, Name: "Mug", CategoryID: 2, Price: 1000})
+
+		newCategoryDiscount := func(minOrderValue int64, usageLimit int) *usecase.DiscountUseCase {
+			discountRepo := mock.NewMockDiscountRepository()
+			discount, _ := entity.NewDiscount(
+				"ELECTRONICS25",
+				entity.DiscountTypeProduct,
+				entity.DiscountMethodPercentage,
+				25.0,
+				minOrderValue,
+				0,
+				[]uint{},
+				[]uint{1},
+				time.Now().Add(-24*time.Hour),
+				time.Now().Add(30*24*time.Hour),
+				usageLimit,
+			)
+			discount.CurrentUsage = usageLimit
+			discountRepo.Create(discount)
+			return usecase.NewDiscountUseCase(discountRepo, productRepo, mock.NewMockCategoryRepository(), mock.NewMockOrderRepository(false), nil)
+		}
+		newOrder := func(productID uint) *entity.Order {
+			order, _ := entity.NewOrder(1, []entity.OrderItem{
+				{ProductID: productID, Quantity: 1, Price: 10000, Subtotal: 10000},
+			}, entity.Address{Street: "123 Main St"}, entity.Address{Street: "123 Main St"}, entity.CustomerDetails{
+				Email:    "test@example.com",
+				FullName: "John Doe",
+			})
+			return order
+		}
+
+		cases := map[string]struct {
+			discountUseCase *usecase.DiscountUseCase
+			order           *entity.Order
+			err             string
+		}{
+			"usage limit reached":      {newCategoryDiscount(0, 1), newOrder(1), "discount is invalid or inactive"},
+			"below the minimum order":  {newCategoryDiscount(20000, 0), newOrder(1), "discount is not applicable to this order"},
+			"no items in the category": {newCategoryDiscount(0, 0), newOrder(2), "discount is not applicable to this order"},
+		}
+
+		for name, c := range cases {
+			// Execute
+			discount, err := c.discountUseCase.ApplyDiscountToNewOrder("ELECTRONICS25", c.order)
+
+			// Assert
+			assert.EqualError(t, err, c.err, name)
+			assert.Nil(t, discount, name)
+			assert.Nil(t, c.order.AppliedDiscount, name)
+			assert.Equal(t, int64(10000), c.order.FinalAmount, name)
+		}
+	})
+
 	t.Run("Apply invalid discount code", func(t *testing.T) {
 		// Setup mocks
 		discountRepo := mock.NewMockDiscountRepository()
@@ -1320,12 +1636,15 @@ func TestDiscountUseCase_ApplyDiscountToOrder(t *testing.T) {
 			},
 		)
 
+		orderRepo.Create(order)
+
 		// Create use case with mocks
 		discountUseCase := usecase.NewDiscountUseCase(
 			discountRepo,
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Apply discount input with invalid code
@@ -1342,6 +1661,227 @@ func TestDiscountUseCase_ApplyDiscountToOrder(t *testing.T) {
 		assert.Nil(t, updatedOrder)
 		assert.Contains(t, err.Error(), "invalid discount code")
 	})
+
+	t.Run("Apply free shipping discount to order", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		productRepo := mock.NewMockProductRepository()
+		categoryRepo := mock.NewMockCategoryRepository()
+		orderRepo := mock.NewMockOrderRepository(false)
+
+		// Create a test discount
+		discount, _ := entity.NewDiscount(
+			"FREESHIP",
+			entity.DiscountTypeShipping,
+			entity.DiscountMethodFreeShipping,
+			0,
+			0,
+			0,
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discount.ShippingMethodIDs = []uint{1}
+		discountRepo.Create(discount)
+
+		// Create test order with shipping
+		order, _ := entity.NewOrder(
+			1,
+			[]entity.OrderItem{
+				{
+					ProductID: 1,
+					Quantity:  2,
+					Price:     5000,
+					Subtotal:  10000,
+				},
+			},
+			entity.Address{Street: "123 Main St"},
+			entity.Address{Street: "123 Main St"},
+			entity.CustomerDetails{
+				Email:    "test@example.com",
+				Phone:    "1234567890",
+				FullName: "John Doe",
+			},
+		)
+		order.SetShippingMethod(&entity.ShippingMethod{ID: 1, Name: "Standard"}, 995, nil, nil)
+
+		orderRepo.Create(order)
+
+		// Create use case with mocks
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			productRepo,
+			categoryRepo,
+			orderRepo,
+			nil,
+		)
+
+		// Execute
+		updatedOrder, err := discountUseCase.ApplyDiscountToOrder(usecase.ApplyDiscountToOrderInput{
+			OrderID:      order.ID,
+			DiscountCode: "FREESHIP",
+		}, order)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, updatedOrder)
+		assert.Equal(t, int64(0), updatedOrder.DiscountAmount)
+		assert.Equal(t, int64(995), updatedOrder.ShippingDiscountAmount)
+		assert.Equal(t, int64(10000), updatedOrder.FinalAmount)
+		assert.NotNil(t, updatedOrder.AppliedDiscount)
+		assert.Equal(t, int64(995), updatedOrder.AppliedDiscount.DiscountAmount)
+	})
+
+	t.Run("Apply shipping discount to order with other shipping method", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		productRepo := mock.NewMockProductRepository()
+		categoryRepo := mock.NewMockCategoryRepository()
+		orderRepo := mock.NewMockOrderRepository(false)
+
+		// Create a test discount restricted to shipping method 1
+		discount, _ := entity.NewDiscount(
+			"FREESHIP",
+			entity.DiscountTypeShipping,
+			entity.DiscountMethodFreeShipping,
+			0,
+			0,
+			0,
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discount.ShippingMethodIDs = []uint{1}
+		discountRepo.Create(discount)
+
+		// Create test order shipped with method 2
+		order, _ := entity.NewOrder(
+			1,
+			[]entity.OrderItem{
+				{
+					ProductID: 1,
+					Quantity:  1,
+					Price:     5000,
+					Subtotal:  5000,
+				},
+			},
+			entity.Address{Street: "123 Main St"},
+			entity.Address{Street: "123 Main St"},
+			entity.CustomerDetails{
+				Email:    "test@example.com",
+				Phone:    "1234567890",
+				FullName: "John Doe",
+			},
+		)
+		order.SetShippingMethod(&entity.ShippingMethod{ID: 2, Name: "Express"}, 1995, nil, nil)
+
+		orderRepo.Create(order)
+
+		// Create use case with mocks
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			productRepo,
+			categoryRepo,
+			orderRepo,
+			nil,
+		)
+
+		// Execute
+		updatedOrder, err := discountUseCase.ApplyDiscountToOrder(usecase.ApplyDiscountToOrderInput{
+			OrderID:      order.ID,
+			DiscountCode: "FREESHIP",
+		}, order)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, updatedOrder)
+	})
+
+	t.Run("Change shipping method of order with shipping discount", func(t *testing.T) {
+		// Setup mocks
+		discountRepo := mock.NewMockDiscountRepository()
+		orderRepo := mock.NewMockOrderRepository(false)
+
+		// Create a test discount of 50% on shipping method 1 or 3
+		discount, _ := entity.NewDiscount(
+			"HALFSHIP",
+			entity.DiscountTypeShipping,
+			entity.DiscountMethodPercentage,
+			50.0,
+			0,
+			0,
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discount.ShippingMethodIDs = []uint{1, 3}
+		discountRepo.Create(discount)
+
+		discountUseCase := usecase.NewDiscountUseCase(
+			discountRepo,
+			mock.NewMockProductRepository(),
+			mock.NewMockCategoryRepository(),
+			orderRepo,
+			nil,
+		)
+
+		newOrder := func() *entity.Order {
+			order, _ := entity.NewOrder(
+				1,
+				[]entity.OrderItem{
+					{
+						ProductID: 1,
+						Quantity:  1,
+						Price:     5000,
+						Subtotal:  5000,
+					},
+				},
+				entity.Address{Street: "123 Main St"},
+				entity.Address{Street: "123 Main St"},
+				entity.CustomerDetails{
+					Email:    "test@example.com",
+					Phone:    "1234567890",
+					FullName: "John Doe",
+				},
+			)
+			order.SetShippingMethod(&entity.ShippingMethod{ID: 1, Name: "Standard"}, 1000, nil, nil)
+			orderRepo.Create(order)
+
+			_, err := discountUseCase.ApplyDiscountToOrder(usecase.ApplyDiscountToOrderInput{
+				OrderID:      order.ID,
+				DiscountCode: "HALFSHIP",
+			}, order)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(500), order.ShippingDiscountAmount)
+			return order
+		}
+
+		// Execute, the discount is calculated again for a method it applies to
+		order := newOrder()
+		err := order.SetShippingMethod(&entity.ShippingMethod{ID: 3, Name: "Express"}, 3000, discount, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1500), order.ShippingDiscountAmount)
+		assert.Equal(t, int64(1500), order.AppliedDiscount.DiscountAmount)
+		assert.Equal(t, int64(6500), order.FinalAmount)
+
+		// Execute, the discount is dropped for a method it doesn't apply to
+		order = newOrder()
+		err = order.SetShippingMethod(&entity.ShippingMethod{ID: 2, Name: "Pickup"}, 300, discount, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), order.ShippingDiscountAmount)
+		assert.Nil(t, order.AppliedDiscount)
+		assert.Equal(t, int64(5300), order.FinalAmount)
+	})
 }
 
 func TestDiscountUseCase_RemoveDiscountFromOrder(t *testing.T) {
@@ -1391,7 +1931,7 @@ func TestDiscountUseCase_RemoveDiscountFromOrder(t *testing.T) {
 		)
 
 		// Apply discount manually
-		order.ApplyDiscount(discount)
+		order.ApplyDiscount(discount, nil)
 		assert.NotNil(t, order.AppliedDiscount)
 		assert.Greater(t, order.DiscountAmount, money.ToCents(0.0))
 		assert.Less(t, order.FinalAmount, order.TotalAmount)
@@ -1402,6 +1942,7 @@ func TestDiscountUseCase_RemoveDiscountFromOrder(t *testing.T) {
 			productRepo,
 			categoryRepo,
 			orderRepo,
+			nil,
 		)
 
 		// Execute
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// ExchangeRateUseCase implements exchange rate synchronisation and review
+type ExchangeRateUseCase struct {
+	currencyRepo     repository.CurrencyRepository
+	historyRepo      repository.ExchangeRateHistoryRepository
+	rateProvider     service.RateProvider
+	maxChangePercent float64
+}
+
+// NewExchangeRateUseCase creates a new ExchangeRateUseCase.
+// Synchronised rates that change more than maxChangePercent are held for admin confirmation;
+// zero disables the guard.
+func NewExchangeRateUseCase(
+	currencyRepo repository.CurrencyRepository,
+	historyRepo repository.ExchangeRateHistoryRepository,
+	rateProvider service.RateProvider,
+	maxChangePercent float64,
+) *ExchangeRateUseCase {
+	return &ExchangeRateUseCase{
+		currencyRepo:     currencyRepo,
+		historyRepo:      historyRepo,
+		rateProvider:     rateProvider,
+		maxChangePercent: maxChangePercent,
+	}
+}
+
+// RateSyncResult summarises a synchronisation run
+type RateSyncResult struct {
+	Updated   []*entity.ExchangeRateHistory `json:"updated"`
+	Pending   []*entity.ExchangeRateHistory `json:"pending"`
+	Unchanged []string                      `json:"unchanged"`
+	Missing   []string                      `json:"missing"` // currencies the provider has no rate for
+}
+
+// SyncRates fetches the latest rates and updates all enabled currencies
+func (uc *ExchangeRateUseCase) SyncRates() (*RateSyncResult, error) {
+	if uc.rateProvider == nil {
+		return nil, errors.New("no exchange rate provider configured")
+	}
+
+	defaultCurrency, err := uc.currencyRepo.GetDefault()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default currency: %w", err)
+	}
+
+	rates, err := uc.rateProvider.GetRates(defaultCurrency.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies, err := uc.currencyRepo.ListEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	pendingRates, err := uc.historyRepo.ListPending()
+	if err != nil {
+		return nil, err
+	}
+
+	pendingByCurrency := make(map[string]*entity.ExchangeRateHistory)
+	for _, pending := range pendingRates {
+		pendingByCurrency[pending.CurrencyCode] = pending
+	}
+
+	result := &RateSyncResult{
+		Updated:   []*entity.ExchangeRateHistory{},
+		Pending:   []*entity.ExchangeRateHistory{},
+		Unchanged: []string{},
+		Missing:   []string{},
+	}
+
+	for _, currency := range currencies {
+		if currency.Code == defaultCurrency.Code {
+			continue
+		}
+
+		rate, ok := rates.Rates[currency.Code]
+		if !ok {
+			result.Missing = append(result.Missing, currency.Code)
+			continue
+		}
+
+		// Rates are stored with six decimals
+		rate = math.Round(rate*1e6) / 1e6
+		if rate == currency.ExchangeRate {
+			result.Unchanged = append(result.Unchanged, currency.Code)
+			continue
+		}
+
+		history, err := entity.NewExchangeRateHistory(
+			currency.Code,
+			rate,
+			currency.ExchangeRate,
+			uc.rateProvider.Name(),
+			entity.ExchangeRateStatusApplied,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		pending := pendingByCurrency[currency.Code]
+
+		// Hold implausible jumps until an admin confirms them
+		if uc.maxChangePercent > 0 && history.ChangePercentage() > uc.maxChangePercent {
+			if pending != nil {
+				// Keep a single pending rate per currency, refreshed with the latest value
+				pending.Rate = rate
+				pending.PreviousRate = currency.ExchangeRate
+				if err := uc.historyRepo.Update(pending); err != nil {
+					return nil, err
+				}
+				result.Pending = append(result.Pending, pending)
+				continue
+			}
+
+			history.Status = entity.ExchangeRateStatusPending
+			if err := uc.historyRepo.Create(history); err != nil {
+				return nil, err
+			}
+			result.Pending = append(result.Pending, history)
+			continue
+		}
+
+		if err := currency.SetExchangeRate(rate); err != nil {
+			return nil, err
+		}
+		if err := uc.currencyRepo.Update(currency); err != nil {
+			return nil, err
+		}
+		if err := uc.historyRepo.Create(history); err != nil {
+			return nil, err
+		}
+		result.Updated = append(result.Updated, history)
+
+		// A plausible rate supersedes a jump that is still awaiting review
+		if pending != nil {
+			if err := pending.Reject(); err != nil {
+				return nil, err
+			}
+			if err := uc.historyRepo.Update(pending); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ConfirmRate applies a rate that is pending confirmation
+func (uc *ExchangeRateUseCase) ConfirmRate(historyID uint) (*entity.ExchangeRateHistory, error) {
+	history, err := uc.historyRepo.GetByID(historyID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := uc.currencyRepo.GetByCode(history.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := history.Confirm(); err != nil {
+		return nil, err
+	}
+
+	history.PreviousRate = currency.ExchangeRate
+	if err := currency.SetExchangeRate(history.Rate); err != nil {
+		return nil, err
+	}
+
+	if err := uc.currencyRepo.Update(currency); err != nil {
+		return nil, err
+	}
+
+	if err := uc.historyRepo.Update(history); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// RejectRate discards a rate that is pending confirmation
+func (uc *ExchangeRateUseCase) RejectRate(historyID uint) (*entity.ExchangeRateHistory, error) {
+	history, err := uc.historyRepo.GetByID(historyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := history.Reject(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.historyRepo.Update(history); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// ListPendingRates lists the rates awaiting admin confirmation
+func (uc *ExchangeRateUseCase) ListPendingRates() ([]*entity.ExchangeRateHistory, error) {
+	return uc.historyRepo.ListPending()
+}
+
+// GetRateHistory lists the rate changes of a currency, newest first
+func (uc *ExchangeRateUseCase) GetRateHistory(currencyCode string, offset, limit int) ([]*entity.ExchangeRateHistory, error) {
+	if currencyCode == "" {
+		return nil, errors.New("currency code is required")
+	}
+
+	return uc.historyRepo.ListByCurrency(strings.ToUpper(currencyCode), offset, limit)
+}
@@ -0,0 +1,140 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func setupExchangeRateUseCase(rates map[string]float64) (*usecase.ExchangeRateUseCase, repository.CurrencyRepository, repository.ExchangeRateHistoryRepository) {
+	currencyRepo := mock.NewMockCurrencyRepository()
+	historyRepo := mock.NewMockExchangeRateHistoryRepository()
+
+	eur, _ := entity.NewCurrency("EUR", "Euro", "€", 0.9, true, false)
+	currencyRepo.Create(eur)
+	dkk, _ := entity.NewCurrency("DKK", "Danish Krone", "kr.", 6.8, true, false)
+	currencyRepo.Create(dkk)
+
+	uc := usecase.NewExchangeRateUseCase(currencyRepo, historyRepo, mock.NewMockRateProvider(rates), 20)
+	return uc, currencyRepo, historyRepo
+}
+
+func TestExchangeRateUseCase_SyncRates(t *testing.T) {
+	t.Run("Apply plausible rates", func(t *testing.T) {
+		// Setup mocks
+		uc, currencyRepo, historyRepo := setupExchangeRateUseCase(map[string]float64{
+			"EUR": 0.92,
+			"DKK": 6.8,
+		})
+
+		// Execute
+		result, err := uc.SyncRates()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, result.Updated, 1)
+		assert.Empty(t, result.Pending)
+		assert.Equal(t, []string{"DKK"}, result.Unchanged)
+
+		eur, _ := currencyRepo.GetByCode("EUR")
+		assert.Equal(t, 0.92, eur.ExchangeRate)
+
+		history, _ := historyRepo.ListByCurrency("EUR", 0, 10)
+		assert.Len(t, history, 1)
+		assert.Equal(t, 0.9, history[0].PreviousRate)
+		assert.Equal(t, "mock", history[0].Source)
+		assert.Equal(t, entity.ExchangeRateStatusApplied, history[0].Status)
+	})
+
+	t.Run("Hold implausible jumps for confirmation", func(t *testing.T) {
+		// Setup mocks
+		uc, currencyRepo, historyRepo := setupExchangeRateUseCase(map[string]float64{
+			"EUR": 1.5,
+			"DKK": 6.9,
+		})
+
+		// Execute
+		result, err := uc.SyncRates()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, result.Updated, 1)
+		assert.Len(t, result.Pending, 1)
+
+		eur, _ := currencyRepo.GetByCode("EUR")
+		assert.Equal(t, 0.9, eur.ExchangeRate)
+
+		pending, _ := historyRepo.ListPending()
+		assert.Len(t, pending, 1)
+		assert.Equal(t, "EUR", pending[0].CurrencyCode)
+		assert.Equal(t, 1.5, pending[0].Rate)
+
+		// A second sync refreshes the existing pending rate
+		_, err = uc.SyncRates()
+		assert.NoError(t, err)
+		pending, _ = historyRepo.ListPending()
+		assert.Len(t, pending, 1)
+	})
+
+	t.Run("Report currencies missing from the feed", func(t *testing.T) {
+		// Setup mocks
+		uc, _, _ := setupExchangeRateUseCase(map[string]float64{
+			"EUR": 0.9,
+		})
+
+		// Execute
+		result, err := uc.SyncRates()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"DKK"}, result.Missing)
+	})
+}
+
+func TestExchangeRateUseCase_ReviewRates(t *testing.T) {
+	t.Run("Confirm pending rate", func(t *testing.T) {
+		// Setup mocks
+		uc, currencyRepo, historyRepo := setupExchangeRateUseCase(map[string]float64{"EUR": 1.5})
+		uc.SyncRates()
+		pending, _ := historyRepo.ListPending()
+
+		// Execute
+		history, err := uc.ConfirmRate(pending[0].ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ExchangeRateStatusApplied, history.Status)
+		assert.NotNil(t, history.ReviewedAt)
+
+		eur, _ := currencyRepo.GetByCode("EUR")
+		assert.Equal(t, 1.5, eur.ExchangeRate)
+
+		// A confirmed rate cannot be confirmed again
+		_, err = uc.ConfirmRate(pending[0].ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Reject pending rate", func(t *testing.T) {
+		// Setup mocks
+		uc, currencyRepo, historyRepo := setupExchangeRateUseCase(map[string]float64{"EUR": 1.5})
+		uc.SyncRates()
+		pending, _ := historyRepo.ListPending()
+
+		// Execute
+		history, err := uc.RejectRate(pending[0].ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ExchangeRateStatusRejected, history.Status)
+
+		eur, _ := currencyRepo.GetByCode("EUR")
+		assert.Equal(t, 0.9, eur.ExchangeRate)
+
+		remaining, _ := historyRepo.ListPending()
+		assert.Empty(t, remaining)
+	})
+}
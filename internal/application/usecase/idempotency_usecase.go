@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+var (
+	// ErrIdempotencyKeyReused is returned when a key is sent with a different request than the one it was first used for
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+	// ErrIdempotencyKeyInProgress is returned when a key is sent again before its first request has finished
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is in progress")
+)
+
+// IdempotencyUseCase implements replaying the responses of requests repeated with the same Idempotency-Key
+type IdempotencyUseCase struct {
+	keyRepo repository.IdempotencyKeyRepository
+	ttl     time.Duration
+}
+
+// NewIdempotencyUseCase creates a new IdempotencyUseCase. Responses are replayed for the TTL.
+func NewIdempotencyUseCase(keyRepo repository.IdempotencyKeyRepository, ttl time.Duration) *IdempotencyUseCase {
+	return &IdempotencyUseCase{
+		keyRepo: keyRepo,
+		ttl:     ttl,
+	}
+}
+
+// BeginRequest starts a request sent with an idempotency key by a client.
+// For a new request it returns the key to finish with FinishRequest once the response is known.
+// For a repeat of a finished request it returns the stored key with replay set, whose response is sent instead.
+func (uc *IdempotencyUseCase) BeginRequest(scope, key, method, path string, body []byte) (idempotencyKey *entity.IdempotencyKey, replay bool, err error) {
+	requestHash := requestFingerprint(method, path, body)
+
+	idempotencyKey, err = entity.NewIdempotencyKey(scope, key, method, path, requestHash, uc.ttl)
+	if err != nil {
+		return nil, false, err
+	}
+
+	created, err := uc.keyRepo.Create(idempotencyKey)
+	if err != nil {
+		return nil, false, err
+	}
+	if created {
+		return idempotencyKey, false, nil
+	}
+
+	existing, err := uc.keyRepo.Get(scope, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if !existing.Matches(method, path, requestHash) {
+		return nil, false, ErrIdempotencyKeyReused
+	}
+	if !existing.IsCompleted() {
+		return nil, false, ErrIdempotencyKeyInProgress
+	}
+
+	return existing, true, nil
+}
+
+// FinishRequest stores the response of a request to replay it to repeats.
+// Server errors are not stored, so the client can retry the request with the same key.
+func (uc *IdempotencyUseCase) FinishRequest(idempotencyKey *entity.IdempotencyKey, statusCode int, contentType string, body []byte) error {
+	if statusCode >= http.StatusInternalServerError {
+		return uc.keyRepo.Delete(idempotencyKey.Scope, idempotencyKey.Key)
+	}
+
+	idempotencyKey.Complete(statusCode, contentType, body)
+	return uc.keyRepo.Complete(idempotencyKey)
+}
+
+// DeleteExpiredKeys deletes the keys whose TTL has passed
+func (uc *IdempotencyUseCase) DeleteExpiredKeys() (int64, error) {
+	return uc.keyRepo.DeleteExpired(time.Now())
+}
+
+// requestFingerprint hashes the method, path and body of a request
+func requestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
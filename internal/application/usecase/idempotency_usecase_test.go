@@ -0,0 +1,128 @@
+package usecase_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func TestIdempotencyUseCase_BeginRequest(t *testing.T) {
+	body := []byte(`{"payment_method":"credit_card"}`)
+
+	t.Run("New request", func(t *testing.T) {
+		// Setup mocks
+		idempotencyUseCase := usecase.NewIdempotencyUseCase(mock.NewMockIdempotencyKeyRepository(), time.Hour)
+
+		// Execute
+		key, replay, err := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", body)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, replay)
+		assert.Equal(t, "key-1", key.Key)
+		assert.False(t, key.IsCompleted())
+	})
+
+	t.Run("Repeated request", func(t *testing.T) {
+		// Setup mocks
+		idempotencyUseCase := usecase.NewIdempotencyUseCase(mock.NewMockIdempotencyKeyRepository(), time.Hour)
+		key, _, _ := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", body)
+		idempotencyUseCase.FinishRequest(key, http.StatusOK, "application/json", []byte(`{"success":true}`))
+
+		// Execute
+		stored, replay, err := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", body)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, replay)
+		assert.Equal(t, http.StatusOK, stored.StatusCode)
+		assert.Equal(t, "application/json", stored.ContentType)
+		assert.Equal(t, `{"success":true}`, string(stored.ResponseBody))
+	})
+
+	t.Run("Different request", func(t *testing.T) {
+		// Setup mocks
+		idempotencyUseCase := usecase.NewIdempotencyUseCase(mock.NewMockIdempotencyKeyRepository(), time.Hour)
+		key, _, _ := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", body)
+		idempotencyUseCase.FinishRequest(key, http.StatusOK, "application/json", []byte(`{"success":true}`))
+
+		// Execute
+		_, _, err := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", []byte(`{"payment_method":"wallet"}`))
+
+		// Assert
+		assert.ErrorIs(t, err, usecase.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("Same key of another client", func(t *testing.T) {
+		// Setup mocks
+		idempotencyUseCase := usecase.NewIdempotencyUseCase(mock.NewMockIdempotencyKeyRepository(), time.Hour)
+		idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", body)
+
+		// Execute
+		_, replay, err := idempotencyUseCase.BeginRequest("other-client", "key-1", http.MethodPost, "/api/orders/2/payment", body)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, replay)
+	})
+
+	t.Run("Request in progress", func(t *testing.T) {
+		// Setup mocks
+		idempotencyUseCase := usecase.NewIdempotencyUseCase(mock.NewMockIdempotencyKeyRepository(), time.Hour)
+		idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", body)
+
+		// Execute
+		_, _, err := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", body)
+
+		// Assert
+		assert.ErrorIs(t, err, usecase.ErrIdempotencyKeyInProgress)
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		// Setup mocks
+		idempotencyUseCase := usecase.NewIdempotencyUseCase(mock.NewMockIdempotencyKeyRepository(), time.Hour)
+		key, _, _ := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", body)
+		idempotencyUseCase.FinishRequest(key, http.StatusBadGateway, "text/plain", []byte("payment provider unavailable"))
+
+		// Execute
+		_, replay, err := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", body)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, replay)
+	})
+
+	t.Run("Empty key", func(t *testing.T) {
+		// Setup mocks
+		idempotencyUseCase := usecase.NewIdempotencyUseCase(mock.NewMockIdempotencyKeyRepository(), time.Hour)
+
+		// Execute
+		_, _, err := idempotencyUseCase.BeginRequest("client", "", http.MethodPost, "/api/orders/1/payment", body)
+
+		// Assert
+		assert.EqualError(t, err, "idempotency key cannot be empty")
+	})
+}
+
+func TestIdempotencyUseCase_DeleteExpiredKeys(t *testing.T) {
+	// Setup mocks
+	idempotencyUseCase := usecase.NewIdempotencyUseCase(mock.NewMockIdempotencyKeyRepository(), time.Millisecond)
+	key, _, _ := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", nil)
+	idempotencyUseCase.FinishRequest(key, http.StatusOK, "application/json", []byte(`{}`))
+	time.Sleep(5 * time.Millisecond)
+
+	// Execute
+	deleted, err := idempotencyUseCase.DeleteExpiredKeys()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	_, replay, err := idempotencyUseCase.BeginRequest("client", "key-1", http.MethodPost, "/api/orders/1/payment", []byte(`{"other":true}`))
+	assert.NoError(t, err)
+	assert.False(t, replay)
+}
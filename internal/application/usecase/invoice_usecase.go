@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// InvoiceUseCase implements invoice and credit note use cases
+type InvoiceUseCase struct {
+	invoiceRepo      repository.InvoiceRepository
+	orderRepo        repository.OrderRepository
+	userRepo         repository.UserRepository
+	renderer         service.InvoiceRenderer
+	emailSvc         service.EmailService
+	invoicePrefix    string
+	creditNotePrefix string
+}
+
+// NewInvoiceUseCase creates a new InvoiceUseCase.
+// Invoice and credit note numbers are formatted with their prefix, e.g. "INV-000042".
+func NewInvoiceUseCase(
+	invoiceRepo repository.InvoiceRepository,
+	orderRepo repository.OrderRepository,
+	userRepo repository.UserRepository,
+	renderer service.InvoiceRenderer,
+	emailSvc service.EmailService,
+	invoicePrefix string,
+	creditNotePrefix string,
+) *InvoiceUseCase {
+	return &InvoiceUseCase{
+		invoiceRepo:      invoiceRepo,
+		orderRepo:        orderRepo,
+		userRepo:         userRepo,
+		renderer:         renderer,
+		emailSvc:         emailSvc,
+		invoicePrefix:    invoicePrefix,
+		creditNotePrefix: creditNotePrefix,
+	}
+}
+
+// IssueInvoice issues the invoice of a paid or captured order and emails it to the customer.
+// An order is invoiced once; issuing it again returns the existing invoice.
+func (uc *InvoiceUseCase) IssueInvoice(order *entity.Order) (*entity.Invoice, error) {
+	if existing, err := uc.invoiceRepo.GetInvoiceByOrderID(order.ID); err == nil {
+		return existing, nil
+	}
+
+	invoice, err := entity.NewInvoice(order)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.invoiceRepo.Create(invoice, uc.invoicePrefix); err != nil {
+		// A concurrent payment update may have invoiced the order first
+		if existing, getErr := uc.invoiceRepo.GetInvoiceByOrderID(order.ID); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	uc.sendDocument(invoice, order)
+
+	return invoice, nil
+}
+
+// IssueCreditNote issues a credit note for a refund of an order and emails it to the customer.
+// Orders paid before they could be invoiced are invoiced first.
+func (uc *InvoiceUseCase) IssueCreditNote(order *entity.Order, amount int64) (*entity.Invoice, error) {
+	invoice, err := uc.IssueInvoice(order)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := uc.invoiceRepo.ListByOrderID(order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var creditedAmount, creditedTax int64
+	for _, document := range documents {
+		if document.IsCreditNote() {
+			creditedAmount += document.Amount
+			creditedTax += document.TaxAmount
+		}
+	}
+
+	if creditedAmount+amount > invoice.Amount {
+		return nil, errors.New("credit notes cannot exceed the invoice amount")
+	}
+
+	creditNote, err := entity.NewCreditNote(invoice, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// The final credit note credits the remaining tax, so rounding never leaves tax uncredited
+	if creditedAmount+amount == invoice.Amount {
+		creditNote.TaxAmount = invoice.TaxAmount - creditedTax
+	}
+
+	if err := uc.invoiceRepo.Create(creditNote, uc.creditNotePrefix); err != nil {
+		return nil, err
+	}
+
+	uc.sendDocument(creditNote, order)
+
+	return creditNote, nil
+}
+
+// GetInvoice retrieves an invoice or credit note by ID
+func (uc *InvoiceUseCase) GetInvoice(invoiceID uint) (*entity.Invoice, error) {
+	return uc.invoiceRepo.GetByID(invoiceID)
+}
+
+// ListOrderInvoices lists the invoice and credit notes of an order
+func (uc *InvoiceUseCase) ListOrderInvoices(orderID uint) ([]*entity.Invoice, error) {
+	return uc.invoiceRepo.ListByOrderID(orderID)
+}
+
+// RenderInvoice renders the document of an invoice or credit note
+func (uc *InvoiceUseCase) RenderInvoice(invoice *entity.Invoice) ([]byte, error) {
+	order, err := uc.orderRepo.GetByID(invoice.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.renderer.Render(invoice, order)
+}
+
+// ContentType returns the media type of rendered invoices
+func (uc *InvoiceUseCase) ContentType() string {
+	return uc.renderer.ContentType()
+}
+
+// sendDocument emails an invoice or credit note to the customer in the background
+func (uc *InvoiceUseCase) sendDocument(invoice *entity.Invoice, order *entity.Order) {
+	if uc.emailSvc == nil {
+		return
+	}
+
+	go func() {
+		recipient, err := uc.recipient(order)
+		if err != nil {
+			log.Printf("Failed to find the recipient of %s: %v\n", invoice.Number, err)
+			return
+		}
+
+		document, err := uc.renderer.Render(invoice, order)
+		if err != nil {
+			log.Printf("Failed to render %s: %v\n", invoice.Number, err)
+			return
+		}
+
+		if err := uc.emailSvc.SendInvoice(order, recipient, invoice, document); err != nil {
+			log.Printf("Failed to send %s: %v\n", invoice.Number, err)
+		}
+	}()
+}
+
+// recipient returns the customer of an order, using the order's details for guests
+func (uc *InvoiceUseCase) recipient(order *entity.Order) (*entity.User, error) {
+	if order.IsGuestOrder || order.UserID == 0 {
+		return &entity.User{
+			Email:     order.CustomerDetails.Email,
+			FirstName: order.CustomerDetails.FullName,
+		}, nil
+	}
+
+	return uc.userRepo.GetByID(order.UserID)
+}
@@ -0,0 +1,235 @@
+package usecase_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/infrastructure/pdf"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func newInvoiceUseCase(orderRepo repository.OrderRepository) *usecase.InvoiceUseCase {
+	return usecase.NewInvoiceUseCase(
+		mock.NewMockInvoiceRepository(),
+		orderRepo,
+		mock.NewMockUserRepository(),
+		pdf.NewInvoiceRenderer(pdf.SellerDetails{Name: "Test Store ApS", VATID: "DK12345678"}, "en-US"),
+		nil,
+		"INV-",
+		"CN-",
+	)
+}
+
+// newPaidOrder creates a saved order of 12500 including 2500 tax
+func newPaidOrder(orderRepo repository.OrderRepository) *entity.Order {
+	order, _ := entity.NewGuestOrder([]entity.OrderItem{
+		{ProductID: 1, Quantity: 2, Price: 5000, Subtotal: 10000, TaxRate: 25, TaxAmount: 2500},
+	}, entity.Address{Street: "Main St 1", City: "Copenhagen", Country: "DK"}, entity.Address{}, entity.CustomerDetails{
+		Email:    "guest@example.com",
+		FullName: "Guest User",
+	})
+	order.TaxAmount = 2500
+	order.FinalAmount = 12500
+	order.Status = entity.OrderStatusPaid
+	orderRepo.Create(order)
+	return order
+}
+
+func TestInvoiceUseCase_IssueInvoice(t *testing.T) {
+	t.Run("Sequential numbers", func(t *testing.T) {
+		orderRepo := mock.NewMockOrderRepository(false)
+		invoiceUseCase := newInvoiceUseCase(orderRepo)
+
+		// Execute
+		first, err1 := invoiceUseCase.IssueInvoice(newPaidOrder(orderRepo))
+		second, err2 := invoiceUseCase.IssueInvoice(newPaidOrder(orderRepo))
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, "INV-000001", first.Number)
+		assert.Equal(t, "INV-000002", second.Number)
+		assert.Equal(t, int64(12500), first.Amount)
+		assert.Equal(t, int64(2500), first.TaxAmount)
+	})
+
+	t.Run("Orders are invoiced once", func(t *testing.T) {
+		orderRepo := mock.NewMockOrderRepository(false)
+		invoiceUseCase := newInvoiceUseCase(orderRepo)
+		order := newPaidOrder(orderRepo)
+
+		// Execute
+		first, _ := invoiceUseCase.IssueInvoice(order)
+		second, err := invoiceUseCase.IssueInvoice(order)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+		invoices, _ := invoiceUseCase.ListOrderInvoices(order.ID)
+		assert.Len(t, invoices, 1)
+	})
+
+	t.Run("Concurrent captures get gap-free numbers", func(t *testing.T) {
+		orderRepo := mock.NewMockOrderRepository(false)
+		invoiceUseCase := newInvoiceUseCase(orderRepo)
+
+		orders := make([]*entity.Order, 20)
+		for i := range orders {
+			orders[i] = newPaidOrder(orderRepo)
+		}
+
+		// Execute: every order is captured twice at the same time
+		var wg sync.WaitGroup
+		numbers := sync.Map{}
+		for _, order := range orders {
+			for range 2 {
+				wg.Add(1)
+				go func(order *entity.Order) {
+					defer wg.Done()
+					invoice, err := invoiceUseCase.IssueInvoice(order)
+					assert.NoError(t, err)
+					numbers.Store(invoice.Number, order.ID)
+				}(order)
+			}
+		}
+		wg.Wait()
+
+		// Assert
+		for i := range orders {
+			_, ok := numbers.Load(fmt.Sprintf("INV-%06d", i+1))
+			assert.True(t, ok, "missing invoice number %d", i+1)
+		}
+		_, ok := numbers.Load(fmt.Sprintf("INV-%06d", len(orders)+1))
+		assert.False(t, ok)
+	})
+
+	t.Run("Orders without an amount", func(t *testing.T) {
+		orderRepo := mock.NewMockOrderRepository(false)
+		invoiceUseCase := newInvoiceUseCase(orderRepo)
+		order := newPaidOrder(orderRepo)
+		order.FinalAmount = 0
+
+		// Execute
+		invoice, err := invoiceUseCase.IssueInvoice(order)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, invoice)
+	})
+}
+
+func TestInvoiceUseCase_IssueCreditNote(t *testing.T) {
+	t.Run("Partial and final refunds", func(t *testing.T) {
+		orderRepo := mock.NewMockOrderRepository(false)
+		invoiceUseCase := newInvoiceUseCase(orderRepo)
+		order := newPaidOrder(orderRepo)
+		invoice, _ := invoiceUseCase.IssueInvoice(order)
+
+		// Execute
+		partial, err1 := invoiceUseCase.IssueCreditNote(order, 3333)
+		final, err2 := invoiceUseCase.IssueCreditNote(order, 12500-3333)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, "CN-000001", partial.Number)
+		assert.Equal(t, "CN-000002", final.Number)
+		assert.Equal(t, invoice.ID, partial.InvoiceID)
+		assert.Equal(t, "INV-000001", partial.InvoiceNumber)
+		assert.Equal(t, int64(667), partial.TaxAmount)
+		assert.Equal(t, int64(2500-667), final.TaxAmount)
+	})
+
+	t.Run("Credit notes cannot exceed the invoice", func(t *testing.T) {
+		orderRepo := mock.NewMockOrderRepository(false)
+		invoiceUseCase := newInvoiceUseCase(orderRepo)
+		order := newPaidOrder(orderRepo)
+		invoiceUseCase.IssueCreditNote(order, 10000)
+
+		// Execute
+		creditNote, err := invoiceUseCase.IssueCreditNote(order, 2501)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, creditNote)
+	})
+
+	t.Run("Uninvoiced orders are invoiced first", func(t *testing.T) {
+		orderRepo := mock.NewMockOrderRepository(false)
+		invoiceUseCase := newInvoiceUseCase(orderRepo)
+		order := newPaidOrder(orderRepo)
+
+		// Execute
+		creditNote, err := invoiceUseCase.IssueCreditNote(order, 12500)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "INV-000001", creditNote.InvoiceNumber)
+		assert.Equal(t, int64(2500), creditNote.TaxAmount)
+	})
+}
+
+func TestInvoiceUseCase_RenderInvoice(t *testing.T) {
+	orderRepo := mock.NewMockOrderRepository(false)
+	invoiceUseCase := newInvoiceUseCase(orderRepo)
+	order := newPaidOrder(orderRepo)
+	order.ReverseCharge = true
+	orderRepo.Update(order)
+	invoice, _ := invoiceUseCase.IssueInvoice(order)
+	creditNote, _ := invoiceUseCase.IssueCreditNote(order, 5000)
+
+	// Execute
+	invoiceDocument, err1 := invoiceUseCase.RenderInvoice(invoice)
+	creditNoteDocument, err2 := invoiceUseCase.RenderInvoice(creditNote)
+
+	// Assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, "application/pdf", invoiceUseCase.ContentType())
+	assert.Contains(t, string(invoiceDocument[:8]), "%PDF-")
+	assert.Contains(t, string(invoiceDocument), "(INV-000001)")
+	assert.Contains(t, string(invoiceDocument), "Reverse charge: VAT to be accounted for by the recipient")
+	assert.Contains(t, string(creditNoteDocument), "(Credit Note)")
+	assert.Contains(t, string(creditNoteDocument), "(INV-000001)")
+}
+
+func TestOrderUseCase_UpdateOrderStatus_IssuesInvoice(t *testing.T) {
+	orderRepo := mock.NewMockOrderRepository(false)
+	invoiceUseCase := newInvoiceUseCase(orderRepo)
+	order := newPaidOrder(orderRepo)
+	order.Status = entity.OrderStatusPendingAction
+	orderRepo.Update(order)
+
+	orderUseCase := usecase.NewOrderUseCase(
+		orderRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		invoiceUseCase,
+		nil,
+	)
+
+	// Execute
+	_, err := orderUseCase.UpdateOrderStatus(usecase.UpdateOrderStatusInput{
+		OrderID: order.ID,
+		Status:  entity.OrderStatusPaid,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	invoices, _ := invoiceUseCase.ListOrderInvoices(order.ID)
+	assert.Len(t, invoices, 1)
+}
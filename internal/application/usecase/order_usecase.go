@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/money"
@@ -14,15 +17,20 @@ import (
 
 // OrderUseCase implements order-related use cases
 type OrderUseCase struct {
-	orderRepo       repository.OrderRepository
-	cartRepo        repository.CartRepository
-	productRepo     repository.ProductRepository
-	userRepo        repository.UserRepository
-	paymentSvc      service.PaymentService
-	emailSvc        service.EmailService
-	paymentTxnRepo  repository.PaymentTransactionRepository
-	shippingUseCase *ShippingUseCase
-	currencyRepo    repository.CurrencyRepository
+	orderRepo            repository.OrderRepository
+	cartRepo             repository.CartRepository
+	productRepo          repository.ProductRepository
+	userRepo             repository.UserRepository
+	paymentSvc           service.PaymentService
+	emailSvc             service.EmailService
+	paymentTxnRepo       repository.PaymentTransactionRepository
+	shippingUseCase      *ShippingUseCase
+	currencyRepo         repository.CurrencyRepository
+	discountUseCase      *DiscountUseCase
+	priceListUseCase     *PriceListUseCase
+	taxUseCase           *TaxUseCase
+	invoiceUseCase       *InvoiceUseCase
+	paymentMethodUseCase *PaymentMethodUseCase
 }
 
 // NewOrderUseCase creates a new OrderUseCase
@@ -36,17 +44,27 @@ func NewOrderUseCase(
 	paymentTxnRepo repository.PaymentTransactionRepository,
 	shippingUseCase *ShippingUseCase,
 	currencyRepo repository.CurrencyRepository,
+	discountUseCase *DiscountUseCase,
+	priceListUseCase *PriceListUseCase,
+	taxUseCase *TaxUseCase,
+	invoiceUseCase *InvoiceUseCase,
+	paymentMethodUseCase *PaymentMethodUseCase,
 ) *OrderUseCase {
 	return &OrderUseCase{
-		orderRepo:       orderRepo,
-		cartRepo:        cartRepo,
-		productRepo:     productRepo,
-		userRepo:        userRepo,
-		paymentSvc:      paymentSvc,
-		emailSvc:        emailSvc,
-		paymentTxnRepo:  paymentTxnRepo,
-		shippingUseCase: shippingUseCase,
-		currencyRepo:    currencyRepo,
+		orderRepo:            orderRepo,
+		cartRepo:             cartRepo,
+		productRepo:          productRepo,
+		userRepo:             userRepo,
+		paymentSvc:           paymentSvc,
+		emailSvc:             emailSvc,
+		paymentTxnRepo:       paymentTxnRepo,
+		shippingUseCase:      shippingUseCase,
+		currencyRepo:         currencyRepo,
+		discountUseCase:      discountUseCase,
+		priceListUseCase:     priceListUseCase,
+		taxUseCase:           taxUseCase,
+		invoiceUseCase:       invoiceUseCase,
+		paymentMethodUseCase: paymentMethodUseCase,
 	}
 }
 
@@ -64,7 +82,11 @@ type CreateOrderInput struct {
 	Email            string         `json:"email,omitempty"`
 	PhoneNumber      string         `json:"phone_number,omitempty"`
 	FullName         string         `json:"full_name,omitempty"`
+	CompanyName      string         `json:"company_name,omitempty"`
+	VATID            string         `json:"vat_id,omitempty"` // EU VAT number for reverse charge
 	ShippingMethodID uint           `json:"shipping_method_id"`
+	PickupPointID    string         `json:"pickup_point_id,omitempty"` // required by pickup point methods
+	CurrencyCode     string         `json:"currency"`                  // defaults to the default currency
 }
 
 // CreateOrderFromCart creates an order from a user's cart
@@ -104,13 +126,30 @@ func (uc *OrderUseCase) createOrderFromUserCart(input CreateOrderInput) (*entity
 		return nil, errors.New("user not found")
 	}
 
+	// Resolve the currency the order is placed in
+	currency, defaultCurrency, err := uc.resolveOrderCurrency(input.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the prices of the user's customer group
+	var pricing *CustomerPricing
+	if uc.priceListUseCase != nil {
+		pricing, err = uc.priceListUseCase.PricingForUser(user.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Convert cart items to order items
 	orderItems := make([]entity.OrderItem, 0, len(cart.Items))
+	packingItems := make([]entity.PackingItem, 0, len(cart.Items))
+	reservations := make([]stockReservation, 0, len(cart.Items))
 	totalWeight := 0.0
 
 	for _, cartItem := range cart.Items {
 		// Get product to get current price
-		product, err := uc.productRepo.GetByID(cartItem.ProductID)
+		product, err := uc.productRepo.GetByIDWithVariants(cartItem.ProductID)
 		if err != nil {
 			return nil, fmt.Errorf("product not found: ProductID=%d", cartItem.ProductID)
 		}
@@ -120,40 +159,44 @@ func (uc *OrderUseCase) createOrderFromUserCart(input CreateOrderInput) (*entity
 			return nil, errors.New("insufficient stock for product: " + product.Name)
 		}
 
+		// TODO: Check for variant and assign variant ID
+		variant := product.GetVariantByID(cartItem.ProductVariantID)
+
+		// Price the item in the order currency, applying customer group prices
+		price := priceInCurrency(product, variant, currency, defaultCurrency)
+		price = pricing.Price(currency.Code, cartItem.ProductID, cartItem.ProductVariantID, cartItem.Quantity, price)
+
 		// Create order item with weight
 		orderItem := entity.OrderItem{
 			ProductID:   cartItem.ProductID,
 			Quantity:    cartItem.Quantity,
-			Price:       product.Price,
-			Subtotal:    int64(cartItem.Quantity) * product.Price,
+			Price:       price,
+			Subtotal:    int64(cartItem.Quantity) * price,
 			Weight:      product.Weight,
 			ProductName: product.Name,
+			TaxClassID:  product.TaxClassID,
 		}
 
-		// TODO: Check for variant and assign variant ID
 		// If this is a variant, store the variant ID
-		variant := product.GetVariantByID(cartItem.ProductVariantID)
 		if variant != nil {
 			orderItem.SKU = variant.SKU
 		}
 
 		orderItems = append(orderItems, orderItem)
+		packingItems = append(packingItems, packingItem(product, variant, cartItem.Quantity))
 		totalWeight += product.Weight * float64(cartItem.Quantity)
 
-		// Update product stock
-		if err := product.UpdateStock(-cartItem.Quantity); err != nil {
-			return nil, err
-		}
-		if err := uc.productRepo.Update(product); err != nil {
-			return nil, err
-		}
+		// Take the item from stock once the order is valid
+		reservations = addStockReservation(reservations, product, cartItem.Quantity)
 	}
 
 	// Create order
 	order, err := entity.NewOrder(input.UserID, orderItems, input.ShippingAddr, input.BillingAddr, entity.CustomerDetails{
-		Email:    input.Email,
-		Phone:    input.PhoneNumber,
-		FullName: input.FullName,
+		Email:       input.Email,
+		Phone:       input.PhoneNumber,
+		FullName:    input.FullName,
+		CompanyName: input.CompanyName,
+		VATID:       entity.NormalizeVATID(input.VATID),
 	})
 	if err != nil {
 		return nil, err
@@ -162,6 +205,11 @@ func (uc *OrderUseCase) createOrderFromUserCart(input CreateOrderInput) (*entity
 	// Set the total weight
 	order.TotalWeight = totalWeight
 
+	// Snapshot the currency and its exchange rate at order time
+	if err := order.SetCurrency(currency.Code, currency.ExchangeRate/defaultCurrency.ExchangeRate); err != nil {
+		return nil, err
+	}
+
 	// Set shipping method ID
 	order.ShippingMethodID = input.ShippingMethodID
 
@@ -172,22 +220,65 @@ func (uc *OrderUseCase) createOrderFromUserCart(input CreateOrderInput) (*entity
 			return nil, errors.New("shipping method not found")
 		}
 
-		// Calculate shipping cost
-		shippingCost, err := uc.shippingUseCase.GetShippingCost(input.ShippingMethodID, order.TotalAmount, order.TotalWeight)
+		// Deliver to the chosen pickup point instead of the customer's address
+		if err := uc.applyPickupPoint(order, shippingMethod, input.PickupPointID); err != nil {
+			return nil, err
+		}
+
+		// Pack the items for the warehouse, rates apply to the billable weight of the packages
+		packages, billableWeight, err := uc.shippingUseCase.PackItems(packingItems)
+		if err != nil {
+			return nil, fmt.Errorf("error packing order: %v", err)
+		}
+		order.Packages = packages
+
+		// The method must be allowed for the items and weight of the order
+		if err := uc.shippingUseCase.CheckRestrictions(shippingMethod, packingItems, billableWeight); err != nil {
+			return nil, err
+		}
+
+		// Calculate shipping cost, rates and carrier quotes are in the default currency
+		shipment := service.CarrierShipment{To: order.ShippingAddr, Packages: packages, Weight: billableWeight}
+		shippingCost, err := uc.shippingUseCase.QuoteShippingCost(input.ShippingMethodID, order.ToBaseAmount(order.TotalAmount), shipment)
 		if err != nil {
 			return nil, fmt.Errorf("error calculating shipping cost: %v", err)
 		}
 
 		// Apply shipping method and cost to order
-		if err := order.SetShippingMethod(shippingMethod, shippingCost); err != nil {
+		if err := order.SetShippingMethod(shippingMethod, order.FromBaseAmount(shippingCost), nil, nil); err != nil {
 			return nil, err
 		}
+
+		// Store the delivery window shown for the option at checkout
+		estimate, err := uc.shippingUseCase.EstimateDelivery(shippingMethod, order.ShippingAddr, order.ToBaseAmount(order.TotalAmount), time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("error estimating delivery: %v", err)
+		}
+		order.EstimatedDelivery = estimate
 	}
 
-	// Save order
+	// Carry over the discount code attached to the cart
+	discount, err := uc.applyCartDiscount(cart, order)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate the tax of the discounted items and shipping
+	if uc.taxUseCase != nil {
+		if err := uc.taxUseCase.ApplyOrderTax(order); err != nil {
+			return nil, fmt.Errorf("error calculating tax: %v", err)
+		}
+	}
+
+	// Take the items from stock and save order
+	if err := uc.reserveStock(reservations); err != nil {
+		return nil, err
+	}
 	if err := uc.orderRepo.Create(order); err != nil {
+		uc.releaseStock(reservations)
 		return nil, err
 	}
+	uc.recordCartDiscountUsage(discount, order)
 
 	// Clear cart after successful order creation
 	cart.Clear()
@@ -234,13 +325,21 @@ func (uc *OrderUseCase) createOrderFromGuestCart(input CreateOrderInput) (*entit
 		return nil, errors.New("cart is empty")
 	}
 
+	// Resolve the currency the order is placed in
+	currency, defaultCurrency, err := uc.resolveOrderCurrency(input.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert cart items to order items
 	orderItems := make([]entity.OrderItem, 0, len(cart.Items))
+	packingItems := make([]entity.PackingItem, 0, len(cart.Items))
+	reservations := make([]stockReservation, 0, len(cart.Items))
 	totalWeight := 0.0
 
 	for _, cartItem := range cart.Items {
 		// Get product to get current price
-		product, err := uc.productRepo.GetByID(cartItem.ProductID)
+		product, err := uc.productRepo.GetByIDWithVariants(cartItem.ProductID)
 		if err != nil {
 			return nil, fmt.Errorf("product not found: ProductID=%d", cartItem.ProductID)
 		}
@@ -250,38 +349,41 @@ func (uc *OrderUseCase) createOrderFromGuestCart(input CreateOrderInput) (*entit
 			return nil, errors.New("insufficient stock for product: " + product.Name)
 		}
 
+		// Price the item in the order currency
+		variant := product.GetVariantByID(cartItem.ProductVariantID)
+		price := priceInCurrency(product, variant, currency, defaultCurrency)
+
 		// Calculate item weight
 		itemWeight := product.Weight
 
 		// Create order item with weight
 		orderItem := entity.OrderItem{
-			ProductID: cartItem.ProductID,
-			Quantity:  cartItem.Quantity,
-			Price:     product.Price,
-			Subtotal:  int64(cartItem.Quantity) * product.Price,
-			Weight:    itemWeight,
+			ProductID:  cartItem.ProductID,
+			Quantity:   cartItem.Quantity,
+			Price:      price,
+			Subtotal:   int64(cartItem.Quantity) * price,
+			Weight:     itemWeight,
+			TaxClassID: product.TaxClassID,
 		}
 
 		// If this is a variant, store the variant ID
 		orderItem.ProductID = cartItem.ProductID
 
 		orderItems = append(orderItems, orderItem)
+		packingItems = append(packingItems, packingItem(product, variant, cartItem.Quantity))
 		totalWeight += itemWeight * float64(cartItem.Quantity)
 
-		// Update product stock
-		if err := product.UpdateStock(-cartItem.Quantity); err != nil {
-			return nil, err
-		}
-		if err := uc.productRepo.Update(product); err != nil {
-			return nil, err
-		}
+		// Take the item from stock once the order is valid
+		reservations = addStockReservation(reservations, product, cartItem.Quantity)
 	}
 
 	// Create guest order (0 as UserID indicates a guest order)
 	order, err := entity.NewGuestOrder(orderItems, input.ShippingAddr, input.BillingAddr, entity.CustomerDetails{
-		Email:    input.Email,
-		Phone:    input.PhoneNumber,
-		FullName: input.FullName,
+		Email:       input.Email,
+		Phone:       input.PhoneNumber,
+		FullName:    input.FullName,
+		CompanyName: input.CompanyName,
+		VATID:       entity.NormalizeVATID(input.VATID),
 	})
 	if err != nil {
 		return nil, err
@@ -290,6 +392,11 @@ func (uc *OrderUseCase) createOrderFromGuestCart(input CreateOrderInput) (*entit
 	// Set the total weight
 	order.TotalWeight = totalWeight
 
+	// Snapshot the currency and its exchange rate at order time
+	if err := order.SetCurrency(currency.Code, currency.ExchangeRate/defaultCurrency.ExchangeRate); err != nil {
+		return nil, err
+	}
+
 	// Set shipping method ID
 	order.ShippingMethodID = input.ShippingMethodID
 
@@ -300,22 +407,65 @@ func (uc *OrderUseCase) createOrderFromGuestCart(input CreateOrderInput) (*entit
 			return nil, errors.New("shipping method not found")
 		}
 
-		// Calculate shipping cost
-		shippingCost, err := uc.shippingUseCase.GetShippingCost(input.ShippingMethodID, order.TotalAmount, order.TotalWeight)
+		// Deliver to the chosen pickup point instead of the customer's address
+		if err := uc.applyPickupPoint(order, shippingMethod, input.PickupPointID); err != nil {
+			return nil, err
+		}
+
+		// Pack the items for the warehouse, rates apply to the billable weight of the packages
+		packages, billableWeight, err := uc.shippingUseCase.PackItems(packingItems)
+		if err != nil {
+			return nil, fmt.Errorf("error packing order: %v", err)
+		}
+		order.Packages = packages
+
+		// The method must be allowed for the items and weight of the order
+		if err := uc.shippingUseCase.CheckRestrictions(shippingMethod, packingItems, billableWeight); err != nil {
+			return nil, err
+		}
+
+		// Calculate shipping cost, rates and carrier quotes are in the default currency
+		shipment := service.CarrierShipment{To: order.ShippingAddr, Packages: packages, Weight: billableWeight}
+		shippingCost, err := uc.shippingUseCase.QuoteShippingCost(input.ShippingMethodID, order.ToBaseAmount(order.TotalAmount), shipment)
 		if err != nil {
 			return nil, fmt.Errorf("error calculating shipping cost: %v", err)
 		}
 
 		// Apply shipping method and cost to order
-		if err := order.SetShippingMethod(shippingMethod, shippingCost); err != nil {
+		if err := order.SetShippingMethod(shippingMethod, order.FromBaseAmount(shippingCost), nil, nil); err != nil {
 			return nil, err
 		}
+
+		// Store the delivery window shown for the option at checkout
+		estimate, err := uc.shippingUseCase.EstimateDelivery(shippingMethod, order.ShippingAddr, order.ToBaseAmount(order.TotalAmount), time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("error estimating delivery: %v", err)
+		}
+		order.EstimatedDelivery = estimate
 	}
 
-	// Save order
+	// Carry over the discount code attached to the cart
+	discount, err := uc.applyCartDiscount(cart, order)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate the tax of the discounted items and shipping
+	if uc.taxUseCase != nil {
+		if err := uc.taxUseCase.ApplyOrderTax(order); err != nil {
+			return nil, fmt.Errorf("error calculating tax: %v", err)
+		}
+	}
+
+	// Take the items from stock and save order
+	if err := uc.reserveStock(reservations); err != nil {
+		return nil, err
+	}
 	if err := uc.orderRepo.Create(order); err != nil {
+		uc.releaseStock(reservations)
 		return nil, err
 	}
+	uc.recordCartDiscountUsage(discount, order)
 
 	// Clear cart after successful order creation
 	cart.Clear()
@@ -346,6 +496,145 @@ func (uc *OrderUseCase) createOrderFromGuestCart(input CreateOrderInput) (*entit
 	return order, nil
 }
 
+// stockReservation is the quantity of a product taken from stock by an order
+type stockReservation struct {
+	product  *entity.Product
+	quantity int
+}
+
+// addStockReservation adds the quantity of a product to the reservations of an order
+func addStockReservation(reservations []stockReservation, product *entity.Product, quantity int) []stockReservation {
+	for i := range reservations {
+		if reservations[i].product.ID == product.ID {
+			reservations[i].quantity += quantity
+			return reservations
+		}
+	}
+	return append(reservations, stockReservation{product: product, quantity: quantity})
+}
+
+// reserveStock takes the reserved quantities from stock, restoring them all if one fails
+func (uc *OrderUseCase) reserveStock(reservations []stockReservation) error {
+	for i, reservation := range reservations {
+		if err := reservation.product.UpdateStock(-reservation.quantity); err != nil {
+			uc.releaseStock(reservations[:i])
+			return errors.New("insufficient stock for product: " + reservation.product.Name)
+		}
+		if err := uc.productRepo.Update(reservation.product); err != nil {
+			reservation.product.UpdateStock(reservation.quantity)
+			uc.releaseStock(reservations[:i])
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseStock puts the reserved quantities back in stock
+func (uc *OrderUseCase) releaseStock(reservations []stockReservation) {
+	for _, reservation := range reservations {
+		reservation.product.UpdateStock(reservation.quantity)
+		if err := uc.productRepo.Update(reservation.product); err != nil {
+			log.Printf("Failed to restore stock of product %d: %v", reservation.product.ID, err)
+		}
+	}
+}
+
+// applyPickupPoint delivers an order shipped with a pickup point method to the chosen pickup point
+func (uc *OrderUseCase) applyPickupPoint(order *entity.Order, method *entity.ShippingMethod, pickupPointID string) error {
+	if !method.PickupPoint {
+		if pickupPointID != "" {
+			return errors.New("shipping method does not deliver to pickup points")
+		}
+		return nil
+	}
+
+	point, err := uc.shippingUseCase.GetPickupPoint(method, pickupPointID)
+	if err != nil {
+		return err
+	}
+	return order.SetPickupPoint(point)
+}
+
+// resolveOrderCurrency returns the currency an order is placed in together with the default currency.
+// An empty currency code selects the default currency.
+func (uc *OrderUseCase) resolveOrderCurrency(currencyCode string) (*entity.Currency, *entity.Currency, error) {
+	defaultCurrency, err := uc.currencyRepo.GetDefault()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get default currency: %w", err)
+	}
+
+	if currencyCode == "" || strings.EqualFold(currencyCode, defaultCurrency.Code) {
+		return defaultCurrency, defaultCurrency, nil
+	}
+
+	currency, err := uc.currencyRepo.GetByCode(strings.ToUpper(currencyCode))
+	if err != nil || !currency.IsEnabled {
+		return nil, nil, errors.New("invalid currency code: " + currencyCode)
+	}
+
+	return currency, defaultCurrency, nil
+}
+
+// orderCurrencyCode returns the currency of an order.
+// Orders without a stored currency were placed in the default currency.
+func (uc *OrderUseCase) orderCurrencyCode(order *entity.Order) (string, error) {
+	if order.Currency != "" {
+		return order.Currency, nil
+	}
+
+	defaultCurrency, err := uc.currencyRepo.GetDefault()
+	if err != nil {
+		return "", fmt.Errorf("failed to get default currency: %w", err)
+	}
+
+	return defaultCurrency.Code, nil
+}
+
+// priceInCurrency returns the price of a product, or one of its variants, in the given currency.
+// Prices without an explicit amount in that currency are converted from the default currency.
+func priceInCurrency(product *entity.Product, variant *entity.ProductVariant, currency, defaultCurrency *entity.Currency) int64 {
+	var price int64
+	var found bool
+	if variant != nil {
+		price, found = variant.GetPriceInCurrency(currency.Code)
+	} else {
+		price, found = product.GetPriceInCurrency(currency.Code)
+	}
+
+	if found {
+		return price
+	}
+
+	return defaultCurrency.ConvertAmount(price, currency)
+}
+
+// applyCartDiscount applies the discount code attached to the cart to a new order before it is saved.
+// The checkout fails when the code can no longer be applied, so the customer isn't charged more than
+// the cart showed.
+func (uc *OrderUseCase) applyCartDiscount(cart *entity.Cart, order *entity.Order) (*entity.Discount, error) {
+	if cart.DiscountCode == "" || uc.discountUseCase == nil {
+		return nil, nil
+	}
+
+	discount, err := uc.discountUseCase.ApplyDiscountToNewOrder(cart.DiscountCode, order)
+	if err != nil {
+		return nil, fmt.Errorf("discount code %s can no longer be applied: %v", cart.DiscountCode, err)
+	}
+
+	return discount, nil
+}
+
+// recordCartDiscountUsage counts the use of the cart's discount once the order is saved
+func (uc *OrderUseCase) recordCartDiscountUsage(discount *entity.Discount, order *entity.Order) {
+	if discount == nil {
+		return
+	}
+
+	if err := uc.discountUseCase.RecordDiscountUsage(discount); err != nil {
+		log.Printf("Failed to record usage of discount %s for order %d: %v", discount.Code, order.ID, err)
+	}
+}
+
 // ProcessPaymentInput contains the data needed to process a payment
 type ProcessPaymentInput struct {
 	OrderID         uint
@@ -356,6 +645,10 @@ type ProcessPaymentInput struct {
 	BankDetails     *service.BankDetails
 	CustomerEmail   string
 	PhoneNumber     string
+
+	SavedPaymentMethodID uint // charge a card the user saved instead of entering one
+	SavePaymentMethod    bool // save the entered card for later payments
+	IdempotencyKey       string
 }
 
 // ProcessPayment processes payment for an order
@@ -386,17 +679,16 @@ func (uc *OrderUseCase) ProcessPayment(input ProcessPaymentInput) (*entity.Order
 		return nil, errors.New("payment provider not available")
 	}
 
-	// Get default currency
-	defaultCurrency, err := uc.currencyRepo.GetDefault()
+	// Payments are made in the currency the order was placed in
+	currencyCode, err := uc.orderCurrencyCode(order)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get default currency: %w", err)
+		return nil, err
 	}
 
-	// Process payment
-	paymentResult, err := uc.paymentSvc.ProcessPayment(service.PaymentRequest{
+	request := service.PaymentRequest{
 		OrderID:         order.ID,
 		Amount:          order.FinalAmount, // Use final amount (after discounts)
-		Currency:        defaultCurrency.Code,
+		Currency:        currencyCode,
 		PaymentMethod:   input.PaymentMethod,
 		PaymentProvider: input.PaymentProvider,
 		CardDetails:     input.CardDetails,
@@ -404,8 +696,19 @@ func (uc *OrderUseCase) ProcessPayment(input ProcessPaymentInput) (*entity.Order
 		BankDetails:     input.BankDetails,
 		CustomerEmail:   input.CustomerEmail,
 		PhoneNumber:     input.PhoneNumber,
-	})
+		LineItems:       paymentLineItems(order),
+		ShippingName:    shippingName(order),
+		ShippingAmount:  order.ShippingCost - order.ShippingDiscountAmount,
+		DiscountAmount:  order.DiscountAmount,
+		TaxAmount:       chargedTax(order),
+		IdempotencyKey:  providerIdempotencyKey(order, input.IdempotencyKey),
+	}
+	if err := uc.applyPaymentCustomer(order, input, &request); err != nil {
+		return nil, err
+	}
 
+	// Process payment
+	paymentResult, err := uc.paymentSvc.ProcessPayment(request)
 	if err != nil {
 		return nil, err
 	}
@@ -438,7 +741,7 @@ func (uc *OrderUseCase) ProcessPayment(input ProcessPaymentInput) (*entity.Order
 			entity.TransactionTypeAuthorize,
 			entity.TransactionStatusPending,
 			order.FinalAmount,
-			defaultCurrency.Code,
+			currencyCode,
 			string(paymentResult.Provider),
 		)
 		if err != nil {
@@ -467,7 +770,7 @@ func (uc *OrderUseCase) ProcessPayment(input ProcessPaymentInput) (*entity.Order
 			entity.TransactionTypeAuthorize,
 			entity.TransactionStatusFailed,
 			order.FinalAmount,
-			defaultCurrency.Code,
+			currencyCode,
 			string(paymentResult.Provider),
 		)
 		if err == nil {
@@ -496,6 +799,7 @@ func (uc *OrderUseCase) ProcessPayment(input ProcessPaymentInput) (*entity.Order
 	if err := order.UpdateStatus(entity.OrderStatusPaid); err != nil {
 		return nil, err
 	}
+	uc.authorize(order)
 
 	// Update order in repository
 	if err := uc.orderRepo.Update(order); err != nil {
@@ -509,7 +813,7 @@ func (uc *OrderUseCase) ProcessPayment(input ProcessPaymentInput) (*entity.Order
 		entity.TransactionTypeAuthorize,
 		entity.TransactionStatusSuccessful,
 		order.FinalAmount,
-		defaultCurrency.Code,
+		currencyCode,
 		string(paymentResult.Provider),
 	)
 	if err == nil {
@@ -520,6 +824,261 @@ func (uc *OrderUseCase) ProcessPayment(input ProcessPaymentInput) (*entity.Order
 		}
 	}
 
+	uc.issueInvoice(order)
+
+	return order, nil
+}
+
+// applyPaymentCustomer adds the user's provider customer and the saved payment method to charge to a payment request.
+// Guests pay without a customer, and can't use or save payment methods.
+func (uc *OrderUseCase) applyPaymentCustomer(order *entity.Order, input ProcessPaymentInput, request *service.PaymentRequest) error {
+	if order.UserID == 0 || uc.paymentMethodUseCase == nil || input.PaymentProvider != service.PaymentProviderStripe {
+		if input.SavedPaymentMethodID != 0 || input.SavePaymentMethod {
+			return errors.New("saved payment methods are not available for this payment")
+		}
+		return nil
+	}
+
+	customerID, err := uc.paymentMethodUseCase.CustomerID(order.UserID, input.PaymentProvider)
+	if err != nil {
+		if input.SavedPaymentMethodID != 0 || input.SavePaymentMethod {
+			return err
+		}
+		// Continue with payment, just without customer association
+		log.Printf("Failed to get payment customer of user %d: %v", order.UserID, err)
+		return nil
+	}
+	request.CustomerID = customerID
+	request.SavePaymentMethod = input.SavePaymentMethod
+
+	if input.SavedPaymentMethodID != 0 {
+		method, err := uc.paymentMethodUseCase.PaymentMethodForPayment(order.UserID, input.SavedPaymentMethodID, input.PaymentProvider)
+		if err != nil {
+			return err
+		}
+		request.SavedPaymentMethodID = method.ProviderMethodID
+	}
+
+	return nil
+}
+
+// providerIdempotencyKey scopes a client's idempotency key to the order, as keys are shared by all
+// clients at the payment provider
+func providerIdempotencyKey(order *entity.Order, key string) string {
+	if key == "" {
+		return ""
+	}
+	return fmt.Sprintf("order-%d-%s", order.ID, key)
+}
+
+// CreatePaymentIntentInput contains the data needed to create a payment intent for an order
+type CreatePaymentIntentInput struct {
+	OrderID           uint
+	CustomerEmail     string
+	SavePaymentMethod bool // keep the card for later payments
+	IdempotencyKey    string
+}
+
+// CreatePaymentIntent creates a Stripe payment intent for an order, which the storefront confirms with Stripe.js.
+// The order waits for the payment_intent webhooks to mark it paid.
+func (uc *OrderUseCase) CreatePaymentIntent(input CreatePaymentIntentInput) (*payment.ClientIntent, error) {
+	order, err := uc.orderRepo.GetByID(input.OrderID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	if order.Status != entity.OrderStatusPending && order.Status != entity.OrderStatusPendingAction {
+		return nil, errors.New("order is already paid")
+	}
+
+	paymentSvc, ok := uc.paymentSvc.(*payment.MultiProviderPaymentService)
+	if !ok {
+		return nil, errors.New("invalid payment service")
+	}
+
+	currencyCode, err := uc.orderCurrencyCode(order)
+	if err != nil {
+		return nil, err
+	}
+
+	request := service.PaymentRequest{
+		OrderID:         order.ID,
+		Amount:          order.FinalAmount,
+		Currency:        currencyCode,
+		PaymentMethod:   service.PaymentMethodCreditCard,
+		PaymentProvider: service.PaymentProviderStripe,
+		CustomerEmail:   input.CustomerEmail,
+		IdempotencyKey:  providerIdempotencyKey(order, input.IdempotencyKey),
+	}
+
+	// Cards of registered users are saved to their customer
+	if order.UserID != 0 && uc.paymentMethodUseCase != nil {
+		customerID, err := uc.paymentMethodUseCase.CustomerID(order.UserID, service.PaymentProviderStripe)
+		if err != nil {
+			return nil, err
+		}
+		request.CustomerID = customerID
+	}
+
+	intent, err := paymentSvc.CreatePaymentIntent(request, input.SavePaymentMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := order.SetPaymentID(intent.ID); err != nil {
+		return nil, err
+	}
+	if err := order.SetPaymentProvider(string(service.PaymentProviderStripe)); err != nil {
+		return nil, err
+	}
+	if err := order.SetPaymentMethod(string(service.PaymentMethodCreditCard)); err != nil {
+		return nil, err
+	}
+	if order.Status == entity.OrderStatusPending {
+		if err := order.UpdateStatus(entity.OrderStatusPendingAction); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.orderRepo.Update(order); err != nil {
+		return nil, err
+	}
+
+	// Record the pending authorization, completed by the payment_intent webhooks
+	txn, err := entity.NewPaymentTransaction(
+		order.ID,
+		intent.ID,
+		entity.TransactionTypeAuthorize,
+		entity.TransactionStatusPending,
+		order.FinalAmount,
+		currencyCode,
+		string(service.PaymentProviderStripe),
+	)
+	if err == nil {
+		txn.AddMetadata("payment_method", string(service.PaymentMethodCreditCard))
+		txn.AddMetadata("client_confirmation", "true")
+
+		if err := uc.paymentTxnRepo.Create(txn); err != nil {
+			log.Printf("Failed to save payment transaction: %v\n", err)
+		}
+	}
+
+	return intent, nil
+}
+
+// CreateSetupIntent creates a Stripe setup intent for a user saving a card without paying
+func (uc *OrderUseCase) CreateSetupIntent(userID uint) (*payment.ClientIntent, error) {
+	paymentSvc, ok := uc.paymentSvc.(*payment.MultiProviderPaymentService)
+	if !ok || uc.paymentMethodUseCase == nil {
+		return nil, errors.New("invalid payment service")
+	}
+
+	customerID, err := uc.paymentMethodUseCase.CustomerID(userID, service.PaymentProviderStripe)
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentSvc.CreateSetupIntent(customerID)
+}
+
+// paymentLineItems lists the order's items for hosted payment pages
+func paymentLineItems(order *entity.Order) []service.PaymentLineItem {
+	items := make([]service.PaymentLineItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, service.PaymentLineItem{
+			Name:       item.ProductName,
+			SKU:        item.SKU,
+			Quantity:   item.Quantity,
+			UnitAmount: item.Price,
+		})
+	}
+	return items
+}
+
+func shippingName(order *entity.Order) string {
+	if order.ShippingMethod == nil {
+		return ""
+	}
+	return order.ShippingMethod.Name
+}
+
+// chargedTax returns the tax charged on top of the order's prices
+func chargedTax(order *entity.Order) int64 {
+	if order.PricesIncludeTax {
+		return 0
+	}
+	return order.TaxAmount
+}
+
+// CompleteHostedPayment marks an order paid once the customer has paid on a hosted payment page.
+// The session the customer was redirected to is replaced by the payment it produced,
+// so the payment can be captured, refunded and cancelled like any other.
+func (uc *OrderUseCase) CompleteHostedPayment(orderID uint, sessionID, paymentID string) (*entity.Order, error) {
+	order, err := uc.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	if order.PaymentID != sessionID && order.PaymentID != paymentID {
+		return nil, errors.New("payment session does not belong to the order")
+	}
+
+	if err := order.SetPaymentID(paymentID); err != nil {
+		return nil, err
+	}
+
+	// Payment webhooks may have marked the order paid already
+	paid := order.Status == entity.OrderStatusPendingAction || order.Status == entity.OrderStatusPending
+	if paid {
+		if err := order.UpdateStatus(entity.OrderStatusPaid); err != nil {
+			return nil, err
+		}
+		uc.authorize(order)
+	}
+
+	if err := uc.orderRepo.Update(order); err != nil {
+		return nil, err
+	}
+
+	if err := uc.UpdatePaymentTransaction(sessionID, entity.TransactionStatusSuccessful, map[string]string{
+		"payment_id": paymentID,
+	}); err != nil {
+		log.Printf("Failed to update payment transaction for session %s: %v", sessionID, err)
+	}
+
+	if paid {
+		uc.issueInvoice(order)
+	}
+
+	return order, nil
+}
+
+// ExpireHostedPayment cancels an order whose hosted payment page expired before the customer paid
+func (uc *OrderUseCase) ExpireHostedPayment(orderID uint, sessionID string) (*entity.Order, error) {
+	order, err := uc.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	// The customer may have started another payment for the order
+	if order.PaymentID != sessionID || order.Status != entity.OrderStatusPendingAction {
+		return order, nil
+	}
+
+	if err := order.UpdateStatus(entity.OrderStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	if err := uc.orderRepo.Update(order); err != nil {
+		return nil, err
+	}
+
+	if err := uc.UpdatePaymentTransaction(sessionID, entity.TransactionStatusFailed, map[string]string{
+		"error_message": "payment session expired",
+	}); err != nil {
+		log.Printf("Failed to update payment transaction for session %s: %v", sessionID, err)
+	}
+
 	return order, nil
 }
 
@@ -542,11 +1101,31 @@ func (uc *OrderUseCase) UpdateOrderStatus(input UpdateOrderStatusInput) (*entity
 		return nil, err
 	}
 
+	// Payments captured outside the store, e.g. in the provider's dashboard, settle the whole authorization
+	switch input.Status {
+	case entity.OrderStatusPaid:
+		uc.authorize(order)
+	case entity.OrderStatusCaptured:
+		order.SettleAuthorization()
+	}
+
+	// Buy the carrier label when the order ships, so the warehouse can print it
+	if input.Status == entity.OrderStatusShipped && order.ShippingLabel == nil && uc.shippingUseCase != nil {
+		if _, err := uc.shippingUseCase.CreateShippingLabel(order); err != nil {
+			return nil, fmt.Errorf("failed to create shipping label: %w", err)
+		}
+	}
+
 	// Update order in repository
 	if err := uc.orderRepo.Update(order); err != nil {
 		return nil, err
 	}
 
+	// Payment provider webhooks mark orders as paid or captured
+	if input.Status == entity.OrderStatusPaid || input.Status == entity.OrderStatusCaptured {
+		uc.issueInvoice(order)
+	}
+
 	return order, nil
 }
 
@@ -588,96 +1167,125 @@ func (uc *OrderUseCase) ListOrdersByStatus(status entity.OrderStatus, offset, li
 	return uc.orderRepo.ListByStatus(status, offset, limit)
 }
 
-// CapturePayment captures an authorized payment
-func (uc *OrderUseCase) CapturePayment(transactionID string, amount int64) error {
+// CapturePaymentInput contains the data needed to capture a payment
+type CapturePaymentInput struct {
+	PaymentID string `json:"payment_id"`
+	Amount    int64  `json:"amount"`
+	Final     bool   `json:"final"`    // release the rest of the authorization after this capture
+	Shipment  string `json:"shipment"` // reference of the shipment the capture pays for, e.g. its tracking code
+}
+
+// CapturePayment captures an amount of an authorized payment. An order can be captured in
+// several parts, e.g. one per shipment, until its authorization is used up or a final capture
+// releases the rest. The order is set to captured once a paid order is fully captured.
+func (uc *OrderUseCase) CapturePayment(input CapturePaymentInput) (*entity.Order, error) {
 	// Find the order with this payment ID
-	order, err := uc.orderRepo.GetByPaymentID(transactionID)
+	order, err := uc.orderRepo.GetByPaymentID(input.PaymentID)
 	if err != nil {
-		return errors.New("order not found for payment ID")
+		return nil, errors.New("order not found for payment ID")
 	}
 
 	// Check if the order is already captured
 	if order.Status == entity.OrderStatusCaptured {
-		return errors.New("payment already captured")
+		return nil, errors.New("payment already captured")
 	}
-	// Check if the order is in a state that allows capture
-	if order.Status != entity.OrderStatusPaid {
-		return errors.New("payment capture not allowed in current order status")
+	// Check if the order is in a state that allows capture, shipped orders may still have shipments to capture
+	if order.Status != entity.OrderStatusPaid && order.Status != entity.OrderStatusShipped {
+		return nil, errors.New("payment capture not allowed in current order status")
 	}
 
 	// Check if the amount is valid
-	if amount <= 0 {
-		return errors.New("capture amount must be greater than zero")
+	if input.Amount <= 0 {
+		return nil, errors.New("capture amount must be greater than zero")
+	}
+
+	// Orders paid before authorizations were tracked hold their final amount
+	if order.AuthorizedAmount == 0 && order.CapturedAmount == 0 {
+		order.AuthorizedAmount = order.FinalAmount
 	}
 
-	// Check if amount is greater than the order amount
-	if amount > order.FinalAmount {
-		return errors.New("capture amount cannot exceed the original payment amount")
+	// Check if the amount is left of the authorization
+	if order.RemainingCapturable() == 0 {
+		return nil, errors.New("payment already captured")
+	}
+	if input.Amount > order.RemainingCapturable() {
+		return nil, errors.New("capture amount exceeds the remaining authorized amount")
+	}
+	if order.IsAuthorizationExpired() {
+		return nil, errors.New("payment authorization has expired")
 	}
 
+	// The last of the authorization is always a final capture
+	final := input.Final || input.Amount == order.RemainingCapturable()
+
 	providerType := service.PaymentProviderType(order.PaymentProvider)
 
-	// Get default currency
-	defaultCurrency, err := uc.currencyRepo.GetDefault()
+	// Payments are made in the currency the order was placed in
+	currencyCode, err := uc.orderCurrencyCode(order)
 	if err != nil {
-		return fmt.Errorf("failed to get default currency: %w", err)
+		return nil, err
 	}
 
 	// Call payment service to capture payment
-	err = uc.paymentSvc.CapturePayment(transactionID, amount, providerType)
+	err = uc.paymentSvc.CapturePayment(input.PaymentID, input.Amount, final, providerType)
 	if err != nil {
 		// Record failed capture attempt
 		txn, txErr := entity.NewPaymentTransaction(
 			order.ID,
-			transactionID,
+			input.PaymentID,
 			entity.TransactionTypeCapture,
 			entity.TransactionStatusFailed,
-			amount,
-			defaultCurrency.Code,
+			input.Amount,
+			currencyCode,
 			string(providerType),
 		)
 
 		if txErr == nil {
 			txn.AddMetadata("error", err.Error())
+			if input.Shipment != "" {
+				txn.AddMetadata("shipment", input.Shipment)
+			}
 			if err := uc.paymentTxnRepo.Create(txn); err != nil {
 				log.Printf("Failed to save capture transaction: %v\n", err)
 			}
 		}
 
-		return fmt.Errorf("failed to capture payment: %v", err)
+		return nil, fmt.Errorf("failed to capture payment: %v", err)
 	}
 
-	if err := order.UpdateStatus(entity.OrderStatusCaptured); err != nil {
-		return fmt.Errorf("failed to update order status: %v", err)
+	if err := order.RecordCapture(input.Amount, final); err != nil {
+		return nil, err
+	}
+
+	// Shipped orders stay shipped, the capture is recorded on them
+	if order.IsFullyCaptured() && order.Status == entity.OrderStatusPaid {
+		if err := order.UpdateStatus(entity.OrderStatusCaptured); err != nil {
+			return nil, fmt.Errorf("failed to update order status: %v", err)
+		}
 	}
 
 	// Save the updated order in repository
 	if err := uc.orderRepo.Update(order); err != nil {
-		return fmt.Errorf("failed to save order status: %v", err)
+		return nil, fmt.Errorf("failed to save order status: %v", err)
 	}
 
 	// Record successful capture transaction
-	// Track if this is a full or partial capture
-	isFullCapture := amount >= order.FinalAmount
-
 	txn, err := entity.NewPaymentTransaction(
 		order.ID,
-		transactionID,
+		input.PaymentID,
 		entity.TransactionTypeCapture,
 		entity.TransactionStatusSuccessful,
-		amount,
-		defaultCurrency.Code,
+		input.Amount,
+		currencyCode,
 		string(providerType),
 	)
 	if err == nil {
-		txn.AddMetadata("full_capture", fmt.Sprintf("%t", isFullCapture))
-
-		// Record total authorized amount
-		if isFullCapture {
-			txn.AddMetadata("remaining_amount", "0")
-		} else {
-			remainingAmount := order.FinalAmount - amount
-			txn.AddMetadata("remaining_amount", fmt.Sprintf("%.2f", money.FromCents(remainingAmount)))
+		txn.AddMetadata("full_capture", fmt.Sprintf("%t", order.IsFullyCaptured()))
+		txn.AddMetadata("final_capture", fmt.Sprintf("%t", final))
+		txn.AddMetadata("total_captured", strconv.FormatFloat(money.New(order.CapturedAmount, currencyCode).Decimal(), 'f', money.Exponent(currencyCode), 64))
+		txn.AddMetadata("remaining_amount", strconv.FormatFloat(money.New(order.RemainingCapturable(), currencyCode).Decimal(), 'f', money.Exponent(currencyCode), 64))
+		if input.Shipment != "" {
+			txn.AddMetadata("shipment", input.Shipment)
 		}
 
 		if err := uc.paymentTxnRepo.Create(txn); err != nil {
@@ -685,7 +1293,9 @@ func (uc *OrderUseCase) CapturePayment(transactionID string, amount int64) error
 		}
 	}
 
-	return nil
+	uc.issueInvoice(order)
+
+	return order, nil
 }
 
 // CancelPayment cancels a payment
@@ -711,10 +1321,10 @@ func (uc *OrderUseCase) CancelPayment(transactionID string) error {
 
 	providerType := service.PaymentProviderType(order.PaymentProvider)
 
-	// Get default currency
-	defaultCurrency, err := uc.currencyRepo.GetDefault()
+	// Payments are made in the currency the order was placed in
+	currencyCode, err := uc.orderCurrencyCode(order)
 	if err != nil {
-		return fmt.Errorf("failed to get default currency: %w", err)
+		return err
 	}
 
 	err = uc.paymentSvc.CancelPayment(transactionID, providerType)
@@ -726,7 +1336,7 @@ func (uc *OrderUseCase) CancelPayment(transactionID string) error {
 			entity.TransactionTypeCancel,
 			entity.TransactionStatusFailed,
 			0, // No amount for cancellation
-			defaultCurrency.Code,
+			currencyCode,
 			string(providerType),
 		)
 		if txErr == nil {
@@ -756,7 +1366,7 @@ func (uc *OrderUseCase) CancelPayment(transactionID string) error {
 		entity.TransactionTypeCancel,
 		entity.TransactionStatusSuccessful,
 		0, // No amount for cancellation
-		defaultCurrency.Code,
+		currencyCode,
 		string(providerType),
 	)
 	if err == nil {
@@ -791,26 +1401,30 @@ func (uc *OrderUseCase) RefundPayment(transactionID string, amount int64) error
 		return errors.New("refund amount must be greater than zero")
 	}
 
-	// Check if the refund amount exceeds the original amount
-	if amount > order.FinalAmount {
-		return errors.New("refund amount cannot exceed the original payment amount")
+	// Only the captured amount can be refunded
+	capturedAmount := order.CapturedTotal()
+	if capturedAmount == 0 {
+		return errors.New("payment has not been captured")
+	}
+	if amount > capturedAmount {
+		return errors.New("refund amount cannot exceed the captured amount")
 	}
 
 	providerType := service.PaymentProviderType(order.PaymentProvider)
 
-	// Get default currency
-	defaultCurrency, err := uc.currencyRepo.GetDefault()
+	// Payments are made in the currency the order was placed in
+	currencyCode, err := uc.orderCurrencyCode(order)
 	if err != nil {
-		return fmt.Errorf("failed to get default currency: %w", err)
+		return err
 	}
 
 	// Get total refunded amount so far (if any)
 	var totalRefundedSoFar int64 = 0
 	totalRefundedSoFar, _ = uc.paymentTxnRepo.SumAmountByOrderIDAndType(order.ID, entity.TransactionTypeRefund)
 
-	// Check if we're trying to refund more than the original amount when combining with previous refunds
-	if totalRefundedSoFar+amount > order.FinalAmount {
-		return errors.New("total refund amount would exceed the original payment amount")
+	// Check if we're trying to refund more than the captured amount when combining with previous refunds
+	if totalRefundedSoFar+amount > capturedAmount {
+		return errors.New("total refund amount would exceed the captured amount")
 	}
 
 	err = uc.paymentSvc.RefundPayment(transactionID, amount, providerType)
@@ -822,7 +1436,7 @@ func (uc *OrderUseCase) RefundPayment(transactionID string, amount int64) error
 			entity.TransactionTypeRefund,
 			entity.TransactionStatusFailed,
 			amount,
-			defaultCurrency.Code,
+			currencyCode,
 			string(providerType),
 		)
 		if txErr == nil {
@@ -837,7 +1451,7 @@ func (uc *OrderUseCase) RefundPayment(transactionID string, amount int64) error
 
 	// Calculate if this is a full refund
 	isFullRefund := false
-	if amount >= order.FinalAmount || (totalRefundedSoFar+amount) >= order.FinalAmount {
+	if totalRefundedSoFar+amount >= capturedAmount {
 		isFullRefund = true
 	}
 
@@ -860,7 +1474,7 @@ func (uc *OrderUseCase) RefundPayment(transactionID string, amount int64) error
 		entity.TransactionTypeRefund,
 		entity.TransactionStatusSuccessful,
 		amount,
-		defaultCurrency.Code,
+		currencyCode,
 		string(providerType),
 	)
 	if err == nil {
@@ -869,20 +1483,71 @@ func (uc *OrderUseCase) RefundPayment(transactionID string, amount int64) error
 
 		// Record total refunded amount including this transaction
 		totalRefunded := totalRefundedSoFar + amount
-		txn.AddMetadata("total_refunded", fmt.Sprintf("%.2f", money.FromCents(totalRefunded)))
+		txn.AddMetadata("total_refunded", strconv.FormatFloat(money.New(totalRefunded, currencyCode).Decimal(), 'f', money.Exponent(currencyCode), 64))
 
 		// Record remaining amount still available for refund
-		remainingAmount := max(order.FinalAmount-totalRefunded, 0)
-		txn.AddMetadata("remaining_available", fmt.Sprintf("%.2f", money.FromCents(remainingAmount)))
+		remainingAmount := max(capturedAmount-totalRefunded, 0)
+		txn.AddMetadata("remaining_available", strconv.FormatFloat(money.New(remainingAmount, currencyCode).Decimal(), 'f', money.Exponent(currencyCode), 64))
 
 		if err := uc.paymentTxnRepo.Create(txn); err != nil {
 			log.Printf("Failed to save refund transaction: %v\n", err)
 		}
 	}
 
+	// Credit the refund
+	if uc.invoiceUseCase != nil {
+		if _, err := uc.invoiceUseCase.IssueCreditNote(order, amount); err != nil {
+			log.Printf("Failed to issue credit note for order %d: %v\n", order.ID, err)
+		}
+	}
+
 	return nil
 }
 
+// issueInvoice issues the invoice of a paid or captured order. Invoicing
+// failures are logged and don't fail the payment.
+func (uc *OrderUseCase) issueInvoice(order *entity.Order) {
+	if uc.invoiceUseCase == nil {
+		return
+	}
+
+	if _, err := uc.invoiceUseCase.IssueInvoice(order); err != nil {
+		log.Printf("Failed to issue invoice for order %d: %v\n", order.ID, err)
+	}
+}
+
+// authorize starts tracking the authorization of an order that was just paid,
+// which lapses after the authorization validity of its payment provider
+func (uc *OrderUseCase) authorize(order *entity.Order) {
+	validity := service.DefaultAuthorizationValidity
+	if policy, ok := uc.paymentSvc.(service.AuthorizationPolicy); ok {
+		validity = policy.AuthorizationValidity(service.PaymentProviderType(order.PaymentProvider))
+	}
+	order.Authorize(validity)
+}
+
+// WarnExpiringAuthorizations flags orders whose authorization lapses within the given window
+// without being fully captured. Each order is warned about once; the warned orders are returned.
+func (uc *OrderUseCase) WarnExpiringAuthorizations(within time.Duration) ([]*entity.Order, error) {
+	orders, err := uc.orderRepo.ListExpiringAuthorizations(time.Now().Add(within))
+	if err != nil {
+		return nil, err
+	}
+
+	warned := make([]*entity.Order, 0, len(orders))
+	for _, order := range orders {
+		now := time.Now()
+		order.AuthorizationWarnedAt = &now
+		if err := uc.orderRepo.Update(order); err != nil {
+			log.Printf("Failed to mark authorization of order %d warned: %v\n", order.ID, err)
+			continue
+		}
+		warned = append(warned, order)
+	}
+
+	return warned, nil
+}
+
 // GetShippingOptions calculates available shipping options for an order based on the cart
 func (uc *OrderUseCase) GetShippingOptions(userID uint, sessionID string, shippingAddr entity.Address) (*ShippingOptions, error) {
 	var cart *entity.Cart
@@ -905,20 +1570,18 @@ func (uc *OrderUseCase) GetShippingOptions(userID uint, sessionID string, shippi
 		return nil, errors.New("cart is empty")
 	}
 
-	// Calculate cart's total value and weight
+	// Calculate cart's total value and collect the items to pack
 	var totalValue int64
-	var totalWeight float64
+	packingItems := make([]entity.PackingItem, 0, len(cart.Items))
 
 	for _, item := range cart.Items {
-		product, err := uc.productRepo.GetByID(item.ProductID)
+		product, err := uc.productRepo.GetByIDWithVariants(item.ProductID)
 		if err != nil {
 			return nil, fmt.Errorf("product not found: ProductID=%d", item.ProductID)
 		}
 
-		totalValue += int64(item.Quantity) * product.Price
-
-		// Calculate weight based on product or product variant
-		totalWeight += float64(item.Quantity) * product.Weight
+		totalValue += int64(item.Quantity) * product.EffectivePrice(time.Now())
+		packingItems = append(packingItems, packingItem(product, product.GetVariantByID(item.ProductVariantID), item.Quantity))
 	}
 
 	// Call shipping use case to calculate options
@@ -926,7 +1589,34 @@ func (uc *OrderUseCase) GetShippingOptions(userID uint, sessionID string, shippi
 		return nil, errors.New("shipping use case not initialized")
 	}
 
-	return uc.shippingUseCase.CalculateShippingOptions(shippingAddr, totalValue, totalWeight)
+	// Rates apply to the billable weight of the packages the cart ships in
+	_, totalWeight, err := uc.shippingUseCase.PackItems(packingItems)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include the cart's discount code, unless it no longer exists
+	discountCode := cart.DiscountCode
+	if discountCode != "" && uc.discountUseCase != nil {
+		if _, err := uc.discountUseCase.GetDiscountByCode(discountCode); err != nil {
+			discountCode = ""
+		}
+	}
+
+	// Only methods allowed for the cart's items are offered
+	options, err := uc.shippingUseCase.CalculateShippingOptions(shippingAddr, totalValue, totalWeight, discountCode, packingItems)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the tax on each option's shipping cost
+	if uc.taxUseCase != nil {
+		if err := uc.taxUseCase.ApplyShippingOptionsTax(shippingAddr, options); err != nil {
+			return nil, fmt.Errorf("error calculating shipping tax: %v", err)
+		}
+	}
+
+	return options, nil
 }
 
 // RecordPaymentTransaction records a payment transaction for an order
@@ -981,3 +1671,22 @@ func (uc *OrderUseCase) GetUserByID(id uint) (*entity.User, error) {
 func (uc *OrderUseCase) ListAllOrders(offset, limit int) ([]*entity.Order, error) {
 	return uc.orderRepo.ListAll(offset, limit)
 }
+
+// packingItem describes a cart line for packing, with the dimensions of its variant if it has its own
+func packingItem(product *entity.Product, variant *entity.ProductVariant, quantity int) entity.PackingItem {
+	item := entity.PackingItem{
+		ProductID:         product.ID,
+		SKU:               product.ProductNumber,
+		Name:              product.Name,
+		Quantity:          quantity,
+		Weight:            product.Weight,
+		Dimensions:        product.ShippingDimensions(variant),
+		CategoryID:        product.CategoryID,
+		ShippingProfileID: product.ShippingProfileID,
+	}
+	if variant != nil {
+		item.VariantID = variant.ID
+		item.SKU = variant.SKU
+	}
+	return item
+}
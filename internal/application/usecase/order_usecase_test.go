@@ -0,0 +1,1113 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/internal/infrastructure/carrier"
+	"github.com/zenfulcode/commercify/internal/infrastructure/payment"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func TestOrderUseCase_CreateOrderFromCart_Currency(t *testing.T) {
+	setup := func() (*usecase.OrderUseCase, *entity.Product, *entity.Product) {
+		// Setup mocks
+		orderRepo := mock.NewMockOrderRepository(false)
+		cartRepo := mock.NewMockCartRepository()
+		productRepo := mock.NewMockProductRepository()
+		currencyRepo := mock.NewMockCurrencyRepository()
+
+		eur, _ := entity.NewCurrency("EUR", "Euro", "€", 0.5, true, false)
+		currencyRepo.Create(eur)
+
+		// A product with an explicit EUR price and one without
+		pricedProduct, _ := entity.NewProduct("Priced", "Has a EUR price", 10000, "USD", 10, 1.0, 1, nil)
+		pricedProduct.Prices = []entity.ProductPrice{{CurrencyCode: "EUR", Price: 4500}}
+		productRepo.Create(pricedProduct)
+
+		convertedProduct, _ := entity.NewProduct("Converted", "No EUR price", 2000, "USD", 10, 1.0, 1, nil)
+		productRepo.Create(convertedProduct)
+
+		cart, _ := entity.NewGuestCart("session-1")
+		cart.AddItem(pricedProduct.ID, 0, 1)
+		cart.AddItem(convertedProduct.ID, 0, 2)
+		cartRepo.Create(cart)
+
+		orderUseCase := usecase.NewOrderUseCase(
+			orderRepo,
+			cartRepo,
+			productRepo,
+			mock.NewMockUserRepository(),
+			nil,
+			nil,
+			mock.NewMockPaymentTransactionRepository(),
+			nil,
+			currencyRepo,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		return orderUseCase, pricedProduct, convertedProduct
+	}
+
+	input := usecase.CreateOrderInput{
+		SessionID:        "session-1",
+		Email:            "guest@example.com",
+		FullName:         "Guest User",
+		ShippingMethodID: 1,
+	}
+
+	t.Run("Default currency", func(t *testing.T) {
+		orderUseCase, _, _ := setup()
+
+		// Execute
+		order, err := orderUseCase.CreateOrderFromCart(input)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", order.Currency)
+		assert.Equal(t, 1.0, order.ExchangeRate)
+		assert.Equal(t, int64(14000), order.TotalAmount)
+	})
+
+	t.Run("Order currency with stored and converted prices", func(t *testing.T) {
+		orderUseCase, pricedProduct, convertedProduct := setup()
+
+		// Execute
+		eurInput := input
+		eurInput.CurrencyCode = "eur"
+		order, err := orderUseCase.CreateOrderFromCart(eurInput)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "EUR", order.Currency)
+		assert.Equal(t, 0.5, order.ExchangeRate)
+
+		prices := map[uint]int64{}
+		for _, item := range order.Items {
+			prices[item.ProductID] = item.Price
+		}
+		assert.Equal(t, int64(4500), prices[pricedProduct.ID])
+		assert.Equal(t, int64(1000), prices[convertedProduct.ID])
+		assert.Equal(t, int64(6500), order.TotalAmount)
+
+		// Converting back uses the rate stored on the order
+		assert.Equal(t, int64(13000), order.ToBaseAmount(order.TotalAmount))
+	})
+
+	t.Run("Unknown currency", func(t *testing.T) {
+		orderUseCase, _, _ := setup()
+
+		// Execute
+		badInput := input
+		badInput.CurrencyCode = "XYZ"
+		order, err := orderUseCase.CreateOrderFromCart(badInput)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, order)
+	})
+}
+
+func TestOrderUseCase_CreateOrderFromCart_VariantCurrency(t *testing.T) {
+	// Setup mocks
+	cartRepo := mock.NewMockCartRepository()
+	productRepo := mock.NewMockProductRepository()
+	currencyRepo := mock.NewMockCurrencyRepository()
+
+	eur, _ := entity.NewCurrency("EUR", "Euro", "€", 0.5, true, false)
+	currencyRepo.Create(eur)
+
+	// The variant has its own EUR price, the product doesn't
+	product, _ := entity.NewProduct("Shirt", "Comes in sizes", 2000, "USD", 10, 0.2, 1, nil)
+	productRepo.Create(product)
+	variant, _ := entity.NewProductVariant(product.ID, "SHIRT-L", 3000, "USD", 5, []entity.VariantAttribute{{Name: "Size", Value: "L"}}, nil, true)
+	variant.ID = 7
+	variant.Prices = []entity.ProductVariantPrice{{VariantID: variant.ID, CurrencyCode: "EUR", Price: 2700}}
+	product.AddVariant(variant)
+	productRepo.Update(product)
+
+	cart, _ := entity.NewGuestCart("session-1")
+	cart.AddItem(product.ID, variant.ID, 2)
+	cartRepo.Create(cart)
+
+	orderUseCase := usecase.NewOrderUseCase(
+		mock.NewMockOrderRepository(false),
+		cartRepo,
+		productRepo,
+		mock.NewMockUserRepository(),
+		nil,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		nil,
+		currencyRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	// Execute
+	order, err := orderUseCase.CreateOrderFromCart(usecase.CreateOrderInput{
+		SessionID:        "session-1",
+		Email:            "guest@example.com",
+		FullName:         "Guest User",
+		ShippingMethodID: 1,
+		CurrencyCode:     "EUR",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", order.Currency)
+	assert.Equal(t, int64(2700), order.Items[0].Price)
+	assert.Equal(t, int64(5400), order.TotalAmount)
+}
+
+func TestOrderUseCase_CreateOrderFromCart_Discount(t *testing.T) {
+	setup := func(code string) (*usecase.OrderUseCase, repository.OrderRepository, *entity.Discount) {
+		// Setup mocks
+		orderRepo := mock.NewMockOrderRepository(false)
+		cartRepo := mock.NewMockCartRepository()
+		productRepo := mock.NewMockProductRepository()
+		discountRepo := mock.NewMockDiscountRepository()
+
+		product, _ := entity.NewProduct("Mug", "A mug", 5000, "USD", 10, 1.0, 1, nil)
+		productRepo.Create(product)
+
+		discount, _ := entity.NewDiscount(
+			"TENPERCENT",
+			entity.DiscountTypeBasket,
+			entity.DiscountMethodPercentage,
+			10.0,
+			0,
+			0,
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discountRepo.Create(discount)
+
+		cart, _ := entity.NewGuestCart("session-1")
+		cart.AddItem(product.ID, 0, 2)
+		cart.SetDiscountCode(code)
+		cartRepo.Create(cart)
+
+		discountUseCase := usecase.NewDiscountUseCase(discountRepo, productRepo, mock.NewMockCategoryRepository(), orderRepo, nil)
+		orderUseCase := usecase.NewOrderUseCase(
+			orderRepo,
+			cartRepo,
+			productRepo,
+			mock.NewMockUserRepository(),
+			nil,
+			nil,
+			mock.NewMockPaymentTransactionRepository(),
+			nil,
+			mock.NewMockCurrencyRepository(),
+			discountUseCase,
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		return orderUseCase, orderRepo, discount
+	}
+
+	input := usecase.CreateOrderInput{
+		SessionID:        "session-1",
+		Email:            "guest@example.com",
+		FullName:         "Guest User",
+		ShippingMethodID: 1,
+	}
+
+	t.Run("Cart discount is saved with the order", func(t *testing.T) {
+		orderUseCase, orderRepo, discount := setup("TENPERCENT")
+
+		// Execute
+		order, err := orderUseCase.CreateOrderFromCart(input)
+
+		// Assert
+		assert.NoError(t, err)
+		stored, _ := orderRepo.GetByID(order.ID)
+		assert.Equal(t, int64(1000), stored.DiscountAmount)
+		assert.Equal(t, int64(9000), stored.FinalAmount)
+		assert.Equal(t, "TENPERCENT", stored.AppliedDiscount.DiscountCode)
+		assert.Equal(t, 1, discount.CurrentUsage)
+	})
+
+	t.Run("Code that can no longer be applied", func(t *testing.T) {
+		orderUseCase, orderRepo, _ := setup("EXPIRED")
+
+		// Execute
+		order, err := orderUseCase.CreateOrderFromCart(input)
+
+		// Assert
+		assert.ErrorContains(t, err, "discount code EXPIRED can no longer be applied")
+		assert.Nil(t, order)
+		orders, _ := orderRepo.ListAll(0, 10)
+		assert.Empty(t, orders)
+	})
+}
+
+func TestOrderUseCase_CreateOrderFromCart_Packages(t *testing.T) {
+	// Setup mocks
+	orderRepo := mock.NewMockOrderRepository(false)
+	cartRepo := mock.NewMockCartRepository()
+	productRepo := mock.NewMockProductRepository()
+
+	methodRepo := mock.NewMockShippingMethodRepository()
+	zoneRepo := mock.NewMockShippingZoneRepository()
+	shippingUseCase := usecase.NewShippingUseCase(
+		methodRepo,
+		zoneRepo,
+		mock.NewMockShippingRateRepository(zoneRepo, methodRepo),
+		mock.NewMockShippingPackageRepository(),
+		mock.NewMockShippingProfileRepository(),
+		mock.NewMockCategoryRepository(),
+		mock.NewMockDiscountRepository(),
+		mock.NewMockHolidayRepository(),
+		entity.DefaultDimensionalWeightDivisor,
+		nil,
+		nil,
+		usecase.DispatchOrigin{},
+	)
+	method, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{Name: "Parcel", EstimatedDeliveryDays: 2})
+	zone, _ := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{Name: "Everywhere"})
+	rate, _ := shippingUseCase.CreateShippingRate(usecase.CreateShippingRateInput{
+		ShippingMethodID: method.ID,
+		ShippingZoneID:   zone.ID,
+		BaseRate:         5,
+		Active:           true,
+	})
+	shippingUseCase.CreateWeightBasedRate(usecase.CreateWeightBasedRateInput{ShippingRateID: rate.ID, MinWeight: 0, MaxWeight: 2, Rate: 1})
+	shippingUseCase.CreateWeightBasedRate(usecase.CreateWeightBasedRateInput{ShippingRateID: rate.ID, MinWeight: 2, MaxWeight: 10, Rate: 5})
+	shippingUseCase.CreateShippingPackage(usecase.ShippingPackageInput{
+		Name:       "Small",
+		Dimensions: entity.Dimensions{Length: 20, Width: 15, Height: 10},
+	})
+	shippingUseCase.CreateShippingPackage(usecase.ShippingPackageInput{
+		Name:       "Large",
+		Dimensions: entity.Dimensions{Length: 40, Width: 30, Height: 20},
+	})
+
+	// A small lamp whose large variant needs the large box
+	product, _ := entity.NewProduct("Lamp", "Paper lamp", 2000, "USD", 10, 0.5, 1, nil)
+	product.Dimensions = entity.Dimensions{Length: 15, Width: 15, Height: 8}
+	productRepo.Create(product)
+	variant, _ := entity.NewProductVariant(product.ID, "LAMP-XL", 2000, "USD", 10, []entity.VariantAttribute{{Name: "Size", Value: "XL"}}, nil, true)
+	variant.ID = 1
+	variant.Dimensions = entity.Dimensions{Length: 35, Width: 25, Height: 15}
+	product.Variants = []*entity.ProductVariant{variant}
+	product.HasVariants = true
+
+	cart, _ := entity.NewGuestCart("session-1")
+	cart.AddItem(product.ID, variant.ID, 1)
+	cartRepo.Create(cart)
+
+	orderUseCase := usecase.NewOrderUseCase(
+		orderRepo,
+		cartRepo,
+		productRepo,
+		mock.NewMockUserRepository(),
+		nil,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		shippingUseCase,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	// Execute
+	order, err := orderUseCase.CreateOrderFromCart(usecase.CreateOrderInput{
+		SessionID:        "session-1",
+		Email:            "guest@example.com",
+		FullName:         "Guest User",
+		ShippingMethodID: method.ID,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, order.Packages, 1)
+	assert.Equal(t, "Large", order.Packages[0].Name)
+	assert.Equal(t, "LAMP-XL", order.Packages[0].Items[0].SKU)
+	assert.Equal(t, 4.8, order.Packages[0].BillableWeight())
+
+	// The dimensional weight of 4.8 kg selects the 2-10 kg tier instead of the 0-2 kg tier
+	assert.Equal(t, int64(1000), order.ShippingCost)
+
+	// The parcel takes the method's 2 business days
+	assert.NotNil(t, order.EstimatedDelivery)
+	assert.Equal(t, order.EstimatedDelivery.Earliest, order.EstimatedDelivery.Latest)
+	assert.True(t, order.EstimatedDelivery.Earliest.After(time.Now()))
+}
+
+func TestOrderUseCase_UpdateOrderStatus_ShippingLabel(t *testing.T) {
+	// Setup mocks
+	orderRepo := mock.NewMockOrderRepository(false)
+	methodRepo := mock.NewMockShippingMethodRepository()
+	zoneRepo := mock.NewMockShippingZoneRepository()
+	fake := carrier.NewFakeCarrier("USD")
+	shippingUseCase := usecase.NewShippingUseCase(
+		methodRepo,
+		zoneRepo,
+		mock.NewMockShippingRateRepository(zoneRepo, methodRepo),
+		mock.NewMockShippingPackageRepository(),
+		mock.NewMockShippingProfileRepository(),
+		mock.NewMockCategoryRepository(),
+		mock.NewMockDiscountRepository(),
+		mock.NewMockHolidayRepository(),
+		entity.DefaultDimensionalWeightDivisor,
+		[]service.CarrierService{fake},
+		nil,
+		usecase.DispatchOrigin{},
+	)
+	method, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+		Name:                  "Express",
+		EstimatedDeliveryDays: 1,
+		Carrier:               "fake",
+		CarrierServiceCode:    "express",
+	})
+
+	address := entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"}
+	order, _ := entity.NewOrder(
+		1,
+		[]entity.OrderItem{{ProductID: 1, Quantity: 1, Price: 2000, Subtotal: 2000, Weight: 1.5}},
+		address,
+		address,
+		entity.CustomerDetails{Email: "jane@example.com", FullName: "Jane Doe"},
+	)
+	order.ShippingMethodID = method.ID
+	order.UpdateStatus(entity.OrderStatusPaid)
+	orderRepo.Create(order)
+
+	orderUseCase := usecase.NewOrderUseCase(
+		orderRepo,
+		mock.NewMockCartRepository(),
+		mock.NewMockProductRepository(),
+		mock.NewMockUserRepository(),
+		nil,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		shippingUseCase,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	// Execute
+	shipped, err := orderUseCase.UpdateOrderStatus(usecase.UpdateOrderStatusInput{
+		OrderID: order.ID,
+		Status:  entity.OrderStatusShipped,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, shipped.ShippingLabel)
+	assert.Equal(t, "fake", shipped.ShippingLabel.Carrier)
+	assert.Equal(t, "express", shipped.ShippingLabel.ServiceCode)
+	assert.Equal(t, int64(2000), shipped.ShippingLabel.Cost) // 15.00 plus 2.50 for each of 2 started kg
+	assert.Equal(t, shipped.ShippingLabel.TrackingNumber, shipped.TrackingCode)
+
+	tracking, err := fake.Track(*shipped.ShippingLabel)
+	assert.NoError(t, err)
+	assert.Equal(t, "PRE_TRANSIT", tracking.Status)
+}
+
+func TestOrderUseCase_CreateOrderFromCart_PickupPoint(t *testing.T) {
+	setup := func() (*usecase.OrderUseCase, *usecase.ShippingUseCase, *entity.ShippingMethod, repository.ProductRepository) {
+		// Setup mocks
+		cartRepo := mock.NewMockCartRepository()
+		productRepo := mock.NewMockProductRepository()
+		methodRepo := mock.NewMockShippingMethodRepository()
+		zoneRepo := mock.NewMockShippingZoneRepository()
+		shippingUseCase := usecase.NewShippingUseCase(
+			methodRepo,
+			zoneRepo,
+			mock.NewMockShippingRateRepository(zoneRepo, methodRepo),
+			mock.NewMockShippingPackageRepository(),
+			mock.NewMockShippingProfileRepository(),
+			mock.NewMockCategoryRepository(),
+			mock.NewMockDiscountRepository(),
+			mock.NewMockHolidayRepository(),
+			entity.DefaultDimensionalWeightDivisor,
+			nil,
+			carrier.NewFakePickupPointProvider(),
+			usecase.DispatchOrigin{},
+		)
+		method, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+			Name:                  "Parcel Shop",
+			EstimatedDeliveryDays: 2,
+			PickupPoint:           true,
+		})
+		zone, _ := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{Name: "Denmark", Countries: []string{"DK"}})
+		shippingUseCase.CreateShippingRate(usecase.CreateShippingRateInput{
+			ShippingMethodID: method.ID,
+			ShippingZoneID:   zone.ID,
+			BaseRate:         4,
+			Active:           true,
+		})
+
+		product, _ := entity.NewProduct("Lamp", "Paper lamp", 2000, "USD", 10, 0.5, 1, nil)
+		productRepo.Create(product)
+		cart, _ := entity.NewGuestCart("session-1")
+		cart.AddItem(product.ID, 0, 1)
+		cartRepo.Create(cart)
+
+		orderUseCase := usecase.NewOrderUseCase(
+			mock.NewMockOrderRepository(false),
+			cartRepo,
+			productRepo,
+			mock.NewMockUserRepository(),
+			nil,
+			nil,
+			mock.NewMockPaymentTransactionRepository(),
+			shippingUseCase,
+			mock.NewMockCurrencyRepository(),
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		return orderUseCase, shippingUseCase, method, productRepo
+	}
+	home := entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"}
+
+	t.Run("Delivered to the chosen pickup point", func(t *testing.T) {
+		orderUseCase, shippingUseCase, method, productRepo := setup()
+		points, _ := shippingUseCase.ListPickupPoints(method.ID, "8000", "DK")
+
+		// Execute
+		order, err := orderUseCase.CreateOrderFromCart(usecase.CreateOrderInput{
+			SessionID:        "session-1",
+			Email:            "guest@example.com",
+			FullName:         "Guest User",
+			ShippingAddr:     home,
+			BillingAddr:      home,
+			ShippingMethodID: method.ID,
+			PickupPointID:    points[0].ID,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, points[0].ID, order.PickupPoint.ID)
+		assert.Equal(t, "Parcel Shop 1", order.ShippingAddr.Company)
+		assert.Equal(t, points[0].Address.Street, order.ShippingAddr.Street)
+		assert.Equal(t, home, order.BillingAddr)
+		assert.Equal(t, int64(400), order.ShippingCost)
+		product, _ := productRepo.GetByID(1)
+		assert.Equal(t, 9, product.Stock)
+	})
+
+	t.Run("Pickup point required", func(t *testing.T) {
+		orderUseCase, _, method, productRepo := setup()
+
+		// Execute
+		order, err := orderUseCase.CreateOrderFromCart(usecase.CreateOrderInput{
+			SessionID:        "session-1",
+			Email:            "guest@example.com",
+			FullName:         "Guest User",
+			ShippingAddr:     home,
+			BillingAddr:      home,
+			ShippingMethodID: method.ID,
+		})
+
+		// Assert
+		assert.EqualError(t, err, "pickup point is required for this shipping method")
+		assert.Nil(t, order)
+		product, _ := productRepo.GetByID(1)
+		assert.Equal(t, 10, product.Stock)
+	})
+}
+
+func TestOrderUseCase_CreateOrderFromCart_ShippingRestrictions(t *testing.T) {
+	// Setup mocks
+	cartRepo := mock.NewMockCartRepository()
+	productRepo := mock.NewMockProductRepository()
+	methodRepo := mock.NewMockShippingMethodRepository()
+	zoneRepo := mock.NewMockShippingZoneRepository()
+	shippingUseCase := usecase.NewShippingUseCase(
+		methodRepo,
+		zoneRepo,
+		mock.NewMockShippingRateRepository(zoneRepo, methodRepo),
+		mock.NewMockShippingPackageRepository(),
+		mock.NewMockShippingProfileRepository(),
+		mock.NewMockCategoryRepository(),
+		mock.NewMockDiscountRepository(),
+		mock.NewMockHolidayRepository(),
+		entity.DefaultDimensionalWeightDivisor,
+		nil,
+		nil,
+		usecase.DispatchOrigin{},
+	)
+	hazardous, _ := shippingUseCase.CreateShippingProfile(usecase.ShippingProfileInput{Name: "Hazardous"})
+	express, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+		Name:                  "Express",
+		EstimatedDeliveryDays: 1,
+		Restrictions:          entity.ShippingRestrictions{ExcludedProfileIDs: []uint{hazardous.ID}},
+	})
+	zone, _ := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{Name: "Everywhere"})
+	shippingUseCase.CreateShippingRate(usecase.CreateShippingRateInput{
+		ShippingMethodID: express.ID,
+		ShippingZoneID:   zone.ID,
+		BaseRate:         15,
+		Active:           true,
+	})
+
+	product, _ := entity.NewProduct("Lamp oil", "Paraffin lamp oil", 900, "USD", 10, 1, 1, nil)
+	product.ShippingProfileID = hazardous.ID
+	productRepo.Create(product)
+	cart, _ := entity.NewGuestCart("session-1")
+	cart.AddItem(product.ID, 0, 1)
+	cartRepo.Create(cart)
+
+	orderUseCase := usecase.NewOrderUseCase(
+		mock.NewMockOrderRepository(false),
+		cartRepo,
+		productRepo,
+		mock.NewMockUserRepository(),
+		nil,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		shippingUseCase,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	address := entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"}
+
+	t.Run("Restricted method not offered", func(t *testing.T) {
+		// Execute
+		options, err := orderUseCase.GetShippingOptions(0, "session-1", address)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, options.Options)
+	})
+
+	t.Run("Restricted method rejected", func(t *testing.T) {
+		// Execute
+		order, err := orderUseCase.CreateOrderFromCart(usecase.CreateOrderInput{
+			SessionID:        "session-1",
+			Email:            "guest@example.com",
+			FullName:         "Guest User",
+			ShippingAddr:     address,
+			BillingAddr:      address,
+			ShippingMethodID: express.ID,
+		})
+
+		// Assert
+		assert.EqualError(t, err, "shipping method is not available for the items in the cart")
+		assert.Nil(t, order)
+		stored, _ := productRepo.GetByID(product.ID)
+		assert.Equal(t, 10, stored.Stock)
+	})
+}
+
+func TestOrderUseCase_HostedPayment(t *testing.T) {
+	setup := func(t *testing.T) (*usecase.OrderUseCase, *entity.Order, repository.PaymentTransactionRepository) {
+		orderRepo := mock.NewMockOrderRepository(false)
+		txnRepo := mock.NewMockPaymentTransactionRepository()
+
+		address := entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"}
+		order, _ := entity.NewOrder(
+			1,
+			[]entity.OrderItem{{ProductID: 1, Quantity: 1, Price: 2000, Subtotal: 2000}},
+			address,
+			address,
+			entity.CustomerDetails{Email: "jane@example.com", FullName: "Jane Doe"},
+		)
+		order.SetPaymentID("cs_test_1")
+		order.UpdateStatus(entity.OrderStatusPendingAction)
+		orderRepo.Create(order)
+
+		txn, _ := entity.NewPaymentTransaction(order.ID, "cs_test_1", entity.TransactionTypeAuthorize,
+			entity.TransactionStatusPending, 2000, "USD", "stripe")
+		txnRepo.Create(txn)
+
+		orderUseCase := usecase.NewOrderUseCase(
+			orderRepo,
+			mock.NewMockCartRepository(),
+			mock.NewMockProductRepository(),
+			mock.NewMockUserRepository(),
+			nil,
+			nil,
+			txnRepo,
+			nil,
+			mock.NewMockCurrencyRepository(),
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		return orderUseCase, order, txnRepo
+	}
+
+	t.Run("Complete payment", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, order, txnRepo := setup(t)
+
+		// Execute
+		paid, err := orderUseCase.CompleteHostedPayment(order.ID, "cs_test_1", "pi_test_1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusPaid, paid.Status)
+		assert.Equal(t, "pi_test_1", paid.PaymentID)
+
+		txn, _ := txnRepo.GetByTransactionID("cs_test_1")
+		assert.Equal(t, entity.TransactionStatusSuccessful, txn.Status)
+		assert.Equal(t, "pi_test_1", txn.Metadata["payment_id"])
+	})
+
+	t.Run("Complete payment already marked paid", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, order, _ := setup(t)
+		orderUseCase.UpdateOrderStatus(usecase.UpdateOrderStatusInput{OrderID: order.ID, Status: entity.OrderStatusPaid})
+
+		// Execute
+		paid, err := orderUseCase.CompleteHostedPayment(order.ID, "cs_test_1", "pi_test_1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusPaid, paid.Status)
+		assert.Equal(t, "pi_test_1", paid.PaymentID)
+	})
+
+	t.Run("Complete payment of another session", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, order, _ := setup(t)
+
+		// Execute
+		_, err := orderUseCase.CompleteHostedPayment(order.ID, "cs_test_2", "pi_test_2")
+
+		// Assert
+		assert.EqualError(t, err, "payment session does not belong to the order")
+	})
+
+	t.Run("Expire payment", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, order, txnRepo := setup(t)
+
+		// Execute
+		expired, err := orderUseCase.ExpireHostedPayment(order.ID, "cs_test_1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusCancelled, expired.Status)
+
+		txn, _ := txnRepo.GetByTransactionID("cs_test_1")
+		assert.Equal(t, entity.TransactionStatusFailed, txn.Status)
+	})
+
+	t.Run("Expire a replaced session", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, order, _ := setup(t)
+
+		// Execute
+		expired, err := orderUseCase.ExpireHostedPayment(order.ID, "cs_test_0")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusPendingAction, expired.Status)
+	})
+}
+
+func TestOrderUseCase_CreatePaymentIntent(t *testing.T) {
+	// Setup mocks
+	orderRepo := mock.NewMockOrderRepository(false)
+	address := entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"}
+	order, _ := entity.NewOrder(
+		1,
+		[]entity.OrderItem{{ProductID: 1, Quantity: 1, Price: 2000, Subtotal: 2000}},
+		address,
+		address,
+		entity.CustomerDetails{Email: "jane@example.com", FullName: "Jane Doe"},
+	)
+	orderRepo.Create(order)
+
+	orderUseCase := usecase.NewOrderUseCase(
+		orderRepo,
+		mock.NewMockCartRepository(),
+		mock.NewMockProductRepository(),
+		mock.NewMockUserRepository(),
+		nil,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		nil,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	t.Run("Without Stripe", func(t *testing.T) {
+		// Execute
+		intent, err := orderUseCase.CreatePaymentIntent(usecase.CreatePaymentIntentInput{OrderID: order.ID})
+
+		// Assert
+		assert.EqualError(t, err, "invalid payment service")
+		assert.Nil(t, intent)
+	})
+
+	t.Run("Paid order", func(t *testing.T) {
+		order.UpdateStatus(entity.OrderStatusPaid)
+		orderRepo.Update(order)
+
+		// Execute
+		intent, err := orderUseCase.CreatePaymentIntent(usecase.CreatePaymentIntentInput{OrderID: order.ID})
+
+		// Assert
+		assert.EqualError(t, err, "order is already paid")
+		assert.Nil(t, intent)
+	})
+}
+
+func TestOrderUseCase_ProcessPayment_SavedPaymentMethod(t *testing.T) {
+	// Setup mocks
+	orderRepo := mock.NewMockOrderRepository(false)
+	address := entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"}
+	order, _ := entity.NewGuestOrder(
+		[]entity.OrderItem{{ProductID: 1, Quantity: 1, Price: 2000, Subtotal: 2000}},
+		address,
+		address,
+		entity.CustomerDetails{Email: "guest@example.com", FullName: "Guest User"},
+	)
+	orderRepo.Create(order)
+
+	paymentMethodUseCase, _, _ := newPaymentMethodUseCase()
+	orderUseCase := usecase.NewOrderUseCase(
+		orderRepo,
+		mock.NewMockCartRepository(),
+		mock.NewMockProductRepository(),
+		mock.NewMockUserRepository(),
+		payment.NewMockPaymentService(),
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		nil,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		paymentMethodUseCase,
+	)
+
+	// Execute
+	paidOrder, err := orderUseCase.ProcessPayment(usecase.ProcessPaymentInput{
+		OrderID:              order.ID,
+		PaymentMethod:        service.PaymentMethodCreditCard,
+		PaymentProvider:      service.PaymentProviderMock,
+		SavedPaymentMethodID: 1,
+	})
+
+	// Assert
+	assert.EqualError(t, err, "saved payment methods are not available for this payment")
+	assert.Nil(t, paidOrder)
+}
+
+// fakeCapturePaymentService records the captures of payments authorized for two days
+type fakeCapturePaymentService struct {
+	service.PaymentService
+	finals  []bool
+	refunds []int64
+}
+
+func (s *fakeCapturePaymentService) CapturePayment(transactionID string, amount int64, final bool, provider service.PaymentProviderType) error {
+	s.finals = append(s.finals, final)
+	return nil
+}
+
+func (s *fakeCapturePaymentService) RefundPayment(transactionID string, amount int64, provider service.PaymentProviderType) error {
+	s.refunds = append(s.refunds, amount)
+	return nil
+}
+
+func (s *fakeCapturePaymentService) AuthorizationValidity(provider service.PaymentProviderType) time.Duration {
+	return 48 * time.Hour
+}
+
+func newCaptureOrderUseCase() (*usecase.OrderUseCase, repository.OrderRepository, *fakeCapturePaymentService) {
+	orderRepo := mock.NewMockOrderRepository(false)
+	paymentSvc := &fakeCapturePaymentService{}
+
+	orderUseCase := usecase.NewOrderUseCase(
+		orderRepo,
+		mock.NewMockCartRepository(),
+		mock.NewMockProductRepository(),
+		mock.NewMockUserRepository(),
+		paymentSvc,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		nil,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	return orderUseCase, orderRepo, paymentSvc
+}
+
+// addAuthorizedOrder stores a paid order whose 2000 cents are authorized for the given validity
+func addAuthorizedOrder(orderRepo repository.OrderRepository, paymentID string, validity time.Duration) *entity.Order {
+	address := entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"}
+	order, _ := entity.NewGuestOrder(
+		[]entity.OrderItem{{ProductID: 1, Quantity: 2, Price: 1000, Subtotal: 2000}},
+		address,
+		address,
+		entity.CustomerDetails{Email: "guest@example.com", FullName: "Guest User"},
+	)
+	order.Status = entity.OrderStatusPaid
+	order.PaymentID = paymentID
+	order.PaymentProvider = string(service.PaymentProviderStripe)
+	order.Currency = "EUR"
+	order.Authorize(validity)
+	orderRepo.Create(order)
+	return order
+}
+
+func TestOrderUseCase_CapturePayment(t *testing.T) {
+	t.Run("Capture per shipment", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, paymentSvc := newCaptureOrderUseCase()
+		addAuthorizedOrder(orderRepo, "pi_1", 48*time.Hour)
+
+		// Execute
+		first, err := orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 1200, Shipment: "TRACK-1"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusPaid, first.Status)
+		assert.Equal(t, int64(1200), first.CapturedAmount)
+		assert.Equal(t, int64(800), first.RemainingCapturable())
+
+		// Execute
+		second, err := orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 800, Shipment: "TRACK-2"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusCaptured, second.Status)
+		assert.Equal(t, int64(2000), second.CapturedAmount)
+		assert.Equal(t, []bool{false, true}, paymentSvc.finals)
+
+		_, err = orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 100})
+		assert.EqualError(t, err, "payment already captured")
+	})
+
+	t.Run("Final capture releases the rest", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, paymentSvc := newCaptureOrderUseCase()
+		addAuthorizedOrder(orderRepo, "pi_1", 48*time.Hour)
+
+		// Execute
+		captured, err := orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 1500, Final: true})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusCaptured, captured.Status)
+		assert.Equal(t, int64(1500), captured.AuthorizedAmount)
+		assert.Equal(t, int64(0), captured.RemainingCapturable())
+		assert.Equal(t, []bool{true}, paymentSvc.finals)
+	})
+
+	t.Run("Shipped order", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, _ := newCaptureOrderUseCase()
+		order := addAuthorizedOrder(orderRepo, "pi_1", 48*time.Hour)
+		order.Status = entity.OrderStatusShipped
+		orderRepo.Update(order)
+
+		// Execute
+		captured, err := orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 2000})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusShipped, captured.Status)
+		assert.True(t, captured.IsFullyCaptured())
+	})
+
+	t.Run("Exceeds the remaining authorization", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, paymentSvc := newCaptureOrderUseCase()
+		addAuthorizedOrder(orderRepo, "pi_1", 48*time.Hour)
+		orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 1500})
+
+		// Execute
+		_, err := orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 600})
+
+		// Assert
+		assert.EqualError(t, err, "capture amount exceeds the remaining authorized amount")
+		assert.Len(t, paymentSvc.finals, 1)
+	})
+
+	t.Run("Expired authorization", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, paymentSvc := newCaptureOrderUseCase()
+		addAuthorizedOrder(orderRepo, "pi_1", -time.Hour)
+
+		// Execute
+		_, err := orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 2000})
+
+		// Assert
+		assert.EqualError(t, err, "payment authorization has expired")
+		assert.Empty(t, paymentSvc.finals)
+	})
+
+	t.Run("Order paid before authorizations were tracked", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, _ := newCaptureOrderUseCase()
+		order := addAuthorizedOrder(orderRepo, "pi_1", 48*time.Hour)
+		order.AuthorizedAmount = 0
+		order.AuthorizationExpiresAt = nil
+		orderRepo.Update(order)
+
+		// Execute
+		captured, err := orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 2000})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusCaptured, captured.Status)
+	})
+}
+
+func TestOrderUseCase_RefundPayment(t *testing.T) {
+	t.Run("Refund up to the captured amount", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, paymentSvc := newCaptureOrderUseCase()
+		addAuthorizedOrder(orderRepo, "pi_1", 48*time.Hour)
+		orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 1200, Shipment: "TRACK-1"})
+
+		// Execute
+		err := orderUseCase.RefundPayment("pi_1", 1500)
+
+		// Assert
+		assert.EqualError(t, err, "refund amount cannot exceed the captured amount")
+		assert.Empty(t, paymentSvc.refunds)
+
+		// Execute
+		err = orderUseCase.RefundPayment("pi_1", 1200)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1200}, paymentSvc.refunds)
+		order, _ := orderRepo.GetByPaymentID("pi_1")
+		assert.Equal(t, entity.OrderStatusRefunded, order.Status)
+	})
+
+	t.Run("Partial refund of a captured order", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, _ := newCaptureOrderUseCase()
+		addAuthorizedOrder(orderRepo, "pi_1", 48*time.Hour)
+		orderUseCase.CapturePayment(usecase.CapturePaymentInput{PaymentID: "pi_1", Amount: 1500, Final: true})
+
+		// Execute
+		err := orderUseCase.RefundPayment("pi_1", 1000)
+
+		// Assert
+		assert.NoError(t, err)
+		order, _ := orderRepo.GetByPaymentID("pi_1")
+		assert.Equal(t, entity.OrderStatusCaptured, order.Status)
+	})
+
+	t.Run("Authorized but not captured", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, paymentSvc := newCaptureOrderUseCase()
+		addAuthorizedOrder(orderRepo, "pi_1", 48*time.Hour)
+
+		// Execute
+		err := orderUseCase.RefundPayment("pi_1", 500)
+
+		// Assert
+		assert.EqualError(t, err, "payment has not been captured")
+		assert.Empty(t, paymentSvc.refunds)
+	})
+}
+
+func TestOrderUseCase_UpdateOrderStatus_Authorization(t *testing.T) {
+	t.Run("Paid", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, _ := newCaptureOrderUseCase()
+		order := addAuthorizedOrder(orderRepo, "pi_1", 0)
+		order.Status = entity.OrderStatusPendingAction
+		order.AuthorizedAmount = 0
+		order.AuthorizationExpiresAt = nil
+		orderRepo.Update(order)
+
+		// Execute
+		paid, err := orderUseCase.UpdateOrderStatus(usecase.UpdateOrderStatusInput{OrderID: order.ID, Status: entity.OrderStatusPaid})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2000), paid.RemainingCapturable())
+		assert.WithinDuration(t, time.Now().Add(48*time.Hour), *paid.AuthorizationExpiresAt, time.Minute)
+	})
+
+	t.Run("Captured outside the store", func(t *testing.T) {
+		// Setup mocks
+		orderUseCase, orderRepo, _ := newCaptureOrderUseCase()
+		order := addAuthorizedOrder(orderRepo, "pi_1", 48*time.Hour)
+
+		// Execute
+		captured, err := orderUseCase.UpdateOrderStatus(usecase.UpdateOrderStatusInput{OrderID: order.ID, Status: entity.OrderStatusCaptured})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2000), captured.CapturedAmount)
+		assert.Equal(t, int64(0), captured.RemainingCapturable())
+	})
+}
+
+func TestOrderUseCase_WarnExpiringAuthorizations(t *testing.T) {
+	// Setup mocks
+	orderUseCase, orderRepo, _ := newCaptureOrderUseCase()
+	expiring := addAuthorizedOrder(orderRepo, "pi_expiring", 12*time.Hour)
+	addAuthorizedOrder(orderRepo, "pi_later", 5*24*time.Hour)
+	captured := addAuthorizedOrder(orderRepo, "pi_captured", time.Hour)
+	captured.RecordCapture(2000, true)
+	orderRepo.Update(captured)
+
+	// Execute
+	warned, err := orderUseCase.WarnExpiringAuthorizations(24 * time.Hour)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, warned, 1)
+	assert.Equal(t, expiring.ID, warned[0].ID)
+
+	stored, _ := orderRepo.GetByID(expiring.ID)
+	assert.NotNil(t, stored.AuthorizationWarnedAt)
+
+	// Orders are warned about once
+	warned, err = orderUseCase.WarnExpiringAuthorizations(24 * time.Hour)
+	assert.NoError(t, err)
+	assert.Empty(t, warned)
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// PaymentMethodUseCase implements use cases for the customers and saved payment methods of users
+type PaymentMethodUseCase struct {
+	methodRepo repository.SavedPaymentMethodRepository
+	userRepo   repository.UserRepository
+	vault      service.PaymentVault
+}
+
+// NewPaymentMethodUseCase creates a new PaymentMethodUseCase
+func NewPaymentMethodUseCase(
+	methodRepo repository.SavedPaymentMethodRepository,
+	userRepo repository.UserRepository,
+	vault service.PaymentVault,
+) *PaymentMethodUseCase {
+	return &PaymentMethodUseCase{
+		methodRepo: methodRepo,
+		userRepo:   userRepo,
+		vault:      vault,
+	}
+}
+
+// CustomerID returns the user's customer at a payment provider, creating it on first use
+func (uc *PaymentMethodUseCase) CustomerID(userID uint, provider service.PaymentProviderType) (string, error) {
+	customerID, err := uc.methodRepo.GetCustomerID(userID, string(provider))
+	if err != nil {
+		return "", err
+	}
+	if customerID != "" {
+		return customerID, nil
+	}
+
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return "", errors.New("user not found")
+	}
+
+	customerID, err = uc.vault.CreateCustomer(provider, user.Email, strings.TrimSpace(user.FirstName+" "+user.LastName))
+	if err != nil {
+		return "", err
+	}
+
+	if err := uc.methodRepo.SetCustomerID(userID, string(provider), customerID); err != nil {
+		return "", err
+	}
+
+	return customerID, nil
+}
+
+// ListPaymentMethods lists a user's saved payment methods, the default first
+func (uc *PaymentMethodUseCase) ListPaymentMethods(userID uint) ([]*entity.SavedPaymentMethod, error) {
+	return uc.methodRepo.ListByUser(userID)
+}
+
+// GetPaymentMethod retrieves a saved payment method of a user
+func (uc *PaymentMethodUseCase) GetPaymentMethod(userID, methodID uint) (*entity.SavedPaymentMethod, error) {
+	method, err := uc.methodRepo.GetByID(methodID)
+	if err != nil || method.UserID != userID {
+		return nil, errors.New("payment method not found")
+	}
+
+	return method, nil
+}
+
+// SetDefaultPaymentMethod makes a saved payment method the user's default
+func (uc *PaymentMethodUseCase) SetDefaultPaymentMethod(userID, methodID uint) (*entity.SavedPaymentMethod, error) {
+	method, err := uc.GetPaymentMethod(userID, methodID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.methodRepo.SetDefault(userID, method.ID); err != nil {
+		return nil, err
+	}
+	method.IsDefault = true
+
+	return method, nil
+}
+
+// DeletePaymentMethod detaches a saved payment method at the provider and deletes it.
+// When the default is deleted, the most recently saved remaining method becomes the default.
+func (uc *PaymentMethodUseCase) DeletePaymentMethod(userID, methodID uint) error {
+	method, err := uc.GetPaymentMethod(userID, methodID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.vault.DetachPaymentMethod(service.PaymentProviderType(method.Provider), method.ProviderMethodID); err != nil {
+		return err
+	}
+
+	return uc.deletePaymentMethod(method)
+}
+
+// SavePaymentMethodInput contains a payment method a provider saved to a customer
+type SavePaymentMethodInput struct {
+	Provider         service.PaymentProviderType
+	CustomerID       string
+	ProviderMethodID string
+	Brand            string
+	Last4            string
+	ExpMonth         int
+	ExpYear          int
+}
+
+// SavePaymentMethod saves or updates a payment method the provider attached to a customer.
+// A user's first saved method becomes their default. Methods of customers that don't belong
+// to a user are ignored and nil is returned.
+func (uc *PaymentMethodUseCase) SavePaymentMethod(input SavePaymentMethodInput) (*entity.SavedPaymentMethod, error) {
+	if existing, err := uc.methodRepo.GetByProviderMethodID(input.ProviderMethodID); err == nil {
+		if err := existing.UpdateCard(input.Brand, input.Last4, input.ExpMonth, input.ExpYear); err != nil {
+			return nil, err
+		}
+		if err := uc.methodRepo.Update(existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	if input.CustomerID == "" {
+		return nil, nil
+	}
+
+	userID, err := uc.methodRepo.GetUserIDByCustomerID(string(input.Provider), input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if userID == 0 {
+		return nil, nil
+	}
+
+	method, err := entity.NewSavedPaymentMethod(
+		userID,
+		string(input.Provider),
+		input.ProviderMethodID,
+		input.Brand,
+		input.Last4,
+		input.ExpMonth,
+		input.ExpYear,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	methods, err := uc.methodRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	method.IsDefault = len(methods) == 0
+
+	if err := uc.methodRepo.Create(method); err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
+
+// RemovePaymentMethod deletes a payment method the provider detached from its customer.
+// Methods that aren't saved are ignored.
+func (uc *PaymentMethodUseCase) RemovePaymentMethod(providerMethodID string) error {
+	method, err := uc.methodRepo.GetByProviderMethodID(providerMethodID)
+	if err != nil {
+		return nil
+	}
+
+	return uc.deletePaymentMethod(method)
+}
+
+// PaymentMethodForPayment returns a saved payment method of a user that can be charged
+func (uc *PaymentMethodUseCase) PaymentMethodForPayment(userID, methodID uint, provider service.PaymentProviderType) (*entity.SavedPaymentMethod, error) {
+	method, err := uc.GetPaymentMethod(userID, methodID)
+	if err != nil {
+		return nil, err
+	}
+
+	if method.Provider != string(provider) {
+		return nil, errors.New("payment method was saved with another payment provider")
+	}
+	if method.IsExpired(time.Now()) {
+		return nil, errors.New("payment method has expired")
+	}
+
+	return method, nil
+}
+
+// deletePaymentMethod deletes a saved payment method and promotes another to default if needed
+func (uc *PaymentMethodUseCase) deletePaymentMethod(method *entity.SavedPaymentMethod) error {
+	if err := uc.methodRepo.Delete(method.ID); err != nil {
+		return err
+	}
+
+	if !method.IsDefault {
+		return nil
+	}
+
+	remaining, err := uc.methodRepo.ListByUser(method.UserID)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+
+	return uc.methodRepo.SetDefault(method.UserID, remaining[0].ID)
+}
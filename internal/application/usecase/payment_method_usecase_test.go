@@ -0,0 +1,242 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+// fakePaymentVault records the customers created and payment methods detached at the provider
+type fakePaymentVault struct {
+	customers []string
+	detached  []string
+	detachErr error
+}
+
+func (v *fakePaymentVault) CreateCustomer(provider service.PaymentProviderType, email, name string) (string, error) {
+	v.customers = append(v.customers, email+"/"+name)
+	return "cus_1", nil
+}
+
+func (v *fakePaymentVault) DetachPaymentMethod(provider service.PaymentProviderType, methodID string) error {
+	if v.detachErr != nil {
+		return v.detachErr
+	}
+	v.detached = append(v.detached, methodID)
+	return nil
+}
+
+func newPaymentMethodUseCase() (*usecase.PaymentMethodUseCase, repository.SavedPaymentMethodRepository, *fakePaymentVault) {
+	userRepo := mock.NewMockUserRepository()
+	userRepo.Create(&entity.User{Email: "jane@example.com", FirstName: "Jane", LastName: "Doe"})
+
+	methodRepo := mock.NewMockSavedPaymentMethodRepository()
+	vault := &fakePaymentVault{}
+	return usecase.NewPaymentMethodUseCase(methodRepo, userRepo, vault), methodRepo, vault
+}
+
+func savedCard(methodID, last4 string) usecase.SavePaymentMethodInput {
+	return usecase.SavePaymentMethodInput{
+		Provider:         service.PaymentProviderStripe,
+		CustomerID:       "cus_1",
+		ProviderMethodID: methodID,
+		Brand:            "visa",
+		Last4:            last4,
+		ExpMonth:         12,
+		ExpYear:          time.Now().Year() + 2,
+	}
+}
+
+func TestPaymentMethodUseCase_CustomerID(t *testing.T) {
+	// Setup mocks
+	paymentMethodUseCase, _, vault := newPaymentMethodUseCase()
+
+	// Execute
+	first, err := paymentMethodUseCase.CustomerID(1, service.PaymentProviderStripe)
+	assert.NoError(t, err)
+	second, err := paymentMethodUseCase.CustomerID(1, service.PaymentProviderStripe)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "cus_1", first)
+	assert.Equal(t, "cus_1", second)
+	assert.Equal(t, []string{"jane@example.com/Jane Doe"}, vault.customers)
+}
+
+func TestPaymentMethodUseCase_SavePaymentMethod(t *testing.T) {
+	t.Run("Customer of a user", func(t *testing.T) {
+		// Setup mocks
+		paymentMethodUseCase, _, _ := newPaymentMethodUseCase()
+		paymentMethodUseCase.CustomerID(1, service.PaymentProviderStripe)
+
+		// Execute
+		first, err := paymentMethodUseCase.SavePaymentMethod(savedCard("pm_1", "4242"))
+		assert.NoError(t, err)
+		second, err := paymentMethodUseCase.SavePaymentMethod(savedCard("pm_2", "4444"))
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, uint(1), first.UserID)
+		assert.True(t, first.IsDefault)
+		assert.False(t, second.IsDefault)
+
+		methods, _ := paymentMethodUseCase.ListPaymentMethods(1)
+		assert.Len(t, methods, 2)
+		assert.Equal(t, "pm_1", methods[0].ProviderMethodID)
+	})
+
+	t.Run("Updated card", func(t *testing.T) {
+		// Setup mocks
+		paymentMethodUseCase, _, _ := newPaymentMethodUseCase()
+		paymentMethodUseCase.CustomerID(1, service.PaymentProviderStripe)
+		paymentMethodUseCase.SavePaymentMethod(savedCard("pm_1", "4242"))
+
+		updated := savedCard("pm_1", "4242")
+		updated.CustomerID = ""
+		updated.ExpYear++
+
+		// Execute
+		method, err := paymentMethodUseCase.SavePaymentMethod(updated)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, updated.ExpYear, method.ExpYear)
+
+		methods, _ := paymentMethodUseCase.ListPaymentMethods(1)
+		assert.Len(t, methods, 1)
+	})
+
+	t.Run("Unknown customer", func(t *testing.T) {
+		// Setup mocks
+		paymentMethodUseCase, _, _ := newPaymentMethodUseCase()
+
+		// Execute
+		method, err := paymentMethodUseCase.SavePaymentMethod(savedCard("pm_1", "4242"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, method)
+	})
+}
+
+func TestPaymentMethodUseCase_ManagePaymentMethods(t *testing.T) {
+	setup := func() (*usecase.PaymentMethodUseCase, *fakePaymentVault, *entity.SavedPaymentMethod, *entity.SavedPaymentMethod) {
+		paymentMethodUseCase, _, vault := newPaymentMethodUseCase()
+		paymentMethodUseCase.CustomerID(1, service.PaymentProviderStripe)
+		first, _ := paymentMethodUseCase.SavePaymentMethod(savedCard("pm_1", "4242"))
+		second, _ := paymentMethodUseCase.SavePaymentMethod(savedCard("pm_2", "4444"))
+		return paymentMethodUseCase, vault, first, second
+	}
+
+	t.Run("Set default", func(t *testing.T) {
+		// Setup mocks
+		paymentMethodUseCase, _, first, second := setup()
+
+		// Execute
+		method, err := paymentMethodUseCase.SetDefaultPaymentMethod(1, second.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, method.IsDefault)
+		assert.False(t, first.IsDefault)
+	})
+
+	t.Run("Method of another user", func(t *testing.T) {
+		// Setup mocks
+		paymentMethodUseCase, vault, first, _ := setup()
+
+		// Execute
+		_, setErr := paymentMethodUseCase.SetDefaultPaymentMethod(2, first.ID)
+		deleteErr := paymentMethodUseCase.DeletePaymentMethod(2, first.ID)
+
+		// Assert
+		assert.EqualError(t, setErr, "payment method not found")
+		assert.EqualError(t, deleteErr, "payment method not found")
+		assert.Empty(t, vault.detached)
+	})
+
+	t.Run("Delete the default", func(t *testing.T) {
+		// Setup mocks
+		paymentMethodUseCase, vault, first, second := setup()
+
+		// Execute
+		err := paymentMethodUseCase.DeletePaymentMethod(1, first.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pm_1"}, vault.detached)
+		assert.True(t, second.IsDefault)
+
+		methods, _ := paymentMethodUseCase.ListPaymentMethods(1)
+		assert.Len(t, methods, 1)
+	})
+
+	t.Run("Detaching fails", func(t *testing.T) {
+		// Setup mocks
+		paymentMethodUseCase, vault, first, _ := setup()
+		vault.detachErr = errors.New("failed to detach payment method")
+
+		// Execute
+		err := paymentMethodUseCase.DeletePaymentMethod(1, first.ID)
+
+		// Assert
+		assert.EqualError(t, err, "failed to detach payment method")
+		methods, _ := paymentMethodUseCase.ListPaymentMethods(1)
+		assert.Len(t, methods, 2)
+	})
+
+	t.Run("Detached at the provider", func(t *testing.T) {
+		// Setup mocks
+		paymentMethodUseCase, _, _, second := setup()
+
+		// Execute
+		err := paymentMethodUseCase.RemovePaymentMethod("pm_2")
+
+		// Assert
+		assert.NoError(t, err)
+		_, getErr := paymentMethodUseCase.GetPaymentMethod(1, second.ID)
+		assert.EqualError(t, getErr, "payment method not found")
+	})
+}
+
+func TestPaymentMethodUseCase_PaymentMethodForPayment(t *testing.T) {
+	// Setup mocks
+	paymentMethodUseCase, methodRepo, _ := newPaymentMethodUseCase()
+	paymentMethodUseCase.CustomerID(1, service.PaymentProviderStripe)
+	method, _ := paymentMethodUseCase.SavePaymentMethod(savedCard("pm_1", "4242"))
+
+	t.Run("Saved card", func(t *testing.T) {
+		// Execute
+		found, err := paymentMethodUseCase.PaymentMethodForPayment(1, method.ID, service.PaymentProviderStripe)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "pm_1", found.ProviderMethodID)
+	})
+
+	t.Run("Other provider", func(t *testing.T) {
+		// Execute
+		_, err := paymentMethodUseCase.PaymentMethodForPayment(1, method.ID, service.PaymentProviderPayPal)
+
+		// Assert
+		assert.EqualError(t, err, "payment method was saved with another payment provider")
+	})
+
+	t.Run("Expired card", func(t *testing.T) {
+		method.ExpYear = time.Now().Year() - 1
+		methodRepo.Update(method)
+
+		// Execute
+		_, err := paymentMethodUseCase.PaymentMethodForPayment(1, method.ID, service.PaymentProviderStripe)
+
+		// Assert
+		assert.EqualError(t, err, "payment method has expired")
+	})
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// PriceListUseCase implements customer group and price list use cases
+type PriceListUseCase struct {
+	priceListRepo     repository.PriceListRepository
+	customerGroupRepo repository.CustomerGroupRepository
+	userRepo          repository.UserRepository
+	productRepo       repository.ProductRepository
+	currencyRepo      repository.CurrencyRepository
+}
+
+// NewPriceListUseCase creates a new PriceListUseCase
+func NewPriceListUseCase(
+	priceListRepo repository.PriceListRepository,
+	customerGroupRepo repository.CustomerGroupRepository,
+	userRepo repository.UserRepository,
+	productRepo repository.ProductRepository,
+	currencyRepo repository.CurrencyRepository,
+) *PriceListUseCase {
+	return &PriceListUseCase{
+		priceListRepo:     priceListRepo,
+		customerGroupRepo: customerGroupRepo,
+		userRepo:          userRepo,
+		productRepo:       productRepo,
+		currencyRepo:      currencyRepo,
+	}
+}
+
+// CustomerGroupInput contains the data needed to create or update a customer group
+type CustomerGroupInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CreateCustomerGroup creates a new customer group
+func (uc *PriceListUseCase) CreateCustomerGroup(input CustomerGroupInput) (*entity.CustomerGroup, error) {
+	group, err := entity.NewCustomerGroup(input.Name, input.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.customerGroupRepo.Create(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// UpdateCustomerGroup updates a customer group
+func (uc *PriceListUseCase) UpdateCustomerGroup(groupID uint, input CustomerGroupInput) (*entity.CustomerGroup, error) {
+	group, err := uc.customerGroupRepo.GetByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != "" {
+		group.Name = input.Name
+	}
+	group.Description = input.Description
+	group.UpdatedAt = time.Now()
+
+	if err := uc.customerGroupRepo.Update(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// DeleteCustomerGroup deletes a customer group
+func (uc *PriceListUseCase) DeleteCustomerGroup(groupID uint) error {
+	if _, err := uc.customerGroupRepo.GetByID(groupID); err != nil {
+		return err
+	}
+
+	return uc.customerGroupRepo.Delete(groupID)
+}
+
+// ListCustomerGroups lists all customer groups
+func (uc *PriceListUseCase) ListCustomerGroups() ([]*entity.CustomerGroup, error) {
+	return uc.customerGroupRepo.List()
+}
+
+// AssignCustomerGroup assigns a user to a customer group, or removes the assignment when groupID is nil
+func (uc *PriceListUseCase) AssignCustomerGroup(userID uint, groupID *uint) (*entity.User, error) {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupID != nil {
+		if _, err := uc.customerGroupRepo.GetByID(*groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	user.SetCustomerGroup(groupID)
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// PriceListEntryInput contains the price of a product or variant in a price list (price in dollars)
+type PriceListEntryInput struct {
+	ProductID   uint    `json:"product_id"`
+	VariantID   uint    `json:"variant_id"`
+	MinQuantity int     `json:"min_quantity"`
+	Price       float64 `json:"price"`
+}
+
+// PriceListInput contains the data needed to create or update a price list
+type PriceListInput struct {
+	Name               string                `json:"name"`
+	CurrencyCode       string                `json:"currency_code"`
+	DiscountPercentage float64               `json:"discount_percentage"`
+	CustomerGroupIDs   []uint                `json:"customer_group_ids"`
+	Entries            []PriceListEntryInput `json:"entries"`
+	StartDate          *time.Time            `json:"start_date"`
+	EndDate            *time.Time            `json:"end_date"`
+	Active             *bool                 `json:"active"` // defaults to true
+}
+
+// CreatePriceList creates a new price list
+func (uc *PriceListUseCase) CreatePriceList(input PriceListInput) (*entity.PriceList, error) {
+	priceList, err := entity.NewPriceList(input.Name, input.CurrencyCode, input.DiscountPercentage, input.StartDate, input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.applyPriceListInput(priceList, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.priceListRepo.Create(priceList); err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+// UpdatePriceList replaces the settings, customer groups and entries of a price list
+func (uc *PriceListUseCase) UpdatePriceList(priceListID uint, input PriceListInput) (*entity.PriceList, error) {
+	priceList, err := uc.priceListRepo.GetByID(priceListID)
+	if err != nil {
+		return nil, err
+	}
+
+	priceList.Name = input.Name
+	priceList.CurrencyCode = strings.ToUpper(input.CurrencyCode)
+	priceList.DiscountPercentage = input.DiscountPercentage
+	priceList.StartDate = input.StartDate
+	priceList.EndDate = input.EndDate
+
+	if err := uc.applyPriceListInput(priceList, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.priceListRepo.Update(priceList); err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+// DeletePriceList deletes a price list
+func (uc *PriceListUseCase) DeletePriceList(priceListID uint) error {
+	if _, err := uc.priceListRepo.GetByID(priceListID); err != nil {
+		return err
+	}
+
+	return uc.priceListRepo.Delete(priceListID)
+}
+
+// GetPriceList retrieves a price list by ID
+func (uc *PriceListUseCase) GetPriceList(priceListID uint) (*entity.PriceList, error) {
+	return uc.priceListRepo.GetByID(priceListID)
+}
+
+// ListPriceLists lists price lists with pagination
+func (uc *PriceListUseCase) ListPriceLists(offset, limit int) ([]*entity.PriceList, error) {
+	return uc.priceListRepo.List(offset, limit)
+}
+
+// applyPriceListInput validates the input and applies it to a price list
+func (uc *PriceListUseCase) applyPriceListInput(priceList *entity.PriceList, input PriceListInput) error {
+	currency, err := uc.currencyRepo.GetByCode(priceList.CurrencyCode)
+	if err != nil {
+		return errors.New("invalid currency code: " + priceList.CurrencyCode)
+	}
+
+	if input.Active != nil {
+		priceList.Active = *input.Active
+	}
+
+	if err := priceList.Validate(); err != nil {
+		return err
+	}
+
+	for _, groupID := range input.CustomerGroupIDs {
+		if _, err := uc.customerGroupRepo.GetByID(groupID); err != nil {
+			return err
+		}
+	}
+	groupIDs := input.CustomerGroupIDs
+	if groupIDs == nil {
+		groupIDs = []uint{}
+	}
+	priceList.SetCustomerGroups(groupIDs)
+
+	entries := make([]entity.PriceListEntry, 0, len(input.Entries))
+	for _, entryInput := range input.Entries {
+		product, err := uc.productRepo.GetByIDWithVariants(entryInput.ProductID)
+		if err != nil {
+			return errors.New("invalid product ID in price list entry")
+		}
+		if entryInput.VariantID != 0 && product.GetVariantByID(entryInput.VariantID) == nil {
+			return errors.New("variant does not belong to product in price list entry")
+		}
+
+		entries = append(entries, entity.PriceListEntry{
+			ProductID:   entryInput.ProductID,
+			VariantID:   entryInput.VariantID,
+			MinQuantity: entryInput.MinQuantity,
+			Price:       money.FromDecimal(entryInput.Price, currency.Code).Amount,
+		})
+	}
+
+	return priceList.SetEntries(entries)
+}
+
+// CustomerPricing resolves customer group prices for a customer.
+// A nil CustomerPricing prices everything at the base price.
+type CustomerPricing struct {
+	groupID    uint
+	priceLists []*entity.PriceList
+	at         time.Time
+}
+
+// PricingForUser returns the pricing of the price lists assigned to a user's customer group.
+// Guests and users without a group get nil pricing.
+func (uc *PriceListUseCase) PricingForUser(userID uint) (*CustomerPricing, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.CustomerGroupID == nil {
+		return nil, nil
+	}
+
+	priceLists, err := uc.priceListRepo.ListByCustomerGroup(*user.CustomerGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CustomerPricing{
+		groupID:    *user.CustomerGroupID,
+		priceLists: priceLists,
+		at:         time.Now(),
+	}, nil
+}
+
+// Price returns the price of a product or variant for the quantity in the given currency.
+// When several price lists apply the lowest price wins; without one the base price is kept.
+func (p *CustomerPricing) Price(currencyCode string, productID, variantID uint, quantity int, basePrice int64) int64 {
+	if p == nil {
+		return basePrice
+	}
+
+	price := basePrice
+	found := false
+	for _, priceList := range p.priceLists {
+		if !priceList.AppliesTo(p.groupID, currencyCode, p.at) {
+			continue
+		}
+
+		listPrice, ok := priceList.PriceFor(productID, variantID, quantity, basePrice)
+		if ok && (!found || listPrice < price) {
+			price = listPrice
+			found = true
+		}
+	}
+
+	return price
+}
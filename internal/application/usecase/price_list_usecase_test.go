@@ -0,0 +1,196 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+type priceListFixture struct {
+	priceListUseCase *usecase.PriceListUseCase
+	priceListRepo    repository.PriceListRepository
+	userRepo         repository.UserRepository
+	productRepo      repository.ProductRepository
+	group            *entity.CustomerGroup
+	customer         *entity.User
+	product          *entity.Product
+}
+
+func newPriceListFixture() priceListFixture {
+	priceListRepo := mock.NewMockPriceListRepository()
+	customerGroupRepo := mock.NewMockCustomerGroupRepository()
+	userRepo := mock.NewMockUserRepository()
+	productRepo := mock.NewMockProductRepository()
+	currencyRepo := mock.NewMockCurrencyRepository()
+
+	group, _ := entity.NewCustomerGroup("Wholesale", "B2B accounts")
+	customerGroupRepo.Create(group)
+
+	customer, _ := entity.NewUser("buyer@example.com", "password123", "Bulk", "Buyer", entity.RoleUser)
+	userRepo.Create(customer)
+
+	product, _ := entity.NewProduct("Widget", "A widget", 10000, "USD", 100, 1.0, 1, nil)
+	productRepo.Create(product)
+
+	return priceListFixture{
+		priceListUseCase: usecase.NewPriceListUseCase(priceListRepo, customerGroupRepo, userRepo, productRepo, currencyRepo),
+		priceListRepo:    priceListRepo,
+		userRepo:         userRepo,
+		productRepo:      productRepo,
+		group:            group,
+		customer:         customer,
+		product:          product,
+	}
+}
+
+func TestPriceListUseCase_CreatePriceList(t *testing.T) {
+	t.Run("Create price list with quantity breaks", func(t *testing.T) {
+		f := newPriceListFixture()
+
+		// Execute
+		priceList, err := f.priceListUseCase.CreatePriceList(usecase.PriceListInput{
+			Name:             "Wholesale USD",
+			CurrencyCode:     "usd",
+			CustomerGroupIDs: []uint{f.group.ID},
+			Entries: []usecase.PriceListEntryInput{
+				{ProductID: f.product.ID, Price: 80.00},
+				{ProductID: f.product.ID, MinQuantity: 10, Price: 70.50},
+			},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", priceList.CurrencyCode)
+		assert.True(t, priceList.Active)
+		assert.Equal(t, []uint{f.group.ID}, priceList.CustomerGroupIDs)
+		assert.Len(t, priceList.Entries, 2)
+		assert.Equal(t, 1, priceList.Entries[0].MinQuantity)
+		assert.Equal(t, int64(8000), priceList.Entries[0].Price)
+		assert.Equal(t, int64(7050), priceList.Entries[1].Price)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		f := newPriceListFixture()
+
+		inputs := map[string]usecase.PriceListInput{
+			"unknown currency": {Name: "List", CurrencyCode: "XYZ"},
+			"unknown group":    {Name: "List", CurrencyCode: "USD", CustomerGroupIDs: []uint{99}},
+			"unknown product": {Name: "List", CurrencyCode: "USD", Entries: []usecase.PriceListEntryInput{
+				{ProductID: 99, Price: 10},
+			}},
+			"duplicate entry": {Name: "List", CurrencyCode: "USD", Entries: []usecase.PriceListEntryInput{
+				{ProductID: f.product.ID, Price: 10},
+				{ProductID: f.product.ID, MinQuantity: 1, Price: 9},
+			}},
+			"invalid percentage": {Name: "List", CurrencyCode: "USD", DiscountPercentage: 120},
+		}
+
+		for name, input := range inputs {
+			// Execute
+			priceList, err := f.priceListUseCase.CreatePriceList(input)
+
+			// Assert
+			assert.Error(t, err, name)
+			assert.Nil(t, priceList, name)
+		}
+	})
+}
+
+func TestPriceListUseCase_PricingForUser(t *testing.T) {
+	setup := func() priceListFixture {
+		f := newPriceListFixture()
+		f.priceListUseCase.AssignCustomerGroup(f.customer.ID, &f.group.ID)
+
+		wholesale, _ := entity.NewPriceList("Wholesale USD", "USD", 10, nil, nil)
+		wholesale.SetCustomerGroups([]uint{f.group.ID})
+		wholesale.SetEntries([]entity.PriceListEntry{
+			{ProductID: f.product.ID, MinQuantity: 1, Price: 8000},
+			{ProductID: f.product.ID, MinQuantity: 10, Price: 7000},
+			{ProductID: f.product.ID, VariantID: 5, MinQuantity: 1, Price: 7500},
+		})
+		f.priceListRepo.Create(wholesale)
+
+		return f
+	}
+
+	t.Run("Guest and users without a group pay base prices", func(t *testing.T) {
+		f := setup()
+		other, _ := entity.NewUser("retail@example.com", "password123", "Retail", "Buyer", entity.RoleUser)
+		f.userRepo.Create(other)
+
+		for _, userID := range []uint{0, other.ID} {
+			// Execute
+			pricing, err := f.priceListUseCase.PricingForUser(userID)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, int64(10000), pricing.Price("USD", f.product.ID, 0, 1, 10000))
+		}
+	})
+
+	t.Run("Quantity breaks and variant prices", func(t *testing.T) {
+		f := setup()
+
+		// Execute
+		pricing, err := f.priceListUseCase.PricingForUser(f.customer.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8000), pricing.Price("USD", f.product.ID, 0, 9, 10000))
+		assert.Equal(t, int64(7000), pricing.Price("USD", f.product.ID, 0, 10, 10000))
+		assert.Equal(t, int64(7500), pricing.Price("USD", f.product.ID, 5, 1, 10000))
+		// The product's quantity break applies to variants without their own
+		assert.Equal(t, int64(7000), pricing.Price("USD", f.product.ID, 6, 12, 10000))
+		// Products without an entry get the list percentage
+		assert.Equal(t, int64(4500), pricing.Price("USD", 42, 0, 1, 5000))
+		// Lists only price their own currency
+		assert.Equal(t, int64(9000), pricing.Price("EUR", f.product.ID, 0, 1, 9000))
+	})
+
+	t.Run("Lowest valid price list wins", func(t *testing.T) {
+		f := setup()
+
+		yesterday := time.Now().Add(-24 * time.Hour)
+		expired, _ := entity.NewPriceList("Expired sale", "USD", 50, nil, &yesterday)
+		expired.SetCustomerGroups([]uint{f.group.ID})
+		f.priceListRepo.Create(expired)
+
+		campaign, _ := entity.NewPriceList("Campaign", "USD", 0, &yesterday, nil)
+		campaign.SetCustomerGroups([]uint{f.group.ID})
+		campaign.SetEntries([]entity.PriceListEntry{
+			{ProductID: f.product.ID, MinQuantity: 1, Price: 7800},
+		})
+		f.priceListRepo.Create(campaign)
+
+		// Execute
+		pricing, err := f.priceListUseCase.PricingForUser(f.customer.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7800), pricing.Price("USD", f.product.ID, 0, 1, 10000))
+		assert.Equal(t, int64(7000), pricing.Price("USD", f.product.ID, 0, 10, 10000))
+	})
+
+	t.Run("Cart summary uses group prices", func(t *testing.T) {
+		f := setup()
+		cartRepo := mock.NewMockCartRepository()
+		cartUseCase := usecase.NewCartUseCase(cartRepo, f.productRepo, nil, f.priceListUseCase)
+
+		cart, _ := entity.NewCart(f.customer.ID)
+		cart.AddItem(f.product.ID, 0, 10)
+		cartRepo.Create(cart)
+
+		// Execute
+		summary, err := cartUseCase.GetCartSummary(cart)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7000), summary.Lines[0].Price)
+		assert.Equal(t, int64(70000), summary.Total)
+	})
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"errors"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/money"
@@ -14,6 +15,7 @@ type ProductUseCase struct {
 	categoryRepo       repository.CategoryRepository
 	productVariantRepo repository.ProductVariantRepository
 	currencyRepo       repository.CurrencyRepository
+	priceListUseCase   *PriceListUseCase
 	defaultCurrency    *entity.Currency
 }
 
@@ -23,6 +25,7 @@ func NewProductUseCase(
 	categoryRepo repository.CategoryRepository,
 	productVariantRepo repository.ProductVariantRepository,
 	currencyRepo repository.CurrencyRepository,
+	priceListUseCase *PriceListUseCase,
 ) *ProductUseCase {
 	defaultCurrency, err := currencyRepo.GetDefault()
 	if err != nil {
@@ -34,6 +37,7 @@ func NewProductUseCase(
 		categoryRepo:       categoryRepo,
 		productVariantRepo: productVariantRepo,
 		currencyRepo:       currencyRepo,
+		priceListUseCase:   priceListUseCase,
 		defaultCurrency:    defaultCurrency,
 	}
 }
@@ -42,19 +46,53 @@ func NewProductUseCase(
 type CurrencyPriceInput struct {
 	CurrencyCode string
 	Price        float64
+	Sale         *SalePriceInput
+}
+
+// SalePriceInput contains the compare-at price and scheduled sale of a price (prices in dollars)
+type SalePriceInput struct {
+	CompareAtPrice float64
+	SalePrice      float64
+	SaleStartsAt   *time.Time
+	SaleEndsAt     *time.Time
+}
+
+// priceSchedule converts a sale input to minor units of the currency and validates it against the regular price.
+// A nil input gives no compare-at price and no sale.
+func priceSchedule(input *SalePriceInput, currencyCode string, price int64) (entity.PriceSchedule, error) {
+	if input == nil {
+		return entity.PriceSchedule{}, nil
+	}
+
+	schedule := entity.PriceSchedule{
+		CompareAtPrice: money.FromDecimal(input.CompareAtPrice, currencyCode).Amount,
+		SalePrice:      money.FromDecimal(input.SalePrice, currencyCode).Amount,
+		SaleStartsAt:   input.SaleStartsAt,
+		SaleEndsAt:     input.SaleEndsAt,
+	}
+
+	if err := schedule.ValidateSale(price); err != nil {
+		return entity.PriceSchedule{}, err
+	}
+
+	return schedule, nil
 }
 
 // CreateProductInput contains the data needed to create a product (prices in dollars)
 type CreateProductInput struct {
-	Name           string
-	Description    string
-	Price          float64
-	Stock          int
-	Weight         float64
-	CategoryID     uint
-	Images         []string
-	Variants       []CreateVariantInput
-	CurrencyPrices []CurrencyPriceInput
+	Name              string
+	Description       string
+	Price             float64
+	Stock             int
+	Weight            float64
+	Dimensions        entity.Dimensions
+	CategoryID        uint
+	TaxClassID        uint // 0 for the standard tax class
+	ShippingProfileID uint // 0 for the category's shipping profile
+	Images            []string
+	Variants          []CreateVariantInput
+	CurrencyPrices    []CurrencyPriceInput
+	Sale              *SalePriceInput
 }
 
 // CreateVariantInput contains the data needed to create a product variant
@@ -65,7 +103,9 @@ type CreateVariantInput struct {
 	Attributes     []entity.VariantAttribute
 	Images         []string
 	IsDefault      bool
+	Dimensions     entity.Dimensions // empty to use the product's dimensions
 	CurrencyPrices []CurrencyPriceInput
+	Sale           *SalePriceInput
 }
 
 // CreateProduct creates a new product
@@ -76,8 +116,8 @@ func (uc *ProductUseCase) CreateProduct(input CreateProductInput) (*entity.Produ
 		return nil, errors.New("category not found")
 	}
 
-	// Convert price to cents
-	priceCents := money.ToCents(input.Price)
+	// Convert price to minor units of the default currency
+	priceCents := money.FromDecimal(input.Price, uc.defaultCurrency.Code).Amount
 
 	// Create product
 	product, err := entity.NewProduct(
@@ -94,6 +134,19 @@ func (uc *ProductUseCase) CreateProduct(input CreateProductInput) (*entity.Produ
 		return nil, err
 	}
 
+	product.TaxClassID = input.TaxClassID
+	product.ShippingProfileID = input.ShippingProfileID
+
+	if err := input.Dimensions.Validate(); err != nil {
+		return nil, err
+	}
+	product.Dimensions = input.Dimensions
+
+	product.PriceSchedule, err = priceSchedule(input.Sale, product.CurrencyCode, product.Price)
+	if err != nil {
+		return nil, err
+	}
+
 	// Process currency-specific prices, if any
 	if len(input.CurrencyPrices) > 0 {
 		product.Prices = make([]entity.ProductPrice, 0, len(input.CurrencyPrices))
@@ -105,12 +158,18 @@ func (uc *ProductUseCase) CreateProduct(input CreateProductInput) (*entity.Produ
 				return nil, errors.New("invalid currency code: " + currPrice.CurrencyCode)
 			}
 
-			// Convert price to cents
-			priceCents := money.ToCents(currPrice.Price)
+			// Convert price to minor units
+			priceCents := money.FromDecimal(currPrice.Price, currPrice.CurrencyCode).Amount
+
+			schedule, err := priceSchedule(currPrice.Sale, currPrice.CurrencyCode, priceCents)
+			if err != nil {
+				return nil, err
+			}
 
 			product.Prices = append(product.Prices, entity.ProductPrice{
-				CurrencyCode: currPrice.CurrencyCode,
-				Price:        priceCents,
+				CurrencyCode:  currPrice.CurrencyCode,
+				Price:         priceCents,
+				PriceSchedule: schedule,
 			})
 		}
 	}
@@ -126,8 +185,8 @@ func (uc *ProductUseCase) CreateProduct(input CreateProductInput) (*entity.Produ
 	if len(input.Variants) > 0 {
 		variants := make([]*entity.ProductVariant, 0, len(input.Variants))
 		for _, variantInput := range input.Variants {
-			// Convert variant prices to cents
-			variantPriceCents := money.ToCents(variantInput.Price)
+			// Convert variant prices to minor units
+			variantPriceCents := money.FromDecimal(variantInput.Price, product.CurrencyCode).Amount
 
 			variant, err := entity.NewProductVariant(
 				product.ID,
@@ -143,6 +202,16 @@ func (uc *ProductUseCase) CreateProduct(input CreateProductInput) (*entity.Produ
 				return nil, err
 			}
 
+			variant.PriceSchedule, err = priceSchedule(variantInput.Sale, variant.CurrencyCode, variant.Price)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := variantInput.Dimensions.Validate(); err != nil {
+				return nil, err
+			}
+			variant.Dimensions = variantInput.Dimensions
+
 			// Process currency-specific prices for variant, if any
 			if len(variantInput.CurrencyPrices) > 0 {
 				variant.Prices = make([]entity.ProductVariantPrice, 0, len(variantInput.CurrencyPrices))
@@ -154,12 +223,18 @@ func (uc *ProductUseCase) CreateProduct(input CreateProductInput) (*entity.Produ
 						return nil, errors.New("invalid currency code: " + currPrice.CurrencyCode)
 					}
 
-					// Convert price to cents
-					priceCents := money.ToCents(currPrice.Price)
+					// Convert price to minor units
+					priceCents := money.FromDecimal(currPrice.Price, currPrice.CurrencyCode).Amount
+
+					schedule, err := priceSchedule(currPrice.Sale, currPrice.CurrencyCode, priceCents)
+					if err != nil {
+						return nil, err
+					}
 
 					variant.Prices = append(variant.Prices, entity.ProductVariantPrice{
-						CurrencyCode: currPrice.CurrencyCode,
-						Price:        priceCents,
+						CurrencyCode:  currPrice.CurrencyCode,
+						Price:         priceCents,
+						PriceSchedule: schedule,
 					})
 				}
 			}
@@ -205,13 +280,16 @@ func (uc *ProductUseCase) GetProductByID(id uint, currencyCode string) (*entity.
 		return nil, errors.New("invalid currency code: " + currencyCode)
 	}
 
-	currencyPrice, found := product.GetPriceInCurrency(currency.Code)
-	if found {
-		product.Price = currencyPrice
-	} else {
-		product.Price = uc.defaultCurrency.ConvertAmount(currencyPrice, currency)
+	// Resolve the prices at the current time so the product carries the effective and compare-at price
+	price, compareAt, found := product.PriceInCurrencyAt(currency.Code, time.Now())
+	if !found {
+		price = uc.defaultCurrency.ConvertAmount(price, currency)
+		compareAt = uc.defaultCurrency.ConvertAmount(compareAt, currency)
 	}
 
+	product.Price = price
+	product.PriceSchedule = entity.PriceSchedule{CompareAtPrice: compareAt}
+
 	product.CurrencyCode = currency.Code
 
 	return product, nil
@@ -219,14 +297,18 @@ func (uc *ProductUseCase) GetProductByID(id uint, currencyCode string) (*entity.
 
 // UpdateProductInput contains the data needed to update a product (prices in dollars)
 type UpdateProductInput struct {
-	Name           string
-	Description    string
-	Price          float64
-	Stock          int
-	CategoryID     uint
-	Images         []string
-	CurrencyPrices []CurrencyPriceInput
-	Active         bool
+	Name              string
+	Description       string
+	Price             float64
+	Stock             int
+	CategoryID        uint
+	TaxClassID        *uint // nil keeps the current tax class, 0 selects the standard tax class
+	ShippingProfileID *uint // nil keeps the current shipping profile, 0 selects the category's shipping profile
+	Images            []string
+	Dimensions        *entity.Dimensions // nil keeps the current dimensions
+	CurrencyPrices    []CurrencyPriceInput
+	Sale              *SalePriceInput // nil keeps the current sale settings
+	Active            bool
 }
 
 // UpdateProduct updates a product
@@ -246,6 +328,14 @@ func (uc *ProductUseCase) UpdateProduct(id uint, input UpdateProductInput) (*ent
 		product.CategoryID = input.CategoryID
 	}
 
+	if input.TaxClassID != nil {
+		product.TaxClassID = *input.TaxClassID
+	}
+
+	if input.ShippingProfileID != nil {
+		product.ShippingProfileID = *input.ShippingProfileID
+	}
+
 	// Update product fields
 	if input.Name != "" {
 		product.Name = input.Name
@@ -254,7 +344,7 @@ func (uc *ProductUseCase) UpdateProduct(id uint, input UpdateProductInput) (*ent
 		product.Description = input.Description
 	}
 	if input.Price > 0 && !product.HasVariants {
-		product.Price = money.ToCents(input.Price) // Convert to cents
+		product.Price = money.FromDecimal(input.Price, product.CurrencyCode).Amount
 	}
 	if input.Stock >= 0 && !product.HasVariants {
 		product.Stock = input.Stock
@@ -262,9 +352,23 @@ func (uc *ProductUseCase) UpdateProduct(id uint, input UpdateProductInput) (*ent
 	if len(input.Images) > 0 {
 		product.Images = input.Images
 	}
+	if input.Dimensions != nil {
+		if err := input.Dimensions.Validate(); err != nil {
+			return nil, err
+		}
+		product.Dimensions = *input.Dimensions
+	}
 	if input.Active != product.Active {
 		product.Active = input.Active
 	}
+	if input.Sale != nil {
+		product.PriceSchedule, err = priceSchedule(input.Sale, product.CurrencyCode, product.Price)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := product.ValidateSale(product.Price); err != nil {
+		return nil, err
+	}
 
 	// Process currency-specific prices, if any
 	if len(input.CurrencyPrices) > 0 {
@@ -278,13 +382,19 @@ func (uc *ProductUseCase) UpdateProduct(id uint, input UpdateProductInput) (*ent
 				return nil, errors.New("invalid currency code: " + currPrice.CurrencyCode)
 			}
 
-			// Convert price to cents
-			priceCents := money.ToCents(currPrice.Price)
+			// Convert price to minor units
+			priceCents := money.FromDecimal(currPrice.Price, currPrice.CurrencyCode).Amount
+
+			schedule, err := priceSchedule(currPrice.Sale, currPrice.CurrencyCode, priceCents)
+			if err != nil {
+				return nil, err
+			}
 
 			product.Prices = append(product.Prices, entity.ProductPrice{
-				ProductID:    product.ID,
-				CurrencyCode: currPrice.CurrencyCode,
-				Price:        priceCents,
+				ProductID:     product.ID,
+				CurrencyCode:  currPrice.CurrencyCode,
+				Price:         priceCents,
+				PriceSchedule: schedule,
 			})
 		}
 	}
@@ -305,7 +415,9 @@ type UpdateVariantInput struct {
 	Attributes     []entity.VariantAttribute
 	Images         []string
 	IsDefault      bool
+	Dimensions     *entity.Dimensions // nil keeps the current dimensions
 	CurrencyPrices []CurrencyPriceInput
+	Sale           *SalePriceInput
 }
 
 // UpdateVariant updates a product variant
@@ -326,7 +438,7 @@ func (uc *ProductUseCase) UpdateVariant(productID uint, variantID uint, input Up
 		variant.SKU = input.SKU
 	}
 	if input.Price > 0 {
-		variant.Price = money.ToCents(input.Price) // Convert to cents
+		variant.Price = money.FromDecimal(input.Price, variant.CurrencyCode).Amount
 	}
 	if input.Stock >= 0 {
 		variant.Stock = input.Stock
@@ -337,6 +449,20 @@ func (uc *ProductUseCase) UpdateVariant(productID uint, variantID uint, input Up
 	if len(input.Images) > 0 {
 		variant.Images = input.Images
 	}
+	if input.Dimensions != nil {
+		if err := input.Dimensions.Validate(); err != nil {
+			return nil, err
+		}
+		variant.Dimensions = *input.Dimensions
+	}
+	if input.Sale != nil {
+		variant.PriceSchedule, err = priceSchedule(input.Sale, variant.CurrencyCode, variant.Price)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := variant.ValidateSale(variant.Price); err != nil {
+		return nil, err
+	}
 
 	// Process currency-specific prices, if any
 	if len(input.CurrencyPrices) > 0 {
@@ -350,13 +476,19 @@ func (uc *ProductUseCase) UpdateVariant(productID uint, variantID uint, input Up
 				return nil, errors.New("invalid currency code: " + currPrice.CurrencyCode)
 			}
 
-			// Convert price to cents
-			priceCents := money.ToCents(currPrice.Price)
+			// Convert price to minor units
+			priceCents := money.FromDecimal(currPrice.Price, currPrice.CurrencyCode).Amount
+
+			schedule, err := priceSchedule(currPrice.Sale, currPrice.CurrencyCode, priceCents)
+			if err != nil {
+				return nil, err
+			}
 
 			variant.Prices = append(variant.Prices, entity.ProductVariantPrice{
-				VariantID:    variant.ID,
-				CurrencyCode: currPrice.CurrencyCode,
-				Price:        priceCents,
+				VariantID:     variant.ID,
+				CurrencyCode:  currPrice.CurrencyCode,
+				Price:         priceCents,
+				PriceSchedule: schedule,
 			})
 		}
 	}
@@ -400,7 +532,9 @@ type AddVariantInput struct {
 	Attributes     []entity.VariantAttribute
 	Images         []string
 	IsDefault      bool
+	Dimensions     entity.Dimensions // empty to use the product's dimensions
 	CurrencyPrices []CurrencyPriceInput
+	Sale           *SalePriceInput
 }
 
 // AddVariant adds a new variant to a product
@@ -410,8 +544,8 @@ func (uc *ProductUseCase) AddVariant(input AddVariantInput) (*entity.ProductVari
 		return nil, err
 	}
 
-	// Convert prices to cents
-	priceCents := money.ToCents(input.Price)
+	// Convert prices to minor units
+	priceCents := money.FromDecimal(input.Price, product.CurrencyCode).Amount
 
 	// Create variant
 	variant, err := entity.NewProductVariant(
@@ -428,6 +562,16 @@ func (uc *ProductUseCase) AddVariant(input AddVariantInput) (*entity.ProductVari
 		return nil, err
 	}
 
+	variant.PriceSchedule, err = priceSchedule(input.Sale, variant.CurrencyCode, variant.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.Dimensions.Validate(); err != nil {
+		return nil, err
+	}
+	variant.Dimensions = input.Dimensions
+
 	// Process currency-specific prices, if any
 	if len(input.CurrencyPrices) > 0 {
 		variant.Prices = make([]entity.ProductVariantPrice, 0, len(input.CurrencyPrices))
@@ -439,12 +583,18 @@ func (uc *ProductUseCase) AddVariant(input AddVariantInput) (*entity.ProductVari
 				return nil, errors.New("invalid currency code: " + currPrice.CurrencyCode)
 			}
 
-			// Convert price to cents
-			priceCents := money.ToCents(currPrice.Price)
+			// Convert price to minor units
+			priceCents := money.FromDecimal(currPrice.Price, currPrice.CurrencyCode).Amount
+
+			schedule, err := priceSchedule(currPrice.Sale, currPrice.CurrencyCode, priceCents)
+			if err != nil {
+				return nil, err
+			}
 
 			variant.Prices = append(variant.Prices, entity.ProductVariantPrice{
-				CurrencyCode: currPrice.CurrencyCode,
-				Price:        priceCents,
+				CurrencyCode:  currPrice.CurrencyCode,
+				Price:         priceCents,
+				PriceSchedule: schedule,
 			})
 		}
 	}
@@ -521,6 +671,7 @@ type SearchProductsInput struct {
 	MinPrice     float64 `json:"min_price"`     // Price in dollars
 	MaxPrice     float64 `json:"max_price"`     // Price in dollars
 	CurrencyCode string  `json:"currency_code"` // Optional currency code for prices
+	UserID       uint    `json:"user_id"`       // Optional customer whose group prices apply
 	Offset       int     `json:"offset"`
 	Limit        int     `json:"limit"`
 }
@@ -538,15 +689,12 @@ func (uc *ProductUseCase) SearchProducts(input SearchProductsInput) ([]*entity.P
 		}
 
 		// Convert min/max prices to default currency using exchange rate
-		defaultPrice := input.MinPrice / currency.ExchangeRate
-		minPriceCents = money.ToCents(defaultPrice)
-
-		defaultPrice = input.MaxPrice / currency.ExchangeRate
-		maxPriceCents = money.ToCents(defaultPrice)
+		minPriceCents = currency.ConvertAmount(money.FromDecimal(input.MinPrice, currency.Code).Amount, uc.defaultCurrency)
+		maxPriceCents = currency.ConvertAmount(money.FromDecimal(input.MaxPrice, currency.Code).Amount, uc.defaultCurrency)
 	} else {
-		// Convert min/max prices to cents for repository search
-		minPriceCents = money.ToCents(input.MinPrice)
-		maxPriceCents = money.ToCents(input.MaxPrice)
+		// Convert min/max prices to minor units for repository search
+		minPriceCents = money.FromDecimal(input.MinPrice, uc.defaultCurrency.Code).Amount
+		maxPriceCents = money.FromDecimal(input.MaxPrice, uc.defaultCurrency.Code).Amount
 	}
 
 	products, err := uc.productRepo.Search(
@@ -571,9 +719,36 @@ func (uc *ProductUseCase) SearchProducts(input SearchProductsInput) ([]*entity.P
 		return products, 0, err
 	}
 
+	// Show the prices of the customer's group
+	if uc.priceListUseCase != nil && input.UserID > 0 {
+		pricing, err := uc.priceListUseCase.PricingForUser(input.UserID)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, product := range products {
+			applyCustomerPricing(pricing, product)
+		}
+	}
+
 	return products, total, nil
 }
 
+// applyCustomerPricing replaces the prices of a product and its variants with customer group prices.
+// Group prices are based on the effective price, and the compare-at price is kept.
+func applyCustomerPricing(pricing *CustomerPricing, product *entity.Product) {
+	now := time.Now()
+
+	price, compareAt := product.Resolve(product.Price, now)
+	product.Price = pricing.Price(product.CurrencyCode, product.ID, 0, 1, price)
+	product.PriceSchedule = entity.PriceSchedule{CompareAtPrice: compareAt}
+
+	for _, variant := range product.Variants {
+		price, compareAt := variant.Resolve(variant.Price, now)
+		variant.Price = pricing.Price(variant.CurrencyCode, product.ID, variant.ID, 1, price)
+		variant.PriceSchedule = entity.PriceSchedule{CompareAtPrice: compareAt}
+	}
+}
+
 // ListProducts lists all products with pagination and returns total count
 func (uc *ProductUseCase) ListProducts(offset, limit int) ([]*entity.Product, int, error) {
 	products, err := uc.productRepo.List(offset, limit)
@@ -594,7 +769,8 @@ func (uc *ProductUseCase) ListCategories() ([]*entity.Category, error) {
 	return uc.categoryRepo.List()
 }
 
-// SetProductCurrencyPrices sets currency-specific prices for a product
+// SetProductCurrencyPrices sets currency-specific prices for a product.
+// A price without a sale keeps the sale settings its currency had.
 func (uc *ProductUseCase) SetProductCurrencyPrices(productID uint, currencyPrices []CurrencyPriceInput) error {
 	// Get product to check ownership
 	product, err := uc.productRepo.GetByID(productID)
@@ -602,6 +778,12 @@ func (uc *ProductUseCase) SetProductCurrencyPrices(productID uint, currencyPrice
 		return err
 	}
 
+	// Remember the sale settings of the current currency prices
+	schedules := make(map[string]entity.PriceSchedule, len(product.Prices))
+	for _, price := range product.Prices {
+		schedules[price.CurrencyCode] = price.PriceSchedule
+	}
+
 	// Clear existing currency prices
 	product.Prices = make([]entity.ProductPrice, 0, len(currencyPrices))
 
@@ -613,13 +795,24 @@ func (uc *ProductUseCase) SetProductCurrencyPrices(productID uint, currencyPrice
 			return errors.New("invalid currency code: " + currPrice.CurrencyCode)
 		}
 
-		// Convert prices to cents
-		priceCents := money.ToCents(currPrice.Price)
+		// Convert prices to minor units
+		priceCents := money.FromDecimal(currPrice.Price, currPrice.CurrencyCode).Amount
+
+		schedule := schedules[currPrice.CurrencyCode]
+		if currPrice.Sale != nil {
+			schedule, err = priceSchedule(currPrice.Sale, currPrice.CurrencyCode, priceCents)
+			if err != nil {
+				return err
+			}
+		} else if err := schedule.ValidateSale(priceCents); err != nil {
+			return err
+		}
 
 		product.Prices = append(product.Prices, entity.ProductPrice{
-			ProductID:    productID,
-			CurrencyCode: currPrice.CurrencyCode,
-			Price:        priceCents,
+			ProductID:     productID,
+			CurrencyCode:  currPrice.CurrencyCode,
+			Price:         priceCents,
+			PriceSchedule: schedule,
 		})
 	}
 
@@ -627,7 +820,8 @@ func (uc *ProductUseCase) SetProductCurrencyPrices(productID uint, currencyPrice
 	return uc.productRepo.Update(product)
 }
 
-// SetVariantCurrencyPrices sets currency-specific prices for a product variant
+// SetVariantCurrencyPrices sets currency-specific prices for a product variant.
+// A price without a sale keeps the sale settings its currency had.
 func (uc *ProductUseCase) SetVariantCurrencyPrices(productID uint, variantID uint, currencyPrices []CurrencyPriceInput) error {
 	// Get variant
 	variant, err := uc.productVariantRepo.GetByID(variantID)
@@ -635,6 +829,12 @@ func (uc *ProductUseCase) SetVariantCurrencyPrices(productID uint, variantID uin
 		return err
 	}
 
+	// Remember the sale settings of the current currency prices
+	schedules := make(map[string]entity.PriceSchedule, len(variant.Prices))
+	for _, price := range variant.Prices {
+		schedules[price.CurrencyCode] = price.PriceSchedule
+	}
+
 	// Clear existing currency prices
 	variant.Prices = make([]entity.ProductVariantPrice, 0, len(currencyPrices))
 
@@ -646,13 +846,24 @@ func (uc *ProductUseCase) SetVariantCurrencyPrices(productID uint, variantID uin
 			return errors.New("invalid currency code: " + currPrice.CurrencyCode)
 		}
 
-		// Convert prices to cents
-		priceCents := money.ToCents(currPrice.Price)
+		// Convert prices to minor units
+		priceCents := money.FromDecimal(currPrice.Price, currPrice.CurrencyCode).Amount
+
+		schedule := schedules[currPrice.CurrencyCode]
+		if currPrice.Sale != nil {
+			schedule, err = priceSchedule(currPrice.Sale, currPrice.CurrencyCode, priceCents)
+			if err != nil {
+				return err
+			}
+		} else if err := schedule.ValidateSale(priceCents); err != nil {
+			return err
+		}
 
 		variant.Prices = append(variant.Prices, entity.ProductVariantPrice{
-			VariantID:    variantID,
-			CurrencyCode: currPrice.CurrencyCode,
-			Price:        priceCents,
+			VariantID:     variantID,
+			CurrencyCode:  currPrice.CurrencyCode,
+			Price:         priceCents,
+			PriceSchedule: schedule,
 		})
 	}
 
@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
@@ -31,6 +32,7 @@ func TestProductUseCase_CreateProduct(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Create product input
@@ -78,6 +80,7 @@ func TestProductUseCase_CreateProduct(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Create product input with variants
@@ -135,6 +138,7 @@ func TestProductUseCase_CreateProduct(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Create product input with invalid category
@@ -155,6 +159,40 @@ func TestProductUseCase_CreateProduct(t *testing.T) {
 		assert.Nil(t, product)
 		assert.Contains(t, err.Error(), "category not found")
 	})
+
+	t.Run("Create product with dimensions", func(t *testing.T) {
+		// Setup mocks
+		categoryRepo := mock.NewMockCategoryRepository()
+		categoryRepo.Create(&entity.Category{ID: 1, Name: "Test Category"})
+		productUseCase := usecase.NewProductUseCase(
+			mock.NewMockProductRepository(),
+			categoryRepo,
+			mock.NewMockProductVariantRepository(),
+			mock.NewMockCurrencyRepository(),
+			nil,
+		)
+
+		input := usecase.CreateProductInput{
+			Name:       "Boxed Product",
+			Price:      19.99,
+			Stock:      10,
+			Weight:     0.8,
+			Dimensions: entity.Dimensions{Length: 30, Width: 20, Height: 10},
+			CategoryID: 1,
+		}
+
+		// Execute
+		product, err := productUseCase.CreateProduct(input)
+
+		input.Dimensions = entity.Dimensions{Length: 30, Width: 20}
+		_, invalidErr := productUseCase.CreateProduct(input)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, input.Weight, product.Weight)
+		assert.Equal(t, entity.Dimensions{Length: 30, Width: 20, Height: 10}, product.Dimensions)
+		assert.EqualError(t, invalidErr, "length, width and height must all be set")
+	})
 }
 
 func TestProductUseCase_GetProductByID(t *testing.T) {
@@ -183,6 +221,7 @@ func TestProductUseCase_GetProductByID(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Execute
@@ -207,6 +246,7 @@ func TestProductUseCase_GetProductByID(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Execute with non-existent ID
@@ -253,6 +293,7 @@ func TestProductUseCase_GetProductByID(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Execute
@@ -304,6 +345,7 @@ func TestProductUseCase_GetProductByID(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Execute
@@ -341,6 +383,7 @@ func TestProductUseCase_GetProductByID(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Execute
@@ -390,6 +433,7 @@ func TestProductUseCase_UpdateProduct(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Update input
@@ -442,6 +486,7 @@ func TestProductUseCase_AddVariant(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Add variant input
@@ -536,6 +581,7 @@ func TestProductUseCase_UpdateVariant(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Update variant input
@@ -627,6 +673,7 @@ func TestProductUseCase_DeleteVariant(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Execute - delete the non-default variant
@@ -701,6 +748,7 @@ func TestProductUseCase_DeleteVariant(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Execute - delete the default variant
@@ -813,6 +861,7 @@ func TestProductUseCase_SearchProducts(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Search by shirt
@@ -885,6 +934,7 @@ func TestProductUseCase_DeleteProduct(t *testing.T) {
 			categoryRepo,
 			productVariantRepo,
 			currencyRepo,
+			nil,
 		)
 
 		// Execute
@@ -901,3 +951,163 @@ func TestProductUseCase_DeleteProduct(t *testing.T) {
 		assert.Nil(t, deletedProduct)
 	})
 }
+
+func TestProductUseCase_SalePrices(t *testing.T) {
+	setup := func() *usecase.ProductUseCase {
+		categoryRepo := mock.NewMockCategoryRepository()
+		categoryRepo.Create(&entity.Category{ID: 1, Name: "Test Category"})
+
+		currencyRepo := mock.NewMockCurrencyRepository()
+		currencyRepo.Create(&entity.Currency{Code: "EUR", ExchangeRate: 0.5, IsEnabled: true})
+
+		return usecase.NewProductUseCase(
+			mock.NewMockProductRepository(),
+			categoryRepo,
+			mock.NewMockProductVariantRepository(),
+			currencyRepo,
+			nil,
+		)
+	}
+
+	yesterday := time.Now().Add(-24 * time.Hour)
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	t.Run("Active sale is the effective price", func(t *testing.T) {
+		productUseCase := setup()
+
+		// Execute
+		product, err := productUseCase.CreateProduct(usecase.CreateProductInput{
+			Name:       "Sale Product",
+			Price:      100.00,
+			Stock:      10,
+			CategoryID: 1,
+			Sale:       &usecase.SalePriceInput{SalePrice: 75.00, SaleStartsAt: &yesterday, SaleEndsAt: &tomorrow},
+		})
+		assert.NoError(t, err)
+
+		result, err := productUseCase.GetProductByID(product.ID, "USD")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7500), result.Price)
+		assert.Equal(t, int64(10000), result.CompareAtPrice)
+
+		// Converted prices keep the strike-through price
+		result, err = productUseCase.GetProductByID(product.ID, "EUR")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3750), result.Price)
+		assert.Equal(t, int64(5000), result.CompareAtPrice)
+	})
+
+	t.Run("Scheduled sale has not started", func(t *testing.T) {
+		productUseCase := setup()
+
+		product, _ := productUseCase.CreateProduct(usecase.CreateProductInput{
+			Name:       "Upcoming Sale",
+			Price:      100.00,
+			Stock:      10,
+			CategoryID: 1,
+			Sale:       &usecase.SalePriceInput{CompareAtPrice: 120.00, SalePrice: 75.00, SaleStartsAt: &tomorrow},
+		})
+
+		// The sale price applies once the sale starts
+		assert.Equal(t, int64(7500), product.EffectivePrice(tomorrow.Add(time.Minute)))
+
+		// Execute
+		result, err := productUseCase.GetProductByID(product.ID, "USD")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10000), result.Price)
+		assert.Equal(t, int64(12000), result.CompareAtPrice)
+	})
+
+	t.Run("Currency price with its own sale", func(t *testing.T) {
+		productUseCase := setup()
+
+		product, err := productUseCase.CreateProduct(usecase.CreateProductInput{
+			Name:       "Euro Sale",
+			Price:      100.00,
+			Stock:      10,
+			CategoryID: 1,
+			CurrencyPrices: []usecase.CurrencyPriceInput{
+				{CurrencyCode: "EUR", Price: 90.00, Sale: &usecase.SalePriceInput{SalePrice: 60.00, SaleEndsAt: &tomorrow}},
+			},
+		})
+		assert.NoError(t, err)
+
+		// Execute
+		result, err := productUseCase.GetProductByID(product.ID, "EUR")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6000), result.Price)
+		assert.Equal(t, int64(9000), result.CompareAtPrice)
+	})
+
+	t.Run("Setting currency prices keeps their sale", func(t *testing.T) {
+		productUseCase := setup()
+
+		product, err := productUseCase.CreateProduct(usecase.CreateProductInput{
+			Name:       "Euro Sale",
+			Price:      100.00,
+			Stock:      10,
+			CategoryID: 1,
+			CurrencyPrices: []usecase.CurrencyPriceInput{
+				{CurrencyCode: "EUR", Price: 90.00, Sale: &usecase.SalePriceInput{SalePrice: 60.00, SaleEndsAt: &tomorrow}},
+			},
+		})
+		assert.NoError(t, err)
+
+		// Execute
+		err = productUseCase.SetProductCurrencyPrices(product.ID, []usecase.CurrencyPriceInput{
+			{CurrencyCode: "EUR", Price: 80.00},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		result, _ := productUseCase.GetProductByID(product.ID, "EUR")
+		assert.Equal(t, int64(6000), result.Price)
+		assert.Equal(t, int64(8000), result.CompareAtPrice)
+
+		// A new sale replaces the current one
+		err = productUseCase.SetProductCurrencyPrices(product.ID, []usecase.CurrencyPriceInput{
+			{CurrencyCode: "EUR", Price: 80.00, Sale: &usecase.SalePriceInput{SalePrice: 70.00}},
+		})
+		assert.NoError(t, err)
+		result, _ = productUseCase.GetProductByID(product.ID, "EUR")
+		assert.Equal(t, int64(7000), result.Price)
+
+		// The kept sale must stay below the new price
+		err = productUseCase.SetProductCurrencyPrices(product.ID, []usecase.CurrencyPriceInput{
+			{CurrencyCode: "EUR", Price: 65.00},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid sale", func(t *testing.T) {
+		productUseCase := setup()
+
+		sales := map[string]*usecase.SalePriceInput{
+			"sale above price":    {SalePrice: 150.00},
+			"negative compare-at": {CompareAtPrice: -1},
+			"window without sale": {SaleStartsAt: &yesterday},
+			"end before start":    {SalePrice: 50.00, SaleStartsAt: &tomorrow, SaleEndsAt: &yesterday},
+		}
+
+		for name, sale := range sales {
+			// Execute
+			product, err := productUseCase.CreateProduct(usecase.CreateProductInput{
+				Name:       "Invalid Sale",
+				Price:      100.00,
+				Stock:      10,
+				CategoryID: 1,
+				Sale:       sale,
+			})
+
+			// Assert
+			assert.Error(t, err, name)
+			assert.Nil(t, product, name)
+		}
+	})
+}
@@ -0,0 +1,410 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// DiscrepancyKind describes how an order's payment differs from its provider's records
+type DiscrepancyKind string
+
+const (
+	// Fixed automatically, the provider's records are taken as the truth
+	DiscrepancyUnrecordedPayment      DiscrepancyKind = "unrecorded_payment"      // paid at the provider, order still awaiting payment
+	DiscrepancyUnrecordedCapture      DiscrepancyKind = "unrecorded_capture"      // captured at the provider, no capture recorded
+	DiscrepancyUnrecordedCancellation DiscrepancyKind = "unrecorded_cancellation" // cancelled at the provider, order still awaiting payment
+
+	// Reported for review
+	DiscrepancyAmountMismatch  DiscrepancyKind = "amount_mismatch"  // payment amount differs from the order total
+	DiscrepancyMissingPayment  DiscrepancyKind = "missing_payment"  // paid order whose payment is pending or cancelled at the provider
+	DiscrepancyCaptureMismatch DiscrepancyKind = "capture_mismatch" // captured amounts differ
+	DiscrepancyRefundMismatch  DiscrepancyKind = "refund_mismatch"  // refunded amounts differ
+	DiscrepancyProviderError   DiscrepancyKind = "provider_error"   // the provider couldn't be asked
+	DiscrepancyLedgerError     DiscrepancyKind = "ledger_error"     // the order or its recorded transactions couldn't be read
+)
+
+// reconciliationPageSize is the number of orders listed at a time
+const reconciliationPageSize = 100
+
+// Discrepancy is a difference between an order's payment and its provider's records
+type Discrepancy struct {
+	OrderID     uint               `json:"order_id"`
+	OrderNumber string             `json:"order_number"`
+	OrderStatus entity.OrderStatus `json:"order_status"`
+	Provider    string             `json:"provider"`
+	PaymentID   string             `json:"payment_id"`
+	Kind        DiscrepancyKind    `json:"kind"`
+	Detail      string             `json:"detail"`
+	Fixable     bool               `json:"fixable"` // safe to fix automatically
+	Fixed       bool               `json:"fixed"`
+}
+
+// ReconciliationReport is the outcome of reconciling orders with their payment providers
+type ReconciliationReport struct {
+	StartedAt     time.Time      `json:"started_at"`
+	DryRun        bool           `json:"dry_run"`
+	Checked       int            `json:"checked"`
+	Discrepancies []*Discrepancy `json:"discrepancies"`
+}
+
+// Fixed returns the discrepancies that were fixed
+func (r *ReconciliationReport) Fixed() []*Discrepancy {
+	var fixed []*Discrepancy
+	for _, discrepancy := range r.Discrepancies {
+		if discrepancy.Fixed {
+			fixed = append(fixed, discrepancy)
+		}
+	}
+	return fixed
+}
+
+// Unresolved returns the discrepancies that need review, or were left alone in a dry run
+func (r *ReconciliationReport) Unresolved() []*Discrepancy {
+	var unresolved []*Discrepancy
+	for _, discrepancy := range r.Discrepancies {
+		if !discrepancy.Fixed {
+			unresolved = append(unresolved, discrepancy)
+		}
+	}
+	return unresolved
+}
+
+// ReconcileInput contains the orders to reconcile
+type ReconcileInput struct {
+	StaleAfter time.Duration // orders awaiting payment are checked once untouched this long
+	Lookback   time.Duration // only orders placed this recently are checked
+	DryRun     bool          // report the discrepancies without fixing any
+}
+
+// ReconciliationUseCase implements reconciling orders with the records of their payment providers,
+// for when webhooks are lost
+type ReconciliationUseCase struct {
+	orderUseCase   *OrderUseCase
+	orderRepo      repository.OrderRepository
+	paymentTxnRepo repository.PaymentTransactionRepository
+	statusProvider service.PaymentStatusProvider
+}
+
+// NewReconciliationUseCase creates a new ReconciliationUseCase
+func NewReconciliationUseCase(
+	orderUseCase *OrderUseCase,
+	orderRepo repository.OrderRepository,
+	paymentTxnRepo repository.PaymentTransactionRepository,
+	statusProvider service.PaymentStatusProvider,
+) *ReconciliationUseCase {
+	return &ReconciliationUseCase{
+		orderUseCase:   orderUseCase,
+		orderRepo:      orderRepo,
+		paymentTxnRepo: paymentTxnRepo,
+		statusProvider: statusProvider,
+	}
+}
+
+// Reconcile compares the orders awaiting payment that went stale, and the paid orders, with their
+// providers' records and the recorded payment transactions. Discrepancies where the provider has simply
+// moved on, because a webhook never arrived, are fixed; all others are reported.
+func (uc *ReconciliationUseCase) Reconcile(input ReconcileInput) (*ReconciliationReport, error) {
+	report := &ReconciliationReport{
+		StartedAt:     time.Now(),
+		DryRun:        input.DryRun,
+		Discrepancies: []*Discrepancy{},
+	}
+	placedAfter := report.StartedAt.Add(-input.Lookback)
+	staleBefore := report.StartedAt.Add(-input.StaleAfter)
+
+	statuses := []entity.OrderStatus{
+		entity.OrderStatusPending,
+		entity.OrderStatusPendingAction,
+		entity.OrderStatusPaid,
+		entity.OrderStatusCaptured,
+		entity.OrderStatusShipped,
+	}
+
+	// Collect the orders first, as fixed orders move to another status while paging
+	var orders []*entity.Order
+	for _, status := range statuses {
+		statusOrders, err := uc.listPlacedAfter(status, placedAfter)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, statusOrders...)
+	}
+
+	for _, listed := range orders {
+		if listed.PaymentID == "" || listed.PaymentProvider == "" {
+			continue
+		}
+		awaitingPayment := listed.Status == entity.OrderStatusPending || listed.Status == entity.OrderStatusPendingAction
+		if awaitingPayment && listed.UpdatedAt.After(staleBefore) {
+			continue
+		}
+
+		report.Checked++
+
+		// Listed orders lack the totals to compare, and fixes must not save them without their shipping details
+		order, err := uc.orderRepo.GetByID(listed.ID)
+		if err != nil {
+			report.Discrepancies = append(report.Discrepancies, &Discrepancy{
+				OrderID:     listed.ID,
+				OrderNumber: listed.OrderNumber,
+				OrderStatus: listed.Status,
+				Provider:    listed.PaymentProvider,
+				PaymentID:   listed.PaymentID,
+				Kind:        DiscrepancyLedgerError,
+				Detail:      fmt.Sprintf("failed to load order: %v", err),
+			})
+			continue
+		}
+
+		report.Discrepancies = append(report.Discrepancies, uc.reconcileOrder(order, input.DryRun)...)
+	}
+
+	return report, nil
+}
+
+// listPlacedAfter lists the orders of a status placed after a time, the newest first
+func (uc *ReconciliationUseCase) listPlacedAfter(status entity.OrderStatus, placedAfter time.Time) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	for offset := 0; ; offset += reconciliationPageSize {
+		page, err := uc.orderRepo.ListByStatus(status, offset, reconciliationPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range page {
+			if order.CreatedAt.Before(placedAfter) {
+				return orders, nil
+			}
+			orders = append(orders, order)
+		}
+		if len(page) < reconciliationPageSize {
+			return orders, nil
+		}
+	}
+}
+
+// reconcileOrder compares an order with its provider's records and the recorded transactions
+func (uc *ReconciliationUseCase) reconcileOrder(order *entity.Order, dryRun bool) []*Discrepancy {
+	discrepancy := func(kind DiscrepancyKind, format string, args ...any) *Discrepancy {
+		return &Discrepancy{
+			OrderID:     order.ID,
+			OrderNumber: order.OrderNumber,
+			OrderStatus: order.Status,
+			Provider:    order.PaymentProvider,
+			PaymentID:   order.PaymentID,
+			Kind:        kind,
+			Detail:      fmt.Sprintf(format, args...),
+		}
+	}
+
+	status, err := uc.statusProvider.GetPaymentStatus(order.PaymentID, service.PaymentProviderType(order.PaymentProvider))
+	if err != nil {
+		return []*Discrepancy{discrepancy(DiscrepancyProviderError, "%v", err)}
+	}
+
+	captured, err := uc.paymentTxnRepo.SumAmountByOrderIDAndType(order.ID, entity.TransactionTypeCapture)
+	if err != nil {
+		return []*Discrepancy{discrepancy(DiscrepancyLedgerError, "failed to sum captures: %v", err)}
+	}
+	refunded, err := uc.paymentTxnRepo.SumAmountByOrderIDAndType(order.ID, entity.TransactionTypeRefund)
+	if err != nil {
+		return []*Discrepancy{discrepancy(DiscrepancyLedgerError, "failed to sum refunds: %v", err)}
+	}
+
+	// Providers that only verify payments don't report amounts to compare
+	amountsReported := status.Amount > 0
+
+	// A payment of another amount than the order's is never fixed automatically
+	if amountsReported && status.Amount != order.FinalAmount {
+		return []*Discrepancy{discrepancy(DiscrepancyAmountMismatch,
+			"provider has a payment of %d, the order total is %d", status.Amount, order.FinalAmount)}
+	}
+
+	var found []*Discrepancy
+	fix := func(d *Discrepancy, apply func() error) {
+		d.Fixable = true
+		if !dryRun {
+			if err := apply(); err != nil {
+				d.Detail += fmt.Sprintf(": fix failed: %v", err)
+			} else {
+				d.Fixed = true
+			}
+		}
+		found = append(found, d)
+	}
+
+	switch order.Status {
+	case entity.OrderStatusPending, entity.OrderStatusPendingAction:
+		switch status.State {
+		case service.PaymentStateAuthorized, service.PaymentStateCaptured:
+			fix(discrepancy(DiscrepancyUnrecordedPayment, "payment is %s at the provider", status.State), func() error {
+				return uc.markPaid(order, status)
+			})
+		case service.PaymentStateCancelled:
+			// Orders pending after a failed payment may be paid again, only those awaiting the customer are cancelled
+			if order.Status == entity.OrderStatusPendingAction {
+				fix(discrepancy(DiscrepancyUnrecordedCancellation, "payment is cancelled at the provider"), func() error {
+					return uc.cancelOrder(order)
+				})
+			}
+		}
+
+	default:
+		switch status.State {
+		case service.PaymentStatePending, service.PaymentStateCancelled:
+			if captured == 0 {
+				found = append(found, discrepancy(DiscrepancyMissingPayment, "payment is %s at the provider", status.State))
+			} else {
+				found = append(found, discrepancy(DiscrepancyCaptureMismatch,
+					"payment is %s at the provider, %d is recorded as captured", status.State, captured))
+			}
+		case service.PaymentStateAuthorized:
+			if amountsReported && captured > 0 {
+				found = append(found, discrepancy(DiscrepancyCaptureMismatch,
+					"payment is not captured at the provider, %d is recorded as captured", captured))
+			}
+		case service.PaymentStateCaptured:
+			if captured == 0 && status.CapturedAmount > 0 {
+				fix(discrepancy(DiscrepancyUnrecordedCapture, "%d is captured at the provider", status.CapturedAmount), func() error {
+					return uc.recordCapture(order, status.CapturedAmount)
+				})
+			} else if status.CapturedAmount > 0 && captured != status.CapturedAmount {
+				found = append(found, discrepancy(DiscrepancyCaptureMismatch,
+					"%d is captured at the provider, %d is recorded", status.CapturedAmount, captured))
+			}
+		}
+
+		if amountsReported && refunded != status.RefundedAmount {
+			found = append(found, discrepancy(DiscrepancyRefundMismatch,
+				"%d is refunded at the provider, %d is recorded", status.RefundedAmount, refunded))
+		}
+	}
+
+	return found
+}
+
+// markPaid marks an order paid whose payment webhook never arrived, completing its authorization transaction
+func (uc *ReconciliationUseCase) markPaid(order *entity.Order, status *service.PaymentStatus) error {
+	metadata := map[string]string{"reconciled": "true"}
+
+	if status.PaymentID != "" && status.PaymentID != order.PaymentID {
+		// A hosted payment session was paid, continue with the payment it resulted in
+		paid, err := uc.orderUseCase.CompleteHostedPayment(order.ID, order.PaymentID, status.PaymentID)
+		if err != nil {
+			return err
+		}
+		*order = *paid
+	} else {
+		if err := uc.orderUseCase.UpdatePaymentTransaction(order.PaymentID, entity.TransactionStatusSuccessful, metadata); err != nil {
+			currencyCode, err := uc.orderUseCase.orderCurrencyCode(order)
+			if err != nil {
+				return err
+			}
+
+			txn, err := entity.NewPaymentTransaction(
+				order.ID,
+				order.PaymentID,
+				entity.TransactionTypeAuthorize,
+				entity.TransactionStatusSuccessful,
+				order.FinalAmount,
+				currencyCode,
+				order.PaymentProvider,
+			)
+			if err != nil {
+				return err
+			}
+			for key, value := range metadata {
+				txn.AddMetadata(key, value)
+			}
+			if err := uc.paymentTxnRepo.Create(txn); err != nil {
+				return err
+			}
+		}
+
+		paid, err := uc.orderUseCase.UpdateOrderStatus(UpdateOrderStatusInput{OrderID: order.ID, Status: entity.OrderStatusPaid})
+		if err != nil {
+			return err
+		}
+		*order = *paid
+	}
+
+	if status.State == service.PaymentStateCaptured && status.CapturedAmount > 0 {
+		return uc.recordCapture(order, status.CapturedAmount)
+	}
+	return nil
+}
+
+// recordCapture records a capture made at the provider whose webhook never arrived
+func (uc *ReconciliationUseCase) recordCapture(order *entity.Order, amount int64) error {
+	currencyCode, err := uc.orderUseCase.orderCurrencyCode(order)
+	if err != nil {
+		return err
+	}
+
+	txn, err := entity.NewPaymentTransaction(
+		order.ID,
+		order.PaymentID,
+		entity.TransactionTypeCapture,
+		entity.TransactionStatusSuccessful,
+		amount,
+		currencyCode,
+		order.PaymentProvider,
+	)
+	if err != nil {
+		return err
+	}
+	txn.AddMetadata("reconciled", "true")
+	txn.AddMetadata("full_capture", fmt.Sprintf("%t", amount >= order.FinalAmount))
+
+	if err := uc.paymentTxnRepo.Create(txn); err != nil {
+		return err
+	}
+
+	// Orders paid before authorizations were tracked hold their final amount
+	if order.AuthorizedAmount == 0 && order.CapturedAmount == 0 {
+		order.AuthorizedAmount = order.FinalAmount
+	}
+	if capturable := min(amount, order.RemainingCapturable()); capturable > 0 {
+		if err := order.RecordCapture(capturable, false); err != nil {
+			return err
+		}
+		if err := uc.orderRepo.Update(order); err != nil {
+			return err
+		}
+	}
+
+	// Orders captured in part stay paid, so the rest can be captured
+	if order.Status != entity.OrderStatusPaid || !order.IsFullyCaptured() {
+		return nil
+	}
+
+	captured, err := uc.orderUseCase.UpdateOrderStatus(UpdateOrderStatusInput{OrderID: order.ID, Status: entity.OrderStatusCaptured})
+	if err != nil {
+		return err
+	}
+	*order = *captured
+	return nil
+}
+
+// cancelOrder cancels an order awaiting the customer whose payment was cancelled at the provider
+func (uc *ReconciliationUseCase) cancelOrder(order *entity.Order) error {
+	if err := order.UpdateStatus(entity.OrderStatusCancelled); err != nil {
+		return err
+	}
+	if err := uc.orderRepo.Update(order); err != nil {
+		return err
+	}
+
+	if err := uc.orderUseCase.UpdatePaymentTransaction(order.PaymentID, entity.TransactionStatusFailed, map[string]string{
+		"reconciled":    "true",
+		"error_message": "payment was cancelled at the provider",
+	}); err != nil {
+		log.Printf("Failed to update payment transaction for payment %s: %v", order.PaymentID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+// fakePaymentStatusProvider reports the payments of a provider by transaction ID
+type fakePaymentStatusProvider struct {
+	statuses map[string]*service.PaymentStatus
+}
+
+func (p *fakePaymentStatusProvider) GetPaymentStatus(transactionID string, provider service.PaymentProviderType) (*service.PaymentStatus, error) {
+	status, exists := p.statuses[transactionID]
+	if !exists {
+		return nil, errors.New("payment not found")
+	}
+	return status, nil
+}
+
+type reconciliationFixture struct {
+	reconciliationUseCase *usecase.ReconciliationUseCase
+	orderRepo             repository.OrderRepository
+	paymentTxnRepo        repository.PaymentTransactionRepository
+	provider              *fakePaymentStatusProvider
+}
+
+func newReconciliationFixture() *reconciliationFixture {
+	orderRepo := mock.NewMockOrderRepository(false)
+	paymentTxnRepo := mock.NewMockPaymentTransactionRepository()
+	provider := &fakePaymentStatusProvider{statuses: map[string]*service.PaymentStatus{}}
+
+	orderUseCase := usecase.NewOrderUseCase(
+		orderRepo,
+		mock.NewMockCartRepository(),
+		mock.NewMockProductRepository(),
+		mock.NewMockUserRepository(),
+		nil,
+		nil,
+		paymentTxnRepo,
+		nil,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	return &reconciliationFixture{
+		reconciliationUseCase: usecase.NewReconciliationUseCase(orderUseCase, orderRepo, paymentTxnRepo, provider),
+		orderRepo:             orderRepo,
+		paymentTxnRepo:        paymentTxnRepo,
+		provider:              provider,
+	}
+}
+
+// addOrder stores an order paid with Stripe that was last updated an hour ago
+func (f *reconciliationFixture) addOrder(status entity.OrderStatus, paymentID string) *entity.Order {
+	address := entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"}
+	order, _ := entity.NewGuestOrder(
+		[]entity.OrderItem{{ProductID: 1, Quantity: 1, Price: 2000, Subtotal: 2000}},
+		address,
+		address,
+		entity.CustomerDetails{Email: "guest@example.com", FullName: "Guest User"},
+	)
+	order.Status = status
+	order.PaymentID = paymentID
+	order.PaymentProvider = string(service.PaymentProviderStripe)
+	order.Currency = "EUR"
+	order.UpdatedAt = time.Now().Add(-time.Hour)
+	f.orderRepo.Create(order)
+	return order
+}
+
+func reconcileInput() usecase.ReconcileInput {
+	return usecase.ReconcileInput{StaleAfter: 30 * time.Minute, Lookback: 24 * time.Hour}
+}
+
+func TestReconciliationUseCase_Reconcile_AwaitingPayment(t *testing.T) {
+	t.Run("Paid at the provider", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		order := f.addOrder(entity.OrderStatusPendingAction, "pi_1")
+		txn, _ := entity.NewPaymentTransaction(order.ID, "pi_1", entity.TransactionTypeAuthorize, entity.TransactionStatusPending, order.FinalAmount, "EUR", "stripe")
+		f.paymentTxnRepo.Create(txn)
+		f.provider.statuses["pi_1"] = &service.PaymentStatus{State: service.PaymentStateAuthorized, Amount: order.FinalAmount}
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(reconcileInput())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Checked)
+		assert.Len(t, report.Fixed(), 1)
+		assert.Equal(t, usecase.DiscrepancyUnrecordedPayment, report.Discrepancies[0].Kind)
+
+		reconciled, _ := f.orderRepo.GetByID(order.ID)
+		assert.Equal(t, entity.OrderStatusPaid, reconciled.Status)
+		authorization, _ := f.paymentTxnRepo.GetByTransactionID("pi_1")
+		assert.Equal(t, entity.TransactionStatusSuccessful, authorization.Status)
+		assert.Equal(t, "true", authorization.Metadata["reconciled"])
+	})
+
+	t.Run("Checkout session paid and captured at the provider", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		order := f.addOrder(entity.OrderStatusPendingAction, "cs_1")
+		f.provider.statuses["cs_1"] = &service.PaymentStatus{
+			State:          service.PaymentStateCaptured,
+			PaymentID:      "pi_1",
+			Amount:         order.FinalAmount,
+			CapturedAmount: order.FinalAmount,
+		}
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(reconcileInput())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, report.Fixed(), 1)
+
+		reconciled, _ := f.orderRepo.GetByID(order.ID)
+		assert.Equal(t, entity.OrderStatusCaptured, reconciled.Status)
+		assert.Equal(t, "pi_1", reconciled.PaymentID)
+		captured, _ := f.paymentTxnRepo.SumAmountByOrderIDAndType(order.ID, entity.TransactionTypeCapture)
+		assert.Equal(t, order.FinalAmount, captured)
+	})
+
+	t.Run("Cancelled at the provider", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		order := f.addOrder(entity.OrderStatusPendingAction, "pi_1")
+		f.provider.statuses["pi_1"] = &service.PaymentStatus{State: service.PaymentStateCancelled, Amount: order.FinalAmount}
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(reconcileInput())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, usecase.DiscrepancyUnrecordedCancellation, report.Discrepancies[0].Kind)
+		reconciled, _ := f.orderRepo.GetByID(order.ID)
+		assert.Equal(t, entity.OrderStatusCancelled, reconciled.Status)
+	})
+
+	t.Run("Still awaiting the customer", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		order := f.addOrder(entity.OrderStatusPendingAction, "pi_1")
+		f.provider.statuses["pi_1"] = &service.PaymentStatus{State: service.PaymentStatePending, Amount: order.FinalAmount}
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(reconcileInput())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Checked)
+		assert.Empty(t, report.Discrepancies)
+	})
+
+	t.Run("Not stale yet", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		f.addOrder(entity.OrderStatusPendingAction, "pi_1")
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(usecase.ReconcileInput{StaleAfter: 2 * time.Hour, Lookback: 24 * time.Hour})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Checked)
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		order := f.addOrder(entity.OrderStatusPendingAction, "pi_1")
+		f.provider.statuses["pi_1"] = &service.PaymentStatus{State: service.PaymentStateAuthorized, Amount: order.FinalAmount}
+
+		input := reconcileInput()
+		input.DryRun = true
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(input)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, report.Fixed())
+		assert.True(t, report.Discrepancies[0].Fixable)
+
+		reconciled, _ := f.orderRepo.GetByID(order.ID)
+		assert.Equal(t, entity.OrderStatusPendingAction, reconciled.Status)
+	})
+}
+
+func TestReconciliationUseCase_Reconcile_PaidOrders(t *testing.T) {
+	t.Run("Capture not recorded", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		order := f.addOrder(entity.OrderStatusPaid, "pi_1")
+		f.provider.statuses["pi_1"] = &service.PaymentStatus{State: service.PaymentStateCaptured, Amount: order.FinalAmount, CapturedAmount: order.FinalAmount}
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(reconcileInput())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, usecase.DiscrepancyUnrecordedCapture, report.Discrepancies[0].Kind)
+		assert.True(t, report.Discrepancies[0].Fixed)
+
+		reconciled, _ := f.orderRepo.GetByID(order.ID)
+		assert.Equal(t, entity.OrderStatusCaptured, reconciled.Status)
+	})
+
+	t.Run("Partial capture not recorded", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		order := f.addOrder(entity.OrderStatusPaid, "pi_1")
+		order.Authorize(7 * 24 * time.Hour)
+		f.orderRepo.Update(order)
+		f.provider.statuses["pi_1"] = &service.PaymentStatus{State: service.PaymentStateCaptured, Amount: order.FinalAmount, CapturedAmount: 1200}
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(reconcileInput())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, report.Discrepancies[0].Fixed)
+
+		reconciled, _ := f.orderRepo.GetByID(order.ID)
+		assert.Equal(t, entity.OrderStatusPaid, reconciled.Status)
+		assert.Equal(t, int64(1200), reconciled.CapturedAmount)
+		assert.Equal(t, order.FinalAmount-1200, reconciled.RemainingCapturable())
+	})
+
+	t.Run("In agreement", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		order := f.addOrder(entity.OrderStatusCaptured, "pi_1")
+		txn, _ := entity.NewPaymentTransaction(order.ID, "pi_1", entity.TransactionTypeCapture, entity.TransactionStatusSuccessful, order.FinalAmount, "EUR", "stripe")
+		f.paymentTxnRepo.Create(txn)
+		f.provider.statuses["pi_1"] = &service.PaymentStatus{State: service.PaymentStateCaptured, Amount: order.FinalAmount, CapturedAmount: order.FinalAmount}
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(reconcileInput())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Checked)
+		assert.Empty(t, report.Discrepancies)
+	})
+
+	t.Run("Reported for review", func(t *testing.T) {
+		// Setup mocks
+		f := newReconciliationFixture()
+		cancelled := f.addOrder(entity.OrderStatusPaid, "pi_cancelled")
+		f.provider.statuses["pi_cancelled"] = &service.PaymentStatus{State: service.PaymentStateCancelled, Amount: cancelled.FinalAmount}
+
+		mismatched := f.addOrder(entity.OrderStatusPaid, "pi_mismatched")
+		f.provider.statuses["pi_mismatched"] = &service.PaymentStatus{State: service.PaymentStateAuthorized, Amount: mismatched.FinalAmount + 100}
+
+		refunded := f.addOrder(entity.OrderStatusCaptured, "pi_refunded")
+		txn, _ := entity.NewPaymentTransaction(refunded.ID, "pi_refunded", entity.TransactionTypeCapture, entity.TransactionStatusSuccessful, refunded.FinalAmount, "EUR", "stripe")
+		f.paymentTxnRepo.Create(txn)
+		f.provider.statuses["pi_refunded"] = &service.PaymentStatus{
+			State:          service.PaymentStateCaptured,
+			Amount:         refunded.FinalAmount,
+			CapturedAmount: refunded.FinalAmount,
+			RefundedAmount: 500,
+		}
+
+		f.addOrder(entity.OrderStatusPaid, "pi_unknown")
+
+		// Execute
+		report, err := f.reconciliationUseCase.Reconcile(reconcileInput())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 4, report.Checked)
+		assert.Empty(t, report.Fixed())
+
+		kinds := map[string]usecase.DiscrepancyKind{}
+		for _, discrepancy := range report.Unresolved() {
+			kinds[discrepancy.PaymentID] = discrepancy.Kind
+		}
+		assert.Equal(t, map[string]usecase.DiscrepancyKind{
+			"pi_cancelled":  usecase.DiscrepancyMissingPayment,
+			"pi_mismatched": usecase.DiscrepancyAmountMismatch,
+			"pi_refunded":   usecase.DiscrepancyRefundMismatch,
+			"pi_unknown":    usecase.DiscrepancyProviderError,
+		}, kinds)
+
+		unchanged, _ := f.orderRepo.GetByID(cancelled.ID)
+		assert.Equal(t, entity.OrderStatusPaid, unchanged.Status)
+	})
+}
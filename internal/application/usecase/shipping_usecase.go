@@ -2,38 +2,95 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/money"
 	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
 )
 
 // ShippingUseCase implements shipping-related use cases
 type ShippingUseCase struct {
-	shippingMethodRepo repository.ShippingMethodRepository
-	shippingZoneRepo   repository.ShippingZoneRepository
-	shippingRateRepo   repository.ShippingRateRepository
+	shippingMethodRepo       repository.ShippingMethodRepository
+	shippingZoneRepo         repository.ShippingZoneRepository
+	shippingRateRepo         repository.ShippingRateRepository
+	shippingPackageRepo      repository.ShippingPackageRepository
+	shippingProfileRepo      repository.ShippingProfileRepository
+	categoryRepo             repository.CategoryRepository
+	discountRepo             repository.DiscountRepository
+	holidayRepo              repository.HolidayRepository
+	dimensionalWeightDivisor float64
+	carriers                 map[string]service.CarrierService
+	pickupPoints             service.PickupPointProvider
+	origin                   DispatchOrigin
 }
 
-// NewShippingUseCase creates a new ShippingUseCase
+// DispatchOrigin is where orders are dispatched from
+type DispatchOrigin struct {
+	Country  string         // Country whose business days orders are handled on, weekdays only if empty
+	Location *time.Location // Time zone of cut-off times, UTC if nil
+}
+
+// NewShippingUseCase creates a new ShippingUseCase.
+// Packages are billed by the greater of their actual weight and their volume divided by dimensionalWeightDivisor.
+// Shipping methods can be backed by any of the carriers to quote live rates and create labels,
+// and deliver to the pickup points of pickupPoints, which is nil if no provider is configured.
+// Deliveries are estimated from the business days of the origin and the public holidays in holidayRepo.
+// Items have the shipping profile of their product, or of their category in categoryRepo if the product has none.
 func NewShippingUseCase(
 	shippingMethodRepo repository.ShippingMethodRepository,
 	shippingZoneRepo repository.ShippingZoneRepository,
 	shippingRateRepo repository.ShippingRateRepository,
+	shippingPackageRepo repository.ShippingPackageRepository,
+	shippingProfileRepo repository.ShippingProfileRepository,
+	categoryRepo repository.CategoryRepository,
+	discountRepo repository.DiscountRepository,
+	holidayRepo repository.HolidayRepository,
+	dimensionalWeightDivisor float64,
+	carriers []service.CarrierService,
+	pickupPoints service.PickupPointProvider,
+	origin DispatchOrigin,
 ) *ShippingUseCase {
+	carrierMap := make(map[string]service.CarrierService, len(carriers))
+	for _, carrier := range carriers {
+		carrierMap[carrier.Name()] = carrier
+	}
+	if origin.Location == nil {
+		origin.Location = time.UTC
+	}
+	origin.Country = strings.ToUpper(origin.Country)
+
 	return &ShippingUseCase{
-		shippingMethodRepo: shippingMethodRepo,
-		shippingZoneRepo:   shippingZoneRepo,
-		shippingRateRepo:   shippingRateRepo,
+		shippingMethodRepo:       shippingMethodRepo,
+		shippingZoneRepo:         shippingZoneRepo,
+		shippingRateRepo:         shippingRateRepo,
+		shippingPackageRepo:      shippingPackageRepo,
+		shippingProfileRepo:      shippingProfileRepo,
+		categoryRepo:             categoryRepo,
+		discountRepo:             discountRepo,
+		holidayRepo:              holidayRepo,
+		dimensionalWeightDivisor: dimensionalWeightDivisor,
+		carriers:                 carrierMap,
+		pickupPoints:             pickupPoints,
+		origin:                   origin,
 	}
 }
 
 // CreateShippingMethodInput contains the data needed to create a shipping method
 type CreateShippingMethodInput struct {
-	Name                  string `json:"name"`
-	Description           string `json:"description"`
-	EstimatedDeliveryDays int    `json:"estimated_delivery_days"`
+	Name                  string                      `json:"name"`
+	Description           string                      `json:"description"`
+	EstimatedDeliveryDays int                         `json:"estimated_delivery_days"`
+	Carrier               string                      `json:"carrier,omitempty"` // omit for table rates only
+	CarrierServiceCode    string                      `json:"carrier_service_code,omitempty"`
+	PickupPoint           bool                        `json:"pickup_point"`          // customers choose a pickup point to collect the parcel at
+	CutoffTime            string                      `json:"cutoff_time,omitempty"` // "HH:MM" in the store's time zone
+	HandlingDays          int                         `json:"handling_days"`
+	Restrictions          entity.ShippingRestrictions `json:"restrictions"`
 }
 
 // CreateShippingMethod creates a new shipping method
@@ -44,6 +101,19 @@ func (uc *ShippingUseCase) CreateShippingMethod(input CreateShippingMethodInput)
 		return nil, err
 	}
 
+	if err := uc.setCarrier(method, input.Carrier, input.CarrierServiceCode); err != nil {
+		return nil, err
+	}
+	if err := uc.setPickupPoint(method, input.PickupPoint); err != nil {
+		return nil, err
+	}
+	if err := method.SetDispatch(input.CutoffTime, input.HandlingDays); err != nil {
+		return nil, err
+	}
+	if err := uc.setRestrictions(method, input.Restrictions); err != nil {
+		return nil, err
+	}
+
 	// Save to repository
 	if err := uc.shippingMethodRepo.Create(method); err != nil {
 		return nil, err
@@ -64,11 +134,17 @@ func (uc *ShippingUseCase) ListShippingMethods(activeOnly bool) ([]*entity.Shipp
 
 // UpdateShippingMethodInput contains the data needed to update a shipping method
 type UpdateShippingMethodInput struct {
-	ID                    uint   `json:"id"`
-	Name                  string `json:"name"`
-	Description           string `json:"description"`
-	EstimatedDeliveryDays int    `json:"estimated_delivery_days"`
-	Active                bool   `json:"active"`
+	ID                    uint                        `json:"id"`
+	Name                  string                      `json:"name"`
+	Description           string                      `json:"description"`
+	EstimatedDeliveryDays int                         `json:"estimated_delivery_days"`
+	Carrier               string                      `json:"carrier,omitempty"` // omit for table rates only
+	CarrierServiceCode    string                      `json:"carrier_service_code,omitempty"`
+	PickupPoint           bool                        `json:"pickup_point"`
+	CutoffTime            string                      `json:"cutoff_time,omitempty"`
+	HandlingDays          int                         `json:"handling_days"`
+	Restrictions          entity.ShippingRestrictions `json:"restrictions"`
+	Active                bool                        `json:"active"`
 }
 
 // UpdateShippingMethod updates a shipping method
@@ -86,6 +162,19 @@ func (uc *ShippingUseCase) UpdateShippingMethod(input UpdateShippingMethodInput)
 	method.Active = input.Active
 	method.UpdatedAt = time.Now()
 
+	if err := uc.setCarrier(method, input.Carrier, input.CarrierServiceCode); err != nil {
+		return nil, err
+	}
+	if err := uc.setPickupPoint(method, input.PickupPoint); err != nil {
+		return nil, err
+	}
+	if err := method.SetDispatch(input.CutoffTime, input.HandlingDays); err != nil {
+		return nil, err
+	}
+	if err := uc.setRestrictions(method, input.Restrictions); err != nil {
+		return nil, err
+	}
+
 	// Save changes
 	if err := uc.shippingMethodRepo.Update(method); err != nil {
 		return nil, err
@@ -94,6 +183,78 @@ func (uc *ShippingUseCase) UpdateShippingMethod(input UpdateShippingMethodInput)
 	return method, nil
 }
 
+// setRestrictions limits the carts a shipping method is offered for to restrictions on existing shipping profiles
+func (uc *ShippingUseCase) setRestrictions(method *entity.ShippingMethod, restrictions entity.ShippingRestrictions) error {
+	if err := restrictions.Validate(); err != nil {
+		return err
+	}
+	for _, profileID := range restrictions.ProfileIDs() {
+		if _, err := uc.shippingProfileRepo.GetByID(profileID); err != nil {
+			return fmt.Errorf("shipping profile %d not found", profileID)
+		}
+	}
+
+	method.Restrictions = restrictions
+	return nil
+}
+
+// setCarrier backs a shipping method by one of the configured carriers
+func (uc *ShippingUseCase) setCarrier(method *entity.ShippingMethod, carrier, serviceCode string) error {
+	if carrier != "" {
+		if _, ok := uc.carriers[carrier]; !ok {
+			return fmt.Errorf("carrier %s is not configured", carrier)
+		}
+	}
+	return method.SetCarrier(carrier, serviceCode)
+}
+
+// setPickupPoint makes a shipping method deliver to pickup points, which requires a pickup point provider
+func (uc *ShippingUseCase) setPickupPoint(method *entity.ShippingMethod, pickupPoint bool) error {
+	if pickupPoint && uc.pickupPoints == nil {
+		return errors.New("pickup points are not configured")
+	}
+	method.PickupPoint = pickupPoint
+	return nil
+}
+
+// ListPickupPoints returns the pickup points of a shipping method nearest to a postal code
+func (uc *ShippingUseCase) ListPickupPoints(methodID uint, postalCode, country string) ([]entity.PickupPoint, error) {
+	if strings.TrimSpace(postalCode) == "" {
+		return nil, errors.New("postal code is required")
+	}
+
+	method, err := uc.shippingMethodRepo.GetByID(methodID)
+	if err != nil || !method.Active {
+		return nil, errors.New("shipping method not found")
+	}
+	if !method.PickupPoint || uc.pickupPoints == nil {
+		return nil, errors.New("shipping method does not deliver to pickup points")
+	}
+
+	return uc.pickupPoints.FindPickupPoints(service.PickupPointQuery{
+		Carrier:     method.Carrier,
+		ServiceCode: method.CarrierServiceCode,
+		PostalCode:  strings.TrimSpace(postalCode),
+		Country:     strings.ToUpper(strings.TrimSpace(country)),
+	})
+}
+
+// GetPickupPoint returns a pickup point a shipping method delivers to
+func (uc *ShippingUseCase) GetPickupPoint(method *entity.ShippingMethod, id string) (*entity.PickupPoint, error) {
+	if !method.PickupPoint || uc.pickupPoints == nil {
+		return nil, errors.New("shipping method does not deliver to pickup points")
+	}
+	if id == "" {
+		return nil, errors.New("pickup point is required for this shipping method")
+	}
+
+	point, err := uc.pickupPoints.GetPickupPoint(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pickup point: %w", err)
+	}
+	return point, nil
+}
+
 // CreateShippingZoneInput contains the data needed to create a shipping zone
 type CreateShippingZoneInput struct {
 	Name        string   `json:"name"`
@@ -111,6 +272,10 @@ func (uc *ShippingUseCase) CreateShippingZone(input CreateShippingZoneInput) (*e
 		return nil, err
 	}
 
+	if err := setZoneAreas(zone, input.Countries, input.States, input.ZipCodes); err != nil {
+		return nil, err
+	}
+
 	// Save to repository
 	if err := uc.shippingZoneRepo.Create(zone); err != nil {
 		return nil, err
@@ -149,11 +314,12 @@ func (uc *ShippingUseCase) UpdateShippingZone(input UpdateShippingZoneInput) (*e
 	}
 
 	// Update fields
-	zone.Name = input.Name
-	zone.Description = input.Description
-	zone.Countries = input.Countries
-	zone.States = input.States
-	zone.ZipCodes = input.ZipCodes
+	if err := zone.Update(input.Name, input.Description); err != nil {
+		return nil, err
+	}
+	if err := setZoneAreas(zone, input.Countries, input.States, input.ZipCodes); err != nil {
+		return nil, err
+	}
 	zone.Active = input.Active
 	zone.UpdatedAt = time.Now()
 
@@ -165,6 +331,284 @@ func (uc *ShippingUseCase) UpdateShippingZone(input UpdateShippingZoneInput) (*e
 	return zone, nil
 }
 
+// setZoneAreas sets the countries, states and postal code patterns of a zone and validates them
+func setZoneAreas(zone *entity.ShippingZone, countries, states, zipCodes []string) error {
+	normalized := make([]string, len(countries))
+	for i, country := range countries {
+		normalized[i] = strings.ToUpper(strings.TrimSpace(country))
+	}
+	if states == nil {
+		states = []string{}
+	}
+	if zipCodes == nil {
+		zipCodes = []string{}
+	}
+
+	zone.SetCountries(normalized)
+	zone.SetStates(states)
+	zone.SetZipCodes(zipCodes)
+
+	return zone.Validate()
+}
+
+// ShippingZoneEvaluation is the outcome of matching an address against one shipping zone
+type ShippingZoneEvaluation struct {
+	ZoneID   uint   `json:"zone_id"`
+	ZoneName string `json:"zone_name"`
+	Active   bool   `json:"active"`
+	entity.ShippingZoneMatch
+}
+
+// ShippingZoneResolution explains which shipping zones an address belongs to
+type ShippingZoneResolution struct {
+	Country        string                    `json:"country"`
+	State          string                    `json:"state"`
+	PostalCode     string                    `json:"postal_code"` // Normalized postal code used for matching
+	MatchedZoneIDs []uint                    `json:"matched_zone_ids"`
+	Zones          []*ShippingZoneEvaluation `json:"zones"`
+}
+
+// ResolveShippingZones matches an address against every shipping zone and explains each outcome.
+// Shipping rates of all matched active zones are offered for the address.
+func (uc *ShippingUseCase) ResolveShippingZones(address entity.Address) (*ShippingZoneResolution, error) {
+	zones, err := uc.shippingZoneRepo.List(false)
+	if err != nil {
+		return nil, err
+	}
+
+	resolution := &ShippingZoneResolution{
+		Country:        strings.ToUpper(address.Country),
+		State:          address.State,
+		PostalCode:     entity.NormalizePostalCode(address.Country, address.PostalCode),
+		MatchedZoneIDs: []uint{},
+		Zones:          make([]*ShippingZoneEvaluation, 0, len(zones)),
+	}
+
+	for _, zone := range zones {
+		evaluation := &ShippingZoneEvaluation{
+			ZoneID:            zone.ID,
+			ZoneName:          zone.Name,
+			Active:            zone.Active,
+			ShippingZoneMatch: zone.MatchAddress(address),
+		}
+		if !zone.Active {
+			evaluation.Matched = false
+			evaluation.Reasons = append(evaluation.Reasons, "zone is inactive")
+		}
+		if evaluation.Matched {
+			resolution.MatchedZoneIDs = append(resolution.MatchedZoneIDs, zone.ID)
+		}
+		resolution.Zones = append(resolution.Zones, evaluation)
+	}
+
+	return resolution, nil
+}
+
+// ShippingPackageInput contains the data needed to create or update a shipping package
+type ShippingPackageInput struct {
+	Name       string            `json:"name"`
+	Dimensions entity.Dimensions `json:"dimensions"`
+	Weight     float64           `json:"weight"`
+	MaxWeight  float64           `json:"max_weight"`
+	Active     bool              `json:"active"`
+}
+
+// CreateShippingPackage creates a new shipping package
+func (uc *ShippingUseCase) CreateShippingPackage(input ShippingPackageInput) (*entity.ShippingPackage, error) {
+	pkg, err := entity.NewShippingPackage(input.Name, input.Dimensions, input.Weight, input.MaxWeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.shippingPackageRepo.Create(pkg); err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// ListShippingPackages lists shipping packages, smallest first
+func (uc *ShippingUseCase) ListShippingPackages(activeOnly bool) ([]*entity.ShippingPackage, error) {
+	return uc.shippingPackageRepo.List(activeOnly)
+}
+
+// UpdateShippingPackage updates a shipping package
+func (uc *ShippingUseCase) UpdateShippingPackage(id uint, input ShippingPackageInput) (*entity.ShippingPackage, error) {
+	pkg, err := uc.shippingPackageRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg.Name = input.Name
+	pkg.Dimensions = input.Dimensions
+	pkg.Weight = input.Weight
+	pkg.MaxWeight = input.MaxWeight
+	pkg.Active = input.Active
+	pkg.UpdatedAt = time.Now()
+
+	if err := pkg.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.shippingPackageRepo.Update(pkg); err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// DeleteShippingPackage deletes a shipping package
+func (uc *ShippingUseCase) DeleteShippingPackage(id uint) error {
+	if _, err := uc.shippingPackageRepo.GetByID(id); err != nil {
+		return err
+	}
+	return uc.shippingPackageRepo.Delete(id)
+}
+
+// PackItems picks the packages for items and returns them with their billable weight, the weight
+// shipping rates are calculated on. Without active packages nothing is packed and the actual weight is billed.
+func (uc *ShippingUseCase) PackItems(items []entity.PackingItem) ([]entity.OrderPackage, float64, error) {
+	boxes, err := uc.shippingPackageRepo.List(true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(boxes) == 0 {
+		var weight float64
+		for _, item := range items {
+			weight += item.Weight * float64(item.Quantity)
+		}
+		return nil, weight, nil
+	}
+
+	packages := entity.PackItems(items, boxes, uc.dimensionalWeightDivisor)
+	return packages, entity.BillableWeight(packages), nil
+}
+
+// ShippingProfileInput contains the data needed to create or update a shipping profile
+type ShippingProfileInput struct {
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	ShippingMethodIDs []uint `json:"shipping_method_ids"` // only these methods ship items of the profile, any method if empty
+}
+
+// CreateShippingProfile creates a new shipping profile
+func (uc *ShippingUseCase) CreateShippingProfile(input ShippingProfileInput) (*entity.ShippingProfile, error) {
+	profile, err := entity.NewShippingProfile(input.Name, input.Description)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.setProfileMethods(profile, input.ShippingMethodIDs); err != nil {
+		return nil, err
+	}
+
+	if err := uc.shippingProfileRepo.Create(profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// GetShippingProfileByID retrieves a shipping profile by ID
+func (uc *ShippingUseCase) GetShippingProfileByID(id uint) (*entity.ShippingProfile, error) {
+	return uc.shippingProfileRepo.GetByID(id)
+}
+
+// ListShippingProfiles lists all shipping profiles
+func (uc *ShippingUseCase) ListShippingProfiles() ([]*entity.ShippingProfile, error) {
+	return uc.shippingProfileRepo.List()
+}
+
+// UpdateShippingProfile updates a shipping profile
+func (uc *ShippingUseCase) UpdateShippingProfile(id uint, input ShippingProfileInput) (*entity.ShippingProfile, error) {
+	profile, err := uc.shippingProfileRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := profile.Update(input.Name, input.Description); err != nil {
+		return nil, err
+	}
+	if err := uc.setProfileMethods(profile, input.ShippingMethodIDs); err != nil {
+		return nil, err
+	}
+
+	if err := uc.shippingProfileRepo.Update(profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// DeleteShippingProfile deletes a shipping profile and removes it from the restrictions of shipping methods.
+// Products and categories having the profile are left without one. A profile that is the only one
+// a shipping method allows can't be deleted, since the method would then allow every profile.
+func (uc *ShippingUseCase) DeleteShippingProfile(id uint) error {
+	if _, err := uc.shippingProfileRepo.GetByID(id); err != nil {
+		return err
+	}
+
+	methods, err := uc.shippingMethodRepo.List(false)
+	if err != nil {
+		return err
+	}
+	for _, method := range methods {
+		if slices.Equal(method.Restrictions.AllowedProfileIDs, []uint{id}) {
+			return errors.New("shipping profile is the only profile allowed by a shipping method")
+		}
+	}
+
+	for _, method := range methods {
+		if !slices.Contains(method.Restrictions.ProfileIDs(), id) {
+			continue
+		}
+		method.Restrictions = method.Restrictions.WithoutProfile(id)
+		if err := uc.shippingMethodRepo.Update(method); err != nil {
+			return err
+		}
+	}
+
+	return uc.shippingProfileRepo.Delete(id)
+}
+
+// AssignCategoryShippingProfile gives the products of a category without a profile of their own
+// a shipping profile, or removes the category's profile when profileID is 0
+func (uc *ShippingUseCase) AssignCategoryShippingProfile(categoryID, profileID uint) (*entity.Category, error) {
+	category, err := uc.categoryRepo.GetByID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profileID != 0 {
+		if _, err := uc.shippingProfileRepo.GetByID(profileID); err != nil {
+			return nil, err
+		}
+	}
+
+	category.ShippingProfileID = profileID
+	category.UpdatedAt = time.Now()
+	if err := uc.categoryRepo.Update(category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// setProfileMethods limits the shipping methods of a profile to existing methods
+func (uc *ShippingUseCase) setProfileMethods(profile *entity.ShippingProfile, methodIDs []uint) error {
+	for _, methodID := range methodIDs {
+		if _, err := uc.shippingMethodRepo.GetByID(methodID); err != nil {
+			return fmt.Errorf("shipping method %d not found", methodID)
+		}
+	}
+
+	if methodIDs == nil {
+		methodIDs = []uint{}
+	}
+	profile.ShippingMethodIDs = methodIDs
+	return nil
+}
+
 // CreateShippingRateInput contains the data needed to create a shipping rate
 type CreateShippingRateInput struct {
 	ShippingMethodID      uint     `json:"shipping_method_id"`
@@ -172,6 +616,8 @@ type CreateShippingRateInput struct {
 	BaseRate              float64  `json:"base_rate"`
 	MinOrderValue         float64  `json:"min_order_value"`
 	FreeShippingThreshold *float64 `json:"free_shipping_threshold"`
+	TransitDaysMin        int      `json:"transit_days_min"` // omit both to use the method's estimated delivery days
+	TransitDaysMax        int      `json:"transit_days_max"`
 	Active                bool     `json:"active"`
 }
 
@@ -206,6 +652,9 @@ func (uc *ShippingUseCase) CreateShippingRate(input CreateShippingRateInput) (*e
 		CreatedAt:             time.Now(),
 		UpdatedAt:             time.Now(),
 	}
+	if err := rate.SetTransitDays(input.TransitDaysMin, input.TransitDaysMax); err != nil {
+		return nil, err
+	}
 
 	// Save to repository
 	if err := uc.shippingRateRepo.Create(rate); err != nil {
@@ -296,6 +745,8 @@ type UpdateShippingRateInput struct {
 	BaseRate              float64  `json:"base_rate"`
 	MinOrderValue         float64  `json:"min_order_value"`
 	FreeShippingThreshold *float64 `json:"free_shipping_threshold"`
+	TransitDaysMin        int      `json:"transit_days_min"`
+	TransitDaysMax        int      `json:"transit_days_max"`
 	Active                bool     `json:"active"`
 }
 
@@ -317,6 +768,9 @@ func (uc *ShippingUseCase) UpdateShippingRate(input UpdateShippingRateInput) (*e
 	rate.FreeShippingThreshold = freeShippingThresholdCents
 	rate.Active = input.Active
 	rate.UpdatedAt = time.Now()
+	if err := rate.SetTransitDays(input.TransitDaysMin, input.TransitDaysMax); err != nil {
+		return nil, err
+	}
 
 	// Save changes
 	if err := uc.shippingRateRepo.Update(rate); err != nil {
@@ -331,8 +785,20 @@ type ShippingOptions struct {
 	Options []*entity.ShippingOption `json:"options"`
 }
 
-// CalculateShippingOptions calculates available shipping options for an order
-func (uc *ShippingUseCase) CalculateShippingOptions(address entity.Address, orderValue int64, orderWeight float64) (*ShippingOptions, error) {
+// CalculateShippingOptions calculates available shipping options for an order.
+// If a shipping discount code is given, it is applied to every option it is valid for.
+// Methods are only offered if they are allowed for the items and weight of the order,
+// without items only the methods' weight limits apply.
+func (uc *ShippingUseCase) CalculateShippingOptions(address entity.Address, orderValue int64, orderWeight float64, discountCode string, items []entity.PackingItem) (*ShippingOptions, error) {
+	var discount *entity.Discount
+	if discountCode != "" {
+		var err error
+		discount, err = uc.discountRepo.GetByCode(discountCode)
+		if err != nil {
+			return nil, errors.New("invalid discount code")
+		}
+	}
+
 	// Get available shipping rates for address and order value
 	rates, err := uc.shippingRateRepo.GetAvailableRatesForAddress(address, orderValue)
 	if err != nil {
@@ -343,26 +809,39 @@ func (uc *ShippingUseCase) CalculateShippingOptions(address entity.Address, orde
 		Options: make([]*entity.ShippingOption, 0, len(rates)),
 	}
 
+	profileIDs, profiles, err := uc.itemProfiles(items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deliveries are estimated on the business days of the store and the destination
+	orderedAt := time.Now().In(uc.origin.Location)
+	origin, destination, err := uc.calendars(address.Country, orderedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Carriers are asked once for quotes of all their services
+	shipment := service.CarrierShipment{To: address, Weight: orderWeight}
+	quotes := make(map[string][]service.CarrierRate)
+
 	for _, rate := range rates {
-		cost := rate.BaseRate
-
-		// Check if there are weight-based rates
-		if len(rate.WeightBasedRates) > 0 {
-			for _, weightRate := range rate.WeightBasedRates {
-				if orderWeight >= weightRate.MinWeight && (weightRate.MaxWeight == 0 || orderWeight <= weightRate.MaxWeight) {
-					cost += weightRate.Rate
-					break
-				}
-			}
+		if !allowsMethod(rate.ShippingMethod, profileIDs, profiles, orderWeight) {
+			continue
 		}
 
-		// Check if there are value-based rates
-		if len(rate.ValueBasedRates) > 0 {
-			for _, valueRate := range rate.ValueBasedRates {
-				if orderValue >= valueRate.MinOrderValue && (valueRate.MaxOrderValue == 0 || orderValue <= valueRate.MaxOrderValue) {
-					cost += valueRate.Rate
-					break
-				}
+		cost := tableCost(rate, orderValue, orderWeight)
+
+		// Carrier-backed methods use a live quote, falling back to the table rate if the carrier cannot quote
+		carrier := ""
+		if rate.ShippingMethod.IsCarrierBacked() {
+			carrierName := rate.ShippingMethod.Carrier
+			if _, asked := quotes[carrierName]; !asked {
+				quotes[carrierName] = uc.carrierQuotes(carrierName, shipment)
+			}
+			if quote := findQuote(quotes[carrierName], rate.ShippingMethod.CarrierServiceCode); quote != nil {
+				cost = quote.Amount
+				carrier = carrierName
 			}
 		}
 
@@ -373,14 +852,27 @@ func (uc *ShippingUseCase) CalculateShippingOptions(address entity.Address, orde
 			freeShipping = true
 		}
 
+		// Apply shipping discount
+		var discountAmount int64
+		if discount != nil && cost > 0 {
+			discountAmount = discount.CalculateShippingDiscount(orderValue, cost, rate.ShippingMethodID, []uint{rate.ShippingZoneID})
+			cost -= discountAmount
+			if cost == 0 {
+				freeShipping = true
+			}
+		}
+
 		option := &entity.ShippingOption{
-			ShippingRateID:        rate.ID,
-			ShippingMethodID:      rate.ShippingMethodID,
-			Name:                  rate.ShippingMethod.Name,
-			Description:           rate.ShippingMethod.Description,
-			EstimatedDeliveryDays: rate.ShippingMethod.EstimatedDeliveryDays,
-			Cost:                  cost,
-			FreeShipping:          freeShipping,
+			ShippingRateID:    rate.ID,
+			ShippingMethodID:  rate.ShippingMethodID,
+			Name:              rate.ShippingMethod.Name,
+			Description:       rate.ShippingMethod.Description,
+			EstimatedDelivery: rate.ShippingMethod.EstimateDelivery(orderedAt, rate, origin, destination),
+			Carrier:           carrier,
+			PickupPoint:       rate.ShippingMethod.PickupPoint,
+			Cost:              cost,
+			DiscountAmount:    discountAmount,
+			FreeShipping:      freeShipping,
 		}
 
 		options.Options = append(options.Options, option)
@@ -389,6 +881,175 @@ func (uc *ShippingUseCase) CalculateShippingOptions(address entity.Address, orde
 	return options, nil
 }
 
+// CheckRestrictions checks if a shipping method is allowed for items with a billable weight
+func (uc *ShippingUseCase) CheckRestrictions(method *entity.ShippingMethod, items []entity.PackingItem, weight float64) error {
+	profileIDs, profiles, err := uc.itemProfiles(items)
+	if err != nil {
+		return err
+	}
+	if !allowsMethod(method, profileIDs, profiles, weight) {
+		return errors.New("shipping method is not available for the items in the cart")
+	}
+	return nil
+}
+
+// itemProfiles resolves the shipping profile of each item, 0 for none, and loads the profiles
+func (uc *ShippingUseCase) itemProfiles(items []entity.PackingItem) ([]uint, []*entity.ShippingProfile, error) {
+	profileIDs := make([]uint, 0, len(items))
+	loaded := make(map[uint]bool)
+	profiles := []*entity.ShippingProfile{}
+	categories := make(map[uint]uint)
+
+	for _, item := range items {
+		profileID := item.ShippingProfileID
+		if profileID == 0 && item.CategoryID != 0 {
+			categoryProfileID, ok := categories[item.CategoryID]
+			if !ok {
+				category, err := uc.categoryRepo.GetByID(item.CategoryID)
+				if err == nil {
+					categoryProfileID = category.ShippingProfileID
+				}
+				categories[item.CategoryID] = categoryProfileID
+			}
+			profileID = categoryProfileID
+		}
+		profileIDs = append(profileIDs, profileID)
+
+		if profileID == 0 || loaded[profileID] {
+			continue
+		}
+		loaded[profileID] = true
+
+		profile, err := uc.shippingProfileRepo.GetByID(profileID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting shipping profile: %v", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profileIDs, profiles, nil
+}
+
+// allowsMethod checks if a method may ship items of the profiles with a billable weight,
+// both the method's restrictions and the profiles must allow it
+func allowsMethod(method *entity.ShippingMethod, profileIDs []uint, profiles []*entity.ShippingProfile, weight float64) bool {
+	if !method.Restrictions.Allows(profileIDs, weight) {
+		return false
+	}
+	for _, profile := range profiles {
+		if !profile.AllowsMethod(method.ID) {
+			return false
+		}
+	}
+	return true
+}
+
+// EstimateDelivery estimates the delivery window of an order placed at orderedAt and shipped with a
+// method to an address, with the transit days of the method's rate for the address if it has one
+func (uc *ShippingUseCase) EstimateDelivery(method *entity.ShippingMethod, address entity.Address, orderValue int64, orderedAt time.Time) (*entity.DeliveryEstimate, error) {
+	var methodRate *entity.ShippingRate
+	if rates, err := uc.shippingRateRepo.GetAvailableRatesForAddress(address, orderValue); err == nil {
+		for _, rate := range rates {
+			if rate.ShippingMethodID == method.ID {
+				methodRate = rate
+				break
+			}
+		}
+	}
+
+	orderedAt = orderedAt.In(uc.origin.Location)
+	origin, destination, err := uc.calendars(address.Country, orderedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := method.EstimateDelivery(orderedAt, methodRate, origin, destination)
+	return &estimate, nil
+}
+
+// calendars returns the business calendars of the origin and of the destination country for the year from date
+func (uc *ShippingUseCase) calendars(destinationCountry string, date time.Time) (*entity.BusinessCalendar, *entity.BusinessCalendar, error) {
+	origin, err := uc.calendar(uc.origin.Country, date)
+	if err != nil {
+		return nil, nil, err
+	}
+	destination, err := uc.calendar(strings.ToUpper(destinationCountry), date)
+	if err != nil {
+		return nil, nil, err
+	}
+	return origin, destination, nil
+}
+
+func (uc *ShippingUseCase) calendar(country string, date time.Time) (*entity.BusinessCalendar, error) {
+	if country == "" {
+		return entity.NewBusinessCalendar(nil), nil
+	}
+
+	from := entity.CalendarDate(date)
+	holidays, err := uc.holidayRepo.List(country, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load holidays: %w", err)
+	}
+	return entity.NewBusinessCalendar(holidays), nil
+}
+
+// CreateHolidayInput contains the data needed to create a public holiday
+type CreateHolidayInput struct {
+	Country string `json:"country"`
+	Date    string `json:"date"` // YYYY-MM-DD
+	Name    string `json:"name"`
+}
+
+// CreateHoliday adds a public holiday to a country's business calendar
+func (uc *ShippingUseCase) CreateHoliday(input CreateHolidayInput) (*entity.Holiday, error) {
+	date, err := time.Parse(entity.DateLayout, input.Date)
+	if err != nil {
+		return nil, errors.New("date must be formatted as YYYY-MM-DD")
+	}
+
+	holiday, err := entity.NewHoliday(input.Country, date, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.holidayRepo.Create(holiday); err != nil {
+		return nil, err
+	}
+
+	return holiday, nil
+}
+
+// ListHolidays lists the public holidays of a year, of all countries if country is empty
+func (uc *ShippingUseCase) ListHolidays(country string, year int) ([]*entity.Holiday, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return uc.holidayRepo.List(strings.ToUpper(country), from, from.AddDate(1, 0, -1))
+}
+
+// DeleteHoliday deletes a public holiday
+func (uc *ShippingUseCase) DeleteHoliday(id uint) error {
+	if _, err := uc.holidayRepo.GetByID(id); err != nil {
+		return err
+	}
+	return uc.holidayRepo.Delete(id)
+}
+
+// GetZoneIDsForAddress returns the IDs of all active shipping zones that contain the address
+func (uc *ShippingUseCase) GetZoneIDsForAddress(address entity.Address) ([]uint, error) {
+	zones, err := uc.shippingZoneRepo.List(true)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneIDs := make([]uint, 0, len(zones))
+	for _, zone := range zones {
+		if zone.IsAddressInZone(address) {
+			zoneIDs = append(zoneIDs, zone.ID)
+		}
+	}
+
+	return zoneIDs, nil
+}
+
 // GetShippingCost calculates the shipping cost for a specific shipping rate
 func (uc *ShippingUseCase) GetShippingCost(rateID uint, orderValue int64, orderWeight float64) (int64, error) {
 	// Get shipping rate
@@ -397,33 +1058,118 @@ func (uc *ShippingUseCase) GetShippingCost(rateID uint, orderValue int64, orderW
 		return 0, err
 	}
 
+	return uc.shippingCost(rate, orderValue, orderWeight, nil), nil
+}
+
+// QuoteShippingCost calculates the shipping cost of a rate for a shipment.
+// Rates of carrier-backed methods are quoted live, falling back to the table rate if the carrier cannot quote.
+func (uc *ShippingUseCase) QuoteShippingCost(rateID uint, orderValue int64, shipment service.CarrierShipment) (int64, error) {
+	rate, err := uc.shippingRateRepo.GetByID(rateID)
+	if err != nil {
+		return 0, err
+	}
+
+	return uc.shippingCost(rate, orderValue, shipment.Weight, &shipment), nil
+}
+
+// shippingCost calculates the cost of a rate, with a live quote if a shipment is given
+func (uc *ShippingUseCase) shippingCost(rate *entity.ShippingRate, orderValue int64, orderWeight float64, shipment *service.CarrierShipment) int64 {
+	cost := tableCost(rate, orderValue, orderWeight)
+
+	if shipment != nil && rate.ShippingMethod != nil && rate.ShippingMethod.IsCarrierBacked() {
+		quotes := uc.carrierQuotes(rate.ShippingMethod.Carrier, *shipment)
+		if quote := findQuote(quotes, rate.ShippingMethod.CarrierServiceCode); quote != nil {
+			cost = quote.Amount
+		}
+	}
+
+	// Check if free shipping applies
+	if rate.FreeShippingThreshold != nil && orderValue >= *rate.FreeShippingThreshold {
+		cost = 0
+	}
+
+	return cost
+}
+
+// tableCost calculates the cost of a rate from its base rate and its weight and value based rates
+func tableCost(rate *entity.ShippingRate, orderValue int64, orderWeight float64) int64 {
 	// Start with base rate
 	cost := rate.BaseRate
 
 	// Check if there are weight-based rates
-	if len(rate.WeightBasedRates) > 0 {
-		for _, weightRate := range rate.WeightBasedRates {
-			if orderWeight >= weightRate.MinWeight && (weightRate.MaxWeight == 0 || orderWeight <= weightRate.MaxWeight) {
-				cost += weightRate.Rate
-				break
-			}
+	for _, weightRate := range rate.WeightBasedRates {
+		if orderWeight >= weightRate.MinWeight && (weightRate.MaxWeight == 0 || orderWeight <= weightRate.MaxWeight) {
+			cost += weightRate.Rate
+			break
 		}
 	}
 
 	// Check if there are value-based rates
-	if len(rate.ValueBasedRates) > 0 {
-		for _, valueRate := range rate.ValueBasedRates {
-			if orderValue >= valueRate.MinOrderValue && (valueRate.MaxOrderValue == 0 || orderValue <= valueRate.MaxOrderValue) {
-				cost += valueRate.Rate
-				break
-			}
+	for _, valueRate := range rate.ValueBasedRates {
+		if orderValue >= valueRate.MinOrderValue && (valueRate.MaxOrderValue == 0 || orderValue <= valueRate.MaxOrderValue) {
+			cost += valueRate.Rate
+			break
 		}
 	}
 
-	// Check if free shipping applies
-	if rate.FreeShippingThreshold != nil && orderValue >= *rate.FreeShippingThreshold {
-		cost = 0
+	return cost
+}
+
+// carrierQuotes asks a carrier for quotes, returning none if the carrier is not configured or fails
+func (uc *ShippingUseCase) carrierQuotes(carrierName string, shipment service.CarrierShipment) []service.CarrierRate {
+	carrier, ok := uc.carriers[carrierName]
+	if !ok {
+		return nil
+	}
+
+	quotes, err := carrier.GetRates(shipment)
+	if err != nil {
+		return nil
+	}
+	return quotes
+}
+
+func findQuote(quotes []service.CarrierRate, serviceCode string) *service.CarrierRate {
+	for i := range quotes {
+		if quotes[i].ServiceCode == serviceCode {
+			return &quotes[i]
+		}
+	}
+	return nil
+}
+
+// CreateShippingLabel buys a label for an order shipped with a carrier-backed method and stores it on the order.
+// Orders shipped with table-rate methods get no label.
+func (uc *ShippingUseCase) CreateShippingLabel(order *entity.Order) (*entity.ShippingLabel, error) {
+	method, err := uc.shippingMethodRepo.GetByID(order.ShippingMethodID)
+	if err != nil {
+		return nil, err
+	}
+	if !method.IsCarrierBacked() {
+		return nil, nil
+	}
+
+	carrier, ok := uc.carriers[method.Carrier]
+	if !ok {
+		return nil, fmt.Errorf("carrier %s is not configured", method.Carrier)
+	}
+
+	label, err := carrier.CreateLabel(service.CarrierShipment{
+		Reference: order.OrderNumber,
+		Recipient: order.CustomerDetails.FullName,
+		Email:     order.CustomerDetails.Email,
+		Phone:     order.CustomerDetails.Phone,
+		To:        order.ShippingAddr,
+		Packages:  order.Packages,
+		Weight:    order.TotalWeight,
+	}, method.CarrierServiceCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := order.SetShippingLabel(label); err != nil {
+		return nil, err
 	}
 
-	return cost, nil
+	return label, nil
 }
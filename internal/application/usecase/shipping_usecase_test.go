@@ -0,0 +1,755 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/internal/infrastructure/carrier"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func newShippingUseCase(carriers ...service.CarrierService) *usecase.ShippingUseCase {
+	return newPickupPointShippingUseCase(nil, carriers...)
+}
+
+func newPickupPointShippingUseCase(pickupPoints service.PickupPointProvider, carriers ...service.CarrierService) *usecase.ShippingUseCase {
+	methodRepo := mock.NewMockShippingMethodRepository()
+	zoneRepo := mock.NewMockShippingZoneRepository()
+	rateRepo := mock.NewMockShippingRateRepository(zoneRepo, methodRepo)
+	return usecase.NewShippingUseCase(methodRepo, zoneRepo, rateRepo, mock.NewMockShippingPackageRepository(), mock.NewMockShippingProfileRepository(), mock.NewMockCategoryRepository(), mock.NewMockDiscountRepository(), mock.NewMockHolidayRepository(), entity.DefaultDimensionalWeightDivisor, carriers, pickupPoints, usecase.DispatchOrigin{})
+}
+
+func TestShippingUseCase_CreateShippingZone(t *testing.T) {
+	t.Run("Valid patterns", func(t *testing.T) {
+		shippingUseCase := newShippingUseCase()
+
+		// Execute
+		zone, err := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{
+			Name:      "Denmark North",
+			Countries: []string{"dk"},
+			ZipCodes:  []string{"3700-3799", "DK-9*", "1000-001"},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"DK"}, zone.Countries)
+		assert.Equal(t, []string{"3700-3799", "DK-9*", "1000-001"}, zone.ZipCodes)
+	})
+
+	invalid := map[string][]string{
+		"Reversed range":       {"3799-3700"},
+		"Wildcard in range":    {"37*0-3799"},
+		"Invalid character":    {"37%"},
+		"Several separators":   {"1-2-3"},
+		"Country outside zone": {"SE-9*"},
+		"Empty pattern":        {" "},
+	}
+	for name, zipCodes := range invalid {
+		t.Run(name, func(t *testing.T) {
+			shippingUseCase := newShippingUseCase()
+
+			// Execute
+			zone, err := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{
+				Name:      "Denmark",
+				Countries: []string{"DK"},
+				ZipCodes:  zipCodes,
+			})
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, zone)
+		})
+	}
+}
+
+func TestShippingZone_IsAddressInZone(t *testing.T) {
+	tests := []struct {
+		name      string
+		countries []string
+		states    []string
+		zipCodes  []string
+		address   entity.Address
+		inZone    bool
+	}{
+		{"Range", []string{"DK"}, nil, []string{"3700-3799"}, entity.Address{Country: "DK", PostalCode: "3730"}, true},
+		{"Outside range", []string{"DK"}, nil, []string{"3700-3799"}, entity.Address{Country: "DK", PostalCode: "3800"}, false},
+		{"Range prefix of a longer code", []string{"US"}, nil, []string{"90000-90099"}, entity.Address{Country: "US", PostalCode: "90012-3456"}, true},
+		{"Country prefixed wildcard", []string{"DK", "SE"}, nil, []string{"DK-9*"}, entity.Address{Country: "DK", PostalCode: "9000"}, true},
+		{"Country prefixed wildcard in another country", []string{"DK", "SE"}, nil, []string{"DK-9*"}, entity.Address{Country: "SE", PostalCode: "9000"}, false},
+		{"Prefixed address code", []string{"DK"}, nil, []string{"9*"}, entity.Address{Country: "DK", PostalCode: "DK-9000"}, true},
+		{"Repeated wildcards", []string{"US"}, []string{"CA"}, []string{"900**"}, entity.Address{Country: "US", State: "CA", PostalCode: "90012"}, true},
+		{"Single character wildcards", []string{"US"}, nil, []string{"900??"}, entity.Address{Country: "US", PostalCode: "9021"}, false},
+		{"Spaces and case", []string{"GB"}, nil, []string{"sw1a *"}, entity.Address{Country: "GB", PostalCode: "sw1a 1aa"}, true},
+		{"Zip codes without states", []string{"DK"}, nil, []string{"8000"}, entity.Address{Country: "DK", PostalCode: "9000"}, false},
+		{"Wrong state", []string{"US"}, []string{"CA"}, nil, entity.Address{Country: "US", State: "NY", PostalCode: "10001"}, false},
+		{"Empty postal code", []string{"DK"}, nil, []string{"*"}, entity.Address{Country: "DK"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone, _ := entity.NewShippingZone("Zone", "")
+			zone.SetCountries(tt.countries)
+			if tt.states != nil {
+				zone.SetStates(tt.states)
+			}
+			zone.SetZipCodes(tt.zipCodes)
+
+			assert.Equal(t, tt.inZone, zone.IsAddressInZone(tt.address))
+		})
+	}
+}
+
+func TestShippingUseCase_ResolveShippingZones(t *testing.T) {
+	shippingUseCase := newShippingUseCase()
+	method, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{Name: "Standard", EstimatedDeliveryDays: 3})
+	north, _ := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{
+		Name:      "North",
+		Countries: []string{"DK"},
+		ZipCodes:  []string{"DK-9*"},
+	})
+	south, _ := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{
+		Name:      "South",
+		Countries: []string{"DK"},
+		ZipCodes:  []string{"6000-6999"},
+	})
+	shippingUseCase.CreateShippingRate(usecase.CreateShippingRateInput{
+		ShippingMethodID: method.ID,
+		ShippingZoneID:   north.ID,
+		BaseRate:         49,
+		Active:           true,
+	})
+	shippingUseCase.CreateShippingRate(usecase.CreateShippingRateInput{
+		ShippingMethodID: method.ID,
+		ShippingZoneID:   south.ID,
+		BaseRate:         39,
+		Active:           true,
+	})
+	address := entity.Address{Country: "dk", PostalCode: "9000"}
+
+	// Execute
+	resolution, err := shippingUseCase.ResolveShippingZones(address)
+	options, optionsErr := shippingUseCase.CalculateShippingOptions(address, 10000, 1, "", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "DK", resolution.Country)
+	assert.Equal(t, []uint{north.ID}, resolution.MatchedZoneIDs)
+	assert.Len(t, resolution.Zones, 2)
+	assert.True(t, resolution.Zones[0].Matched)
+	assert.Equal(t, "DK-9*", resolution.Zones[0].PostalCodePattern)
+	assert.Contains(t, resolution.Zones[0].Reasons, `postal code 9000 matches wildcard pattern "DK-9*"`)
+	assert.False(t, resolution.Zones[1].Matched)
+	assert.Contains(t, resolution.Zones[1].Reasons, `postal code "9000" matches none of 6000-6999`)
+
+	assert.NoError(t, optionsErr)
+	assert.Len(t, options.Options, 1)
+	assert.Equal(t, int64(4900), options.Options[0].Cost)
+}
+
+func TestShippingUseCase_PackItems(t *testing.T) {
+	setup := func() *usecase.ShippingUseCase {
+		shippingUseCase := newShippingUseCase()
+		shippingUseCase.CreateShippingPackage(usecase.ShippingPackageInput{
+			Name:       "Large",
+			Dimensions: entity.Dimensions{Length: 40, Width: 30, Height: 20},
+			Weight:     0.3,
+		})
+		shippingUseCase.CreateShippingPackage(usecase.ShippingPackageInput{
+			Name:       "Small",
+			Dimensions: entity.Dimensions{Length: 20, Width: 15, Height: 10},
+			Weight:     0.1,
+			MaxWeight:  5,
+		})
+		return shippingUseCase
+	}
+
+	t.Run("No packages configured", func(t *testing.T) {
+		shippingUseCase := newShippingUseCase()
+
+		// Execute
+		packages, weight, err := shippingUseCase.PackItems([]entity.PackingItem{
+			{ProductID: 1, Quantity: 2, Weight: 0.5, Dimensions: entity.Dimensions{Length: 15, Width: 10, Height: 3}},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, packages)
+		assert.Equal(t, 1.0, weight)
+	})
+
+	t.Run("Items share the smallest box that holds them", func(t *testing.T) {
+		shippingUseCase := setup()
+
+		// Execute
+		packages, weight, err := shippingUseCase.PackItems([]entity.PackingItem{
+			{ProductID: 1, SKU: "BOOK", Quantity: 2, Weight: 0.5, Dimensions: entity.Dimensions{Length: 15, Width: 3, Height: 10}},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, packages, 1)
+		assert.Equal(t, "Small", packages[0].Name)
+		assert.Equal(t, 1.1, packages[0].Weight)
+		assert.Equal(t, 0.6, packages[0].DimensionalWeight)
+		assert.Equal(t, []entity.OrderPackageItem{{ProductID: 1, SKU: "BOOK", Quantity: 2}}, packages[0].Items)
+		assert.Equal(t, 1.1, weight)
+	})
+
+	t.Run("Light bulky item is billed by dimensional weight", func(t *testing.T) {
+		shippingUseCase := setup()
+
+		// Execute
+		packages, weight, err := shippingUseCase.PackItems([]entity.PackingItem{
+			{ProductID: 1, Quantity: 1, Weight: 0.4, Dimensions: entity.Dimensions{Length: 35, Width: 25, Height: 15}},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, packages, 1)
+		assert.Equal(t, "Large", packages[0].Name)
+		assert.Equal(t, 0.7, packages[0].Weight)
+		assert.Equal(t, 4.8, packages[0].DimensionalWeight)
+		assert.Equal(t, 4.8, weight)
+	})
+
+	t.Run("Maximum weight opens another box", func(t *testing.T) {
+		shippingUseCase := setup()
+
+		// Execute
+		packages, _, err := shippingUseCase.PackItems([]entity.PackingItem{
+			{ProductID: 1, Quantity: 3, Weight: 2, Dimensions: entity.Dimensions{Length: 10, Width: 10, Height: 5}},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, packages, 2)
+		assert.Equal(t, "Small", packages[0].Name)
+		assert.Equal(t, 2, packages[0].Items[0].Quantity)
+		assert.Equal(t, 4.1, packages[0].Weight)
+		assert.Equal(t, "Small", packages[1].Name)
+		assert.Equal(t, 1, packages[1].Items[0].Quantity)
+	})
+
+	t.Run("Oversized item ships in its own packaging", func(t *testing.T) {
+		shippingUseCase := setup()
+
+		// Execute
+		packages, weight, err := shippingUseCase.PackItems([]entity.PackingItem{
+			{ProductID: 1, Quantity: 1, Weight: 0.5, Dimensions: entity.Dimensions{Length: 15, Width: 10, Height: 3}},
+			{ProductID: 2, Quantity: 1, Weight: 8, Dimensions: entity.Dimensions{Length: 100, Width: 50, Height: 10}},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, packages, 2)
+		assert.Equal(t, "Small", packages[0].Name)
+		assert.Equal(t, uint(0), packages[1].PackageID)
+		assert.Equal(t, "Own packaging", packages[1].Name)
+		assert.Equal(t, 10.0, packages[1].DimensionalWeight)
+		assert.Equal(t, 10.6, weight)
+	})
+
+	t.Run("Inactive boxes are not used", func(t *testing.T) {
+		shippingUseCase := setup()
+		shippingUseCase.UpdateShippingPackage(2, usecase.ShippingPackageInput{
+			Name:       "Small",
+			Dimensions: entity.Dimensions{Length: 20, Width: 15, Height: 10},
+			Weight:     0.1,
+			Active:     false,
+		})
+
+		// Execute
+		packages, _, err := shippingUseCase.PackItems([]entity.PackingItem{
+			{ProductID: 1, Quantity: 1, Weight: 0.5, Dimensions: entity.Dimensions{Length: 15, Width: 10, Height: 3}},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, packages, 1)
+		assert.Equal(t, "Large", packages[0].Name)
+	})
+}
+
+func TestShippingUseCase_CreateShippingPackage_Invalid(t *testing.T) {
+	shippingUseCase := newShippingUseCase()
+
+	// Execute
+	_, missingErr := shippingUseCase.CreateShippingPackage(usecase.ShippingPackageInput{Name: "Box"})
+	_, partialErr := shippingUseCase.CreateShippingPackage(usecase.ShippingPackageInput{
+		Name:       "Box",
+		Dimensions: entity.Dimensions{Length: 20, Width: 15},
+	})
+
+	// Assert
+	assert.EqualError(t, missingErr, "package dimensions are required")
+	assert.EqualError(t, partialErr, "length, width and height must all be set")
+}
+
+func TestShippingUseCase_CalculateShippingOptions_Carrier(t *testing.T) {
+	setup := func(t *testing.T) (*usecase.ShippingUseCase, *carrier.FakeCarrier) {
+		fake := carrier.NewFakeCarrier("USD")
+		shippingUseCase := newShippingUseCase(fake)
+
+		zone, err := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{Name: "Denmark", Countries: []string{"DK"}})
+		assert.NoError(t, err)
+
+		table, err := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{Name: "Standard", EstimatedDeliveryDays: 5})
+		assert.NoError(t, err)
+		express, err := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+			Name:                  "Express",
+			EstimatedDeliveryDays: 2,
+			Carrier:               "fake",
+			CarrierServiceCode:    "express",
+		})
+		assert.NoError(t, err)
+
+		for _, method := range []*entity.ShippingMethod{table, express} {
+			_, err := shippingUseCase.CreateShippingRate(usecase.CreateShippingRateInput{
+				ShippingMethodID: method.ID,
+				ShippingZoneID:   zone.ID,
+				BaseRate:         9.99,
+				Active:           true,
+			})
+			assert.NoError(t, err)
+		}
+
+		return shippingUseCase, fake
+	}
+	address := entity.Address{Country: "DK", PostalCode: "8000"}
+
+	t.Run("Live quote merged with table rates", func(t *testing.T) {
+		shippingUseCase, _ := setup(t)
+
+		// Execute
+		options, err := shippingUseCase.CalculateShippingOptions(address, 5000, 2.5, "", nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, options.Options, 2)
+		costs := map[string]*entity.ShippingOption{}
+		for _, option := range options.Options {
+			costs[option.Name] = option
+		}
+		assert.Equal(t, int64(999), costs["Standard"].Cost)
+		assert.Empty(t, costs["Standard"].Carrier)
+		assert.Equal(t, int64(2250), costs["Express"].Cost) // 15.00 plus 2.50 for each of 3 started kg
+		assert.Equal(t, "fake", costs["Express"].Carrier)
+		assert.True(t, costs["Express"].EstimatedDelivery.Latest.Before(costs["Standard"].EstimatedDelivery.Earliest))
+	})
+
+	t.Run("Falls back to table rate when the carrier is unavailable", func(t *testing.T) {
+		shippingUseCase, fake := setup(t)
+		fake.SetUnavailable(true)
+
+		// Execute
+		options, err := shippingUseCase.CalculateShippingOptions(address, 5000, 2.5, "", nil)
+
+		// Assert
+		assert.NoError(t, err)
+		for _, option := range options.Options {
+			assert.Equal(t, int64(999), option.Cost)
+			assert.Empty(t, option.Carrier)
+		}
+	})
+}
+
+func TestShippingUseCase_CreateShippingMethod_UnknownCarrier(t *testing.T) {
+	shippingUseCase := newShippingUseCase(carrier.NewFakeCarrier("USD"))
+
+	// Execute
+	method, err := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+		Name:                  "Express",
+		EstimatedDeliveryDays: 1,
+		Carrier:               "ups",
+		CarrierServiceCode:    "ups_express",
+	})
+
+	// Assert
+	assert.EqualError(t, err, "carrier ups is not configured")
+	assert.Nil(t, method)
+}
+
+func TestShippingUseCase_ListPickupPoints(t *testing.T) {
+	t.Run("Pickup point method", func(t *testing.T) {
+		shippingUseCase := newPickupPointShippingUseCase(carrier.NewFakePickupPointProvider())
+		method, err := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+			Name:                  "Parcel Shop",
+			EstimatedDeliveryDays: 2,
+			PickupPoint:           true,
+		})
+		assert.NoError(t, err)
+
+		// Execute
+		points, err := shippingUseCase.ListPickupPoints(method.ID, "8000", "dk")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, points, 5)
+		assert.Equal(t, "DK:8000:1", points[0].ID)
+		assert.Equal(t, "8000", points[0].Address.PostalCode)
+
+		point, err := shippingUseCase.GetPickupPoint(method, points[2].ID)
+		assert.NoError(t, err)
+		assert.Equal(t, points[2], *point)
+	})
+
+	t.Run("Home delivery method", func(t *testing.T) {
+		shippingUseCase := newPickupPointShippingUseCase(carrier.NewFakePickupPointProvider())
+		method, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{Name: "Home", EstimatedDeliveryDays: 2})
+
+		// Execute
+		points, err := shippingUseCase.ListPickupPoints(method.ID, "8000", "DK")
+
+		// Assert
+		assert.EqualError(t, err, "shipping method does not deliver to pickup points")
+		assert.Nil(t, points)
+	})
+
+	t.Run("No pickup point provider", func(t *testing.T) {
+		shippingUseCase := newShippingUseCase()
+
+		// Execute
+		method, err := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+			Name:                  "Parcel Shop",
+			EstimatedDeliveryDays: 2,
+			PickupPoint:           true,
+		})
+
+		// Assert
+		assert.EqualError(t, err, "pickup points are not configured")
+		assert.Nil(t, method)
+	})
+}
+
+func TestShippingUseCase_EstimateDelivery(t *testing.T) {
+	methodRepo := mock.NewMockShippingMethodRepository()
+	zoneRepo := mock.NewMockShippingZoneRepository()
+	holidayRepo := mock.NewMockHolidayRepository()
+	shippingUseCase := usecase.NewShippingUseCase(
+		methodRepo,
+		zoneRepo,
+		mock.NewMockShippingRateRepository(zoneRepo, methodRepo),
+		mock.NewMockShippingPackageRepository(),
+		mock.NewMockShippingProfileRepository(),
+		mock.NewMockCategoryRepository(),
+		mock.NewMockDiscountRepository(),
+		holidayRepo,
+		entity.DefaultDimensionalWeightDivisor,
+		nil,
+		nil,
+		usecase.DispatchOrigin{Country: "DK", Location: time.FixedZone("CET", 3600)},
+	)
+
+	zone, _ := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{Name: "Nordics", Countries: []string{"DK", "SE"}})
+	standard, err := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+		Name:                  "Standard",
+		EstimatedDeliveryDays: 5,
+		CutoffTime:            "14:00",
+		HandlingDays:          1,
+	})
+	assert.NoError(t, err)
+	economy, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{Name: "Economy", EstimatedDeliveryDays: 5})
+	_, err = shippingUseCase.CreateShippingRate(usecase.CreateShippingRateInput{
+		ShippingMethodID: standard.ID,
+		ShippingZoneID:   zone.ID,
+		BaseRate:         5,
+		TransitDaysMin:   1,
+		TransitDaysMax:   4,
+		Active:           true,
+	})
+	assert.NoError(t, err)
+	shippingUseCase.CreateShippingRate(usecase.CreateShippingRateInput{
+		ShippingMethodID: economy.ID,
+		ShippingZoneID:   zone.ID,
+		BaseRate:         3,
+		Active:           true,
+	})
+
+	for _, holiday := range []usecase.CreateHolidayInput{
+		{Country: "dk", Date: "2026-12-24", Name: "Juleaften"},
+		{Country: "DK", Date: "2026-12-25", Name: "Juledag"},
+		{Country: "SE", Date: "2027-01-01", Name: "Nyårsdagen"},
+	} {
+		_, err := shippingUseCase.CreateHoliday(holiday)
+		assert.NoError(t, err)
+	}
+
+	denmark := entity.Address{Country: "DK", PostalCode: "8000"}
+	sweden := entity.Address{Country: "SE", PostalCode: "11120"}
+	date := func(value string) time.Time {
+		parsed, _ := time.Parse(entity.DateLayout, value)
+		return parsed
+	}
+
+	tests := []struct {
+		name      string
+		method    *entity.ShippingMethod
+		address   entity.Address
+		orderedAt time.Time
+		earliest  string
+		latest    string
+	}{
+		{"Before the cut-off", standard, denmark, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), "2026-10-21", "2026-10-26"},
+		{"After the cut-off in the store's time zone", standard, denmark, time.Date(2026, 10, 19, 13, 30, 0, 0, time.UTC), "2026-10-22", "2026-10-27"},
+		{"On a weekend", standard, denmark, time.Date(2026, 10, 24, 9, 0, 0, 0, time.UTC), "2026-10-28", "2026-11-02"},
+		{"Over the store's holidays", standard, denmark, time.Date(2026, 12, 23, 9, 0, 0, 0, time.UTC), "2026-12-29", "2027-01-01"},
+		{"Over the destination's holidays", standard, sweden, time.Date(2026, 12, 23, 9, 0, 0, 0, time.UTC), "2026-12-29", "2027-01-04"},
+		{"Method's estimate without transit days", economy, denmark, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), "2026-10-26", "2026-10-26"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			estimate, err := shippingUseCase.EstimateDelivery(tt.method, tt.address, 5000, tt.orderedAt)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, date(tt.earliest), estimate.Earliest)
+			assert.Equal(t, date(tt.latest), estimate.Latest)
+		})
+	}
+}
+
+func TestShippingUseCase_Holidays(t *testing.T) {
+	shippingUseCase := newShippingUseCase()
+
+	holiday, err := shippingUseCase.CreateHoliday(usecase.CreateHolidayInput{Country: "dk", Date: "2026-12-25", Name: "Juledag"})
+	assert.NoError(t, err)
+	assert.Equal(t, "DK", holiday.Country)
+
+	_, err = shippingUseCase.CreateHoliday(usecase.CreateHolidayInput{Country: "DK", Date: "2026-12-25", Name: "Christmas"})
+	assert.EqualError(t, err, "a holiday on this date already exists for the country")
+
+	_, err = shippingUseCase.CreateHoliday(usecase.CreateHolidayInput{Country: "DK", Date: "25-12-2026", Name: "Juledag"})
+	assert.EqualError(t, err, "date must be formatted as YYYY-MM-DD")
+
+	holidays, _ := shippingUseCase.ListHolidays("dk", 2026)
+	assert.Len(t, holidays, 1)
+	holidays, _ = shippingUseCase.ListHolidays("DK", 2027)
+	assert.Empty(t, holidays)
+
+	assert.NoError(t, shippingUseCase.DeleteHoliday(holiday.ID))
+	assert.EqualError(t, shippingUseCase.DeleteHoliday(holiday.ID), "holiday not found")
+}
+
+func TestShippingUseCase_CreateShippingMethod_Dispatch(t *testing.T) {
+	shippingUseCase := newShippingUseCase()
+
+	_, err := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{Name: "Standard", CutoffTime: "2pm"})
+	assert.EqualError(t, err, "cut-off time must be formatted as HH:MM")
+
+	_, err = shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{Name: "Standard", HandlingDays: -1})
+	assert.EqualError(t, err, "handling days must be a non-negative number")
+}
+
+func TestShippingUseCase_CalculateShippingOptions_Restrictions(t *testing.T) {
+	// Setup mocks
+	methodRepo := mock.NewMockShippingMethodRepository()
+	zoneRepo := mock.NewMockShippingZoneRepository()
+	categoryRepo := mock.NewMockCategoryRepository()
+	shippingUseCase := usecase.NewShippingUseCase(
+		methodRepo,
+		zoneRepo,
+		mock.NewMockShippingRateRepository(zoneRepo, methodRepo),
+		mock.NewMockShippingPackageRepository(),
+		mock.NewMockShippingProfileRepository(),
+		categoryRepo,
+		mock.NewMockDiscountRepository(),
+		mock.NewMockHolidayRepository(),
+		entity.DefaultDimensionalWeightDivisor,
+		nil,
+		nil,
+		usecase.DispatchOrigin{},
+	)
+
+	hazardous, err := shippingUseCase.CreateShippingProfile(usecase.ShippingProfileInput{Name: "Hazardous"})
+	assert.NoError(t, err)
+	small, err := shippingUseCase.CreateShippingProfile(usecase.ShippingProfileInput{Name: "Small"})
+	assert.NoError(t, err)
+
+	standard, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{Name: "Standard", EstimatedDeliveryDays: 5})
+	freight, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{Name: "Freight", EstimatedDeliveryDays: 7})
+	_, err = shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+		Name:                  "Express",
+		EstimatedDeliveryDays: 1,
+		Restrictions:          entity.ShippingRestrictions{ExcludedProfileIDs: []uint{hazardous.ID}},
+	})
+	assert.NoError(t, err)
+	_, err = shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+		Name:                  "Letter",
+		EstimatedDeliveryDays: 3,
+		Restrictions:          entity.ShippingRestrictions{AllowedProfileIDs: []uint{small.ID}, WeightLimit: 2},
+	})
+	assert.NoError(t, err)
+	oversize, err := shippingUseCase.CreateShippingProfile(usecase.ShippingProfileInput{Name: "Oversize", ShippingMethodIDs: []uint{freight.ID}})
+	assert.NoError(t, err)
+
+	zone, _ := shippingUseCase.CreateShippingZone(usecase.CreateShippingZoneInput{Name: "Everywhere"})
+	methods, _ := shippingUseCase.ListShippingMethods(false)
+	for _, method := range methods {
+		_, err := shippingUseCase.CreateShippingRate(usecase.CreateShippingRateInput{
+			ShippingMethodID: method.ID,
+			ShippingZoneID:   zone.ID,
+			BaseRate:         5,
+			Active:           true,
+		})
+		assert.NoError(t, err)
+	}
+
+	stickers, _ := entity.NewCategory("Stickers", "", nil)
+	categoryRepo.Create(stickers)
+	_, err = shippingUseCase.AssignCategoryShippingProfile(stickers.ID, small.ID)
+	assert.NoError(t, err)
+
+	address := entity.Address{Country: "DK", PostalCode: "8000"}
+	names := func(options *usecase.ShippingOptions) []string {
+		names := []string{}
+		for _, option := range options.Options {
+			names = append(names, option.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name   string
+		items  []entity.PackingItem
+		weight float64
+		want   []string
+	}{
+		{
+			name:   "Any method without profiles",
+			items:  []entity.PackingItem{{ProductID: 1, Quantity: 1}},
+			weight: 1,
+			want:   []string{"Standard", "Freight", "Express"},
+		},
+		{
+			name:   "No express for hazardous goods",
+			items:  []entity.PackingItem{{ProductID: 1, Quantity: 1}, {ProductID: 2, Quantity: 1, ShippingProfileID: hazardous.ID}},
+			weight: 1,
+			want:   []string{"Standard", "Freight"},
+		},
+		{
+			name:   "Oversize items only by freight",
+			items:  []entity.PackingItem{{ProductID: 3, Quantity: 1, ShippingProfileID: oversize.ID}},
+			weight: 30,
+			want:   []string{"Freight"},
+		},
+		{
+			name:   "Letter post for small items from the category",
+			items:  []entity.PackingItem{{ProductID: 4, Quantity: 2, CategoryID: stickers.ID}},
+			weight: 0.1,
+			want:   []string{"Standard", "Freight", "Express", "Letter"},
+		},
+		{
+			name:   "No letter post from the weight limit",
+			items:  []entity.PackingItem{{ProductID: 4, Quantity: 40, CategoryID: stickers.ID}},
+			weight: 2,
+			want:   []string{"Standard", "Freight", "Express"},
+		},
+		{
+			name:   "No letter post unless every item is small",
+			items:  []entity.PackingItem{{ProductID: 4, Quantity: 1, CategoryID: stickers.ID}, {ProductID: 1, Quantity: 1}},
+			weight: 0.5,
+			want:   []string{"Standard", "Freight", "Express"},
+		},
+		{
+			name:   "Product profile overrides the category",
+			items:  []entity.PackingItem{{ProductID: 5, Quantity: 1, CategoryID: stickers.ID, ShippingProfileID: hazardous.ID}},
+			weight: 0.1,
+			want:   []string{"Standard", "Freight"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			options, err := shippingUseCase.CalculateShippingOptions(address, 5000, tt.weight, "", tt.items)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, names(options))
+		})
+	}
+
+	t.Run("Check restrictions of a chosen method", func(t *testing.T) {
+		// Execute
+		err := shippingUseCase.CheckRestrictions(standard, []entity.PackingItem{{ProductID: 3, Quantity: 1, ShippingProfileID: oversize.ID}}, 30)
+
+		// Assert
+		assert.EqualError(t, err, "shipping method is not available for the items in the cart")
+		assert.NoError(t, shippingUseCase.CheckRestrictions(freight, []entity.PackingItem{{ProductID: 3, Quantity: 1, ShippingProfileID: oversize.ID}}, 30))
+	})
+}
+
+func TestShippingUseCase_ShippingProfiles(t *testing.T) {
+	t.Run("Unknown profile in restrictions", func(t *testing.T) {
+		shippingUseCase := newShippingUseCase()
+
+		// Execute
+		method, err := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+			Name:                  "Express",
+			EstimatedDeliveryDays: 1,
+			Restrictions:          entity.ShippingRestrictions{ExcludedProfileIDs: []uint{7}},
+		})
+
+		// Assert
+		assert.EqualError(t, err, "shipping profile 7 not found")
+		assert.Nil(t, method)
+	})
+
+	t.Run("Unknown method in profile", func(t *testing.T) {
+		shippingUseCase := newShippingUseCase()
+
+		// Execute
+		profile, err := shippingUseCase.CreateShippingProfile(usecase.ShippingProfileInput{Name: "Oversize", ShippingMethodIDs: []uint{3}})
+
+		// Assert
+		assert.EqualError(t, err, "shipping method 3 not found")
+		assert.Nil(t, profile)
+	})
+
+	t.Run("Delete removes the profile from restrictions", func(t *testing.T) {
+		shippingUseCase := newShippingUseCase()
+		hazardous, _ := shippingUseCase.CreateShippingProfile(usecase.ShippingProfileInput{Name: "Hazardous"})
+		express, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+			Name:                  "Express",
+			EstimatedDeliveryDays: 1,
+			Restrictions:          entity.ShippingRestrictions{ExcludedProfileIDs: []uint{hazardous.ID}, WeightLimit: 10},
+		})
+
+		// Execute
+		err := shippingUseCase.DeleteShippingProfile(hazardous.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		method, _ := shippingUseCase.GetShippingMethodByID(express.ID)
+		assert.Empty(t, method.Restrictions.ExcludedProfileIDs)
+		assert.Equal(t, 10.0, method.Restrictions.WeightLimit)
+		_, err = shippingUseCase.GetShippingProfileByID(hazardous.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Delete refuses the only allowed profile of a method", func(t *testing.T) {
+		shippingUseCase := newShippingUseCase()
+		freight, _ := shippingUseCase.CreateShippingProfile(usecase.ShippingProfileInput{Name: "Freight"})
+		truck, _ := shippingUseCase.CreateShippingMethod(usecase.CreateShippingMethodInput{
+			Name:                  "Truck",
+			EstimatedDeliveryDays: 5,
+			Restrictions:          entity.ShippingRestrictions{AllowedProfileIDs: []uint{freight.ID}},
+		})
+
+		// Execute
+		err := shippingUseCase.DeleteShippingProfile(freight.ID)
+
+		// Assert
+		assert.EqualError(t, err, "shipping profile is the only profile allowed by a shipping method")
+		method, _ := shippingUseCase.GetShippingMethodByID(truck.ID)
+		assert.Equal(t, []uint{freight.ID}, method.Restrictions.AllowedProfileIDs)
+		_, err = shippingUseCase.GetShippingProfileByID(freight.ID)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// TaxUseCase implements tax class, tax rate and tax calculation use cases
+type TaxUseCase struct {
+	taxClassRepo     repository.TaxClassRepository
+	taxRateRepo      repository.TaxRateRepository
+	calculator       service.TaxCalculator
+	vatValidator     service.VATNumberValidator
+	pricesIncludeTax bool
+	storeCountry     string
+}
+
+// NewTaxUseCase creates a new TaxUseCase.
+// pricesIncludeTax is the store setting for whether prices and shipping costs include tax.
+// storeCountry is the ISO code of the country the store ships from; reverse charge is
+// disabled without it or without a VAT number validator.
+func NewTaxUseCase(
+	taxClassRepo repository.TaxClassRepository,
+	taxRateRepo repository.TaxRateRepository,
+	calculator service.TaxCalculator,
+	vatValidator service.VATNumberValidator,
+	pricesIncludeTax bool,
+	storeCountry string,
+) *TaxUseCase {
+	return &TaxUseCase{
+		taxClassRepo:     taxClassRepo,
+		taxRateRepo:      taxRateRepo,
+		calculator:       calculator,
+		vatValidator:     vatValidator,
+		pricesIncludeTax: pricesIncludeTax,
+		storeCountry:     strings.ToUpper(storeCountry),
+	}
+}
+
+// PricesIncludeTax returns whether prices and shipping costs include tax
+func (uc *TaxUseCase) PricesIncludeTax() bool {
+	return uc.pricesIncludeTax
+}
+
+// TaxClassInput contains the data needed to create or update a tax class
+type TaxClassInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CreateTaxClass creates a new tax class
+func (uc *TaxUseCase) CreateTaxClass(input TaxClassInput) (*entity.TaxClass, error) {
+	taxClass, err := entity.NewTaxClass(input.Name, input.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.taxClassRepo.Create(taxClass); err != nil {
+		return nil, err
+	}
+
+	return taxClass, nil
+}
+
+// UpdateTaxClass updates a tax class
+func (uc *TaxUseCase) UpdateTaxClass(taxClassID uint, input TaxClassInput) (*entity.TaxClass, error) {
+	taxClass, err := uc.taxClassRepo.GetByID(taxClassID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != "" {
+		taxClass.Name = input.Name
+	}
+	taxClass.Description = input.Description
+	taxClass.UpdatedAt = time.Now()
+
+	if err := uc.taxClassRepo.Update(taxClass); err != nil {
+		return nil, err
+	}
+
+	return taxClass, nil
+}
+
+// DeleteTaxClass deletes a tax class and its rates
+func (uc *TaxUseCase) DeleteTaxClass(taxClassID uint) error {
+	if _, err := uc.taxClassRepo.GetByID(taxClassID); err != nil {
+		return err
+	}
+
+	return uc.taxClassRepo.Delete(taxClassID)
+}
+
+// ListTaxClasses lists all tax classes
+func (uc *TaxUseCase) ListTaxClasses() ([]*entity.TaxClass, error) {
+	return uc.taxClassRepo.List()
+}
+
+// TaxRateInput contains the data needed to create or update a tax rate
+type TaxRateInput struct {
+	Name        string   `json:"name"`
+	TaxClassID  uint     `json:"tax_class_id"` // 0 for the standard rate
+	Country     string   `json:"country"`
+	States      []string `json:"states"`
+	PostalCodes []string `json:"postal_codes"`
+	Rate        float64  `json:"rate"`   // percentage
+	Active      *bool    `json:"active"` // defaults to true
+}
+
+// CreateTaxRate creates a new tax rate
+func (uc *TaxUseCase) CreateTaxRate(input TaxRateInput) (*entity.TaxRate, error) {
+	taxRate, err := entity.NewTaxRate(input.Name, input.TaxClassID, input.Country, input.Rate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.applyTaxRateInput(taxRate, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.taxRateRepo.Create(taxRate); err != nil {
+		return nil, err
+	}
+
+	return taxRate, nil
+}
+
+// UpdateTaxRate replaces the details of a tax rate
+func (uc *TaxUseCase) UpdateTaxRate(taxRateID uint, input TaxRateInput) (*entity.TaxRate, error) {
+	taxRate, err := uc.taxRateRepo.GetByID(taxRateID)
+	if err != nil {
+		return nil, err
+	}
+
+	taxRate.Name = input.Name
+	taxRate.TaxClassID = input.TaxClassID
+	taxRate.Country = strings.ToUpper(input.Country)
+	taxRate.Rate = input.Rate
+
+	if err := taxRate.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.applyTaxRateInput(taxRate, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.taxRateRepo.Update(taxRate); err != nil {
+		return nil, err
+	}
+
+	return taxRate, nil
+}
+
+// DeleteTaxRate deletes a tax rate
+func (uc *TaxUseCase) DeleteTaxRate(taxRateID uint) error {
+	if _, err := uc.taxRateRepo.GetByID(taxRateID); err != nil {
+		return err
+	}
+
+	return uc.taxRateRepo.Delete(taxRateID)
+}
+
+// GetTaxRate retrieves a tax rate by ID
+func (uc *TaxUseCase) GetTaxRate(taxRateID uint) (*entity.TaxRate, error) {
+	return uc.taxRateRepo.GetByID(taxRateID)
+}
+
+// ListTaxRates lists all tax rates
+func (uc *TaxUseCase) ListTaxRates() ([]*entity.TaxRate, error) {
+	return uc.taxRateRepo.List()
+}
+
+// applyTaxRateInput validates the tax class and applies the regions and status of the input
+func (uc *TaxUseCase) applyTaxRateInput(taxRate *entity.TaxRate, input TaxRateInput) error {
+	if input.TaxClassID != entity.StandardTaxClassID {
+		if _, err := uc.taxClassRepo.GetByID(input.TaxClassID); err != nil {
+			return err
+		}
+	}
+
+	states := input.States
+	if states == nil {
+		states = []string{}
+	}
+	postalCodes := input.PostalCodes
+	if postalCodes == nil {
+		postalCodes = []string{}
+	}
+	taxRate.SetStates(states)
+	taxRate.SetPostalCodes(postalCodes)
+
+	if input.Active != nil {
+		taxRate.Active = *input.Active
+	}
+
+	return nil
+}
+
+// ValidateVATID validates an EU VAT number
+func (uc *TaxUseCase) ValidateVATID(vatID string) (*service.VATValidation, error) {
+	if uc.vatValidator == nil {
+		return nil, errors.New("VAT number validation is not enabled")
+	}
+	if vatID == "" {
+		return nil, errors.New("VAT number is required")
+	}
+
+	return uc.vatValidator.Validate(vatID)
+}
+
+// ApplyOrderTax calculates the tax of an order's items and shipping for its shipping address.
+// Orders of EU businesses with a valid VAT number shipped to another EU country are zero-rated
+// under the reverse charge, and pay the net price when prices include tax.
+func (uc *TaxUseCase) ApplyOrderTax(order *entity.Order) error {
+	reverseCharge, err := uc.isReverseCharge(order)
+	if err != nil {
+		return err
+	}
+	if reverseCharge && !uc.pricesIncludeTax {
+		order.ApplyReverseCharge()
+		return nil
+	}
+
+	result, err := uc.calculateOrderTax(order)
+	if err != nil {
+		return err
+	}
+
+	if reverseCharge {
+		if err := order.RemoveIncludedTax(result.Lines, result.Shipping); err != nil {
+			return err
+		}
+		order.ApplyReverseCharge()
+		return nil
+	}
+
+	return order.SetTax(result.Lines, result.Shipping, uc.pricesIncludeTax)
+}
+
+// calculateOrderTax calculates the tax of an order's items and shipping for its shipping address
+func (uc *TaxUseCase) calculateOrderTax(order *entity.Order) (*service.TaxResult, error) {
+	amounts, shippingAmount := order.TaxableAmounts()
+
+	lines := make([]service.TaxableLine, len(order.Items))
+	for i, item := range order.Items {
+		lines[i] = service.TaxableLine{
+			TaxClassID: item.TaxClassID,
+			Amount:     amounts[i],
+		}
+	}
+
+	return uc.calculator.Calculate(service.TaxRequest{
+		Address:          order.ShippingAddr,
+		Currency:         order.Currency,
+		Lines:            lines,
+		ShippingAmount:   shippingAmount,
+		PricesIncludeTax: uc.pricesIncludeTax,
+	})
+}
+
+// isReverseCharge checks if an order is an intra-EU B2B sale eligible for the reverse charge.
+// An invalid VAT number is an error. When the validator is unavailable VAT is charged,
+// so the order can still be placed.
+func (uc *TaxUseCase) isReverseCharge(order *entity.Order) (bool, error) {
+	vatID := order.CustomerDetails.VATID
+	if vatID == "" || uc.vatValidator == nil || !entity.IsEUCountry(uc.storeCountry) {
+		return false, nil
+	}
+
+	validation, err := uc.vatValidator.Validate(vatID)
+	if err != nil {
+		return false, nil
+	}
+	if !validation.Valid {
+		return false, errors.New("invalid VAT number")
+	}
+	order.CustomerDetails.VATID = validation.VATID
+
+	shippingCountry := strings.ToUpper(order.ShippingAddr.Country)
+	return entity.IsEUCountry(shippingCountry) &&
+		shippingCountry != uc.storeCountry &&
+		validation.CountryCode != uc.storeCountry, nil
+}
+
+// ApplyShippingOptionsTax sets the tax on the cost, less any discount, of each shipping option
+// delivered to the address. Shipping rates are defined in the default currency.
+func (uc *TaxUseCase) ApplyShippingOptionsTax(address entity.Address, options *ShippingOptions) error {
+	for _, option := range options.Options {
+		result, err := uc.calculator.Calculate(service.TaxRequest{
+			Address:          address,
+			ShippingAmount:   option.Cost - option.DiscountAmount,
+			PricesIncludeTax: uc.pricesIncludeTax,
+		})
+		if err != nil {
+			return err
+		}
+		option.TaxAmount = result.Shipping.Amount
+	}
+
+	return nil
+}
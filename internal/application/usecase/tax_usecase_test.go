@@ -0,0 +1,274 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/tax"
+	"github.com/zenfulcode/commercify/internal/infrastructure/vat"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+// newTaxUseCase creates a tax use case with a 25% standard rate in Denmark,
+// German standard and reduced rates and a reduced rate for Heligoland's postal code
+func newTaxUseCase(pricesIncludeTax bool) (*usecase.TaxUseCase, *entity.TaxClass) {
+	taxClassRepo := mock.NewMockTaxClassRepository()
+	taxRateRepo := mock.NewMockTaxRateRepository()
+	taxUseCase := usecase.NewTaxUseCase(taxClassRepo, taxRateRepo, tax.NewRateTableCalculator(taxRateRepo), nil, pricesIncludeTax, "")
+
+	reduced, _ := taxUseCase.CreateTaxClass(usecase.TaxClassInput{Name: "Reduced"})
+	taxUseCase.CreateTaxRate(usecase.TaxRateInput{Name: "DK VAT", Country: "dk", Rate: 25})
+	taxUseCase.CreateTaxRate(usecase.TaxRateInput{Name: "DE VAT", Country: "DE", Rate: 19})
+	taxUseCase.CreateTaxRate(usecase.TaxRateInput{Name: "DE reduced VAT", TaxClassID: reduced.ID, Country: "DE", Rate: 7})
+	taxUseCase.CreateTaxRate(usecase.TaxRateInput{Name: "Heligoland", Country: "DE", PostalCodes: []string{"27498"}, Rate: 0})
+
+	return taxUseCase, reduced
+}
+
+// newReverseChargeTaxUseCase creates a tax use case for a Danish store with Danish and
+// German standard rates, validating VAT numbers with the fake VIES client
+func newReverseChargeTaxUseCase(client vat.VIESClient, pricesIncludeTax bool) *usecase.TaxUseCase {
+	taxRateRepo := mock.NewMockTaxRateRepository()
+	taxUseCase := usecase.NewTaxUseCase(
+		mock.NewMockTaxClassRepository(),
+		taxRateRepo,
+		tax.NewRateTableCalculator(taxRateRepo),
+		vat.NewVIESValidator(client),
+		pricesIncludeTax,
+		"DK",
+	)
+
+	taxUseCase.CreateTaxRate(usecase.TaxRateInput{Name: "DK VAT", Country: "DK", Rate: 25})
+	taxUseCase.CreateTaxRate(usecase.TaxRateInput{Name: "DE VAT", Country: "DE", Rate: 19})
+
+	return taxUseCase
+}
+
+func newTaxedOrder(country, postalCode string, taxClassID uint) *entity.Order {
+	order, _ := entity.NewGuestOrder([]entity.OrderItem{
+		{ProductID: 1, Quantity: 2, Price: 5000, Subtotal: 10000},
+		{ProductID: 2, Quantity: 1, Price: 2000, Subtotal: 2000, TaxClassID: taxClassID},
+	}, entity.Address{Country: country, PostalCode: postalCode}, entity.Address{}, entity.CustomerDetails{})
+	order.SetShippingMethod(&entity.ShippingMethod{ID: 1}, 500, nil, nil)
+	return order
+}
+
+func TestTaxUseCase_CreateTaxRate(t *testing.T) {
+	t.Run("Invalid input", func(t *testing.T) {
+		taxUseCase, _ := newTaxUseCase(false)
+
+		inputs := map[string]usecase.TaxRateInput{
+			"missing name":      {Country: "DK", Rate: 25},
+			"invalid country":   {Name: "VAT", Country: "DNK", Rate: 25},
+			"negative rate":     {Name: "VAT", Country: "DK", Rate: -1},
+			"rate above 100":    {Name: "VAT", Country: "DK", Rate: 101},
+			"unknown tax class": {Name: "VAT", TaxClassID: 99, Country: "DK", Rate: 25},
+		}
+
+		for name, input := range inputs {
+			// Execute
+			taxRate, err := taxUseCase.CreateTaxRate(input)
+
+			// Assert
+			assert.Error(t, err, name)
+			assert.Nil(t, taxRate, name)
+		}
+	})
+}
+
+func TestTaxUseCase_ApplyOrderTax(t *testing.T) {
+	t.Run("Tax-exclusive prices", func(t *testing.T) {
+		taxUseCase, _ := newTaxUseCase(false)
+		order := newTaxedOrder("DK", "2100", 0)
+
+		// Execute
+		err := taxUseCase.ApplyOrderTax(order)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2500), order.Items[0].TaxAmount)
+		assert.Equal(t, int64(500), order.Items[1].TaxAmount)
+		assert.Equal(t, 25.0, order.ShippingTaxRate)
+		assert.Equal(t, int64(125), order.ShippingTaxAmount)
+		assert.Equal(t, int64(3125), order.TaxAmount)
+		assert.Equal(t, int64(12000+500+3125), order.FinalAmount)
+	})
+
+	t.Run("Tax-inclusive prices", func(t *testing.T) {
+		taxUseCase, _ := newTaxUseCase(true)
+		order := newTaxedOrder("DK", "2100", 0)
+
+		// Execute
+		err := taxUseCase.ApplyOrderTax(order)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, order.PricesIncludeTax)
+		assert.Equal(t, int64(2000), order.Items[0].TaxAmount)
+		assert.Equal(t, int64(400), order.Items[1].TaxAmount)
+		assert.Equal(t, int64(100), order.ShippingTaxAmount)
+		assert.Equal(t, int64(2500), order.TaxAmount)
+		assert.Equal(t, int64(12500), order.FinalAmount)
+	})
+
+	t.Run("Tax classes and the most specific rate", func(t *testing.T) {
+		taxUseCase, reduced := newTaxUseCase(false)
+		order := newTaxedOrder("DE", "10115", reduced.ID)
+		heligoland := newTaxedOrder("DE", "27498", reduced.ID)
+
+		// Execute
+		err := taxUseCase.ApplyOrderTax(order)
+		heligolandErr := taxUseCase.ApplyOrderTax(heligoland)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 19.0, order.Items[0].TaxRate)
+		assert.Equal(t, 7.0, order.Items[1].TaxRate)
+		assert.Equal(t, int64(140), order.Items[1].TaxAmount)
+		assert.Equal(t, 19.0, order.ShippingTaxRate)
+
+		// The postal code rate replaces the standard rate, the class keeps its own rate
+		assert.NoError(t, heligolandErr)
+		assert.Equal(t, 0.0, heligoland.Items[0].TaxRate)
+		assert.Equal(t, 7.0, heligoland.Items[1].TaxRate)
+		assert.Equal(t, int64(0), heligoland.ShippingTaxAmount)
+	})
+
+	t.Run("Countries without rates are not taxed", func(t *testing.T) {
+		taxUseCase, _ := newTaxUseCase(false)
+		order := newTaxedOrder("US", "10001", 0)
+
+		// Execute
+		err := taxUseCase.ApplyOrderTax(order)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), order.TaxAmount)
+		assert.Equal(t, int64(12500), order.FinalAmount)
+	})
+
+	t.Run("Discounts reduce the taxable amounts", func(t *testing.T) {
+		taxUseCase, _ := newTaxUseCase(false)
+		order := newTaxedOrder("DK", "2100", 0)
+		taxUseCase.ApplyOrderTax(order)
+
+		discount, _ := entity.NewDiscount(
+			"SAVE10",
+			entity.DiscountTypeBasket,
+			entity.DiscountMethodPercentage,
+			10.0,
+			0,
+			0,
+			[]uint{},
+			[]uint{},
+			time.Now().Add(-24*time.Hour),
+			time.Now().Add(30*24*time.Hour),
+			0,
+		)
+		discount.ID = 1
+
+		// Execute
+		err := order.ApplyDiscount(discount, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1200), order.DiscountAmount)
+		assert.Equal(t, int64(2250), order.Items[0].TaxAmount)
+		assert.Equal(t, int64(450), order.Items[1].TaxAmount)
+		assert.Equal(t, int64(2825), order.TaxAmount)
+		assert.Equal(t, int64(12000+500-1200+2825), order.FinalAmount)
+
+		// Removing the discount restores the tax
+		order.RemoveDiscount()
+		assert.Equal(t, int64(3125), order.TaxAmount)
+	})
+}
+
+func TestTaxUseCase_ReverseCharge(t *testing.T) {
+	newBusinessOrder := func(country, vatID string) *entity.Order {
+		order := newTaxedOrder(country, "10115", 0)
+		order.CustomerDetails.CompanyName = "Example GmbH"
+		order.CustomerDetails.VATID = vatID
+		return order
+	}
+
+	t.Run("Valid VAT number in another member state", func(t *testing.T) {
+		taxUseCase := newReverseChargeTaxUseCase(vat.NewFakeVIESClient(), false)
+		order := newBusinessOrder("DE", "de 123.456.789")
+
+		// Execute
+		err := taxUseCase.ApplyOrderTax(order)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, order.ReverseCharge)
+		assert.Equal(t, "DE123456789", order.CustomerDetails.VATID)
+		assert.Equal(t, 0.0, order.Items[0].TaxRate)
+		assert.Equal(t, int64(0), order.TaxAmount)
+		assert.Equal(t, int64(12000+500), order.FinalAmount)
+	})
+
+	t.Run("Tax-inclusive prices are charged net", func(t *testing.T) {
+		taxUseCase := newReverseChargeTaxUseCase(vat.NewFakeVIESClient(), true)
+		order := newBusinessOrder("DE", "DE123456789")
+
+		// Execute
+		err := taxUseCase.ApplyOrderTax(order)
+
+		// Assert: the 19% German VAT is removed from the prices and shipping
+		assert.NoError(t, err)
+		assert.True(t, order.ReverseCharge)
+		assert.False(t, order.PricesIncludeTax)
+		assert.Equal(t, int64(0), order.TaxAmount)
+		assert.Equal(t, int64(4202), order.Items[0].Price)
+		assert.Equal(t, int64(8403), order.Items[0].Subtotal)
+		assert.Equal(t, int64(1681), order.Items[1].Subtotal)
+		assert.Equal(t, int64(420), order.ShippingCost)
+		assert.Equal(t, int64(8403+1681+420), order.FinalAmount)
+	})
+
+	t.Run("Domestic business customers pay VAT", func(t *testing.T) {
+		taxUseCase := newReverseChargeTaxUseCase(vat.NewFakeVIESClient(), false)
+		order := newBusinessOrder("DK", "DK12345678")
+
+		// Execute
+		err := taxUseCase.ApplyOrderTax(order)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, order.ReverseCharge)
+		assert.Equal(t, int64(3125), order.TaxAmount)
+	})
+
+	t.Run("Invalid VAT numbers are rejected", func(t *testing.T) {
+		client := vat.NewFakeVIESClient()
+		client.Register("DE999999999", vat.VIESResult{Valid: false})
+		taxUseCase := newReverseChargeTaxUseCase(client, false)
+
+		for _, vatID := range []string{"DE999999999", "DE1234", "XX123456789"} {
+			// Execute
+			err := taxUseCase.ApplyOrderTax(newBusinessOrder("DE", vatID))
+
+			// Assert
+			assert.Error(t, err, vatID)
+		}
+	})
+
+	t.Run("VAT is charged when VIES is unavailable", func(t *testing.T) {
+		client := vat.NewFakeVIESClient()
+		client.SetUnavailable(true)
+		taxUseCase := newReverseChargeTaxUseCase(client, false)
+		order := newBusinessOrder("DE", "DE123456789")
+
+		// Execute
+		err := taxUseCase.ApplyOrderTax(order)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, order.ReverseCharge)
+		assert.Equal(t, int64(2280+95), order.TaxAmount)
+	})
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// TrackingUseCase implements shipment tracking use cases
+type TrackingUseCase struct {
+	orderRepo    repository.OrderRepository
+	trackingRepo repository.TrackingEventRepository
+	provider     service.TrackingProvider
+}
+
+// NewTrackingUseCase creates a new TrackingUseCase.
+// The provider is nil if no tracking provider is configured, orders are then tracked by their tracking code only.
+func NewTrackingUseCase(
+	orderRepo repository.OrderRepository,
+	trackingRepo repository.TrackingEventRepository,
+	provider service.TrackingProvider,
+) *TrackingUseCase {
+	return &TrackingUseCase{
+		orderRepo:    orderRepo,
+		trackingRepo: trackingRepo,
+		provider:     provider,
+	}
+}
+
+// OrderTracking is the tracking of an order's shipment with its events oldest first
+type OrderTracking struct {
+	Order  *entity.Order
+	Events []*entity.TrackingEvent
+}
+
+// RecordTracking stores the new events of a tracking update for the order shipped with its tracking number.
+// The order is marked as delivered when the carrier reports delivery.
+func (uc *TrackingUseCase) RecordTracking(update *service.TrackingUpdate) (*entity.Order, error) {
+	order, err := uc.orderRepo.GetByTrackingCode(update.TrackingNumber)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	if err := uc.recordEvents(order, update); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// HandleWebhook records a tracking webhook sent by the carrier
+func (uc *TrackingUseCase) HandleWebhook(payload []byte) (*entity.Order, error) {
+	if uc.provider == nil {
+		return nil, errors.New("tracking is not configured")
+	}
+
+	update, err := uc.provider.ParseWebhook(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracking webhook: %w", err)
+	}
+
+	return uc.RecordTracking(update)
+}
+
+// PollShipments polls the carrier for the tracking of every shipped order and returns the number of orders
+// updated. Orders the carrier cannot track are skipped and reported in the error.
+func (uc *TrackingUseCase) PollShipments() (int, error) {
+	if uc.provider == nil {
+		return 0, errors.New("tracking is not configured")
+	}
+
+	// Collect the orders first, as delivered orders drop out of the list while paging
+	const pageSize = 100
+	var orders []*entity.Order
+	for offset := 0; ; offset += pageSize {
+		page, err := uc.orderRepo.ListByStatus(entity.OrderStatusShipped, offset, pageSize)
+		if err != nil {
+			return 0, err
+		}
+		orders = append(orders, page...)
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	updated := 0
+	var errs []error
+	for _, listed := range orders {
+		if listed.TrackingCode == "" {
+			continue
+		}
+
+		// Listed orders lack the shipping label and totals, which the update must keep
+		order, err := uc.orderRepo.GetByID(listed.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", listed.OrderNumber, err))
+			continue
+		}
+
+		carrierCode := ""
+		if order.ShippingLabel != nil {
+			carrierCode = order.ShippingLabel.CarrierCode
+		}
+
+		update, err := uc.provider.GetTracking(carrierCode, order.TrackingCode)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", order.OrderNumber, err))
+			continue
+		}
+		if err := uc.recordEvents(order, update); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", order.OrderNumber, err))
+			continue
+		}
+		updated++
+	}
+
+	return updated, errors.Join(errs...)
+}
+
+// GetOrderTracking returns the tracking of an order for the customer who placed it.
+// Orders whose email does not match are reported as not found.
+func (uc *TrackingUseCase) GetOrderTracking(orderNumber, email string) (*OrderTracking, error) {
+	order, err := uc.orderRepo.GetByOrderNumber(strings.TrimSpace(orderNumber))
+	if err != nil || email == "" || !strings.EqualFold(order.CustomerDetails.Email, strings.TrimSpace(email)) {
+		return nil, errors.New("order not found")
+	}
+
+	events, err := uc.trackingRepo.ListByOrderID(order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OrderTracking{Order: order, Events: events}, nil
+}
+
+// recordEvents stores the events of an update and marks a shipped order as delivered on delivery
+func (uc *TrackingUseCase) recordEvents(order *entity.Order, update *service.TrackingUpdate) error {
+	delivered := false
+	for _, updateEvent := range update.Events {
+		event, err := entity.NewTrackingEvent(
+			order.ID,
+			order.TrackingCode,
+			updateEvent.Status,
+			updateEvent.Description,
+			updateEvent.Location,
+			updateEvent.OccurredAt,
+		)
+		if err != nil {
+			return err
+		}
+		if err := uc.trackingRepo.Create(event); err != nil {
+			return err
+		}
+		delivered = delivered || event.Status == entity.TrackingStatusDelivered
+	}
+
+	if delivered && order.Status == entity.OrderStatusShipped {
+		if err := order.UpdateStatus(entity.OrderStatusDelivered); err != nil {
+			return err
+		}
+		if err := uc.orderRepo.Update(order); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/infrastructure/carrier"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func setupTracking(t *testing.T) (*usecase.TrackingUseCase, *carrier.FakeTrackingProvider, repository.OrderRepository, *entity.Order) {
+	orderRepo := mock.NewMockOrderRepository(false)
+	provider := carrier.NewFakeTrackingProvider()
+	trackingUseCase := usecase.NewTrackingUseCase(orderRepo, mock.NewMockTrackingEventRepository(), provider)
+
+	address := entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"}
+	order, _ := entity.NewOrder(
+		1,
+		[]entity.OrderItem{{ProductID: 1, Quantity: 1, Price: 2000, Subtotal: 2000}},
+		address,
+		address,
+		entity.CustomerDetails{Email: "jane@example.com", FullName: "Jane Doe"},
+	)
+	order.UpdateStatus(entity.OrderStatusPaid)
+	order.UpdateStatus(entity.OrderStatusShipped)
+	order.SetTrackingCode("FAKE0000000001")
+	orderRepo.Create(order)
+	order.SetOrderNumber(order.ID)
+	assert.NoError(t, orderRepo.Update(order))
+
+	return trackingUseCase, provider, orderRepo, order
+}
+
+func TestTrackingUseCase_HandleWebhook(t *testing.T) {
+	t.Run("Delivery marks the order delivered", func(t *testing.T) {
+		trackingUseCase, _, orderRepo, order := setupTracking(t)
+		payload := []byte(`{
+			"tracking_number": "FAKE0000000001",
+			"events": [
+				{"status": "in_transit", "description": "Parcel sorted", "location": "Odense, DK", "occurred_at": "2026-10-19T08:00:00Z"},
+				{"status": "out_for_delivery", "description": "Out for delivery", "occurred_at": "2026-10-20T07:00:00Z"},
+				{"status": "delivered", "description": "Delivered to recipient", "occurred_at": "2026-10-20T12:30:00Z"}
+			]
+		}`)
+
+		// Execute
+		updated, err := trackingUseCase.HandleWebhook(payload)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusDelivered, updated.Status)
+		stored, _ := orderRepo.GetByID(order.ID)
+		assert.Equal(t, entity.OrderStatusDelivered, stored.Status)
+
+		tracking, err := trackingUseCase.GetOrderTracking(order.OrderNumber, "Jane@Example.com")
+		assert.NoError(t, err)
+		assert.Len(t, tracking.Events, 3)
+		assert.Equal(t, entity.TrackingStatusInTransit, tracking.Events[0].Status)
+		assert.Equal(t, "Odense, DK", tracking.Events[0].Location)
+		assert.Equal(t, entity.TrackingStatusDelivered, tracking.Events[2].Status)
+	})
+
+	t.Run("Events reported again are stored once", func(t *testing.T) {
+		trackingUseCase, _, _, order := setupTracking(t)
+		payload := []byte(`{"tracking_number": "FAKE0000000001", "events": [
+			{"status": "in_transit", "description": "Parcel sorted", "occurred_at": "2026-10-19T08:00:00Z"}
+		]}`)
+
+		// Execute
+		_, firstErr := trackingUseCase.HandleWebhook(payload)
+		updated, secondErr := trackingUseCase.HandleWebhook(payload)
+
+		// Assert
+		assert.NoError(t, firstErr)
+		assert.NoError(t, secondErr)
+		assert.Equal(t, entity.OrderStatusShipped, updated.Status)
+		tracking, _ := trackingUseCase.GetOrderTracking(order.OrderNumber, "jane@example.com")
+		assert.Len(t, tracking.Events, 1)
+	})
+
+	t.Run("Unknown shipment", func(t *testing.T) {
+		trackingUseCase, _, _, _ := setupTracking(t)
+
+		// Execute
+		_, err := trackingUseCase.HandleWebhook([]byte(`{"tracking_number": "UNKNOWN", "events": []}`))
+
+		// Assert
+		assert.EqualError(t, err, "order not found")
+	})
+
+	t.Run("Invalid status", func(t *testing.T) {
+		trackingUseCase, _, _, _ := setupTracking(t)
+
+		// Execute
+		_, err := trackingUseCase.HandleWebhook([]byte(`{"tracking_number": "FAKE0000000001", "events": [{"status": "lost"}]}`))
+
+		// Assert
+		assert.EqualError(t, err, "invalid tracking status")
+	})
+}
+
+func TestTrackingUseCase_PollShipments(t *testing.T) {
+	trackingUseCase, provider, orderRepo, order := setupTracking(t)
+	provider.AddEvent("FAKE0000000001", entity.TrackingStatusInTransit, "Parcel sorted")
+	provider.AddEvent("FAKE0000000001", entity.TrackingStatusDelivered, "Delivered to recipient")
+
+	// A shipped order the carrier does not know
+	other, _ := entity.NewOrder(
+		1,
+		[]entity.OrderItem{{ProductID: 1, Quantity: 1, Price: 2000, Subtotal: 2000}},
+		entity.Address{Country: "DK"},
+		entity.Address{Country: "DK"},
+		entity.CustomerDetails{Email: "john@example.com"},
+	)
+	other.UpdateStatus(entity.OrderStatusPaid)
+	other.UpdateStatus(entity.OrderStatusShipped)
+	other.SetTrackingCode("UNKNOWN")
+	orderRepo.Create(other)
+
+	// Execute
+	updated, err := trackingUseCase.PollShipments()
+
+	// Assert
+	assert.Equal(t, 1, updated)
+	assert.ErrorContains(t, err, "tracking number not found")
+	stored, _ := orderRepo.GetByID(order.ID)
+	assert.Equal(t, entity.OrderStatusDelivered, stored.Status)
+}
+
+func TestTrackingUseCase_PollShipmentsKeepsOrderDetails(t *testing.T) {
+	trackingUseCase, provider, orderRepo, order := setupTracking(t)
+	order.ShippingCost = 500
+	order.TaxAmount = 400
+	order.FinalAmount = 2500
+	order.Packages = []entity.OrderPackage{{Name: "Small box", Weight: 0.5}}
+	order.SetShippingLabel(&entity.ShippingLabel{
+		Carrier:        "shippo",
+		CarrierCode:    "usps",
+		TrackingNumber: "FAKE0000000001",
+		LabelURL:       "https://labels.example.com/1.pdf",
+		Cost:           450,
+		Currency:       "USD",
+	})
+	assert.NoError(t, orderRepo.Update(order))
+	provider.AddEvent("FAKE0000000001", entity.TrackingStatusDelivered, "Delivered to recipient")
+
+	// Execute
+	updated, err := trackingUseCase.PollShipments()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	stored, _ := orderRepo.GetByID(order.ID)
+	assert.Equal(t, entity.OrderStatusDelivered, stored.Status)
+	assert.Equal(t, int64(2500), stored.FinalAmount)
+	assert.Equal(t, int64(400), stored.TaxAmount)
+	assert.Equal(t, int64(500), stored.ShippingCost)
+	assert.Len(t, stored.Packages, 1)
+	if assert.NotNil(t, stored.ShippingLabel) {
+		assert.Equal(t, "usps", stored.ShippingLabel.CarrierCode)
+	}
+}
+
+func TestTrackingUseCase_GetOrderTracking(t *testing.T) {
+	trackingUseCase, _, _, order := setupTracking(t)
+
+	t.Run("Wrong email", func(t *testing.T) {
+		// Execute
+		tracking, err := trackingUseCase.GetOrderTracking(order.OrderNumber, "john@example.com")
+
+		// Assert
+		assert.EqualError(t, err, "order not found")
+		assert.Nil(t, tracking)
+	})
+
+	t.Run("No events yet", func(t *testing.T) {
+		// Execute
+		tracking, err := trackingUseCase.GetOrderTracking(order.OrderNumber, "jane@example.com")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "FAKE0000000001", tracking.Order.TrackingCode)
+		assert.Empty(t, tracking.Events)
+	})
+}
+
+func TestNewTrackingEvent(t *testing.T) {
+	event, err := entity.NewTrackingEvent(1, "FAKE0000000001", entity.TrackingStatusException, "Address not found", "", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, entity.TrackingStatusException, event.Status)
+
+	_, err = entity.NewTrackingEvent(1, "FAKE0000000001", entity.TrackingStatusDelivered, "", "", time.Time{})
+	assert.EqualError(t, err, "tracking event time cannot be empty")
+}
@@ -8,12 +8,13 @@ import (
 
 // Cart represents a user's shopping cart
 type Cart struct {
-	ID        uint       `json:"id"`
-	UserID    uint       `json:"user_id,omitempty"`
-	SessionID string     `json:"session_id,omitempty"`
-	Items     []CartItem `json:"items"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID           uint       `json:"id"`
+	UserID       uint       `json:"user_id,omitempty"`
+	SessionID    string     `json:"session_id,omitempty"`
+	Items        []CartItem `json:"items"`
+	DiscountCode string     `json:"discount_code,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 }
 
 // CartItem represents an item in a shopping cart
@@ -139,6 +140,24 @@ func (c *Cart) RemoveItem(productID uint, variantID uint) error {
 // Clear empties the cart
 func (c *Cart) Clear() {
 	c.Items = []CartItem{}
+	c.DiscountCode = ""
+	c.UpdatedAt = time.Now()
+}
+
+// SetDiscountCode attaches a discount code to the cart
+func (c *Cart) SetDiscountCode(code string) error {
+	if code == "" {
+		return errors.New("discount code cannot be empty")
+	}
+
+	c.DiscountCode = code
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// RemoveDiscountCode removes the discount code from the cart
+func (c *Cart) RemoveDiscountCode() {
+	c.DiscountCode = ""
 	c.UpdatedAt = time.Now()
 }
 
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"strings"
 	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/money"
 )
 
 // Currency represents a currency in the system
@@ -26,6 +28,7 @@ type ProductPrice struct {
 	Price        int64     `json:"price"` // Price in cents
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	PriceSchedule
 }
 
 // ProductVariantPrice represents a price for a product variant in a specific currency
@@ -36,6 +39,7 @@ type ProductVariantPrice struct {
 	Price        int64     `json:"price"` // Price in cents
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	PriceSchedule
 }
 
 // NewCurrency creates a new Currency
@@ -116,11 +120,8 @@ func (c *Currency) ConvertAmount(amount int64, targetCurrency *Currency) int64 {
 		return amount
 	}
 
-	// First convert to a base unit
-	baseAmount := float64(amount) / c.ExchangeRate
-
-	// Then convert to target currency
-	targetAmount := baseAmount * targetCurrency.ExchangeRate
+	// Convert via the base currency, respecting the minor units of both currencies
+	rate := targetCurrency.ExchangeRate / c.ExchangeRate
 
-	return int64(targetAmount)
+	return money.New(amount, c.Code).Convert(targetCurrency.Code, rate).Amount
 }
@@ -16,6 +16,8 @@ const (
 	DiscountTypeBasket DiscountType = "basket"
 	// DiscountTypeProduct applies to specific products
 	DiscountTypeProduct DiscountType = "product"
+	// DiscountTypeShipping applies to the shipping cost of the order
+	DiscountTypeShipping DiscountType = "shipping"
 )
 
 // DiscountMethod represents how the discount is calculated
@@ -26,6 +28,8 @@ const (
 	DiscountMethodFixed DiscountMethod = "fixed"
 	// DiscountMethodPercentage is a percentage discount
 	DiscountMethodPercentage DiscountMethod = "percentage"
+	// DiscountMethodFreeShipping removes the shipping cost entirely (shipping discounts only)
+	DiscountMethodFreeShipping DiscountMethod = "free_shipping"
 )
 
 // Discount represents a discount in the system
@@ -39,13 +43,17 @@ type Discount struct {
 	MaxDiscountValue int64          `json:"max_discount_value"` // stored in cents
 	ProductIDs       []uint         `json:"product_ids,omitempty"`
 	CategoryIDs      []uint         `json:"category_ids,omitempty"`
-	StartDate        time.Time      `json:"start_date"`
-	EndDate          time.Time      `json:"end_date"`
-	UsageLimit       int            `json:"usage_limit"`
-	CurrentUsage     int            `json:"current_usage"`
-	Active           bool           `json:"active"`
-	CreatedAt        time.Time      `json:"created_at"`
-	UpdatedAt        time.Time      `json:"updated_at"`
+	// Shipping discounts can be restricted to specific shipping methods and zones
+	ShippingMethodIDs []uint    `json:"shipping_method_ids,omitempty"`
+	ShippingZoneIDs   []uint    `json:"shipping_zone_ids,omitempty"`
+	StartDate         time.Time `json:"start_date"`
+	EndDate           time.Time `json:"end_date"`
+	UsageLimit        int       `json:"usage_limit"`
+	CurrentUsage      int       `json:"current_usage"`
+	Active            bool      `json:"active"`
+	Campaign          string    `json:"campaign,omitempty"` // Groups discount codes for reporting
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // NewDiscount creates a new discount
@@ -66,7 +74,11 @@ func NewDiscount(
 		return nil, errors.New("discount code cannot be empty")
 	}
 
-	if value <= 0 {
+	if method == DiscountMethodFreeShipping {
+		if discountType != DiscountTypeShipping {
+			return nil, errors.New("free shipping method can only be used with shipping discounts")
+		}
+	} else if value <= 0 {
 		return nil, errors.New("discount value must be greater than zero")
 	}
 
@@ -117,13 +129,13 @@ func (d *Discount) IsApplicableToOrder(order *Order) bool {
 		return false
 	}
 
-	// Check minimum order value
-	if d.MinOrderValue > 0 && order.TotalAmount < d.MinOrderValue {
+	// Check minimum order value, which is in the default currency
+	if d.MinOrderValue > 0 && order.ToBaseAmount(order.TotalAmount) < d.MinOrderValue {
 		return false
 	}
 
 	switch d.Type {
-	case DiscountTypeBasket:
+	case DiscountTypeBasket, DiscountTypeShipping:
 		return true
 	case DiscountTypeProduct:
 		for _, item := range order.Items {
@@ -157,9 +169,8 @@ func (d *Discount) CalculateDiscount(order *Order) int64 {
 	if d.Type == DiscountTypeBasket {
 		// Calculate discount for the entire order
 		if d.Method == DiscountMethodFixed {
-			// For fixed amount method, the value is in dollars and needs to be converted to cents
-			// But since we updated the structure, the database will provide the value already in cents
-			discountAmount = money.ToCents(d.Value)
+			// For fixed amount method, the value is in the default currency
+			discountAmount = order.FromBaseAmount(money.ToCents(d.Value))
 		} else if d.Method == DiscountMethodPercentage {
 			// For percentage, apply the percentage to the total amount
 			discountAmount = money.ApplyPercentage(order.TotalAmount, d.Value)
@@ -174,7 +185,7 @@ func (d *Discount) CalculateDiscount(order *Order) int64 {
 				if d.Method == DiscountMethodFixed {
 					// For fixed discount, apply once per item (not per quantity)
 					// This matches with the current implementation in ApplyDiscountToOrder
-					fixedDiscountInCents := money.ToCents(d.Value)
+					fixedDiscountInCents := order.FromBaseAmount(money.ToCents(d.Value))
 					itemDiscount := min(fixedDiscountInCents, itemTotal)
 					discountAmount += itemDiscount
 				} else if d.Method == DiscountMethodPercentage {
@@ -185,9 +196,10 @@ func (d *Discount) CalculateDiscount(order *Order) int64 {
 		}
 	}
 
-	// Apply maximum discount cap if specified
-	if d.MaxDiscountValue > 0 && discountAmount > d.MaxDiscountValue {
-		discountAmount = d.MaxDiscountValue
+	// Apply maximum discount cap if specified, converted from the default currency
+	maxDiscount := order.FromBaseAmount(d.MaxDiscountValue)
+	if maxDiscount > 0 && discountAmount > maxDiscount {
+		discountAmount = maxDiscount
 	}
 
 	// Ensure discount doesn't exceed order total
@@ -198,6 +210,80 @@ func (d *Discount) CalculateDiscount(order *Order) int64 {
 	return discountAmount
 }
 
+// IsApplicableToShipping checks if a shipping discount applies to the given shipping method
+// and to an address that belongs to the given shipping zones
+func (d *Discount) IsApplicableToShipping(orderValue int64, shippingMethodID uint, zoneIDs []uint) bool {
+	if d.Type != DiscountTypeShipping || !d.IsValid() {
+		return false
+	}
+
+	// Check minimum order value
+	if d.MinOrderValue > 0 && orderValue < d.MinOrderValue {
+		return false
+	}
+
+	// Check shipping method restriction
+	if len(d.ShippingMethodIDs) > 0 && !slices.Contains(d.ShippingMethodIDs, shippingMethodID) {
+		return false
+	}
+
+	// Check shipping zone restriction
+	if len(d.ShippingZoneIDs) > 0 {
+		return slices.ContainsFunc(zoneIDs, func(zoneID uint) bool {
+			return slices.Contains(d.ShippingZoneIDs, zoneID)
+		})
+	}
+
+	return true
+}
+
+// CalculateShippingDiscount calculates the discount amount for a shipping cost.
+// The order value and shipping cost are in the default currency.
+func (d *Discount) CalculateShippingDiscount(orderValue, shippingCost int64, shippingMethodID uint, zoneIDs []uint) int64 {
+	if shippingCost <= 0 || !d.IsApplicableToShipping(orderValue, shippingMethodID, zoneIDs) {
+		return 0
+	}
+
+	return d.shippingDiscount(shippingCost, money.ToCents(d.Value), d.MaxDiscountValue)
+}
+
+// CalculateOrderShippingDiscount calculates the discount amount for the shipping cost
+// of an order, in the order currency
+func (d *Discount) CalculateOrderShippingDiscount(order *Order, zoneIDs []uint) int64 {
+	if order.ShippingCost <= 0 || !d.IsApplicableToShipping(order.ToBaseAmount(order.TotalAmount), order.ShippingMethodID, zoneIDs) {
+		return 0
+	}
+
+	return d.shippingDiscount(order.ShippingCost, order.FromBaseAmount(money.ToCents(d.Value)), order.FromBaseAmount(d.MaxDiscountValue))
+}
+
+// shippingDiscount calculates the discount for a shipping cost given the fixed
+// amount and maximum discount in the same currency as the cost
+func (d *Discount) shippingDiscount(shippingCost, fixedAmount, maxDiscount int64) int64 {
+	var discountAmount int64
+
+	switch d.Method {
+	case DiscountMethodFreeShipping:
+		discountAmount = shippingCost
+	case DiscountMethodFixed:
+		discountAmount = fixedAmount
+	case DiscountMethodPercentage:
+		discountAmount = money.ApplyPercentage(shippingCost, d.Value)
+	}
+
+	// Apply maximum discount cap if specified
+	if maxDiscount > 0 && discountAmount > maxDiscount {
+		discountAmount = maxDiscount
+	}
+
+	// Ensure discount doesn't exceed the shipping cost
+	if discountAmount > shippingCost {
+		discountAmount = shippingCost
+	}
+
+	return discountAmount
+}
+
 // IncrementUsage increments the usage count of the discount
 func (d *Discount) IncrementUsage() {
 	d.CurrentUsage++
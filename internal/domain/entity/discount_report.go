@@ -0,0 +1,66 @@
+package entity
+
+import "time"
+
+// DiscountReportInterval is the period redemptions are grouped by in a discount report
+type DiscountReportInterval string
+
+const (
+	DiscountReportIntervalDay   DiscountReportInterval = "day"
+	DiscountReportIntervalWeek  DiscountReportInterval = "week"
+	DiscountReportIntervalMonth DiscountReportInterval = "month"
+)
+
+// IsValid checks if the interval is supported
+func (i DiscountReportInterval) IsValid() bool {
+	switch i {
+	case DiscountReportIntervalDay, DiscountReportIntervalWeek, DiscountReportIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// DiscountOrderStats contains aggregated figures for a set of orders.
+// Amounts are in the default currency, converted with each order's exchange rate.
+type DiscountOrderStats struct {
+	OrderCount      int   // all orders, including unpaid and cancelled ones
+	PaidOrders      int   // orders whose payment went through
+	GrossRevenue    int64 // final amount of paid orders, stored in cents
+	DiscountAmount  int64 // item and shipping discounts granted, stored in cents
+	CancelledOrders int
+	RefundedOrders  int   // orders with at least one successful refund
+	RefundedAmount  int64 // stored in cents
+}
+
+// AverageOrderValue returns the average final amount of the paid orders
+func (s *DiscountOrderStats) AverageOrderValue() int64 {
+	if s.PaidOrders == 0 {
+		return 0
+	}
+	return s.GrossRevenue / int64(s.PaidOrders)
+}
+
+// CancellationRate returns the share of orders that were cancelled
+func (s *DiscountOrderStats) CancellationRate() float64 {
+	if s.OrderCount == 0 {
+		return 0
+	}
+	return float64(s.CancelledOrders) / float64(s.OrderCount)
+}
+
+// RefundRate returns the share of paid orders that were (partially) refunded
+func (s *DiscountOrderStats) RefundRate() float64 {
+	if s.PaidOrders == 0 {
+		return 0
+	}
+	return float64(s.RefundedOrders) / float64(s.PaidOrders)
+}
+
+// DiscountRedemptionPeriod contains the redemptions of a discount within one period.
+// Amounts are in the default currency.
+type DiscountRedemptionPeriod struct {
+	PeriodStart    time.Time
+	Redemptions    int
+	GrossRevenue   int64 // stored in cents
+	DiscountAmount int64 // stored in cents
+}
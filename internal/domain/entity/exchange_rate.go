@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ExchangeRateStatus represents the status of an exchange rate change
+type ExchangeRateStatus string
+
+const (
+	ExchangeRateStatusApplied  ExchangeRateStatus = "applied"
+	ExchangeRateStatusPending  ExchangeRateStatus = "pending" // Awaiting admin confirmation
+	ExchangeRateStatusRejected ExchangeRateStatus = "rejected"
+)
+
+// ExchangeRateSourceManual is the source of rates set by an admin
+const ExchangeRateSourceManual = "manual"
+
+// ExchangeRateHistory records a change of a currency's exchange rate
+type ExchangeRateHistory struct {
+	ID           uint               `json:"id"`
+	CurrencyCode string             `json:"currency_code"`
+	Rate         float64            `json:"rate"`
+	PreviousRate float64            `json:"previous_rate"`
+	Source       string             `json:"source"`
+	Status       ExchangeRateStatus `json:"status"`
+	CreatedAt    time.Time          `json:"created_at"`
+	ReviewedAt   *time.Time         `json:"reviewed_at,omitempty"`
+}
+
+// NewExchangeRateHistory creates a new exchange rate history entry
+func NewExchangeRateHistory(currencyCode string, rate, previousRate float64, source string, status ExchangeRateStatus) (*ExchangeRateHistory, error) {
+	if currencyCode == "" {
+		return nil, errors.New("currency code is required")
+	}
+
+	if rate <= 0 {
+		return nil, errors.New("exchange rate must be positive")
+	}
+
+	if source == "" {
+		return nil, errors.New("source is required")
+	}
+
+	return &ExchangeRateHistory{
+		CurrencyCode: currencyCode,
+		Rate:         rate,
+		PreviousRate: previousRate,
+		Source:       source,
+		Status:       status,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+// ChangePercentage returns the relative change from the previous rate in percent
+func (h *ExchangeRateHistory) ChangePercentage() float64 {
+	if h.PreviousRate <= 0 {
+		return 0
+	}
+	return math.Abs(h.Rate-h.PreviousRate) / h.PreviousRate * 100
+}
+
+// Confirm marks a pending rate as applied
+func (h *ExchangeRateHistory) Confirm() error {
+	if h.Status != ExchangeRateStatusPending {
+		return errors.New("exchange rate is not pending confirmation")
+	}
+
+	now := time.Now()
+	h.Status = ExchangeRateStatusApplied
+	h.ReviewedAt = &now
+	return nil
+}
+
+// Reject marks a pending rate as rejected
+func (h *ExchangeRateHistory) Reject() error {
+	if h.Status != ExchangeRateStatusPending {
+		return errors.New("exchange rate is not pending confirmation")
+	}
+
+	now := time.Now()
+	h.Status = ExchangeRateStatusRejected
+	h.ReviewedAt = &now
+	return nil
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of calendar dates in requests, e.g. 2026-12-25
+const DateLayout = "2006-01-02"
+
+// Holiday is a public holiday on which parcels are neither dispatched nor delivered in a country
+type Holiday struct {
+	ID        uint      `json:"id"`
+	Country   string    `json:"country"` // Country code like "DK"
+	Date      time.Time `json:"date"`    // Midnight UTC of the holiday
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewHoliday creates a new public holiday
+func NewHoliday(country string, date time.Time, name string) (*Holiday, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 {
+		return nil, errors.New("country must be a two-letter country code")
+	}
+	if date.IsZero() {
+		return nil, errors.New("holiday date cannot be empty")
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("holiday name cannot be empty")
+	}
+
+	return &Holiday{
+		Country:   country,
+		Date:      CalendarDate(date),
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// CalendarDate returns the date of t as midnight UTC, dropping its time of day and time zone
+func CalendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// BusinessCalendar knows the business days of a country: Monday to Friday, except public holidays
+type BusinessCalendar struct {
+	holidays map[string]bool
+}
+
+// NewBusinessCalendar creates a business calendar closed on the holidays
+func NewBusinessCalendar(holidays []*Holiday) *BusinessCalendar {
+	calendar := &BusinessCalendar{holidays: make(map[string]bool, len(holidays))}
+	for _, holiday := range holidays {
+		calendar.holidays[holiday.Date.Format(DateLayout)] = true
+	}
+	return calendar
+}
+
+// IsBusinessDay checks if parcels are handled on the date
+func (c *BusinessCalendar) IsBusinessDay(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.holidays[date.Format(DateLayout)]
+}
+
+// AddBusinessDays returns the date the given number of business days after date.
+// If that is not a business day, such as adding no days to a Sunday, the next business day is returned.
+func (c *BusinessCalendar) AddBusinessDays(date time.Time, days int) time.Time {
+	date = CalendarDate(date)
+	for added := 0; added < days; {
+		date = date.AddDate(0, 0, 1)
+		if c.IsBusinessDay(date) {
+			added++
+		}
+	}
+	for !c.IsBusinessDay(date) {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxIdempotencyKeyLength is the longest Idempotency-Key header accepted
+const MaxIdempotencyKeyLength = 255
+
+// ErrIdempotencyKeyTooLong is returned for keys longer than MaxIdempotencyKeyLength
+var ErrIdempotencyKeyTooLong = errors.New("idempotency key is too long")
+
+// IdempotencyKey is a client's key for a mutating request, with the response replayed to repeats of the request.
+// A key is scoped to the client that sent it, and expires after a while.
+type IdempotencyKey struct {
+	Scope        string // identifies the client, e.g. a hash of its credentials
+	Key          string
+	Method       string
+	Path         string
+	RequestHash  string // fingerprint of the method, path and body
+	StatusCode   int    // 0 while the request is in progress
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// NewIdempotencyKey creates the key of a request that is about to be handled
+func NewIdempotencyKey(scope, key, method, path, requestHash string, ttl time.Duration) (*IdempotencyKey, error) {
+	if key == "" {
+		return nil, errors.New("idempotency key cannot be empty")
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrIdempotencyKeyTooLong
+	}
+	if ttl <= 0 {
+		return nil, errors.New("idempotency key TTL must be positive")
+	}
+
+	now := time.Now()
+	return &IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		Method:      method,
+		Path:        path,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+// Complete stores the response to replay to repeats of the request
+func (k *IdempotencyKey) Complete(statusCode int, contentType string, body []byte) {
+	k.StatusCode = statusCode
+	k.ContentType = contentType
+	k.ResponseBody = body
+}
+
+// IsCompleted checks if the response of the request is stored
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
+
+// Matches checks if a request is a repeat of the key's request
+func (k *IdempotencyKey) Matches(method, path, requestHash string) bool {
+	return k.Method == method && k.Path == path && k.RequestHash == requestHash
+}
+
+// IsExpired checks if the key expired before the given time
+func (k *IdempotencyKey) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// InvoiceType distinguishes invoices from credit notes
+type InvoiceType string
+
+const (
+	InvoiceTypeInvoice    InvoiceType = "invoice"
+	InvoiceTypeCreditNote InvoiceType = "credit_note"
+)
+
+// Invoice is an invoice issued for a paid order, or a credit note issued for a refund.
+// Invoices and credit notes are numbered in separate gap-free series.
+type Invoice struct {
+	ID            uint        `json:"id"`
+	OrderID       uint        `json:"order_id"`
+	Type          InvoiceType `json:"type"`
+	Number        string      `json:"number"`
+	Sequence      int64       `json:"sequence"`
+	InvoiceID     uint        `json:"invoice_id,omitempty"`     // credited invoice of a credit note
+	InvoiceNumber string      `json:"invoice_number,omitempty"` // number of the credited invoice
+	Amount        int64       `json:"amount"`                   // in cents, including tax
+	TaxAmount     int64       `json:"tax_amount"`               // in cents
+	Currency      string      `json:"currency"`
+	ReverseCharge bool        `json:"reverse_charge"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// NewInvoice creates the invoice of an order. The number is assigned when it is saved.
+func NewInvoice(order *Order) (*Invoice, error) {
+	if order.ID == 0 {
+		return nil, errors.New("order ID cannot be empty")
+	}
+	if order.FinalAmount <= 0 {
+		return nil, errors.New("cannot invoice an order without an amount")
+	}
+
+	return &Invoice{
+		OrderID:       order.ID,
+		Type:          InvoiceTypeInvoice,
+		Amount:        order.FinalAmount,
+		TaxAmount:     order.TaxAmount,
+		Currency:      order.Currency,
+		ReverseCharge: order.ReverseCharge,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// NewCreditNote creates a credit note for part or all of an invoice.
+// The tax is credited in proportion to the amount.
+func NewCreditNote(invoice *Invoice, amount int64) (*Invoice, error) {
+	if invoice.Type != InvoiceTypeInvoice {
+		return nil, errors.New("only invoices can be credited")
+	}
+	if amount <= 0 {
+		return nil, errors.New("credit note amount must be greater than zero")
+	}
+	if amount > invoice.Amount {
+		return nil, errors.New("credit note amount cannot exceed the invoice amount")
+	}
+
+	taxAmount := int64(math.Round(float64(invoice.TaxAmount) * float64(amount) / float64(invoice.Amount)))
+
+	return &Invoice{
+		OrderID:       invoice.OrderID,
+		Type:          InvoiceTypeCreditNote,
+		InvoiceID:     invoice.ID,
+		InvoiceNumber: invoice.Number,
+		Amount:        amount,
+		TaxAmount:     taxAmount,
+		Currency:      invoice.Currency,
+		ReverseCharge: invoice.ReverseCharge,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// AssignNumber sets the position of the invoice in its series and formats its number
+func (i *Invoice) AssignNumber(prefix string, sequence int64) {
+	i.Sequence = sequence
+	i.Number = fmt.Sprintf("%s%06d", prefix, sequence)
+}
+
+// IsCreditNote checks if the invoice is a credit note
+func (i *Invoice) IsCreditNote() bool {
+	return i.Type == InvoiceTypeCreditNote
+}
+
+// Filename returns the file name of the invoice's document
+func (i *Invoice) Filename() string {
+	return i.Number + ".pdf"
+}
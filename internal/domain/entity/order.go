@@ -3,8 +3,11 @@ package entity
 import (
 	"errors"
 	"fmt"
+	"math"
 	"slices"
 	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/money"
 )
 
 // OrderStatus represents the status of an order
@@ -27,7 +30,9 @@ type Order struct {
 	OrderNumber     string
 	UserID          uint // 0 for guest orders
 	Items           []OrderItem
-	TotalAmount     int64 // stored in cents
+	TotalAmount     int64   // stored in cents
+	Currency        string  // currency the order was placed in
+	ExchangeRate    float64 // rate of Currency to the default currency at order time
 	Status          OrderStatus
 	ShippingAddr    Address
 	BillingAddr     Address
@@ -45,15 +50,33 @@ type Order struct {
 	IsGuestOrder    bool            `json:"is_guest_order"`
 
 	// Shipping information
-	ShippingMethodID uint            `json:"shipping_method_id,omitempty"`
-	ShippingMethod   *ShippingMethod `json:"shipping_method,omitempty"`
-	ShippingCost     int64           `json:"shipping_cost"` // stored in cents
-	TotalWeight      float64         `json:"total_weight"`
+	ShippingMethodID  uint              `json:"shipping_method_id,omitempty"`
+	ShippingMethod    *ShippingMethod   `json:"shipping_method,omitempty"`
+	ShippingCost      int64             `json:"shipping_cost"` // stored in cents
+	TotalWeight       float64           `json:"total_weight"`
+	Packages          []OrderPackage    `json:"packages,omitempty"` // Packages picked for the warehouse
+	ShippingLabel     *ShippingLabel    `json:"shipping_label,omitempty"`
+	PickupPoint       *PickupPoint      `json:"pickup_point,omitempty"`       // Set when delivered to a pickup point instead of the customer's address
+	EstimatedDelivery *DeliveryEstimate `json:"estimated_delivery,omitempty"` // Delivery window promised at checkout
 
 	// Discount-related fields
-	DiscountAmount  int64 // stored in cents
-	FinalAmount     int64 // stored in cents
-	AppliedDiscount *AppliedDiscount
+	DiscountAmount         int64 // stored in cents
+	ShippingDiscountAmount int64 // stored in cents, deducted from the shipping cost
+	FinalAmount            int64 // stored in cents
+	AppliedDiscount        *AppliedDiscount
+
+	// Tax-related fields
+	TaxAmount         int64   // stored in cents, tax on the items and the shipping
+	ShippingTaxRate   float64 // percentage applied to the shipping cost
+	ShippingTaxAmount int64   // stored in cents
+	PricesIncludeTax  bool    // whether prices and shipping costs already include tax
+	ReverseCharge     bool    // EU B2B sale where the customer accounts for the VAT
+
+	// Authorization-related fields
+	AuthorizedAmount       int64      // stored in cents, the amount the provider holds for capture
+	CapturedAmount         int64      // stored in cents, the sum of the captures so far
+	AuthorizationExpiresAt *time.Time // when the provider releases the uncaptured authorization
+	AuthorizationWarnedAt  *time.Time // when the expiry warning was raised
 }
 
 // OrderItem represents an item in an order
@@ -68,10 +91,15 @@ type OrderItem struct {
 
 	ProductName string `json:"product_name"`
 	SKU         string `json:"sku"`
+
+	TaxClassID uint    `json:"tax_class_id,omitempty"`
+	TaxRate    float64 `json:"tax_rate"`   // percentage applied to the line
+	TaxAmount  int64   `json:"tax_amount"` // stored in cents
 }
 
 // Address represents a shipping or billing address
 type Address struct {
+	Company    string `json:"company,omitempty"`
 	Street     string `json:"street"`
 	City       string `json:"city"`
 	State      string `json:"state"`
@@ -80,9 +108,11 @@ type Address struct {
 }
 
 type CustomerDetails struct {
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
-	FullName string `json:"full_name"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	FullName    string `json:"full_name"`
+	CompanyName string `json:"company_name,omitempty"`
+	VATID       string `json:"vat_id,omitempty"` // EU VAT number including the country prefix
 }
 
 // NewOrder creates a new order
@@ -123,6 +153,7 @@ func NewOrder(userID uint, items []OrderItem, shippingAddr, billingAddr Address,
 		ShippingCost:    0, // Default to 0, will be set later
 		DiscountAmount:  0,
 		FinalAmount:     totalAmount, // Initially same as total amount
+		ExchangeRate:    1,
 		Status:          OrderStatusPending,
 		ShippingAddr:    shippingAddr,
 		BillingAddr:     billingAddr,
@@ -167,6 +198,7 @@ func NewGuestOrder(items []OrderItem, shippingAddr, billingAddr Address, custome
 		ShippingCost:   0, // Default to 0, will be set later
 		DiscountAmount: 0,
 		FinalAmount:    totalAmount, // Initially same as total amount
+		ExchangeRate:   1,
 		Status:         OrderStatusPending,
 		ShippingAddr:   shippingAddr,
 		BillingAddr:    billingAddr,
@@ -263,8 +295,47 @@ func (o *Order) SetOrderNumber(id uint) {
 	o.OrderNumber = fmt.Sprintf("ORD-%s-%06d", o.CreatedAt.Format("20060102"), id)
 }
 
-// ApplyDiscount applies a discount to the order
-func (o *Order) ApplyDiscount(discount *Discount) error {
+// SetCurrency sets the order currency and its exchange rate to the default currency
+func (o *Order) SetCurrency(currencyCode string, exchangeRate float64) error {
+	if currencyCode == "" {
+		return errors.New("currency code cannot be empty")
+	}
+
+	if exchangeRate <= 0 {
+		return errors.New("exchange rate must be positive")
+	}
+
+	o.Currency = currencyCode
+	o.ExchangeRate = exchangeRate
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// ToBaseAmount converts an amount in the order currency to the default currency
+// using the exchange rate stored on the order. Amounts in the default currency
+// are in hundredths, like discounts and shipping rates.
+func (o *Order) ToBaseAmount(amount int64) int64 {
+	return money.New(amount, o.Currency).Convert("", 1/o.exchangeRate()).Amount
+}
+
+// FromBaseAmount converts an amount in the default currency to the order currency
+// using the exchange rate stored on the order
+func (o *Order) FromBaseAmount(amount int64) int64 {
+	return money.New(amount, "").Convert(o.Currency, o.exchangeRate()).Amount
+}
+
+// exchangeRate returns the exchange rate stored on the order, orders without one are in the default currency.
+// The minor units are rescaled even at a rate of 1, since currencies differ in their number of decimals.
+func (o *Order) exchangeRate() float64 {
+	if o.ExchangeRate <= 0 {
+		return 1
+	}
+	return o.ExchangeRate
+}
+
+// ApplyDiscount applies a discount to the order.
+// zoneIDs are the shipping zones the order's shipping address belongs to.
+func (o *Order) ApplyDiscount(discount *Discount, zoneIDs []uint) error {
 	if discount == nil {
 		return errors.New("discount cannot be nil")
 	}
@@ -274,6 +345,11 @@ func (o *Order) ApplyDiscount(discount *Discount) error {
 		return errors.New("discount is invalid or inactive")
 	}
 
+	// Shipping discounts reduce the shipping cost rather than the item amounts
+	if discount.Type == DiscountTypeShipping {
+		return o.ApplyShippingDiscount(discount, zoneIDs)
+	}
+
 	// Use the Discount entity's CalculateDiscount method to calculate the discount amount
 	discountAmount := discount.CalculateDiscount(o)
 	if discountAmount <= 0 {
@@ -282,7 +358,8 @@ func (o *Order) ApplyDiscount(discount *Discount) error {
 
 	// Apply the calculated discount
 	o.DiscountAmount = discountAmount
-	o.FinalAmount = o.TotalAmount + o.ShippingCost - discountAmount
+	o.ShippingDiscountAmount = 0
+	o.RecalculateTotals()
 
 	// Record the applied discount
 	o.AppliedDiscount = &AppliedDiscount{
@@ -295,11 +372,47 @@ func (o *Order) ApplyDiscount(discount *Discount) error {
 	return nil
 }
 
+// ApplyShippingDiscount applies a shipping discount to the order.
+// zoneIDs are the shipping zones the order's shipping address belongs to.
+func (o *Order) ApplyShippingDiscount(discount *Discount, zoneIDs []uint) error {
+	if discount == nil {
+		return errors.New("discount cannot be nil")
+	}
+
+	if discount.Type != DiscountTypeShipping {
+		return errors.New("discount is not a shipping discount")
+	}
+
+	if o.ShippingMethodID == 0 {
+		return errors.New("order has no shipping method")
+	}
+
+	shippingDiscount := discount.CalculateOrderShippingDiscount(o, zoneIDs)
+	if shippingDiscount <= 0 {
+		return errors.New("discount is not applicable to this order")
+	}
+
+	o.DiscountAmount = 0
+	o.ShippingDiscountAmount = shippingDiscount
+	o.RecalculateTotals()
+
+	// Record the applied discount
+	o.AppliedDiscount = &AppliedDiscount{
+		DiscountID:     discount.ID,
+		DiscountCode:   discount.Code,
+		DiscountAmount: shippingDiscount,
+	}
+
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
 // RemoveDiscount removes any applied discount from the order
 func (o *Order) RemoveDiscount() {
 	o.DiscountAmount = 0
-	o.FinalAmount = o.TotalAmount + o.ShippingCost
+	o.ShippingDiscountAmount = 0
 	o.AppliedDiscount = nil
+	o.RecalculateTotals()
 	o.UpdatedAt = time.Now()
 }
 
@@ -314,8 +427,11 @@ func (o *Order) SetActionURL(actionURL string) error {
 	return nil
 }
 
-// SetShippingMethod sets the shipping method for the order and updates shipping cost
-func (o *Order) SetShippingMethod(method *ShippingMethod, cost int64) error {
+// SetShippingMethod sets the shipping method for the order and updates shipping cost.
+// discount is the shipping discount applied to the order, if any, and zoneIDs are the shipping zones
+// the order's shipping address belongs to. When the method changes the discount is calculated again,
+// and dropped if it doesn't apply to the new method or isn't given.
+func (o *Order) SetShippingMethod(method *ShippingMethod, cost int64, discount *Discount, zoneIDs []uint) error {
 	if method == nil {
 		return errors.New("shipping method cannot be nil")
 	}
@@ -324,17 +440,167 @@ func (o *Order) SetShippingMethod(method *ShippingMethod, cost int64) error {
 		return errors.New("shipping cost cannot be negative")
 	}
 
+	methodChanged := o.ShippingMethodID != method.ID
 	o.ShippingMethodID = method.ID
 	o.ShippingMethod = method
 	o.ShippingCost = cost
 
-	// Update final amount with new shipping cost
-	o.FinalAmount = o.TotalAmount + o.ShippingCost - o.DiscountAmount
+	if o.ShippingDiscountAmount > 0 {
+		shippingDiscount := min(o.ShippingDiscountAmount, cost)
+		if methodChanged {
+			shippingDiscount = 0
+			if discount != nil && o.AppliedDiscount != nil && discount.ID == o.AppliedDiscount.DiscountID {
+				shippingDiscount = discount.CalculateOrderShippingDiscount(o, zoneIDs)
+			}
+		}
+
+		// A shipping discount can never exceed the new shipping cost
+		o.ShippingDiscountAmount = shippingDiscount
+		if shippingDiscount == 0 {
+			o.AppliedDiscount = nil
+		} else if o.AppliedDiscount != nil {
+			o.AppliedDiscount.DiscountAmount = shippingDiscount
+		}
+	}
+
+	// Update tax and final amount with new shipping cost
+	o.RecalculateTotals()
 
 	o.UpdatedAt = time.Now()
 	return nil
 }
 
+// TaxableAmounts returns the amounts tax is calculated on: each item's subtotal less its share
+// of the order discount, and the shipping cost less the shipping discount
+func (o *Order) TaxableAmounts() ([]int64, int64) {
+	amounts := make([]int64, len(o.Items))
+	remaining := o.DiscountAmount
+	for i, item := range o.Items {
+		share := int64(0)
+		if o.TotalAmount > 0 {
+			if i == len(o.Items)-1 {
+				share = remaining
+			} else {
+				share = int64(math.Round(float64(o.DiscountAmount) * float64(item.Subtotal) / float64(o.TotalAmount)))
+				share = min(share, remaining)
+			}
+		}
+		remaining -= share
+		amounts[i] = max(item.Subtotal-share, 0)
+	}
+
+	return amounts, max(o.ShippingCost-o.ShippingDiscountAmount, 0)
+}
+
+// SetTax sets the tax rates and amounts of the items and the shipping
+func (o *Order) SetTax(items []TaxLine, shipping TaxLine, pricesIncludeTax bool) error {
+	if len(items) != len(o.Items) {
+		return errors.New("tax lines do not match the order items")
+	}
+
+	var total int64
+	for i, line := range items {
+		if line.Rate < 0 || line.Amount < 0 {
+			return errors.New("tax cannot be negative")
+		}
+		o.Items[i].TaxRate = line.Rate
+		o.Items[i].TaxAmount = line.Amount
+		total += line.Amount
+	}
+	if shipping.Rate < 0 || shipping.Amount < 0 {
+		return errors.New("tax cannot be negative")
+	}
+
+	o.ShippingTaxRate = shipping.Rate
+	o.ShippingTaxAmount = shipping.Amount
+	o.TaxAmount = total + shipping.Amount
+	o.PricesIncludeTax = pricesIncludeTax
+	o.ReverseCharge = false
+	o.FinalAmount = o.finalAmount()
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// RemoveIncludedTax reduces the tax-inclusive prices, shipping cost and discounts of the order
+// to their net amounts at the given rates, so that none of the tax is charged
+func (o *Order) RemoveIncludedTax(items []TaxLine, shipping TaxLine) error {
+	if len(items) != len(o.Items) {
+		return errors.New("tax lines do not match the order items")
+	}
+
+	net := func(amount int64, rate float64) int64 {
+		return amount - CalculateTax(amount, rate, true)
+	}
+
+	amounts, shippingAmount := o.TaxableAmounts()
+
+	var total, discount int64
+	for i, line := range items {
+		subtotal := net(o.Items[i].Subtotal, line.Rate)
+		discount += subtotal - net(amounts[i], line.Rate)
+		o.Items[i].Price = net(o.Items[i].Price, line.Rate)
+		o.Items[i].Subtotal = subtotal
+		total += subtotal
+	}
+
+	shippingCost := net(o.ShippingCost, shipping.Rate)
+	o.ShippingDiscountAmount = shippingCost - net(shippingAmount, shipping.Rate)
+	o.ShippingCost = shippingCost
+	o.TotalAmount = total
+	o.DiscountAmount = discount
+	if o.AppliedDiscount != nil {
+		o.AppliedDiscount.DiscountAmount = o.DiscountAmount + o.ShippingDiscountAmount
+	}
+
+	o.PricesIncludeTax = false
+	o.FinalAmount = o.finalAmount()
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// ApplyReverseCharge zero-rates the items and shipping of an EU B2B order,
+// where the customer accounts for the VAT. Tax included in the prices must
+// be removed first with RemoveIncludedTax.
+func (o *Order) ApplyReverseCharge() {
+	for i := range o.Items {
+		o.Items[i].TaxRate = 0
+		o.Items[i].TaxAmount = 0
+	}
+
+	o.ShippingTaxRate = 0
+	o.ShippingTaxAmount = 0
+	o.TaxAmount = 0
+	o.PricesIncludeTax = false
+	o.ReverseCharge = true
+	o.FinalAmount = o.finalAmount()
+	o.UpdatedAt = time.Now()
+}
+
+// RecalculateTotals recalculates the tax at the order's stored rates and the final amount,
+// after discounts or the shipping cost changed
+func (o *Order) RecalculateTotals() {
+	amounts, shippingAmount := o.TaxableAmounts()
+
+	var total int64
+	for i := range o.Items {
+		o.Items[i].TaxAmount = CalculateTax(amounts[i], o.Items[i].TaxRate, o.PricesIncludeTax)
+		total += o.Items[i].TaxAmount
+	}
+	o.ShippingTaxAmount = CalculateTax(shippingAmount, o.ShippingTaxRate, o.PricesIncludeTax)
+	o.TaxAmount = total + o.ShippingTaxAmount
+
+	o.FinalAmount = o.finalAmount()
+}
+
+// finalAmount returns the amount to pay; tax is added unless the prices already include it
+func (o *Order) finalAmount() int64 {
+	final := o.TotalAmount + o.ShippingCost - o.DiscountAmount - o.ShippingDiscountAmount
+	if !o.PricesIncludeTax {
+		final += o.TaxAmount
+	}
+	return final
+}
+
 // CalculateTotalWeight calculates the total weight of all items in the order
 func (o *Order) CalculateTotalWeight() float64 {
 	totalWeight := 0.0
@@ -354,3 +620,69 @@ func (o *Order) IsCaptured() bool {
 func (o *Order) IsRefunded() bool {
 	return o.Status == OrderStatusRefunded
 }
+
+// Authorize records that the provider holds the final amount for capture until the given validity lapses
+func (o *Order) Authorize(validity time.Duration) {
+	now := time.Now()
+	expiresAt := now.Add(validity)
+
+	o.AuthorizedAmount = o.FinalAmount
+	o.CapturedAmount = 0
+	o.AuthorizationExpiresAt = &expiresAt
+	o.AuthorizationWarnedAt = nil
+	o.UpdatedAt = now
+}
+
+// RemainingCapturable returns the authorized amount that hasn't been captured yet
+func (o *Order) RemainingCapturable() int64 {
+	return max(o.AuthorizedAmount-o.CapturedAmount, 0)
+}
+
+// IsAuthorizationExpired returns true if the authorization lapsed before it was fully captured
+func (o *Order) IsAuthorizationExpired() bool {
+	return o.AuthorizationExpiresAt != nil && time.Now().After(*o.AuthorizationExpiresAt)
+}
+
+// RecordCapture adds a capture to the captured amount. A final capture releases
+// the rest of the authorization, so nothing more can be captured.
+func (o *Order) RecordCapture(amount int64, final bool) error {
+	if amount <= 0 {
+		return errors.New("capture amount must be greater than zero")
+	}
+
+	if amount > o.RemainingCapturable() {
+		return errors.New("capture amount exceeds the remaining authorized amount")
+	}
+
+	o.CapturedAmount += amount
+	if final {
+		o.AuthorizedAmount = o.CapturedAmount
+	}
+
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// SettleAuthorization marks the whole authorization captured, for payments captured outside the store
+func (o *Order) SettleAuthorization() {
+	if o.AuthorizedAmount == 0 {
+		o.AuthorizedAmount = o.FinalAmount
+	}
+	o.CapturedAmount = max(o.CapturedAmount, o.AuthorizedAmount)
+	o.AuthorizedAmount = o.CapturedAmount
+	o.UpdatedAt = time.Now()
+}
+
+// CapturedTotal returns the amount captured from the customer.
+// Orders paid before authorizations were tracked were captured in full.
+func (o *Order) CapturedTotal() int64 {
+	if o.AuthorizedAmount == 0 && o.CapturedAmount == 0 {
+		return o.FinalAmount
+	}
+	return o.CapturedAmount
+}
+
+// IsFullyCaptured returns true if the whole authorization has been captured or released
+func (o *Order) IsFullyCaptured() bool {
+	return o.AuthorizedAmount > 0 && o.RemainingCapturable() == 0
+}
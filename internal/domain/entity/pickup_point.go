@@ -0,0 +1,26 @@
+package entity
+
+import "errors"
+
+// PickupPoint is a parcel shop or parcel locker where customers collect their parcels
+type PickupPoint struct {
+	ID           string   `json:"id"` // Provider's ID, printed on labels
+	Name         string   `json:"name"`
+	Address      Address  `json:"address"`
+	Carrier      string   `json:"carrier,omitempty"`
+	Distance     int      `json:"distance,omitempty"` // Meters from the searched postal code
+	OpeningHours []string `json:"opening_hours,omitempty"`
+}
+
+// SetPickupPoint delivers the order to a pickup point. The pickup point's address
+// becomes the shipping address, the billing address stays the customer's.
+func (o *Order) SetPickupPoint(point *PickupPoint) error {
+	if point == nil || point.ID == "" {
+		return errors.New("pickup point ID is required")
+	}
+
+	o.PickupPoint = point
+	o.ShippingAddr = point.Address
+	o.ShippingAddr.Company = point.Name
+	return nil
+}
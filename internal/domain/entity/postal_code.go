@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// PostalCodePatternType is the kind of a shipping zone postal code pattern
+type PostalCodePatternType string
+
+const (
+	// PostalCodeExact matches one postal code, e.g. "8000"
+	PostalCodeExact PostalCodePatternType = "exact"
+	// PostalCodeRange matches codes starting with a value in a range, e.g. "3700-3799"
+	PostalCodeRange PostalCodePatternType = "range"
+	// PostalCodeWildcard matches codes where "*" stands for any characters
+	// and "?" for one character, e.g. "9*" or "900??"
+	PostalCodeWildcard PostalCodePatternType = "wildcard"
+)
+
+// PostalCodePattern is a parsed shipping zone postal code pattern.
+// A pattern can be limited to one country with a prefix like "DK-9*".
+type PostalCodePattern struct {
+	Pattern string // Pattern as configured
+	Country string // ISO country code of a "DK-" prefix, empty for any country
+	Type    PostalCodePatternType
+	Value   string // Normalized code or wildcard pattern
+	From    string // Lower bound of a range
+	To      string // Upper bound of a range
+}
+
+// NormalizePostalCode upper-cases a postal code and removes its spaces and its country prefix,
+// so "dk-9000" and "9000" are the same Danish code and "sw1a 1aa" becomes "SW1A1AA"
+func NormalizePostalCode(country, postalCode string) string {
+	code := strings.ToUpper(strings.ReplaceAll(postalCode, " ", ""))
+	country = strings.ToUpper(country)
+	if country != "" && strings.HasPrefix(code, country+"-") {
+		code = strings.TrimPrefix(code, country+"-")
+	}
+	return code
+}
+
+// ParsePostalCodePattern parses and validates a postal code pattern.
+// "A-B" with bounds of the same length is a range; bounds of different lengths are
+// an exact code with a dash, like the Portuguese "1000-001".
+func ParsePostalCodePattern(pattern string) (PostalCodePattern, error) {
+	p := PostalCodePattern{Pattern: pattern}
+
+	value := strings.ToUpper(strings.ReplaceAll(pattern, " ", ""))
+	if len(value) > 3 && value[2] == '-' && isLetters(value[:2]) {
+		p.Country = value[:2]
+		value = value[3:]
+	}
+
+	if value == "" {
+		return p, errors.New("postal code pattern cannot be empty")
+	}
+	for _, r := range value {
+		if !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '*' || r == '?') {
+			return p, fmt.Errorf("postal code pattern %q contains invalid character %q", pattern, r)
+		}
+	}
+
+	wildcard := strings.ContainsAny(value, "*?")
+	if strings.Count(value, "-") > 1 {
+		return p, fmt.Errorf("postal code pattern %q contains more than one range separator", pattern)
+	}
+
+	if from, to, isRange := strings.Cut(value, "-"); isRange && len(from) == len(to) {
+		if wildcard {
+			return p, fmt.Errorf("postal code range %q cannot contain wildcards", pattern)
+		}
+		if from > to {
+			return p, fmt.Errorf("postal code range %q starts after it ends", pattern)
+		}
+		p.Type = PostalCodeRange
+		p.From = from
+		p.To = to
+		return p, nil
+	}
+
+	p.Value = value
+	p.Type = PostalCodeExact
+	if wildcard {
+		p.Type = PostalCodeWildcard
+	}
+
+	return p, nil
+}
+
+// Matches checks if a postal code of a country matches the pattern
+func (p PostalCodePattern) Matches(country, postalCode string) bool {
+	country = strings.ToUpper(country)
+	if p.Country != "" && p.Country != country {
+		return false
+	}
+
+	code := NormalizePostalCode(country, postalCode)
+	if code == "" {
+		return false
+	}
+
+	switch p.Type {
+	case PostalCodeRange:
+		if len(code) < len(p.From) {
+			return false
+		}
+		prefix := code[:len(p.From)]
+		return prefix >= p.From && prefix <= p.To
+	case PostalCodeWildcard:
+		// Patterns only contain letters, digits, dashes and wildcards, so path.Match
+		// behaves like the LIKE matching used by the database
+		matched, _ := path.Match(p.Value, code)
+		return matched
+	default:
+		return code == p.Value
+	}
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
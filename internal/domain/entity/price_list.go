@@ -0,0 +1,203 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// CustomerGroup groups customers that share price lists, e.g. wholesale accounts
+type CustomerGroup struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewCustomerGroup creates a new customer group
+func NewCustomerGroup(name, description string) (*CustomerGroup, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("customer group name is required")
+	}
+
+	now := time.Now()
+	return &CustomerGroup{
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// PriceList is a named set of prices for one or more customer groups, e.g. "Wholesale DKK"
+type PriceList struct {
+	ID                 uint             `json:"id"`
+	Name               string           `json:"name"`
+	CurrencyCode       string           `json:"currency_code"`
+	DiscountPercentage float64          `json:"discount_percentage"` // off the base price of items without an entry
+	CustomerGroupIDs   []uint           `json:"customer_group_ids"`
+	Entries            []PriceListEntry `json:"entries"`
+	StartDate          *time.Time       `json:"start_date,omitempty"`
+	EndDate            *time.Time       `json:"end_date,omitempty"`
+	Active             bool             `json:"active"`
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+}
+
+// PriceListEntry is the price of a product or variant from a minimum quantity
+type PriceListEntry struct {
+	ID          uint  `json:"id"`
+	PriceListID uint  `json:"price_list_id"`
+	ProductID   uint  `json:"product_id"`
+	VariantID   uint  `json:"variant_id,omitempty"` // 0 applies to the product and all its variants
+	MinQuantity int   `json:"min_quantity"`         // quantity break, 1 for the regular price
+	Price       int64 `json:"price"`                // stored in cents
+}
+
+// NewPriceList creates a new price list
+func NewPriceList(name, currencyCode string, discountPercentage float64, startDate, endDate *time.Time) (*PriceList, error) {
+	now := time.Now()
+	priceList := &PriceList{
+		Name:               name,
+		CurrencyCode:       strings.ToUpper(currencyCode),
+		DiscountPercentage: discountPercentage,
+		CustomerGroupIDs:   []uint{},
+		Entries:            []PriceListEntry{},
+		StartDate:          startDate,
+		EndDate:            endDate,
+		Active:             true,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	if err := priceList.Validate(); err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+// Validate checks the price list settings
+func (p *PriceList) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("price list name is required")
+	}
+
+	if len(p.CurrencyCode) != 3 {
+		return errors.New("price list currency code must be 3 characters")
+	}
+
+	if p.DiscountPercentage < 0 || p.DiscountPercentage > 100 {
+		return errors.New("discount percentage must be between 0 and 100")
+	}
+
+	if p.StartDate != nil && p.EndDate != nil && p.EndDate.Before(*p.StartDate) {
+		return errors.New("end date cannot be before start date")
+	}
+
+	return nil
+}
+
+// SetEntries replaces the entries of the price list
+func (p *PriceList) SetEntries(entries []PriceListEntry) error {
+	seen := make(map[[3]uint]bool)
+	for i := range entries {
+		entry := &entries[i]
+		if entry.ProductID == 0 {
+			return errors.New("price list entry requires a product")
+		}
+		if entry.Price <= 0 {
+			return errors.New("price list entry price must be greater than zero")
+		}
+		if entry.MinQuantity <= 0 {
+			entry.MinQuantity = 1
+		}
+
+		key := [3]uint{entry.ProductID, entry.VariantID, uint(entry.MinQuantity)}
+		if seen[key] {
+			return errors.New("duplicate price list entry for the same product, variant and quantity")
+		}
+		seen[key] = true
+
+		entry.PriceListID = p.ID
+	}
+
+	p.Entries = entries
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetCustomerGroups replaces the customer groups the price list is assigned to
+func (p *PriceList) SetCustomerGroups(groupIDs []uint) {
+	p.CustomerGroupIDs = groupIDs
+	p.UpdatedAt = time.Now()
+}
+
+// IsValidAt checks if the price list is active at the given time
+func (p *PriceList) IsValidAt(at time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.StartDate != nil && at.Before(*p.StartDate) {
+		return false
+	}
+	if p.EndDate != nil && at.After(*p.EndDate) {
+		return false
+	}
+	return true
+}
+
+// AppliesTo checks if the price list prices the given customer group in the given currency
+func (p *PriceList) AppliesTo(groupID uint, currencyCode string, at time.Time) bool {
+	if !strings.EqualFold(p.CurrencyCode, currencyCode) || !p.IsValidAt(at) {
+		return false
+	}
+
+	for _, id := range p.CustomerGroupIDs {
+		if id == groupID {
+			return true
+		}
+	}
+	return false
+}
+
+// PriceFor returns the price list price of a product or variant for the given quantity.
+// Variant entries take precedence over product entries, and the highest quantity break
+// not exceeding the quantity wins. Items without an entry get the list's percentage off
+// the base price. The second return value is false if the list does not price the item.
+func (p *PriceList) PriceFor(productID, variantID uint, quantity int, basePrice int64) (int64, bool) {
+	var variantEntry, productEntry *PriceListEntry
+	for i := range p.Entries {
+		entry := &p.Entries[i]
+		if entry.ProductID != productID || entry.MinQuantity > quantity {
+			continue
+		}
+
+		switch {
+		case variantID != 0 && entry.VariantID == variantID:
+			if variantEntry == nil || entry.MinQuantity > variantEntry.MinQuantity {
+				variantEntry = entry
+			}
+		case entry.VariantID == 0:
+			if productEntry == nil || entry.MinQuantity > productEntry.MinQuantity {
+				productEntry = entry
+			}
+		}
+	}
+
+	if variantEntry != nil {
+		return variantEntry.Price, true
+	}
+	if productEntry != nil {
+		return productEntry.Price, true
+	}
+
+	if p.DiscountPercentage > 0 {
+		discount := int64(math.Round(float64(basePrice) * p.DiscountPercentage / 100))
+		return basePrice - discount, true
+	}
+
+	return basePrice, false
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// PriceSchedule holds the compare-at price and an optional scheduled sale of a price.
+// It is embedded in products, variants and their currency-specific prices.
+type PriceSchedule struct {
+	CompareAtPrice int64      `json:"compare_at_price,omitempty"` // original price shown struck through, in cents
+	SalePrice      int64      `json:"sale_price,omitempty"`       // in cents, 0 means no sale
+	SaleStartsAt   *time.Time `json:"sale_starts_at,omitempty"`   // nil starts the sale immediately
+	SaleEndsAt     *time.Time `json:"sale_ends_at,omitempty"`     // nil runs the sale until removed
+}
+
+// ValidateSale checks the schedule against the regular price it applies to
+func (s PriceSchedule) ValidateSale(price int64) error {
+	if s.CompareAtPrice < 0 {
+		return errors.New("compare-at price cannot be negative")
+	}
+	if s.SalePrice < 0 {
+		return errors.New("sale price cannot be negative")
+	}
+	if s.SalePrice > 0 && s.SalePrice >= price {
+		return errors.New("sale price must be lower than the regular price")
+	}
+	if s.SalePrice == 0 && (s.SaleStartsAt != nil || s.SaleEndsAt != nil) {
+		return errors.New("sale window requires a sale price")
+	}
+	if s.SaleStartsAt != nil && s.SaleEndsAt != nil && !s.SaleEndsAt.After(*s.SaleStartsAt) {
+		return errors.New("sale end must be after sale start")
+	}
+	return nil
+}
+
+// IsOnSale checks if the sale price applies at the given time
+func (s PriceSchedule) IsOnSale(at time.Time) bool {
+	if s.SalePrice <= 0 {
+		return false
+	}
+	if s.SaleStartsAt != nil && at.Before(*s.SaleStartsAt) {
+		return false
+	}
+	if s.SaleEndsAt != nil && !at.Before(*s.SaleEndsAt) {
+		return false
+	}
+	return true
+}
+
+// Resolve returns the effective price and the compare-at price of a regular price at the given time.
+// While on sale the regular price is the compare-at price unless a higher one is set.
+// The compare-at price is 0 when it would not be above the effective price.
+func (s PriceSchedule) Resolve(price int64, at time.Time) (effective, compareAt int64) {
+	effective = price
+	if s.IsOnSale(at) && s.SalePrice < price {
+		effective = s.SalePrice
+	}
+
+	compareAt = s.CompareAtPrice
+	if effective < price && compareAt < price {
+		compareAt = price
+	}
+	if compareAt <= effective {
+		compareAt = 0
+	}
+
+	return effective, compareAt
+}
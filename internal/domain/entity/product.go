@@ -8,22 +8,26 @@ import (
 
 // Product represents a product in the system
 type Product struct {
-	ID            uint              `json:"id"`
-	ProductNumber string            `json:"product_number"`
-	Name          string            `json:"name"`
-	Description   string            `json:"description"`
-	Price         int64             `json:"price"` // Stored as cents (in default currency)
-	CurrencyCode  string            `json:"currency_code,omitempty"`
-	Stock         int               `json:"stock"`
-	Weight        float64           `json:"weight"` // Weight in kg
-	CategoryID    uint              `json:"category_id"`
-	Images        []string          `json:"images"`
-	HasVariants   bool              `json:"has_variants"`
-	Variants      []*ProductVariant `json:"variants,omitempty"`
-	Prices        []ProductPrice    `json:"prices,omitempty"` // Prices in different currencies
-	CreatedAt     time.Time         `json:"created_at"`
-	UpdatedAt     time.Time         `json:"updated_at"`
-	Active        bool              `json:"active"`
+	ID                uint              `json:"id"`
+	ProductNumber     string            `json:"product_number"`
+	Name              string            `json:"name"`
+	Description       string            `json:"description"`
+	Price             int64             `json:"price"` // Stored as cents (in default currency)
+	CurrencyCode      string            `json:"currency_code,omitempty"`
+	Stock             int               `json:"stock"`
+	Weight            float64           `json:"weight"` // Weight in kg
+	Dimensions        Dimensions        `json:"dimensions"`
+	CategoryID        uint              `json:"category_id"`
+	TaxClassID        uint              `json:"tax_class_id"`        // 0 for the standard tax class
+	ShippingProfileID uint              `json:"shipping_profile_id"` // 0 for the category's shipping profile
+	Images            []string          `json:"images"`
+	HasVariants       bool              `json:"has_variants"`
+	Variants          []*ProductVariant `json:"variants,omitempty"`
+	Prices            []ProductPrice    `json:"prices,omitempty"` // Prices in different currencies
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	Active            bool              `json:"active"`
+	PriceSchedule
 }
 
 // NewProduct creates a new product with the given details (price in cents)
@@ -162,6 +166,15 @@ func (p *Product) SetProductNumber(id uint) {
 	p.ProductNumber = fmt.Sprintf("PROD-%06d", id)
 }
 
+// ShippingDimensions returns the dimensions of the product or of one of its variants.
+// Variants without dimensions of their own have the product's dimensions.
+func (p *Product) ShippingDimensions(variant *ProductVariant) Dimensions {
+	if variant != nil && !variant.Dimensions.IsZero() {
+		return variant.Dimensions
+	}
+	return p.Dimensions
+}
+
 // GetTotalWeight calculates the total weight for a quantity of this product
 func (p *Product) GetTotalWeight(quantity int) float64 {
 	if quantity <= 0 {
@@ -170,30 +183,46 @@ func (p *Product) GetTotalWeight(quantity int) float64 {
 	return p.Weight * float64(quantity)
 }
 
-// GetPriceInCurrency returns the price for a specific currency
+// EffectivePrice returns the price in the default currency at the given time, taking a scheduled sale into account
+func (p *Product) EffectivePrice(at time.Time) int64 {
+	price, _ := p.Resolve(p.Price, at)
+	return price
+}
+
+// GetPriceInCurrency returns the current effective price for a specific currency
 func (p *Product) GetPriceInCurrency(currencyCode string) (int64, bool) {
+	price, _, found := p.PriceInCurrencyAt(currencyCode, time.Now())
+	return price, found
+}
+
+// PriceInCurrencyAt returns the effective and compare-at price for a specific currency at the given time.
+// If the currency has no price of its own, the default currency prices are returned with found false.
+func (p *Product) PriceInCurrencyAt(currencyCode string, at time.Time) (price, compareAt int64, found bool) {
 	variant := p.GetDefaultVariant()
 	if variant != nil {
-		return variant.GetPriceInCurrency(currencyCode)
+		return variant.PriceInCurrencyAt(currencyCode, at)
 	}
 
 	for _, productPrice := range p.Prices {
 		if productPrice.CurrencyCode == currencyCode {
-			return productPrice.Price, true
+			price, compareAt = productPrice.Resolve(productPrice.Price, at)
+			return price, compareAt, true
 		}
 	}
 
-	return p.Price, false
+	price, compareAt = p.Resolve(p.Price, at)
+	return price, compareAt, false
 }
 
 // Category represents a product category
 type Category struct {
-	ID          uint      `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	ParentID    *uint     `json:"parent_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                uint      `json:"id"`
+	Name              string    `json:"name"`
+	Description       string    `json:"description"`
+	ParentID          *uint     `json:"parent_id"`
+	ShippingProfileID uint      `json:"shipping_profile_id"` // 0 for no shipping profile
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // NewCategory creates a new category
@@ -22,9 +22,11 @@ type ProductVariant struct {
 	Attributes   []VariantAttribute    `json:"attributes"`
 	Images       []string              `json:"images"`
 	IsDefault    bool                  `json:"is_default"`
+	Dimensions   Dimensions            `json:"dimensions"` // Empty to use the product's dimensions
 	CreatedAt    time.Time             `json:"created_at"`
 	UpdatedAt    time.Time             `json:"updated_at"`
 	Prices       []ProductVariantPrice `json:"prices,omitempty"` // Prices in different currencies
+	PriceSchedule
 }
 
 // NewProductVariant creates a new product variant
@@ -77,13 +79,28 @@ func (v *ProductVariant) IsAvailable(quantity int) bool {
 	return v.Stock >= quantity
 }
 
-// GetPriceInCurrency returns the price in the specified currency
+// EffectivePrice returns the price in the default currency at the given time, taking a scheduled sale into account
+func (v *ProductVariant) EffectivePrice(at time.Time) int64 {
+	price, _ := v.Resolve(v.Price, at)
+	return price
+}
+
+// GetPriceInCurrency returns the current effective price in the specified currency
 func (v *ProductVariant) GetPriceInCurrency(currencyCode string) (int64, bool) {
-	for _, price := range v.Prices {
-		if price.CurrencyCode == currencyCode {
-			return price.Price, true
+	price, _, found := v.PriceInCurrencyAt(currencyCode, time.Now())
+	return price, found
+}
+
+// PriceInCurrencyAt returns the effective and compare-at price in the specified currency at the given time.
+// If the currency has no price of its own, the default currency prices are returned with found false.
+func (v *ProductVariant) PriceInCurrencyAt(currencyCode string, at time.Time) (price, compareAt int64, found bool) {
+	for _, variantPrice := range v.Prices {
+		if variantPrice.CurrencyCode == currencyCode {
+			price, compareAt = variantPrice.Resolve(variantPrice.Price, at)
+			return price, compareAt, true
 		}
 	}
 
-	return v.Price, false
+	price, compareAt = v.Resolve(v.Price, at)
+	return price, compareAt, false
 }
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// SavedPaymentMethod references a card a user saved with a payment provider.
+// Only the provider's reference and the details needed to recognise the card are stored.
+type SavedPaymentMethod struct {
+	ID               uint      `json:"id"`
+	UserID           uint      `json:"user_id"`
+	Provider         string    `json:"provider"`
+	ProviderMethodID string    `json:"-"` // e.g. Stripe's pm_... ID
+	Brand            string    `json:"brand"`
+	Last4            string    `json:"last4"`
+	ExpMonth         int       `json:"exp_month"`
+	ExpYear          int       `json:"exp_year"`
+	IsDefault        bool      `json:"is_default"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// NewSavedPaymentMethod creates a new saved payment method
+func NewSavedPaymentMethod(userID uint, provider, providerMethodID, brand, last4 string, expMonth, expYear int) (*SavedPaymentMethod, error) {
+	if userID == 0 {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if provider == "" {
+		return nil, errors.New("provider cannot be empty")
+	}
+	if providerMethodID == "" {
+		return nil, errors.New("provider method ID cannot be empty")
+	}
+
+	now := time.Now()
+	method := &SavedPaymentMethod{
+		UserID:           userID,
+		Provider:         provider,
+		ProviderMethodID: providerMethodID,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := method.UpdateCard(brand, last4, expMonth, expYear); err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
+
+// UpdateCard updates the card details, e.g. when the issuer sends a new expiry date
+func (m *SavedPaymentMethod) UpdateCard(brand, last4 string, expMonth, expYear int) error {
+	if len(last4) != 4 {
+		return errors.New("last4 must be 4 digits")
+	}
+	if expMonth < 1 || expMonth > 12 {
+		return errors.New("invalid expiry month")
+	}
+	if expYear < 2000 {
+		return errors.New("invalid expiry year")
+	}
+
+	m.Brand = brand
+	m.Last4 = last4
+	m.ExpMonth = expMonth
+	m.ExpYear = expYear
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsExpired checks if the card expired before the given time
+func (m *SavedPaymentMethod) IsExpired(now time.Time) bool {
+	year, month, _ := now.Date()
+	return m.ExpYear < year || (m.ExpYear == year && m.ExpMonth < int(month))
+}
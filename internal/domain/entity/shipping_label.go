@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ShippingLabel is a carrier label bought for an order
+type ShippingLabel struct {
+	Carrier        string    `json:"carrier"`                // Carrier service that created the label, e.g. "shippo"
+	CarrierCode    string    `json:"carrier_code,omitempty"` // Carrier behind an aggregator, e.g. "usps", needed for tracking
+	ServiceCode    string    `json:"service_code"`
+	TrackingNumber string    `json:"tracking_number"`
+	TrackingURL    string    `json:"tracking_url,omitempty"`
+	LabelURL       string    `json:"label_url"`
+	Cost           int64     `json:"cost"` // stored in cents
+	Currency       string    `json:"currency"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// SetShippingLabel stores the label bought for the order and uses its tracking number as the tracking code
+func (o *Order) SetShippingLabel(label *ShippingLabel) error {
+	if label == nil || label.TrackingNumber == "" {
+		return errors.New("shipping label must have a tracking number")
+	}
+
+	o.ShippingLabel = label
+	return o.SetTrackingCode(label.TrackingNumber)
+}
@@ -7,13 +7,19 @@ import (
 
 // ShippingMethod represents a shipping method option (e.g., standard, express)
 type ShippingMethod struct {
-	ID                    uint      `json:"id"`
-	Name                  string    `json:"name"`
-	Description           string    `json:"description"`
-	EstimatedDeliveryDays int       `json:"estimated_delivery_days"`
-	Active                bool      `json:"active"`
-	CreatedAt             time.Time `json:"created_at"`
-	UpdatedAt             time.Time `json:"updated_at"`
+	ID                    uint                 `json:"id"`
+	Name                  string               `json:"name"`
+	Description           string               `json:"description"`
+	EstimatedDeliveryDays int                  `json:"estimated_delivery_days"`
+	Carrier               string               `json:"carrier,omitempty"`              // Carrier quoting live rates and creating labels, empty for table rates only
+	CarrierServiceCode    string               `json:"carrier_service_code,omitempty"` // Carrier's service, e.g. "usps_priority"
+	PickupPoint           bool                 `json:"pickup_point"`                   // Delivers to a pickup point the customer chooses
+	CutoffTime            string               `json:"cutoff_time,omitempty"`          // Orders after this time of day, e.g. "14:00", are dispatched the next business day
+	HandlingDays          int                  `json:"handling_days"`                  // Business days from the order until the parcel is dispatched
+	Restrictions          ShippingRestrictions `json:"restrictions"`                   // Carts the method is offered for
+	Active                bool                 `json:"active"`
+	CreatedAt             time.Time            `json:"created_at"`
+	UpdatedAt             time.Time            `json:"updated_at"`
 }
 
 // NewShippingMethod creates a new shipping method
@@ -54,6 +60,84 @@ func (s *ShippingMethod) Update(name string, description string, estimatedDelive
 	return nil
 }
 
+// SetCarrier backs the shipping method by a carrier service, or by table rates only if carrier is empty
+func (s *ShippingMethod) SetCarrier(carrier, serviceCode string) error {
+	if carrier != "" && serviceCode == "" {
+		return errors.New("carrier service code is required")
+	}
+	if carrier == "" {
+		serviceCode = ""
+	}
+
+	s.Carrier = carrier
+	s.CarrierServiceCode = serviceCode
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetDispatch sets the daily order cut-off time, "HH:MM" in the store's time zone or empty for none,
+// and the business days orders take to be dispatched
+func (s *ShippingMethod) SetDispatch(cutoffTime string, handlingDays int) error {
+	if cutoffTime != "" {
+		if _, err := time.Parse("15:04", cutoffTime); err != nil {
+			return errors.New("cut-off time must be formatted as HH:MM")
+		}
+	}
+	if handlingDays < 0 {
+		return errors.New("handling days must be a non-negative number")
+	}
+
+	s.CutoffTime = cutoffTime
+	s.HandlingDays = handlingDays
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// DeliveryEstimate is the window of dates a parcel is expected to be delivered in
+type DeliveryEstimate struct {
+	Earliest time.Time `json:"earliest"` // Midnight UTC of the date
+	Latest   time.Time `json:"latest"`
+}
+
+// EstimateDelivery estimates the delivery of an order placed at orderedAt, in the store's time zone.
+// The order is dispatched on the origin's business days, today if it is placed before the cut-off time,
+// and is then in transit for the rate's transit days on the destination's business days.
+// Without transit days on the rate, the method's estimated delivery days are used.
+func (s *ShippingMethod) EstimateDelivery(orderedAt time.Time, rate *ShippingRate, origin, destination *BusinessCalendar) DeliveryEstimate {
+	dispatch := CalendarDate(orderedAt)
+	if !origin.IsBusinessDay(dispatch) || s.isPastCutoff(orderedAt) {
+		dispatch = origin.AddBusinessDays(dispatch, 1)
+	}
+	dispatch = origin.AddBusinessDays(dispatch, s.HandlingDays)
+
+	minDays, maxDays := s.EstimatedDeliveryDays, s.EstimatedDeliveryDays
+	if rate != nil && rate.TransitDaysMax > 0 {
+		minDays, maxDays = rate.TransitDaysMin, rate.TransitDaysMax
+	}
+
+	return DeliveryEstimate{
+		Earliest: destination.AddBusinessDays(dispatch, minDays),
+		Latest:   destination.AddBusinessDays(dispatch, maxDays),
+	}
+}
+
+// isPastCutoff checks if an order placed at orderedAt missed the day's cut-off time
+func (s *ShippingMethod) isPastCutoff(orderedAt time.Time) bool {
+	if s.CutoffTime == "" {
+		return false
+	}
+	cutoff, err := time.Parse("15:04", s.CutoffTime)
+	if err != nil {
+		return false
+	}
+	return orderedAt.Hour()*60+orderedAt.Minute() >= cutoff.Hour()*60+cutoff.Minute()
+}
+
+// IsCarrierBacked checks if the shipping method's rates are quoted and its labels created by a carrier
+func (s *ShippingMethod) IsCarrierBacked() bool {
+	return s.Carrier != ""
+}
+
 // Activate activates a shipping method
 func (s *ShippingMethod) Activate() {
 	if !s.Active {
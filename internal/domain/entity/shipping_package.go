@@ -0,0 +1,300 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// DefaultDimensionalWeightDivisor is the common courier divisor for dimensional weights in cm³ per kg
+const DefaultDimensionalWeightDivisor = 5000
+
+// Dimensions are the length, width and height of an item or the inner size of a box, in cm
+type Dimensions struct {
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// IsZero checks if no dimensions are set
+func (d Dimensions) IsZero() bool {
+	return d.Length == 0 && d.Width == 0 && d.Height == 0
+}
+
+// Validate checks that the dimensions are either all set or all empty
+func (d Dimensions) Validate() error {
+	if d.Length < 0 || d.Width < 0 || d.Height < 0 {
+		return errors.New("dimensions cannot be negative")
+	}
+	if !d.IsZero() && (d.Length == 0 || d.Width == 0 || d.Height == 0) {
+		return errors.New("length, width and height must all be set")
+	}
+	return nil
+}
+
+// Volume returns the volume in cm³
+func (d Dimensions) Volume() float64 {
+	return d.Length * d.Width * d.Height
+}
+
+// FitsIn checks if an item of these dimensions fits in a box, in any orientation
+func (d Dimensions) FitsIn(box Dimensions) bool {
+	item, space := d.sorted(), box.sorted()
+	return item[0] <= space[0] && item[1] <= space[1] && item[2] <= space[2]
+}
+
+// DimensionalWeight returns the volumetric weight in kg couriers bill for a package of these dimensions
+func (d Dimensions) DimensionalWeight(divisor float64) float64 {
+	if divisor <= 0 {
+		return 0
+	}
+	return roundWeight(d.Volume() / divisor)
+}
+
+func (d Dimensions) sorted() []float64 {
+	sides := []float64{d.Length, d.Width, d.Height}
+	slices.Sort(sides)
+	return sides
+}
+
+// ShippingPackage is a box the warehouse packs orders in
+type ShippingPackage struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Dimensions Dimensions `json:"dimensions"` // Inner dimensions
+	Weight     float64    `json:"weight"`     // Weight of the empty box in kg
+	MaxWeight  float64    `json:"max_weight"` // Maximum content weight in kg, 0 for no limit
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NewShippingPackage creates a new shipping package
+func NewShippingPackage(name string, dimensions Dimensions, weight, maxWeight float64) (*ShippingPackage, error) {
+	now := time.Now()
+	pkg := &ShippingPackage{
+		Name:       name,
+		Dimensions: dimensions,
+		Weight:     weight,
+		MaxWeight:  maxWeight,
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := pkg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// Validate checks the package's details
+func (p *ShippingPackage) Validate() error {
+	if p.Name == "" {
+		return errors.New("package name cannot be empty")
+	}
+	if err := p.Dimensions.Validate(); err != nil {
+		return err
+	}
+	if p.Dimensions.IsZero() {
+		return errors.New("package dimensions are required")
+	}
+	if p.Weight < 0 || p.MaxWeight < 0 {
+		return errors.New("package weights cannot be negative")
+	}
+	return nil
+}
+
+// holds checks if the package can take an item alone
+func (p *ShippingPackage) holds(item packingUnit) bool {
+	return item.dimensions.FitsIn(p.Dimensions) && (p.MaxWeight == 0 || item.weight <= p.MaxWeight)
+}
+
+// PackingItem is an order line to pack
+type PackingItem struct {
+	ProductID         uint
+	VariantID         uint
+	SKU               string
+	Name              string
+	Quantity          int
+	Weight            float64    // Weight of one unit in kg
+	Dimensions        Dimensions // Dimensions of one unit, empty if unknown
+	CategoryID        uint
+	ShippingProfileID uint // 0 for the category's shipping profile
+}
+
+// OrderPackage is a package chosen for an order, with the items the warehouse puts in it
+type OrderPackage struct {
+	PackageID         uint               `json:"package_id,omitempty"` // 0 when an item ships in its own packaging
+	Name              string             `json:"name"`
+	Dimensions        Dimensions         `json:"dimensions"`
+	Weight            float64            `json:"weight"`             // Contents and box in kg
+	DimensionalWeight float64            `json:"dimensional_weight"` // Volumetric weight in kg
+	Items             []OrderPackageItem `json:"items"`
+}
+
+// OrderPackageItem is a quantity of an order line in a package
+type OrderPackageItem struct {
+	ProductID uint   `json:"product_id"`
+	VariantID uint   `json:"variant_id,omitempty"`
+	SKU       string `json:"sku,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Quantity  int    `json:"quantity"`
+}
+
+// BillableWeight returns the greater of the package's actual and dimensional weight
+func (p OrderPackage) BillableWeight() float64 {
+	return math.Max(p.Weight, p.DimensionalWeight)
+}
+
+// BillableWeight returns the billable weight of all packages of an order
+func BillableWeight(packages []OrderPackage) float64 {
+	var total float64
+	for _, pkg := range packages {
+		total += pkg.BillableWeight()
+	}
+	return roundWeight(total)
+}
+
+// packingUnit is one unit of a packing item
+type packingUnit struct {
+	item       int
+	weight     float64
+	dimensions Dimensions
+}
+
+// openPackage is a package being filled
+type openPackage struct {
+	box    *ShippingPackage
+	volume float64
+	weight float64
+	units  []packingUnit
+}
+
+// PackItems picks packages for items with a first-fit decreasing bin packing:
+// the largest units go first, each into the first open package with room for it,
+// otherwise into the smallest box that holds it. Units that fit no box ship in their own packaging.
+// Room is judged by volume and weight, so packages are an estimate rather than a packing plan.
+func PackItems(items []PackingItem, boxes []*ShippingPackage, divisor float64) []OrderPackage {
+	units := make([]packingUnit, 0)
+	for i, item := range items {
+		for range item.Quantity {
+			units = append(units, packingUnit{item: i, weight: item.Weight, dimensions: item.Dimensions})
+		}
+	}
+
+	sort.SliceStable(units, func(i, j int) bool {
+		vi, vj := units[i].dimensions.Volume(), units[j].dimensions.Volume()
+		if vi != vj {
+			return vi > vj
+		}
+		return units[i].weight > units[j].weight
+	})
+
+	available := make([]*ShippingPackage, 0, len(boxes))
+	for _, box := range boxes {
+		if box.Active {
+			available = append(available, box)
+		}
+	}
+	sort.SliceStable(available, func(i, j int) bool {
+		return available[i].Dimensions.Volume() < available[j].Dimensions.Volume()
+	})
+
+	var open []*openPackage
+	var own []packingUnit
+
+	for _, unit := range units {
+		placed := false
+		for _, pkg := range open {
+			if pkg.fits(unit) {
+				pkg.add(unit)
+				placed = true
+				break
+			}
+		}
+		if placed {
+			continue
+		}
+
+		idx := slices.IndexFunc(available, func(box *ShippingPackage) bool { return box.holds(unit) })
+		if idx < 0 {
+			own = append(own, unit)
+			continue
+		}
+
+		pkg := &openPackage{box: available[idx]}
+		pkg.add(unit)
+		open = append(open, pkg)
+	}
+
+	packages := make([]OrderPackage, 0, len(open)+len(own))
+	for _, pkg := range open {
+		packages = append(packages, OrderPackage{
+			PackageID:         pkg.box.ID,
+			Name:              pkg.box.Name,
+			Dimensions:        pkg.box.Dimensions,
+			Weight:            roundWeight(pkg.weight + pkg.box.Weight),
+			DimensionalWeight: pkg.box.Dimensions.DimensionalWeight(divisor),
+			Items:             packageItems(items, pkg.units),
+		})
+	}
+	for _, unit := range own {
+		packages = append(packages, OrderPackage{
+			Name:              "Own packaging",
+			Dimensions:        unit.dimensions,
+			Weight:            roundWeight(unit.weight),
+			DimensionalWeight: unit.dimensions.DimensionalWeight(divisor),
+			Items:             packageItems(items, []packingUnit{unit}),
+		})
+	}
+
+	return packages
+}
+
+func (p *openPackage) fits(unit packingUnit) bool {
+	if !unit.dimensions.FitsIn(p.box.Dimensions) {
+		return false
+	}
+	if p.volume+unit.dimensions.Volume() > p.box.Dimensions.Volume() {
+		return false
+	}
+	return p.box.MaxWeight == 0 || p.weight+unit.weight <= p.box.MaxWeight
+}
+
+func (p *openPackage) add(unit packingUnit) {
+	p.volume += unit.dimensions.Volume()
+	p.weight += unit.weight
+	p.units = append(p.units, unit)
+}
+
+// packageItems groups the units of a package by order line, in order line order
+func packageItems(items []PackingItem, units []packingUnit) []OrderPackageItem {
+	quantities := make(map[int]int)
+	for _, unit := range units {
+		quantities[unit.item]++
+	}
+
+	packed := make([]OrderPackageItem, 0, len(quantities))
+	for i, item := range items {
+		if quantities[i] == 0 {
+			continue
+		}
+		packed = append(packed, OrderPackageItem{
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			SKU:       item.SKU,
+			Name:      item.Name,
+			Quantity:  quantities[i],
+		})
+	}
+
+	return packed
+}
+
+func roundWeight(weight float64) float64 {
+	return math.Round(weight*1000) / 1000
+}
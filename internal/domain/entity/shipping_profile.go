@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// ShippingProfile groups products that ship under the same rules, e.g. hazardous goods or oversize items.
+// Products without a profile of their own have the profile of their category.
+type ShippingProfile struct {
+	ID                uint      `json:"id"`
+	Name              string    `json:"name"`
+	Description       string    `json:"description"`
+	ShippingMethodIDs []uint    `json:"shipping_method_ids"` // Only these methods ship items of the profile, any method if empty
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// NewShippingProfile creates a new shipping profile
+func NewShippingProfile(name, description string) (*ShippingProfile, error) {
+	if name == "" {
+		return nil, errors.New("shipping profile name cannot be empty")
+	}
+
+	now := time.Now()
+	return &ShippingProfile{
+		Name:              name,
+		Description:       description,
+		ShippingMethodIDs: []uint{},
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}, nil
+}
+
+// Update updates a shipping profile's details
+func (p *ShippingProfile) Update(name, description string) error {
+	if name == "" {
+		return errors.New("shipping profile name cannot be empty")
+	}
+
+	p.Name = name
+	p.Description = description
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// AllowsMethod checks if items of the profile may be shipped with a shipping method
+func (p *ShippingProfile) AllowsMethod(methodID uint) bool {
+	return len(p.ShippingMethodIDs) == 0 || slices.Contains(p.ShippingMethodIDs, methodID)
+}
+
+// ShippingRestrictions limit the carts a shipping method is offered for
+type ShippingRestrictions struct {
+	AllowedProfileIDs  []uint  `json:"allowed_profile_ids,omitempty"`  // Every item must have one of these profiles, any items if empty
+	ExcludedProfileIDs []uint  `json:"excluded_profile_ids,omitempty"` // No item may have one of these profiles
+	WeightLimit        float64 `json:"weight_limit,omitempty"`         // Carts must weigh less than this in kg, no limit if 0
+}
+
+// Validate checks the restrictions are consistent
+func (r ShippingRestrictions) Validate() error {
+	if r.WeightLimit < 0 {
+		return errors.New("weight limit cannot be negative")
+	}
+	for _, id := range r.AllowedProfileIDs {
+		if slices.Contains(r.ExcludedProfileIDs, id) {
+			return errors.New("a shipping profile cannot be both allowed and excluded")
+		}
+	}
+	return nil
+}
+
+// ProfileIDs returns the shipping profiles the restrictions refer to
+func (r ShippingRestrictions) ProfileIDs() []uint {
+	return append(slices.Clone(r.AllowedProfileIDs), r.ExcludedProfileIDs...)
+}
+
+// Allows checks if a cart may be shipped, given the shipping profile of each item (0 for none) and its billable weight
+func (r ShippingRestrictions) Allows(itemProfileIDs []uint, weight float64) bool {
+	if r.WeightLimit > 0 && weight >= r.WeightLimit {
+		return false
+	}
+	for _, profileID := range itemProfileIDs {
+		if slices.Contains(r.ExcludedProfileIDs, profileID) {
+			return false
+		}
+		if len(r.AllowedProfileIDs) > 0 && !slices.Contains(r.AllowedProfileIDs, profileID) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithoutProfile returns the restrictions without a deleted shipping profile
+func (r ShippingRestrictions) WithoutProfile(profileID uint) ShippingRestrictions {
+	remove := func(id uint) bool { return id == profileID }
+	r.AllowedProfileIDs = slices.DeleteFunc(slices.Clone(r.AllowedProfileIDs), remove)
+	r.ExcludedProfileIDs = slices.DeleteFunc(slices.Clone(r.ExcludedProfileIDs), remove)
+	return r
+}
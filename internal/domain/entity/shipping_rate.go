@@ -17,6 +17,8 @@ type ShippingRate struct {
 	FreeShippingThreshold *int64            `json:"free_shipping_threshold"`
 	WeightBasedRates      []WeightBasedRate `json:"weight_based_rates,omitempty"`
 	ValueBasedRates       []ValueBasedRate  `json:"value_based_rates,omitempty"`
+	TransitDaysMin        int               `json:"transit_days_min"` // Business days in transit to the zone, 0 for both to use the method's estimate
+	TransitDaysMax        int               `json:"transit_days_max"`
 	Active                bool              `json:"active"`
 	CreatedAt             time.Time         `json:"created_at"`
 	UpdatedAt             time.Time         `json:"updated_at"`
@@ -46,13 +48,17 @@ type ValueBasedRate struct {
 
 // ShippingOption represents a single shipping option with its cost
 type ShippingOption struct {
-	ShippingRateID        uint   `json:"shipping_rate_id"`
-	ShippingMethodID      uint   `json:"shipping_method_id"`
-	Name                  string `json:"name"`
-	Description           string `json:"description"`
-	EstimatedDeliveryDays int    `json:"estimated_delivery_days"`
-	Cost                  int64  `json:"cost"`
-	FreeShipping          bool   `json:"free_shipping"`
+	ShippingRateID    uint             `json:"shipping_rate_id"`
+	ShippingMethodID  uint             `json:"shipping_method_id"`
+	Name              string           `json:"name"`
+	Description       string           `json:"description"`
+	EstimatedDelivery DeliveryEstimate `json:"estimated_delivery"`
+	Carrier           string           `json:"carrier,omitempty"` // Set when the cost is a live carrier quote
+	PickupPoint       bool             `json:"pickup_point"`      // Customer must choose a pickup point
+	Cost              int64            `json:"cost"`
+	DiscountAmount    int64            `json:"discount_amount"`
+	FreeShipping      bool             `json:"free_shipping"`
+	TaxAmount         int64            `json:"tax_amount"` // tax on the cost less the discount
 }
 
 // NewShippingRate creates a new shipping rate
@@ -117,6 +123,22 @@ func (r *ShippingRate) SetFreeShippingThreshold(threshold *int64) {
 	r.UpdatedAt = time.Now()
 }
 
+// SetTransitDays sets the window of business days parcels are in transit to the rate's zone,
+// or clears it with zero for both to use the shipping method's estimated delivery days
+func (r *ShippingRate) SetTransitDays(minDays, maxDays int) error {
+	if minDays < 0 || maxDays < 0 {
+		return errors.New("transit days must be non-negative numbers")
+	}
+	if maxDays < minDays {
+		return errors.New("maximum transit days cannot be less than minimum transit days")
+	}
+
+	r.TransitDaysMin = minDays
+	r.TransitDaysMax = maxDays
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
 // CalculateShippingCost calculates the shipping cost for an order
 func (r *ShippingRate) CalculateShippingCost(orderValue int64, weight float64) int64 {
 	// Check if order qualifies for free shipping
@@ -2,6 +2,9 @@ package entity
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -61,7 +64,7 @@ func (z *ShippingZone) SetStates(states []string) {
 	z.UpdatedAt = time.Now()
 }
 
-// SetZipCodes sets the zip/postal codes for this shipping zone
+// SetZipCodes sets the zip/postal codes or patterns for this shipping zone, see PostalCodePattern
 func (z *ShippingZone) SetZipCodes(zipCodes []string) {
 	z.ZipCodes = zipCodes
 	z.UpdatedAt = time.Now()
@@ -83,55 +86,82 @@ func (z *ShippingZone) Deactivate() {
 	}
 }
 
-// IsAddressInZone checks if an address is within this zone
-func (z *ShippingZone) IsAddressInZone(address Address) bool {
-	// If no countries are specified, all countries match
-	if len(z.Countries) == 0 {
-		return true
-	}
-
-	// Check country match
-	countryMatch := false
+// Validate checks the zone's countries and postal code patterns
+func (z *ShippingZone) Validate() error {
 	for _, country := range z.Countries {
-		if country == address.Country {
-			countryMatch = true
-			break
+		if len(country) != 2 || strings.ToUpper(country) != country {
+			return fmt.Errorf("country %q must be a two-letter ISO code", country)
 		}
 	}
 
-	if !countryMatch {
-		return false
+	for _, zipCode := range z.ZipCodes {
+		pattern, err := ParsePostalCodePattern(zipCode)
+		if err != nil {
+			return err
+		}
+		if pattern.Country != "" && len(z.Countries) > 0 && !slices.Contains(z.Countries, pattern.Country) {
+			return fmt.Errorf("postal code pattern %q is for a country outside the zone", zipCode)
+		}
 	}
 
-	// If we matched country and no states are specified, it's a match
-	if len(z.States) == 0 {
-		return true
-	}
+	return nil
+}
 
-	// Check state match
-	stateMatch := false
-	for _, state := range z.States {
-		if state == address.State {
-			stateMatch = true
-			break
-		}
+// ShippingZoneMatch explains whether an address is in a shipping zone and why
+type ShippingZoneMatch struct {
+	Matched           bool     `json:"matched"`
+	PostalCodePattern string   `json:"postal_code_pattern,omitempty"` // Pattern the postal code matched
+	Reasons           []string `json:"reasons"`
+}
+
+// IsAddressInZone checks if an address is within this zone
+func (z *ShippingZone) IsAddressInZone(address Address) bool {
+	return z.MatchAddress(address).Matched
+}
+
+// MatchAddress checks if an address is within this zone and explains the outcome.
+// Countries, states and postal codes each narrow the zone; an empty list matches everything.
+func (z *ShippingZone) MatchAddress(address Address) ShippingZoneMatch {
+	match := ShippingZoneMatch{Reasons: []string{}}
+	country := strings.ToUpper(address.Country)
+
+	if len(z.Countries) == 0 {
+		match.Reasons = append(match.Reasons, "zone covers all countries")
+	} else if slices.Contains(z.Countries, country) {
+		match.Reasons = append(match.Reasons, fmt.Sprintf("country %s is in the zone", country))
+	} else {
+		match.Reasons = append(match.Reasons, fmt.Sprintf("country %q is not one of %s", country, strings.Join(z.Countries, ", ")))
+		return match
 	}
 
-	if !stateMatch {
-		return false
+	if len(z.States) == 0 {
+		match.Reasons = append(match.Reasons, "zone covers all states")
+	} else if slices.Contains(z.States, address.State) {
+		match.Reasons = append(match.Reasons, fmt.Sprintf("state %s is in the zone", address.State))
+	} else {
+		match.Reasons = append(match.Reasons, fmt.Sprintf("state %q is not one of %s", address.State, strings.Join(z.States, ", ")))
+		return match
 	}
 
-	// If we matched country and state, and no zip codes are specified, it's a match
 	if len(z.ZipCodes) == 0 {
-		return true
+		match.Reasons = append(match.Reasons, "zone covers all postal codes")
+		match.Matched = true
+		return match
 	}
 
-	// Check zip code match (exact match only - could be extended for patterns/ranges)
 	for _, zipCode := range z.ZipCodes {
-		if zipCode == address.PostalCode {
-			return true
+		pattern, err := ParsePostalCodePattern(zipCode)
+		if err != nil {
+			continue
+		}
+		if pattern.Matches(country, address.PostalCode) {
+			match.Reasons = append(match.Reasons, fmt.Sprintf("postal code %s matches %s pattern %q", NormalizePostalCode(country, address.PostalCode), pattern.Type, zipCode))
+			match.PostalCodePattern = zipCode
+			match.Matched = true
+			return match
 		}
 	}
 
-	return false
+	match.Reasons = append(match.Reasons, fmt.Sprintf("postal code %q matches none of %s", address.PostalCode, strings.Join(z.ZipCodes, ", ")))
+	return match
 }
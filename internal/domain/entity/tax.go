@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// StandardTaxClassID is the tax class of products without a tax class.
+// Rates for it apply to those products and to shipping.
+const StandardTaxClassID uint = 0
+
+// TaxClass groups products taxed at the same rates, e.g. "Reduced" for food or books
+type TaxClass struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewTaxClass creates a new tax class
+func NewTaxClass(name, description string) (*TaxClass, error) {
+	if name == "" {
+		return nil, errors.New("tax class name cannot be empty")
+	}
+
+	now := time.Now()
+	return &TaxClass{
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// TaxRate is the rate of a tax class in a country, optionally narrowed to states or postal codes
+type TaxRate struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	TaxClassID  uint      `json:"tax_class_id"` // 0 for the standard rate
+	Country     string    `json:"country"`      // ISO country code like "DK", "DE"
+	States      []string  `json:"states"`       // State/region codes, empty for the whole country
+	PostalCodes []string  `json:"postal_codes"` // Postal codes, empty for the whole country or states
+	Rate        float64   `json:"rate"`         // Percentage, e.g. 25 for 25%
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewTaxRate creates a new tax rate
+func NewTaxRate(name string, taxClassID uint, country string, rate float64) (*TaxRate, error) {
+	now := time.Now()
+	taxRate := &TaxRate{
+		Name:        name,
+		TaxClassID:  taxClassID,
+		Country:     strings.ToUpper(country),
+		States:      []string{},
+		PostalCodes: []string{},
+		Rate:        rate,
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := taxRate.Validate(); err != nil {
+		return nil, err
+	}
+
+	return taxRate, nil
+}
+
+// Validate checks the tax rate's details
+func (r *TaxRate) Validate() error {
+	if r.Name == "" {
+		return errors.New("tax rate name cannot be empty")
+	}
+	if len(r.Country) != 2 || strings.ToUpper(r.Country) != r.Country {
+		return errors.New("country must be a two-letter ISO code")
+	}
+	if r.Rate < 0 || r.Rate > 100 {
+		return errors.New("tax rate must be between 0 and 100")
+	}
+	return nil
+}
+
+// SetStates sets the states/regions the rate is limited to
+func (r *TaxRate) SetStates(states []string) {
+	r.States = states
+	r.UpdatedAt = time.Now()
+}
+
+// SetPostalCodes sets the postal codes the rate is limited to
+func (r *TaxRate) SetPostalCodes(postalCodes []string) {
+	r.PostalCodes = postalCodes
+	r.UpdatedAt = time.Now()
+}
+
+// AppliesTo checks if the rate applies to an address.
+// States and postal codes narrow the country like they do for shipping zones.
+func (r *TaxRate) AppliesTo(address Address) bool {
+	if !r.Active || r.Country != strings.ToUpper(address.Country) {
+		return false
+	}
+
+	if len(r.States) > 0 && !slices.Contains(r.States, address.State) {
+		return false
+	}
+
+	if len(r.PostalCodes) > 0 && !slices.Contains(r.PostalCodes, address.PostalCode) {
+		return false
+	}
+
+	return true
+}
+
+// Specificity ranks matching rates: postal code rates beat state rates, which beat country rates
+func (r *TaxRate) Specificity() int {
+	switch {
+	case len(r.PostalCodes) > 0:
+		return 2
+	case len(r.States) > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CalculateTax returns the tax on an amount at a percentage rate.
+// For tax-inclusive amounts the tax is the part of the amount that is tax.
+func CalculateTax(amount int64, rate float64, inclusive bool) int64 {
+	if amount <= 0 || rate <= 0 {
+		return 0
+	}
+
+	if inclusive {
+		net := math.Round(float64(amount) / (1 + rate/100))
+		return amount - int64(net)
+	}
+
+	return int64(math.Round(float64(amount) * rate / 100))
+}
+
+// TaxLine is the rate and tax amount of an order line or the shipping
+type TaxLine struct {
+	Rate   float64 `json:"rate"`
+	Amount int64   `json:"amount"`
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// TrackingStatus is the status of a shipment reported by a carrier, normalised across carriers
+type TrackingStatus string
+
+const (
+	TrackingStatusPreTransit     TrackingStatus = "pre_transit" // Label created, parcel not yet handed over
+	TrackingStatusInTransit      TrackingStatus = "in_transit"
+	TrackingStatusOutForDelivery TrackingStatus = "out_for_delivery"
+	TrackingStatusDelivered      TrackingStatus = "delivered"
+	TrackingStatusException      TrackingStatus = "exception" // Delayed, failed delivery or returned to sender
+)
+
+// TrackingEvent is a scan or status change of an order's shipment
+type TrackingEvent struct {
+	ID             uint           `json:"id"`
+	OrderID        uint           `json:"order_id"`
+	TrackingNumber string         `json:"tracking_number"`
+	Status         TrackingStatus `json:"status"`
+	Description    string         `json:"description"`
+	Location       string         `json:"location,omitempty"`
+	OccurredAt     time.Time      `json:"occurred_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// NewTrackingEvent creates a new tracking event
+func NewTrackingEvent(orderID uint, trackingNumber string, status TrackingStatus, description, location string, occurredAt time.Time) (*TrackingEvent, error) {
+	if orderID == 0 {
+		return nil, errors.New("order ID cannot be empty")
+	}
+	if trackingNumber == "" {
+		return nil, errors.New("tracking number cannot be empty")
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid tracking status")
+	}
+	if occurredAt.IsZero() {
+		return nil, errors.New("tracking event time cannot be empty")
+	}
+
+	return &TrackingEvent{
+		OrderID:        orderID,
+		TrackingNumber: trackingNumber,
+		Status:         status,
+		Description:    description,
+		Location:       location,
+		OccurredAt:     occurredAt,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// IsValid checks if the tracking status is known
+func (s TrackingStatus) IsValid() bool {
+	switch s {
+	case TrackingStatusPreTransit, TrackingStatusInTransit, TrackingStatusOutForDelivery,
+		TrackingStatusDelivered, TrackingStatusException:
+		return true
+	}
+	return false
+}
+
+// IsSameAs checks if two events describe the same scan, as carriers report every event again on each update
+func (e *TrackingEvent) IsSameAs(other *TrackingEvent) bool {
+	return e.TrackingNumber == other.TrackingNumber &&
+		e.Status == other.Status &&
+		e.OccurredAt.Equal(other.OccurredAt)
+}
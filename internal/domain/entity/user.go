@@ -9,14 +9,15 @@ import (
 
 // User represents a user in the system
 type User struct {
-	ID        uint      `json:"id"`
-	Email     string    `json:"email"`
-	Password  string    `json:"-"` // Never expose password in JSON
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint      `json:"id"`
+	Email           string    `json:"email"`
+	Password        string    `json:"-"` // Never expose password in JSON
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	Role            string    `json:"role"`
+	CustomerGroupID *uint     `json:"customer_group_id,omitempty"` // group with its own price lists
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 // UserRole defines the available roles for users
@@ -84,3 +85,9 @@ func (u *User) FullName() string {
 func (u *User) IsAdmin() bool {
 	return u.Role == string(RoleAdmin)
 }
+
+// SetCustomerGroup assigns the user to a customer group, or removes the assignment when groupID is nil
+func (u *User) SetCustomerGroup(groupID *uint) {
+	u.CustomerGroupID = groupID
+	u.UpdatedAt = time.Now()
+}
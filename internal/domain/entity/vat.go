@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+)
+
+// ReverseChargeNote is printed on orders and invoices charged under the EU reverse charge
+const ReverseChargeNote = "Reverse charge: VAT to be accounted for by the recipient (Article 196, Council Directive 2006/112/EC)"
+
+// euCountries are the ISO codes of the EU member states
+var euCountries = []string{
+	"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "GR", "HR", "HU",
+	"IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK",
+}
+
+// IsEUCountry checks if an ISO country code is an EU member state
+func IsEUCountry(country string) bool {
+	return slices.Contains(euCountries, strings.ToUpper(country))
+}
+
+// NormalizeVATID uppercases a VAT number and strips spaces, dots and dashes
+func NormalizeVATID(vatID string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(vatID)))
+}
+
+// VATIDCountry returns the ISO country code of a normalised VAT number's prefix.
+// Greek VAT numbers use the prefix EL.
+func VATIDCountry(vatID string) string {
+	if len(vatID) < 2 {
+		return ""
+	}
+
+	prefix := vatID[:2]
+	if prefix == "EL" {
+		return "GR"
+	}
+	return prefix
+}
@@ -0,0 +1,74 @@
+package money
+
+import "strings"
+
+// DefaultExponent is the number of minor-unit digits of currencies not listed below
+const DefaultExponent = 2
+
+// currencyInfo describes the minor units and cash rounding of a currency
+type currencyInfo struct {
+	exponent      int
+	cashIncrement int64 // smallest cash amount in minor units, 0 when coins match the minor unit
+	symbol        string
+}
+
+// currencies lists the ISO 4217 currencies whose conventions differ from the defaults
+// or that have a well-known symbol
+var currencies = map[string]currencyInfo{
+	"USD": {exponent: 2, symbol: "$"},
+	"EUR": {exponent: 2, symbol: "€"},
+	"GBP": {exponent: 2, symbol: "£"},
+	"DKK": {exponent: 2, cashIncrement: 50, symbol: "kr."},
+	"NOK": {exponent: 2, cashIncrement: 100, symbol: "kr"},
+	"SEK": {exponent: 2, cashIncrement: 100, symbol: "kr"},
+	"CHF": {exponent: 2, cashIncrement: 5, symbol: "CHF"},
+	"CAD": {exponent: 2, cashIncrement: 5, symbol: "$"},
+	"AUD": {exponent: 2, cashIncrement: 5, symbol: "$"},
+	"NZD": {exponent: 2, cashIncrement: 10, symbol: "$"},
+	"JPY": {exponent: 0, symbol: "¥"},
+	"KRW": {exponent: 0, symbol: "₩"},
+	"ISK": {exponent: 0, symbol: "kr"},
+	"CLP": {exponent: 0, symbol: "$"},
+	"VND": {exponent: 0, symbol: "₫"},
+	"BHD": {exponent: 3, symbol: "BD"},
+	"JOD": {exponent: 3, symbol: "JD"},
+	"KWD": {exponent: 3, symbol: "KD"},
+	"OMR": {exponent: 3, symbol: "OMR"},
+	"TND": {exponent: 3, symbol: "DT"},
+}
+
+// Exponent returns the number of minor-unit digits of a currency, e.g. 2 for EUR and 0 for JPY
+func Exponent(currency string) int {
+	if info, ok := currencies[strings.ToUpper(currency)]; ok {
+		return info.exponent
+	}
+	return DefaultExponent
+}
+
+// NonDefaultExponents returns the currencies whose number of minor-unit digits differs from DefaultExponent
+func NonDefaultExponents() map[string]int {
+	exponents := make(map[string]int)
+	for code, info := range currencies {
+		if info.exponent != DefaultExponent {
+			exponents[code] = info.exponent
+		}
+	}
+	return exponents
+}
+
+// CashIncrement returns the smallest cash amount of a currency in minor units, e.g. 50 for DKK
+func CashIncrement(currency string) int64 {
+	if info, ok := currencies[strings.ToUpper(currency)]; ok && info.cashIncrement > 0 {
+		return info.cashIncrement
+	}
+	return 1
+}
+
+// Symbol returns the symbol of a currency, falling back to its code
+func Symbol(currency string) string {
+	currency = strings.ToUpper(currency)
+	if info, ok := currencies[currency]; ok && info.symbol != "" {
+		return info.symbol
+	}
+	return currency
+}
@@ -0,0 +1,143 @@
+package money
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used when no supported locale is requested
+const DefaultLocale = "en-US"
+
+// Locale describes how amounts are written in a locale
+type Locale struct {
+	DecimalSeparator string
+	GroupSeparator   string
+	SymbolFirst      bool // symbol before the amount
+	SymbolSpace      bool // space between the symbol and the amount
+}
+
+// locales lists the supported locales
+var locales = map[string]Locale{
+	"en-US": {DecimalSeparator: ".", GroupSeparator: ",", SymbolFirst: true},
+	"en-GB": {DecimalSeparator: ".", GroupSeparator: ",", SymbolFirst: true},
+	"da-DK": {DecimalSeparator: ",", GroupSeparator: ".", SymbolSpace: true},
+	"de-DE": {DecimalSeparator: ",", GroupSeparator: ".", SymbolSpace: true},
+	"nl-NL": {DecimalSeparator: ",", GroupSeparator: ".", SymbolFirst: true, SymbolSpace: true},
+	"nb-NO": {DecimalSeparator: ",", GroupSeparator: " ", SymbolFirst: true, SymbolSpace: true},
+	"sv-SE": {DecimalSeparator: ",", GroupSeparator: " ", SymbolSpace: true},
+	"fr-FR": {DecimalSeparator: ",", GroupSeparator: " ", SymbolSpace: true},
+}
+
+// ResolveLocale returns the supported locale matching a language tag such as "da", "da-DK" or "en_GB".
+// Unsupported tags resolve to the default locale.
+func ResolveLocale(tag string) string {
+	tag = strings.TrimSpace(strings.ReplaceAll(tag, "_", "-"))
+	if tag == "" {
+		return DefaultLocale
+	}
+
+	language, region, _ := strings.Cut(tag, "-")
+	language = strings.ToLower(language)
+	if region != "" {
+		candidate := language + "-" + strings.ToUpper(region)
+		if _, ok := locales[candidate]; ok {
+			return candidate
+		}
+	}
+
+	// Fall back to the first supported locale of the language
+	for _, candidate := range []string{"en-US", "en-GB", "da-DK", "de-DE", "nl-NL", "nb-NO", "sv-SE", "fr-FR"} {
+		if strings.HasPrefix(candidate, language+"-") {
+			return candidate
+		}
+	}
+
+	// Norwegian is commonly requested as "no"
+	if language == "no" {
+		return "nb-NO"
+	}
+
+	return DefaultLocale
+}
+
+// LocaleFromAcceptLanguage returns the first supported locale of an Accept-Language header
+func LocaleFromAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		if locale := ResolveLocale(tag); locale != DefaultLocale || strings.HasPrefix(strings.ToLower(tag), "en") {
+			return locale
+		}
+	}
+
+	return DefaultLocale
+}
+
+// FormatWithSymbol formats an amount using the conventions of a locale and the given currency symbol
+func FormatWithSymbol(m Money, locale, symbol string) string {
+	format := locales[ResolveLocale(locale)]
+
+	number := formatNumber(m, format.DecimalSeparator, format.GroupSeparator)
+
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign = "-"
+		number = number[1:]
+	}
+
+	space := ""
+	if format.SymbolSpace {
+		space = " "
+	}
+
+	if format.SymbolFirst {
+		return sign + symbol + space + number
+	}
+	return sign + number + space + symbol
+}
+
+// formatNumber writes the amount with the given separators and the currency's number of decimals
+func formatNumber(m Money, decimalSeparator, groupSeparator string) string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if m.Exponent > 0 {
+		// Pad so there is at least one digit before the decimal separator
+		for len(digits) <= m.Exponent {
+			digits = "0" + digits
+		}
+	}
+
+	integer := digits[:len(digits)-m.Exponent]
+	fraction := digits[len(digits)-m.Exponent:]
+
+	// Group the integer digits in thousands
+	if groupSeparator != "" && len(integer) > 3 {
+		var grouped strings.Builder
+		lead := len(integer) % 3
+		if lead > 0 {
+			grouped.WriteString(integer[:lead])
+		}
+		for i := lead; i < len(integer); i += 3 {
+			if grouped.Len() > 0 {
+				grouped.WriteString(groupSeparator)
+			}
+			grouped.WriteString(integer[i : i+3])
+		}
+		integer = grouped.String()
+	}
+
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + decimalSeparator + fraction
+}
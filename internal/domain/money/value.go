@@ -0,0 +1,98 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// Money is an amount in the minor units of a currency, e.g. cents for EUR or yen for JPY
+type Money struct {
+	Amount   int64  `json:"amount"`   // in minor units
+	Currency string `json:"currency"` // ISO 4217 code
+	Exponent int    `json:"exponent"` // number of minor-unit digits
+}
+
+// New creates a Money from an amount in minor units
+func New(amount int64, currency string) Money {
+	currency = strings.ToUpper(currency)
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+		Exponent: Exponent(currency),
+	}
+}
+
+// FromDecimal creates a Money from a decimal amount, rounding half away from zero
+// to the minor units of the currency
+func FromDecimal(value float64, currency string) Money {
+	m := New(0, currency)
+	m.Amount = int64(math.Round(value * m.factor()))
+	return m
+}
+
+// Decimal returns the amount in major units, e.g. 12.5 for 1250 cents
+func (m Money) Decimal() float64 {
+	return float64(m.Amount) / m.factor()
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Add returns the sum of two amounts in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, errors.New("cannot add amounts in different currencies")
+	}
+	m.Amount += other.Amount
+	return m, nil
+}
+
+// Sub returns the difference of two amounts in the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, errors.New("cannot subtract amounts in different currencies")
+	}
+	m.Amount -= other.Amount
+	return m, nil
+}
+
+// Multiply multiplies the amount by a factor, rounding to the minor units of the currency
+func (m Money) Multiply(factor float64) Money {
+	m.Amount = int64(math.Round(float64(m.Amount) * factor))
+	return m
+}
+
+// Convert converts the amount to another currency at the given rate
+// (units of the target currency per unit of this currency)
+func (m Money) Convert(currency string, rate float64) Money {
+	return FromDecimal(m.Decimal()*rate, currency)
+}
+
+// CashRounded rounds the amount to the smallest coin of the currency,
+// e.g. 0.50 for DKK and 0.05 for CHF. Halves are rounded away from zero.
+func (m Money) CashRounded() Money {
+	increment := CashIncrement(m.Currency)
+	if increment <= 1 {
+		return m
+	}
+	m.Amount = int64(math.Round(float64(m.Amount)/float64(increment))) * increment
+	return m
+}
+
+// Format formats the amount using the conventions of the given locale, e.g. "1.234,50 kr." for da-DK
+func (m Money) Format(locale string) string {
+	return FormatWithSymbol(m, locale, Symbol(m.Currency))
+}
+
+// String formats the amount with its currency code, e.g. "1234.50 EUR"
+func (m Money) String() string {
+	return formatNumber(m, ".", "") + " " + m.Currency
+}
+
+// factor returns 10 to the power of the exponent
+func (m Money) factor() float64 {
+	return math.Pow10(m.Exponent)
+}
@@ -0,0 +1,81 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+)
+
+func TestMoney_MinorUnits(t *testing.T) {
+	t.Run("Two decimals", func(t *testing.T) {
+		m := money.FromDecimal(12.345, "eur")
+		assert.Equal(t, int64(1235), m.Amount)
+		assert.Equal(t, "EUR", m.Currency)
+		assert.Equal(t, 12.35, m.Decimal())
+	})
+
+	t.Run("Zero decimals", func(t *testing.T) {
+		m := money.FromDecimal(1234.5, "JPY")
+		assert.Equal(t, int64(1235), m.Amount)
+		assert.Equal(t, 1235.0, m.Decimal())
+	})
+
+	t.Run("Three decimals", func(t *testing.T) {
+		m := money.FromDecimal(1.2345, "KWD")
+		assert.Equal(t, int64(1235), m.Amount)
+		assert.Equal(t, 1.235, m.Decimal())
+	})
+
+	t.Run("Convert between exponents", func(t *testing.T) {
+		// 10.00 USD at 150 JPY per USD
+		assert.Equal(t, int64(1500), money.New(1000, "USD").Convert("JPY", 150).Amount)
+		// 1500 JPY at 0.002 KWD per JPY
+		assert.Equal(t, int64(3000), money.New(1500, "JPY").Convert("KWD", 0.002).Amount)
+	})
+
+	t.Run("Currencies with other exponents", func(t *testing.T) {
+		exponents := money.NonDefaultExponents()
+		assert.Equal(t, 0, exponents["JPY"])
+		assert.Equal(t, 3, exponents["KWD"])
+		assert.NotContains(t, exponents, "EUR")
+	})
+}
+
+func TestMoney_CashRounded(t *testing.T) {
+	assert.Equal(t, int64(12350), money.New(12326, "DKK").CashRounded().Amount)
+	assert.Equal(t, int64(12300), money.New(12324, "SEK").CashRounded().Amount)
+	assert.Equal(t, int64(1005), money.New(1003, "CHF").CashRounded().Amount)
+	assert.Equal(t, int64(1003), money.New(1003, "EUR").CashRounded().Amount)
+}
+
+func TestMoney_Format(t *testing.T) {
+	tests := []struct {
+		name     string
+		money    money.Money
+		locale   string
+		expected string
+	}{
+		{"US dollars", money.New(123450, "USD"), "en-US", "$1,234.50"},
+		{"Danish kroner", money.New(123450, "DKK"), "da-DK", "1.234,50 kr."},
+		{"Euro in Germany", money.New(123450, "EUR"), "de", "1.234,50 €"},
+		{"Yen", money.New(1234567, "JPY"), "en-US", "¥1,234,567"},
+		{"Dinar", money.New(1234, "KWD"), "en-US", "KD1.234"},
+		{"Negative", money.New(-5, "USD"), "en-US", "-$0.05"},
+		{"Unknown currency", money.New(100, "XYZ"), "sv-SE", "1,00 XYZ"},
+		{"Unsupported locale", money.New(100, "USD"), "xx-XX", "$1.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.money.Format(tt.locale))
+		})
+	}
+}
+
+func TestLocaleFromAcceptLanguage(t *testing.T) {
+	assert.Equal(t, "da-DK", money.LocaleFromAcceptLanguage("da, en-GB;q=0.8"))
+	assert.Equal(t, "en-GB", money.LocaleFromAcceptLanguage("xx, en-GB;q=0.8"))
+	assert.Equal(t, "nb-NO", money.LocaleFromAcceptLanguage("no"))
+	assert.Equal(t, money.DefaultLocale, money.LocaleFromAcceptLanguage(""))
+}
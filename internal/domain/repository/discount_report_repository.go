@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// DiscountReportRepository defines the interface for discount performance data access.
+// All methods consider orders created in [startDate, endDate).
+type DiscountReportRepository interface {
+	// GetOrderStats aggregates the orders that used one of the discounts
+	GetOrderStats(discountIDs []uint, startDate, endDate time.Time) (*entity.DiscountOrderStats, error)
+
+	// GetOrderStatsWithout aggregates the orders that did not use any of the discounts
+	GetOrderStatsWithout(discountIDs []uint, startDate, endDate time.Time) (*entity.DiscountOrderStats, error)
+
+	// GetRedemptionsByPeriod groups the orders that used one of the discounts by period
+	GetRedemptionsByPeriod(discountIDs []uint, startDate, endDate time.Time, interval entity.DiscountReportInterval) ([]entity.DiscountRedemptionPeriod, error)
+}
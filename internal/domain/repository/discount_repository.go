@@ -11,5 +11,6 @@ type DiscountRepository interface {
 	Delete(discountID uint) error
 	List(offset, limit int) ([]*entity.Discount, error)
 	ListActive(offset, limit int) ([]*entity.Discount, error)
+	ListByCampaign(campaign string) ([]*entity.Discount, error)
 	IncrementUsage(discountID uint) error
 }
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// ExchangeRateHistoryRepository defines the interface for exchange rate history data access
+type ExchangeRateHistoryRepository interface {
+	Create(history *entity.ExchangeRateHistory) error
+	Update(history *entity.ExchangeRateHistory) error
+	GetByID(historyID uint) (*entity.ExchangeRateHistory, error)
+	ListByCurrency(currencyCode string, offset, limit int) ([]*entity.ExchangeRateHistory, error)
+	ListPending() ([]*entity.ExchangeRateHistory, error)
+}
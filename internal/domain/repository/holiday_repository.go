@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// HolidayRepository defines the interface for public holiday data access
+type HolidayRepository interface {
+	Create(holiday *entity.Holiday) error
+	GetByID(holidayID uint) (*entity.Holiday, error)
+	List(country string, from, to time.Time) ([]*entity.Holiday, error) // all countries if country is empty
+	Delete(holidayID uint) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// IdempotencyKeyRepository defines the interface for idempotency key data access
+type IdempotencyKeyRepository interface {
+	// Create saves the key of a new request. It returns false without saving when the scope
+	// already has an unexpired key of the same value, also under concurrent use.
+	Create(key *entity.IdempotencyKey) (bool, error)
+	Get(scope, key string) (*entity.IdempotencyKey, error)
+	// Complete stores the response of the key's request
+	Complete(key *entity.IdempotencyKey) error
+	Delete(scope, key string) error
+	// DeleteExpired deletes the keys expired before the given time and returns how many were deleted
+	DeleteExpired(before time.Time) (int64, error)
+}
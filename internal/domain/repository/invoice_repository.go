@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// InvoiceRepository defines the interface for invoice and credit note data access
+type InvoiceRepository interface {
+	// Create saves an invoice with the next number of its type's series, formatted with the prefix.
+	// Numbers are gap-free and unique under concurrent use, and an order has at most one invoice.
+	Create(invoice *entity.Invoice, prefix string) error
+	GetByID(invoiceID uint) (*entity.Invoice, error)
+	GetInvoiceByOrderID(orderID uint) (*entity.Invoice, error)
+	ListByOrderID(orderID uint) ([]*entity.Invoice, error)
+}
@@ -1,6 +1,10 @@
 package repository
 
-import "github.com/zenfulcode/commercify/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
 
 // OrderRepository defines the interface for order data access
 type OrderRepository interface {
@@ -11,5 +15,8 @@ type OrderRepository interface {
 	ListByStatus(status entity.OrderStatus, offset, limit int) ([]*entity.Order, error)
 	IsDiscountIdUsed(discountID uint) (bool, error)
 	GetByPaymentID(paymentID string) (*entity.Order, error)
+	GetByOrderNumber(orderNumber string) (*entity.Order, error)
+	GetByTrackingCode(trackingCode string) (*entity.Order, error)
 	ListAll(offset, limit int) ([]*entity.Order, error)
+	ListExpiringAuthorizations(before time.Time) ([]*entity.Order, error)
 }
@@ -0,0 +1,23 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// CustomerGroupRepository defines the interface for customer group data access
+type CustomerGroupRepository interface {
+	Create(group *entity.CustomerGroup) error
+	Update(group *entity.CustomerGroup) error
+	Delete(groupID uint) error
+	GetByID(groupID uint) (*entity.CustomerGroup, error)
+	List() ([]*entity.CustomerGroup, error)
+}
+
+// PriceListRepository defines the interface for price list data access.
+// Price lists are loaded and saved with their entries and customer groups.
+type PriceListRepository interface {
+	Create(priceList *entity.PriceList) error
+	Update(priceList *entity.PriceList) error
+	Delete(priceListID uint) error
+	GetByID(priceListID uint) (*entity.PriceList, error)
+	List(offset, limit int) ([]*entity.PriceList, error)
+	ListByCustomerGroup(groupID uint) ([]*entity.PriceList, error)
+}
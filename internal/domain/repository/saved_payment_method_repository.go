@@ -0,0 +1,23 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// SavedPaymentMethodRepository defines the interface for saved payment methods and the
+// provider customers they are saved to
+type SavedPaymentMethodRepository interface {
+	Create(method *entity.SavedPaymentMethod) error
+	GetByID(methodID uint) (*entity.SavedPaymentMethod, error)
+	GetByProviderMethodID(providerMethodID string) (*entity.SavedPaymentMethod, error)
+	// ListByUser lists a user's saved payment methods, the default first
+	ListByUser(userID uint) ([]*entity.SavedPaymentMethod, error)
+	Update(method *entity.SavedPaymentMethod) error
+	Delete(methodID uint) error
+	// SetDefault makes a method the user's default and clears the flag on their other methods
+	SetDefault(userID, methodID uint) error
+
+	// GetCustomerID returns the user's customer ID at a provider, empty if they have none
+	GetCustomerID(userID uint, provider string) (string, error)
+	SetCustomerID(userID uint, provider, customerID string) error
+	// GetUserIDByCustomerID returns the user of a provider customer, 0 if it is unknown
+	GetUserIDByCustomerID(provider, customerID string) (uint, error)
+}
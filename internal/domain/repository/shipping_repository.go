@@ -34,3 +34,21 @@ type ShippingRateRepository interface {
 	Update(rate *entity.ShippingRate) error
 	Delete(rateID uint) error
 }
+
+// ShippingPackageRepository defines the interface for shipping package data access
+type ShippingPackageRepository interface {
+	Create(pkg *entity.ShippingPackage) error
+	GetByID(packageID uint) (*entity.ShippingPackage, error)
+	List(active bool) ([]*entity.ShippingPackage, error)
+	Update(pkg *entity.ShippingPackage) error
+	Delete(packageID uint) error
+}
+
+// ShippingProfileRepository defines the interface for shipping profile data access
+type ShippingProfileRepository interface {
+	Create(profile *entity.ShippingProfile) error
+	GetByID(profileID uint) (*entity.ShippingProfile, error)
+	List() ([]*entity.ShippingProfile, error)
+	Update(profile *entity.ShippingProfile) error
+	Delete(profileID uint) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// TaxClassRepository defines the interface for tax class data access
+type TaxClassRepository interface {
+	Create(taxClass *entity.TaxClass) error
+	Update(taxClass *entity.TaxClass) error
+	Delete(taxClassID uint) error
+	GetByID(taxClassID uint) (*entity.TaxClass, error)
+	List() ([]*entity.TaxClass, error)
+}
+
+// TaxRateRepository defines the interface for tax rate data access
+type TaxRateRepository interface {
+	Create(taxRate *entity.TaxRate) error
+	Update(taxRate *entity.TaxRate) error
+	Delete(taxRateID uint) error
+	GetByID(taxRateID uint) (*entity.TaxRate, error)
+	List() ([]*entity.TaxRate, error)
+	ListActiveByCountry(country string) ([]*entity.TaxRate, error)
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// TrackingEventRepository defines the interface for shipment tracking event data access
+type TrackingEventRepository interface {
+	Create(event *entity.TrackingEvent) error
+	ListByOrderID(orderID uint) ([]*entity.TrackingEvent, error)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// CarrierShipment describes a shipment to quote or to create a label for.
+// The carrier service knows the address it ships from.
+type CarrierShipment struct {
+	Reference string // Order number printed on labels, empty for quotes
+	Recipient string
+	Email     string
+	Phone     string
+	To        entity.Address
+	Packages  []entity.OrderPackage
+	Weight    float64 // Total weight in kg, quoted as one parcel when there are no packages
+}
+
+// CarrierRate is a live quote for one of a carrier's services
+type CarrierRate struct {
+	ServiceCode   string
+	ServiceName   string
+	Amount        int64 // in cents
+	Currency      string
+	EstimatedDays int // 0 if the carrier gives no estimate
+}
+
+// CarrierTrackingEvent is a scan or status change reported by a carrier
+type CarrierTrackingEvent struct {
+	Status      string // Carrier's status, e.g. "TRANSIT"
+	Description string
+	Location    string
+	OccurredAt  time.Time
+}
+
+// CarrierTracking is the tracking status of a label, with its events oldest first
+type CarrierTracking struct {
+	TrackingNumber string
+	Status         string // Carrier's latest status
+	Events         []CarrierTrackingEvent
+}
+
+// CarrierService defines the interface for carriers quoting live rates, creating labels and tracking parcels
+type CarrierService interface {
+	// Name returns the name shipping methods refer to the carrier by
+	Name() string
+
+	// GetRates returns quotes for the carrier's services that can ship the shipment
+	GetRates(shipment CarrierShipment) ([]CarrierRate, error)
+
+	// CreateLabel buys a label for the shipment with one of the carrier's services
+	CreateLabel(shipment CarrierShipment, serviceCode string) (*entity.ShippingLabel, error)
+
+	// Track returns the tracking status of a label
+	Track(label entity.ShippingLabel) (*CarrierTracking, error)
+}
@@ -4,12 +4,20 @@ import "github.com/zenfulcode/commercify/internal/domain/entity"
 
 // EmailData represents the data needed to send an email
 type EmailData struct {
-	To       string
-	Subject  string
-	Body     string
-	IsHTML   bool
-	Template string
-	Data     map[string]interface{}
+	To          string
+	Subject     string
+	Body        string
+	IsHTML      bool
+	Template    string
+	Data        map[string]interface{}
+	Attachments []EmailAttachment
+}
+
+// EmailAttachment is a file attached to an email
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
 }
 
 // EmailService defines the interface for email operations
@@ -22,4 +30,7 @@ type EmailService interface {
 
 	// SendOrderNotification sends an order notification email to the admin
 	SendOrderNotification(order *entity.Order, user *entity.User) error
+
+	// SendInvoice sends an invoice or credit note to the customer with its document attached
+	SendInvoice(order *entity.Order, user *entity.User, invoice *entity.Invoice, document []byte) error
 }
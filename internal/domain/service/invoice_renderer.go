@@ -0,0 +1,12 @@
+package service
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// InvoiceRenderer defines the interface for rendering invoices and credit notes as documents
+type InvoiceRenderer interface {
+	// ContentType returns the media type of the rendered documents, e.g. "application/pdf"
+	ContentType() string
+
+	// Render renders an invoice or credit note of an order
+	Render(invoice *entity.Invoice, order *entity.Order) ([]byte, error)
+}
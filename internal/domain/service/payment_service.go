@@ -1,5 +1,7 @@
 package service
 
+import "time"
+
 // PaymentProviderType represents a payment provider type
 type PaymentProviderType string
 
@@ -42,6 +44,29 @@ type PaymentRequest struct {
 	BankDetails     *BankDetails
 	CustomerEmail   string
 	PhoneNumber     string
+
+	// Provider customer of a registered user, and the saved payment method to charge
+	CustomerID           string
+	SavedPaymentMethodID string
+	SavePaymentMethod    bool // save the card to the customer for future payments
+
+	// IdempotencyKey is sent to providers that support it, so a retried request doesn't charge twice
+	IdempotencyKey string
+
+	// Order contents, shown on hosted payment pages
+	LineItems      []PaymentLineItem
+	ShippingName   string
+	ShippingAmount int64 // after shipping discounts
+	DiscountAmount int64
+	TaxAmount      int64 // tax charged on top of the prices, 0 when prices include tax
+}
+
+// PaymentLineItem is an order line shown on a hosted payment page
+type PaymentLineItem struct {
+	Name       string
+	SKU        string
+	Quantity   int
+	UnitAmount int64 // in the smallest currency unit
 }
 
 // CardDetails represents credit card payment details
@@ -91,8 +116,9 @@ type PaymentService interface {
 	// RefundPayment refunds a payment
 	RefundPayment(transactionID string, amount int64, provider PaymentProviderType) error
 
-	// CapturePayment captures a payment
-	CapturePayment(transactionID string, amount int64, provider PaymentProviderType) error
+	// CapturePayment captures an amount of an authorized payment. A final capture releases
+	// the rest of the authorization, otherwise more can be captured later.
+	CapturePayment(transactionID string, amount int64, final bool, provider PaymentProviderType) error
 
 	// CancelPayment cancels a payment
 	CancelPayment(transactionID string, provider PaymentProviderType) error
@@ -100,3 +126,47 @@ type PaymentService interface {
 	// ForceApprovePayment force approves a payment
 	ForceApprovePayment(transactionID string, phoneNumber string, provider PaymentProviderType) error
 }
+
+// PaymentVault manages customers and their saved payment methods at payment providers
+type PaymentVault interface {
+	// CreateCustomer creates a customer at the provider and returns its ID
+	CreateCustomer(provider PaymentProviderType, email, name string) (string, error)
+
+	// DetachPaymentMethod removes a saved payment method from its customer
+	DetachPaymentMethod(provider PaymentProviderType, methodID string) error
+}
+
+// PaymentState is the state of a payment at its provider
+type PaymentState string
+
+const (
+	PaymentStatePending    PaymentState = "pending"    // not authorized yet
+	PaymentStateAuthorized PaymentState = "authorized" // funds reserved but not captured
+	PaymentStateCaptured   PaymentState = "captured"   // funds captured, in full or in part
+	PaymentStateCancelled  PaymentState = "cancelled"  // cancelled, declined or expired before it was captured
+)
+
+// PaymentStatus is a payment as recorded by its provider, amounts in the smallest currency unit.
+// An amount of 0 means the provider didn't report it.
+type PaymentStatus struct {
+	State          PaymentState
+	PaymentID      string // payment a hosted payment session resulted in, empty if it is the transaction itself
+	Amount         int64
+	CapturedAmount int64
+	RefundedAmount int64
+}
+
+// PaymentStatusProvider looks up payments at their provider, to reconcile orders whose webhooks never arrived
+type PaymentStatusProvider interface {
+	// GetPaymentStatus returns the state and amounts of a payment
+	GetPaymentStatus(transactionID string, provider PaymentProviderType) (*PaymentStatus, error)
+}
+
+// DefaultAuthorizationValidity is how long card networks hold an uncaptured payment
+const DefaultAuthorizationValidity = 7 * 24 * time.Hour
+
+// AuthorizationPolicy tells how long payments of a provider stay authorized before they must be captured
+type AuthorizationPolicy interface {
+	// AuthorizationValidity returns how long an uncaptured payment stays authorized
+	AuthorizationValidity(provider PaymentProviderType) time.Duration
+}
@@ -0,0 +1,21 @@
+package service
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// PickupPointQuery describes where to look for pickup points of a shipping method
+type PickupPointQuery struct {
+	Carrier     string // Carrier of the shipping method, empty for table-rate methods
+	ServiceCode string
+	PostalCode  string
+	Country     string // ISO 3166-1 alpha-2 code, empty if unknown
+	Limit       int
+}
+
+// PickupPointProvider defines the interface for finding parcel shops and lockers near the customer
+type PickupPointProvider interface {
+	// FindPickupPoints returns the pickup points nearest to the postal code, nearest first
+	FindPickupPoints(query PickupPointQuery) ([]entity.PickupPoint, error)
+
+	// GetPickupPoint returns a pickup point by its ID
+	GetPickupPoint(id string) (*entity.PickupPoint, error)
+}
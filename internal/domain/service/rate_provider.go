@@ -0,0 +1,19 @@
+package service
+
+import "time"
+
+// ExchangeRates contains the rates of a set of currencies relative to a base currency
+type ExchangeRates struct {
+	Base  string
+	Date  time.Time
+	Rates map[string]float64 // units of each currency per unit of Base
+}
+
+// RateProvider defines the interface for fetching exchange rates from an external source
+type RateProvider interface {
+	// Name returns the name of the provider, recorded as the source of synchronised rates
+	Name() string
+
+	// GetRates returns the latest rates relative to the given base currency
+	GetRates(base string) (*ExchangeRates, error)
+}
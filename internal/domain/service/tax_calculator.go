@@ -0,0 +1,30 @@
+package service
+
+import "github.com/zenfulcode/commercify/internal/domain/entity"
+
+// TaxableLine is an amount to be taxed together with the tax class of its product
+type TaxableLine struct {
+	TaxClassID uint  // 0 for the standard tax class
+	Amount     int64 // in cents, after discounts
+}
+
+// TaxRequest contains everything needed to calculate the tax of an order or cart
+type TaxRequest struct {
+	Address          entity.Address
+	Currency         string // empty for the default currency
+	Lines            []TaxableLine
+	ShippingAmount   int64 // in cents, after shipping discounts
+	PricesIncludeTax bool
+}
+
+// TaxResult contains the tax of each line, in request order, and of the shipping
+type TaxResult struct {
+	Lines    []entity.TaxLine
+	Shipping entity.TaxLine
+}
+
+// TaxCalculator defines the interface for calculating taxes,
+// implemented by the built-in rate table or an external tax engine
+type TaxCalculator interface {
+	Calculate(request TaxRequest) (*TaxResult, error)
+}
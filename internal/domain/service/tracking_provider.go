@@ -0,0 +1,35 @@
+package service
+
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// TrackingUpdateEvent is a tracking event reported by a carrier
+type TrackingUpdateEvent struct {
+	Status      entity.TrackingStatus
+	Description string
+	Location    string
+	OccurredAt  time.Time
+}
+
+// TrackingUpdate is the tracking of a shipment reported by a carrier, with its events oldest first
+type TrackingUpdate struct {
+	TrackingNumber string
+	Events         []TrackingUpdateEvent
+}
+
+// TrackingProvider defines the interface for receiving shipment tracking from carriers,
+// either by polling or through carrier webhooks
+type TrackingProvider interface {
+	// Name returns the name of the provider
+	Name() string
+
+	// GetTracking polls the carrier for the tracking of a shipment.
+	// carrierCode identifies the carrier behind an aggregator and may be empty.
+	GetTracking(carrierCode, trackingNumber string) (*TrackingUpdate, error)
+
+	// ParseWebhook parses a tracking webhook sent by the carrier
+	ParseWebhook(payload []byte) (*TrackingUpdate, error)
+}
@@ -0,0 +1,16 @@
+package service
+
+// VATValidation is the result of validating a VAT number
+type VATValidation struct {
+	VATID       string // normalised VAT number including the country prefix
+	CountryCode string // ISO country code of the VAT number
+	Valid       bool
+	Name        string // registered company name, when the validator returns it
+	Address     string // registered company address, when the validator returns it
+}
+
+// VATNumberValidator defines the interface for validating EU VAT numbers.
+// An error means the number could not be checked; an invalid number is not an error.
+type VATNumberValidator interface {
+	Validate(vatID string) (*VATValidation, error)
+}
@@ -4,24 +4,30 @@ import "time"
 
 // CartDTO represents a shopping cart in the system
 type CartDTO struct {
-	ID        uint          `json:"id"`
-	UserID    uint          `json:"user_id"`
-	SessionID string        `json:"session_id"`
-	Items     []CartItemDTO `json:"items"`
-	Currency  string        `json:"currency"`
-	CreatedAt time.Time     `json:"created_at"`
-	UpdatedAt time.Time     `json:"updated_at"`
+	ID             uint          `json:"id"`
+	UserID         uint          `json:"user_id"`
+	SessionID      string        `json:"session_id"`
+	Items          []CartItemDTO `json:"items"`
+	Currency       string        `json:"currency"`
+	Subtotal       float64       `json:"subtotal"`
+	DiscountCode   string        `json:"discount_code,omitempty"`
+	DiscountAmount float64       `json:"discount_amount"`
+	Total          float64       `json:"total"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
 }
 
 // CartItemDTO represents an item in a shopping cart
 type CartItemDTO struct {
-	ID        uint      `json:"id"`
-	ProductID uint      `json:"product_id"`
-	VariantID uint      `json:"variant_id,omitempty"`
-	Price     float64   `json:"price"`
-	Quantity  int       `json:"quantity"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             uint      `json:"id"`
+	ProductID      uint      `json:"product_id"`
+	VariantID      uint      `json:"variant_id,omitempty"`
+	Price          float64   `json:"price"`
+	Quantity       int       `json:"quantity"`
+	Subtotal       float64   `json:"subtotal"`
+	DiscountAmount float64   `json:"discount_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 // AddToCartRequest represents the data needed to add an item to the cart
@@ -37,6 +43,11 @@ type UpdateCartItemRequest struct {
 	VariantID uint `json:"variant_id,omitempty"`
 }
 
+// ApplyCartDiscountRequest represents the data needed to attach a discount code to the cart
+type ApplyCartDiscountRequest struct {
+	DiscountCode string `json:"discount_code"`
+}
+
 // CartListResponse represents a paginated list of carts
 type CartListResponse struct {
 	ListResponseDTO[CartDTO]
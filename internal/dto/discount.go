@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// DiscountReportDTO represents the performance of one or more discount codes
+type DiscountReportDTO struct {
+	DiscountIDs              []uint                        `json:"discount_ids"`
+	Codes                    []string                      `json:"codes"`
+	Campaign                 string                        `json:"campaign,omitempty"`
+	StartDate                time.Time                     `json:"start_date"`
+	EndDate                  time.Time                     `json:"end_date"`
+	Interval                 string                        `json:"interval"`
+	Redemptions              int                           `json:"redemptions"`
+	GrossRevenue             float64                       `json:"gross_revenue"`
+	TotalDiscount            float64                       `json:"total_discount"`
+	AverageOrderValueWith    float64                       `json:"average_order_value_with"`
+	AverageOrderValueWithout float64                       `json:"average_order_value_without"`
+	CancellationRate         float64                       `json:"cancellation_rate"`
+	RefundRate               float64                       `json:"refund_rate"`
+	RefundedAmount           float64                       `json:"refunded_amount"`
+	RedemptionsOverTime      []DiscountRedemptionPeriodDTO `json:"redemptions_over_time"`
+}
+
+// DiscountRedemptionPeriodDTO represents the redemptions of a discount within one period
+type DiscountRedemptionPeriodDTO struct {
+	PeriodStart    time.Time `json:"period_start"`
+	Redemptions    int       `json:"redemptions"`
+	GrossRevenue   float64   `json:"gross_revenue"`
+	DiscountAmount float64   `json:"discount_amount"`
+}
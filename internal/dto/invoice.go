@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// InvoiceDTO represents an invoice or credit note of an order
+type InvoiceDTO struct {
+	ID            uint      `json:"id"`
+	OrderID       uint      `json:"order_id"`
+	Type          string    `json:"type"` // "invoice" or "credit_note"
+	Number        string    `json:"number"`
+	InvoiceNumber string    `json:"invoice_number,omitempty"` // credited invoice of a credit note
+	Amount        float64   `json:"amount"`
+	TaxAmount     float64   `json:"tax_amount"`
+	Currency      string    `json:"currency"`
+	ReverseCharge bool      `json:"reverse_charge"`
+	DownloadURL   string    `json:"download_url"`
+	CreatedAt     time.Time `json:"created_at"`
+}
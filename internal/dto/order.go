@@ -16,11 +16,13 @@ type OrderDTO struct {
 	TotalAmount     float64         `json:"total_amount"`
 	FinalAmount     float64         `json:"final_amount"`
 	Currency        string          `json:"currency"`
+	ExchangeRate    float64         `json:"exchange_rate"`
 	ShippingAddress AddressDTO      `json:"shipping_address"`
 	BillingAddress  AddressDTO      `json:"billing_address"`
 	PaymentDetails  PaymentDetails  `json:"payment_details"`
 	ShippingDetails ShippingDetails `json:"shipping_details"`
 	DiscountDetails DiscountDetails `json:"discount_details"`
+	TaxDetails      TaxDetails      `json:"tax_details"`
 	Customer        CustomerDetails `json:"customer"`
 	ActionURL       string          `json:"action_url,omitempty"`
 	CreatedAt       time.Time       `json:"created_at"`
@@ -34,23 +36,92 @@ type PaymentDetails struct {
 	Status   string          `json:"status"`
 	Captured bool            `json:"captured"`
 	Refunded bool            `json:"refunded"`
+
+	AuthorizedAmount       float64    `json:"authorized_amount"`
+	CapturedAmount         float64    `json:"captured_amount"`
+	RemainingCapturable    float64    `json:"remaining_capturable"`
+	AuthorizationExpiresAt *time.Time `json:"authorization_expires_at,omitempty"` // when the uncaptured amount is released
 }
 
 type ShippingDetails struct {
-	MethodID uint    `json:"method_id"`
-	Method   string  `json:"method"`
-	Cost     float64 `json:"cost"`
+	MethodID          uint                 `json:"method_id"`
+	Method            string               `json:"method"`
+	Cost              float64              `json:"cost"`
+	Packages          []OrderPackageDTO    `json:"packages,omitempty"`
+	Label             *ShippingLabelDTO    `json:"label,omitempty"`
+	PickupPoint       *PickupPointDTO      `json:"pickup_point,omitempty"` // its address is the shipping address
+	EstimatedDelivery *DeliveryEstimateDTO `json:"estimated_delivery,omitempty"`
+}
+
+// DeliveryEstimateDTO represents the window of dates the order is expected to be delivered in
+type DeliveryEstimateDTO struct {
+	Earliest time.Time `json:"earliest"`
+	Latest   time.Time `json:"latest"`
+}
+
+// PickupPointDTO represents a parcel shop or locker the customer collects the order at
+type PickupPointDTO struct {
+	ID      string     `json:"id"`
+	Name    string     `json:"name"`
+	Address AddressDTO `json:"address"`
+	Carrier string     `json:"carrier,omitempty"`
+}
+
+// ShippingLabelDTO represents the carrier label bought when the order shipped
+type ShippingLabelDTO struct {
+	Carrier        string  `json:"carrier"`
+	ServiceCode    string  `json:"service_code"`
+	TrackingNumber string  `json:"tracking_number"`
+	TrackingURL    string  `json:"tracking_url,omitempty"`
+	LabelURL       string  `json:"label_url"`
+	Cost           float64 `json:"cost"`
+	Currency       string  `json:"currency"`
+}
+
+// OrderPackageDTO represents a box the warehouse packs part of an order in.
+// The shipping cost is based on the greater of the weight and the dimensional weight.
+type OrderPackageDTO struct {
+	PackageID         uint                  `json:"package_id,omitempty"` // omitted for items shipped in their own packaging
+	Name              string                `json:"name"`
+	Dimensions        DimensionsDTO         `json:"dimensions"`
+	Weight            float64               `json:"weight"`
+	DimensionalWeight float64               `json:"dimensional_weight"`
+	Items             []OrderPackageItemDTO `json:"items"`
+}
+
+// OrderPackageItemDTO represents the quantity of an order item in a package
+type OrderPackageItemDTO struct {
+	ProductID uint   `json:"product_id"`
+	VariantID uint   `json:"variant_id,omitempty"`
+	SKU       string `json:"sku,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Quantity  int    `json:"quantity"`
 }
 
 type CustomerDetails struct {
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
-	FullName string `json:"full_name"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	FullName    string `json:"full_name"`
+	CompanyName string `json:"company_name,omitempty"`
+	VATID       string `json:"vat_id,omitempty"`
 }
 
 type DiscountDetails struct {
-	Code   string  `json:"code"`
-	Amount float64 `json:"amount"`
+	Code           string  `json:"code"`
+	Amount         float64 `json:"amount"`
+	ShippingAmount float64 `json:"shipping_amount"`
+}
+
+// TaxDetails contains the tax of an order. When prices include tax the amounts
+// are already part of the item and shipping prices, otherwise they are added to the final amount.
+// Reverse charge orders are zero-rated and carry the note to print on documents.
+type TaxDetails struct {
+	Amount            float64 `json:"amount"`
+	ShippingAmount    float64 `json:"shipping_amount"`
+	ShippingRate      float64 `json:"shipping_rate"`
+	PricesIncludeTax  bool    `json:"prices_include_tax"`
+	ReverseCharge     bool    `json:"reverse_charge"`
+	ReverseChargeNote string  `json:"reverse_charge_note,omitempty"`
 }
 
 // OrderItemDTO represents an item in an order
@@ -65,12 +136,15 @@ type OrderItemDTO struct {
 	Quantity    int       `json:"quantity"`
 	UnitPrice   float64   `json:"unit_price"`
 	TotalPrice  float64   `json:"total_price"`
+	TaxRate     float64   `json:"tax_rate"`
+	TaxAmount   float64   `json:"tax_amount"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // AddressDTO represents a shipping or billing address
 type AddressDTO struct {
+	Company      string `json:"company,omitempty"`
 	AddressLine1 string `json:"address_line1"`
 	AddressLine2 string `json:"address_line2,omitempty"`
 	City         string `json:"city"`
@@ -85,9 +159,13 @@ type CreateOrderRequest struct {
 	LastName         string     `json:"last_name"`
 	Email            string     `json:"email"`
 	PhoneNumber      string     `json:"phone_number,omitempty"`
+	CompanyName      string     `json:"company_name,omitempty"`
+	VATID            string     `json:"vat_id,omitempty"` // EU VAT number, zero-rates intra-EU business orders
 	ShippingAddress  AddressDTO `json:"shipping_address"`
 	BillingAddress   AddressDTO `json:"billing_address"`
 	ShippingMethodID uint       `json:"shipping_method_id"`
+	PickupPointID    string     `json:"pickup_point_id,omitempty"` // required by pickup point methods, replaces the shipping address
+	Currency         string     `json:"currency,omitempty"`
 }
 
 // CreateOrderItemRequest represents the data needed to create a new order item
@@ -126,6 +204,47 @@ type ProcessPaymentRequest struct {
 	PaymentProvider PaymentProvider      `json:"payment_provider"`
 	CardDetails     *service.CardDetails `json:"card_details,omitempty"`
 	PhoneNumber     string               `json:"phone_number,omitempty"`
+
+	SavedPaymentMethodID uint `json:"saved_payment_method_id,omitempty"` // pay with a saved card instead
+	SavePaymentMethod    bool `json:"save_payment_method,omitempty"`     // save the card for later payments
+}
+
+// CreatePaymentIntentRequest represents the options for a payment intent confirmed by the storefront
+type CreatePaymentIntentRequest struct {
+	SavePaymentMethod bool `json:"save_payment_method,omitempty"`
+}
+
+// PaymentIntentResponse holds the client secret the storefront confirms a payment or setup intent with
+type PaymentIntentResponse struct {
+	ID           string `json:"id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// CapturePaymentRequest represents a capture of an authorized payment, e.g. for one shipment of an order
+type CapturePaymentRequest struct {
+	Amount   float64 `json:"amount"`
+	Final    bool    `json:"final,omitempty"`    // release the rest of the authorization after this capture
+	Shipment string  `json:"shipment,omitempty"` // reference of the shipment the capture pays for
+}
+
+// CapturePaymentResponse reports what is captured of a payment and what is left to capture
+type CapturePaymentResponse struct {
+	Status              string  `json:"status"`
+	Message             string  `json:"message"`
+	CapturedAmount      float64 `json:"captured_amount"`
+	RemainingCapturable float64 `json:"remaining_capturable"`
+}
+
+// SavedPaymentMethodDTO represents a card a user saved for later payments
+type SavedPaymentMethodDTO struct {
+	ID        uint      `json:"id"`
+	Provider  string    `json:"provider"`
+	Brand     string    `json:"brand"`
+	Last4     string    `json:"last4"`
+	ExpMonth  int       `json:"exp_month"`
+	ExpYear   int       `json:"exp_year"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // OrderStatus represents the status of an order
@@ -148,6 +267,7 @@ type PaymentMethod string
 const (
 	PaymentMethodCard   PaymentMethod = "credit_card"
 	PaymentMethodWallet PaymentMethod = "wallet"
+	PaymentMethodPayPal PaymentMethod = "paypal"
 )
 
 // PaymentProvider represents the payment provider used for an order
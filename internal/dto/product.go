@@ -4,36 +4,49 @@ import "time"
 
 // ProductDTO represents a product in the system
 type ProductDTO struct {
-	ID          uint         `json:"id"`
-	Name        string       `json:"name"`
-	Description string       `json:"description"`
-	SKU         string       `json:"sku"`
-	Price       float64      `json:"price"`
-	Currency    string       `json:"currency"`
-	Stock       int          `json:"stock"`
-	Weight      float64      `json:"weight"`
-	CategoryID  uint         `json:"category_id"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
-	Images      []string     `json:"images"`
-	HasVariants bool         `json:"has_variants"`
-	Variants    []VariantDTO `json:"variants,omitempty"`
-	Active      bool         `json:"active"`
+	ID                uint          `json:"id"`
+	Name              string        `json:"name"`
+	Description       string        `json:"description"`
+	SKU               string        `json:"sku"`
+	Price             float64       `json:"price"`                      // effective price, including a running sale
+	CompareAtPrice    float64       `json:"compare_at_price,omitempty"` // original price to show struck through
+	Currency          string        `json:"currency"`
+	Stock             int           `json:"stock"`
+	Weight            float64       `json:"weight"`
+	Dimensions        DimensionsDTO `json:"dimensions"`
+	CategoryID        uint          `json:"category_id"`
+	TaxClassID        uint          `json:"tax_class_id"`
+	ShippingProfileID uint          `json:"shipping_profile_id"`
+	CreatedAt         time.Time     `json:"created_at"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+	Images            []string      `json:"images"`
+	HasVariants       bool          `json:"has_variants"`
+	Variants          []VariantDTO  `json:"variants,omitempty"`
+	Active            bool          `json:"active"`
 }
 
 // VariantDTO represents a product variant
 type VariantDTO struct {
-	ID         uint                  `json:"id"`
-	ProductID  uint                  `json:"product_id"`
-	SKU        string                `json:"sku"`
-	Price      float64               `json:"price"`
-	Currency   string                `json:"currency"`
-	Stock      int                   `json:"stock"`
-	Attributes []VariantAttributeDTO `json:"attributes"`
-	Images     []string              `json:"images,omitempty"`
-	IsDefault  bool                  `json:"is_default"`
-	CreatedAt  time.Time             `json:"created_at"`
-	UpdatedAt  time.Time             `json:"updated_at"`
+	ID             uint                  `json:"id"`
+	ProductID      uint                  `json:"product_id"`
+	SKU            string                `json:"sku"`
+	Price          float64               `json:"price"`                      // effective price, including a running sale
+	CompareAtPrice float64               `json:"compare_at_price,omitempty"` // original price to show struck through
+	Currency       string                `json:"currency"`
+	Stock          int                   `json:"stock"`
+	Attributes     []VariantAttributeDTO `json:"attributes"`
+	Images         []string              `json:"images,omitempty"`
+	IsDefault      bool                  `json:"is_default"`
+	Dimensions     *DimensionsDTO        `json:"dimensions,omitempty"` // omitted when the product's dimensions apply
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// DimensionsDTO represents the length, width and height of an item or box in cm
+type DimensionsDTO struct {
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
 }
 
 type VariantAttributeDTO struct {
@@ -43,36 +56,64 @@ type VariantAttributeDTO struct {
 
 // CreateProductRequest represents the data needed to create a new product
 type CreateProductRequest struct {
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Price       float64                `json:"price"`
-	Stock       int                    `json:"stock"`
-	Weight      float64                `json:"weight"`
-	CategoryID  uint                   `json:"category_id"`
-	Images      []string               `json:"images"`
-	Variants    []CreateVariantRequest `json:"variants,omitempty"`
+	Name              string                 `json:"name"`
+	Description       string                 `json:"description"`
+	Price             float64                `json:"price"`
+	Stock             int                    `json:"stock"`
+	Weight            float64                `json:"weight"`
+	Dimensions        *DimensionsDTO         `json:"dimensions,omitempty"`
+	CategoryID        uint                   `json:"category_id"`
+	TaxClassID        uint                   `json:"tax_class_id,omitempty"`        // omit for the standard tax class
+	ShippingProfileID uint                   `json:"shipping_profile_id,omitempty"` // omit for the category's shipping profile
+	Images            []string               `json:"images"`
+	Variants          []CreateVariantRequest `json:"variants,omitempty"`
+	CurrencyPrices    []CurrencyPriceRequest `json:"currency_prices,omitempty"`
+	Sale              *SalePriceRequest      `json:"sale,omitempty"`
 }
 
 // CreateVariantRequest represents the data needed to create a new product variant
 type CreateVariantRequest struct {
-	SKU        string                `json:"sku"`
-	Price      float64               `json:"price,omitempty"`
-	Stock      int                   `json:"stock"`
-	Attributes []VariantAttributeDTO `json:"attributes"`
-	Images     []string              `json:"images,omitempty"`
-	IsDefault  bool                  `json:"is_default,omitempty"`
+	SKU            string                 `json:"sku"`
+	Price          float64                `json:"price,omitempty"`
+	Stock          int                    `json:"stock"`
+	Attributes     []VariantAttributeDTO  `json:"attributes"`
+	Images         []string               `json:"images,omitempty"`
+	IsDefault      bool                   `json:"is_default,omitempty"`
+	Dimensions     *DimensionsDTO         `json:"dimensions,omitempty"` // omit to use the product's dimensions
+	CurrencyPrices []CurrencyPriceRequest `json:"currency_prices,omitempty"`
+	Sale           *SalePriceRequest      `json:"sale,omitempty"`
 }
 
 // UpdateProductRequest represents the data needed to update an existing product
 type UpdateProductRequest struct {
-	Name          string   `json:"name,omitempty"`
-	Description   string   `json:"description,omitempty"`
-	Price         *float64 `json:"price,omitempty"`
-	StockQuantity *int     `json:"stock,omitempty"`
-	Weight        *float64 `json:"weight,omitempty"`
-	CategoryID    *uint    `json:"category_id,omitempty"`
-	Images        []string `json:"images,omitempty"`
-	Active        bool     `json:"active,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	Price             *float64               `json:"price,omitempty"`
+	StockQuantity     *int                   `json:"stock,omitempty"`
+	Weight            *float64               `json:"weight,omitempty"`
+	Dimensions        *DimensionsDTO         `json:"dimensions,omitempty"` // omit to keep the current dimensions
+	CategoryID        *uint                  `json:"category_id,omitempty"`
+	TaxClassID        *uint                  `json:"tax_class_id,omitempty"`        // 0 selects the standard tax class
+	ShippingProfileID *uint                  `json:"shipping_profile_id,omitempty"` // 0 selects the category's shipping profile
+	Images            []string               `json:"images,omitempty"`
+	Active            bool                   `json:"active,omitempty"`
+	CurrencyPrices    []CurrencyPriceRequest `json:"currency_prices,omitempty"`
+	Sale              *SalePriceRequest      `json:"sale,omitempty"` // omit to keep the current sale
+}
+
+// CurrencyPriceRequest represents a price in a specific currency
+type CurrencyPriceRequest struct {
+	CurrencyCode string            `json:"currency_code"`
+	Price        float64           `json:"price"`
+	Sale         *SalePriceRequest `json:"sale,omitempty"`
+}
+
+// SalePriceRequest represents the compare-at price and scheduled sale of a price
+type SalePriceRequest struct {
+	CompareAtPrice float64    `json:"compare_at_price,omitempty"`
+	SalePrice      float64    `json:"sale_price,omitempty"`
+	SaleStartsAt   *time.Time `json:"sale_starts_at,omitempty"`
+	SaleEndsAt     *time.Time `json:"sale_ends_at,omitempty"`
 }
 
 // ProductListResponse represents a paginated list of products
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// OrderTrackingDTO represents the shipment tracking of an order shown to the customer
+type OrderTrackingDTO struct {
+	OrderNumber       string               `json:"order_number"`
+	Status            OrderStatus          `json:"status"`
+	Carrier           string               `json:"carrier,omitempty"`
+	TrackingNumber    string               `json:"tracking_number,omitempty"`
+	TrackingURL       string               `json:"tracking_url,omitempty"`
+	PickupPoint       *PickupPointDTO      `json:"pickup_point,omitempty"`
+	EstimatedDelivery *DeliveryEstimateDTO `json:"estimated_delivery,omitempty"`
+	Events            []TrackingEventDTO   `json:"events"` // oldest first
+}
+
+// TrackingEventDTO represents a scan or status change of a shipment
+type TrackingEventDTO struct {
+	Status      string    `json:"status"` // "pre_transit", "in_transit", "out_for_delivery", "delivered" or "exception"
+	Description string    `json:"description"`
+	Location    string    `json:"location,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
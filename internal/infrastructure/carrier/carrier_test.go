@@ -0,0 +1,248 @@
+package carrier_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/internal/infrastructure/carrier"
+)
+
+const shippoShipment = `{
+	"object_id": "shp_1",
+	"rates": [
+		{"object_id": "rate_1", "amount": "7.25", "currency": "EUR", "provider": "GLS", "estimated_days": 2,
+			"servicelevel": {"name": "Business Parcel", "token": "gls_business_parcel"}},
+		{"object_id": "rate_2", "amount": "15.00", "currency": "EUR", "provider": "DHL Express", "estimated_days": 1,
+			"servicelevel": {"name": "Worldwide", "token": "dhl_express_worldwide"}},
+		{"object_id": "rate_3", "amount": "9.00", "currency": "USD", "provider": "USPS", "estimated_days": 5,
+			"servicelevel": {"name": "Priority Mail", "token": "usps_priority"}}
+	]
+}`
+
+func newShippoServer(t *testing.T, requests map[string]map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ShippoToken test-key", r.Header.Get("Authorization"))
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		requests[r.Method+" "+r.URL.Path] = body
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/shipments/":
+			w.Write([]byte(shippoShipment))
+		case "/transactions/":
+			w.Write([]byte(`{"status": "SUCCESS", "tracking_number": "GLS123", "tracking_url_provider": "https://gls.example/GLS123", "label_url": "https://shippo.example/label.pdf"}`))
+		case "/tracks/gls/GLS123":
+			w.Write([]byte(`{
+				"tracking_number": "GLS123",
+				"tracking_status": {"status": "TRANSIT", "status_details": "In transit", "status_date": "2026-10-19T08:00:00Z"},
+				"tracking_history": [
+					{"status": "PRE_TRANSIT", "status_details": "Label created", "status_date": "2026-10-18T10:00:00Z"},
+					{"status": "TRANSIT", "status_details": "In transit", "status_date": "2026-10-19T08:00:00Z",
+						"location": {"city": "Odense", "country": "DK"}}
+				]
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newShippoCarrier(baseURL string) *carrier.ShippoCarrier {
+	sender := carrier.Sender{
+		Name:    "Commercify ApS",
+		Address: entity.Address{Street: "Example Street 1", City: "Copenhagen", PostalCode: "1000", Country: "DK"},
+	}
+	return carrier.NewShippoCarrier(baseURL, "test-key", sender, "eur")
+}
+
+var shipment = service.CarrierShipment{
+	Reference: "ORD-20261018-000001",
+	Recipient: "Jane Doe",
+	To:        entity.Address{Street: "Vestergade 2", City: "Aarhus", PostalCode: "8000", Country: "DK"},
+	Packages: []entity.OrderPackage{
+		{Name: "Small", Dimensions: entity.Dimensions{Length: 20, Width: 15, Height: 10}, Weight: 1.1},
+	},
+}
+
+func TestShippoCarrier_GetRates(t *testing.T) {
+	requests := map[string]map[string]any{}
+	server := newShippoServer(t, requests)
+	defer server.Close()
+
+	rates, err := newShippoCarrier(server.URL).GetRates(shipment)
+
+	assert.NoError(t, err)
+	// The USD quote is not in the store's currency
+	assert.Len(t, rates, 2)
+	assert.Equal(t, service.CarrierRate{
+		ServiceCode:   "gls_business_parcel",
+		ServiceName:   "GLS Business Parcel",
+		Amount:        725,
+		Currency:      "EUR",
+		EstimatedDays: 2,
+	}, rates[0])
+
+	request := requests["POST /shipments/"]
+	assert.Equal(t, "Copenhagen", request["address_from"].(map[string]any)["city"])
+	assert.Equal(t, "8000", request["address_to"].(map[string]any)["zip"])
+	parcel := request["parcels"].([]any)[0].(map[string]any)
+	assert.Equal(t, "20", parcel["length"])
+	assert.Equal(t, "1.1", parcel["weight"])
+	assert.Equal(t, "kg", parcel["mass_unit"])
+}
+
+func TestShippoCarrier_CreateLabelAndTrack(t *testing.T) {
+	requests := map[string]map[string]any{}
+	server := newShippoServer(t, requests)
+	defer server.Close()
+	shippo := newShippoCarrier(server.URL)
+
+	label, err := shippo.CreateLabel(shipment, "gls_business_parcel")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rate_1", requests["POST /transactions/"]["rate"])
+	assert.Equal(t, "shippo", label.Carrier)
+	assert.Equal(t, "gls", label.CarrierCode)
+	assert.Equal(t, "GLS123", label.TrackingNumber)
+	assert.Equal(t, "https://shippo.example/label.pdf", label.LabelURL)
+	assert.Equal(t, int64(725), label.Cost)
+
+	tracking, err := shippo.Track(*label)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "TRANSIT", tracking.Status)
+	assert.Len(t, tracking.Events, 2)
+	assert.Equal(t, "Label created", tracking.Events[0].Description)
+	assert.Equal(t, "Odense, DK", tracking.Events[1].Location)
+
+	_, err = shippo.CreateLabel(shipment, "postnord_parcel")
+	assert.EqualError(t, err, "carrier service postnord_parcel is not available for this shipment")
+}
+
+func TestShippoCarrier_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	rates, err := newShippoCarrier(server.URL).GetRates(shipment)
+
+	assert.Error(t, err)
+	assert.Nil(t, rates)
+}
+
+func TestFakeCarrier(t *testing.T) {
+	fake := carrier.NewFakeCarrier("EUR")
+
+	rates, err := fake.GetRates(shipment)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(700), rates[0].Amount) // 5.00 plus 1.00 for each started kg
+
+	label, err := fake.CreateLabel(shipment, "express")
+	assert.NoError(t, err)
+	assert.Equal(t, "FAKE0000000001", label.TrackingNumber)
+
+	assert.NoError(t, fake.SetTrackingStatus(label.TrackingNumber, "DELIVERED", "Delivered to recipient"))
+	tracking, err := fake.Track(*label)
+	assert.NoError(t, err)
+	assert.Equal(t, "DELIVERED", tracking.Status)
+	assert.Len(t, tracking.Events, 2)
+
+	fake.SetUnavailable(true)
+	_, err = fake.GetRates(shipment)
+	assert.Error(t, err)
+}
+
+func TestFakePickupPointProvider(t *testing.T) {
+	provider := carrier.NewFakePickupPointProvider()
+
+	points, err := provider.FindPickupPoints(service.PickupPointQuery{PostalCode: "8000", Country: "DK", Limit: 3})
+	assert.NoError(t, err)
+	assert.Len(t, points, 3)
+	assert.Less(t, points[0].Distance, points[1].Distance)
+
+	point, err := provider.GetPickupPoint(points[1].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, points[1], *point)
+
+	_, err = provider.GetPickupPoint("DK:8000:9")
+	assert.Error(t, err)
+	_, err = provider.FindPickupPoints(service.PickupPointQuery{Country: "DK"})
+	assert.Error(t, err)
+}
+
+func TestShippoCarrier_GetTracking(t *testing.T) {
+	server := newShippoServer(t, map[string]map[string]any{})
+	defer server.Close()
+
+	update, err := newShippoCarrier(server.URL).GetTracking("gls", "GLS123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "GLS123", update.TrackingNumber)
+	assert.Len(t, update.Events, 2)
+	assert.Equal(t, entity.TrackingStatusPreTransit, update.Events[0].Status)
+	assert.Equal(t, entity.TrackingStatusInTransit, update.Events[1].Status)
+	assert.Equal(t, "Odense, DK", update.Events[1].Location)
+}
+
+func TestShippoCarrier_ParseWebhook(t *testing.T) {
+	shippo := newShippoCarrier("http://localhost")
+
+	t.Run("Track updated", func(t *testing.T) {
+		update, err := shippo.ParseWebhook([]byte(`{
+			"event": "track_updated",
+			"data": {
+				"tracking_number": "GLS123",
+				"tracking_history": [
+					{"status": "UNKNOWN", "status_details": "Awaiting scan", "status_date": "2026-10-18T09:00:00Z"},
+					{"status": "TRANSIT", "status_details": "Out for delivery", "status_date": "2026-10-20T07:00:00Z",
+						"substatus": {"code": "out_for_delivery"}},
+					{"status": "DELIVERED", "status_details": "Delivered", "status_date": "2026-10-20T12:30:00Z"},
+					{"status": "RETURNED", "status_details": "Returned to sender", "status_date": "2026-10-21T10:00:00Z"}
+				]
+			}
+		}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "GLS123", update.TrackingNumber)
+		// The UNKNOWN status is skipped
+		assert.Len(t, update.Events, 3)
+		assert.Equal(t, entity.TrackingStatusOutForDelivery, update.Events[0].Status)
+		assert.Equal(t, entity.TrackingStatusDelivered, update.Events[1].Status)
+		assert.Equal(t, entity.TrackingStatusException, update.Events[2].Status)
+	})
+
+	t.Run("Other event", func(t *testing.T) {
+		_, err := shippo.ParseWebhook([]byte(`{"event": "transaction_created", "data": {}}`))
+
+		assert.EqualError(t, err, `unsupported webhook event "transaction_created"`)
+	})
+}
+
+func TestFakeTrackingProvider(t *testing.T) {
+	provider := carrier.NewFakeTrackingProvider()
+	provider.AddEvent("FAKE123", entity.TrackingStatusInTransit, "Parcel sorted")
+
+	update, err := provider.GetTracking("", "FAKE123")
+	assert.NoError(t, err)
+	assert.Len(t, update.Events, 1)
+	assert.False(t, update.Events[0].OccurredAt.IsZero())
+
+	_, err = provider.GetTracking("", "FAKE999")
+	assert.EqualError(t, err, "tracking number not found")
+
+	update, err = provider.ParseWebhook([]byte(`{"tracking_number": "FAKE123", "events": [{"status": "delivered", "description": "Delivered"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.TrackingStatusDelivered, update.Events[0].Status)
+	assert.False(t, update.Events[0].OccurredAt.IsZero())
+
+	_, err = provider.ParseWebhook([]byte(`{"events": []}`))
+	assert.EqualError(t, err, "tracking number is required")
+}
@@ -0,0 +1,175 @@
+package carrier
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// FakeCarrierService is a service of the fake carrier, priced per started kg of billable weight
+type FakeCarrierService struct {
+	Code          string
+	Name          string
+	BaseAmount    int64 // in cents
+	AmountPerKg   int64 // in cents
+	EstimatedDays int
+}
+
+// FakeCarrier is an in-memory carrier for development and testing.
+// It quotes a "standard" and an "express" service and tracks the labels it created.
+type FakeCarrier struct {
+	mu          sync.RWMutex
+	currency    string
+	services    []FakeCarrierService
+	tracking    map[string]*service.CarrierTracking
+	lastLabel   int
+	unavailable bool
+}
+
+// NewFakeCarrier creates a new FakeCarrier quoting in a currency
+func NewFakeCarrier(currency string) *FakeCarrier {
+	return &FakeCarrier{
+		currency: strings.ToUpper(currency),
+		services: []FakeCarrierService{
+			{Code: "standard", Name: "Fake Standard", BaseAmount: 500, AmountPerKg: 100, EstimatedDays: 3},
+			{Code: "express", Name: "Fake Express", BaseAmount: 1500, AmountPerKg: 250, EstimatedDays: 1},
+		},
+		tracking: make(map[string]*service.CarrierTracking),
+	}
+}
+
+// Name returns the name shipping methods refer to the carrier by
+func (c *FakeCarrier) Name() string {
+	return "fake"
+}
+
+// SetServices replaces the services the carrier quotes
+func (c *FakeCarrier) SetServices(services []FakeCarrierService) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.services = services
+}
+
+// SetUnavailable simulates a carrier outage
+func (c *FakeCarrier) SetUnavailable(unavailable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.unavailable = unavailable
+}
+
+// SetTrackingStatus adds a tracking event to a label the carrier created
+func (c *FakeCarrier) SetTrackingStatus(trackingNumber, status, description string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tracking, ok := c.tracking[trackingNumber]
+	if !ok {
+		return errors.New("tracking number not found")
+	}
+
+	tracking.Status = status
+	tracking.Events = append(tracking.Events, service.CarrierTrackingEvent{
+		Status:      status,
+		Description: description,
+		OccurredAt:  time.Now(),
+	})
+	return nil
+}
+
+// GetRates quotes every service for the billable weight of the shipment
+func (c *FakeCarrier) GetRates(shipment service.CarrierShipment) ([]service.CarrierRate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.unavailable {
+		return nil, errors.New("carrier is unavailable")
+	}
+
+	weight := shipment.Weight
+	if len(shipment.Packages) > 0 {
+		weight = entity.BillableWeight(shipment.Packages)
+	}
+
+	rates := make([]service.CarrierRate, 0, len(c.services))
+	for _, svc := range c.services {
+		rates = append(rates, service.CarrierRate{
+			ServiceCode:   svc.Code,
+			ServiceName:   svc.Name,
+			Amount:        svc.BaseAmount + svc.AmountPerKg*int64(math.Ceil(weight)),
+			Currency:      c.currency,
+			EstimatedDays: svc.EstimatedDays,
+		})
+	}
+
+	return rates, nil
+}
+
+// CreateLabel creates a label with a FAKE tracking number
+func (c *FakeCarrier) CreateLabel(shipment service.CarrierShipment, serviceCode string) (*entity.ShippingLabel, error) {
+	rates, err := c.GetRates(shipment)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *service.CarrierRate
+	for i, rate := range rates {
+		if rate.ServiceCode == serviceCode {
+			selected = &rates[i]
+			break
+		}
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("carrier service %s is not available for this shipment", serviceCode)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastLabel++
+	trackingNumber := fmt.Sprintf("FAKE%010d", c.lastLabel)
+	now := time.Now()
+	c.tracking[trackingNumber] = &service.CarrierTracking{
+		TrackingNumber: trackingNumber,
+		Status:         "PRE_TRANSIT",
+		Events: []service.CarrierTrackingEvent{
+			{Status: "PRE_TRANSIT", Description: "Label created", OccurredAt: now},
+		},
+	}
+
+	return &entity.ShippingLabel{
+		Carrier:        c.Name(),
+		ServiceCode:    serviceCode,
+		TrackingNumber: trackingNumber,
+		LabelURL:       "https://carrier.example/labels/" + trackingNumber + ".pdf",
+		Cost:           selected.Amount,
+		Currency:       c.currency,
+		CreatedAt:      now,
+	}, nil
+}
+
+// Track returns the events of a label the carrier created
+func (c *FakeCarrier) Track(label entity.ShippingLabel) (*service.CarrierTracking, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.unavailable {
+		return nil, errors.New("carrier is unavailable")
+	}
+
+	tracking, ok := c.tracking[label.TrackingNumber]
+	if !ok {
+		return nil, errors.New("tracking number not found")
+	}
+
+	result := *tracking
+	result.Events = append([]service.CarrierTrackingEvent(nil), tracking.Events...)
+	return &result, nil
+}
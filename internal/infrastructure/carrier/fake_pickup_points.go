@@ -0,0 +1,74 @@
+package carrier
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// FakePickupPointProvider is a pickup point provider for development and testing.
+// It returns the same pickup points for a postal code every time, so their IDs stay valid across restarts.
+type FakePickupPointProvider struct {
+	count int
+}
+
+// NewFakePickupPointProvider creates a new FakePickupPointProvider with five pickup points per postal code
+func NewFakePickupPointProvider() *FakePickupPointProvider {
+	return &FakePickupPointProvider{count: 5}
+}
+
+// FindPickupPoints returns the pickup points of a postal code, nearest first
+func (p *FakePickupPointProvider) FindPickupPoints(query service.PickupPointQuery) ([]entity.PickupPoint, error) {
+	postalCode := strings.TrimSpace(query.PostalCode)
+	if postalCode == "" || strings.Contains(postalCode, ":") {
+		return nil, errors.New("invalid postal code")
+	}
+
+	count := p.count
+	if query.Limit > 0 && query.Limit < count {
+		count = query.Limit
+	}
+
+	points := make([]entity.PickupPoint, 0, count)
+	for i := 1; i <= count; i++ {
+		points = append(points, fakePickupPoint(strings.ToUpper(query.Country), postalCode, i))
+	}
+
+	return points, nil
+}
+
+// GetPickupPoint returns a pickup point by the ID FindPickupPoints gave it
+func (p *FakePickupPointProvider) GetPickupPoint(id string) (*entity.PickupPoint, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return nil, errors.New("pickup point not found")
+	}
+
+	number, err := strconv.Atoi(parts[2])
+	if err != nil || number < 1 || number > p.count || parts[1] == "" {
+		return nil, errors.New("pickup point not found")
+	}
+
+	point := fakePickupPoint(parts[0], parts[1], number)
+	return &point, nil
+}
+
+func fakePickupPoint(country, postalCode string, number int) entity.PickupPoint {
+	return entity.PickupPoint{
+		ID:   fmt.Sprintf("%s:%s:%d", country, postalCode, number),
+		Name: fmt.Sprintf("Parcel Shop %d", number),
+		Address: entity.Address{
+			Street:     fmt.Sprintf("Pickup Street %d", number),
+			City:       "Pickup Town",
+			PostalCode: postalCode,
+			Country:    country,
+		},
+		Carrier:      "fake",
+		Distance:     number * 350,
+		OpeningHours: []string{"Mon-Fri 08:00-20:00", "Sat-Sun 10:00-16:00"},
+	}
+}
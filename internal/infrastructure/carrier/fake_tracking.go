@@ -0,0 +1,99 @@
+package carrier
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// FakeTrackingProvider is an in-memory tracking provider for development and testing.
+// Events are added with AddEvent or by posting them to the tracking webhook.
+type FakeTrackingProvider struct {
+	mu     sync.RWMutex
+	events map[string][]service.TrackingUpdateEvent
+}
+
+// fakeTrackingWebhook is the payload of the fake carrier's tracking webhook
+type fakeTrackingWebhook struct {
+	TrackingNumber string `json:"tracking_number"`
+	Events         []struct {
+		Status      entity.TrackingStatus `json:"status"`
+		Description string                `json:"description"`
+		Location    string                `json:"location"`
+		OccurredAt  time.Time             `json:"occurred_at"`
+	} `json:"events"`
+}
+
+// NewFakeTrackingProvider creates a new FakeTrackingProvider
+func NewFakeTrackingProvider() *FakeTrackingProvider {
+	return &FakeTrackingProvider{
+		events: make(map[string][]service.TrackingUpdateEvent),
+	}
+}
+
+// Name returns the name of the provider
+func (p *FakeTrackingProvider) Name() string {
+	return "fake"
+}
+
+// AddEvent simulates a carrier scan of a shipment
+func (p *FakeTrackingProvider) AddEvent(trackingNumber string, status entity.TrackingStatus, description string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events[trackingNumber] = append(p.events[trackingNumber], service.TrackingUpdateEvent{
+		Status:      status,
+		Description: description,
+		OccurredAt:  time.Now(),
+	})
+}
+
+// GetTracking returns the events added for a shipment
+func (p *FakeTrackingProvider) GetTracking(carrierCode, trackingNumber string) (*service.TrackingUpdate, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	events, ok := p.events[trackingNumber]
+	if !ok {
+		return nil, errors.New("tracking number not found")
+	}
+
+	return &service.TrackingUpdate{
+		TrackingNumber: trackingNumber,
+		Events:         append([]service.TrackingUpdateEvent(nil), events...),
+	}, nil
+}
+
+// ParseWebhook parses a webhook with the tracking number and events of a shipment
+func (p *FakeTrackingProvider) ParseWebhook(payload []byte) (*service.TrackingUpdate, error) {
+	var webhook fakeTrackingWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return nil, err
+	}
+	if webhook.TrackingNumber == "" {
+		return nil, errors.New("tracking number is required")
+	}
+
+	update := &service.TrackingUpdate{
+		TrackingNumber: webhook.TrackingNumber,
+		Events:         make([]service.TrackingUpdateEvent, 0, len(webhook.Events)),
+	}
+	for _, event := range webhook.Events {
+		occurredAt := event.OccurredAt
+		if occurredAt.IsZero() {
+			occurredAt = time.Now()
+		}
+		update.Events = append(update.Events, service.TrackingUpdateEvent{
+			Status:      event.Status,
+			Description: event.Description,
+			Location:    event.Location,
+			OccurredAt:  occurredAt,
+		})
+	}
+
+	return update, nil
+}
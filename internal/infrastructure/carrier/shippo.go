@@ -0,0 +1,451 @@
+package carrier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// ShippoURL is the base URL of the Shippo API
+const ShippoURL = "https://api.goshippo.com"
+
+// Sender is the address parcels are shipped from
+type Sender struct {
+	Name    string
+	Email   string
+	Phone   string
+	Address entity.Address
+}
+
+// ShippoCarrier quotes rates, buys labels and tracks parcels through the Shippo API,
+// which aggregates carriers like USPS, UPS, DHL, GLS and PostNord.
+// It also provides tracking, polled or received through Shippo's track_updated webhooks.
+type ShippoCarrier struct {
+	baseURL  string
+	apiKey   string
+	sender   Sender
+	currency string
+	client   *http.Client
+}
+
+// NewShippoCarrier creates a new ShippoCarrier.
+// Quotes in other currencies than the store's currency are ignored.
+// An empty base URL uses the Shippo API.
+func NewShippoCarrier(baseURL, apiKey string, sender Sender, currency string) *ShippoCarrier {
+	if baseURL == "" {
+		baseURL = ShippoURL
+	}
+
+	return &ShippoCarrier{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		apiKey:   apiKey,
+		sender:   sender,
+		currency: strings.ToUpper(currency),
+		client:   &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+// Name returns the name shipping methods refer to the carrier by
+func (c *ShippoCarrier) Name() string {
+	return "shippo"
+}
+
+// shippoAddress mirrors the Shippo address object
+type shippoAddress struct {
+	Name    string `json:"name"`
+	Company string `json:"company,omitempty"`
+	Street1 string `json:"street1"`
+	City    string `json:"city"`
+	State   string `json:"state,omitempty"`
+	Zip     string `json:"zip"`
+	Country string `json:"country"`
+	Email   string `json:"email,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+}
+
+// shippoParcel mirrors the Shippo parcel object; Shippo takes measurements as strings
+type shippoParcel struct {
+	Length       string `json:"length"`
+	Width        string `json:"width"`
+	Height       string `json:"height"`
+	DistanceUnit string `json:"distance_unit"`
+	Weight       string `json:"weight"`
+	MassUnit     string `json:"mass_unit"`
+}
+
+type shippoShipmentRequest struct {
+	AddressFrom shippoAddress  `json:"address_from"`
+	AddressTo   shippoAddress  `json:"address_to"`
+	Parcels     []shippoParcel `json:"parcels"`
+	Metadata    string         `json:"metadata,omitempty"`
+	Async       bool           `json:"async"`
+}
+
+type shippoRate struct {
+	ObjectID      string `json:"object_id"`
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+	Provider      string `json:"provider"`
+	EstimatedDays int    `json:"estimated_days"`
+	ServiceLevel  struct {
+		Name  string `json:"name"`
+		Token string `json:"token"`
+	} `json:"servicelevel"`
+}
+
+type shippoShipmentResponse struct {
+	ObjectID string       `json:"object_id"`
+	Rates    []shippoRate `json:"rates"`
+}
+
+type shippoTransactionRequest struct {
+	Rate          string `json:"rate"`
+	LabelFileType string `json:"label_file_type"`
+	Async         bool   `json:"async"`
+}
+
+type shippoTransactionResponse struct {
+	Status         string `json:"status"`
+	TrackingNumber string `json:"tracking_number"`
+	TrackingURL    string `json:"tracking_url_provider"`
+	LabelURL       string `json:"label_url"`
+	Messages       []struct {
+		Text string `json:"text"`
+	} `json:"messages"`
+}
+
+type shippoTrackingStatus struct {
+	Status        string    `json:"status"`
+	StatusDetails string    `json:"status_details"`
+	StatusDate    time.Time `json:"status_date"`
+	Substatus     *struct {
+		Code string `json:"code"`
+	} `json:"substatus"`
+	Location *struct {
+		City    string `json:"city"`
+		Country string `json:"country"`
+	} `json:"location"`
+}
+
+type shippoTrackResponse struct {
+	TrackingNumber  string                 `json:"tracking_number"`
+	TrackingStatus  *shippoTrackingStatus  `json:"tracking_status"`
+	TrackingHistory []shippoTrackingStatus `json:"tracking_history"`
+}
+
+type shippoWebhook struct {
+	Event string              `json:"event"`
+	Data  shippoTrackResponse `json:"data"`
+}
+
+// GetRates returns quotes for the services that can ship the shipment
+func (c *ShippoCarrier) GetRates(shipment service.CarrierShipment) ([]service.CarrierRate, error) {
+	response, err := c.createShipment(shipment)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]service.CarrierRate, 0, len(response.Rates))
+	for _, rate := range response.Rates {
+		amount, err := c.amount(rate)
+		if err != nil {
+			continue
+		}
+		rates = append(rates, service.CarrierRate{
+			ServiceCode:   rate.ServiceLevel.Token,
+			ServiceName:   strings.TrimSpace(rate.Provider + " " + rate.ServiceLevel.Name),
+			Amount:        amount,
+			Currency:      c.currency,
+			EstimatedDays: rate.EstimatedDays,
+		})
+	}
+
+	return rates, nil
+}
+
+// CreateLabel buys a label for the shipment with one of the services quoted for it
+func (c *ShippoCarrier) CreateLabel(shipment service.CarrierShipment, serviceCode string) (*entity.ShippingLabel, error) {
+	response, err := c.createShipment(shipment)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *shippoRate
+	for i, rate := range response.Rates {
+		if rate.ServiceLevel.Token == serviceCode {
+			selected = &response.Rates[i]
+			break
+		}
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("carrier service %s is not available for this shipment", serviceCode)
+	}
+
+	cost, err := c.amount(*selected)
+	if err != nil {
+		return nil, err
+	}
+
+	var transaction shippoTransactionResponse
+	err = c.do(http.MethodPost, "/transactions/", shippoTransactionRequest{
+		Rate:          selected.ObjectID,
+		LabelFileType: "PDF",
+		Async:         false,
+	}, &transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+
+	if transaction.Status != "SUCCESS" {
+		messages := make([]string, 0, len(transaction.Messages))
+		for _, message := range transaction.Messages {
+			messages = append(messages, message.Text)
+		}
+		return nil, fmt.Errorf("failed to create label: %s %s", transaction.Status, strings.Join(messages, "; "))
+	}
+
+	return &entity.ShippingLabel{
+		Carrier:        c.Name(),
+		CarrierCode:    strings.ToLower(selected.Provider),
+		ServiceCode:    serviceCode,
+		TrackingNumber: transaction.TrackingNumber,
+		TrackingURL:    transaction.TrackingURL,
+		LabelURL:       transaction.LabelURL,
+		Cost:           cost,
+		Currency:       c.currency,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// Track returns the tracking status of a label
+func (c *ShippoCarrier) Track(label entity.ShippingLabel) (*service.CarrierTracking, error) {
+	response, err := c.track(label.CarrierCode, label.TrackingNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	tracking := &service.CarrierTracking{
+		TrackingNumber: label.TrackingNumber,
+		Events:         make([]service.CarrierTrackingEvent, 0, len(response.TrackingHistory)),
+	}
+	if response.TrackingStatus != nil {
+		tracking.Status = response.TrackingStatus.Status
+	}
+	for _, status := range response.TrackingHistory {
+		tracking.Events = append(tracking.Events, service.CarrierTrackingEvent{
+			Status:      status.Status,
+			Description: status.StatusDetails,
+			Location:    status.location(),
+			OccurredAt:  status.StatusDate,
+		})
+	}
+
+	return tracking, nil
+}
+
+// GetTracking polls Shippo for the tracking of a shipment, carrierCode is Shippo's carrier token, e.g. "usps"
+func (c *ShippoCarrier) GetTracking(carrierCode, trackingNumber string) (*service.TrackingUpdate, error) {
+	response, err := c.track(carrierCode, trackingNumber)
+	if err != nil {
+		return nil, err
+	}
+	return response.update(), nil
+}
+
+// ParseWebhook parses a Shippo track_updated webhook
+func (c *ShippoCarrier) ParseWebhook(payload []byte) (*service.TrackingUpdate, error) {
+	var webhook shippoWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return nil, err
+	}
+	if webhook.Event != "track_updated" {
+		return nil, fmt.Errorf("unsupported webhook event %q", webhook.Event)
+	}
+	if webhook.Data.TrackingNumber == "" {
+		return nil, errors.New("tracking number is required")
+	}
+
+	return webhook.Data.update(), nil
+}
+
+func (c *ShippoCarrier) track(carrierCode, trackingNumber string) (*shippoTrackResponse, error) {
+	path := fmt.Sprintf("/tracks/%s/%s", url.PathEscape(carrierCode), url.PathEscape(trackingNumber))
+
+	var response shippoTrackResponse
+	if err := c.do(http.MethodGet, path, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to track parcel: %w", err)
+	}
+	return &response, nil
+}
+
+// update converts Shippo's tracking history, skipping events with an unknown status
+func (r *shippoTrackResponse) update() *service.TrackingUpdate {
+	update := &service.TrackingUpdate{
+		TrackingNumber: r.TrackingNumber,
+		Events:         make([]service.TrackingUpdateEvent, 0, len(r.TrackingHistory)),
+	}
+	for _, status := range r.TrackingHistory {
+		trackingStatus, ok := status.trackingStatus()
+		if !ok {
+			continue
+		}
+		update.Events = append(update.Events, service.TrackingUpdateEvent{
+			Status:      trackingStatus,
+			Description: status.StatusDetails,
+			Location:    status.location(),
+			OccurredAt:  status.StatusDate,
+		})
+	}
+	return update
+}
+
+// trackingStatus maps Shippo's tracking status, which reports parcels out for delivery as a substatus of TRANSIT
+func (s shippoTrackingStatus) trackingStatus() (entity.TrackingStatus, bool) {
+	switch s.Status {
+	case "PRE_TRANSIT":
+		return entity.TrackingStatusPreTransit, true
+	case "TRANSIT":
+		if s.Substatus != nil && s.Substatus.Code == "out_for_delivery" {
+			return entity.TrackingStatusOutForDelivery, true
+		}
+		return entity.TrackingStatusInTransit, true
+	case "DELIVERED":
+		return entity.TrackingStatusDelivered, true
+	case "RETURNED", "FAILURE":
+		return entity.TrackingStatusException, true
+	}
+	return "", false
+}
+
+func (s shippoTrackingStatus) location() string {
+	if s.Location == nil {
+		return ""
+	}
+	return strings.Trim(s.Location.City+", "+s.Location.Country, ", ")
+}
+
+func (c *ShippoCarrier) createShipment(shipment service.CarrierShipment) (*shippoShipmentResponse, error) {
+	request := shippoShipmentRequest{
+		AddressFrom: shippoAddress{
+			Name:    c.sender.Name,
+			Company: c.sender.Address.Company,
+			Street1: c.sender.Address.Street,
+			City:    c.sender.Address.City,
+			State:   c.sender.Address.State,
+			Zip:     c.sender.Address.PostalCode,
+			Country: c.sender.Address.Country,
+			Email:   c.sender.Email,
+			Phone:   c.sender.Phone,
+		},
+		AddressTo: shippoAddress{
+			Name:    shipment.Recipient,
+			Company: shipment.To.Company,
+			Street1: shipment.To.Street,
+			City:    shipment.To.City,
+			State:   shipment.To.State,
+			Zip:     shipment.To.PostalCode,
+			Country: shipment.To.Country,
+			Email:   shipment.Email,
+			Phone:   shipment.Phone,
+		},
+		Parcels:  shippoParcels(shipment),
+		Metadata: shipment.Reference,
+		Async:    false,
+	}
+
+	var response shippoShipmentResponse
+	if err := c.do(http.MethodPost, "/shipments/", request, &response); err != nil {
+		return nil, fmt.Errorf("failed to get carrier rates: %w", err)
+	}
+
+	return &response, nil
+}
+
+// amount converts a quote to cents, refusing quotes in other currencies
+func (c *ShippoCarrier) amount(rate shippoRate) (int64, error) {
+	if !strings.EqualFold(rate.Currency, c.currency) {
+		return 0, fmt.Errorf("carrier quoted in %s instead of %s", rate.Currency, c.currency)
+	}
+
+	value, err := strconv.ParseFloat(rate.Amount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid carrier amount %q", rate.Amount)
+	}
+
+	return money.FromDecimal(value, c.currency).Amount, nil
+}
+
+func (c *ShippoCarrier) do(method, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "ShippoToken "+c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// shippoParcels describes the packages of a shipment, or one parcel of its weight when it has none
+func shippoParcels(shipment service.CarrierShipment) []shippoParcel {
+	measure := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	if len(shipment.Packages) == 0 {
+		return []shippoParcel{{
+			Length:       "0",
+			Width:        "0",
+			Height:       "0",
+			DistanceUnit: "cm",
+			Weight:       measure(shipment.Weight),
+			MassUnit:     "kg",
+		}}
+	}
+
+	parcels := make([]shippoParcel, 0, len(shipment.Packages))
+	for _, pkg := range shipment.Packages {
+		parcels = append(parcels, shippoParcel{
+			Length:       measure(pkg.Dimensions.Length),
+			Width:        measure(pkg.Dimensions.Width),
+			Height:       measure(pkg.Dimensions.Height),
+			DistanceUnit: "cm",
+			Weight:       measure(pkg.Weight),
+			MassUnit:     "kg",
+		})
+	}
+
+	return parcels
+}
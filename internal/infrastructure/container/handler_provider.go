@@ -17,6 +17,10 @@ type HandlerProvider interface {
 	DiscountHandler() *handler.DiscountHandler
 	ShippingHandler() *handler.ShippingHandler
 	CurrencyHandler() *handler.CurrencyHandler
+	PriceListHandler() *handler.PriceListHandler
+	TaxHandler() *handler.TaxHandler
+	InvoiceHandler() *handler.InvoiceHandler
+	TrackingHandler() *handler.TrackingHandler
 }
 
 // handlerProvider is the concrete implementation of HandlerProvider
@@ -24,15 +28,19 @@ type handlerProvider struct {
 	container Container
 	mu        sync.Mutex
 
-	userHandler     *handler.UserHandler
-	productHandler  *handler.ProductHandler
-	cartHandler     *handler.CartHandler
-	orderHandler    *handler.OrderHandler
-	paymentHandler  *handler.PaymentHandler
-	webhookHandler  *handler.WebhookHandler
-	discountHandler *handler.DiscountHandler
-	shippingHandler *handler.ShippingHandler
-	currencyHandler *handler.CurrencyHandler
+	userHandler      *handler.UserHandler
+	productHandler   *handler.ProductHandler
+	cartHandler      *handler.CartHandler
+	orderHandler     *handler.OrderHandler
+	paymentHandler   *handler.PaymentHandler
+	webhookHandler   *handler.WebhookHandler
+	discountHandler  *handler.DiscountHandler
+	shippingHandler  *handler.ShippingHandler
+	currencyHandler  *handler.CurrencyHandler
+	priceListHandler *handler.PriceListHandler
+	taxHandler       *handler.TaxHandler
+	invoiceHandler   *handler.InvoiceHandler
+	trackingHandler  *handler.TrackingHandler
 }
 
 // NewHandlerProvider creates a new handler provider
@@ -108,6 +116,7 @@ func (p *handlerProvider) PaymentHandler() *handler.PaymentHandler {
 	if p.paymentHandler == nil {
 		p.paymentHandler = handler.NewPaymentHandler(
 			p.container.UseCases().OrderUseCase(),
+			p.container.UseCases().PaymentMethodUseCase(),
 			p.container.Logger(),
 		)
 	}
@@ -124,6 +133,8 @@ func (p *handlerProvider) WebhookHandler() *handler.WebhookHandler {
 			p.container.Config(),
 			p.container.UseCases().OrderUseCase(),
 			p.container.UseCases().WebhookUseCase(),
+			p.container.UseCases().PaymentMethodUseCase(),
+			p.container.Services().PayPalService(),
 			p.container.Logger(),
 		)
 	}
@@ -138,6 +149,7 @@ func (p *handlerProvider) DiscountHandler() *handler.DiscountHandler {
 	if p.discountHandler == nil {
 		p.discountHandler = handler.NewDiscountHandler(
 			p.container.UseCases().DiscountUseCase(),
+			p.container.UseCases().DiscountReportUseCase(),
 			p.container.UseCases().OrderUseCase(),
 			p.container.Logger(),
 		)
@@ -153,6 +165,7 @@ func (p *handlerProvider) ShippingHandler() *handler.ShippingHandler {
 	if p.shippingHandler == nil {
 		p.shippingHandler = handler.NewShippingHandler(
 			p.container.UseCases().ShippingUseCase(),
+			p.container.UseCases().TaxUseCase(),
 			p.container.Logger(),
 		)
 	}
@@ -168,8 +181,67 @@ func (p *handlerProvider) CurrencyHandler() *handler.CurrencyHandler {
 		// Check if CurrencyUseCase exists in the UseCaseProvider
 		p.currencyHandler = handler.NewCurrencyHandler(
 			p.container.UseCases().CurrencyUsecase(),
+			p.container.UseCases().ExchangeRateUseCase(),
 			p.container.Logger(),
 		)
 	}
 	return p.currencyHandler
 }
+
+// PriceListHandler returns the price list handler
+func (p *handlerProvider) PriceListHandler() *handler.PriceListHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.priceListHandler == nil {
+		p.priceListHandler = handler.NewPriceListHandler(
+			p.container.UseCases().PriceListUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.priceListHandler
+}
+
+// TaxHandler returns the tax handler
+func (p *handlerProvider) TaxHandler() *handler.TaxHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.taxHandler == nil {
+		p.taxHandler = handler.NewTaxHandler(
+			p.container.UseCases().TaxUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.taxHandler
+}
+
+// InvoiceHandler returns the invoice handler
+func (p *handlerProvider) InvoiceHandler() *handler.InvoiceHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.invoiceHandler == nil {
+		p.invoiceHandler = handler.NewInvoiceHandler(
+			p.container.UseCases().InvoiceUseCase(),
+			p.container.UseCases().OrderUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.invoiceHandler
+}
+
+// TrackingHandler returns the shipment tracking handler
+func (p *handlerProvider) TrackingHandler() *handler.TrackingHandler {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.trackingHandler == nil {
+		p.trackingHandler = handler.NewTrackingHandler(
+			p.container.UseCases().TrackingUseCase(),
+			p.container.Config().Shipping.TrackingWebhookSecret,
+			p.container.Logger(),
+		)
+	}
+	return p.trackingHandler
+}
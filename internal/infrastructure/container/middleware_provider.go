@@ -10,6 +10,7 @@ import (
 type MiddlewareProvider interface {
 	AuthMiddleware() *middleware.AuthMiddleware
 	CorsMiddleware() *middleware.CorsMiddleware
+	IdempotencyMiddleware() *middleware.IdempotencyMiddleware
 }
 
 // middlewareProvider is the concrete implementation of MiddlewareProvider
@@ -19,6 +20,7 @@ type middlewareProvider struct {
 
 	authMiddleware *middleware.AuthMiddleware
 	corsMiddleware *middleware.CorsMiddleware
+	idempotency    *middleware.IdempotencyMiddleware
 }
 
 // NewMiddlewareProvider creates a new middleware provider
@@ -54,3 +56,17 @@ func (p *middlewareProvider) CorsMiddleware() *middleware.CorsMiddleware {
 	}
 	return p.corsMiddleware
 }
+
+// IdempotencyMiddleware returns the Idempotency-Key middleware
+func (p *middlewareProvider) IdempotencyMiddleware() *middleware.IdempotencyMiddleware {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.idempotency == nil {
+		p.idempotency = middleware.NewIdempotencyMiddleware(
+			p.container.UseCases().IdempotencyUseCase(),
+			p.container.Logger(),
+		)
+	}
+	return p.idempotency
+}
@@ -16,14 +16,27 @@ type RepositoryProvider interface {
 	OrderRepository() repository.OrderRepository
 	CartRepository() repository.CartRepository
 	DiscountRepository() repository.DiscountRepository
+	DiscountReportRepository() repository.DiscountReportRepository
 	WebhookRepository() repository.WebhookRepository
 	PaymentTransactionRepository() repository.PaymentTransactionRepository
 	CurrencyRepository() repository.CurrencyRepository
+	ExchangeRateHistoryRepository() repository.ExchangeRateHistoryRepository
+	CustomerGroupRepository() repository.CustomerGroupRepository
+	PriceListRepository() repository.PriceListRepository
+	TaxClassRepository() repository.TaxClassRepository
+	TaxRateRepository() repository.TaxRateRepository
+	InvoiceRepository() repository.InvoiceRepository
+	SavedPaymentMethodRepository() repository.SavedPaymentMethodRepository
+	IdempotencyKeyRepository() repository.IdempotencyKeyRepository
 
 	// Shipping related repository
 	ShippingMethodRepository() repository.ShippingMethodRepository
 	ShippingZoneRepository() repository.ShippingZoneRepository
 	ShippingRateRepository() repository.ShippingRateRepository
+	ShippingPackageRepository() repository.ShippingPackageRepository
+	ShippingProfileRepository() repository.ShippingProfileRepository
+	TrackingEventRepository() repository.TrackingEventRepository
+	HolidayRepository() repository.HolidayRepository
 }
 
 // repositoryProvider is the concrete implementation of RepositoryProvider
@@ -38,13 +51,26 @@ type repositoryProvider struct {
 	orderRepo          repository.OrderRepository
 	cartRepo           repository.CartRepository
 	discountRepo       repository.DiscountRepository
+	discountReportRepo repository.DiscountReportRepository
 	webhookRepo        repository.WebhookRepository
 	paymentTrxRepo     repository.PaymentTransactionRepository
 	currencyRepo       repository.CurrencyRepository
+	rateHistoryRepo    repository.ExchangeRateHistoryRepository
+	customerGroupRepo  repository.CustomerGroupRepository
+	priceListRepo      repository.PriceListRepository
+	taxClassRepo       repository.TaxClassRepository
+	taxRateRepo        repository.TaxRateRepository
+	invoiceRepo        repository.InvoiceRepository
+	paymentMethodRepo  repository.SavedPaymentMethodRepository
+	idempotencyKeyRepo repository.IdempotencyKeyRepository
 
 	shippingMethodRepo repository.ShippingMethodRepository
 	shippingZoneRepo   repository.ShippingZoneRepository
 	shippingRateRepo   repository.ShippingRateRepository
+	shippingPkgRepo    repository.ShippingPackageRepository
+	shippingProfRepo   repository.ShippingProfileRepository
+	trackingEventRepo  repository.TrackingEventRepository
+	holidayRepo        repository.HolidayRepository
 }
 
 // NewRepositoryProvider creates a new repository provider
@@ -135,6 +161,17 @@ func (p *repositoryProvider) DiscountRepository() repository.DiscountRepository
 	return p.discountRepo
 }
 
+// DiscountReportRepository returns the discount report repository
+func (p *repositoryProvider) DiscountReportRepository() repository.DiscountReportRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discountReportRepo == nil {
+		p.discountReportRepo = postgres.NewDiscountReportRepository(p.container.DB())
+	}
+	return p.discountReportRepo
+}
+
 // WebhookRepository returns the webhook repository
 func (p *repositoryProvider) WebhookRepository() repository.WebhookRepository {
 	p.mu.Lock()
@@ -190,6 +227,50 @@ func (p *repositoryProvider) ShippingRateRepository() repository.ShippingRateRep
 	return p.shippingRateRepo
 }
 
+// ShippingPackageRepository returns the shipping package repository
+func (p *repositoryProvider) ShippingPackageRepository() repository.ShippingPackageRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.shippingPkgRepo == nil {
+		p.shippingPkgRepo = postgres.NewShippingPackageRepository(p.container.DB())
+	}
+	return p.shippingPkgRepo
+}
+
+// ShippingProfileRepository returns the shipping profile repository
+func (p *repositoryProvider) ShippingProfileRepository() repository.ShippingProfileRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.shippingProfRepo == nil {
+		p.shippingProfRepo = postgres.NewShippingProfileRepository(p.container.DB())
+	}
+	return p.shippingProfRepo
+}
+
+// SavedPaymentMethodRepository returns the saved payment method repository
+func (p *repositoryProvider) SavedPaymentMethodRepository() repository.SavedPaymentMethodRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.paymentMethodRepo == nil {
+		p.paymentMethodRepo = postgres.NewSavedPaymentMethodRepository(p.container.DB())
+	}
+	return p.paymentMethodRepo
+}
+
+// IdempotencyKeyRepository returns the idempotency key repository
+func (p *repositoryProvider) IdempotencyKeyRepository() repository.IdempotencyKeyRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.idempotencyKeyRepo == nil {
+		p.idempotencyKeyRepo = postgres.NewIdempotencyKeyRepository(p.container.DB())
+	}
+	return p.idempotencyKeyRepo
+}
+
 // CurrencyRepository returns the currency repository
 func (p *repositoryProvider) CurrencyRepository() repository.CurrencyRepository {
 	p.mu.Lock()
@@ -200,3 +281,91 @@ func (p *repositoryProvider) CurrencyRepository() repository.CurrencyRepository
 	}
 	return p.currencyRepo
 }
+
+// ExchangeRateHistoryRepository returns the exchange rate history repository
+func (p *repositoryProvider) ExchangeRateHistoryRepository() repository.ExchangeRateHistoryRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.rateHistoryRepo == nil {
+		p.rateHistoryRepo = postgres.NewExchangeRateHistoryRepository(p.container.DB())
+	}
+	return p.rateHistoryRepo
+}
+
+// CustomerGroupRepository returns the customer group repository
+func (p *repositoryProvider) CustomerGroupRepository() repository.CustomerGroupRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.customerGroupRepo == nil {
+		p.customerGroupRepo = postgres.NewCustomerGroupRepository(p.container.DB())
+	}
+	return p.customerGroupRepo
+}
+
+// PriceListRepository returns the price list repository
+func (p *repositoryProvider) PriceListRepository() repository.PriceListRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.priceListRepo == nil {
+		p.priceListRepo = postgres.NewPriceListRepository(p.container.DB())
+	}
+	return p.priceListRepo
+}
+
+// TaxClassRepository returns the tax class repository
+func (p *repositoryProvider) TaxClassRepository() repository.TaxClassRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.taxClassRepo == nil {
+		p.taxClassRepo = postgres.NewTaxClassRepository(p.container.DB())
+	}
+	return p.taxClassRepo
+}
+
+// TaxRateRepository returns the tax rate repository
+func (p *repositoryProvider) TaxRateRepository() repository.TaxRateRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.taxRateRepo == nil {
+		p.taxRateRepo = postgres.NewTaxRateRepository(p.container.DB())
+	}
+	return p.taxRateRepo
+}
+
+// InvoiceRepository returns the invoice repository
+func (p *repositoryProvider) InvoiceRepository() repository.InvoiceRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.invoiceRepo == nil {
+		p.invoiceRepo = postgres.NewInvoiceRepository(p.container.DB())
+	}
+	return p.invoiceRepo
+}
+
+// TrackingEventRepository returns the tracking event repository
+func (p *repositoryProvider) TrackingEventRepository() repository.TrackingEventRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.trackingEventRepo == nil {
+		p.trackingEventRepo = postgres.NewTrackingEventRepository(p.container.DB())
+	}
+	return p.trackingEventRepo
+}
+
+// HolidayRepository returns the public holiday repository
+func (p *repositoryProvider) HolidayRepository() repository.HolidayRepository {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.holidayRepo == nil {
+		p.holidayRepo = postgres.NewHolidayRepository(p.container.DB())
+	}
+	return p.holidayRepo
+}
@@ -3,20 +3,37 @@ package container
 import (
 	"sync"
 
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/service"
 	"github.com/zenfulcode/commercify/internal/infrastructure/auth"
+	"github.com/zenfulcode/commercify/internal/infrastructure/carrier"
 	"github.com/zenfulcode/commercify/internal/infrastructure/email"
+	"github.com/zenfulcode/commercify/internal/infrastructure/exchangerate"
 	"github.com/zenfulcode/commercify/internal/infrastructure/payment"
+	"github.com/zenfulcode/commercify/internal/infrastructure/pdf"
+	"github.com/zenfulcode/commercify/internal/infrastructure/tax"
+	"github.com/zenfulcode/commercify/internal/infrastructure/vat"
 )
 
 // ServiceProvider provides access to all services
 type ServiceProvider interface {
 	JWTService() *auth.JWTService
 	PaymentService() service.PaymentService
+	PaymentVault() service.PaymentVault
+	PaymentStatusProvider() service.PaymentStatusProvider
 	WebhookService() *payment.WebhookService
 	EmailService() service.EmailService
 	MobilePayService() *payment.MobilePayPaymentService
+	PayPalService() *payment.PayPalPaymentService
 	InitializeMobilePay() *payment.MobilePayPaymentService
+	RateProvider() service.RateProvider
+	TaxCalculator() service.TaxCalculator
+	VATValidator() service.VATNumberValidator
+	InvoiceRenderer() service.InvoiceRenderer
+	Carriers() []service.CarrierService
+	PickupPointProvider() service.PickupPointProvider
+	TrackingProvider() service.TrackingProvider
 }
 
 // serviceProvider is the concrete implementation of ServiceProvider
@@ -29,6 +46,14 @@ type serviceProvider struct {
 	webhookService   *payment.WebhookService
 	emailService     service.EmailService
 	mobilePayService *payment.MobilePayPaymentService
+	payPalService    *payment.PayPalPaymentService
+	rateProvider     service.RateProvider
+	taxCalculator    service.TaxCalculator
+	vatValidator     service.VATNumberValidator
+	invoiceRenderer  service.InvoiceRenderer
+	carriers         []service.CarrierService
+	pickupPoints     service.PickupPointProvider
+	trackingProvider service.TrackingProvider
 }
 
 // NewServiceProvider creates a new service provider
@@ -58,22 +83,32 @@ func (p *serviceProvider) PaymentService() service.PaymentService {
 		multiProviderService := payment.NewMultiProviderPaymentService(p.container.Config(), p.container.Logger())
 		p.paymentService = multiProviderService
 
-		// Extract MobilePay service for webhook registration if it exists
+		// Extract the MobilePay and PayPal services for webhook handling if they exist
 		// We need to access the actual MultiProviderPaymentService concrete type
 		// to access its GetProviders method
 		for _, providerWithService := range multiProviderService.GetProviders() {
-			if providerWithService.Type == service.PaymentProviderMobilePay {
-				// Cast the generic service to the concrete MobilePayPaymentService type
-				if mobilePayService, ok := providerWithService.Service.(*payment.MobilePayPaymentService); ok {
-					p.mobilePayService = mobilePayService
-					break
-				}
+			switch providerService := providerWithService.Service.(type) {
+			case *payment.MobilePayPaymentService:
+				p.mobilePayService = providerService
+			case *payment.PayPalPaymentService:
+				p.payPalService = providerService
 			}
 		}
 	}
 	return p.paymentService
 }
 
+// PaymentVault returns the customers and saved payment methods at payment providers
+func (p *serviceProvider) PaymentVault() service.PaymentVault {
+	// The payment service manages customers at the providers that save payment methods
+	return p.PaymentService().(*payment.MultiProviderPaymentService)
+}
+
+// PaymentStatusProvider returns the lookup of payments at the payment providers
+func (p *serviceProvider) PaymentStatusProvider() service.PaymentStatusProvider {
+	return p.PaymentService().(*payment.MultiProviderPaymentService)
+}
+
 // InitializeMobilePay directly initializes the MobilePay service to break circular dependency
 func (p *serviceProvider) InitializeMobilePay() *payment.MobilePayPaymentService {
 	if !p.container.Config().MobilePay.Enabled {
@@ -96,6 +131,17 @@ func (p *serviceProvider) MobilePayService() *payment.MobilePayPaymentService {
 	return p.mobilePayService
 }
 
+// PayPalService returns the PayPal payment service, or nil when PayPal is not enabled
+func (p *serviceProvider) PayPalService() *payment.PayPalPaymentService {
+	// The PayPal service is created with the payment service
+	p.PaymentService()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.payPalService
+}
+
 // WebhookService returns the webhook service
 func (p *serviceProvider) WebhookService() *payment.WebhookService {
 	p.mu.Lock()
@@ -124,3 +170,123 @@ func (p *serviceProvider) EmailService() service.EmailService {
 	}
 	return p.emailService
 }
+
+// RateProvider returns the exchange rate provider
+func (p *serviceProvider) RateProvider() service.RateProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.rateProvider == nil {
+		p.rateProvider = exchangerate.NewECBRateProvider(p.container.Config().ExchangeRate.FeedURL)
+	}
+	return p.rateProvider
+}
+
+// TaxCalculator returns the tax calculator
+func (p *serviceProvider) TaxCalculator() service.TaxCalculator {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.taxCalculator == nil {
+		p.taxCalculator = tax.NewRateTableCalculator(p.container.Repositories().TaxRateRepository())
+	}
+	return p.taxCalculator
+}
+
+// VATValidator returns the VAT number validator
+func (p *serviceProvider) VATValidator() service.VATNumberValidator {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.vatValidator == nil {
+		taxConfig := p.container.Config().Tax
+		switch taxConfig.VATValidator {
+		case "vies":
+			p.vatValidator = vat.NewVIESValidator(vat.NewHTTPVIESClient(taxConfig.VIESURL))
+		case "fake":
+			p.vatValidator = vat.NewVIESValidator(vat.NewFakeVIESClient())
+		default:
+			p.vatValidator = vat.NewFormatValidator()
+		}
+	}
+	return p.vatValidator
+}
+
+// InvoiceRenderer returns the invoice renderer
+func (p *serviceProvider) InvoiceRenderer() service.InvoiceRenderer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.invoiceRenderer == nil {
+		invoiceConfig := p.container.Config().Invoice
+		p.invoiceRenderer = pdf.NewInvoiceRenderer(pdf.SellerDetails{
+			Name:    invoiceConfig.SellerName,
+			Address: invoiceConfig.SellerAddress,
+			VATID:   invoiceConfig.SellerVATID,
+		}, p.container.Config().Email.Locale)
+	}
+	return p.invoiceRenderer
+}
+
+// Carriers returns the carriers shipping methods can be backed by
+func (p *serviceProvider) Carriers() []service.CarrierService {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.carriers == nil {
+		cfg := p.container.Config()
+		switch cfg.Shipping.Carrier {
+		case "shippo":
+			p.carriers = []service.CarrierService{newShippoCarrier(cfg)}
+		case "fake":
+			p.carriers = []service.CarrierService{carrier.NewFakeCarrier(cfg.DefaultCurrency)}
+		default:
+			p.carriers = []service.CarrierService{}
+		}
+	}
+	return p.carriers
+}
+
+// PickupPointProvider returns the pickup point provider, or nil if none is configured
+func (p *serviceProvider) PickupPointProvider() service.PickupPointProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pickupPoints == nil && p.container.Config().Shipping.PickupPointProvider == "fake" {
+		p.pickupPoints = carrier.NewFakePickupPointProvider()
+	}
+	return p.pickupPoints
+}
+
+// TrackingProvider returns the shipment tracking provider, or nil if none is configured
+func (p *serviceProvider) TrackingProvider() service.TrackingProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.trackingProvider == nil {
+		cfg := p.container.Config()
+		switch cfg.Shipping.TrackingProvider {
+		case "shippo":
+			p.trackingProvider = newShippoCarrier(cfg)
+		case "fake":
+			p.trackingProvider = carrier.NewFakeTrackingProvider()
+		}
+	}
+	return p.trackingProvider
+}
+
+// newShippoCarrier creates the Shippo client, which quotes rates and buys labels as well as tracking parcels
+func newShippoCarrier(cfg *config.Config) *carrier.ShippoCarrier {
+	sender := carrier.Sender{
+		Name:  cfg.Shipping.SenderName,
+		Email: cfg.Shipping.SenderEmail,
+		Phone: cfg.Shipping.SenderPhone,
+		Address: entity.Address{
+			Street:     cfg.Shipping.SenderStreet,
+			City:       cfg.Shipping.SenderCity,
+			PostalCode: cfg.Shipping.SenderPostalCode,
+			Country:    cfg.Shipping.SenderCountry,
+		},
+	}
+	return carrier.NewShippoCarrier(cfg.Shipping.ShippoURL, cfg.Shipping.ShippoAPIKey, sender, cfg.DefaultCurrency)
+}
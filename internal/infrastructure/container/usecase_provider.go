@@ -2,7 +2,9 @@ package container
 
 import (
 	"sync"
+	"time"
 
+	"github.com/zenfulcode/commercify/config"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 )
 
@@ -13,9 +15,18 @@ type UseCaseProvider interface {
 	CartUseCase() *usecase.CartUseCase
 	OrderUseCase() *usecase.OrderUseCase
 	DiscountUseCase() *usecase.DiscountUseCase
+	DiscountReportUseCase() *usecase.DiscountReportUseCase
 	WebhookUseCase() *usecase.WebhookUseCase
 	ShippingUseCase() *usecase.ShippingUseCase
 	CurrencyUsecase() *usecase.CurrencyUseCase
+	ExchangeRateUseCase() *usecase.ExchangeRateUseCase
+	PriceListUseCase() *usecase.PriceListUseCase
+	TaxUseCase() *usecase.TaxUseCase
+	InvoiceUseCase() *usecase.InvoiceUseCase
+	PaymentMethodUseCase() *usecase.PaymentMethodUseCase
+	IdempotencyUseCase() *usecase.IdempotencyUseCase
+	TrackingUseCase() *usecase.TrackingUseCase
+	ReconciliationUseCase() *usecase.ReconciliationUseCase
 }
 
 // useCaseProvider is the concrete implementation of UseCaseProvider
@@ -23,14 +34,23 @@ type useCaseProvider struct {
 	container Container
 	mu        sync.Mutex
 
-	userUseCase     *usecase.UserUseCase
-	productUseCase  *usecase.ProductUseCase
-	cartUseCase     *usecase.CartUseCase
-	orderUseCase    *usecase.OrderUseCase
-	discountUseCase *usecase.DiscountUseCase
-	webhookUseCase  *usecase.WebhookUseCase
-	shippingUseCase *usecase.ShippingUseCase
-	currencyUseCase *usecase.CurrencyUseCase
+	userUseCase           *usecase.UserUseCase
+	productUseCase        *usecase.ProductUseCase
+	cartUseCase           *usecase.CartUseCase
+	orderUseCase          *usecase.OrderUseCase
+	discountUseCase       *usecase.DiscountUseCase
+	discountReportUseCase *usecase.DiscountReportUseCase
+	webhookUseCase        *usecase.WebhookUseCase
+	shippingUseCase       *usecase.ShippingUseCase
+	currencyUseCase       *usecase.CurrencyUseCase
+	exchangeRateUseCase   *usecase.ExchangeRateUseCase
+	priceListUseCase      *usecase.PriceListUseCase
+	taxUseCase            *usecase.TaxUseCase
+	invoiceUseCase        *usecase.InvoiceUseCase
+	paymentMethodUseCase  *usecase.PaymentMethodUseCase
+	idempotencyUseCase    *usecase.IdempotencyUseCase
+	trackingUseCase       *usecase.TrackingUseCase
+	reconciliationUseCase *usecase.ReconciliationUseCase
 }
 
 // NewUseCaseProvider creates a new use case provider
@@ -64,6 +84,7 @@ func (p *useCaseProvider) ProductUseCase() *usecase.ProductUseCase {
 			p.container.Repositories().CategoryRepository(),
 			p.container.Repositories().ProductVariantRepository(),
 			p.container.Repositories().CurrencyRepository(),
+			p.PriceListUsecase(), // Use non-locking helper method
 		)
 	}
 	return p.productUseCase
@@ -78,6 +99,8 @@ func (p *useCaseProvider) CartUseCase() *usecase.CartUseCase {
 		p.cartUseCase = usecase.NewCartUseCase(
 			p.container.Repositories().CartRepository(),
 			p.container.Repositories().ProductRepository(),
+			p.DiscountUsecase(),  // Use non-locking helper method
+			p.PriceListUsecase(), // Use non-locking helper method
 		)
 	}
 	return p.cartUseCase
@@ -99,6 +122,11 @@ func (p *useCaseProvider) OrderUseCase() *usecase.OrderUseCase {
 			p.container.Repositories().PaymentTransactionRepository(),
 			p.ShippingUsecase(), // Use non-locking helper method
 			p.container.Repositories().CurrencyRepository(),
+			p.DiscountUsecase(),      // Use non-locking helper method
+			p.PriceListUsecase(),     // Use non-locking helper method
+			p.TaxUsecase(),           // Use non-locking helper method
+			p.InvoiceUsecase(),       // Use non-locking helper method
+			p.PaymentMethodUsecase(), // Use non-locking helper method
 		)
 	}
 	return p.orderUseCase
@@ -109,17 +137,41 @@ func (p *useCaseProvider) DiscountUseCase() *usecase.DiscountUseCase {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.discountUseCase == nil {
+		p.discountUseCase = p.DiscountUsecase()
+	}
+	return p.discountUseCase
+}
+
+// DiscountUsecase initializes the discount use case without locking
+// Used to break circular dependencies
+func (p *useCaseProvider) DiscountUsecase() *usecase.DiscountUseCase {
 	if p.discountUseCase == nil {
 		p.discountUseCase = usecase.NewDiscountUseCase(
 			p.container.Repositories().DiscountRepository(),
 			p.container.Repositories().ProductRepository(),
 			p.container.Repositories().CategoryRepository(),
 			p.container.Repositories().OrderRepository(),
+			p.ShippingUsecase(), // Use non-locking helper method
 		)
 	}
 	return p.discountUseCase
 }
 
+// DiscountReportUseCase returns the discount report use case
+func (p *useCaseProvider) DiscountReportUseCase() *usecase.DiscountReportUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discountReportUseCase == nil {
+		p.discountReportUseCase = usecase.NewDiscountReportUseCase(
+			p.container.Repositories().DiscountRepository(),
+			p.container.Repositories().DiscountReportRepository(),
+		)
+	}
+	return p.discountReportUseCase
+}
+
 // WebhookUseCase returns the webhook use case
 func (p *useCaseProvider) WebhookUseCase() *usecase.WebhookUseCase {
 	p.mu.Lock()
@@ -153,6 +205,15 @@ func (p *useCaseProvider) ShippingUsecase() *usecase.ShippingUseCase {
 			p.container.Repositories().ShippingMethodRepository(),
 			p.container.Repositories().ShippingZoneRepository(),
 			p.container.Repositories().ShippingRateRepository(),
+			p.container.Repositories().ShippingPackageRepository(),
+			p.container.Repositories().ShippingProfileRepository(),
+			p.container.Repositories().CategoryRepository(),
+			p.container.Repositories().DiscountRepository(),
+			p.container.Repositories().HolidayRepository(),
+			p.container.Config().Shipping.DimensionalWeightDivisor,
+			p.container.Services().Carriers(),
+			p.container.Services().PickupPointProvider(),
+			dispatchOrigin(p.container.Config().Shipping),
 		)
 	}
 	return p.shippingUseCase
@@ -166,6 +227,7 @@ func (p *useCaseProvider) CurrencyUsecase() *usecase.CurrencyUseCase {
 	if p.currencyUseCase == nil {
 		p.currencyUseCase = usecase.NewCurrencyUseCase(
 			p.container.Repositories().CurrencyRepository(),
+			p.container.Repositories().ExchangeRateHistoryRepository(),
 		)
 
 		var defaultCurrency usecase.CurrencyInput = usecase.CurrencyInput{
@@ -181,3 +243,181 @@ func (p *useCaseProvider) CurrencyUsecase() *usecase.CurrencyUseCase {
 	}
 	return p.currencyUseCase
 }
+
+// ExchangeRateUseCase returns the exchange rate use case
+func (p *useCaseProvider) ExchangeRateUseCase() *usecase.ExchangeRateUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.exchangeRateUseCase == nil {
+		p.exchangeRateUseCase = usecase.NewExchangeRateUseCase(
+			p.container.Repositories().CurrencyRepository(),
+			p.container.Repositories().ExchangeRateHistoryRepository(),
+			p.container.Services().RateProvider(),
+			p.container.Config().ExchangeRate.MaxChangePercent,
+		)
+	}
+	return p.exchangeRateUseCase
+}
+
+// PriceListUseCase returns the price list use case
+func (p *useCaseProvider) PriceListUseCase() *usecase.PriceListUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.priceListUseCase == nil {
+		p.priceListUseCase = p.PriceListUsecase()
+	}
+	return p.priceListUseCase
+}
+
+// PriceListUsecase initializes the price list use case without locking
+// Used by the use cases that price products for customer groups
+func (p *useCaseProvider) PriceListUsecase() *usecase.PriceListUseCase {
+	if p.priceListUseCase == nil {
+		p.priceListUseCase = usecase.NewPriceListUseCase(
+			p.container.Repositories().PriceListRepository(),
+			p.container.Repositories().CustomerGroupRepository(),
+			p.container.Repositories().UserRepository(),
+			p.container.Repositories().ProductRepository(),
+			p.container.Repositories().CurrencyRepository(),
+		)
+	}
+	return p.priceListUseCase
+}
+
+// TaxUseCase returns the tax use case
+func (p *useCaseProvider) TaxUseCase() *usecase.TaxUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.taxUseCase == nil {
+		p.taxUseCase = p.TaxUsecase()
+	}
+	return p.taxUseCase
+}
+
+// TaxUsecase initializes the tax use case without locking
+// Used by the order use case to calculate order taxes
+func (p *useCaseProvider) TaxUsecase() *usecase.TaxUseCase {
+	if p.taxUseCase == nil {
+		p.taxUseCase = usecase.NewTaxUseCase(
+			p.container.Repositories().TaxClassRepository(),
+			p.container.Repositories().TaxRateRepository(),
+			p.container.Services().TaxCalculator(),
+			p.container.Services().VATValidator(),
+			p.container.Config().Tax.PricesIncludeTax,
+			p.container.Config().Tax.StoreCountry,
+		)
+	}
+	return p.taxUseCase
+}
+
+// InvoiceUseCase returns the invoice use case
+func (p *useCaseProvider) InvoiceUseCase() *usecase.InvoiceUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.invoiceUseCase == nil {
+		p.invoiceUseCase = p.InvoiceUsecase()
+	}
+	return p.invoiceUseCase
+}
+
+// InvoiceUsecase initializes the invoice use case without locking
+// Used by the order use case to invoice payments and credit refunds
+func (p *useCaseProvider) InvoiceUsecase() *usecase.InvoiceUseCase {
+	if p.invoiceUseCase == nil {
+		p.invoiceUseCase = usecase.NewInvoiceUseCase(
+			p.container.Repositories().InvoiceRepository(),
+			p.container.Repositories().OrderRepository(),
+			p.container.Repositories().UserRepository(),
+			p.container.Services().InvoiceRenderer(),
+			p.container.Services().EmailService(),
+			p.container.Config().Invoice.NumberPrefix,
+			p.container.Config().Invoice.CreditNotePrefix,
+		)
+	}
+	return p.invoiceUseCase
+}
+
+// PaymentMethodUseCase returns the saved payment method use case
+func (p *useCaseProvider) PaymentMethodUseCase() *usecase.PaymentMethodUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.paymentMethodUseCase == nil {
+		p.paymentMethodUseCase = p.PaymentMethodUsecase()
+	}
+	return p.paymentMethodUseCase
+}
+
+// PaymentMethodUsecase initializes the saved payment method use case without locking
+// Used by the order use case to charge saved payment methods
+func (p *useCaseProvider) PaymentMethodUsecase() *usecase.PaymentMethodUseCase {
+	if p.paymentMethodUseCase == nil {
+		p.paymentMethodUseCase = usecase.NewPaymentMethodUseCase(
+			p.container.Repositories().SavedPaymentMethodRepository(),
+			p.container.Repositories().UserRepository(),
+			p.container.Services().PaymentVault(),
+		)
+	}
+	return p.paymentMethodUseCase
+}
+
+// IdempotencyUseCase returns the idempotency key use case
+func (p *useCaseProvider) IdempotencyUseCase() *usecase.IdempotencyUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.idempotencyUseCase == nil {
+		p.idempotencyUseCase = usecase.NewIdempotencyUseCase(
+			p.container.Repositories().IdempotencyKeyRepository(),
+			time.Duration(p.container.Config().Server.IdempotencyKeyTTL)*time.Hour,
+		)
+	}
+	return p.idempotencyUseCase
+}
+
+// TrackingUseCase returns the shipment tracking use case
+func (p *useCaseProvider) TrackingUseCase() *usecase.TrackingUseCase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.trackingUseCase == nil {
+		p.trackingUseCase = usecase.NewTrackingUseCase(
+			p.container.Repositories().OrderRepository(),
+			p.container.Repositories().TrackingEventRepository(),
+			p.container.Services().TrackingProvider(),
+		)
+	}
+	return p.trackingUseCase
+}
+
+// ReconciliationUseCase returns the payment reconciliation use case
+func (p *useCaseProvider) ReconciliationUseCase() *usecase.ReconciliationUseCase {
+	// Resolve the order use case first, it takes the lock itself
+	orderUseCase := p.OrderUseCase()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.reconciliationUseCase == nil {
+		p.reconciliationUseCase = usecase.NewReconciliationUseCase(
+			orderUseCase,
+			p.container.Repositories().OrderRepository(),
+			p.container.Repositories().PaymentTransactionRepository(),
+			p.container.Services().PaymentStatusProvider(),
+		)
+	}
+	return p.reconciliationUseCase
+}
+
+// dispatchOrigin returns where orders are dispatched from, the sender of shipping labels
+func dispatchOrigin(cfg config.ShippingConfig) usecase.DispatchOrigin {
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	return usecase.DispatchOrigin{Country: cfg.SenderCountry, Location: location}
+}
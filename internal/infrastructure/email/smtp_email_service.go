@@ -2,13 +2,17 @@ package email
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"html/template"
+	"mime/multipart"
 	"net/smtp"
+	"net/textproto"
 	"path/filepath"
 
 	"github.com/zenfulcode/commercify/config"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
 	"github.com/zenfulcode/commercify/internal/domain/service"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
 )
@@ -69,13 +73,22 @@ func (s *SMTPEmailService) SendEmail(data service.EmailData) error {
 	sanitizedSubject := template.HTMLEscapeString(data.Subject)
 	sanitizedBody := template.HTMLEscapeString(body)
 
-	msg := []byte(fmt.Sprintf("From: %s <%s>\r\n"+
+	headers := fmt.Sprintf("From: %s <%s>\r\n"+
 		"To: %s\r\n"+
 		"Subject: %s\r\n"+
-		"MIME-Version: 1.0\r\n"+
-		"Content-Type: %s; charset=UTF-8\r\n"+
-		"\r\n"+
-		"%s", s.config.FromName, s.config.FromEmail, data.To, sanitizedSubject, contentType, sanitizedBody))
+		"MIME-Version: 1.0\r\n", s.config.FromName, s.config.FromEmail, data.To, sanitizedSubject)
+
+	var msg []byte
+	if len(data.Attachments) > 0 {
+		msg, err = buildMultipartMessage(headers, contentType, sanitizedBody, data.Attachments)
+		if err != nil {
+			return err
+		}
+	} else {
+		msg = []byte(headers + fmt.Sprintf("Content-Type: %s; charset=UTF-8\r\n"+
+			"\r\n"+
+			"%s", contentType, sanitizedBody))
+	}
 
 	// Send email
 	err = smtp.SendMail(
@@ -134,13 +147,96 @@ func (s *SMTPEmailService) SendOrderNotification(order *entity.Order, user *enti
 	})
 }
 
+// SendInvoice sends an invoice or credit note to the customer with its document attached
+func (s *SMTPEmailService) SendInvoice(order *entity.Order, user *entity.User, invoice *entity.Invoice, document []byte) error {
+	// Prepare data for the template
+	data := map[string]interface{}{
+		"Order":        order,
+		"User":         user,
+		"Invoice":      invoice,
+		"StoreName":    s.config.FromName,
+		"ContactEmail": s.config.FromEmail,
+	}
+
+	subject := fmt.Sprintf("Invoice %s for Order #%d", invoice.Number, order.ID)
+	if invoice.IsCreditNote() {
+		subject = fmt.Sprintf("Credit Note %s for Order #%d", invoice.Number, order.ID)
+	}
+
+	// Send email
+	return s.SendEmail(service.EmailData{
+		To:       user.Email,
+		Subject:  subject,
+		IsHTML:   true,
+		Template: "invoice.html",
+		Data:     data,
+		Attachments: []service.EmailAttachment{{
+			Filename:    invoice.Filename(),
+			ContentType: "application/pdf",
+			Data:        document,
+		}},
+	})
+}
+
+// buildMultipartMessage builds a multipart/mixed message of the body followed by the attachments
+func buildMultipartMessage(headers, contentType, body string, attachments []service.EmailAttachment) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	buf.WriteString(headers)
+	buf.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\r\n\r\n", writer.Boundary()))
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {contentType + "; charset=UTF-8"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {fmt.Sprintf("%s; name=%q", attachment.ContentType, attachment.Filename)},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", attachment.Filename)},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Base64 lines must not exceed 76 characters
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+		for start := 0; start < len(encoded); start += 76 {
+			end := min(start+76, len(encoded))
+			if _, err := part.Write([]byte(encoded[start:end] + "\r\n")); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 // renderTemplate renders an HTML template with the given data
 func (s *SMTPEmailService) renderTemplate(templateName string, data map[string]interface{}) (string, error) {
 	// Get template path
 	templatePath := filepath.Join("templates", "emails", templateName)
 
-	// Parse template
-	tmpl, err := template.ParseFiles(templatePath)
+	// Parse template with helpers for formatting amounts in the store locale
+	tmpl, err := template.New(templateName).Funcs(template.FuncMap{
+		"formatMoney": func(amount int64, currency string) string {
+			return money.New(amount, currency).Format(s.config.Locale)
+		},
+		"reverseChargeNote": func() string {
+			return entity.ReverseChargeNote
+		},
+	}).ParseFiles(templatePath)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,110 @@
+// Package exchangerate provides exchange rate providers
+package exchangerate
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// ECBDailyRatesURL is the European Central Bank's daily reference rates feed
+const ECBDailyRatesURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ecbBaseCurrency is the currency all ECB reference rates are quoted against
+const ecbBaseCurrency = "EUR"
+
+// ECBRateProvider fetches exchange rates from the European Central Bank's XML feed
+type ECBRateProvider struct {
+	feedURL string
+	client  *http.Client
+}
+
+// NewECBRateProvider creates a new ECBRateProvider.
+// An empty feed URL uses the ECB daily reference rates.
+func NewECBRateProvider(feedURL string) *ECBRateProvider {
+	if feedURL == "" {
+		feedURL = ECBDailyRatesURL
+	}
+
+	return &ECBRateProvider{
+		feedURL: feedURL,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ecbEnvelope mirrors the structure of the ECB reference rates feed
+type ecbEnvelope struct {
+	Cube struct {
+		Cube struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string  `xml:"currency,attr"`
+				Rate     float64 `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// Name returns the name of the provider
+func (p *ECBRateProvider) Name() string {
+	return "ecb"
+}
+
+// GetRates returns the latest ECB reference rates relative to the given base currency
+func (p *ECBRateProvider) GetRates(base string) (*service.ExchangeRates, error) {
+	resp, err := p.client.Get(p.feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ECB rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch ECB rates: unexpected status %d", resp.StatusCode)
+	}
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse ECB rates: %w", err)
+	}
+
+	if len(envelope.Cube.Cube.Rates) == 0 {
+		return nil, errors.New("ECB feed contains no rates")
+	}
+
+	// The ECB quotes every currency against EUR
+	eurRates := map[string]float64{ecbBaseCurrency: 1}
+	for _, rate := range envelope.Cube.Cube.Rates {
+		if rate.Currency == "" || rate.Rate <= 0 {
+			continue
+		}
+		eurRates[strings.ToUpper(rate.Currency)] = rate.Rate
+	}
+
+	base = strings.ToUpper(base)
+	baseRate, ok := eurRates[base]
+	if !ok {
+		return nil, fmt.Errorf("ECB feed has no rate for base currency %s", base)
+	}
+
+	// Rebase the rates onto the requested base currency
+	rates := make(map[string]float64, len(eurRates))
+	for code, rate := range eurRates {
+		rates[code] = rate / baseRate
+	}
+
+	date, err := time.Parse("2006-01-02", envelope.Cube.Cube.Time)
+	if err != nil {
+		date = time.Now()
+	}
+
+	return &service.ExchangeRates{
+		Base:  base,
+		Date:  date,
+		Rates: rates,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package exchangerate_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/infrastructure/exchangerate"
+)
+
+const ecbFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time='2025-03-14'>
+			<Cube currency='USD' rate='1.0880'/>
+			<Cube currency='DKK' rate='7.4596'/>
+			<Cube currency='NOK' rate='11.4805'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func newFixtureServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestECBRateProvider_GetRates(t *testing.T) {
+	t.Run("EUR base", func(t *testing.T) {
+		server := newFixtureServer(http.StatusOK, ecbFixture)
+		defer server.Close()
+
+		provider := exchangerate.NewECBRateProvider(server.URL)
+
+		rates, err := provider.GetRates("EUR")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "EUR", rates.Base)
+		assert.Equal(t, "2025-03-14", rates.Date.Format("2006-01-02"))
+		assert.Equal(t, 1.0, rates.Rates["EUR"])
+		assert.Equal(t, 7.4596, rates.Rates["DKK"])
+	})
+
+	t.Run("Rebased onto another currency", func(t *testing.T) {
+		server := newFixtureServer(http.StatusOK, ecbFixture)
+		defer server.Close()
+
+		provider := exchangerate.NewECBRateProvider(server.URL)
+
+		rates, err := provider.GetRates("usd")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", rates.Base)
+		assert.Equal(t, 1.0, rates.Rates["USD"])
+		assert.InDelta(t, 1/1.0880, rates.Rates["EUR"], 1e-9)
+		assert.InDelta(t, 7.4596/1.0880, rates.Rates["DKK"], 1e-9)
+	})
+
+	t.Run("Unknown base currency", func(t *testing.T) {
+		server := newFixtureServer(http.StatusOK, ecbFixture)
+		defer server.Close()
+
+		provider := exchangerate.NewECBRateProvider(server.URL)
+
+		_, err := provider.GetRates("XYZ")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Feed unavailable", func(t *testing.T) {
+		server := newFixtureServer(http.StatusServiceUnavailable, "")
+		defer server.Close()
+
+		provider := exchangerate.NewECBRateProvider(server.URL)
+
+		_, err := provider.GetRates("EUR")
+
+		assert.Error(t, err)
+	})
+}
@@ -1,8 +1,12 @@
 package payment
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 
 	"github.com/gkhaavik/vipps-mobilepay-sdk/pkg/client"
@@ -88,8 +92,13 @@ func (s *MobilePayPaymentService) ProcessPayment(request service.PaymentRequest)
 		}, nil
 	}
 
-	// Generate a unique reference for this payment
+	// Generate a unique reference for this payment. A retried request has the same idempotency key
+	// and must send the same payment, so its reference is derived from the key.
 	reference := fmt.Sprintf("order-%d-%s", request.OrderID, uuid.New().String())
+	if request.IdempotencyKey != "" {
+		keyHash := sha256.Sum256([]byte(request.IdempotencyKey))
+		reference = fmt.Sprintf("order-%d-%s", request.OrderID, hex.EncodeToString(keyHash[:16]))
+	}
 
 	// Construct the payment request
 	paymentRequest := models.CreatePaymentRequest{
@@ -109,7 +118,7 @@ func (s *MobilePayPaymentService) ProcessPayment(request service.PaymentRequest)
 		PaymentDescription: s.config.PaymentDescription,
 	}
 
-	res, err := s.epayment.Create(paymentRequest)
+	res, err := s.createPayment(paymentRequest, request.IdempotencyKey)
 	if err != nil {
 		return &service.PaymentResult{
 			Success:      false,
@@ -130,6 +139,25 @@ func (s *MobilePayPaymentService) ProcessPayment(request service.PaymentRequest)
 	}, nil
 }
 
+// createPayment creates an ePayment payment, sending the idempotency key of the request when there is one
+func (s *MobilePayPaymentService) createPayment(paymentRequest models.CreatePaymentRequest, idempotencyKey string) (*models.CreatePaymentResponse, error) {
+	if idempotencyKey == "" {
+		return s.epayment.Create(paymentRequest)
+	}
+
+	body, _, err := s.vippsClient.DoRequest(http.MethodPost, "/epayment/v1/payments", paymentRequest, idempotencyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	var response models.CreatePaymentResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &response, nil
+}
+
 // VerifyPayment verifies a payment
 func (s *MobilePayPaymentService) VerifyPayment(transactionID string, provider service.PaymentProviderType) (bool, error) {
 	if provider != service.PaymentProviderMobilePay {
@@ -149,6 +177,46 @@ func (s *MobilePayPaymentService) VerifyPayment(transactionID string, provider s
 	return res.State == "AUTHORIZED", nil
 }
 
+// GetPaymentStatus returns the state and amounts of a MobilePay payment
+func (s *MobilePayPaymentService) GetPaymentStatus(transactionID string, provider service.PaymentProviderType) (*service.PaymentStatus, error) {
+	if provider != service.PaymentProviderMobilePay {
+		return nil, errors.New("invalid payment provider")
+	}
+
+	if transactionID == "" {
+		return nil, errors.New("transaction ID is required")
+	}
+
+	res, err := s.epayment.Get(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment details: %v", err)
+	}
+
+	status := &service.PaymentStatus{
+		State:  service.PaymentStatePending,
+		Amount: int64(res.Amount.Value),
+	}
+	if res.Aggregate != nil {
+		status.CapturedAmount = int64(res.Aggregate.CapturedAmount.Value)
+		status.RefundedAmount = int64(res.Aggregate.RefundedAmount.Value)
+	}
+
+	switch res.State {
+	case models.PaymentStateAuthorized:
+		status.State = service.PaymentStateAuthorized
+		if status.CapturedAmount > 0 {
+			status.State = service.PaymentStateCaptured
+		} else if res.Aggregate != nil && res.Aggregate.CancelledAmount.Value > 0 &&
+			res.Aggregate.CancelledAmount.Value >= res.Aggregate.AuthorizedAmount.Value {
+			status.State = service.PaymentStateCancelled
+		}
+	case models.PaymentStateAborted, models.PaymentStateExpired, models.PaymentStateTerminated:
+		status.State = service.PaymentStateCancelled
+	}
+
+	return status, nil
+}
+
 // RefundPayment refunds a payment
 func (s *MobilePayPaymentService) RefundPayment(transactionID string, amount int64, provider service.PaymentProviderType) error {
 	if provider != service.PaymentProviderMobilePay {
@@ -172,8 +240,9 @@ func (s *MobilePayPaymentService) RefundPayment(transactionID string, amount int
 	return nil
 }
 
-// CapturePayment captures an authorized payment
-func (s *MobilePayPaymentService) CapturePayment(transactionID string, amount int64, provider service.PaymentProviderType) error {
+// CapturePayment captures an authorized payment. Vipps MobilePay allows captures
+// until the authorization is used up, so a final capture needs no special handling.
+func (s *MobilePayPaymentService) CapturePayment(transactionID string, amount int64, final bool, provider service.PaymentProviderType) error {
 	if provider != service.PaymentProviderMobilePay {
 		return errors.New("invalid payment provider")
 	}
@@ -133,7 +133,7 @@ func (s *MockPaymentService) RefundPayment(transactionID string, amount int64, p
 }
 
 // CapturePayment captures a payment
-func (s *MockPaymentService) CapturePayment(transactionID string, amount int64, provider service.PaymentProviderType) error {
+func (s *MockPaymentService) CapturePayment(transactionID string, amount int64, final bool, provider service.PaymentProviderType) error {
 	if transactionID == "" {
 		return errors.New("transaction ID is required")
 	}
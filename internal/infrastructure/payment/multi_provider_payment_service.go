@@ -2,6 +2,7 @@ package payment
 
 import (
 	"fmt"
+	"time"
 
 	"slices"
 
@@ -37,10 +38,8 @@ func NewMultiProviderPaymentService(cfg *config.Config, logger logger.Logger) *M
 			}
 		case string(service.PaymentProviderPayPal):
 			if cfg.PayPal.Enabled {
-				// This would be a real PayPal implementation in production
-				// For now, we'll use the mock service
-				providers[service.PaymentProviderPayPal] = NewMockPaymentService()
-				logger.Info("PayPal payment provider initialized (mock)")
+				providers[service.PaymentProviderPayPal] = NewPayPalPaymentService(cfg.PayPal, logger)
+				logger.Info("PayPal payment provider initialized")
 			}
 		case string(service.PaymentProviderMobilePay):
 			if cfg.MobilePay.Enabled {
@@ -151,6 +150,46 @@ func (s *MultiProviderPaymentService) VerifyPayment(transactionID string, provid
 	return paymentProvider.VerifyPayment(transactionID, provider)
 }
 
+// GetPaymentStatus returns the state and amounts of a payment. Providers that can't report them
+// are asked to verify the payment instead, leaving its amounts unreported.
+func (s *MultiProviderPaymentService) GetPaymentStatus(transactionID string, provider service.PaymentProviderType) (*service.PaymentStatus, error) {
+	paymentProvider, exists := s.providers[provider]
+	if !exists {
+		return nil, fmt.Errorf("payment provider %s not available", provider)
+	}
+
+	if statusProvider, ok := paymentProvider.(service.PaymentStatusProvider); ok {
+		return statusProvider.GetPaymentStatus(transactionID, provider)
+	}
+
+	verified, err := paymentProvider.VerifyPayment(transactionID, provider)
+	if err != nil {
+		return nil, err
+	}
+	if verified {
+		return &service.PaymentStatus{State: service.PaymentStateAuthorized}, nil
+	}
+	return &service.PaymentStatus{State: service.PaymentStatePending}, nil
+}
+
+// AuthorizationValidity returns how long an uncaptured payment of a provider stays authorized
+func (s *MultiProviderPaymentService) AuthorizationValidity(provider service.PaymentProviderType) time.Duration {
+	days := 0
+	switch provider {
+	case service.PaymentProviderStripe:
+		days = s.config.Stripe.AuthorizationDays
+	case service.PaymentProviderPayPal:
+		days = s.config.PayPal.AuthorizationDays
+	case service.PaymentProviderMobilePay:
+		days = s.config.MobilePay.AuthorizationDays
+	}
+
+	if days <= 0 {
+		return service.DefaultAuthorizationValidity
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
 // RefundPayment refunds a payment
 func (s *MultiProviderPaymentService) RefundPayment(transactionID string, amount int64, provider service.PaymentProviderType) error {
 	paymentProvider, exists := s.providers[provider]
@@ -162,13 +201,13 @@ func (s *MultiProviderPaymentService) RefundPayment(transactionID string, amount
 }
 
 // CapturePayment captures a payment
-func (s *MultiProviderPaymentService) CapturePayment(transactionID string, amount int64, provider service.PaymentProviderType) error {
+func (s *MultiProviderPaymentService) CapturePayment(transactionID string, amount int64, final bool, provider service.PaymentProviderType) error {
 	paymentProvider, exists := s.providers[provider]
 	if !exists {
 		return fmt.Errorf("payment provider %s not available", provider)
 	}
 
-	return paymentProvider.CapturePayment(transactionID, amount, provider)
+	return paymentProvider.CapturePayment(transactionID, amount, final, provider)
 }
 
 // CancelPayment cancels a payment
@@ -194,3 +233,56 @@ func (s *MultiProviderPaymentService) ForceApprovePayment(transactionID string,
 
 	return paymentProvider.ForceApprovePayment(transactionID, phoneNumber, provider)
 }
+
+// stripe returns the Stripe payment service, if Stripe is enabled
+func (s *MultiProviderPaymentService) stripe() (*StripePaymentService, error) {
+	stripeService, ok := s.providers[service.PaymentProviderStripe].(*StripePaymentService)
+	if !ok {
+		return nil, fmt.Errorf("payment provider %s not available", service.PaymentProviderStripe)
+	}
+	return stripeService, nil
+}
+
+// CreatePaymentIntent creates a Stripe payment intent the storefront confirms with Stripe.js
+func (s *MultiProviderPaymentService) CreatePaymentIntent(request service.PaymentRequest, savePaymentMethod bool) (*ClientIntent, error) {
+	stripeService, err := s.stripe()
+	if err != nil {
+		return nil, err
+	}
+	return stripeService.CreatePaymentIntent(request, savePaymentMethod)
+}
+
+// CreateSetupIntent creates a Stripe setup intent the storefront confirms with Stripe.js
+func (s *MultiProviderPaymentService) CreateSetupIntent(customerID string) (*ClientIntent, error) {
+	stripeService, err := s.stripe()
+	if err != nil {
+		return nil, err
+	}
+	return stripeService.CreateSetupIntent(customerID)
+}
+
+// CreateCustomer creates a customer at a provider that saves payment methods
+func (s *MultiProviderPaymentService) CreateCustomer(provider service.PaymentProviderType, email, name string) (string, error) {
+	if provider != service.PaymentProviderStripe {
+		return "", fmt.Errorf("payment provider %s does not save payment methods", provider)
+	}
+
+	stripeService, err := s.stripe()
+	if err != nil {
+		return "", err
+	}
+	return stripeService.CreateCustomer(email, name)
+}
+
+// DetachPaymentMethod removes a saved payment method from its customer at the provider
+func (s *MultiProviderPaymentService) DetachPaymentMethod(provider service.PaymentProviderType, methodID string) error {
+	if provider != service.PaymentProviderStripe {
+		return fmt.Errorf("payment provider %s does not save payment methods", provider)
+	}
+
+	stripeService, err := s.stripe()
+	if err != nil {
+		return err
+	}
+	return stripeService.DetachPaymentMethod(methodID)
+}
@@ -0,0 +1,712 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+)
+
+const (
+	// PayPalSandboxURL is the base URL of the PayPal sandbox API
+	PayPalSandboxURL = "https://api-m.sandbox.paypal.com"
+	// PayPalLiveURL is the base URL of the PayPal live API
+	PayPalLiveURL = "https://api-m.paypal.com"
+)
+
+// PayPal webhook event types handled by the store
+const (
+	PayPalEventOrderApproved       = "CHECKOUT.ORDER.APPROVED"
+	PayPalEventAuthorizationVoided = "PAYMENT.AUTHORIZATION.VOIDED"
+	PayPalEventCaptureCompleted    = "PAYMENT.CAPTURE.COMPLETED"
+	PayPalEventCaptureDenied       = "PAYMENT.CAPTURE.DENIED"
+	PayPalEventCaptureRefunded     = "PAYMENT.CAPTURE.REFUNDED"
+)
+
+// PayPalPaymentService implements a PayPal payment service on the Orders v2 API.
+// Payments are created as orders with the AUTHORIZE intent; the buyer approves them on PayPal,
+// after which the order is authorized and later captured, refunded or voided like a card payment.
+type PayPalPaymentService struct {
+	config  config.PayPalConfig
+	baseURL string
+	client  *http.Client
+	logger  logger.Logger
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewPayPalPaymentService creates a new PayPalPaymentService
+func NewPayPalPaymentService(config config.PayPalConfig, logger logger.Logger) *PayPalPaymentService {
+	baseURL := config.APIURL
+	if baseURL == "" {
+		baseURL = PayPalLiveURL
+		if config.Sandbox {
+			baseURL = PayPalSandboxURL
+		}
+	}
+
+	return &PayPalPaymentService{
+		config:  config,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 20 * time.Second},
+		logger:  logger,
+	}
+}
+
+// PayPalAmount mirrors the PayPal money object
+type PayPalAmount struct {
+	CurrencyCode string `json:"currency_code"`
+	Value        string `json:"value"`
+}
+
+// Cents converts the amount to the smallest currency unit
+func (a PayPalAmount) Cents() (int64, error) {
+	value, err := strconv.ParseFloat(a.Value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid PayPal amount %q", a.Value)
+	}
+
+	return money.FromDecimal(value, a.CurrencyCode).Amount, nil
+}
+
+// newPayPalAmount converts an amount in the smallest currency unit to a PayPal money object
+func newPayPalAmount(amount int64, currency string) PayPalAmount {
+	currency = strings.ToUpper(currency)
+
+	return PayPalAmount{
+		CurrencyCode: currency,
+		Value:        strconv.FormatFloat(money.New(amount, currency).Decimal(), 'f', money.Exponent(currency), 64),
+	}
+}
+
+// PayPalAuthorization mirrors a PayPal authorization
+type PayPalAuthorization struct {
+	ID     string       `json:"id"`
+	Status string       `json:"status"`
+	Amount PayPalAmount `json:"amount"`
+}
+
+// payPalCapture mirrors a PayPal capture or refund
+type payPalCapture struct {
+	ID     string       `json:"id"`
+	Status string       `json:"status"`
+	Amount PayPalAmount `json:"amount"`
+	Links  []payPalLink `json:"links"`
+}
+
+// payPalLink mirrors a HATEOAS link of a PayPal resource
+type payPalLink struct {
+	Href string `json:"href"`
+	Rel  string `json:"rel"`
+}
+
+// payPalOrder mirrors the parts of a PayPal order the store uses
+type payPalOrder struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	PurchaseUnits []struct {
+		CustomID string       `json:"custom_id"`
+		Amount   PayPalAmount `json:"amount"`
+		Payments struct {
+			Authorizations []PayPalAuthorization `json:"authorizations"`
+			Captures       []payPalCapture       `json:"captures"`
+			Refunds        []payPalCapture       `json:"refunds"`
+		} `json:"payments"`
+	} `json:"purchase_units"`
+	Links []payPalLink `json:"links"`
+}
+
+// authorization returns the authorization of the order, if it has one
+func (o *payPalOrder) authorization() *PayPalAuthorization {
+	for _, unit := range o.PurchaseUnits {
+		if len(unit.Payments.Authorizations) > 0 {
+			return &unit.Payments.Authorizations[0]
+		}
+	}
+	return nil
+}
+
+// completedCapture returns the completed capture of the order, if it has one
+func (o *payPalOrder) completedCapture() *payPalCapture {
+	for _, unit := range o.PurchaseUnits {
+		for i, capture := range unit.Payments.Captures {
+			if capture.Status == "COMPLETED" || capture.Status == "PARTIALLY_REFUNDED" {
+				return &unit.Payments.Captures[i]
+			}
+		}
+	}
+	return nil
+}
+
+// refundableCapture is a capture with the amount that can still be refunded from it
+type refundableCapture struct {
+	capture    *payPalCapture
+	refundable int64
+}
+
+// refundableCaptures returns the completed captures of the order with the amount left to refund on each,
+// refunds link up to the capture they were made from
+func (o *payPalOrder) refundableCaptures() ([]refundableCapture, error) {
+	var captures []refundableCapture
+	for _, unit := range o.PurchaseUnits {
+		for i, capture := range unit.Payments.Captures {
+			if capture.Status != "COMPLETED" && capture.Status != "PARTIALLY_REFUNDED" {
+				continue
+			}
+			refundable, err := capture.Amount.Cents()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, refund := range unit.Payments.Refunds {
+				if refund.Status != "COMPLETED" && refund.Status != "PENDING" {
+					continue
+				}
+				for _, link := range refund.Links {
+					if link.Rel != "up" || !strings.HasSuffix(link.Href, "/captures/"+capture.ID) {
+						continue
+					}
+					refunded, err := refund.Amount.Cents()
+					if err != nil {
+						return nil, err
+					}
+					refundable -= refunded
+				}
+			}
+
+			if refundable > 0 {
+				captures = append(captures, refundableCapture{capture: &unit.Payments.Captures[i], refundable: refundable})
+			}
+		}
+	}
+	return captures, nil
+}
+
+// approvalURL returns the link the buyer approves the order on
+func (o *payPalOrder) approvalURL() string {
+	for _, link := range o.Links {
+		if link.Rel == "payer-action" || link.Rel == "approve" {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// PayPalWebhookEvent is a verified PayPal webhook event
+type PayPalWebhookEvent struct {
+	ID        string          `json:"id"`
+	EventType string          `json:"event_type"`
+	Resource  json.RawMessage `json:"resource"`
+}
+
+// PayPalWebhookResource holds the fields the store reads from order, authorization, capture and refund resources
+type PayPalWebhookResource struct {
+	ID            string       `json:"id"`
+	Status        string       `json:"status"`
+	CustomID      string       `json:"custom_id"`
+	Amount        PayPalAmount `json:"amount"`
+	PurchaseUnits []struct {
+		CustomID string       `json:"custom_id"`
+		Amount   PayPalAmount `json:"amount"`
+	} `json:"purchase_units"`
+	SellerPayableBreakdown struct {
+		TotalRefundedAmount PayPalAmount `json:"total_refunded_amount"`
+	} `json:"seller_payable_breakdown"`
+	SupplementaryData struct {
+		RelatedIDs struct {
+			OrderID         string `json:"order_id"`
+			AuthorizationID string `json:"authorization_id"`
+			CaptureID       string `json:"capture_id"`
+		} `json:"related_ids"`
+	} `json:"supplementary_data"`
+}
+
+// ParseResource parses the resource of the event
+func (e *PayPalWebhookEvent) ParseResource() (*PayPalWebhookResource, error) {
+	var resource PayPalWebhookResource
+	if err := json.Unmarshal(e.Resource, &resource); err != nil {
+		return nil, fmt.Errorf("invalid PayPal webhook resource: %w", err)
+	}
+	return &resource, nil
+}
+
+// OrderID returns the store order ID the resource was created for
+func (r *PayPalWebhookResource) OrderID() (uint, error) {
+	customID := r.CustomID
+	if customID == "" && len(r.PurchaseUnits) > 0 {
+		customID = r.PurchaseUnits[0].CustomID
+	}
+
+	orderID, err := strconv.ParseUint(customID, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid order ID %q in PayPal resource", customID)
+	}
+	return uint(orderID), nil
+}
+
+// GetAvailableProviders returns a list of available payment providers
+func (s *PayPalPaymentService) GetAvailableProviders() []service.PaymentProvider {
+	return []service.PaymentProvider{
+		{
+			Type:        service.PaymentProviderPayPal,
+			Name:        "PayPal",
+			Description: "Pay with your PayPal account",
+			IconURL:     "/assets/images/paypal-logo.png",
+			Methods:     []service.PaymentMethod{service.PaymentMethodPayPal},
+			Enabled:     true,
+		},
+	}
+}
+
+// ProcessPayment creates a PayPal order and returns the link the buyer approves it on.
+// The returned transaction ID is the PayPal order ID.
+func (s *PayPalPaymentService) ProcessPayment(request service.PaymentRequest) (*service.PaymentResult, error) {
+	if request.PaymentMethod != service.PaymentMethodPayPal {
+		return &service.PaymentResult{
+			Success:      false,
+			ErrorMessage: "unsupported payment method for PayPal, only paypal is supported",
+			Provider:     service.PaymentProviderPayPal,
+		}, nil
+	}
+
+	body := map[string]any{
+		"intent": "AUTHORIZE",
+		"purchase_units": []map[string]any{
+			{
+				"reference_id": fmt.Sprintf("order-%d", request.OrderID),
+				"custom_id":    strconv.FormatUint(uint64(request.OrderID), 10),
+				"amount":       newPayPalAmount(request.Amount, request.Currency),
+			},
+		},
+		"payment_source": map[string]any{
+			"paypal": map[string]any{
+				"experience_context": map[string]any{
+					"return_url":  s.config.ReturnURL,
+					"cancel_url":  s.config.ReturnURL,
+					"user_action": "PAY_NOW",
+				},
+			},
+		},
+	}
+
+	var order payPalOrder
+	if err := s.do(http.MethodPost, "/v2/checkout/orders", body, &order); err != nil {
+		return &service.PaymentResult{
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("failed to create payment: %v", err),
+			Provider:     service.PaymentProviderPayPal,
+		}, nil
+	}
+
+	approvalURL := order.approvalURL()
+	if approvalURL == "" {
+		return &service.PaymentResult{
+			Success:      false,
+			ErrorMessage: "PayPal did not return an approval link",
+			Provider:     service.PaymentProviderPayPal,
+		}, nil
+	}
+
+	// PayPal requires the buyer to approve the order
+	return &service.PaymentResult{
+		Success:        false,
+		TransactionID:  order.ID,
+		ErrorMessage:   "payment requires user action",
+		RequiresAction: true,
+		ActionURL:      approvalURL,
+		Provider:       service.PaymentProviderPayPal,
+	}, nil
+}
+
+// AuthorizeOrder authorizes an order the buyer has approved.
+// An order that is already authorized returns its existing authorization.
+func (s *PayPalPaymentService) AuthorizeOrder(paypalOrderID string) (*PayPalAuthorization, error) {
+	if paypalOrderID == "" {
+		return nil, errors.New("transaction ID is required")
+	}
+
+	order, err := s.getOrder(paypalOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if authorization := order.authorization(); authorization != nil {
+		return authorization, nil
+	}
+
+	if order.Status != "APPROVED" {
+		return nil, fmt.Errorf("PayPal order %s is not approved", paypalOrderID)
+	}
+
+	var authorized payPalOrder
+	if err := s.do(http.MethodPost, "/v2/checkout/orders/"+url.PathEscape(paypalOrderID)+"/authorize", map[string]any{}, &authorized); err != nil {
+		return nil, fmt.Errorf("failed to authorize payment: %v", err)
+	}
+
+	authorization := authorized.authorization()
+	if authorization == nil {
+		return nil, errors.New("PayPal did not return an authorization")
+	}
+	if authorization.Status != "CREATED" {
+		return nil, fmt.Errorf("PayPal authorization is %s", strings.ToLower(authorization.Status))
+	}
+
+	return authorization, nil
+}
+
+// VerifyPayment verifies a payment
+func (s *PayPalPaymentService) VerifyPayment(transactionID string, provider service.PaymentProviderType) (bool, error) {
+	if provider != service.PaymentProviderPayPal {
+		return false, errors.New("invalid payment provider")
+	}
+
+	if transactionID == "" {
+		return false, errors.New("transaction ID is required")
+	}
+
+	order, err := s.getOrder(transactionID)
+	if err != nil {
+		return false, err
+	}
+
+	// Return true if payment is authorized or captured
+	if authorization := order.authorization(); authorization != nil {
+		return authorization.Status == "CREATED" || authorization.Status == "CAPTURED" || authorization.Status == "PARTIALLY_CAPTURED", nil
+	}
+	return order.completedCapture() != nil, nil
+}
+
+// GetPaymentStatus returns the state and amounts of a PayPal order
+func (s *PayPalPaymentService) GetPaymentStatus(transactionID string, provider service.PaymentProviderType) (*service.PaymentStatus, error) {
+	if provider != service.PaymentProviderPayPal {
+		return nil, errors.New("invalid payment provider")
+	}
+
+	if transactionID == "" {
+		return nil, errors.New("transaction ID is required")
+	}
+
+	order, err := s.getOrder(transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &service.PaymentStatus{State: service.PaymentStatePending}
+	for _, unit := range order.PurchaseUnits {
+		amount, err := unit.Amount.Cents()
+		if err != nil {
+			return nil, err
+		}
+		status.Amount += amount
+
+		for _, capture := range unit.Payments.Captures {
+			if capture.Status != "COMPLETED" && capture.Status != "PARTIALLY_REFUNDED" && capture.Status != "REFUNDED" {
+				continue
+			}
+			captured, err := capture.Amount.Cents()
+			if err != nil {
+				return nil, err
+			}
+			status.CapturedAmount += captured
+		}
+
+		for _, refund := range unit.Payments.Refunds {
+			if refund.Status != "COMPLETED" {
+				continue
+			}
+			refunded, err := refund.Amount.Cents()
+			if err != nil {
+				return nil, err
+			}
+			status.RefundedAmount += refunded
+		}
+	}
+
+	authorization := order.authorization()
+	switch {
+	case status.CapturedAmount > 0:
+		status.State = service.PaymentStateCaptured
+	case authorization != nil && (authorization.Status == "CREATED" || authorization.Status == "PENDING"):
+		status.State = service.PaymentStateAuthorized
+	case authorization != nil && (authorization.Status == "VOIDED" || authorization.Status == "EXPIRED" || authorization.Status == "DENIED"):
+		status.State = service.PaymentStateCancelled
+	case order.Status == "VOIDED":
+		status.State = service.PaymentStateCancelled
+	}
+
+	return status, nil
+}
+
+// CapturePayment captures an authorized payment
+func (s *PayPalPaymentService) CapturePayment(transactionID string, amount int64, final bool, provider service.PaymentProviderType) error {
+	if provider != service.PaymentProviderPayPal {
+		return errors.New("invalid payment provider")
+	}
+
+	if transactionID == "" {
+		return errors.New("transaction ID is required")
+	}
+
+	order, err := s.getOrder(transactionID)
+	if err != nil {
+		return err
+	}
+
+	authorization := order.authorization()
+	if authorization == nil {
+		return errors.New("payment has not been authorized")
+	}
+
+	body := map[string]any{
+		"amount":        newPayPalAmount(amount, authorization.Amount.CurrencyCode),
+		"final_capture": final,
+	}
+
+	var capture payPalCapture
+	if err := s.do(http.MethodPost, "/v2/payments/authorizations/"+url.PathEscape(authorization.ID)+"/capture", body, &capture); err != nil {
+		return fmt.Errorf("failed to capture payment: %v", err)
+	}
+
+	if capture.Status != "COMPLETED" && capture.Status != "PENDING" {
+		return fmt.Errorf("failed to capture payment: capture is %s", strings.ToLower(capture.Status))
+	}
+
+	return nil
+}
+
+// RefundPayment refunds a captured payment. An order captured per shipment has a capture per shipment,
+// the amount is refunded from the captures in turn.
+func (s *PayPalPaymentService) RefundPayment(transactionID string, amount int64, provider service.PaymentProviderType) error {
+	if provider != service.PaymentProviderPayPal {
+		return errors.New("invalid payment provider")
+	}
+
+	if transactionID == "" {
+		return errors.New("transaction ID is required")
+	}
+
+	order, err := s.getOrder(transactionID)
+	if err != nil {
+		return err
+	}
+
+	captures, err := order.refundableCaptures()
+	if err != nil {
+		return err
+	}
+	if len(captures) == 0 {
+		return errors.New("payment has not been captured")
+	}
+
+	var refundable int64
+	for _, capture := range captures {
+		refundable += capture.refundable
+	}
+	if amount > refundable {
+		return errors.New("refund amount exceeds the captured amount")
+	}
+
+	for _, capture := range captures {
+		if amount == 0 {
+			break
+		}
+
+		part := min(amount, capture.refundable)
+		body := map[string]any{
+			"amount": newPayPalAmount(part, capture.capture.Amount.CurrencyCode),
+		}
+
+		var refund struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		}
+		if err := s.do(http.MethodPost, "/v2/payments/captures/"+url.PathEscape(capture.capture.ID)+"/refund", body, &refund); err != nil {
+			return fmt.Errorf("failed to refund payment: %v", err)
+		}
+
+		if refund.Status != "COMPLETED" && refund.Status != "PENDING" {
+			return fmt.Errorf("failed to refund payment: refund is %s", strings.ToLower(refund.Status))
+		}
+
+		amount -= part
+	}
+
+	return nil
+}
+
+// CancelPayment voids the authorization of a payment.
+// An order that was never authorized holds no funds and expires on its own.
+func (s *PayPalPaymentService) CancelPayment(transactionID string, provider service.PaymentProviderType) error {
+	if provider != service.PaymentProviderPayPal {
+		return errors.New("invalid payment provider")
+	}
+
+	if transactionID == "" {
+		return errors.New("transaction ID is required")
+	}
+
+	order, err := s.getOrder(transactionID)
+	if err != nil {
+		return err
+	}
+
+	authorization := order.authorization()
+	if authorization == nil || authorization.Status == "VOIDED" {
+		return nil
+	}
+
+	if err := s.do(http.MethodPost, "/v2/payments/authorizations/"+url.PathEscape(authorization.ID)+"/void", nil, nil); err != nil {
+		return fmt.Errorf("failed to cancel payment: %v", err)
+	}
+
+	return nil
+}
+
+// ForceApprovePayment is only supported by MobilePay test environments
+func (s *PayPalPaymentService) ForceApprovePayment(transactionID string, phoneNumber string, provider service.PaymentProviderType) error {
+	return errors.New("not implemented")
+}
+
+// VerifyWebhook verifies the signature of a webhook through PayPal and returns its event
+func (s *PayPalPaymentService) VerifyWebhook(header http.Header, payload []byte) (*PayPalWebhookEvent, error) {
+	if s.config.WebhookID == "" {
+		return nil, errors.New("PayPal webhook ID is not configured")
+	}
+
+	var event PayPalWebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid PayPal webhook payload: %w", err)
+	}
+
+	body := map[string]any{
+		"auth_algo":         header.Get("PAYPAL-AUTH-ALGO"),
+		"cert_url":          header.Get("PAYPAL-CERT-URL"),
+		"transmission_id":   header.Get("PAYPAL-TRANSMISSION-ID"),
+		"transmission_sig":  header.Get("PAYPAL-TRANSMISSION-SIG"),
+		"transmission_time": header.Get("PAYPAL-TRANSMISSION-TIME"),
+		"webhook_id":        s.config.WebhookID,
+		"webhook_event":     json.RawMessage(payload),
+	}
+
+	var verification struct {
+		VerificationStatus string `json:"verification_status"`
+	}
+	if err := s.do(http.MethodPost, "/v1/notifications/verify-webhook-signature", body, &verification); err != nil {
+		return nil, fmt.Errorf("failed to verify webhook signature: %v", err)
+	}
+
+	if verification.VerificationStatus != "SUCCESS" {
+		return nil, errors.New("invalid webhook signature")
+	}
+
+	return &event, nil
+}
+
+func (s *PayPalPaymentService) getOrder(paypalOrderID string) (*payPalOrder, error) {
+	var order payPalOrder
+	if err := s.do(http.MethodGet, "/v2/checkout/orders/"+url.PathEscape(paypalOrderID), nil, &order); err != nil {
+		return nil, fmt.Errorf("failed to get payment details: %v", err)
+	}
+	return &order, nil
+}
+
+// accessToken returns a cached OAuth token, requesting a new one shortly before it expires
+func (s *PayPalPaymentService) accessToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Before(s.tokenExpiry) {
+		return s.token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+"/v1/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(s.config.ClientID, s.config.ClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("failed to get access token: unexpected status %d", resp.StatusCode)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+
+	s.token = token.AccessToken
+	s.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+
+	return s.token, nil
+}
+
+func (s *PayPalPaymentService) do(method, path string, body any, result any) error {
+	token, err := s.accessToken()
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, s.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Name    string `json:"name"`
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Name != "" {
+			return fmt.Errorf("%s: %s", apiErr.Name, apiErr.Message)
+		}
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,340 @@
+package payment_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/infrastructure/payment"
+)
+
+// payPalStub is a local stand-in for the PayPal API holding a single order
+type payPalStub struct {
+	t           *testing.T
+	orderStatus string
+	authorized  bool
+	captures    []map[string]any
+	refunds     []map[string]any
+	verified    string
+	tokens      int
+	requests    map[string]map[string]any
+}
+
+func (s *payPalStub) order() map[string]any {
+	payments := map[string]any{}
+	if s.authorized {
+		payments["authorizations"] = []map[string]any{
+			{"id": "AUTH-1", "status": "CREATED", "amount": map[string]any{"currency_code": "EUR", "value": "25.50"}},
+		}
+	}
+	if len(s.captures) > 0 {
+		payments["captures"] = s.captures
+	}
+	if len(s.refunds) > 0 {
+		payments["refunds"] = s.refunds
+	}
+
+	return map[string]any{
+		"id":     "PAYPAL-ORDER-1",
+		"status": s.orderStatus,
+		"purchase_units": []map[string]any{
+			{"custom_id": "42", "amount": map[string]any{"currency_code": "EUR", "value": "25.50"}, "payments": payments},
+		},
+		"links": []map[string]any{
+			{"href": "https://www.sandbox.paypal.com/checkoutnow?token=PAYPAL-ORDER-1", "rel": "payer-action"},
+		},
+	}
+}
+
+func (s *payPalStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/v1/oauth2/token" {
+		user, pass, ok := r.BasicAuth()
+		assert.True(s.t, ok)
+		assert.Equal(s.t, "client-id", user)
+		assert.Equal(s.t, "client-secret", pass)
+		s.tokens++
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "test-token", "expires_in": 3600})
+		return
+	}
+
+	assert.Equal(s.t, "Bearer test-token", r.Header.Get("Authorization"))
+
+	var body map[string]any
+	json.NewDecoder(r.Body).Decode(&body)
+	s.requests[r.Method+" "+r.URL.Path] = body
+
+	w.Header().Set("Content-Type", "application/json")
+	if captureID, ok := strings.CutPrefix(r.URL.Path, "/v2/payments/captures/"); ok && r.Method == http.MethodPost {
+		captureID = strings.TrimSuffix(captureID, "/refund")
+		refundID := fmt.Sprintf("REF-%d", len(s.refunds)+1)
+		s.refunds = append(s.refunds, map[string]any{
+			"id":     refundID,
+			"status": "COMPLETED",
+			"amount": body["amount"],
+			"links":  []map[string]any{{"href": "https://api.sandbox.paypal.com/v2/payments/captures/" + captureID, "rel": "up"}},
+		})
+		w.Write([]byte(`{"id": "` + refundID + `", "status": "COMPLETED"}`))
+		return
+	}
+
+	switch r.Method + " " + r.URL.Path {
+	case "POST /v2/checkout/orders":
+		s.orderStatus = "PAYER_ACTION_REQUIRED"
+		json.NewEncoder(w).Encode(s.order())
+	case "GET /v2/checkout/orders/PAYPAL-ORDER-1":
+		json.NewEncoder(w).Encode(s.order())
+	case "POST /v2/checkout/orders/PAYPAL-ORDER-1/authorize":
+		s.orderStatus = "COMPLETED"
+		s.authorized = true
+		json.NewEncoder(w).Encode(s.order())
+	case "POST /v2/payments/authorizations/AUTH-1/capture":
+		captureID := fmt.Sprintf("CAP-%d", len(s.captures)+1)
+		s.captures = append(s.captures, map[string]any{"id": captureID, "status": "COMPLETED", "amount": body["amount"]})
+		w.Write([]byte(`{"id": "` + captureID + `", "status": "COMPLETED"}`))
+	case "POST /v2/payments/authorizations/AUTH-1/void":
+		w.WriteHeader(http.StatusNoContent)
+	case "POST /v1/notifications/verify-webhook-signature":
+		w.Write([]byte(`{"verification_status": "` + s.verified + `"}`))
+	default:
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"name": "UNPROCESSABLE_ENTITY", "message": "The requested action could not be performed"}`))
+	}
+}
+
+func newPayPalService(t *testing.T) (*payment.PayPalPaymentService, *payPalStub) {
+	stub := &payPalStub{t: t, verified: "SUCCESS", requests: map[string]map[string]any{}}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	paypal := payment.NewPayPalPaymentService(config.PayPalConfig{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		ReturnURL:    "https://shop.example/checkout/complete",
+		WebhookID:    "WH-1",
+		APIURL:       server.URL,
+		Enabled:      true,
+	}, logger.NewLogger())
+
+	return paypal, stub
+}
+
+func TestPayPalPaymentService_ProcessPayment(t *testing.T) {
+	t.Run("Create order with approval link", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+
+		result, err := paypal.ProcessPayment(service.PaymentRequest{
+			OrderID:         42,
+			Amount:          2550,
+			Currency:        "eur",
+			PaymentMethod:   service.PaymentMethodPayPal,
+			PaymentProvider: service.PaymentProviderPayPal,
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.True(t, result.RequiresAction)
+		assert.Equal(t, "PAYPAL-ORDER-1", result.TransactionID)
+		assert.Equal(t, "https://www.sandbox.paypal.com/checkoutnow?token=PAYPAL-ORDER-1", result.ActionURL)
+		assert.Equal(t, service.PaymentProviderPayPal, result.Provider)
+
+		request := stub.requests["POST /v2/checkout/orders"]
+		assert.Equal(t, "AUTHORIZE", request["intent"])
+		unit := request["purchase_units"].([]any)[0].(map[string]any)
+		assert.Equal(t, "42", unit["custom_id"])
+		assert.Equal(t, map[string]any{"currency_code": "EUR", "value": "25.50"}, unit["amount"])
+	})
+
+	t.Run("Unsupported payment method", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+
+		result, err := paypal.ProcessPayment(service.PaymentRequest{
+			OrderID:       42,
+			Amount:        2550,
+			Currency:      "EUR",
+			PaymentMethod: service.PaymentMethodCreditCard,
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.False(t, result.RequiresAction)
+		assert.Empty(t, stub.requests)
+	})
+}
+
+func TestPayPalPaymentService_PaymentLifecycle(t *testing.T) {
+	t.Run("Authorize, capture and refund", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+		stub.orderStatus = "APPROVED"
+
+		verified, err := paypal.VerifyPayment("PAYPAL-ORDER-1", service.PaymentProviderPayPal)
+		assert.NoError(t, err)
+		assert.False(t, verified)
+
+		authorization, err := paypal.AuthorizeOrder("PAYPAL-ORDER-1")
+		require.NoError(t, err)
+		assert.Equal(t, "AUTH-1", authorization.ID)
+
+		// Authorizing again returns the existing authorization
+		authorization, err = paypal.AuthorizeOrder("PAYPAL-ORDER-1")
+		require.NoError(t, err)
+		assert.Equal(t, "AUTH-1", authorization.ID)
+
+		verified, err = paypal.VerifyPayment("PAYPAL-ORDER-1", service.PaymentProviderPayPal)
+		assert.NoError(t, err)
+		assert.True(t, verified)
+
+		err = paypal.CapturePayment("PAYPAL-ORDER-1", 2000, false, service.PaymentProviderPayPal)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"currency_code": "EUR", "value": "20.00"},
+			stub.requests["POST /v2/payments/authorizations/AUTH-1/capture"]["amount"])
+		assert.Equal(t, false, stub.requests["POST /v2/payments/authorizations/AUTH-1/capture"]["final_capture"])
+
+		err = paypal.CapturePayment("PAYPAL-ORDER-1", 550, true, service.PaymentProviderPayPal)
+		assert.NoError(t, err)
+		assert.Equal(t, true, stub.requests["POST /v2/payments/authorizations/AUTH-1/capture"]["final_capture"])
+
+		err = paypal.RefundPayment("PAYPAL-ORDER-1", 550, service.PaymentProviderPayPal)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"currency_code": "EUR", "value": "5.50"},
+			stub.requests["POST /v2/payments/captures/CAP-1/refund"]["amount"])
+
+		// The access token is reused between requests
+		assert.Equal(t, 1, stub.tokens)
+	})
+
+	t.Run("Refund an order captured per shipment", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+		stub.orderStatus = "COMPLETED"
+		stub.authorized = true
+		require.NoError(t, paypal.CapturePayment("PAYPAL-ORDER-1", 2000, false, service.PaymentProviderPayPal))
+		require.NoError(t, paypal.CapturePayment("PAYPAL-ORDER-1", 550, true, service.PaymentProviderPayPal))
+
+		// The refund is split over both captures
+		err := paypal.RefundPayment("PAYPAL-ORDER-1", 2200, service.PaymentProviderPayPal)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"currency_code": "EUR", "value": "20.00"},
+			stub.requests["POST /v2/payments/captures/CAP-1/refund"]["amount"])
+		assert.Equal(t, map[string]any{"currency_code": "EUR", "value": "2.00"},
+			stub.requests["POST /v2/payments/captures/CAP-2/refund"]["amount"])
+
+		// Only the rest of the second capture can still be refunded
+		err = paypal.RefundPayment("PAYPAL-ORDER-1", 400, service.PaymentProviderPayPal)
+		assert.EqualError(t, err, "refund amount exceeds the captured amount")
+		assert.Len(t, stub.refunds, 2)
+
+		err = paypal.RefundPayment("PAYPAL-ORDER-1", 350, service.PaymentProviderPayPal)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"currency_code": "EUR", "value": "3.50"},
+			stub.requests["POST /v2/payments/captures/CAP-2/refund"]["amount"])
+		assert.Len(t, stub.refunds, 3)
+	})
+
+	t.Run("Authorize an order the buyer has not approved", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+		stub.orderStatus = "PAYER_ACTION_REQUIRED"
+
+		_, err := paypal.AuthorizeOrder("PAYPAL-ORDER-1")
+		assert.EqualError(t, err, "PayPal order PAYPAL-ORDER-1 is not approved")
+	})
+
+	t.Run("Void an authorization", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+		stub.orderStatus = "COMPLETED"
+		stub.authorized = true
+
+		err := paypal.CancelPayment("PAYPAL-ORDER-1", service.PaymentProviderPayPal)
+		assert.NoError(t, err)
+		assert.Contains(t, stub.requests, "POST /v2/payments/authorizations/AUTH-1/void")
+	})
+
+	t.Run("Cancel an order that was never authorized", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+		stub.orderStatus = "PAYER_ACTION_REQUIRED"
+
+		err := paypal.CancelPayment("PAYPAL-ORDER-1", service.PaymentProviderPayPal)
+		assert.NoError(t, err)
+		assert.NotContains(t, stub.requests, "POST /v2/payments/authorizations/AUTH-1/void")
+	})
+
+	t.Run("Capture without authorization", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+		stub.orderStatus = "APPROVED"
+
+		err := paypal.CapturePayment("PAYPAL-ORDER-1", 2550, true, service.PaymentProviderPayPal)
+		assert.EqualError(t, err, "payment has not been authorized")
+	})
+
+	t.Run("PayPal API error", func(t *testing.T) {
+		paypal, _ := newPayPalService(t)
+
+		_, err := paypal.VerifyPayment("UNKNOWN", service.PaymentProviderPayPal)
+		assert.EqualError(t, err, "failed to get payment details: UNPROCESSABLE_ENTITY: The requested action could not be performed")
+	})
+
+	t.Run("Invalid provider", func(t *testing.T) {
+		paypal, _ := newPayPalService(t)
+
+		err := paypal.CapturePayment("PAYPAL-ORDER-1", 2550, true, service.PaymentProviderStripe)
+		assert.EqualError(t, err, "invalid payment provider")
+	})
+}
+
+func TestPayPalPaymentService_VerifyWebhook(t *testing.T) {
+	payload := []byte(`{
+		"id": "WH-EVENT-1",
+		"event_type": "PAYMENT.CAPTURE.COMPLETED",
+		"resource": {
+			"id": "CAP-1",
+			"status": "COMPLETED",
+			"custom_id": "42",
+			"amount": {"currency_code": "EUR", "value": "25.50"},
+			"supplementary_data": {"related_ids": {"order_id": "PAYPAL-ORDER-1", "authorization_id": "AUTH-1"}}
+		}
+	}`)
+
+	header := http.Header{}
+	header.Set("PAYPAL-AUTH-ALGO", "SHA256withRSA")
+	header.Set("PAYPAL-CERT-URL", "https://api.sandbox.paypal.com/v1/notifications/certs/CERT-1")
+	header.Set("PAYPAL-TRANSMISSION-ID", "TRANSMISSION-1")
+	header.Set("PAYPAL-TRANSMISSION-SIG", "signature")
+	header.Set("PAYPAL-TRANSMISSION-TIME", "2026-10-18T10:00:00Z")
+
+	t.Run("Valid signature", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+
+		event, err := paypal.VerifyWebhook(header, payload)
+		require.NoError(t, err)
+		assert.Equal(t, payment.PayPalEventCaptureCompleted, event.EventType)
+
+		request := stub.requests["POST /v1/notifications/verify-webhook-signature"]
+		assert.Equal(t, "WH-1", request["webhook_id"])
+		assert.Equal(t, "TRANSMISSION-1", request["transmission_id"])
+		assert.Equal(t, "WH-EVENT-1", request["webhook_event"].(map[string]any)["id"])
+
+		resource, err := event.ParseResource()
+		require.NoError(t, err)
+		orderID, err := resource.OrderID()
+		assert.NoError(t, err)
+		assert.Equal(t, uint(42), orderID)
+		amount, err := resource.Amount.Cents()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2550), amount)
+		assert.Equal(t, "PAYPAL-ORDER-1", resource.SupplementaryData.RelatedIDs.OrderID)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		paypal, stub := newPayPalService(t)
+		stub.verified = "FAILURE"
+
+		_, err := paypal.VerifyWebhook(header, payload)
+		assert.EqualError(t, err, "invalid webhook signature")
+	})
+}
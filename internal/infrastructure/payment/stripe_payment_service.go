@@ -3,12 +3,16 @@ package payment
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/checkout/session"
+	"github.com/stripe/stripe-go/v82/coupon"
 	"github.com/stripe/stripe-go/v82/customer"
 	"github.com/stripe/stripe-go/v82/paymentintent"
 	"github.com/stripe/stripe-go/v82/paymentmethod"
 	"github.com/stripe/stripe-go/v82/refund"
+	"github.com/stripe/stripe-go/v82/setupintent"
 	"github.com/zenfulcode/commercify/config"
 	"github.com/zenfulcode/commercify/internal/domain/service"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
@@ -82,8 +86,8 @@ func (s *StripePaymentService) createPaymentMethodFromCard(cardDetails *service.
 	return pm.ID, nil
 }
 
-// createCustomer creates a customer in Stripe
-func (s *StripePaymentService) createCustomer(email string, name string) (string, error) {
+// CreateCustomer creates a customer in Stripe
+func (s *StripePaymentService) CreateCustomer(email string, name string) (string, error) {
 	if email == "" {
 		return "", errors.New("email is required to create customer")
 	}
@@ -113,6 +117,25 @@ func (s *StripePaymentService) ProcessPayment(request service.PaymentRequest) (*
 
 	switch request.PaymentMethod {
 	case service.PaymentMethodCreditCard:
+		// A saved card is charged on the customer it is attached to
+		if request.SavedPaymentMethodID != "" {
+			if request.CustomerID == "" {
+				return &service.PaymentResult{
+					Success:      false,
+					ErrorMessage: "a customer is required to pay with a saved payment method",
+					Provider:     service.PaymentProviderStripe,
+				}, nil
+			}
+			paymentMethodType = "card"
+			paymentMethodID = request.SavedPaymentMethodID
+			break
+		}
+
+		// Without card details the customer enters their card on a Stripe Checkout page
+		if request.CardDetails == nil && s.config.CheckoutEnabled {
+			return s.createCheckoutSession(request)
+		}
+
 		if request.CardDetails == nil {
 			return &service.PaymentResult{
 				Success:      false,
@@ -120,6 +143,13 @@ func (s *StripePaymentService) ProcessPayment(request service.PaymentRequest) (*
 				Provider:     service.PaymentProviderStripe,
 			}, nil
 		}
+		if request.CardDetails.Token == "" && !s.config.CardDetailsEnabled {
+			return &service.PaymentResult{
+				Success:      false,
+				ErrorMessage: "card details are not accepted, pay with a card token or through Stripe Checkout",
+				Provider:     service.PaymentProviderStripe,
+			}, nil
+		}
 		paymentMethodType = "card"
 
 		// Create payment method from card details or use token
@@ -177,30 +207,20 @@ func (s *StripePaymentService) ProcessPayment(request service.PaymentRequest) (*
 		},
 		ReturnURL: stripe.String(s.config.ReturnURL),
 	}
+	if request.IdempotencyKey != "" {
+		params.SetIdempotencyKey(request.IdempotencyKey)
+	}
 
-	// Create a customer if email is provided
 	if request.CustomerEmail != "" {
-		// First, attach email to receipt
 		params.ReceiptEmail = stripe.String(request.CustomerEmail)
+	}
 
-		// Then, create customer and attach to payment
-		customerName := ""
-		if request.CardDetails != nil && request.CardDetails.CardholderName != "" {
-			customerName = request.CardDetails.CardholderName
-		}
+	// Payments of registered users are associated with their customer, which cards can be saved to
+	if request.CustomerID != "" {
+		params.Customer = stripe.String(request.CustomerID)
 
-		customerID, err := s.createCustomer(request.CustomerEmail, customerName)
-		if err != nil {
-			s.logger.Warn("Failed to create customer, proceeding with payment: %v", err)
-			// Continue with payment, just without customer association
-		} else {
-			// Associate payment with customer
-			params.Customer = stripe.String(customerID)
-
-			// Save payment method for future use if it's a card
-			if paymentMethodType == "card" {
-				params.SetupFutureUsage = stripe.String("off_session")
-			}
+		if request.SavePaymentMethod && paymentMethodType == "card" && request.SavedPaymentMethodID == "" {
+			params.SetupFutureUsage = stripe.String(string(stripe.PaymentIntentSetupFutureUsageOffSession))
 		}
 	}
 
@@ -226,14 +246,18 @@ func (s *StripePaymentService) ProcessPayment(request service.PaymentRequest) (*
 		}, nil
 
 	case stripe.PaymentIntentStatusRequiresAction:
-
 		// Payment requires additional action (e.g., 3D Secure)
+		actionURL := ""
+		if paymentIntent.NextAction != nil && paymentIntent.NextAction.RedirectToURL != nil {
+			actionURL = paymentIntent.NextAction.RedirectToURL.URL
+		}
+
 		return &service.PaymentResult{
 			Success:        false,
 			TransactionID:  paymentIntent.ID,
 			ErrorMessage:   "payment requires additional action",
 			RequiresAction: true,
-			ActionURL:      paymentIntent.NextAction.RedirectToURL.URL,
+			ActionURL:      actionURL,
 			Provider:       service.PaymentProviderStripe,
 		}, nil
 
@@ -248,12 +272,173 @@ func (s *StripePaymentService) ProcessPayment(request service.PaymentRequest) (*
 	}
 }
 
+// createCheckoutSession creates a Stripe Checkout session for the order and returns its page as the action URL.
+// The returned transaction ID is the session ID; the payment intent is created when the customer pays.
+func (s *StripePaymentService) createCheckoutSession(request service.PaymentRequest) (*service.PaymentResult, error) {
+	currency := strings.ToLower(request.Currency)
+	orderID := fmt.Sprint(request.OrderID)
+
+	params := &stripe.CheckoutSessionParams{
+		Mode:              stripe.String(string(stripe.CheckoutSessionModePayment)),
+		ClientReferenceID: stripe.String(orderID),
+		SuccessURL:        stripe.String(withQuery(s.config.ReturnURL, "session_id={CHECKOUT_SESSION_ID}")),
+		CancelURL:         stripe.String(s.config.ReturnURL),
+		PaymentIntentData: &stripe.CheckoutSessionPaymentIntentDataParams{
+			Description: stripe.String(s.config.PaymentDescription),
+			Metadata: map[string]string{
+				"order_id": orderID,
+				"method":   "card",
+			},
+		},
+		Params: stripe.Params{
+			Metadata: map[string]string{
+				"order_id": orderID,
+			},
+		},
+	}
+
+	if request.CustomerEmail != "" {
+		params.CustomerEmail = stripe.String(request.CustomerEmail)
+	}
+	if request.IdempotencyKey != "" {
+		params.SetIdempotencyKey(request.IdempotencyKey)
+	}
+
+	if checkoutTotal(request) == request.Amount {
+		for _, item := range request.LineItems {
+			params.LineItems = append(params.LineItems, checkoutLineItem(item.Name, item.Quantity, item.UnitAmount, currency))
+		}
+
+		if request.TaxAmount > 0 {
+			params.LineItems = append(params.LineItems, checkoutLineItem("Tax", 1, request.TaxAmount, currency))
+		}
+
+		if request.ShippingAmount > 0 || request.ShippingName != "" {
+			shippingName := request.ShippingName
+			if shippingName == "" {
+				shippingName = "Shipping"
+			}
+			params.ShippingOptions = []*stripe.CheckoutSessionShippingOptionParams{
+				{
+					ShippingRateData: &stripe.CheckoutSessionShippingOptionShippingRateDataParams{
+						DisplayName: stripe.String(shippingName),
+						Type:        stripe.String("fixed_amount"),
+						FixedAmount: &stripe.CheckoutSessionShippingOptionShippingRateDataFixedAmountParams{
+							Amount:   stripe.Int64(request.ShippingAmount),
+							Currency: stripe.String(currency),
+						},
+					},
+				},
+			}
+		}
+
+		// Checkout applies discounts as coupons, so the order's discount becomes a single-use coupon
+		if request.DiscountAmount > 0 {
+			couponParams := &stripe.CouponParams{
+				AmountOff:      stripe.Int64(request.DiscountAmount),
+				Currency:       stripe.String(currency),
+				Duration:       stripe.String(string(stripe.CouponDurationOnce)),
+				MaxRedemptions: stripe.Int64(1),
+				Name:           stripe.String("Order discount"),
+			}
+			if request.IdempotencyKey != "" {
+				couponParams.SetIdempotencyKey(request.IdempotencyKey + "-coupon")
+			}
+
+			discount, err := coupon.New(couponParams)
+			if err != nil {
+				s.logger.Error("Failed to create Stripe coupon: %v", err)
+				return &service.PaymentResult{
+					Success:      false,
+					ErrorMessage: "failed to create checkout session: " + err.Error(),
+					Provider:     service.PaymentProviderStripe,
+				}, nil
+			}
+			params.Discounts = []*stripe.CheckoutSessionDiscountParams{{Coupon: stripe.String(discount.ID)}}
+		}
+	} else {
+		// The order lines don't add up to the amount, so the order is charged as one line
+		params.LineItems = []*stripe.CheckoutSessionLineItemParams{
+			checkoutLineItem(fmt.Sprintf("Order %d", request.OrderID), 1, request.Amount, currency),
+		}
+	}
+
+	checkoutSession, err := session.New(params)
+	if err != nil {
+		s.logger.Error("Failed to create Stripe checkout session: %v", err)
+		return &service.PaymentResult{
+			Success:      false,
+			ErrorMessage: "failed to create checkout session: " + err.Error(),
+			Provider:     service.PaymentProviderStripe,
+		}, nil
+	}
+
+	// The customer completes the payment on the Checkout page
+	return &service.PaymentResult{
+		Success:        false,
+		TransactionID:  checkoutSession.ID,
+		ErrorMessage:   "payment requires user action",
+		RequiresAction: true,
+		ActionURL:      checkoutSession.URL,
+		Provider:       service.PaymentProviderStripe,
+	}, nil
+}
+
+// checkoutTotal sums the order lines, shipping, tax and discount of a payment request
+func checkoutTotal(request service.PaymentRequest) int64 {
+	if len(request.LineItems) == 0 {
+		return 0
+	}
+
+	total := request.ShippingAmount + request.TaxAmount - request.DiscountAmount
+	for _, item := range request.LineItems {
+		total += item.UnitAmount * int64(item.Quantity)
+	}
+	return total
+}
+
+func checkoutLineItem(name string, quantity int, unitAmount int64, currency string) *stripe.CheckoutSessionLineItemParams {
+	return &stripe.CheckoutSessionLineItemParams{
+		PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+			Currency: stripe.String(currency),
+			ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+				Name: stripe.String(name),
+			},
+			UnitAmount: stripe.Int64(unitAmount),
+		},
+		Quantity: stripe.Int64(int64(quantity)),
+	}
+}
+
+// isCheckoutSession reports whether a transaction ID is a Checkout session rather than a payment intent
+func isCheckoutSession(transactionID string) bool {
+	return strings.HasPrefix(transactionID, "cs_")
+}
+
+// withQuery appends a query to a URL that may already have one
+func withQuery(url, query string) string {
+	if strings.Contains(url, "?") {
+		return url + "&" + query
+	}
+	return url + "?" + query
+}
+
 // VerifyPayment verifies a payment
 func (s *StripePaymentService) VerifyPayment(transactionID string, provider service.PaymentProviderType) (bool, error) {
 	if transactionID == "" {
 		return false, errors.New("transaction ID is required")
 	}
 
+	// Checkout sessions are paid once the customer completed the Checkout page
+	if isCheckoutSession(transactionID) {
+		checkoutSession, err := session.Get(transactionID, nil)
+		if err != nil {
+			s.logger.Error("Failed to retrieve Stripe checkout session: %v", err)
+			return false, fmt.Errorf("failed to verify payment: %w", err)
+		}
+		return checkoutSession.PaymentStatus == stripe.CheckoutSessionPaymentStatusPaid, nil
+	}
+
 	// Retrieve the payment intent from Stripe
 	paymentIntent, err := paymentintent.Get(transactionID, nil)
 	if err != nil {
@@ -272,6 +457,72 @@ func (s *StripePaymentService) VerifyPayment(transactionID string, provider serv
 	return false, nil
 }
 
+// GetPaymentStatus returns the state and amounts of a payment intent, or of the payment a Checkout session resulted in
+func (s *StripePaymentService) GetPaymentStatus(transactionID string, provider service.PaymentProviderType) (*service.PaymentStatus, error) {
+	if transactionID == "" {
+		return nil, errors.New("transaction ID is required")
+	}
+
+	if isCheckoutSession(transactionID) {
+		params := &stripe.CheckoutSessionParams{}
+		params.AddExpand("payment_intent")
+		params.AddExpand("payment_intent.latest_charge")
+
+		checkoutSession, err := session.Get(transactionID, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get payment status: %w", err)
+		}
+
+		if checkoutSession.PaymentIntent == nil {
+			if checkoutSession.Status == stripe.CheckoutSessionStatusExpired {
+				return &service.PaymentStatus{State: service.PaymentStateCancelled, Amount: checkoutSession.AmountTotal}, nil
+			}
+			return &service.PaymentStatus{State: service.PaymentStatePending, Amount: checkoutSession.AmountTotal}, nil
+		}
+
+		status := paymentIntentStatus(checkoutSession.PaymentIntent)
+		status.PaymentID = checkoutSession.PaymentIntent.ID
+		return status, nil
+	}
+
+	params := &stripe.PaymentIntentParams{}
+	params.AddExpand("latest_charge")
+
+	paymentIntent, err := paymentintent.Get(transactionID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment status: %w", err)
+	}
+
+	return paymentIntentStatus(paymentIntent), nil
+}
+
+// paymentIntentStatus maps a payment intent to the state of its payment
+func paymentIntentStatus(paymentIntent *stripe.PaymentIntent) *service.PaymentStatus {
+	status := &service.PaymentStatus{Amount: paymentIntent.Amount}
+
+	switch paymentIntent.Status {
+	case stripe.PaymentIntentStatusSucceeded:
+		status.State = service.PaymentStateCaptured
+		status.CapturedAmount = paymentIntent.AmountReceived
+		if paymentIntent.LatestCharge != nil {
+			status.RefundedAmount = paymentIntent.LatestCharge.AmountRefunded
+		}
+	case stripe.PaymentIntentStatusRequiresCapture:
+		status.State = service.PaymentStateAuthorized
+		// Partial captures of a multicapture payment leave the rest capturable
+		if paymentIntent.AmountReceived > 0 {
+			status.State = service.PaymentStateCaptured
+			status.CapturedAmount = paymentIntent.AmountReceived
+		}
+	case stripe.PaymentIntentStatusCanceled:
+		status.State = service.PaymentStateCancelled
+	default:
+		status.State = service.PaymentStatePending
+	}
+
+	return status
+}
+
 // RefundPayment refunds a payment
 func (s *StripePaymentService) RefundPayment(transactionID string, amount int64, provider service.PaymentProviderType) error {
 	if transactionID == "" {
@@ -301,8 +552,9 @@ func (s *StripePaymentService) RefundPayment(transactionID string, amount int64,
 	return nil
 }
 
-// CapturePayment captures a payment
-func (s *StripePaymentService) CapturePayment(transactionID string, amount int64, provider service.PaymentProviderType) error {
+// CapturePayment captures a payment. Captures that aren't final need multicapture
+// to be available for the payment intent.
+func (s *StripePaymentService) CapturePayment(transactionID string, amount int64, final bool, provider service.PaymentProviderType) error {
 	if transactionID == "" {
 		return errors.New("transaction ID is required")
 	}
@@ -313,6 +565,7 @@ func (s *StripePaymentService) CapturePayment(transactionID string, amount int64
 	// Create capture params
 	params := &stripe.PaymentIntentCaptureParams{
 		AmountToCapture: stripe.Int64(amount),
+		FinalCapture:    stripe.Bool(final),
 	}
 
 	// Capture the payment intent
@@ -335,6 +588,15 @@ func (s *StripePaymentService) CancelPayment(transactionID string, provider serv
 		return errors.New("transaction ID is required")
 	}
 
+	// A checkout session the customer has not completed is expired instead
+	if isCheckoutSession(transactionID) {
+		if _, err := session.Expire(transactionID, nil); err != nil {
+			s.logger.Error("Failed to expire Stripe checkout session: %v", err)
+			return fmt.Errorf("failed to cancel payment: %w", err)
+		}
+		return nil
+	}
+
 	// Create cancel params
 	params := &stripe.PaymentIntentCancelParams{}
 
@@ -356,9 +618,88 @@ func (s *StripePaymentService) ForceApprovePayment(transactionID string, phoneNu
 	return errors.New("not implemented")
 }
 
-// CreateSetupIntent creates a setup intent for saving a payment method without charging
-func (s *StripePaymentService) CreateSetupIntent(customerEmail string) (string, string, error) {
-	// This method could be used to save payment methods for future use
-	// Implementation would go here
-	return "", "", errors.New("not implemented")
+// ClientIntent is a payment or setup intent the storefront confirms with Stripe.js
+type ClientIntent struct {
+	ID           string `json:"id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// CreatePaymentIntent creates a payment intent for an order without confirming it.
+// The storefront confirms it with Stripe.js, and the payment_intent webhooks finalise the order.
+func (s *StripePaymentService) CreatePaymentIntent(request service.PaymentRequest, savePaymentMethod bool) (*ClientIntent, error) {
+	if request.Amount <= 0 {
+		return nil, errors.New("payment amount must be greater than zero")
+	}
+
+	params := &stripe.PaymentIntentParams{
+		Amount:      stripe.Int64(request.Amount),
+		Currency:    stripe.String(strings.ToLower(request.Currency)),
+		Description: stripe.String(s.config.PaymentDescription),
+		AutomaticPaymentMethods: &stripe.PaymentIntentAutomaticPaymentMethodsParams{
+			Enabled: stripe.Bool(true),
+		},
+		Params: stripe.Params{
+			Metadata: map[string]string{
+				"order_id": fmt.Sprint(request.OrderID),
+				"method":   "card",
+			},
+		},
+	}
+
+	if request.CustomerEmail != "" {
+		params.ReceiptEmail = stripe.String(request.CustomerEmail)
+	}
+
+	if request.CustomerID != "" {
+		params.Customer = stripe.String(request.CustomerID)
+	}
+	if request.IdempotencyKey != "" {
+		params.SetIdempotencyKey(request.IdempotencyKey)
+	}
+
+	// Saving the payment method requires a customer to attach it to
+	if savePaymentMethod {
+		if request.CustomerID == "" {
+			return nil, errors.New("a customer is required to save the payment method")
+		}
+		params.SetupFutureUsage = stripe.String(string(stripe.PaymentIntentSetupFutureUsageOffSession))
+	}
+
+	paymentIntent, err := paymentintent.New(params)
+	if err != nil {
+		s.logger.Error("Failed to create Stripe payment intent: %v", err)
+		return nil, fmt.Errorf("failed to create payment intent: %w", err)
+	}
+
+	return &ClientIntent{ID: paymentIntent.ID, ClientSecret: paymentIntent.ClientSecret}, nil
+}
+
+// CreateSetupIntent creates a setup intent for saving a payment method to a customer without charging
+func (s *StripePaymentService) CreateSetupIntent(customerID string) (*ClientIntent, error) {
+	if customerID == "" {
+		return nil, errors.New("a customer is required to save the payment method")
+	}
+
+	setupIntent, err := setupintent.New(&stripe.SetupIntentParams{
+		Customer: stripe.String(customerID),
+		Usage:    stripe.String(string(stripe.SetupIntentUsageOffSession)),
+		AutomaticPaymentMethods: &stripe.SetupIntentAutomaticPaymentMethodsParams{
+			Enabled: stripe.Bool(true),
+		},
+	})
+	if err != nil {
+		s.logger.Error("Failed to create Stripe setup intent: %v", err)
+		return nil, fmt.Errorf("failed to create setup intent: %w", err)
+	}
+
+	return &ClientIntent{ID: setupIntent.ID, ClientSecret: setupIntent.ClientSecret}, nil
+}
+
+// DetachPaymentMethod detaches a saved payment method from its customer
+func (s *StripePaymentService) DetachPaymentMethod(methodID string) error {
+	if _, err := paymentmethod.Detach(methodID, nil); err != nil {
+		s.logger.Error("Failed to detach Stripe payment method: %v", err)
+		return fmt.Errorf("failed to detach payment method: %w", err)
+	}
+	return nil
 }
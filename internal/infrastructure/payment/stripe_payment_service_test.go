@@ -0,0 +1,417 @@
+package payment_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stripe/stripe-go/v82"
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/infrastructure/payment"
+)
+
+// newStripeServer points the Stripe client at a local stub recording the form of each request
+func newStripeServer(t *testing.T, requests map[string]url.Values) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		r.Form.Set("Idempotency-Key", r.Header.Get("Idempotency-Key"))
+		requests[r.Method+" "+r.URL.Path] = r.Form
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/customers":
+			w.Write([]byte(`{"id": "cus_new", "object": "customer"}`))
+		case "POST /v1/payment_intents":
+			switch r.Form.Get("payment_method") {
+			case "pm_saved":
+				w.Write([]byte(`{"id": "pi_test_1", "object": "payment_intent", "status": "succeeded"}`))
+			case "pm_3ds":
+				w.Write([]byte(`{"id": "pi_test_1", "object": "payment_intent", "status": "requires_action", "next_action": {"type": "redirect_to_url", "redirect_to_url": {"url": "https://hooks.stripe.com/3d_secure/pi_test_1"}}}`))
+			default:
+				w.Write([]byte(`{"id": "pi_test_1", "object": "payment_intent", "client_secret": "pi_test_1_secret_abc", "status": "requires_payment_method"}`))
+			}
+		case "POST /v1/setup_intents":
+			w.Write([]byte(`{"id": "seti_test_1", "object": "setup_intent", "client_secret": "seti_test_1_secret_abc"}`))
+		case "POST /v1/coupons":
+			w.Write([]byte(`{"id": "coupon_1", "object": "coupon"}`))
+		case "GET /v1/payment_intents/pi_test_1":
+			w.Write([]byte(`{"id": "pi_test_1", "object": "payment_intent", "status": "succeeded", "amount": 2550, "amount_received": 2550, "latest_charge": {"id": "ch_1", "object": "charge", "amount_refunded": 500}}`))
+		case "GET /v1/checkout/sessions/cs_test_1":
+			w.Write([]byte(`{"id": "cs_test_1", "object": "checkout.session", "status": "complete", "amount_total": 2550, "payment_intent": {"id": "pi_test_2", "object": "payment_intent", "status": "requires_capture", "amount": 2550}}`))
+		case "GET /v1/checkout/sessions/cs_test_2":
+			w.Write([]byte(`{"id": "cs_test_2", "object": "checkout.session", "status": "expired", "amount_total": 2550}`))
+		case "POST /v1/checkout/sessions":
+			w.Write([]byte(`{"id": "cs_test_1", "object": "checkout.session", "url": "https://checkout.stripe.com/c/pay/cs_test_1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	stripe.SetBackend(stripe.APIBackend, stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+		URL:               stripe.String(server.URL),
+		MaxNetworkRetries: stripe.Int64(0),
+		LeveledLogger:     &stripe.LeveledLogger{Level: stripe.LevelNull},
+	}))
+
+	t.Cleanup(func() {
+		stripe.SetBackend(stripe.APIBackend, nil)
+		server.Close()
+	})
+}
+
+func newStripeService(checkout, cardDetails bool) *payment.StripePaymentService {
+	return payment.NewStripePaymentService(config.StripeConfig{
+		SecretKey:          "sk_test_key",
+		PaymentDescription: "Commercify Store Purchase",
+		ReturnURL:          "https://shop.example/checkout/complete",
+		CheckoutEnabled:    checkout,
+		CardDetailsEnabled: cardDetails,
+		Enabled:            true,
+	}, logger.NewLogger())
+}
+
+func TestStripePaymentService_CheckoutSession(t *testing.T) {
+	request := service.PaymentRequest{
+		OrderID:       42,
+		Amount:        5350,
+		Currency:      "EUR",
+		PaymentMethod: service.PaymentMethodCreditCard,
+		CustomerEmail: "jane@example.com",
+		LineItems: []service.PaymentLineItem{
+			{Name: "T-shirt", SKU: "TS-1", Quantity: 2, UnitAmount: 2000},
+			{Name: "Socks", SKU: "SO-1", Quantity: 1, UnitAmount: 800},
+		},
+		ShippingName:   "Express",
+		ShippingAmount: 750,
+		DiscountAmount: 500,
+		TaxAmount:      300,
+	}
+
+	t.Run("Line items, shipping, tax and discount", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(true, true)
+
+		result, err := stripeService.ProcessPayment(request)
+
+		assert.NoError(t, err)
+		assert.True(t, result.RequiresAction)
+		assert.Equal(t, "cs_test_1", result.TransactionID)
+		assert.Equal(t, "https://checkout.stripe.com/c/pay/cs_test_1", result.ActionURL)
+
+		coupon := requests["POST /v1/coupons"]
+		assert.Equal(t, "500", coupon.Get("amount_off"))
+		assert.Equal(t, "eur", coupon.Get("currency"))
+
+		form := requests["POST /v1/checkout/sessions"]
+		assert.Equal(t, "payment", form.Get("mode"))
+		assert.Equal(t, "42", form.Get("metadata[order_id]"))
+		assert.Equal(t, "42", form.Get("payment_intent_data[metadata][order_id]"))
+		assert.Equal(t, "https://shop.example/checkout/complete?session_id={CHECKOUT_SESSION_ID}", form.Get("success_url"))
+		assert.Equal(t, "jane@example.com", form.Get("customer_email"))
+		assert.Equal(t, "T-shirt", form.Get("line_items[0][price_data][product_data][name]"))
+		assert.Equal(t, "2000", form.Get("line_items[0][price_data][unit_amount]"))
+		assert.Equal(t, "2", form.Get("line_items[0][quantity]"))
+		assert.Equal(t, "Socks", form.Get("line_items[1][price_data][product_data][name]"))
+		assert.Equal(t, "Tax", form.Get("line_items[2][price_data][product_data][name]"))
+		assert.Equal(t, "300", form.Get("line_items[2][price_data][unit_amount]"))
+		assert.Equal(t, "Express", form.Get("shipping_options[0][shipping_rate_data][display_name]"))
+		assert.Equal(t, "750", form.Get("shipping_options[0][shipping_rate_data][fixed_amount][amount]"))
+		assert.Equal(t, "coupon_1", form.Get("discounts[0][coupon]"))
+	})
+
+	t.Run("Idempotency key", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(true, true)
+
+		retried := request
+		retried.IdempotencyKey = "order-42-key-1"
+
+		_, err := stripeService.ProcessPayment(retried)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "order-42-key-1-coupon", requests["POST /v1/coupons"].Get("Idempotency-Key"))
+		assert.Equal(t, "order-42-key-1", requests["POST /v1/checkout/sessions"].Get("Idempotency-Key"))
+	})
+
+	t.Run("Lines not adding up to the amount", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(true, true)
+
+		mismatched := request
+		mismatched.Amount = 6000
+
+		_, err := stripeService.ProcessPayment(mismatched)
+
+		assert.NoError(t, err)
+		assert.NotContains(t, requests, "POST /v1/coupons")
+
+		form := requests["POST /v1/checkout/sessions"]
+		assert.Equal(t, "Order 42", form.Get("line_items[0][price_data][product_data][name]"))
+		assert.Equal(t, "6000", form.Get("line_items[0][price_data][unit_amount]"))
+		assert.Empty(t, form.Get("line_items[1][price_data][product_data][name]"))
+		assert.Empty(t, form.Get("shipping_options[0][shipping_rate_data][display_name]"))
+	})
+
+	t.Run("Raw card details disabled", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(true, false)
+
+		withCard := request
+		withCard.CardDetails = &service.CardDetails{CardNumber: "4242424242424242", ExpiryMonth: 12, ExpiryYear: 2030, CVV: "123"}
+
+		result, err := stripeService.ProcessPayment(withCard)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.Equal(t, "card details are not accepted, pay with a card token or through Stripe Checkout", result.ErrorMessage)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("Checkout disabled", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, true)
+
+		result, err := stripeService.ProcessPayment(request)
+
+		assert.NoError(t, err)
+		assert.False(t, result.RequiresAction)
+		assert.Equal(t, "card details are required for credit card payment", result.ErrorMessage)
+		assert.Empty(t, requests)
+	})
+}
+
+func TestStripePaymentService_ClientIntents(t *testing.T) {
+	request := service.PaymentRequest{
+		OrderID:       42,
+		Amount:        2550,
+		Currency:      "EUR",
+		PaymentMethod: service.PaymentMethodCreditCard,
+		CustomerEmail: "jane@example.com",
+	}
+
+	t.Run("Payment intent", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, false)
+
+		intent, err := stripeService.CreatePaymentIntent(request, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pi_test_1", intent.ID)
+		assert.Equal(t, "pi_test_1_secret_abc", intent.ClientSecret)
+
+		form := requests["POST /v1/payment_intents"]
+		assert.Equal(t, "2550", form.Get("amount"))
+		assert.Equal(t, "eur", form.Get("currency"))
+		assert.Equal(t, "42", form.Get("metadata[order_id]"))
+		assert.Equal(t, "true", form.Get("automatic_payment_methods[enabled]"))
+		assert.Empty(t, form.Get("confirm"))
+		assert.Empty(t, form.Get("customer"))
+	})
+
+	t.Run("Payment intent with an idempotency key", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, false)
+
+		retried := request
+		retried.IdempotencyKey = "order-42-key-1"
+
+		_, err := stripeService.CreatePaymentIntent(retried, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "order-42-key-1", requests["POST /v1/payment_intents"].Get("Idempotency-Key"))
+	})
+
+	t.Run("Payment intent saving the card", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, false)
+
+		withCustomer := request
+		withCustomer.CustomerID = "cus_known"
+
+		_, err := stripeService.CreatePaymentIntent(withCustomer, true)
+
+		assert.NoError(t, err)
+		form := requests["POST /v1/payment_intents"]
+		assert.Equal(t, "cus_known", form.Get("customer"))
+		assert.Equal(t, "off_session", form.Get("setup_future_usage"))
+		assert.NotContains(t, requests, "POST /v1/customers")
+	})
+
+	t.Run("Saving the card without a customer", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, false)
+
+		intent, err := stripeService.CreatePaymentIntent(request, true)
+
+		assert.EqualError(t, err, "a customer is required to save the payment method")
+		assert.Nil(t, intent)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("Setup intent", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, false)
+
+		intent, err := stripeService.CreateSetupIntent("cus_known")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "seti_test_1_secret_abc", intent.ClientSecret)
+
+		form := requests["POST /v1/setup_intents"]
+		assert.Equal(t, "cus_known", form.Get("customer"))
+		assert.Equal(t, "off_session", form.Get("usage"))
+	})
+
+	t.Run("Customer", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, false)
+
+		customerID, err := stripeService.CreateCustomer("new@example.com", "Jane Doe")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cus_new", customerID)
+		assert.Equal(t, "new@example.com", requests["POST /v1/customers"].Get("email"))
+		assert.Equal(t, "Jane Doe", requests["POST /v1/customers"].Get("name"))
+	})
+}
+
+func TestStripePaymentService_SavedPaymentMethod(t *testing.T) {
+	request := service.PaymentRequest{
+		OrderID:              42,
+		Amount:               2550,
+		Currency:             "EUR",
+		PaymentMethod:        service.PaymentMethodCreditCard,
+		CustomerEmail:        "jane@example.com",
+		CustomerID:           "cus_known",
+		SavedPaymentMethodID: "pm_saved",
+	}
+
+	t.Run("Charges the saved card", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(true, false)
+
+		result, err := stripeService.ProcessPayment(request)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, "pi_test_1", result.TransactionID)
+
+		form := requests["POST /v1/payment_intents"]
+		assert.Equal(t, "pm_saved", form.Get("payment_method"))
+		assert.Equal(t, "cus_known", form.Get("customer"))
+		assert.Equal(t, "true", form.Get("confirm"))
+		assert.Empty(t, form.Get("setup_future_usage"))
+		assert.NotContains(t, requests, "POST /v1/checkout/sessions")
+	})
+
+	t.Run("3-D Secure", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, false)
+
+		challenged := request
+		challenged.SavedPaymentMethodID = "pm_3ds"
+
+		result, err := stripeService.ProcessPayment(challenged)
+
+		assert.NoError(t, err)
+		assert.True(t, result.RequiresAction)
+		assert.Equal(t, "https://hooks.stripe.com/3d_secure/pi_test_1", result.ActionURL)
+		assert.Equal(t, "https://shop.example/checkout/complete", requests["POST /v1/payment_intents"].Get("return_url"))
+	})
+
+	t.Run("Without a customer", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, false)
+
+		withoutCustomer := request
+		withoutCustomer.CustomerID = ""
+
+		result, err := stripeService.ProcessPayment(withoutCustomer)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.Equal(t, "a customer is required to pay with a saved payment method", result.ErrorMessage)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("Saving an entered card", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, false)
+
+		entered := request
+		entered.SavedPaymentMethodID = ""
+		entered.SavePaymentMethod = true
+		entered.CardDetails = &service.CardDetails{Token: "pm_card_visa"}
+
+		_, err := stripeService.ProcessPayment(entered)
+
+		assert.NoError(t, err)
+		form := requests["POST /v1/payment_intents"]
+		assert.Equal(t, "pm_card_visa", form.Get("payment_method"))
+		assert.Equal(t, "cus_known", form.Get("customer"))
+		assert.Equal(t, "off_session", form.Get("setup_future_usage"))
+		assert.NotContains(t, requests, "POST /v1/customers")
+	})
+}
+
+func TestStripePaymentService_GetPaymentStatus(t *testing.T) {
+	t.Run("Payment intent", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(false, true)
+
+		status, err := stripeService.GetPaymentStatus("pi_test_1", service.PaymentProviderStripe)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &service.PaymentStatus{
+			State:          service.PaymentStateCaptured,
+			Amount:         2550,
+			CapturedAmount: 2550,
+			RefundedAmount: 500,
+		}, status)
+		assert.Equal(t, "latest_charge", requests["GET /v1/payment_intents/pi_test_1"].Get("expand[0]"))
+	})
+
+	t.Run("Paid checkout session", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(true, true)
+
+		status, err := stripeService.GetPaymentStatus("cs_test_1", service.PaymentProviderStripe)
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.PaymentStateAuthorized, status.State)
+		assert.Equal(t, "pi_test_2", status.PaymentID)
+		assert.Equal(t, int64(2550), status.Amount)
+	})
+
+	t.Run("Expired checkout session", func(t *testing.T) {
+		requests := map[string]url.Values{}
+		newStripeServer(t, requests)
+		stripeService := newStripeService(true, true)
+
+		status, err := stripeService.GetPaymentStatus("cs_test_2", service.PaymentProviderStripe)
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.PaymentStateCancelled, status.State)
+	})
+}
@@ -0,0 +1,320 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+)
+
+// Layout of the invoice in points
+const (
+	marginLeft   = 50.0
+	marginRight  = PageWidth - 50
+	marginBottom = 60.0
+	lineHeight   = 14.0
+	fontSize     = 10.0
+)
+
+// SellerDetails identify the store on its invoices
+type SellerDetails struct {
+	Name    string
+	Address string
+	VATID   string
+}
+
+// InvoiceRenderer renders invoices and credit notes as PDF documents
+type InvoiceRenderer struct {
+	seller SellerDetails
+	locale string
+}
+
+// NewInvoiceRenderer creates a new InvoiceRenderer.
+// Amounts are formatted using the conventions of the locale, e.g. da-DK.
+func NewInvoiceRenderer(seller SellerDetails, locale string) *InvoiceRenderer {
+	return &InvoiceRenderer{
+		seller: seller,
+		locale: locale,
+	}
+}
+
+// ContentType returns the media type of the rendered documents
+func (r *InvoiceRenderer) ContentType() string {
+	return "application/pdf"
+}
+
+// Render renders an invoice or credit note of an order
+func (r *InvoiceRenderer) Render(invoice *entity.Invoice, order *entity.Order) ([]byte, error) {
+	if invoice.Number == "" {
+		return nil, fmt.Errorf("invoice %d has no number", invoice.ID)
+	}
+
+	p := &page{doc: NewDocument(), y: PageHeight - 60}
+
+	// Title and invoice details
+	title := "Invoice"
+	if invoice.IsCreditNote() {
+		title = "Credit Note"
+	}
+	p.doc.Text(marginLeft, p.y, 20, true, title)
+	p.next(2)
+
+	details := [][2]string{
+		{"Number", invoice.Number},
+		{"Date", invoice.CreatedAt.Format("2006-01-02")},
+		{"Order", orderReference(order)},
+	}
+	if invoice.IsCreditNote() {
+		details = append(details, [2]string{"Credited invoice", invoice.InvoiceNumber})
+	}
+	for _, detail := range details {
+		p.doc.Text(marginLeft, p.y, fontSize, true, detail[0]+":")
+		p.doc.Text(marginLeft+100, p.y, fontSize, false, detail[1])
+		p.next(1)
+	}
+	p.next(1)
+
+	// Seller and customer
+	top := p.y
+	p.doc.Text(marginLeft, p.y, fontSize, true, "From")
+	p.next(1)
+	for _, line := range r.sellerLines() {
+		p.doc.Text(marginLeft, p.y, fontSize, false, line)
+		p.next(1)
+	}
+	sellerBottom := p.y
+
+	p.y = top
+	p.doc.Text(PageWidth/2, p.y, fontSize, true, "Bill to")
+	p.next(1)
+	for _, line := range customerLines(order) {
+		p.doc.Text(PageWidth/2, p.y, fontSize, false, line)
+		p.next(1)
+	}
+	p.y = min(p.y, sellerBottom)
+	p.next(1)
+
+	if invoice.IsCreditNote() {
+		r.renderCreditNoteLines(p, invoice, order)
+	} else {
+		r.renderInvoiceLines(p, invoice, order)
+	}
+
+	if invoice.ReverseCharge {
+		p.next(1)
+		for _, line := range wrap(entity.ReverseChargeNote, marginRight-marginLeft, 9) {
+			p.ensure(1)
+			p.doc.Text(marginLeft, p.y, 9, false, line)
+			p.next(1)
+		}
+	}
+
+	return p.doc.Bytes(), nil
+}
+
+// renderInvoiceLines renders the order's items and totals
+func (r *InvoiceRenderer) renderInvoiceLines(p *page, invoice *entity.Invoice, order *entity.Order) {
+	columns := []float64{marginLeft, 320, 400, 470, marginRight}
+
+	header := func() {
+		p.doc.Text(columns[0], p.y, fontSize, true, "Description")
+		p.doc.TextRight(columns[1]+30, p.y, fontSize, true, "Qty")
+		p.doc.TextRight(columns[2]+20, p.y, fontSize, true, "Unit price")
+		p.doc.TextRight(columns[3]+10, p.y, fontSize, true, "Tax")
+		p.doc.TextRight(columns[4], p.y, fontSize, true, "Amount")
+		p.next(0.5)
+		p.doc.Line(marginLeft, p.y, marginRight, p.y)
+		p.next(1)
+	}
+	header()
+
+	for _, item := range order.Items {
+		if p.ensure(1) {
+			header()
+		}
+
+		name := item.ProductName
+		if name == "" {
+			name = fmt.Sprintf("Product #%d", item.ProductID)
+		}
+		if item.SKU != "" {
+			name += " (" + item.SKU + ")"
+		}
+
+		p.doc.Text(columns[0], p.y, fontSize, false, truncate(name, columns[1]-columns[0]-10))
+		p.doc.TextRight(columns[1]+30, p.y, fontSize, false, fmt.Sprintf("%d", item.Quantity))
+		p.doc.TextRight(columns[2]+20, p.y, fontSize, false, r.format(item.Price, invoice.Currency))
+		p.doc.TextRight(columns[3]+10, p.y, fontSize, false, fmt.Sprintf("%g%%", item.TaxRate))
+		p.doc.TextRight(columns[4], p.y, fontSize, false, r.format(item.Subtotal, invoice.Currency))
+		p.next(1)
+	}
+
+	p.next(0.5)
+	p.doc.Line(marginLeft, p.y+lineHeight/2, marginRight, p.y+lineHeight/2)
+
+	totals := [][2]string{{"Subtotal", r.format(order.TotalAmount, invoice.Currency)}}
+	if order.DiscountAmount > 0 {
+		totals = append(totals, [2]string{"Discount", "-" + r.format(order.DiscountAmount, invoice.Currency)})
+	}
+	if order.ShippingCost > 0 {
+		totals = append(totals, [2]string{fmt.Sprintf("Shipping (tax %g%%)", order.ShippingTaxRate), r.format(order.ShippingCost, invoice.Currency)})
+	}
+	if order.ShippingDiscountAmount > 0 {
+		totals = append(totals, [2]string{"Shipping discount", "-" + r.format(order.ShippingDiscountAmount, invoice.Currency)})
+	}
+	if order.PricesIncludeTax {
+		totals = append(totals, [2]string{"Total", r.format(invoice.Amount, invoice.Currency)})
+		totals = append(totals, [2]string{"Including tax", r.format(invoice.TaxAmount, invoice.Currency)})
+	} else {
+		totals = append(totals, [2]string{"Tax", r.format(invoice.TaxAmount, invoice.Currency)})
+		totals = append(totals, [2]string{"Total", r.format(invoice.Amount, invoice.Currency)})
+	}
+
+	r.renderTotals(p, totals)
+}
+
+// renderCreditNoteLines renders the credited amount and its tax
+func (r *InvoiceRenderer) renderCreditNoteLines(p *page, invoice *entity.Invoice, order *entity.Order) {
+	p.doc.Text(marginLeft, p.y, fontSize, true, "Description")
+	p.doc.TextRight(marginRight, p.y, fontSize, true, "Amount")
+	p.next(0.5)
+	p.doc.Line(marginLeft, p.y, marginRight, p.y)
+	p.next(1)
+
+	description := fmt.Sprintf("Refund for order %s, invoice %s", orderReference(order), invoice.InvoiceNumber)
+	p.doc.Text(marginLeft, p.y, fontSize, false, description)
+	p.doc.TextRight(marginRight, p.y, fontSize, false, "-"+r.format(invoice.Amount, invoice.Currency))
+	p.next(1.5)
+
+	r.renderTotals(p, [][2]string{
+		{"Net", "-" + r.format(invoice.Amount-invoice.TaxAmount, invoice.Currency)},
+		{"Tax", "-" + r.format(invoice.TaxAmount, invoice.Currency)},
+		{"Total credited", "-" + r.format(invoice.Amount, invoice.Currency)},
+	})
+}
+
+// renderTotals renders labelled amounts aligned right, the last one in bold
+func (r *InvoiceRenderer) renderTotals(p *page, totals [][2]string) {
+	for i, total := range totals {
+		p.ensure(1)
+		bold := i == len(totals)-1
+		p.doc.TextRight(470, p.y, fontSize, bold, total[0])
+		p.doc.TextRight(marginRight, p.y, fontSize, bold, total[1])
+		p.next(1)
+	}
+}
+
+// sellerLines returns the store's name, address and VAT number
+func (r *InvoiceRenderer) sellerLines() []string {
+	lines := nonEmpty(r.seller.Name)
+	lines = append(lines, splitAddress(r.seller.Address)...)
+	if r.seller.VATID != "" {
+		lines = append(lines, "VAT number: "+r.seller.VATID)
+	}
+	return lines
+}
+
+// format formats an amount in the renderer's locale
+func (r *InvoiceRenderer) format(amount int64, currency string) string {
+	return money.New(amount, currency).Format(r.locale)
+}
+
+// customerLines returns the customer's name, billing address and VAT number
+func customerLines(order *entity.Order) []string {
+	addr := order.BillingAddr
+	if addr.Street == "" {
+		addr = order.ShippingAddr
+	}
+
+	company := order.CustomerDetails.CompanyName
+	if company == "" {
+		company = addr.Company
+	}
+
+	lines := nonEmpty(company, order.CustomerDetails.FullName, addr.Street)
+	lines = append(lines, nonEmpty(strings.TrimSpace(addr.PostalCode+" "+addr.City), addr.State, addr.Country)...)
+	if order.CustomerDetails.VATID != "" {
+		lines = append(lines, "VAT number: "+order.CustomerDetails.VATID)
+	}
+	return lines
+}
+
+// orderReference returns the order number, or the order ID of orders without one
+func orderReference(order *entity.Order) string {
+	if order.OrderNumber != "" {
+		return order.OrderNumber
+	}
+	return fmt.Sprintf("#%d", order.ID)
+}
+
+// splitAddress splits an address on line breaks or semicolons
+func splitAddress(address string) []string {
+	return nonEmpty(strings.FieldsFunc(address, func(r rune) bool {
+		return r == '\n' || r == ';'
+	})...)
+}
+
+// nonEmpty returns the values that are not blank, trimmed
+func nonEmpty(values ...string) []string {
+	lines := []string{}
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			lines = append(lines, value)
+		}
+	}
+	return lines
+}
+
+// truncate shortens text to fit a width at the default font size
+func truncate(text string, width float64) string {
+	if TextWidth(text, fontSize, false) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && TextWidth(string(runes)+"...", fontSize, false) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// wrap breaks text into lines that fit a width
+func wrap(text string, width, size float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := strings.TrimSpace(line + " " + word)
+		if line != "" && TextWidth(candidate, size, false) > width {
+			lines = append(lines, line)
+			candidate = word
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// page tracks the vertical position on the document's current page
+type page struct {
+	doc *Document
+	y   float64
+}
+
+// next moves down a number of lines
+func (p *page) next(lines float64) {
+	p.y -= lines * lineHeight
+}
+
+// ensure starts a new page when fewer than the given lines fit, and reports whether it did
+func (p *page) ensure(lines float64) bool {
+	if p.y-lines*lineHeight >= marginBottom {
+		return false
+	}
+
+	p.doc.AddPage()
+	p.y = PageHeight - 60
+	return true
+}
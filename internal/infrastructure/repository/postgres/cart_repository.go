@@ -117,7 +117,7 @@ func (r *CartRepository) Create(cart *entity.Cart) error {
 func (r *CartRepository) GetByUserID(userID uint) (*entity.Cart, error) {
 	// Get cart
 	query := `
-		SELECT id, user_id, created_at, updated_at
+		SELECT id, user_id, discount_code, created_at, updated_at
 		FROM carts
 		WHERE user_id = $1
 	`
@@ -126,6 +126,7 @@ func (r *CartRepository) GetByUserID(userID uint) (*entity.Cart, error) {
 	err := r.db.QueryRow(query, userID).Scan(
 		&cart.ID,
 		&cart.UserID,
+		&cart.DiscountCode,
 		&cart.CreatedAt,
 		&cart.UpdatedAt,
 	)
@@ -183,7 +184,7 @@ func (r *CartRepository) GetByUserID(userID uint) (*entity.Cart, error) {
 func (r *CartRepository) GetBySessionID(sessionID string) (*entity.Cart, error) {
 	// Get cart
 	query := `
-		SELECT id, session_id, created_at, updated_at
+		SELECT id, session_id, discount_code, created_at, updated_at
 		FROM carts
 		WHERE session_id = $1
 	`
@@ -192,6 +193,7 @@ func (r *CartRepository) GetBySessionID(sessionID string) (*entity.Cart, error)
 	err := r.db.QueryRow(query, sessionID).Scan(
 		&cart.ID,
 		&cart.SessionID,
+		&cart.DiscountCode,
 		&cart.CreatedAt,
 		&cart.UpdatedAt,
 	)
@@ -260,7 +262,8 @@ func (r *CartRepository) Update(cart *entity.Cart) error {
 
 	// Update cart
 	_, err = tx.Exec(
-		"UPDATE carts SET updated_at = $1 WHERE id = $2",
+		"UPDATE carts SET discount_code = $1, updated_at = $2 WHERE id = $3",
+		cart.DiscountCode,
 		cart.UpdatedAt,
 		cart.ID,
 	)
@@ -376,6 +379,11 @@ func (r *CartRepository) ConvertGuestCartToUserCart(sessionID string, userID uin
 			}
 		}
 
+		// Keep the guest's discount code if the user cart has none
+		if userCart.DiscountCode == "" {
+			userCart.DiscountCode = guestCart.DiscountCode
+		}
+
 		// Update the user cart
 		err = r.Update(userCart)
 		if err != nil {
@@ -22,8 +22,8 @@ func NewCategoryRepository(db *sql.DB) repository.CategoryRepository {
 // Create creates a new category
 func (r *CategoryRepository) Create(category *entity.Category) error {
 	query := `
-		INSERT INTO categories (name, description, parent_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO categories (name, description, parent_id, shipping_profile_id, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, 0), $5, $6)
 		RETURNING id
 	`
 
@@ -32,6 +32,7 @@ func (r *CategoryRepository) Create(category *entity.Category) error {
 		category.Name,
 		category.Description,
 		category.ParentID,
+		category.ShippingProfileID,
 		category.CreatedAt,
 		category.UpdatedAt,
 	).Scan(&category.ID)
@@ -42,7 +43,7 @@ func (r *CategoryRepository) Create(category *entity.Category) error {
 // GetByID retrieves a category by ID
 func (r *CategoryRepository) GetByID(id uint) (*entity.Category, error) {
 	query := `
-		SELECT id, name, description, parent_id, created_at, updated_at
+		SELECT id, name, description, parent_id, COALESCE(shipping_profile_id, 0), created_at, updated_at
 		FROM categories
 		WHERE id = $1
 	`
@@ -55,6 +56,7 @@ func (r *CategoryRepository) GetByID(id uint) (*entity.Category, error) {
 		&category.Name,
 		&category.Description,
 		&parentID,
+		&category.ShippingProfileID,
 		&category.CreatedAt,
 		&category.UpdatedAt,
 	)
@@ -79,8 +81,8 @@ func (r *CategoryRepository) GetByID(id uint) (*entity.Category, error) {
 func (r *CategoryRepository) Update(category *entity.Category) error {
 	query := `
 		UPDATE categories
-		SET name = $1, description = $2, parent_id = $3, updated_at = $4
-		WHERE id = $5
+		SET name = $1, description = $2, parent_id = $3, shipping_profile_id = NULLIF($4, 0), updated_at = $5
+		WHERE id = $6
 	`
 
 	_, err := r.db.Exec(
@@ -88,6 +90,7 @@ func (r *CategoryRepository) Update(category *entity.Category) error {
 		category.Name,
 		category.Description,
 		category.ParentID,
+		category.ShippingProfileID,
 		time.Now(),
 		category.ID,
 	)
@@ -105,7 +108,7 @@ func (r *CategoryRepository) Delete(id uint) error {
 // List retrieves all categories
 func (r *CategoryRepository) List() ([]*entity.Category, error) {
 	query := `
-		SELECT id, name, description, parent_id, created_at, updated_at
+		SELECT id, name, description, parent_id, COALESCE(shipping_profile_id, 0), created_at, updated_at
 		FROM categories
 		ORDER BY name
 	`
@@ -126,6 +129,7 @@ func (r *CategoryRepository) List() ([]*entity.Category, error) {
 			&category.Name,
 			&category.Description,
 			&parentID,
+			&category.ShippingProfileID,
 			&category.CreatedAt,
 			&category.UpdatedAt,
 		)
@@ -147,7 +151,7 @@ func (r *CategoryRepository) List() ([]*entity.Category, error) {
 // GetChildren retrieves child categories for a parent category
 func (r *CategoryRepository) GetChildren(parentID uint) ([]*entity.Category, error) {
 	query := `
-		SELECT id, name, description, parent_id, created_at, updated_at
+		SELECT id, name, description, parent_id, COALESCE(shipping_profile_id, 0), created_at, updated_at
 		FROM categories
 		WHERE parent_id = $1
 		ORDER BY name
@@ -169,6 +173,7 @@ func (r *CategoryRepository) GetChildren(parentID uint) ([]*entity.Category, err
 			&category.Name,
 			&category.Description,
 			&parentIDNull,
+			&category.ShippingProfileID,
 			&category.CreatedAt,
 			&category.UpdatedAt,
 		)
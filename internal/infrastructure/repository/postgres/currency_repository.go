@@ -291,7 +291,8 @@ func (r *CurrencyRepository) SetDefault(code string) error {
 // GetProductPrices retrieves all prices for a product in different currencies
 func (r *CurrencyRepository) GetProductPrices(productID uint) ([]entity.ProductPrice, error) {
 	query := `
-		SELECT id, product_id, currency_code, price, created_at, updated_at
+		SELECT id, product_id, currency_code, price, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at
 		FROM product_prices
 		WHERE product_id = $1
 	`
@@ -313,6 +314,10 @@ func (r *CurrencyRepository) GetProductPrices(productID uint) ([]entity.ProductP
 			&price.Price,
 			&price.CreatedAt,
 			&price.UpdatedAt,
+			&price.CompareAtPrice,
+			&price.SalePrice,
+			&price.SaleStartsAt,
+			&price.SaleEndsAt,
 		)
 		if err != nil {
 			return nil, err
@@ -331,10 +336,15 @@ func (r *CurrencyRepository) GetProductPrices(productID uint) ([]entity.ProductP
 // SetProductPrice sets or updates a price for a product in a specific currency
 func (r *CurrencyRepository) SetProductPrice(price *entity.ProductPrice) error {
 	query := `
-		INSERT INTO product_prices (product_id, currency_code, price, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO product_prices (product_id, currency_code, price, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (product_id, currency_code) DO UPDATE SET
 			price = EXCLUDED.price,
+			compare_at_price = EXCLUDED.compare_at_price,
+			sale_price = EXCLUDED.sale_price,
+			sale_starts_at = EXCLUDED.sale_starts_at,
+			sale_ends_at = EXCLUDED.sale_ends_at,
 			updated_at = EXCLUDED.updated_at
 		RETURNING id
 	`
@@ -348,6 +358,10 @@ func (r *CurrencyRepository) SetProductPrice(price *entity.ProductPrice) error {
 		price.Price,
 		now,
 		now,
+		price.CompareAtPrice,
+		price.SalePrice,
+		price.SaleStartsAt,
+		price.SaleEndsAt,
 	).Scan(&price.ID)
 
 	return err
@@ -363,7 +377,8 @@ func (r *CurrencyRepository) DeleteProductPrice(productID uint, currencyCode str
 // GetProductVariantPrices retrieves all prices for a product variant in different currencies
 func (r *CurrencyRepository) GetVariantPrices(variantID uint) ([]entity.ProductVariantPrice, error) {
 	query := `
-		SELECT id, variant_id, currency_code, price, created_at, updated_at
+		SELECT id, variant_id, currency_code, price, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at
 		FROM product_variant_prices
 		WHERE variant_id = $1
 	`
@@ -385,6 +400,10 @@ func (r *CurrencyRepository) GetVariantPrices(variantID uint) ([]entity.ProductV
 			&price.Price,
 			&price.CreatedAt,
 			&price.UpdatedAt,
+			&price.CompareAtPrice,
+			&price.SalePrice,
+			&price.SaleStartsAt,
+			&price.SaleEndsAt,
 		)
 		if err != nil {
 			return nil, err
@@ -403,10 +422,15 @@ func (r *CurrencyRepository) GetVariantPrices(variantID uint) ([]entity.ProductV
 // SetProductVariantPrice sets or updates a price for a product variant in a specific currency
 func (r *CurrencyRepository) SetVariantPrice(price *entity.ProductVariantPrice) error {
 	query := `
-		INSERT INTO product_variant_prices (variant_id, currency_code, price, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO product_variant_prices (variant_id, currency_code, price, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (variant_id, currency_code) DO UPDATE SET
 			price = EXCLUDED.price,
+			compare_at_price = EXCLUDED.compare_at_price,
+			sale_price = EXCLUDED.sale_price,
+			sale_starts_at = EXCLUDED.sale_starts_at,
+			sale_ends_at = EXCLUDED.sale_ends_at,
 			updated_at = EXCLUDED.updated_at
 		RETURNING id
 	`
@@ -420,6 +444,10 @@ func (r *CurrencyRepository) SetVariantPrice(price *entity.ProductVariantPrice)
 		price.Price,
 		now,
 		now,
+		price.CompareAtPrice,
+		price.SalePrice,
+		price.SaleStartsAt,
+		price.SaleEndsAt,
 	).Scan(&price.ID)
 
 	return err
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// DiscountReportRepository implements the discount report repository interface using PostgreSQL
+type DiscountReportRepository struct {
+	db *sql.DB
+}
+
+// NewDiscountReportRepository creates a new DiscountReportRepository
+func NewDiscountReportRepository(db *sql.DB) repository.DiscountReportRepository {
+	return &DiscountReportRepository{db: db}
+}
+
+// orderStatsQuery aggregates orders together with their successful refunds.
+// Amounts are converted to the default currency with the exchange rate stored on each order.
+// The WHERE clause is appended by the caller.
+var orderStatsQuery = `
+	SELECT
+		COUNT(*),
+		COUNT(*) FILTER (WHERE o.status IN ('paid', 'captured', 'shipped', 'delivered', 'refunded')),
+		COALESCE(SUM(` + baseAmount("o.final_amount") + `) FILTER (WHERE o.status IN ('paid', 'captured', 'shipped', 'delivered', 'refunded')), 0),
+		COALESCE(SUM(` + baseAmount("o.discount_amount + o.shipping_discount_amount") + `), 0),
+		COUNT(*) FILTER (WHERE o.status = 'cancelled'),
+		COUNT(*) FILTER (WHERE r.refunded_amount > 0),
+		COALESCE(SUM(` + baseAmount("r.refunded_amount") + `), 0)
+	FROM orders o
+	LEFT JOIN (
+		SELECT order_id, SUM(amount) AS refunded_amount
+		FROM payment_transactions
+		WHERE type = 'refund' AND status = 'successful'
+		GROUP BY order_id
+	) r ON r.order_id = o.id
+`
+
+// baseAmount converts an amount of an order to hundredths of the default currency, like Order.ToBaseAmount.
+// The minor units of the order currency are rescaled before the exchange rate stored on the order is applied.
+func baseAmount(amount string) string {
+	exponents := money.NonDefaultExponents()
+
+	var exponent strings.Builder
+	exponent.WriteString("CASE o.currency")
+	for _, code := range slices.Sorted(maps.Keys(exponents)) {
+		fmt.Fprintf(&exponent, " WHEN '%s' THEN %d", code, exponents[code])
+	}
+	fmt.Fprintf(&exponent, " ELSE %d END", money.DefaultExponent)
+
+	return fmt.Sprintf("ROUND((%s) * POWER(10.0, %d - %s) / o.exchange_rate)", amount, money.DefaultExponent, exponent.String())
+}
+
+// GetOrderStats aggregates the orders that used one of the discounts
+func (r *DiscountReportRepository) GetOrderStats(discountIDs []uint, startDate, endDate time.Time) (*entity.DiscountOrderStats, error) {
+	query := orderStatsQuery + `
+		WHERE o.discount_id = ANY($1) AND o.created_at >= $2 AND o.created_at < $3
+	`
+
+	return r.scanOrderStats(r.db.QueryRow(query, discountIDArray(discountIDs), startDate, endDate))
+}
+
+// GetOrderStatsWithout aggregates the orders that did not use any of the discounts
+func (r *DiscountReportRepository) GetOrderStatsWithout(discountIDs []uint, startDate, endDate time.Time) (*entity.DiscountOrderStats, error) {
+	query := orderStatsQuery + `
+		WHERE (o.discount_id IS NULL OR NOT o.discount_id = ANY($1))
+			AND o.created_at >= $2 AND o.created_at < $3
+	`
+
+	return r.scanOrderStats(r.db.QueryRow(query, discountIDArray(discountIDs), startDate, endDate))
+}
+
+// GetRedemptionsByPeriod groups the orders that used one of the discounts by period.
+// Amounts are converted to the default currency with the exchange rate stored on each order.
+func (r *DiscountReportRepository) GetRedemptionsByPeriod(discountIDs []uint, startDate, endDate time.Time, interval entity.DiscountReportInterval) ([]entity.DiscountRedemptionPeriod, error) {
+	query := `
+		SELECT
+			date_trunc($4, o.created_at) AS period,
+			COUNT(*),
+			COALESCE(SUM(` + baseAmount("o.final_amount") + `) FILTER (WHERE o.status IN ('paid', 'captured', 'shipped', 'delivered', 'refunded')), 0),
+			COALESCE(SUM(` + baseAmount("o.discount_amount + o.shipping_discount_amount") + `), 0)
+		FROM orders o
+		WHERE o.discount_id = ANY($1) AND o.created_at >= $2 AND o.created_at < $3
+		GROUP BY period
+		ORDER BY period
+	`
+
+	rows, err := r.db.Query(query, discountIDArray(discountIDs), startDate, endDate, string(interval))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := []entity.DiscountRedemptionPeriod{}
+	for rows.Next() {
+		var period entity.DiscountRedemptionPeriod
+		if err := rows.Scan(
+			&period.PeriodStart,
+			&period.Redemptions,
+			&period.GrossRevenue,
+			&period.DiscountAmount,
+		); err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+// scanOrderStats scans a row produced by orderStatsQuery
+func (r *DiscountReportRepository) scanOrderStats(row *sql.Row) (*entity.DiscountOrderStats, error) {
+	stats := &entity.DiscountOrderStats{}
+	err := row.Scan(
+		&stats.OrderCount,
+		&stats.PaidOrders,
+		&stats.GrossRevenue,
+		&stats.DiscountAmount,
+		&stats.CancelledOrders,
+		&stats.RefundedOrders,
+		&stats.RefundedAmount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// discountIDArray converts discount IDs to a PostgreSQL array parameter
+func discountIDArray(discountIDs []uint) interface{} {
+	ids := make([]int64, len(discountIDs))
+	for i, id := range discountIDs {
+		ids[i] = int64(id)
+	}
+	return pq.Array(ids)
+}
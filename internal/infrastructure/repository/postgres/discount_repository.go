@@ -25,10 +25,10 @@ func (r *DiscountRepository) Create(discount *entity.Discount) error {
 	query := `
 		INSERT INTO discounts (
 			code, type, method, value, min_order_value, max_discount_value, 
-			product_ids, category_ids, start_date, end_date, 
-			usage_limit, current_usage, active, created_at, updated_at
+			product_ids, category_ids, shipping_method_ids, shipping_zone_ids, start_date, end_date, 
+			usage_limit, current_usage, active, campaign, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 		RETURNING id
 	`
 
@@ -42,6 +42,16 @@ func (r *DiscountRepository) Create(discount *entity.Discount) error {
 		return err
 	}
 
+	shippingMethodIDsJSON, err := json.Marshal(discount.ShippingMethodIDs)
+	if err != nil {
+		return err
+	}
+
+	shippingZoneIDsJSON, err := json.Marshal(discount.ShippingZoneIDs)
+	if err != nil {
+		return err
+	}
+
 	err = r.db.QueryRow(
 		query,
 		discount.Code,
@@ -52,11 +62,14 @@ func (r *DiscountRepository) Create(discount *entity.Discount) error {
 		discount.MaxDiscountValue,
 		productIDsJSON,
 		categoryIDsJSON,
+		shippingMethodIDsJSON,
+		shippingZoneIDsJSON,
 		discount.StartDate,
 		discount.EndDate,
 		discount.UsageLimit,
 		discount.CurrentUsage,
 		discount.Active,
+		discount.Campaign,
 		discount.CreatedAt,
 		discount.UpdatedAt,
 	).Scan(&discount.ID)
@@ -68,13 +81,13 @@ func (r *DiscountRepository) Create(discount *entity.Discount) error {
 func (r *DiscountRepository) GetByID(discountID uint) (*entity.Discount, error) {
 	query := `
 		SELECT id, code, type, method, value, min_order_value, max_discount_value, 
-			product_ids, category_ids, start_date, end_date, 
-			usage_limit, current_usage, active, created_at, updated_at
+			product_ids, category_ids, shipping_method_ids, shipping_zone_ids, start_date, end_date, 
+			usage_limit, current_usage, active, campaign, created_at, updated_at
 		FROM discounts
 		WHERE id = $1
 	`
 
-	var productIDsJSON, categoryIDsJSON []byte
+	var productIDsJSON, categoryIDsJSON, shippingMethodIDsJSON, shippingZoneIDsJSON []byte
 	discount := &entity.Discount{}
 
 	err := r.db.QueryRow(query, discountID).Scan(
@@ -87,11 +100,14 @@ func (r *DiscountRepository) GetByID(discountID uint) (*entity.Discount, error)
 		&discount.MaxDiscountValue,
 		&productIDsJSON,
 		&categoryIDsJSON,
+		&shippingMethodIDsJSON,
+		&shippingZoneIDsJSON,
 		&discount.StartDate,
 		&discount.EndDate,
 		&discount.UsageLimit,
 		&discount.CurrentUsage,
 		&discount.Active,
+		&discount.Campaign,
 		&discount.CreatedAt,
 		&discount.UpdatedAt,
 	)
@@ -114,6 +130,14 @@ func (r *DiscountRepository) GetByID(discountID uint) (*entity.Discount, error)
 		return nil, err
 	}
 
+	// Unmarshal shipping method and zone IDs
+	if err := json.Unmarshal(shippingMethodIDsJSON, &discount.ShippingMethodIDs); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(shippingZoneIDsJSON, &discount.ShippingZoneIDs); err != nil {
+		return nil, err
+	}
+
 	return discount, nil
 }
 
@@ -121,13 +145,13 @@ func (r *DiscountRepository) GetByID(discountID uint) (*entity.Discount, error)
 func (r *DiscountRepository) GetByCode(code string) (*entity.Discount, error) {
 	query := `
 		SELECT id, code, type, method, value, min_order_value, max_discount_value, 
-			product_ids, category_ids, start_date, end_date, 
-			usage_limit, current_usage, active, created_at, updated_at
+			product_ids, category_ids, shipping_method_ids, shipping_zone_ids, start_date, end_date, 
+			usage_limit, current_usage, active, campaign, created_at, updated_at
 		FROM discounts
 		WHERE code = $1
 	`
 
-	var productIDsJSON, categoryIDsJSON []byte
+	var productIDsJSON, categoryIDsJSON, shippingMethodIDsJSON, shippingZoneIDsJSON []byte
 	discount := &entity.Discount{}
 
 	err := r.db.QueryRow(query, code).Scan(
@@ -140,11 +164,14 @@ func (r *DiscountRepository) GetByCode(code string) (*entity.Discount, error) {
 		&discount.MaxDiscountValue,
 		&productIDsJSON,
 		&categoryIDsJSON,
+		&shippingMethodIDsJSON,
+		&shippingZoneIDsJSON,
 		&discount.StartDate,
 		&discount.EndDate,
 		&discount.UsageLimit,
 		&discount.CurrentUsage,
 		&discount.Active,
+		&discount.Campaign,
 		&discount.CreatedAt,
 		&discount.UpdatedAt,
 	)
@@ -167,6 +194,14 @@ func (r *DiscountRepository) GetByCode(code string) (*entity.Discount, error) {
 		return nil, err
 	}
 
+	// Unmarshal shipping method and zone IDs
+	if err := json.Unmarshal(shippingMethodIDsJSON, &discount.ShippingMethodIDs); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(shippingZoneIDsJSON, &discount.ShippingZoneIDs); err != nil {
+		return nil, err
+	}
+
 	return discount, nil
 }
 
@@ -176,9 +211,10 @@ func (r *DiscountRepository) Update(discount *entity.Discount) error {
 		UPDATE discounts
 		SET code = $1, type = $2, method = $3, value = $4, min_order_value = $5, 
 			max_discount_value = $6, product_ids = $7, category_ids = $8, 
-			start_date = $9, end_date = $10, usage_limit = $11, 
-			current_usage = $12, active = $13, updated_at = $14
-		WHERE id = $15
+			shipping_method_ids = $9, shipping_zone_ids = $10,
+			start_date = $11, end_date = $12, usage_limit = $13, 
+			current_usage = $14, active = $15, campaign = $16, updated_at = $17
+		WHERE id = $18
 	`
 
 	productIDsJSON, err := json.Marshal(discount.ProductIDs)
@@ -191,6 +227,16 @@ func (r *DiscountRepository) Update(discount *entity.Discount) error {
 		return err
 	}
 
+	shippingMethodIDsJSON, err := json.Marshal(discount.ShippingMethodIDs)
+	if err != nil {
+		return err
+	}
+
+	shippingZoneIDsJSON, err := json.Marshal(discount.ShippingZoneIDs)
+	if err != nil {
+		return err
+	}
+
 	_, err = r.db.Exec(
 		query,
 		discount.Code,
@@ -201,11 +247,14 @@ func (r *DiscountRepository) Update(discount *entity.Discount) error {
 		discount.MaxDiscountValue,
 		productIDsJSON,
 		categoryIDsJSON,
+		shippingMethodIDsJSON,
+		shippingZoneIDsJSON,
 		discount.StartDate,
 		discount.EndDate,
 		discount.UsageLimit,
 		discount.CurrentUsage,
 		discount.Active,
+		discount.Campaign,
 		time.Now(),
 		discount.ID,
 	)
@@ -224,8 +273,8 @@ func (r *DiscountRepository) Delete(discountID uint) error {
 func (r *DiscountRepository) List(offset, limit int) ([]*entity.Discount, error) {
 	query := `
 		SELECT id, code, type, method, value, min_order_value, max_discount_value, 
-			product_ids, category_ids, start_date, end_date, 
-			usage_limit, current_usage, active, created_at, updated_at
+			product_ids, category_ids, shipping_method_ids, shipping_zone_ids, start_date, end_date, 
+			usage_limit, current_usage, active, campaign, created_at, updated_at
 		FROM discounts
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -239,7 +288,7 @@ func (r *DiscountRepository) List(offset, limit int) ([]*entity.Discount, error)
 
 	discounts := []*entity.Discount{}
 	for rows.Next() {
-		var productIDsJSON, categoryIDsJSON []byte
+		var productIDsJSON, categoryIDsJSON, shippingMethodIDsJSON, shippingZoneIDsJSON []byte
 		discount := &entity.Discount{}
 
 		err := rows.Scan(
@@ -252,11 +301,14 @@ func (r *DiscountRepository) List(offset, limit int) ([]*entity.Discount, error)
 			&discount.MaxDiscountValue,
 			&productIDsJSON,
 			&categoryIDsJSON,
+			&shippingMethodIDsJSON,
+			&shippingZoneIDsJSON,
 			&discount.StartDate,
 			&discount.EndDate,
 			&discount.UsageLimit,
 			&discount.CurrentUsage,
 			&discount.Active,
+			&discount.Campaign,
 			&discount.CreatedAt,
 			&discount.UpdatedAt,
 		)
@@ -274,6 +326,14 @@ func (r *DiscountRepository) List(offset, limit int) ([]*entity.Discount, error)
 			return nil, err
 		}
 
+		// Unmarshal shipping method and zone IDs
+		if err := json.Unmarshal(shippingMethodIDsJSON, &discount.ShippingMethodIDs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(shippingZoneIDsJSON, &discount.ShippingZoneIDs); err != nil {
+			return nil, err
+		}
+
 		discounts = append(discounts, discount)
 	}
 
@@ -284,8 +344,8 @@ func (r *DiscountRepository) List(offset, limit int) ([]*entity.Discount, error)
 func (r *DiscountRepository) ListActive(offset, limit int) ([]*entity.Discount, error) {
 	query := `
 		SELECT id, code, type, method, value, min_order_value, max_discount_value, 
-			product_ids, category_ids, start_date, end_date, 
-			usage_limit, current_usage, active, created_at, updated_at
+			product_ids, category_ids, shipping_method_ids, shipping_zone_ids, start_date, end_date, 
+			usage_limit, current_usage, active, campaign, created_at, updated_at
 		FROM discounts
 		WHERE active = true 
 		AND start_date <= NOW() 
@@ -303,7 +363,78 @@ func (r *DiscountRepository) ListActive(offset, limit int) ([]*entity.Discount,
 
 	discounts := []*entity.Discount{}
 	for rows.Next() {
-		var productIDsJSON, categoryIDsJSON []byte
+		var productIDsJSON, categoryIDsJSON, shippingMethodIDsJSON, shippingZoneIDsJSON []byte
+		discount := &entity.Discount{}
+
+		err := rows.Scan(
+			&discount.ID,
+			&discount.Code,
+			&discount.Type,
+			&discount.Method,
+			&discount.Value,
+			&discount.MinOrderValue,
+			&discount.MaxDiscountValue,
+			&productIDsJSON,
+			&categoryIDsJSON,
+			&shippingMethodIDsJSON,
+			&shippingZoneIDsJSON,
+			&discount.StartDate,
+			&discount.EndDate,
+			&discount.UsageLimit,
+			&discount.CurrentUsage,
+			&discount.Active,
+			&discount.Campaign,
+			&discount.CreatedAt,
+			&discount.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Unmarshal product IDs
+		if err := json.Unmarshal(productIDsJSON, &discount.ProductIDs); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal category IDs
+		if err := json.Unmarshal(categoryIDsJSON, &discount.CategoryIDs); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal shipping method and zone IDs
+		if err := json.Unmarshal(shippingMethodIDsJSON, &discount.ShippingMethodIDs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(shippingZoneIDsJSON, &discount.ShippingZoneIDs); err != nil {
+			return nil, err
+		}
+
+		discounts = append(discounts, discount)
+	}
+
+	return discounts, nil
+}
+
+// ListByCampaign retrieves all discounts belonging to a campaign
+func (r *DiscountRepository) ListByCampaign(campaign string) ([]*entity.Discount, error) {
+	query := `
+		SELECT id, code, type, method, value, min_order_value, max_discount_value, 
+			product_ids, category_ids, shipping_method_ids, shipping_zone_ids, start_date, end_date, 
+			usage_limit, current_usage, active, campaign, created_at, updated_at
+		FROM discounts
+		WHERE campaign = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, campaign)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	discounts := []*entity.Discount{}
+	for rows.Next() {
+		var productIDsJSON, categoryIDsJSON, shippingMethodIDsJSON, shippingZoneIDsJSON []byte
 		discount := &entity.Discount{}
 
 		err := rows.Scan(
@@ -316,11 +447,14 @@ func (r *DiscountRepository) ListActive(offset, limit int) ([]*entity.Discount,
 			&discount.MaxDiscountValue,
 			&productIDsJSON,
 			&categoryIDsJSON,
+			&shippingMethodIDsJSON,
+			&shippingZoneIDsJSON,
 			&discount.StartDate,
 			&discount.EndDate,
 			&discount.UsageLimit,
 			&discount.CurrentUsage,
 			&discount.Active,
+			&discount.Campaign,
 			&discount.CreatedAt,
 			&discount.UpdatedAt,
 		)
@@ -338,6 +472,14 @@ func (r *DiscountRepository) ListActive(offset, limit int) ([]*entity.Discount,
 			return nil, err
 		}
 
+		// Unmarshal shipping method and zone IDs
+		if err := json.Unmarshal(shippingMethodIDsJSON, &discount.ShippingMethodIDs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(shippingZoneIDsJSON, &discount.ShippingZoneIDs); err != nil {
+			return nil, err
+		}
+
 		discounts = append(discounts, discount)
 	}
 
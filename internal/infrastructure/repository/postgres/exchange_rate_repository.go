@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// ExchangeRateHistoryRepository implements the exchange rate history repository interface using PostgreSQL
+type ExchangeRateHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewExchangeRateHistoryRepository creates a new ExchangeRateHistoryRepository
+func NewExchangeRateHistoryRepository(db *sql.DB) repository.ExchangeRateHistoryRepository {
+	return &ExchangeRateHistoryRepository{db: db}
+}
+
+// Create creates a new exchange rate history entry
+func (r *ExchangeRateHistoryRepository) Create(history *entity.ExchangeRateHistory) error {
+	query := `
+		INSERT INTO exchange_rate_history (currency_code, rate, previous_rate, source, status, created_at, reviewed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(
+		query,
+		history.CurrencyCode,
+		history.Rate,
+		history.PreviousRate,
+		history.Source,
+		history.Status,
+		history.CreatedAt,
+		history.ReviewedAt,
+	).Scan(&history.ID)
+}
+
+// Update updates an exchange rate history entry
+func (r *ExchangeRateHistoryRepository) Update(history *entity.ExchangeRateHistory) error {
+	query := `
+		UPDATE exchange_rate_history
+		SET rate = $1, previous_rate = $2, status = $3, reviewed_at = $4
+		WHERE id = $5
+	`
+
+	result, err := r.db.Exec(
+		query,
+		history.Rate,
+		history.PreviousRate,
+		history.Status,
+		history.ReviewedAt,
+		history.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("exchange rate history not found")
+	}
+
+	return nil
+}
+
+// GetByID retrieves an exchange rate history entry by ID
+func (r *ExchangeRateHistoryRepository) GetByID(historyID uint) (*entity.ExchangeRateHistory, error) {
+	query := `
+		SELECT id, currency_code, rate, previous_rate, source, status, created_at, reviewed_at
+		FROM exchange_rate_history
+		WHERE id = $1
+	`
+
+	history, err := r.scanHistory(r.db.QueryRow(query, historyID))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("exchange rate history not found")
+	}
+
+	return history, err
+}
+
+// ListByCurrency lists the exchange rate history of a currency, newest first
+func (r *ExchangeRateHistoryRepository) ListByCurrency(currencyCode string, offset, limit int) ([]*entity.ExchangeRateHistory, error) {
+	query := `
+		SELECT id, currency_code, rate, previous_rate, source, status, created_at, reviewed_at
+		FROM exchange_rate_history
+		WHERE currency_code = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, currencyCode, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanHistories(rows)
+}
+
+// ListPending lists the exchange rates awaiting confirmation
+func (r *ExchangeRateHistoryRepository) ListPending() ([]*entity.ExchangeRateHistory, error) {
+	query := `
+		SELECT id, currency_code, rate, previous_rate, source, status, created_at, reviewed_at
+		FROM exchange_rate_history
+		WHERE status = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(query, entity.ExchangeRateStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanHistories(rows)
+}
+
+// scanHistories scans all rows into exchange rate history entries
+func (r *ExchangeRateHistoryRepository) scanHistories(rows *sql.Rows) ([]*entity.ExchangeRateHistory, error) {
+	histories := []*entity.ExchangeRateHistory{}
+	for rows.Next() {
+		history, err := r.scanHistory(rows)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, history)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+// scanHistory scans a single exchange rate history entry
+func (r *ExchangeRateHistoryRepository) scanHistory(row interface{ Scan(...any) error }) (*entity.ExchangeRateHistory, error) {
+	history := &entity.ExchangeRateHistory{}
+	var reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&history.ID,
+		&history.CurrencyCode,
+		&history.Rate,
+		&history.PreviousRate,
+		&history.Source,
+		&history.Status,
+		&history.CreatedAt,
+		&reviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewedAt.Valid {
+		history.ReviewedAt = &reviewedAt.Time
+	}
+
+	return history, nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// HolidayRepository implements the public holiday repository interface using PostgreSQL
+type HolidayRepository struct {
+	db *sql.DB
+}
+
+// NewHolidayRepository creates a new HolidayRepository
+func NewHolidayRepository(db *sql.DB) repository.HolidayRepository {
+	return &HolidayRepository{db: db}
+}
+
+// Create creates a new public holiday
+func (r *HolidayRepository) Create(holiday *entity.Holiday) error {
+	err := r.db.QueryRow(`
+		INSERT INTO holidays (country, date, name, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`,
+		holiday.Country,
+		holiday.Date,
+		holiday.Name,
+		holiday.CreatedAt,
+	).Scan(&holiday.ID)
+
+	if err != nil && strings.Contains(err.Error(), "holidays_country_date_key") {
+		return errors.New("a holiday on this date already exists for the country")
+	}
+	return err
+}
+
+// GetByID retrieves a public holiday by ID
+func (r *HolidayRepository) GetByID(holidayID uint) (*entity.Holiday, error) {
+	holiday := &entity.Holiday{}
+	err := r.db.QueryRow(`
+		SELECT id, country, date, name, created_at
+		FROM holidays
+		WHERE id = $1
+	`, holidayID).Scan(
+		&holiday.ID,
+		&holiday.Country,
+		&holiday.Date,
+		&holiday.Name,
+		&holiday.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, errors.New("holiday not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	holiday.Date = entity.CalendarDate(holiday.Date)
+	return holiday, nil
+}
+
+// List lists the public holidays from and to the dates, both included, of a country or of all countries if it is empty
+func (r *HolidayRepository) List(country string, from, to time.Time) ([]*entity.Holiday, error) {
+	rows, err := r.db.Query(`
+		SELECT id, country, date, name, created_at
+		FROM holidays
+		WHERE ($1 = '' OR country = $1) AND date BETWEEN $2 AND $3
+		ORDER BY date, country
+	`, country, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holidays := []*entity.Holiday{}
+	for rows.Next() {
+		holiday := &entity.Holiday{}
+		err := rows.Scan(
+			&holiday.ID,
+			&holiday.Country,
+			&holiday.Date,
+			&holiday.Name,
+			&holiday.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		holiday.Date = entity.CalendarDate(holiday.Date)
+		holidays = append(holidays, holiday)
+	}
+
+	return holidays, rows.Err()
+}
+
+// Delete deletes a public holiday
+func (r *HolidayRepository) Delete(holidayID uint) error {
+	_, err := r.db.Exec(`DELETE FROM holidays WHERE id = $1`, holidayID)
+	return err
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// IdempotencyKeyRepository implements the idempotency key repository interface using PostgreSQL
+type IdempotencyKeyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyKeyRepository creates a new IdempotencyKeyRepository
+func NewIdempotencyKeyRepository(db *sql.DB) repository.IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db: db}
+}
+
+// Create saves the key of a new request, taking over an expired key of the same value.
+// The primary key makes concurrent requests with the same key create it only once.
+func (r *IdempotencyKeyRepository) Create(key *entity.IdempotencyKey) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (scope, idempotency_key, method, path, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (scope, idempotency_key) DO UPDATE
+		SET method = EXCLUDED.method,
+			path = EXCLUDED.path,
+			request_hash = EXCLUDED.request_hash,
+			status_code = 0,
+			content_type = '',
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+	`
+
+	result, err := r.db.Exec(
+		query,
+		key.Scope,
+		key.Key,
+		key.Method,
+		key.Path,
+		key.RequestHash,
+		key.CreatedAt,
+		key.ExpiresAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// Get retrieves an idempotency key
+func (r *IdempotencyKeyRepository) Get(scope, key string) (*entity.IdempotencyKey, error) {
+	query := `
+		SELECT scope, idempotency_key, method, path, request_hash, status_code, content_type, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE scope = $1 AND idempotency_key = $2
+	`
+
+	idempotencyKey := &entity.IdempotencyKey{}
+	err := r.db.QueryRow(query, scope, key).Scan(
+		&idempotencyKey.Scope,
+		&idempotencyKey.Key,
+		&idempotencyKey.Method,
+		&idempotencyKey.Path,
+		&idempotencyKey.RequestHash,
+		&idempotencyKey.StatusCode,
+		&idempotencyKey.ContentType,
+		&idempotencyKey.ResponseBody,
+		&idempotencyKey.CreatedAt,
+		&idempotencyKey.ExpiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("idempotency key not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return idempotencyKey, nil
+}
+
+// Complete stores the response of the key's request
+func (r *IdempotencyKeyRepository) Complete(key *entity.IdempotencyKey) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $1, content_type = $2, response_body = $3
+		WHERE scope = $4 AND idempotency_key = $5
+	`
+
+	_, err := r.db.Exec(query, key.StatusCode, key.ContentType, key.ResponseBody, key.Scope, key.Key)
+	return err
+}
+
+// Delete deletes an idempotency key
+func (r *IdempotencyKeyRepository) Delete(scope, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE scope = $1 AND idempotency_key = $2`
+	_, err := r.db.Exec(query, scope, key)
+	return err
+}
+
+// DeleteExpired deletes the keys expired before the given time
+func (r *IdempotencyKeyRepository) DeleteExpired(before time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+
+	result, err := r.db.Exec(query, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// InvoiceRepository implements the invoice repository interface using PostgreSQL
+type InvoiceRepository struct {
+	db *sql.DB
+}
+
+// NewInvoiceRepository creates a new InvoiceRepository
+func NewInvoiceRepository(db *sql.DB) repository.InvoiceRepository {
+	return &InvoiceRepository{db: db}
+}
+
+// invoiceColumns are the columns read by scanInvoice, including the number of a credited invoice
+const invoiceColumns = `
+	i.id, i.order_id, i.type, i.number, i.sequence, COALESCE(i.invoice_id, 0), COALESCE(c.number, ''),
+	i.amount, i.tax_amount, i.currency, i.reverse_charge, i.created_at
+`
+
+// Create saves an invoice with the next number of its series. The series' row stays locked
+// until the transaction ends, so concurrent invoices are numbered one after the other, and a
+// failed insert rolls the number back, so the series has no gaps.
+func (r *InvoiceRepository) Create(invoice *entity.Invoice, prefix string) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	var sequence int64
+	err = tx.QueryRow(`
+		INSERT INTO invoice_sequences (type, last_number)
+		VALUES ($1, 1)
+		ON CONFLICT (type) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		RETURNING last_number
+	`, invoice.Type).Scan(&sequence)
+	if err != nil {
+		return err
+	}
+
+	invoice.AssignNumber(prefix, sequence)
+
+	err = tx.QueryRow(`
+		INSERT INTO invoices (
+			order_id, type, number, sequence, invoice_id, amount, tax_amount, currency, reverse_charge, created_at
+		)
+		VALUES ($1, $2, $3, $4, NULLIF($5, 0), $6, $7, $8, $9, $10)
+		RETURNING id
+	`,
+		invoice.OrderID,
+		invoice.Type,
+		invoice.Number,
+		invoice.Sequence,
+		invoice.InvoiceID,
+		invoice.Amount,
+		invoice.TaxAmount,
+		invoice.Currency,
+		invoice.ReverseCharge,
+		invoice.CreatedAt,
+	).Scan(&invoice.ID)
+
+	return err
+}
+
+// GetByID retrieves an invoice or credit note by ID
+func (r *InvoiceRepository) GetByID(invoiceID uint) (*entity.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices i
+		LEFT JOIN invoices c ON c.id = i.invoice_id
+		WHERE i.id = $1
+	`
+
+	invoice, err := scanInvoice(r.db.QueryRow(query, invoiceID))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("invoice not found")
+	}
+	return invoice, err
+}
+
+// GetInvoiceByOrderID retrieves the invoice of an order
+func (r *InvoiceRepository) GetInvoiceByOrderID(orderID uint) (*entity.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices i
+		LEFT JOIN invoices c ON c.id = i.invoice_id
+		WHERE i.order_id = $1 AND i.type = $2
+	`
+
+	invoice, err := scanInvoice(r.db.QueryRow(query, orderID, entity.InvoiceTypeInvoice))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("invoice not found")
+	}
+	return invoice, err
+}
+
+// ListByOrderID lists the invoice and credit notes of an order, oldest first
+func (r *InvoiceRepository) ListByOrderID(orderID uint) ([]*entity.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices i
+		LEFT JOIN invoices c ON c.id = i.invoice_id
+		WHERE i.order_id = $1
+		ORDER BY i.created_at, i.id
+	`
+
+	rows, err := r.db.Query(query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invoices := []*entity.Invoice{}
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	return invoices, rows.Err()
+}
+
+// scanInvoice scans an invoice row selected with invoiceColumns
+func scanInvoice(row interface{ Scan(...any) error }) (*entity.Invoice, error) {
+	invoice := &entity.Invoice{}
+	err := row.Scan(
+		&invoice.ID,
+		&invoice.OrderID,
+		&invoice.Type,
+		&invoice.Number,
+		&invoice.Sequence,
+		&invoice.InvoiceID,
+		&invoice.InvoiceNumber,
+		&invoice.Amount,
+		&invoice.TaxAmount,
+		&invoice.Currency,
+		&invoice.ReverseCharge,
+		&invoice.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
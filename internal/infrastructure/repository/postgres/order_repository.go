@@ -45,6 +45,18 @@ func (r *OrderRepository) Create(order *entity.Order) error {
 		return err
 	}
 
+	packagesJSON, err := marshalPackages(order.Packages)
+	if err != nil {
+		return err
+	}
+
+	pickupPointJSON, err := marshalPickupPoint(order.PickupPoint)
+	if err != nil {
+		return err
+	}
+	deliveryEarliest, deliveryLatest := deliveryDates(order.EstimatedDelivery)
+	discountID, discountCode, discountAmount, shippingDiscountAmount := appliedDiscount(order)
+
 	// Insert order
 	var query string
 	var err2 error
@@ -57,9 +69,13 @@ func (r *OrderRepository) Create(order *entity.Order) error {
 				user_id, total_amount, status, shipping_address, billing_address,
 				payment_id, payment_provider, tracking_code, created_at, updated_at, completed_at, final_amount,
 				customer_email, customer_phone, customer_full_name, is_guest_order, shipping_method_id, shipping_cost,
-				total_weight
+				total_weight, currency, exchange_rate, tax_amount, shipping_tax_rate, shipping_tax_amount, prices_include_tax,
+				customer_company_name, customer_vat_id, reverse_charge, packages, pickup_point,
+				estimated_delivery_earliest, estimated_delivery_latest,
+				discount_id, discount_code, discount_amount, shipping_discount_amount
 			)
-			VALUES (NULL, $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+			VALUES (NULL, $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+				$21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35)
 			RETURNING id
 		`
 
@@ -83,6 +99,23 @@ func (r *OrderRepository) Create(order *entity.Order) error {
 			order.ShippingMethodID,
 			order.ShippingCost,
 			order.TotalWeight,
+			order.Currency,
+			order.ExchangeRate,
+			order.TaxAmount,
+			order.ShippingTaxRate,
+			order.ShippingTaxAmount,
+			order.PricesIncludeTax,
+			order.CustomerDetails.CompanyName,
+			order.CustomerDetails.VATID,
+			order.ReverseCharge,
+			packagesJSON,
+			pickupPointJSON,
+			deliveryEarliest,
+			deliveryLatest,
+			discountID,
+			discountCode,
+			discountAmount,
+			shippingDiscountAmount,
 		).Scan(&order.ID)
 	} else {
 		// Regular user order
@@ -90,9 +123,14 @@ func (r *OrderRepository) Create(order *entity.Order) error {
 			INSERT INTO orders (
 				user_id, total_amount, status, shipping_address, billing_address,
 				payment_id, payment_provider, tracking_code, created_at, updated_at, completed_at, final_amount,
-				customer_email, customer_phone, customer_full_name, shipping_method_id, shipping_cost, total_weight
+				customer_email, customer_phone, customer_full_name, shipping_method_id, shipping_cost, total_weight,
+				currency, exchange_rate, tax_amount, shipping_tax_rate, shipping_tax_amount, prices_include_tax,
+				customer_company_name, customer_vat_id, reverse_charge, packages, pickup_point,
+				estimated_delivery_earliest, estimated_delivery_latest,
+				discount_id, discount_code, discount_amount, shipping_discount_amount
 			)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+				$21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35)
 			RETURNING id
 		`
 
@@ -116,6 +154,23 @@ func (r *OrderRepository) Create(order *entity.Order) error {
 			order.ShippingMethodID,
 			order.ShippingCost,
 			order.TotalWeight,
+			order.Currency,
+			order.ExchangeRate,
+			order.TaxAmount,
+			order.ShippingTaxRate,
+			order.ShippingTaxAmount,
+			order.PricesIncludeTax,
+			order.CustomerDetails.CompanyName,
+			order.CustomerDetails.VATID,
+			order.ReverseCharge,
+			packagesJSON,
+			pickupPointJSON,
+			deliveryEarliest,
+			deliveryLatest,
+			discountID,
+			discountCode,
+			discountAmount,
+			shippingDiscountAmount,
 		).Scan(&order.ID)
 	}
 
@@ -140,8 +195,8 @@ func (r *OrderRepository) Create(order *entity.Order) error {
 	for i := range order.Items {
 		order.Items[i].OrderID = order.ID
 		query := `
-			INSERT INTO order_items (order_id, product_id, quantity, price, subtotal, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6)
+			INSERT INTO order_items (order_id, product_id, quantity, price, subtotal, created_at, tax_class_id, tax_rate, tax_amount)
+			VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0), $8, $9)
 			RETURNING id
 		`
 		err = tx.QueryRow(
@@ -152,6 +207,9 @@ func (r *OrderRepository) Create(order *entity.Order) error {
 			order.Items[i].Price,
 			order.Items[i].Subtotal,
 			order.CreatedAt,
+			order.Items[i].TaxClassID,
+			order.Items[i].TaxRate,
+			order.Items[i].TaxAmount,
 		).Scan(&order.Items[i].ID)
 		if err != nil {
 			return err
@@ -167,9 +225,12 @@ func (r *OrderRepository) GetByID(orderID uint) (*entity.Order, error) {
 	query := `
 		SELECT id, order_number, user_id, total_amount, status, shipping_address, billing_address,
 			payment_id, payment_provider, tracking_code, created_at, updated_at, completed_at,
-			discount_amount, discount_id, discount_code, final_amount, action_url,
+			discount_amount, shipping_discount_amount, discount_id, discount_code, final_amount, action_url,
 			customer_email, customer_phone, customer_full_name, is_guest_order, shipping_method_id, shipping_cost,
-			total_weight
+			total_weight, currency, exchange_rate, tax_amount, shipping_tax_rate, shipping_tax_amount, prices_include_tax,
+			customer_company_name, customer_vat_id, reverse_charge, packages, shipping_label, pickup_point,
+			estimated_delivery_earliest, estimated_delivery_latest, authorized_amount, captured_amount,
+			authorization_expires_at, authorization_warned_at
 		FROM orders
 		WHERE id = $1
 	`
@@ -186,6 +247,9 @@ func (r *OrderRepository) GetByID(orderID uint) (*entity.Order, error) {
 	var shippingMethodID sql.NullInt64
 	var shippingCost sql.NullInt64
 	var totalWeight sql.NullFloat64
+	var packagesJSON, labelJSON, pickupPointJSON []byte
+	var deliveryEarliest, deliveryLatest sql.NullTime
+	var authorizationExpiresAt, authorizationWarnedAt sql.NullTime
 
 	var discountID sql.NullInt64
 	var discountCode sql.NullString
@@ -205,6 +269,7 @@ func (r *OrderRepository) GetByID(orderID uint) (*entity.Order, error) {
 		&order.UpdatedAt,
 		&completedAt,
 		&order.DiscountAmount,
+		&order.ShippingDiscountAmount,
 		&discountID,
 		&discountCode,
 		&order.FinalAmount,
@@ -216,6 +281,24 @@ func (r *OrderRepository) GetByID(orderID uint) (*entity.Order, error) {
 		&shippingMethodID,
 		&shippingCost,
 		&totalWeight,
+		&order.Currency,
+		&order.ExchangeRate,
+		&order.TaxAmount,
+		&order.ShippingTaxRate,
+		&order.ShippingTaxAmount,
+		&order.PricesIncludeTax,
+		&order.CustomerDetails.CompanyName,
+		&order.CustomerDetails.VATID,
+		&order.ReverseCharge,
+		&packagesJSON,
+		&labelJSON,
+		&pickupPointJSON,
+		&deliveryEarliest,
+		&deliveryLatest,
+		&order.AuthorizedAmount,
+		&order.CapturedAmount,
+		&authorizationExpiresAt,
+		&authorizationWarnedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -236,17 +319,15 @@ func (r *OrderRepository) GetByID(orderID uint) (*entity.Order, error) {
 	// Handle guest order fields
 	if isGuestOrder.Valid && isGuestOrder.Bool {
 		order.IsGuestOrder = true
-		order.CustomerDetails = entity.CustomerDetails{
-			Email:    customerEmail.String,
-			Phone:    customerPhone.String,
-			FullName: customerFullName.String,
-		}
+		order.CustomerDetails.Email = customerEmail.String
+		order.CustomerDetails.Phone = customerPhone.String
+		order.CustomerDetails.FullName = customerFullName.String
 	}
 
 	order.AppliedDiscount = &entity.AppliedDiscount{
 		DiscountID:     uint(discountID.Int64),
 		DiscountCode:   discountCode.String,
-		DiscountAmount: order.DiscountAmount,
+		DiscountAmount: order.DiscountAmount + order.ShippingDiscountAmount,
 	}
 
 	if order.FinalAmount == 0 {
@@ -297,9 +378,25 @@ func (r *OrderRepository) GetByID(orderID uint) (*entity.Order, error) {
 		order.TotalWeight = totalWeight.Float64
 	}
 
+	if err := json.Unmarshal(packagesJSON, &order.Packages); err != nil {
+		return nil, err
+	}
+
+	if order.ShippingLabel, err = unmarshalShippingLabel(labelJSON); err != nil {
+		return nil, err
+	}
+
+	if order.PickupPoint, err = unmarshalPickupPoint(pickupPointJSON); err != nil {
+		return nil, err
+	}
+	order.EstimatedDelivery = deliveryEstimate(deliveryEarliest, deliveryLatest)
+	order.AuthorizationExpiresAt = nullTimePtr(authorizationExpiresAt)
+	order.AuthorizationWarnedAt = nullTimePtr(authorizationWarnedAt)
+
 	// Get order items
 	query = `
 		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.price, oi.subtotal,
+			COALESCE(oi.tax_class_id, 0), oi.tax_rate, oi.tax_amount,
 			p.name as product_name, p.product_number as sku
 		FROM order_items oi
 		LEFT JOIN products p ON p.id = oi.product_id
@@ -323,6 +420,9 @@ func (r *OrderRepository) GetByID(orderID uint) (*entity.Order, error) {
 			&item.Quantity,
 			&item.Price,
 			&item.Subtotal,
+			&item.TaxClassID,
+			&item.TaxRate,
+			&item.TaxAmount,
 			&productName,
 			&sku,
 		)
@@ -369,22 +469,36 @@ func (r *OrderRepository) Update(order *entity.Order) error {
 			total_weight = $17,
 			customer_email = $18,
 			customer_phone = $19,
-			customer_full_name = $20
-		WHERE id = $21
+			customer_full_name = $20,
+			shipping_discount_amount = $21,
+			tax_amount = $22,
+			shipping_tax_rate = $23,
+			shipping_tax_amount = $24,
+			prices_include_tax = $25,
+			customer_company_name = $26,
+			customer_vat_id = $27,
+			reverse_charge = $28,
+			packages = $29,
+			shipping_label = $30,
+			authorized_amount = $31,
+			captured_amount = $32,
+			authorization_expires_at = $33,
+			authorization_warned_at = $34
+		WHERE id = $35
 	`
 
-	var discountID sql.NullInt64
-	var discountCode sql.NullString
-	var discountAmount int64 = 0
+	packagesJSON, err := marshalPackages(order.Packages)
+	if err != nil {
+		return err
+	}
 
-	if order.AppliedDiscount != nil && order.AppliedDiscount.DiscountID > 0 {
-		discountID.Int64 = int64(order.AppliedDiscount.DiscountID)
-		discountID.Valid = true
-		discountAmount = order.AppliedDiscount.DiscountAmount
-		discountCode.String = order.AppliedDiscount.DiscountCode
-		discountCode.Valid = true
+	labelJSON, err := marshalShippingLabel(order.ShippingLabel)
+	if err != nil {
+		return err
 	}
 
+	discountID, discountCode, discountAmount, shippingDiscountAmount := appliedDiscount(order)
+
 	_, err = r.db.Exec(
 		query,
 		order.Status,
@@ -407,10 +521,43 @@ func (r *OrderRepository) Update(order *entity.Order) error {
 		order.CustomerDetails.Email,
 		order.CustomerDetails.Phone,
 		order.CustomerDetails.FullName,
+		shippingDiscountAmount,
+		order.TaxAmount,
+		order.ShippingTaxRate,
+		order.ShippingTaxAmount,
+		order.PricesIncludeTax,
+		order.CustomerDetails.CompanyName,
+		order.CustomerDetails.VATID,
+		order.ReverseCharge,
+		packagesJSON,
+		labelJSON,
+		order.AuthorizedAmount,
+		order.CapturedAmount,
+		order.AuthorizationExpiresAt,
+		order.AuthorizationWarnedAt,
 		order.ID,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// Item tax changes with the discounts applied to the order
+	for _, item := range order.Items {
+		if item.ID == 0 {
+			continue
+		}
+		_, err = r.db.Exec(
+			"UPDATE order_items SET tax_rate = $1, tax_amount = $2 WHERE id = $3",
+			item.TaxRate,
+			item.TaxAmount,
+			item.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // GetByUser retrieves orders for a user
@@ -418,7 +565,7 @@ func (r *OrderRepository) GetByUser(userID uint, offset, limit int) ([]*entity.O
 	query := `
 		SELECT id, order_number, user_id, total_amount, status, shipping_address, billing_address,
 			payment_id, payment_provider, tracking_code, created_at, updated_at, completed_at,
-			customer_email, customer_phone, customer_full_name, is_guest_order
+			customer_email, customer_phone, customer_full_name, is_guest_order, currency, exchange_rate
 		FROM orders
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -460,6 +607,8 @@ func (r *OrderRepository) GetByUser(userID uint, offset, limit int) ([]*entity.O
 			&customerPhone,
 			&customerFullName,
 			&isGuestOrder,
+			&order.Currency,
+			&order.ExchangeRate,
 		)
 		if err != nil {
 			return nil, err
@@ -548,7 +697,7 @@ func (r *OrderRepository) ListByStatus(status entity.OrderStatus, offset, limit
 	query := `
 		SELECT id, order_number, user_id, total_amount, status, shipping_address, billing_address,
 			payment_id, payment_provider, tracking_code, created_at, updated_at, completed_at,
-			customer_email, customer_phone, customer_full_name, is_guest_order
+			customer_email, customer_phone, customer_full_name, is_guest_order, currency, exchange_rate
 		FROM orders
 		WHERE status = $1
 		ORDER BY created_at DESC
@@ -590,6 +739,8 @@ func (r *OrderRepository) ListByStatus(status entity.OrderStatus, offset, limit
 			&customerPhone,
 			&customerFullName,
 			&isGuestOrder,
+			&order.Currency,
+			&order.ExchangeRate,
 		)
 		if err != nil {
 			return nil, err
@@ -689,6 +840,81 @@ func (r *OrderRepository) IsDiscountIdUsed(discountID uint) (bool, error) {
 	return exists, nil
 }
 
+// GetByOrderNumber retrieves an order by its order number
+func (r *OrderRepository) GetByOrderNumber(orderNumber string) (*entity.Order, error) {
+	var orderID uint
+	err := r.db.QueryRow("SELECT id FROM orders WHERE order_number = $1", orderNumber).Scan(&orderID)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("order not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(orderID)
+}
+
+// GetByTrackingCode retrieves the latest order shipped with a tracking code
+func (r *OrderRepository) GetByTrackingCode(trackingCode string) (*entity.Order, error) {
+	if trackingCode == "" {
+		return nil, errors.New("tracking code cannot be empty")
+	}
+
+	var orderID uint
+	err := r.db.QueryRow(
+		"SELECT id FROM orders WHERE tracking_code = $1 ORDER BY id DESC LIMIT 1",
+		trackingCode,
+	).Scan(&orderID)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("order not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(orderID)
+}
+
+// ListExpiringAuthorizations retrieves orders with an uncaptured authorization that expires
+// before the given time and hasn't been warned about, soonest expiry first
+func (r *OrderRepository) ListExpiringAuthorizations(before time.Time) ([]*entity.Order, error) {
+	rows, err := r.db.Query(`
+		SELECT id FROM orders
+		WHERE authorization_expires_at <= $1
+			AND authorization_warned_at IS NULL
+			AND authorized_amount > captured_amount
+			AND status NOT IN ($2, $3)
+		ORDER BY authorization_expires_at
+	`, before, entity.OrderStatusCancelled, entity.OrderStatusRefunded)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orderIDs []uint
+	for rows.Next() {
+		var orderID uint
+		if err := rows.Scan(&orderID); err != nil {
+			return nil, err
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*entity.Order, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		order, err := r.GetByID(orderID)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
 // GetByPaymentID retrieves an order by payment ID
 func (r *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error) {
 	if paymentID == "" {
@@ -699,9 +925,12 @@ func (r *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error
 	query := `
 		SELECT id, order_number, user_id, total_amount, status, shipping_address, billing_address,
 			payment_id, payment_provider, tracking_code, created_at, updated_at, completed_at,
-			discount_amount, discount_id, discount_code, final_amount, action_url,
+			discount_amount, shipping_discount_amount, discount_id, discount_code, final_amount, action_url,
 			customer_email, customer_phone, customer_full_name, is_guest_order, shipping_method_id, shipping_cost,
-			total_weight
+			total_weight, currency, exchange_rate, tax_amount, shipping_tax_rate, shipping_tax_amount, prices_include_tax,
+			customer_company_name, customer_vat_id, reverse_charge, packages, shipping_label, pickup_point,
+			estimated_delivery_earliest, estimated_delivery_latest, authorized_amount, captured_amount,
+			authorization_expires_at, authorization_warned_at
 		FROM orders
 		WHERE payment_id = $1
 	`
@@ -718,6 +947,9 @@ func (r *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error
 	var shippingMethodID sql.NullInt64
 	var shippingCost sql.NullInt64
 	var totalWeight sql.NullFloat64
+	var packagesJSON, labelJSON, pickupPointJSON []byte
+	var deliveryEarliest, deliveryLatest sql.NullTime
+	var authorizationExpiresAt, authorizationWarnedAt sql.NullTime
 
 	var discountID sql.NullInt64
 	var discountCode sql.NullString
@@ -737,6 +969,7 @@ func (r *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error
 		&order.UpdatedAt,
 		&completedAt,
 		&order.DiscountAmount,
+		&order.ShippingDiscountAmount,
 		&discountID,
 		&discountCode,
 		&order.FinalAmount,
@@ -748,6 +981,24 @@ func (r *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error
 		&shippingMethodID,
 		&shippingCost,
 		&totalWeight,
+		&order.Currency,
+		&order.ExchangeRate,
+		&order.TaxAmount,
+		&order.ShippingTaxRate,
+		&order.ShippingTaxAmount,
+		&order.PricesIncludeTax,
+		&order.CustomerDetails.CompanyName,
+		&order.CustomerDetails.VATID,
+		&order.ReverseCharge,
+		&packagesJSON,
+		&labelJSON,
+		&pickupPointJSON,
+		&deliveryEarliest,
+		&deliveryLatest,
+		&order.AuthorizedAmount,
+		&order.CapturedAmount,
+		&authorizationExpiresAt,
+		&authorizationWarnedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -768,17 +1019,15 @@ func (r *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error
 	// Handle guest order fields
 	if isGuestOrder.Valid && isGuestOrder.Bool {
 		order.IsGuestOrder = true
-		order.CustomerDetails = entity.CustomerDetails{
-			Email:    customerEmail.String,
-			Phone:    customerPhone.String,
-			FullName: customerFullName.String,
-		}
+		order.CustomerDetails.Email = customerEmail.String
+		order.CustomerDetails.Phone = customerPhone.String
+		order.CustomerDetails.FullName = customerFullName.String
 	}
 
 	order.AppliedDiscount = &entity.AppliedDiscount{
 		DiscountID:     uint(discountID.Int64),
 		DiscountCode:   discountCode.String,
-		DiscountAmount: order.DiscountAmount,
+		DiscountAmount: order.DiscountAmount + order.ShippingDiscountAmount,
 	}
 
 	if order.FinalAmount == 0 {
@@ -829,9 +1078,25 @@ func (r *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error
 		order.TotalWeight = totalWeight.Float64
 	}
 
+	if err := json.Unmarshal(packagesJSON, &order.Packages); err != nil {
+		return nil, err
+	}
+
+	if order.ShippingLabel, err = unmarshalShippingLabel(labelJSON); err != nil {
+		return nil, err
+	}
+
+	if order.PickupPoint, err = unmarshalPickupPoint(pickupPointJSON); err != nil {
+		return nil, err
+	}
+	order.EstimatedDelivery = deliveryEstimate(deliveryEarliest, deliveryLatest)
+	order.AuthorizationExpiresAt = nullTimePtr(authorizationExpiresAt)
+	order.AuthorizationWarnedAt = nullTimePtr(authorizationWarnedAt)
+
 	// Get order items
 	query = `
 		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.price, oi.subtotal,
+			COALESCE(oi.tax_class_id, 0), oi.tax_rate, oi.tax_amount,
 			p.name as product_name, p.product_number as sku
 		FROM order_items oi
 		LEFT JOIN products p ON p.id = oi.product_id
@@ -855,6 +1120,9 @@ func (r *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error
 			&item.Quantity,
 			&item.Price,
 			&item.Subtotal,
+			&item.TaxClassID,
+			&item.TaxRate,
+			&item.TaxAmount,
 			&productName,
 			&sku,
 		)
@@ -878,8 +1146,9 @@ func (r *OrderRepository) ListAll(offset, limit int) ([]*entity.Order, error) {
 	query := `
 		SELECT id, order_number, user_id, total_amount, status,
 			payment_id, payment_provider, created_at, updated_at, completed_at,
-			discount_amount, discount_id, discount_code, final_amount,
-			customer_email, customer_phone, customer_full_name, is_guest_order, shipping_method_id, shipping_cost
+			discount_amount, shipping_discount_amount, discount_id, discount_code, final_amount,
+			customer_email, customer_phone, customer_full_name, is_guest_order, shipping_method_id, shipping_cost,
+			currency, exchange_rate
 		FROM orders
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -913,6 +1182,7 @@ func (r *OrderRepository) ListAll(offset, limit int) ([]*entity.Order, error) {
 			&order.UpdatedAt,
 			&completedAt,
 			&order.DiscountAmount,
+			&order.ShippingDiscountAmount,
 			&discountID,
 			&discountCode,
 			&order.FinalAmount,
@@ -922,6 +1192,8 @@ func (r *OrderRepository) ListAll(offset, limit int) ([]*entity.Order, error) {
 			&isGuestOrder,
 			&order.ShippingMethodID,
 			&order.ShippingCost,
+			&order.Currency,
+			&order.ExchangeRate,
 		)
 
 		if err != nil {
@@ -932,7 +1204,7 @@ func (r *OrderRepository) ListAll(offset, limit int) ([]*entity.Order, error) {
 			order.AppliedDiscount = &entity.AppliedDiscount{
 				DiscountID:     uint(discountID.Int64),
 				DiscountCode:   discountCode.String,
-				DiscountAmount: order.DiscountAmount,
+				DiscountAmount: order.DiscountAmount + order.ShippingDiscountAmount,
 			}
 		}
 
@@ -947,3 +1219,87 @@ func (r *OrderRepository) ListAll(offset, limit int) ([]*entity.Order, error) {
 
 	return orders, nil
 }
+
+// marshalPackages converts the packages of an order to JSON, storing no packages as an empty list
+func marshalPackages(packages []entity.OrderPackage) ([]byte, error) {
+	if packages == nil {
+		packages = []entity.OrderPackage{}
+	}
+	return json.Marshal(packages)
+}
+
+// marshalShippingLabel converts the shipping label of an order to JSON, storing no label as NULL
+func marshalShippingLabel(label *entity.ShippingLabel) ([]byte, error) {
+	if label == nil {
+		return nil, nil
+	}
+	return json.Marshal(label)
+}
+
+func unmarshalShippingLabel(data []byte) (*entity.ShippingLabel, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	label := &entity.ShippingLabel{}
+	if err := json.Unmarshal(data, label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// marshalPickupPoint converts the pickup point of an order to JSON, storing no pickup point as NULL
+func marshalPickupPoint(point *entity.PickupPoint) ([]byte, error) {
+	if point == nil {
+		return nil, nil
+	}
+	return json.Marshal(point)
+}
+
+func unmarshalPickupPoint(data []byte) (*entity.PickupPoint, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	point := &entity.PickupPoint{}
+	if err := json.Unmarshal(data, point); err != nil {
+		return nil, err
+	}
+	return point, nil
+}
+
+// deliveryDates splits the estimated delivery of an order into its dates, storing no estimate as NULL
+func deliveryDates(estimate *entity.DeliveryEstimate) (sql.NullTime, sql.NullTime) {
+	if estimate == nil {
+		return sql.NullTime{}, sql.NullTime{}
+	}
+	return sql.NullTime{Time: estimate.Earliest, Valid: true}, sql.NullTime{Time: estimate.Latest, Valid: true}
+}
+
+// deliveryEstimate joins the stored dates of an estimated delivery
+func deliveryEstimate(earliest, latest sql.NullTime) *entity.DeliveryEstimate {
+	if !earliest.Valid || !latest.Valid {
+		return nil
+	}
+	return &entity.DeliveryEstimate{
+		Earliest: entity.CalendarDate(earliest.Time),
+		Latest:   entity.CalendarDate(latest.Time),
+	}
+}
+
+// nullTimePtr converts a nullable timestamp to a pointer, nil when NULL
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// appliedDiscount returns the discount columns of an order, storing no discount as NULL and zero amounts
+func appliedDiscount(order *entity.Order) (sql.NullInt64, sql.NullString, int64, int64) {
+	if order.AppliedDiscount == nil || order.AppliedDiscount.DiscountID == 0 {
+		return sql.NullInt64{}, sql.NullString{}, 0, 0
+	}
+	return sql.NullInt64{Int64: int64(order.AppliedDiscount.DiscountID), Valid: true},
+		sql.NullString{String: order.AppliedDiscount.DiscountCode, Valid: true},
+		order.DiscountAmount,
+		order.ShippingDiscountAmount
+}
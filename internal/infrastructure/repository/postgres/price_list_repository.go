@@ -0,0 +1,445 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// CustomerGroupRepository implements the customer group repository interface using PostgreSQL
+type CustomerGroupRepository struct {
+	db *sql.DB
+}
+
+// NewCustomerGroupRepository creates a new CustomerGroupRepository
+func NewCustomerGroupRepository(db *sql.DB) repository.CustomerGroupRepository {
+	return &CustomerGroupRepository{db: db}
+}
+
+// Create creates a new customer group
+func (r *CustomerGroupRepository) Create(group *entity.CustomerGroup) error {
+	query := `
+		INSERT INTO customer_groups (name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(
+		query,
+		group.Name,
+		group.Description,
+		group.CreatedAt,
+		group.UpdatedAt,
+	).Scan(&group.ID)
+}
+
+// Update updates a customer group
+func (r *CustomerGroupRepository) Update(group *entity.CustomerGroup) error {
+	query := `
+		UPDATE customer_groups
+		SET name = $1, description = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	result, err := r.db.Exec(query, group.Name, group.Description, time.Now(), group.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("customer group not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a customer group. Users in the group keep their account without a group.
+func (r *CustomerGroupRepository) Delete(groupID uint) error {
+	_, err := r.db.Exec("DELETE FROM customer_groups WHERE id = $1", groupID)
+	return err
+}
+
+// GetByID retrieves a customer group by ID
+func (r *CustomerGroupRepository) GetByID(groupID uint) (*entity.CustomerGroup, error) {
+	query := `
+		SELECT id, name, description, created_at, updated_at
+		FROM customer_groups
+		WHERE id = $1
+	`
+
+	group := &entity.CustomerGroup{}
+	var description sql.NullString
+	err := r.db.QueryRow(query, groupID).Scan(
+		&group.ID,
+		&group.Name,
+		&description,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("customer group not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	group.Description = description.String
+
+	return group, nil
+}
+
+// List lists all customer groups
+func (r *CustomerGroupRepository) List() ([]*entity.CustomerGroup, error) {
+	query := `
+		SELECT id, name, description, created_at, updated_at
+		FROM customer_groups
+		ORDER BY name
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []*entity.CustomerGroup{}
+	for rows.Next() {
+		group := &entity.CustomerGroup{}
+		var description sql.NullString
+		if err := rows.Scan(
+			&group.ID,
+			&group.Name,
+			&description,
+			&group.CreatedAt,
+			&group.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		group.Description = description.String
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// PriceListRepository implements the price list repository interface using PostgreSQL
+type PriceListRepository struct {
+	db *sql.DB
+}
+
+// NewPriceListRepository creates a new PriceListRepository
+func NewPriceListRepository(db *sql.DB) repository.PriceListRepository {
+	return &PriceListRepository{db: db}
+}
+
+// Create creates a new price list with its entries and customer groups
+func (r *PriceListRepository) Create(priceList *entity.PriceList) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO price_lists (name, currency_code, discount_percentage, start_date, end_date, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	err = tx.QueryRow(
+		query,
+		priceList.Name,
+		priceList.CurrencyCode,
+		priceList.DiscountPercentage,
+		priceList.StartDate,
+		priceList.EndDate,
+		priceList.Active,
+		priceList.CreatedAt,
+		priceList.UpdatedAt,
+	).Scan(&priceList.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.saveAssociations(tx, priceList); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update updates a price list and replaces its entries and customer groups
+func (r *PriceListRepository) Update(priceList *entity.PriceList) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE price_lists
+		SET name = $1, currency_code = $2, discount_percentage = $3, start_date = $4, end_date = $5,
+			active = $6, updated_at = $7
+		WHERE id = $8
+	`
+
+	result, err := tx.Exec(
+		query,
+		priceList.Name,
+		priceList.CurrencyCode,
+		priceList.DiscountPercentage,
+		priceList.StartDate,
+		priceList.EndDate,
+		priceList.Active,
+		time.Now(),
+		priceList.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("price list not found")
+	}
+
+	if _, err := tx.Exec("DELETE FROM price_list_entries WHERE price_list_id = $1", priceList.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM price_list_customer_groups WHERE price_list_id = $1", priceList.ID); err != nil {
+		return err
+	}
+
+	if err := r.saveAssociations(tx, priceList); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete deletes a price list together with its entries
+func (r *PriceListRepository) Delete(priceListID uint) error {
+	_, err := r.db.Exec("DELETE FROM price_lists WHERE id = $1", priceListID)
+	return err
+}
+
+// GetByID retrieves a price list by ID
+func (r *PriceListRepository) GetByID(priceListID uint) (*entity.PriceList, error) {
+	query := `
+		SELECT id, name, currency_code, discount_percentage, start_date, end_date, active, created_at, updated_at
+		FROM price_lists
+		WHERE id = $1
+	`
+
+	priceList, err := r.scanPriceList(r.db.QueryRow(query, priceListID))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("price list not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadAssociations(priceList); err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+// List lists price lists with pagination
+func (r *PriceListRepository) List(offset, limit int) ([]*entity.PriceList, error) {
+	query := `
+		SELECT id, name, currency_code, discount_percentage, start_date, end_date, active, created_at, updated_at
+		FROM price_lists
+		ORDER BY name, id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPriceLists(rows)
+}
+
+// ListByCustomerGroup lists the active price lists assigned to a customer group
+func (r *PriceListRepository) ListByCustomerGroup(groupID uint) ([]*entity.PriceList, error) {
+	query := `
+		SELECT pl.id, pl.name, pl.currency_code, pl.discount_percentage, pl.start_date, pl.end_date,
+			pl.active, pl.created_at, pl.updated_at
+		FROM price_lists pl
+		JOIN price_list_customer_groups plcg ON plcg.price_list_id = pl.id
+		WHERE plcg.customer_group_id = $1 AND pl.active = true
+		ORDER BY pl.id
+	`
+
+	rows, err := r.db.Query(query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPriceLists(rows)
+}
+
+// saveAssociations inserts the entries and customer groups of a price list
+func (r *PriceListRepository) saveAssociations(tx *sql.Tx, priceList *entity.PriceList) error {
+	if len(priceList.CustomerGroupIDs) > 0 {
+		query := `
+			INSERT INTO price_list_customer_groups (price_list_id, customer_group_id)
+			SELECT $1, UNNEST($2::INTEGER[])
+			ON CONFLICT DO NOTHING
+		`
+		groupIDs := make([]int64, len(priceList.CustomerGroupIDs))
+		for i, id := range priceList.CustomerGroupIDs {
+			groupIDs[i] = int64(id)
+		}
+		if _, err := tx.Exec(query, priceList.ID, pq.Array(groupIDs)); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO price_list_entries (price_list_id, product_id, variant_id, min_quantity, price)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	for i := range priceList.Entries {
+		entry := &priceList.Entries[i]
+		entry.PriceListID = priceList.ID
+		if err := tx.QueryRow(
+			query,
+			priceList.ID,
+			entry.ProductID,
+			entry.VariantID,
+			entry.MinQuantity,
+			entry.Price,
+		).Scan(&entry.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadAssociations loads the entries and customer groups of a price list
+func (r *PriceListRepository) loadAssociations(priceList *entity.PriceList) error {
+	var groupIDs pq.Int64Array
+	err := r.db.QueryRow(
+		"SELECT COALESCE(ARRAY_AGG(customer_group_id ORDER BY customer_group_id), '{}') FROM price_list_customer_groups WHERE price_list_id = $1",
+		priceList.ID,
+	).Scan(&groupIDs)
+	if err != nil {
+		return err
+	}
+
+	priceList.CustomerGroupIDs = make([]uint, len(groupIDs))
+	for i, id := range groupIDs {
+		priceList.CustomerGroupIDs[i] = uint(id)
+	}
+
+	query := `
+		SELECT id, price_list_id, product_id, variant_id, min_quantity, price
+		FROM price_list_entries
+		WHERE price_list_id = $1
+		ORDER BY product_id, variant_id, min_quantity
+	`
+
+	rows, err := r.db.Query(query, priceList.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	priceList.Entries = []entity.PriceListEntry{}
+	for rows.Next() {
+		var entry entity.PriceListEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.PriceListID,
+			&entry.ProductID,
+			&entry.VariantID,
+			&entry.MinQuantity,
+			&entry.Price,
+		); err != nil {
+			return err
+		}
+		priceList.Entries = append(priceList.Entries, entry)
+	}
+
+	return rows.Err()
+}
+
+// scanPriceLists scans all rows into price lists and loads their associations
+func (r *PriceListRepository) scanPriceLists(rows *sql.Rows) ([]*entity.PriceList, error) {
+	priceLists := []*entity.PriceList{}
+	for rows.Next() {
+		priceList, err := r.scanPriceList(rows)
+		if err != nil {
+			return nil, err
+		}
+		priceLists = append(priceLists, priceList)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Load associations once the result set is consumed
+	for _, priceList := range priceLists {
+		if err := r.loadAssociations(priceList); err != nil {
+			return nil, err
+		}
+	}
+
+	return priceLists, nil
+}
+
+// scanPriceList scans a single price list without its associations
+func (r *PriceListRepository) scanPriceList(row interface{ Scan(...any) error }) (*entity.PriceList, error) {
+	priceList := &entity.PriceList{}
+	var startDate, endDate sql.NullTime
+
+	err := row.Scan(
+		&priceList.ID,
+		&priceList.Name,
+		&priceList.CurrencyCode,
+		&priceList.DiscountPercentage,
+		&startDate,
+		&endDate,
+		&priceList.Active,
+		&priceList.CreatedAt,
+		&priceList.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if startDate.Valid {
+		priceList.StartDate = &startDate.Time
+	}
+	if endDate.Valid {
+		priceList.EndDate = &endDate.Time
+	}
+
+	return priceList, nil
+}
@@ -12,6 +12,11 @@ import (
 	"github.com/zenfulcode/commercify/internal/domain/repository"
 )
 
+// effectivePriceSQL selects the sale price of products on sale and the regular price otherwise
+const effectivePriceSQL = `(CASE WHEN sale_price > 0 AND sale_price < price
+	AND (sale_starts_at IS NULL OR sale_starts_at <= NOW())
+	AND (sale_ends_at IS NULL OR sale_ends_at > NOW()) THEN sale_price ELSE price END)`
+
 // ProductRepository is the PostgreSQL implementation of the ProductRepository interface
 type ProductRepository struct {
 	db                *sql.DB
@@ -30,8 +35,9 @@ func NewProductRepository(db *sql.DB, variantRepository repository.ProductVarian
 func (r *ProductRepository) Create(product *entity.Product) error {
 	query := `
 
-	INSERT INTO products (name, description, price, currency_code, stock, weight, category_id, images, has_variants,active, created_at, updated_at)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8,$9, $10, $11)
+	INSERT INTO products (name, description, price, currency_code, stock, weight, category_id, images, has_variants, active, created_at, updated_at,
+		compare_at_price, sale_price, sale_starts_at, sale_ends_at, tax_class_id, length, width, height, shipping_profile_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, NULLIF($17, 0), $18, $19, $20, NULLIF($21, 0))
 	RETURNING id
 	`
 
@@ -54,6 +60,15 @@ func (r *ProductRepository) Create(product *entity.Product) error {
 		product.Active,
 		product.CreatedAt,
 		product.UpdatedAt,
+		product.CompareAtPrice,
+		product.SalePrice,
+		product.SaleStartsAt,
+		product.SaleEndsAt,
+		product.TaxClassID,
+		product.Dimensions.Length,
+		product.Dimensions.Width,
+		product.Dimensions.Height,
+		product.ShippingProfileID,
 	).Scan(&product.ID)
 	if err != nil {
 		return err
@@ -85,10 +100,15 @@ func (r *ProductRepository) Create(product *entity.Product) error {
 // createProductPrice creates a product price entry for a specific currency
 func (r *ProductRepository) createProductPrice(price *entity.ProductPrice) error {
 	query := `
-		INSERT INTO product_prices (product_id, currency_code, price, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO product_prices (product_id, currency_code, price, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (product_id, currency_code) DO UPDATE SET
 		price = EXCLUDED.price,
+		compare_at_price = EXCLUDED.compare_at_price,
+		sale_price = EXCLUDED.sale_price,
+		sale_starts_at = EXCLUDED.sale_starts_at,
+		sale_ends_at = EXCLUDED.sale_ends_at,
 		updated_at = EXCLUDED.updated_at
 		RETURNING id
 		`
@@ -102,13 +122,19 @@ func (r *ProductRepository) createProductPrice(price *entity.ProductPrice) error
 		price.Price,
 		now,
 		now,
+		price.CompareAtPrice,
+		price.SalePrice,
+		price.SaleStartsAt,
+		price.SaleEndsAt,
 	).Scan(&price.ID)
 }
 
 // GetByID gets a product by ID
 func (r *ProductRepository) GetByID(productID uint) (*entity.Product, error) {
 	query := `
-			SELECT id, product_number, name, description, price, currency_code, stock, weight, category_id, images, has_variants, active, created_at, updated_at
+			SELECT id, product_number, name, description, price, currency_code, stock, weight, category_id, images, has_variants, active, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at, COALESCE(tax_class_id, 0),
+			length, width, height, COALESCE(shipping_profile_id, 0)
 			FROM products
 			WHERE id = $1
 			`
@@ -132,6 +158,15 @@ func (r *ProductRepository) GetByID(productID uint) (*entity.Product, error) {
 		&product.Active,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.CompareAtPrice,
+		&product.SalePrice,
+		&product.SaleStartsAt,
+		&product.SaleEndsAt,
+		&product.TaxClassID,
+		&product.Dimensions.Length,
+		&product.Dimensions.Width,
+		&product.Dimensions.Height,
+		&product.ShippingProfileID,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -163,7 +198,8 @@ func (r *ProductRepository) GetByID(productID uint) (*entity.Product, error) {
 // getProductPrices retrieves all prices for a product in different currencies
 func (r *ProductRepository) getProductPrices(productID uint) ([]entity.ProductPrice, error) {
 	query := `
-			SELECT id, product_id, currency_code, price, created_at, updated_at
+			SELECT id, product_id, currency_code, price, created_at, updated_at,
+				compare_at_price, sale_price, sale_starts_at, sale_ends_at
 			FROM product_prices
 			WHERE product_id = $1
 			`
@@ -185,6 +221,10 @@ func (r *ProductRepository) getProductPrices(productID uint) ([]entity.ProductPr
 			&price.Price,
 			&price.CreatedAt,
 			&price.UpdatedAt,
+			&price.CompareAtPrice,
+			&price.SalePrice,
+			&price.SaleStartsAt,
+			&price.SaleEndsAt,
 		)
 		if err != nil {
 			return nil, err
@@ -226,8 +266,10 @@ func (r *ProductRepository) Update(product *entity.Product) error {
 	query := `
 			UPDATE products
 			SET name = $1, description = $2, price = $3, currency_code = $4, stock = $5, weight = $6, category_id = $7, 
-		    images = $8, has_variants = $9, updated_at = $10
-			WHERE id = $11
+		    images = $8, has_variants = $9, updated_at = $10, compare_at_price = $11, sale_price = $12,
+		    sale_starts_at = $13, sale_ends_at = $14, tax_class_id = NULLIF($15, 0),
+		    length = $16, width = $17, height = $18, shipping_profile_id = NULLIF($19, 0)
+			WHERE id = $20
 			`
 
 	imagesJSON, err := json.Marshal(product.Images)
@@ -247,6 +289,15 @@ func (r *ProductRepository) Update(product *entity.Product) error {
 		imagesJSON,
 		product.HasVariants,
 		time.Now(),
+		product.CompareAtPrice,
+		product.SalePrice,
+		product.SaleStartsAt,
+		product.SaleEndsAt,
+		product.TaxClassID,
+		product.Dimensions.Length,
+		product.Dimensions.Width,
+		product.Dimensions.Height,
+		product.ShippingProfileID,
 		product.ID,
 	)
 	if err != nil {
@@ -257,13 +308,15 @@ func (r *ProductRepository) Update(product *entity.Product) error {
 	if len(product.Prices) > 0 {
 		// Use an upsert query to update or insert prices
 		query := `
-			INSERT INTO product_prices (product_id, currency_code, price)
-			VALUES ($1, $2, $3)
+			INSERT INTO product_prices (product_id, currency_code, price, compare_at_price, sale_price, sale_starts_at, sale_ends_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
 			ON CONFLICT (product_id, currency_code)
-			DO UPDATE SET price = EXCLUDED.price
+			DO UPDATE SET price = EXCLUDED.price, compare_at_price = EXCLUDED.compare_at_price,
+				sale_price = EXCLUDED.sale_price, sale_starts_at = EXCLUDED.sale_starts_at, sale_ends_at = EXCLUDED.sale_ends_at
 		`
 		for _, price := range product.Prices {
-			_, err := r.db.Exec(query, product.ID, price.CurrencyCode, price.Price)
+			_, err := r.db.Exec(query, product.ID, price.CurrencyCode, price.Price,
+				price.CompareAtPrice, price.SalePrice, price.SaleStartsAt, price.SaleEndsAt)
 			if err != nil {
 				return err
 			}
@@ -314,7 +367,9 @@ func (r *ProductRepository) Delete(productID uint) error {
 func (r *ProductRepository) List(offset, limit int) ([]*entity.Product, error) {
 	query := `
 
-		SELECT id, product_number, name, description, price, currency_code, stock, weight, category_id, images, has_variants, active, created_at, updated_at
+		SELECT id, product_number, name, description, price, currency_code, stock, weight, category_id, images, has_variants, active, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at, COALESCE(tax_class_id, 0),
+			length, width, height, COALESCE(shipping_profile_id, 0)
 		FROM products
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -347,6 +402,15 @@ func (r *ProductRepository) List(offset, limit int) ([]*entity.Product, error) {
 			&product.Active,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.CompareAtPrice,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.TaxClassID,
+			&product.Dimensions.Length,
+			&product.Dimensions.Width,
+			&product.Dimensions.Height,
+			&product.ShippingProfileID,
 		)
 		if err != nil {
 			return nil, err
@@ -383,7 +447,9 @@ func (r *ProductRepository) List(offset, limit int) ([]*entity.Product, error) {
 func (r *ProductRepository) Search(query string, categoryID uint, minPriceCents, maxPriceCents int64, offset, limit int) ([]*entity.Product, error) {
 	// Build dynamic query parts
 	searchQuery := `
-		SELECT id, product_number, name, description, price, currency_code, stock, weight, category_id, images, has_variants, active, created_at, updated_at
+		SELECT id, product_number, name, description, price, currency_code, stock, weight, category_id, images, has_variants, active, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at, COALESCE(tax_class_id, 0),
+			length, width, height, COALESCE(shipping_profile_id, 0)
 		FROM products
 		WHERE 1=1
 	`
@@ -403,13 +469,13 @@ func (r *ProductRepository) Search(query string, categoryID uint, minPriceCents,
 	}
 
 	if minPriceCents > 0 {
-		searchQuery += fmt.Sprintf(" AND price >= $%d", paramCounter)
+		searchQuery += fmt.Sprintf(" AND %s >= $%d", effectivePriceSQL, paramCounter)
 		queryParams = append(queryParams, minPriceCents) // Use cents
 		paramCounter++
 	}
 
 	if maxPriceCents > 0 {
-		searchQuery += fmt.Sprintf(" AND price <= $%d", paramCounter)
+		searchQuery += fmt.Sprintf(" AND %s <= $%d", effectivePriceSQL, paramCounter)
 		queryParams = append(queryParams, maxPriceCents) // Use cents
 		paramCounter++
 	}
@@ -447,6 +513,15 @@ func (r *ProductRepository) Search(query string, categoryID uint, minPriceCents,
 			&product.Active,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.CompareAtPrice,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.TaxClassID,
+			&product.Dimensions.Length,
+			&product.Dimensions.Width,
+			&product.Dimensions.Height,
+			&product.ShippingProfileID,
 		)
 		if err != nil {
 			return nil, err
@@ -510,13 +585,13 @@ func (r *ProductRepository) CountSearch(searchQuery string, categoryID uint, min
 	}
 
 	if minPriceCents > 0 {
-		query += fmt.Sprintf(" AND price >= $%d", paramCounter)
+		query += fmt.Sprintf(" AND %s >= $%d", effectivePriceSQL, paramCounter)
 		queryParams = append(queryParams, minPriceCents)
 		paramCounter++
 	}
 
 	if maxPriceCents > 0 {
-		query += fmt.Sprintf(" AND price <= $%d", paramCounter)
+		query += fmt.Sprintf(" AND %s <= $%d", effectivePriceSQL, paramCounter)
 		queryParams = append(queryParams, maxPriceCents)
 		paramCounter++
 	}
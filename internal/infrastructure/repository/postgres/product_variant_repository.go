@@ -26,8 +26,9 @@ func NewProductVariantRepository(db *sql.DB) repository.ProductVariantRepository
 // Create creates a new product variant
 func (r *ProductVariantRepository) Create(variant *entity.ProductVariant) error {
 	query := `
-		INSERT INTO product_variants (product_id, sku, price, currency_code, stock, attributes, images, is_default, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO product_variants (product_id, sku, price, currency_code, stock, attributes, images, is_default, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at, length, width, height)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		RETURNING id
 	`
 
@@ -55,6 +56,13 @@ func (r *ProductVariantRepository) Create(variant *entity.ProductVariant) error
 		variant.IsDefault,
 		variant.CreatedAt,
 		variant.UpdatedAt,
+		variant.CompareAtPrice,
+		variant.SalePrice,
+		variant.SaleStartsAt,
+		variant.SaleEndsAt,
+		variant.Dimensions.Length,
+		variant.Dimensions.Width,
+		variant.Dimensions.Height,
 	).Scan(&variant.ID)
 
 	if err != nil {
@@ -81,10 +89,15 @@ func (r *ProductVariantRepository) Create(variant *entity.ProductVariant) error
 // createVariantPrice creates a variant price entry for a specific currency
 func (r *ProductVariantRepository) createVariantPrice(price *entity.ProductVariantPrice) error {
 	query := `
-		INSERT INTO product_variant_prices (variant_id, currency_code, price, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO product_variant_prices (variant_id, currency_code, price, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (variant_id, currency_code) DO UPDATE SET
 			price = EXCLUDED.price,
+			compare_at_price = EXCLUDED.compare_at_price,
+			sale_price = EXCLUDED.sale_price,
+			sale_starts_at = EXCLUDED.sale_starts_at,
+			sale_ends_at = EXCLUDED.sale_ends_at,
 			updated_at = EXCLUDED.updated_at
 		RETURNING id
 	`
@@ -98,13 +111,18 @@ func (r *ProductVariantRepository) createVariantPrice(price *entity.ProductVaria
 		price.Price,
 		now,
 		now,
+		price.CompareAtPrice,
+		price.SalePrice,
+		price.SaleStartsAt,
+		price.SaleEndsAt,
 	).Scan(&price.ID)
 }
 
 // GetByID gets a variant by ID
 func (r *ProductVariantRepository) GetByID(variantID uint) (*entity.ProductVariant, error) {
 	query := `
-		SELECT id, product_id, sku, price, currency_code, stock, attributes, images, is_default, created_at, updated_at
+		SELECT id, product_id, sku, price, currency_code, stock, attributes, images, is_default, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at, length, width, height
 		FROM product_variants
 		WHERE id = $1
 	`
@@ -124,6 +142,13 @@ func (r *ProductVariantRepository) GetByID(variantID uint) (*entity.ProductVaria
 		&variant.IsDefault,
 		&variant.CreatedAt,
 		&variant.UpdatedAt,
+		&variant.CompareAtPrice,
+		&variant.SalePrice,
+		&variant.SaleStartsAt,
+		&variant.SaleEndsAt,
+		&variant.Dimensions.Length,
+		&variant.Dimensions.Width,
+		&variant.Dimensions.Height,
 	)
 
 	if err != nil {
@@ -156,7 +181,8 @@ func (r *ProductVariantRepository) GetByID(variantID uint) (*entity.ProductVaria
 // getVariantPrices retrieves all prices for a variant in different currencies
 func (r *ProductVariantRepository) getVariantPrices(variantID uint) ([]entity.ProductVariantPrice, error) {
 	query := `
-		SELECT id, variant_id, currency_code, price, created_at, updated_at
+		SELECT id, variant_id, currency_code, price, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at
 		FROM product_variant_prices
 		WHERE variant_id = $1
 	`
@@ -178,6 +204,10 @@ func (r *ProductVariantRepository) getVariantPrices(variantID uint) ([]entity.Pr
 			&price.Price,
 			&price.CreatedAt,
 			&price.UpdatedAt,
+			&price.CompareAtPrice,
+			&price.SalePrice,
+			&price.SaleStartsAt,
+			&price.SaleEndsAt,
 		)
 		if err != nil {
 			return nil, err
@@ -198,8 +228,10 @@ func (r *ProductVariantRepository) Update(variant *entity.ProductVariant) error
 	query := `
 		UPDATE product_variants
 		SET sku = $1, price = $2, currency_code = $3, stock = $4, 
-		    attributes = $5, images = $6, is_default = $7, updated_at = $8
-		WHERE id = $9
+		    attributes = $5, images = $6, is_default = $7, updated_at = $8,
+		    compare_at_price = $9, sale_price = $10, sale_starts_at = $11, sale_ends_at = $12,
+		    length = $13, width = $14, height = $15
+		WHERE id = $16
 	`
 
 	// Marshal attributes directly
@@ -224,6 +256,13 @@ func (r *ProductVariantRepository) Update(variant *entity.ProductVariant) error
 		imagesJSON,
 		variant.IsDefault,
 		time.Now(),
+		variant.CompareAtPrice,
+		variant.SalePrice,
+		variant.SaleStartsAt,
+		variant.SaleEndsAt,
+		variant.Dimensions.Length,
+		variant.Dimensions.Width,
+		variant.Dimensions.Height,
 		variant.ID,
 	)
 
@@ -338,7 +377,8 @@ func (r *ProductVariantRepository) Delete(variantID uint) error {
 // GetByProduct gets all variants for a product
 func (r *ProductVariantRepository) GetByProduct(productID uint) ([]*entity.ProductVariant, error) {
 	query := `
-		SELECT id, product_id, sku, price, currency_code, stock, attributes, images, is_default, created_at, updated_at
+		SELECT id, product_id, sku, price, currency_code, stock, attributes, images, is_default, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at, length, width, height
 		FROM product_variants
 		WHERE product_id = $1
 		ORDER BY is_default DESC, id ASC
@@ -367,6 +407,13 @@ func (r *ProductVariantRepository) GetByProduct(productID uint) ([]*entity.Produ
 			&variant.IsDefault,
 			&variant.CreatedAt,
 			&variant.UpdatedAt,
+			&variant.CompareAtPrice,
+			&variant.SalePrice,
+			&variant.SaleStartsAt,
+			&variant.SaleEndsAt,
+			&variant.Dimensions.Length,
+			&variant.Dimensions.Width,
+			&variant.Dimensions.Height,
 		)
 		if err != nil {
 			return nil, err
@@ -403,7 +450,8 @@ func (r *ProductVariantRepository) GetByProduct(productID uint) ([]*entity.Produ
 // GetBySKU gets a variant by SKU
 func (r *ProductVariantRepository) GetBySKU(sku string) (*entity.ProductVariant, error) {
 	query := `
-		SELECT id, product_id, sku, price, currency_code, stock, attributes, images, is_default, created_at, updated_at
+		SELECT id, product_id, sku, price, currency_code, stock, attributes, images, is_default, created_at, updated_at,
+			compare_at_price, sale_price, sale_starts_at, sale_ends_at, length, width, height
 		FROM product_variants
 		WHERE sku = $1
 	`
@@ -423,6 +471,13 @@ func (r *ProductVariantRepository) GetBySKU(sku string) (*entity.ProductVariant,
 		&variant.IsDefault,
 		&variant.CreatedAt,
 		&variant.UpdatedAt,
+		&variant.CompareAtPrice,
+		&variant.SalePrice,
+		&variant.SaleStartsAt,
+		&variant.SaleEndsAt,
+		&variant.Dimensions.Length,
+		&variant.Dimensions.Width,
+		&variant.Dimensions.Height,
 	)
 
 	if err != nil {
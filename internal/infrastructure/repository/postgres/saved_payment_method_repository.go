@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// SavedPaymentMethodRepository implements the saved payment method repository interface using PostgreSQL
+type SavedPaymentMethodRepository struct {
+	db *sql.DB
+}
+
+// NewSavedPaymentMethodRepository creates a new SavedPaymentMethodRepository
+func NewSavedPaymentMethodRepository(db *sql.DB) repository.SavedPaymentMethodRepository {
+	return &SavedPaymentMethodRepository{db: db}
+}
+
+// Create creates a new saved payment method
+func (r *SavedPaymentMethodRepository) Create(method *entity.SavedPaymentMethod) error {
+	query := `
+		INSERT INTO saved_payment_methods (user_id, provider, provider_method_id, brand, last4, exp_month, exp_year, is_default, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(
+		query,
+		method.UserID,
+		method.Provider,
+		method.ProviderMethodID,
+		method.Brand,
+		method.Last4,
+		method.ExpMonth,
+		method.ExpYear,
+		method.IsDefault,
+		method.CreatedAt,
+		method.UpdatedAt,
+	).Scan(&method.ID)
+}
+
+// GetByID retrieves a saved payment method by ID
+func (r *SavedPaymentMethodRepository) GetByID(methodID uint) (*entity.SavedPaymentMethod, error) {
+	query := `
+		SELECT id, user_id, provider, provider_method_id, brand, last4, exp_month, exp_year, is_default, created_at, updated_at
+		FROM saved_payment_methods
+		WHERE id = $1
+	`
+
+	method, err := scanSavedPaymentMethod(r.db.QueryRow(query, methodID))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("payment method not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
+
+// GetByProviderMethodID retrieves a saved payment method by the provider's reference
+func (r *SavedPaymentMethodRepository) GetByProviderMethodID(providerMethodID string) (*entity.SavedPaymentMethod, error) {
+	query := `
+		SELECT id, user_id, provider, provider_method_id, brand, last4, exp_month, exp_year, is_default, created_at, updated_at
+		FROM saved_payment_methods
+		WHERE provider_method_id = $1
+	`
+
+	method, err := scanSavedPaymentMethod(r.db.QueryRow(query, providerMethodID))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("payment method not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
+
+// ListByUser lists a user's saved payment methods, the default first
+func (r *SavedPaymentMethodRepository) ListByUser(userID uint) ([]*entity.SavedPaymentMethod, error) {
+	query := `
+		SELECT id, user_id, provider, provider_method_id, brand, last4, exp_month, exp_year, is_default, created_at, updated_at
+		FROM saved_payment_methods
+		WHERE user_id = $1
+		ORDER BY is_default DESC, created_at DESC
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	methods := []*entity.SavedPaymentMethod{}
+	for rows.Next() {
+		method, err := scanSavedPaymentMethod(rows)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, method)
+	}
+
+	return methods, rows.Err()
+}
+
+// Update updates the card details of a saved payment method
+func (r *SavedPaymentMethodRepository) Update(method *entity.SavedPaymentMethod) error {
+	query := `
+		UPDATE saved_payment_methods
+		SET brand = $1, last4 = $2, exp_month = $3, exp_year = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	_, err := r.db.Exec(
+		query,
+		method.Brand,
+		method.Last4,
+		method.ExpMonth,
+		method.ExpYear,
+		time.Now(),
+		method.ID,
+	)
+
+	return err
+}
+
+// Delete deletes a saved payment method
+func (r *SavedPaymentMethodRepository) Delete(methodID uint) error {
+	query := `DELETE FROM saved_payment_methods WHERE id = $1`
+	_, err := r.db.Exec(query, methodID)
+	return err
+}
+
+// SetDefault makes a method the user's default and clears the flag on their other methods
+func (r *SavedPaymentMethodRepository) SetDefault(userID, methodID uint) error {
+	query := `
+		UPDATE saved_payment_methods
+		SET is_default = (id = $2), updated_at = $3
+		WHERE user_id = $1
+	`
+
+	_, err := r.db.Exec(query, userID, methodID, time.Now())
+	return err
+}
+
+// GetCustomerID returns the user's customer ID at a provider, empty if they have none
+func (r *SavedPaymentMethodRepository) GetCustomerID(userID uint, provider string) (string, error) {
+	query := `SELECT customer_id FROM payment_customers WHERE user_id = $1 AND provider = $2`
+
+	var customerID string
+	err := r.db.QueryRow(query, userID, provider).Scan(&customerID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return customerID, err
+}
+
+// SetCustomerID saves the user's customer ID at a provider
+func (r *SavedPaymentMethodRepository) SetCustomerID(userID uint, provider, customerID string) error {
+	query := `
+		INSERT INTO payment_customers (user_id, provider, customer_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, provider) DO UPDATE SET customer_id = EXCLUDED.customer_id
+	`
+
+	_, err := r.db.Exec(query, userID, provider, customerID)
+	return err
+}
+
+// GetUserIDByCustomerID returns the user of a provider customer, 0 if it is unknown
+func (r *SavedPaymentMethodRepository) GetUserIDByCustomerID(provider, customerID string) (uint, error) {
+	query := `SELECT user_id FROM payment_customers WHERE provider = $1 AND customer_id = $2`
+
+	var userID uint
+	err := r.db.QueryRow(query, provider, customerID).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return userID, err
+}
+
+// scanSavedPaymentMethod scans a saved payment method row
+func scanSavedPaymentMethod(row interface{ Scan(...any) error }) (*entity.SavedPaymentMethod, error) {
+	method := &entity.SavedPaymentMethod{}
+
+	err := row.Scan(
+		&method.ID,
+		&method.UserID,
+		&method.Provider,
+		&method.ProviderMethodID,
+		&method.Brand,
+		&method.Last4,
+		&method.ExpMonth,
+		&method.ExpYear,
+		&method.IsDefault,
+		&method.CreatedAt,
+		&method.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
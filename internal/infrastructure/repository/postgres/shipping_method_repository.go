@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -21,17 +22,29 @@ func NewShippingMethodRepository(db *sql.DB) repository.ShippingMethodRepository
 
 // Create creates a new shipping method
 func (r *ShippingMethodRepository) Create(method *entity.ShippingMethod) error {
+	restrictionsJSON, err := json.Marshal(method.Restrictions)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO shipping_methods (name, description, estimated_delivery_days, active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO shipping_methods (name, description, estimated_delivery_days, carrier, carrier_service_code, pickup_point,
+			cutoff_time, handling_days, restrictions, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id
 	`
 
-	err := r.db.QueryRow(
+	err = r.db.QueryRow(
 		query,
 		method.Name,
 		method.Description,
 		method.EstimatedDeliveryDays,
+		method.Carrier,
+		method.CarrierServiceCode,
+		method.PickupPoint,
+		method.CutoffTime,
+		method.HandlingDays,
+		restrictionsJSON,
 		method.Active,
 		method.CreatedAt,
 		method.UpdatedAt,
@@ -43,17 +56,24 @@ func (r *ShippingMethodRepository) Create(method *entity.ShippingMethod) error {
 // GetByID retrieves a shipping method by ID
 func (r *ShippingMethodRepository) GetByID(methodID uint) (*entity.ShippingMethod, error) {
 	query := `
-		SELECT id, name, description, estimated_delivery_days, active, created_at, updated_at
+		SELECT id, name, description, estimated_delivery_days, carrier, carrier_service_code, pickup_point, cutoff_time, handling_days, restrictions, active, created_at, updated_at
 		FROM shipping_methods
 		WHERE id = $1
 	`
 
 	method := &entity.ShippingMethod{}
+	var restrictionsJSON []byte
 	err := r.db.QueryRow(query, methodID).Scan(
 		&method.ID,
 		&method.Name,
 		&method.Description,
 		&method.EstimatedDeliveryDays,
+		&method.Carrier,
+		&method.CarrierServiceCode,
+		&method.PickupPoint,
+		&method.CutoffTime,
+		&method.HandlingDays,
+		&restrictionsJSON,
 		&method.Active,
 		&method.CreatedAt,
 		&method.UpdatedAt,
@@ -67,6 +87,10 @@ func (r *ShippingMethodRepository) GetByID(methodID uint) (*entity.ShippingMetho
 		return nil, err
 	}
 
+	if err := json.Unmarshal(restrictionsJSON, &method.Restrictions); err != nil {
+		return nil, err
+	}
+
 	return method, nil
 }
 
@@ -78,7 +102,7 @@ func (r *ShippingMethodRepository) List(active bool) ([]*entity.ShippingMethod,
 
 	if active {
 		query = `
-			SELECT id, name, description, estimated_delivery_days, active, created_at, updated_at
+			SELECT id, name, description, estimated_delivery_days, carrier, carrier_service_code, pickup_point, cutoff_time, handling_days, restrictions, active, created_at, updated_at
 			FROM shipping_methods
 			WHERE active = true
 			ORDER BY name
@@ -86,7 +110,7 @@ func (r *ShippingMethodRepository) List(active bool) ([]*entity.ShippingMethod,
 		rows, err = r.db.Query(query)
 	} else {
 		query = `
-			SELECT id, name, description, estimated_delivery_days, active, created_at, updated_at
+			SELECT id, name, description, estimated_delivery_days, carrier, carrier_service_code, pickup_point, cutoff_time, handling_days, restrictions, active, created_at, updated_at
 			FROM shipping_methods
 			ORDER BY name
 		`
@@ -101,11 +125,18 @@ func (r *ShippingMethodRepository) List(active bool) ([]*entity.ShippingMethod,
 	methods := []*entity.ShippingMethod{}
 	for rows.Next() {
 		method := &entity.ShippingMethod{}
+		var restrictionsJSON []byte
 		err := rows.Scan(
 			&method.ID,
 			&method.Name,
 			&method.Description,
 			&method.EstimatedDeliveryDays,
+			&method.Carrier,
+			&method.CarrierServiceCode,
+			&method.PickupPoint,
+			&method.CutoffTime,
+			&method.HandlingDays,
+			&restrictionsJSON,
 			&method.Active,
 			&method.CreatedAt,
 			&method.UpdatedAt,
@@ -113,6 +144,9 @@ func (r *ShippingMethodRepository) List(active bool) ([]*entity.ShippingMethod,
 		if err != nil {
 			return nil, err
 		}
+		if err := json.Unmarshal(restrictionsJSON, &method.Restrictions); err != nil {
+			return nil, err
+		}
 		methods = append(methods, method)
 	}
 
@@ -121,17 +155,29 @@ func (r *ShippingMethodRepository) List(active bool) ([]*entity.ShippingMethod,
 
 // Update updates a shipping method
 func (r *ShippingMethodRepository) Update(method *entity.ShippingMethod) error {
+	restrictionsJSON, err := json.Marshal(method.Restrictions)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE shipping_methods
-		SET name = $1, description = $2, estimated_delivery_days = $3, active = $4, updated_at = $5
-		WHERE id = $6
+		SET name = $1, description = $2, estimated_delivery_days = $3, carrier = $4, carrier_service_code = $5,
+			pickup_point = $6, cutoff_time = $7, handling_days = $8, restrictions = $9, active = $10, updated_at = $11
+		WHERE id = $12
 	`
 
-	_, err := r.db.Exec(
+	_, err = r.db.Exec(
 		query,
 		method.Name,
 		method.Description,
 		method.EstimatedDeliveryDays,
+		method.Carrier,
+		method.CarrierServiceCode,
+		method.PickupPoint,
+		method.CutoffTime,
+		method.HandlingDays,
+		restrictionsJSON,
 		method.Active,
 		time.Now(),
 		method.ID,
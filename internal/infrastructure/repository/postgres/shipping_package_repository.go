@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// ShippingPackageRepository implements the shipping package repository interface using PostgreSQL
+type ShippingPackageRepository struct {
+	db *sql.DB
+}
+
+// NewShippingPackageRepository creates a new ShippingPackageRepository
+func NewShippingPackageRepository(db *sql.DB) repository.ShippingPackageRepository {
+	return &ShippingPackageRepository{db: db}
+}
+
+// Create creates a new shipping package
+func (r *ShippingPackageRepository) Create(pkg *entity.ShippingPackage) error {
+	query := `
+		INSERT INTO shipping_packages (name, length, width, height, weight, max_weight, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(
+		query,
+		pkg.Name,
+		pkg.Dimensions.Length,
+		pkg.Dimensions.Width,
+		pkg.Dimensions.Height,
+		pkg.Weight,
+		pkg.MaxWeight,
+		pkg.Active,
+		pkg.CreatedAt,
+		pkg.UpdatedAt,
+	).Scan(&pkg.ID)
+}
+
+// GetByID retrieves a shipping package by ID
+func (r *ShippingPackageRepository) GetByID(packageID uint) (*entity.ShippingPackage, error) {
+	query := `
+		SELECT id, name, length, width, height, weight, max_weight, active, created_at, updated_at
+		FROM shipping_packages
+		WHERE id = $1
+	`
+
+	pkg, err := scanShippingPackage(r.db.QueryRow(query, packageID))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("shipping package not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// List retrieves shipping packages, smallest first
+func (r *ShippingPackageRepository) List(active bool) ([]*entity.ShippingPackage, error) {
+	query := `
+		SELECT id, name, length, width, height, weight, max_weight, active, created_at, updated_at
+		FROM shipping_packages
+		WHERE active = true OR $1 = false
+		ORDER BY length * width * height, id
+	`
+
+	rows, err := r.db.Query(query, active)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	packages := []*entity.ShippingPackage{}
+	for rows.Next() {
+		pkg, err := scanShippingPackage(rows)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkg)
+	}
+
+	return packages, rows.Err()
+}
+
+// Update updates a shipping package
+func (r *ShippingPackageRepository) Update(pkg *entity.ShippingPackage) error {
+	query := `
+		UPDATE shipping_packages
+		SET name = $1, length = $2, width = $3, height = $4, weight = $5, max_weight = $6, active = $7, updated_at = $8
+		WHERE id = $9
+	`
+
+	_, err := r.db.Exec(
+		query,
+		pkg.Name,
+		pkg.Dimensions.Length,
+		pkg.Dimensions.Width,
+		pkg.Dimensions.Height,
+		pkg.Weight,
+		pkg.MaxWeight,
+		pkg.Active,
+		time.Now(),
+		pkg.ID,
+	)
+
+	return err
+}
+
+// Delete deletes a shipping package
+func (r *ShippingPackageRepository) Delete(packageID uint) error {
+	query := `DELETE FROM shipping_packages WHERE id = $1`
+	_, err := r.db.Exec(query, packageID)
+	return err
+}
+
+func scanShippingPackage(row interface{ Scan(...any) error }) (*entity.ShippingPackage, error) {
+	pkg := &entity.ShippingPackage{}
+	err := row.Scan(
+		&pkg.ID,
+		&pkg.Name,
+		&pkg.Dimensions.Length,
+		&pkg.Dimensions.Width,
+		&pkg.Dimensions.Height,
+		&pkg.Weight,
+		&pkg.MaxWeight,
+		&pkg.Active,
+		&pkg.CreatedAt,
+		&pkg.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
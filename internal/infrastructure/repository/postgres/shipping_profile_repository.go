@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// ShippingProfileRepository implements the shipping profile repository interface using PostgreSQL
+type ShippingProfileRepository struct {
+	db *sql.DB
+}
+
+// NewShippingProfileRepository creates a new ShippingProfileRepository
+func NewShippingProfileRepository(db *sql.DB) repository.ShippingProfileRepository {
+	return &ShippingProfileRepository{db: db}
+}
+
+// Create creates a new shipping profile
+func (r *ShippingProfileRepository) Create(profile *entity.ShippingProfile) error {
+	methodIDsJSON, err := json.Marshal(profile.ShippingMethodIDs)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO shipping_profiles (name, description, shipping_method_ids, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(
+		query,
+		profile.Name,
+		profile.Description,
+		methodIDsJSON,
+		profile.CreatedAt,
+		profile.UpdatedAt,
+	).Scan(&profile.ID)
+}
+
+// GetByID retrieves a shipping profile by ID
+func (r *ShippingProfileRepository) GetByID(profileID uint) (*entity.ShippingProfile, error) {
+	query := `
+		SELECT id, name, description, shipping_method_ids, created_at, updated_at
+		FROM shipping_profiles
+		WHERE id = $1
+	`
+
+	profile, err := scanShippingProfile(r.db.QueryRow(query, profileID))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("shipping profile not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// List retrieves all shipping profiles
+func (r *ShippingProfileRepository) List() ([]*entity.ShippingProfile, error) {
+	query := `
+		SELECT id, name, description, shipping_method_ids, created_at, updated_at
+		FROM shipping_profiles
+		ORDER BY name
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []*entity.ShippingProfile{}
+	for rows.Next() {
+		profile, err := scanShippingProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, rows.Err()
+}
+
+// Update updates a shipping profile
+func (r *ShippingProfileRepository) Update(profile *entity.ShippingProfile) error {
+	methodIDsJSON, err := json.Marshal(profile.ShippingMethodIDs)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE shipping_profiles
+		SET name = $1, description = $2, shipping_method_ids = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	_, err = r.db.Exec(
+		query,
+		profile.Name,
+		profile.Description,
+		methodIDsJSON,
+		time.Now(),
+		profile.ID,
+	)
+
+	return err
+}
+
+// Delete deletes a shipping profile, products and categories having it fall back to no profile
+func (r *ShippingProfileRepository) Delete(profileID uint) error {
+	query := `DELETE FROM shipping_profiles WHERE id = $1`
+	_, err := r.db.Exec(query, profileID)
+	return err
+}
+
+// scanShippingProfile scans a shipping profile row
+func scanShippingProfile(row interface{ Scan(...any) error }) (*entity.ShippingProfile, error) {
+	profile := &entity.ShippingProfile{}
+	var methodIDsJSON []byte
+
+	err := row.Scan(
+		&profile.ID,
+		&profile.Name,
+		&profile.Description,
+		&methodIDsJSON,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(methodIDsJSON, &profile.ShippingMethodIDs); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
@@ -27,8 +27,8 @@ func NewShippingRateRepository(db *sql.DB) repository.ShippingRateRepository {
 func (r *ShippingRateRepository) Create(rate *entity.ShippingRate) error {
 	query := `
 		INSERT INTO shipping_rates (shipping_method_id, shipping_zone_id, base_rate, min_order_value, 
-			free_shipping_threshold, active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			free_shipping_threshold, transit_days_min, transit_days_max, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id
 	`
 
@@ -45,6 +45,8 @@ func (r *ShippingRateRepository) Create(rate *entity.ShippingRate) error {
 		rate.BaseRate,
 		rate.MinOrderValue,
 		freeShippingThresholdSQL,
+		rate.TransitDaysMin,
+		rate.TransitDaysMax,
 		rate.Active,
 		rate.CreatedAt,
 		rate.UpdatedAt,
@@ -58,7 +60,7 @@ func (r *ShippingRateRepository) GetByID(rateID uint) (*entity.ShippingRate, err
 	// First, get the basic shipping rate data
 	query := `
 		SELECT id, shipping_method_id, shipping_zone_id, base_rate, min_order_value, 
-			free_shipping_threshold, active, created_at, updated_at
+			free_shipping_threshold, transit_days_min, transit_days_max, active, created_at, updated_at
 		FROM shipping_rates
 		WHERE id = $1
 	`
@@ -76,6 +78,8 @@ func (r *ShippingRateRepository) GetByID(rateID uint) (*entity.ShippingRate, err
 		&rate.BaseRate,
 		&rate.MinOrderValue,
 		&freeShippingThresholdSQL,
+		&rate.TransitDaysMin,
+		&rate.TransitDaysMax,
 		&rate.Active,
 		&rate.CreatedAt,
 		&rate.UpdatedAt,
@@ -97,21 +101,33 @@ func (r *ShippingRateRepository) GetByID(rateID uint) (*entity.ShippingRate, err
 
 	// Now try to get the shipping method data (if it exists)
 	methodQuery := `
-		SELECT name, description, estimated_delivery_days, active
+		SELECT name, description, estimated_delivery_days, carrier, carrier_service_code, pickup_point, cutoff_time, handling_days, restrictions, active
 		FROM shipping_methods
 		WHERE id = $1
 	`
 
+	var restrictionsJSON []byte
 	err = r.db.QueryRow(methodQuery, rate.ShippingMethodID).Scan(
 		&rate.ShippingMethod.Name,
 		&rate.ShippingMethod.Description,
 		&rate.ShippingMethod.EstimatedDeliveryDays,
+		&rate.ShippingMethod.Carrier,
+		&rate.ShippingMethod.CarrierServiceCode,
+		&rate.ShippingMethod.PickupPoint,
+		&rate.ShippingMethod.CutoffTime,
+		&rate.ShippingMethod.HandlingDays,
+		&restrictionsJSON,
 		&rate.ShippingMethod.Active,
 	)
 
 	if err != nil && err != sql.ErrNoRows {
 		return nil, fmt.Errorf("error fetching shipping method: %w", err)
 	}
+	if err == nil {
+		if err := json.Unmarshal(restrictionsJSON, &rate.ShippingMethod.Restrictions); err != nil {
+			return nil, err
+		}
+	}
 
 	// Set shipping method ID
 	rate.ShippingMethod.ID = rate.ShippingMethodID
@@ -183,7 +199,7 @@ func (r *ShippingRateRepository) GetByID(rateID uint) (*entity.ShippingRate, err
 func (r *ShippingRateRepository) GetByMethodID(methodID uint) ([]*entity.ShippingRate, error) {
 	query := `
 		SELECT id, shipping_method_id, shipping_zone_id, base_rate, min_order_value, 
-			free_shipping_threshold, active, created_at, updated_at
+			free_shipping_threshold, transit_days_min, transit_days_max, active, created_at, updated_at
 		FROM shipping_rates
 		WHERE shipping_method_id = $1
 		ORDER BY base_rate
@@ -206,6 +222,8 @@ func (r *ShippingRateRepository) GetByMethodID(methodID uint) ([]*entity.Shippin
 			&rate.BaseRate,
 			&rate.MinOrderValue,
 			&freeShippingThresholdSQL,
+			&rate.TransitDaysMin,
+			&rate.TransitDaysMax,
 			&rate.Active,
 			&rate.CreatedAt,
 			&rate.UpdatedAt,
@@ -230,7 +248,7 @@ func (r *ShippingRateRepository) GetByMethodID(methodID uint) ([]*entity.Shippin
 func (r *ShippingRateRepository) GetByZoneID(zoneID uint) ([]*entity.ShippingRate, error) {
 	query := `
 		SELECT id, shipping_method_id, shipping_zone_id, base_rate, min_order_value, 
-			free_shipping_threshold, active, created_at, updated_at
+			free_shipping_threshold, transit_days_min, transit_days_max, active, created_at, updated_at
 		FROM shipping_rates
 		WHERE shipping_zone_id = $1
 		ORDER BY base_rate
@@ -253,6 +271,8 @@ func (r *ShippingRateRepository) GetByZoneID(zoneID uint) ([]*entity.ShippingRat
 			&rate.BaseRate,
 			&rate.MinOrderValue,
 			&freeShippingThresholdSQL,
+			&rate.TransitDaysMin,
+			&rate.TransitDaysMax,
 			&rate.Active,
 			&rate.CreatedAt,
 			&rate.UpdatedAt,
@@ -275,19 +295,49 @@ func (r *ShippingRateRepository) GetByZoneID(zoneID uint) ([]*entity.ShippingRat
 
 // GetAvailableRatesForAddress retrieves available shipping rates for a specific address
 func (r *ShippingRateRepository) GetAvailableRatesForAddress(address entity.Address, orderValue int64) ([]*entity.ShippingRate, error) {
-	// First, find applicable shipping zones for this address
+	// First, find applicable shipping zones for this address. Countries, states and postal codes
+	// each narrow a zone, and postal code patterns are matched like entity.PostalCodePattern:
+	// an optional "DK-" country prefix, then a "*"/"?" wildcard, a same-length range or an exact code.
 	query := `
-		SELECT id 
-		FROM shipping_zones 
-		WHERE active = true AND (
-			(countries @> $1::jsonb) OR 
-			(states @> $2::jsonb) OR
-			($3 = ANY(SELECT jsonb_array_elements_text(zip_codes)))
-		)
+		SELECT z.id
+		FROM shipping_zones z
+		WHERE z.active = true
+		AND (jsonb_array_length(z.countries) = 0 OR z.countries @> $1::jsonb)
+		AND (jsonb_array_length(z.states) = 0 OR z.states @> $2::jsonb)
+		AND (jsonb_array_length(z.zip_codes) = 0 OR EXISTS (
+			SELECT 1
+			FROM (
+				SELECT
+					CASE WHEN prefixed THEN substr(p, 1, 2) ELSE '' END AS country,
+					CASE WHEN prefixed THEN substr(p, 4) ELSE p END AS pattern
+				FROM (
+					SELECT p,
+						length(p) > 3 AND substr(p, 3, 1) = '-'
+							AND translate(substr(p, 1, 2), 'ABCDEFGHIJKLMNOPQRSTUVWXYZ', '') = '' AS prefixed
+					FROM (
+						SELECT upper(replace(value, ' ', '')) AS p
+						FROM jsonb_array_elements_text(z.zip_codes)
+					) raw
+				) parsed
+			) patterns
+			WHERE (patterns.country = '' OR patterns.country = $3)
+			AND $4 <> ''
+			AND CASE
+				WHEN strpos(pattern, '*') > 0 OR strpos(pattern, '?') > 0 THEN
+					$4 LIKE replace(replace(pattern, '*', '%'), '?', '_')
+				WHEN strpos(pattern, '-') > 0
+					AND length(split_part(pattern, '-', 1)) = length(split_part(pattern, '-', 2)) THEN
+					length($4) >= length(split_part(pattern, '-', 1))
+					AND left($4, length(split_part(pattern, '-', 1))) COLLATE "C"
+						BETWEEN split_part(pattern, '-', 1) AND split_part(pattern, '-', 2)
+				ELSE $4 = pattern
+			END
+		))
 	`
 
 	// Convert the address data into the format needed for matching
-	countryArray := []string{address.Country}
+	country := strings.ToUpper(address.Country)
+	countryArray := []string{country}
 	countryJSON, err := json.Marshal(countryArray)
 	if err != nil {
 		return nil, err
@@ -299,7 +349,9 @@ func (r *ShippingRateRepository) GetAvailableRatesForAddress(address entity.Addr
 		return nil, err
 	}
 
-	rows, err := r.db.Query(query, countryJSON, stateJSON, address.PostalCode)
+	postalCode := entity.NormalizePostalCode(country, address.PostalCode)
+
+	rows, err := r.db.Query(query, countryJSON, stateJSON, country, postalCode)
 	if err != nil {
 		return nil, err
 	}
@@ -326,8 +378,9 @@ func (r *ShippingRateRepository) GetAvailableRatesForAddress(address entity.Addr
 	// Now get the shipping rates that match these zones and where the order value meets the minimum
 	ratesQuery := `
 		SELECT sr.id, sr.shipping_method_id, sr.shipping_zone_id, sr.base_rate, sr.min_order_value, 
-			sr.free_shipping_threshold, sr.active, sr.created_at, sr.updated_at,
-			sm.name, sm.description, sm.estimated_delivery_days, sm.active
+			sr.free_shipping_threshold, sr.transit_days_min, sr.transit_days_max, sr.active, sr.created_at, sr.updated_at,
+			sm.name, sm.description, sm.estimated_delivery_days, sm.carrier, sm.carrier_service_code, sm.pickup_point,
+			sm.cutoff_time, sm.handling_days, sm.restrictions, sm.active
 		FROM shipping_rates sr
 		JOIN shipping_methods sm ON sr.shipping_method_id = sm.id
 		WHERE sr.shipping_zone_id IN (` + strings.Join(params, ",") + `)
@@ -351,6 +404,7 @@ func (r *ShippingRateRepository) GetAvailableRatesForAddress(address entity.Addr
 	rates := []*entity.ShippingRate{}
 	for rateRows.Next() {
 		var freeShippingThresholdSQL sql.NullInt64
+		var restrictionsJSON []byte
 		rate := &entity.ShippingRate{
 			ShippingMethod: &entity.ShippingMethod{},
 		}
@@ -361,17 +415,28 @@ func (r *ShippingRateRepository) GetAvailableRatesForAddress(address entity.Addr
 			&rate.BaseRate,
 			&rate.MinOrderValue,
 			&freeShippingThresholdSQL,
+			&rate.TransitDaysMin,
+			&rate.TransitDaysMax,
 			&rate.Active,
 			&rate.CreatedAt,
 			&rate.UpdatedAt,
 			&rate.ShippingMethod.Name,
 			&rate.ShippingMethod.Description,
 			&rate.ShippingMethod.EstimatedDeliveryDays,
+			&rate.ShippingMethod.Carrier,
+			&rate.ShippingMethod.CarrierServiceCode,
+			&rate.ShippingMethod.PickupPoint,
+			&rate.ShippingMethod.CutoffTime,
+			&rate.ShippingMethod.HandlingDays,
+			&restrictionsJSON,
 			&rate.ShippingMethod.Active,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if err := json.Unmarshal(restrictionsJSON, &rate.ShippingMethod.Restrictions); err != nil {
+			return nil, err
+		}
 
 		// Set shipping method ID
 		rate.ShippingMethod.ID = rate.ShippingMethodID
@@ -389,6 +454,19 @@ func (r *ShippingRateRepository) GetAvailableRatesForAddress(address entity.Addr
 
 		rates = append(rates, rate)
 	}
+	if err := rateRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Load the weight and value based rates the costs depend on
+	for _, rate := range rates {
+		if rate.WeightBasedRates, err = r.GetWeightBasedRates(rate.ID); err != nil {
+			return nil, err
+		}
+		if rate.ValueBasedRates, err = r.GetValueBasedRates(rate.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	return rates, nil
 }
@@ -522,8 +600,8 @@ func (r *ShippingRateRepository) Update(rate *entity.ShippingRate) error {
 	query := `
 		UPDATE shipping_rates
 		SET shipping_method_id = $1, shipping_zone_id = $2, base_rate = $3, min_order_value = $4,
-			free_shipping_threshold = $5, active = $6, updated_at = $7
-		WHERE id = $8
+			free_shipping_threshold = $5, transit_days_min = $6, transit_days_max = $7, active = $8, updated_at = $9
+		WHERE id = $10
 	`
 
 	var freeShippingThresholdSQL sql.NullInt64
@@ -539,6 +617,8 @@ func (r *ShippingRateRepository) Update(rate *entity.ShippingRate) error {
 		rate.BaseRate,
 		rate.MinOrderValue,
 		freeShippingThresholdSQL,
+		rate.TransitDaysMin,
+		rate.TransitDaysMax,
 		rate.Active,
 		time.Now(),
 		rate.ID,
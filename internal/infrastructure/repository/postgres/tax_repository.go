@@ -0,0 +1,336 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// TaxClassRepository implements the tax class repository interface using PostgreSQL
+type TaxClassRepository struct {
+	db *sql.DB
+}
+
+// NewTaxClassRepository creates a new TaxClassRepository
+func NewTaxClassRepository(db *sql.DB) repository.TaxClassRepository {
+	return &TaxClassRepository{db: db}
+}
+
+// Create creates a new tax class
+func (r *TaxClassRepository) Create(taxClass *entity.TaxClass) error {
+	query := `
+		INSERT INTO tax_classes (name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(
+		query,
+		taxClass.Name,
+		taxClass.Description,
+		taxClass.CreatedAt,
+		taxClass.UpdatedAt,
+	).Scan(&taxClass.ID)
+}
+
+// Update updates a tax class
+func (r *TaxClassRepository) Update(taxClass *entity.TaxClass) error {
+	query := `
+		UPDATE tax_classes
+		SET name = $1, description = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	result, err := r.db.Exec(query, taxClass.Name, taxClass.Description, time.Now(), taxClass.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("tax class not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a tax class together with its rates. Its products fall back to the standard rate.
+func (r *TaxClassRepository) Delete(taxClassID uint) error {
+	_, err := r.db.Exec("DELETE FROM tax_classes WHERE id = $1", taxClassID)
+	return err
+}
+
+// GetByID retrieves a tax class by ID
+func (r *TaxClassRepository) GetByID(taxClassID uint) (*entity.TaxClass, error) {
+	query := `
+		SELECT id, name, description, created_at, updated_at
+		FROM tax_classes
+		WHERE id = $1
+	`
+
+	taxClass := &entity.TaxClass{}
+	var description sql.NullString
+	err := r.db.QueryRow(query, taxClassID).Scan(
+		&taxClass.ID,
+		&taxClass.Name,
+		&description,
+		&taxClass.CreatedAt,
+		&taxClass.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("tax class not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	taxClass.Description = description.String
+
+	return taxClass, nil
+}
+
+// List lists all tax classes
+func (r *TaxClassRepository) List() ([]*entity.TaxClass, error) {
+	query := `
+		SELECT id, name, description, created_at, updated_at
+		FROM tax_classes
+		ORDER BY name
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	taxClasses := []*entity.TaxClass{}
+	for rows.Next() {
+		taxClass := &entity.TaxClass{}
+		var description sql.NullString
+		if err := rows.Scan(
+			&taxClass.ID,
+			&taxClass.Name,
+			&description,
+			&taxClass.CreatedAt,
+			&taxClass.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		taxClass.Description = description.String
+		taxClasses = append(taxClasses, taxClass)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return taxClasses, nil
+}
+
+// TaxRateRepository implements the tax rate repository interface using PostgreSQL
+type TaxRateRepository struct {
+	db *sql.DB
+}
+
+// NewTaxRateRepository creates a new TaxRateRepository
+func NewTaxRateRepository(db *sql.DB) repository.TaxRateRepository {
+	return &TaxRateRepository{db: db}
+}
+
+// Create creates a new tax rate
+func (r *TaxRateRepository) Create(taxRate *entity.TaxRate) error {
+	query := `
+		INSERT INTO tax_rates (name, tax_class_id, country, states, postal_codes, rate, active, created_at, updated_at)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	statesJSON, err := json.Marshal(taxRate.States)
+	if err != nil {
+		return err
+	}
+
+	postalCodesJSON, err := json.Marshal(taxRate.PostalCodes)
+	if err != nil {
+		return err
+	}
+
+	return r.db.QueryRow(
+		query,
+		taxRate.Name,
+		taxRate.TaxClassID,
+		taxRate.Country,
+		statesJSON,
+		postalCodesJSON,
+		taxRate.Rate,
+		taxRate.Active,
+		taxRate.CreatedAt,
+		taxRate.UpdatedAt,
+	).Scan(&taxRate.ID)
+}
+
+// Update updates a tax rate
+func (r *TaxRateRepository) Update(taxRate *entity.TaxRate) error {
+	query := `
+		UPDATE tax_rates
+		SET name = $1, tax_class_id = NULLIF($2, 0), country = $3, states = $4, postal_codes = $5,
+			rate = $6, active = $7, updated_at = $8
+		WHERE id = $9
+	`
+
+	statesJSON, err := json.Marshal(taxRate.States)
+	if err != nil {
+		return err
+	}
+
+	postalCodesJSON, err := json.Marshal(taxRate.PostalCodes)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		query,
+		taxRate.Name,
+		taxRate.TaxClassID,
+		taxRate.Country,
+		statesJSON,
+		postalCodesJSON,
+		taxRate.Rate,
+		taxRate.Active,
+		time.Now(),
+		taxRate.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("tax rate not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a tax rate
+func (r *TaxRateRepository) Delete(taxRateID uint) error {
+	_, err := r.db.Exec("DELETE FROM tax_rates WHERE id = $1", taxRateID)
+	return err
+}
+
+// GetByID retrieves a tax rate by ID
+func (r *TaxRateRepository) GetByID(taxRateID uint) (*entity.TaxRate, error) {
+	query := `
+		SELECT id, name, COALESCE(tax_class_id, 0), country, states, postal_codes, rate, active, created_at, updated_at
+		FROM tax_rates
+		WHERE id = $1
+	`
+
+	taxRate, err := r.scanTaxRate(r.db.QueryRow(query, taxRateID))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("tax rate not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return taxRate, nil
+}
+
+// List lists all tax rates
+func (r *TaxRateRepository) List() ([]*entity.TaxRate, error) {
+	query := `
+		SELECT id, name, COALESCE(tax_class_id, 0), country, states, postal_codes, rate, active, created_at, updated_at
+		FROM tax_rates
+		ORDER BY country, tax_class_id NULLS FIRST, id
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTaxRates(rows)
+}
+
+// ListActiveByCountry lists the active tax rates of a country
+func (r *TaxRateRepository) ListActiveByCountry(country string) ([]*entity.TaxRate, error) {
+	query := `
+		SELECT id, name, COALESCE(tax_class_id, 0), country, states, postal_codes, rate, active, created_at, updated_at
+		FROM tax_rates
+		WHERE country = $1 AND active = true
+		ORDER BY id
+	`
+
+	rows, err := r.db.Query(query, strings.ToUpper(country))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTaxRates(rows)
+}
+
+// scanTaxRates scans all rows into tax rates
+func (r *TaxRateRepository) scanTaxRates(rows *sql.Rows) ([]*entity.TaxRate, error) {
+	taxRates := []*entity.TaxRate{}
+	for rows.Next() {
+		taxRate, err := r.scanTaxRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		taxRates = append(taxRates, taxRate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return taxRates, nil
+}
+
+// scanTaxRate scans a single tax rate
+func (r *TaxRateRepository) scanTaxRate(row interface{ Scan(...any) error }) (*entity.TaxRate, error) {
+	taxRate := &entity.TaxRate{}
+	var statesJSON, postalCodesJSON []byte
+
+	err := row.Scan(
+		&taxRate.ID,
+		&taxRate.Name,
+		&taxRate.TaxClassID,
+		&taxRate.Country,
+		&statesJSON,
+		&postalCodesJSON,
+		&taxRate.Rate,
+		&taxRate.Active,
+		&taxRate.CreatedAt,
+		&taxRate.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(statesJSON, &taxRate.States); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(postalCodesJSON, &taxRate.PostalCodes); err != nil {
+		return nil, err
+	}
+
+	return taxRate, nil
+}
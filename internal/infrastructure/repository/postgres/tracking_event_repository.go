@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// TrackingEventRepository implements the tracking event repository interface using PostgreSQL
+type TrackingEventRepository struct {
+	db *sql.DB
+}
+
+// NewTrackingEventRepository creates a new TrackingEventRepository
+func NewTrackingEventRepository(db *sql.DB) repository.TrackingEventRepository {
+	return &TrackingEventRepository{db: db}
+}
+
+// Create saves a tracking event. Events already recorded for the order are ignored.
+func (r *TrackingEventRepository) Create(event *entity.TrackingEvent) error {
+	err := r.db.QueryRow(`
+		INSERT INTO tracking_events (order_id, tracking_number, status, description, location, occurred_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (order_id, tracking_number, status, occurred_at) DO NOTHING
+		RETURNING id
+	`,
+		event.OrderID,
+		event.TrackingNumber,
+		event.Status,
+		event.Description,
+		event.Location,
+		event.OccurredAt,
+		event.CreatedAt,
+	).Scan(&event.ID)
+
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	return err
+}
+
+// ListByOrderID lists the tracking events of an order, oldest first
+func (r *TrackingEventRepository) ListByOrderID(orderID uint) ([]*entity.TrackingEvent, error) {
+	rows, err := r.db.Query(`
+		SELECT id, order_id, tracking_number, status, description, location, occurred_at, created_at
+		FROM tracking_events
+		WHERE order_id = $1
+		ORDER BY occurred_at, id
+	`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*entity.TrackingEvent{}
+	for rows.Next() {
+		event := &entity.TrackingEvent{}
+		err := rows.Scan(
+			&event.ID,
+			&event.OrderID,
+			&event.TrackingNumber,
+			&event.Status,
+			&event.Description,
+			&event.Location,
+			&event.OccurredAt,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
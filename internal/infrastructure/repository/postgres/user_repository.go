@@ -22,8 +22,8 @@ func NewUserRepository(db *sql.DB) repository.UserRepository {
 // Create creates a new user
 func (r *UserRepository) Create(user *entity.User) error {
 	query := `
-		INSERT INTO users (email, password, first_name, last_name, role, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO users (email, password, first_name, last_name, role, customer_group_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
 
@@ -34,6 +34,7 @@ func (r *UserRepository) Create(user *entity.User) error {
 		user.FirstName,
 		user.LastName,
 		user.Role,
+		user.CustomerGroupID,
 		user.CreatedAt,
 		user.UpdatedAt,
 	).Scan(&user.ID)
@@ -44,7 +45,7 @@ func (r *UserRepository) Create(user *entity.User) error {
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(id uint) (*entity.User, error) {
 	query := `
-		SELECT id, email, password, first_name, last_name, role, created_at, updated_at
+		SELECT id, email, password, first_name, last_name, role, customer_group_id, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -57,6 +58,7 @@ func (r *UserRepository) GetByID(id uint) (*entity.User, error) {
 		&user.FirstName,
 		&user.LastName,
 		&user.Role,
+		&user.CustomerGroupID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -71,7 +73,7 @@ func (r *UserRepository) GetByID(id uint) (*entity.User, error) {
 // GetByEmail retrieves a user by email
 func (r *UserRepository) GetByEmail(email string) (*entity.User, error) {
 	query := `
-		SELECT id, email, password, first_name, last_name, role, created_at, updated_at
+		SELECT id, email, password, first_name, last_name, role, customer_group_id, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -84,6 +86,7 @@ func (r *UserRepository) GetByEmail(email string) (*entity.User, error) {
 		&user.FirstName,
 		&user.LastName,
 		&user.Role,
+		&user.CustomerGroupID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -99,8 +102,8 @@ func (r *UserRepository) GetByEmail(email string) (*entity.User, error) {
 func (r *UserRepository) Update(user *entity.User) error {
 	query := `
 		UPDATE users
-		SET email = $1, password = $2, first_name = $3, last_name = $4, role = $5, updated_at = $6
-		WHERE id = $7
+		SET email = $1, password = $2, first_name = $3, last_name = $4, role = $5, customer_group_id = $6, updated_at = $7
+		WHERE id = $8
 	`
 
 	_, err := r.db.Exec(
@@ -110,6 +113,7 @@ func (r *UserRepository) Update(user *entity.User) error {
 		user.FirstName,
 		user.LastName,
 		user.Role,
+		user.CustomerGroupID,
 		time.Now(),
 		user.ID,
 	)
@@ -127,7 +131,7 @@ func (r *UserRepository) Delete(id uint) error {
 // List retrieves a list of users with pagination
 func (r *UserRepository) List(offset, limit int) ([]*entity.User, error) {
 	query := `
-		SELECT id, email, password, first_name, last_name, role, created_at, updated_at
+		SELECT id, email, password, first_name, last_name, role, customer_group_id, created_at, updated_at
 		FROM users
 		ORDER BY id
 		LIMIT $1 OFFSET $2
@@ -149,6 +153,7 @@ func (r *UserRepository) List(offset, limit int) ([]*entity.User, error) {
 			&user.FirstName,
 			&user.LastName,
 			&user.Role,
+			&user.CustomerGroupID,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
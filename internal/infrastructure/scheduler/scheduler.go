@@ -0,0 +1,93 @@
+// Package scheduler runs background jobs at fixed intervals
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+)
+
+// Job is a unit of background work
+type Job func() error
+
+// scheduledJob is a job together with its schedule
+type scheduledJob struct {
+	name     string
+	interval time.Duration
+	run      Job
+}
+
+// Scheduler runs registered jobs at fixed intervals until it is stopped
+type Scheduler struct {
+	logger logger.Logger
+	jobs   []scheduledJob
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler(logger logger.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Add registers a job that runs once at start and then every interval.
+// Jobs must be added before the scheduler is started.
+func (s *Scheduler) Add(name string, interval time.Duration, job Job) {
+	s.jobs = append(s.jobs, scheduledJob{
+		name:     name,
+		interval: interval,
+		run:      job,
+	})
+}
+
+// Start runs every registered job in its own goroutine
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+}
+
+// Stop stops the scheduler and waits for running jobs to finish
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// loop runs a job until the scheduler is stopped
+func (s *Scheduler) loop(job scheduledJob) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runJob(job)
+
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// runJob runs a job once, logging failures and recovering from panics
+func (s *Scheduler) runJob(job scheduledJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Scheduled job %s panicked: %v", job.name, r)
+		}
+	}()
+
+	start := time.Now()
+	if err := job.run(); err != nil {
+		s.logger.Error("Scheduled job %s failed: %v", job.name, err)
+		return
+	}
+	s.logger.Debug("Scheduled job %s finished in %s", job.name, time.Since(start))
+}
@@ -0,0 +1,68 @@
+// Package tax provides tax calculators
+package tax
+
+import (
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// RateTableCalculator calculates taxes from the tax rates configured in the store
+type RateTableCalculator struct {
+	taxRateRepo repository.TaxRateRepository
+}
+
+// NewRateTableCalculator creates a new RateTableCalculator
+func NewRateTableCalculator(taxRateRepo repository.TaxRateRepository) *RateTableCalculator {
+	return &RateTableCalculator{taxRateRepo: taxRateRepo}
+}
+
+// Calculate calculates the tax of each line at the most specific rate of its tax class
+// for the address. Products of a class without rates for the address fall back to the
+// standard rate, and shipping is taxed at the standard rate.
+func (c *RateTableCalculator) Calculate(request service.TaxRequest) (*service.TaxResult, error) {
+	rates, err := c.taxRateRepo.ListActiveByCountry(request.Address.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &service.TaxResult{
+		Lines: make([]entity.TaxLine, len(request.Lines)),
+	}
+
+	for i, line := range request.Lines {
+		rate := rateFor(rates, line.TaxClassID, request.Address)
+		result.Lines[i] = entity.TaxLine{
+			Rate:   rate,
+			Amount: entity.CalculateTax(line.Amount, rate, request.PricesIncludeTax),
+		}
+	}
+
+	shippingRate := rateFor(rates, entity.StandardTaxClassID, request.Address)
+	result.Shipping = entity.TaxLine{
+		Rate:   shippingRate,
+		Amount: entity.CalculateTax(request.ShippingAmount, shippingRate, request.PricesIncludeTax),
+	}
+
+	return result, nil
+}
+
+// rateFor returns the percentage of the most specific rate of a tax class that applies to the address
+func rateFor(rates []*entity.TaxRate, taxClassID uint, address entity.Address) float64 {
+	var best *entity.TaxRate
+	for _, classID := range []uint{taxClassID, entity.StandardTaxClassID} {
+		for _, rate := range rates {
+			if rate.TaxClassID != classID || !rate.AppliesTo(address) {
+				continue
+			}
+			if best == nil || rate.Specificity() > best.Specificity() {
+				best = rate
+			}
+		}
+		if best != nil {
+			return best.Rate
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,53 @@
+package vat
+
+import (
+	"errors"
+	"sync"
+)
+
+// FakeVIESClient is an in-memory VIES client for development and testing.
+// Numbers that have not been registered are reported as valid.
+type FakeVIESClient struct {
+	mu          sync.RWMutex
+	results     map[string]VIESResult
+	unavailable bool
+}
+
+// NewFakeVIESClient creates a new FakeVIESClient
+func NewFakeVIESClient() *FakeVIESClient {
+	return &FakeVIESClient{
+		results: make(map[string]VIESResult),
+	}
+}
+
+// Register sets the result returned for a VAT number including its prefix
+func (c *FakeVIESClient) Register(vatID string, result VIESResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.results[vatID] = result
+}
+
+// SetUnavailable simulates a VIES outage
+func (c *FakeVIESClient) SetUnavailable(unavailable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.unavailable = unavailable
+}
+
+// CheckVAT returns the registered result of a VAT number
+func (c *FakeVIESClient) CheckVAT(countryCode, number string) (*VIESResult, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.unavailable {
+		return nil, errors.New("VIES is unavailable: SERVICE_UNAVAILABLE")
+	}
+
+	result, ok := c.results[countryCode+number]
+	if !ok {
+		return &VIESResult{Valid: true}, nil
+	}
+	return &result, nil
+}
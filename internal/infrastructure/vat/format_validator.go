@@ -0,0 +1,67 @@
+// Package vat provides EU VAT number validators
+package vat
+
+import (
+	"regexp"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// vatFormats are the formats of the national part of each EU member state's VAT numbers,
+// keyed by VAT prefix
+var vatFormats = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^\d[A-Z0-9+*]\d{5}[A-Z]{1,2}$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^\d{2,10}$`),
+	"SE": regexp.MustCompile(`^\d{10}01$`),
+	"SI": regexp.MustCompile(`^\d{8}$`),
+	"SK": regexp.MustCompile(`^\d{10}$`),
+}
+
+// FormatValidator checks that VAT numbers are well-formed for their member state.
+// It does not check that the number is registered.
+type FormatValidator struct{}
+
+// NewFormatValidator creates a new FormatValidator
+func NewFormatValidator() *FormatValidator {
+	return &FormatValidator{}
+}
+
+// Validate checks the format of a VAT number
+func (v *FormatValidator) Validate(vatID string) (*service.VATValidation, error) {
+	vatID = entity.NormalizeVATID(vatID)
+	validation := &service.VATValidation{
+		VATID:       vatID,
+		CountryCode: entity.VATIDCountry(vatID),
+	}
+
+	if len(vatID) > 2 {
+		if format, ok := vatFormats[vatID[:2]]; ok {
+			validation.Valid = format.MatchString(vatID[2:])
+		}
+	}
+
+	return validation, nil
+}
@@ -0,0 +1,118 @@
+package vat_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/infrastructure/vat"
+)
+
+func TestFormatValidator_Validate(t *testing.T) {
+	validator := vat.NewFormatValidator()
+
+	valid := map[string]string{
+		"DE123456789":    "DE",
+		"dk 12 34 56 78": "DK",
+		"EL123456789":    "GR",
+		"NL123456789B01": "NL",
+		"ATU12345678":    "AT",
+		"FR1A123456789":  "FR",
+	}
+	for vatID, country := range valid {
+		validation, err := validator.Validate(vatID)
+
+		assert.NoError(t, err, vatID)
+		assert.True(t, validation.Valid, vatID)
+		assert.Equal(t, country, validation.CountryCode, vatID)
+	}
+
+	invalid := []string{"", "DE", "DE12345678", "GR123456789", "US123456789", "NL123456789", "AT12345678"}
+	for _, vatID := range invalid {
+		validation, err := validator.Validate(vatID)
+
+		assert.NoError(t, err, vatID)
+		assert.False(t, validation.Valid, vatID)
+	}
+}
+
+func TestVIESValidator_Validate(t *testing.T) {
+	t.Run("Registered number", func(t *testing.T) {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"isValid":true,"userError":"VALID","name":"Example GmbH","address":"Musterstr. 1\n10115 Berlin"}`))
+		}))
+		defer server.Close()
+
+		validator := vat.NewVIESValidator(vat.NewHTTPVIESClient(server.URL))
+
+		validation, err := validator.Validate("DE 123 456 789")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/ms/DE/vat/123456789", path)
+		assert.True(t, validation.Valid)
+		assert.Equal(t, "DE123456789", validation.VATID)
+		assert.Equal(t, "Example GmbH", validation.Name)
+	})
+
+	t.Run("Unregistered number", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"isValid":false,"userError":"INVALID","name":"---","address":"---"}`))
+		}))
+		defer server.Close()
+
+		validator := vat.NewVIESValidator(vat.NewHTTPVIESClient(server.URL))
+
+		validation, err := validator.Validate("DE123456789")
+
+		assert.NoError(t, err)
+		assert.False(t, validation.Valid)
+		assert.Empty(t, validation.Name)
+	})
+
+	t.Run("Malformed numbers are not sent to VIES", func(t *testing.T) {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer server.Close()
+
+		validator := vat.NewVIESValidator(vat.NewHTTPVIESClient(server.URL))
+
+		validation, err := validator.Validate("DE1234")
+
+		assert.NoError(t, err)
+		assert.False(t, validation.Valid)
+		assert.False(t, called)
+	})
+
+	t.Run("Member state unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"isValid":false,"userError":"MS_UNAVAILABLE"}`))
+		}))
+		defer server.Close()
+
+		validator := vat.NewVIESValidator(vat.NewHTTPVIESClient(server.URL))
+
+		validation, err := validator.Validate("DE123456789")
+
+		assert.Error(t, err)
+		assert.Nil(t, validation)
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		validator := vat.NewVIESValidator(vat.NewHTTPVIESClient(server.URL))
+
+		_, err := validator.Validate("DE123456789")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,127 @@
+package vat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// VIESRestURL is the base URL of the European Commission's VIES REST API
+const VIESRestURL = "https://ec.europa.eu/taxation_customs/vies/rest-api"
+
+// VIESResult is the registration of a VAT number in VIES
+type VIESResult struct {
+	Valid   bool
+	Name    string
+	Address string
+}
+
+// VIESClient defines the interface for checking VAT numbers against VIES
+type VIESClient interface {
+	// CheckVAT checks the national part of a VAT number with its VAT prefix, e.g. "EL" for Greece
+	CheckVAT(countryCode, number string) (*VIESResult, error)
+}
+
+// VIESValidator checks the format of VAT numbers and then their registration in VIES
+type VIESValidator struct {
+	format *FormatValidator
+	client VIESClient
+}
+
+// NewVIESValidator creates a new VIESValidator
+func NewVIESValidator(client VIESClient) *VIESValidator {
+	return &VIESValidator{
+		format: NewFormatValidator(),
+		client: client,
+	}
+}
+
+// Validate checks that a VAT number is well-formed and registered
+func (v *VIESValidator) Validate(vatID string) (*service.VATValidation, error) {
+	validation, err := v.format.Validate(vatID)
+	if err != nil || !validation.Valid {
+		return validation, err
+	}
+
+	result, err := v.client.CheckVAT(validation.VATID[:2], validation.VATID[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	validation.Valid = result.Valid
+	validation.Name = result.Name
+	validation.Address = result.Address
+	return validation, nil
+}
+
+// HTTPVIESClient checks VAT numbers with the VIES REST API
+type HTTPVIESClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPVIESClient creates a new HTTPVIESClient.
+// An empty base URL uses the European Commission's VIES REST API.
+func NewHTTPVIESClient(baseURL string) *HTTPVIESClient {
+	if baseURL == "" {
+		baseURL = VIESRestURL
+	}
+
+	return &HTTPVIESClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// viesResponse mirrors the VIES REST API's check response
+type viesResponse struct {
+	IsValid   bool   `json:"isValid"`
+	UserError string `json:"userError"`
+	Name      string `json:"name"`
+	Address   string `json:"address"`
+}
+
+// CheckVAT checks a VAT number with the VIES REST API
+func (c *HTTPVIESClient) CheckVAT(countryCode, number string) (*VIESResult, error) {
+	endpoint := fmt.Sprintf("%s/ms/%s/vat/%s", c.baseURL, url.PathEscape(countryCode), url.PathEscape(number))
+
+	resp, err := c.client.Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check VAT number: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check VAT number: unexpected status %d", resp.StatusCode)
+	}
+
+	var body viesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse VIES response: %w", err)
+	}
+
+	// Member state outages and rate limits are reported as user errors
+	if body.UserError != "" && body.UserError != "VALID" && body.UserError != "INVALID" {
+		return nil, fmt.Errorf("VIES is unavailable: %s", body.UserError)
+	}
+
+	return &VIESResult{
+		Valid:   body.IsValid,
+		Name:    viesValue(body.Name),
+		Address: viesValue(body.Address),
+	}, nil
+}
+
+// viesValue returns a VIES field, which is "---" when the member state does not share it
+func viesValue(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "---" {
+		return ""
+	}
+	return value
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 	"github.com/zenfulcode/commercify/internal/domain/common"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
 	"github.com/zenfulcode/commercify/internal/dto"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
 )
 
 // CartHandler handles cart-related HTTP requests
@@ -75,7 +77,7 @@ func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert entity to DTO
-	cartDTO := convertToCartDTO(cart)
+	cartDTO := h.buildCartDTO(cart)
 
 	// Return cart
 	w.Header().Set("Content-Type", "application/json")
@@ -129,7 +131,7 @@ func (h *CartHandler) AddToCart(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert entity to DTO
-	cartDTO := convertToCartDTO(cart)
+	cartDTO := h.buildCartDTO(cart)
 
 	// Return updated cart
 	w.Header().Set("Content-Type", "application/json")
@@ -186,7 +188,7 @@ func (h *CartHandler) UpdateCartItem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert entity to DTO
-	cartDTO := convertToCartDTO(cart)
+	cartDTO := h.buildCartDTO(cart)
 
 	// Return updated cart
 	w.Header().Set("Content-Type", "application/json")
@@ -227,7 +229,7 @@ func (h *CartHandler) RemoveFromCart(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert entity to DTO
-	cartDTO := convertToCartDTO(cart)
+	cartDTO := h.buildCartDTO(cart)
 
 	// Return updated cart
 	w.Header().Set("Content-Type", "application/json")
@@ -266,7 +268,7 @@ func (h *CartHandler) ClearCart(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert entity to DTO
-	cartDTO := convertToCartDTO(cart)
+	cartDTO := h.buildCartDTO(cart)
 
 	// Return empty cart
 	w.Header().Set("Content-Type", "application/json")
@@ -294,7 +296,7 @@ func (h *CartHandler) ConvertGuestCartToUserCart(w http.ResponseWriter, r *http.
 		}
 
 		// Convert entity to DTO
-		cartDTO := convertToCartDTO(cart)
+		cartDTO := h.buildCartDTO(cart)
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(cartDTO)
@@ -321,13 +323,113 @@ func (h *CartHandler) ConvertGuestCartToUserCart(w http.ResponseWriter, r *http.
 	})
 
 	// Convert entity to DTO
-	cartDTO := convertToCartDTO(cart)
+	cartDTO := h.buildCartDTO(cart)
 
 	// Return updated cart
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(cartDTO)
 }
 
+// ApplyDiscount handles attaching a discount code to the cart
+func (h *CartHandler) ApplyDiscount(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var input dto.ApplyCartDiscountRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Validate input
+	if input.DiscountCode == "" {
+		http.Error(w, "Discount code is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get user ID from context (if authenticated)
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+
+	var cart *entity.Cart
+	var err error
+
+	if ok && userID > 0 {
+		// User is authenticated, apply to user cart
+		cart, err = h.cartUseCase.ApplyDiscountToCart(userID, input.DiscountCode)
+	} else {
+		// User is a guest, apply to guest cart
+		sessionID := h.getSessionID(w, r)
+		cart, err = h.cartUseCase.ApplyDiscountToGuestCart(sessionID, input.DiscountCode)
+	}
+
+	if err != nil {
+		h.logger.Error("Failed to apply discount to cart: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Convert entity to DTO
+	cartDTO := h.buildCartDTO(cart)
+
+	// Return updated cart
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cartDTO)
+}
+
+// RemoveDiscount handles removing the discount code from the cart
+func (h *CartHandler) RemoveDiscount(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (if authenticated)
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+
+	var cart *entity.Cart
+	var err error
+
+	if ok && userID > 0 {
+		// User is authenticated, remove from user cart
+		cart, err = h.cartUseCase.RemoveDiscountFromCart(userID)
+	} else {
+		// User is a guest, remove from guest cart
+		sessionID := h.getSessionID(w, r)
+		cart, err = h.cartUseCase.RemoveDiscountFromGuestCart(sessionID)
+	}
+
+	if err != nil {
+		h.logger.Error("Failed to remove discount from cart: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Convert entity to DTO
+	cartDTO := h.buildCartDTO(cart)
+
+	// Return updated cart
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cartDTO)
+}
+
+// buildCartDTO converts a cart to a DTO including its prices and discount
+func (h *CartHandler) buildCartDTO(cart *entity.Cart) dto.CartDTO {
+	cartDTO := convertToCartDTO(cart)
+
+	summary, err := h.cartUseCase.GetCartSummary(cart)
+	if err != nil {
+		h.logger.Warn("Failed to calculate cart summary: %v", err)
+		return cartDTO
+	}
+
+	for i, line := range summary.Lines {
+		cartDTO.Items[i].Price = money.New(line.Price, summary.Currency).Decimal()
+		cartDTO.Items[i].Subtotal = money.New(line.Subtotal, summary.Currency).Decimal()
+		cartDTO.Items[i].DiscountAmount = money.New(line.DiscountAmount, summary.Currency).Decimal()
+	}
+
+	cartDTO.Currency = summary.Currency
+	cartDTO.Subtotal = money.New(summary.Subtotal, summary.Currency).Decimal()
+	cartDTO.DiscountCode = summary.DiscountCode
+	cartDTO.DiscountAmount = money.New(summary.DiscountAmount, summary.Currency).Decimal()
+	cartDTO.Total = money.New(summary.Total, summary.Currency).Decimal()
+
+	return cartDTO
+}
+
 // convertToCartDTO converts a cart entity to a DTO
 func convertToCartDTO(cart *entity.Cart) dto.CartDTO {
 	// Convert cart items to DTOs
@@ -347,10 +449,11 @@ func convertToCartDTO(cart *entity.Cart) dto.CartDTO {
 
 	// Create cart DTO
 	cartDTO := dto.CartDTO{
-		ID:        cart.ID,
-		Items:     items,
-		CreatedAt: cart.CreatedAt,
-		UpdatedAt: cart.UpdatedAt,
+		ID:           cart.ID,
+		Items:        items,
+		DiscountCode: cart.DiscountCode,
+		CreatedAt:    cart.CreatedAt,
+		UpdatedAt:    cart.UpdatedAt,
 	}
 
 	// Set user ID if it exists
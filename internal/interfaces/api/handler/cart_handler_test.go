@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/handler"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func TestCartHandler_Discount(t *testing.T) {
+	// Setup mocks
+	cartRepo := mock.NewMockCartRepository()
+	productRepo := mock.NewMockProductRepository()
+	discountRepo := mock.NewMockDiscountRepository()
+	discountUseCase := usecase.NewDiscountUseCase(
+		discountRepo,
+		productRepo,
+		mock.NewMockCategoryRepository(),
+		mock.NewMockOrderRepository(false),
+		nil,
+	)
+	discount, _ := entity.NewDiscount(
+		"BASKET10",
+		entity.DiscountTypeBasket,
+		entity.DiscountMethodPercentage,
+		10.0,
+		0,
+		0,
+		[]uint{},
+		[]uint{},
+		time.Now().Add(-24*time.Hour),
+		time.Now().Add(30*24*time.Hour),
+		0,
+	)
+	discountRepo.Create(discount)
+
+	cartHandler := handler.NewCartHandler(usecase.NewCartUseCase(cartRepo, productRepo, discountUseCase, nil), logger.NewLogger())
+	router, protected, jwtService := newProtectedRouter()
+	protected.HandleFunc("/cart/discount", cartHandler.ApplyDiscount).Methods(http.MethodPost)
+	protected.HandleFunc("/cart/discount", cartHandler.RemoveDiscount).Methods(http.MethodDelete)
+
+	user := &entity.User{ID: 1, Email: "jane@example.com", Role: "user"}
+
+	t.Run("Apply to the user's cart", func(t *testing.T) {
+		// Execute
+		token, _ := jwtService.GenerateToken(user)
+		req := httptest.NewRequest(http.MethodPost, "/api/cart/discount", strings.NewReader(`{"discount_code": "BASKET10"}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		cart, err := cartRepo.GetByUserID(user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "BASKET10", cart.DiscountCode)
+	})
+
+	t.Run("Remove from the user's cart", func(t *testing.T) {
+		// Execute
+		rec := serveAs(router, jwtService, user, http.MethodDelete, "/api/cart/discount")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		cart, _ := cartRepo.GetByUserID(user.ID)
+		assert.Empty(t, cart.DiscountCode)
+	})
+}
@@ -3,8 +3,10 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 	"github.com/zenfulcode/commercify/internal/domain/money"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
@@ -12,15 +14,17 @@ import (
 
 // CurrencyHandler handles currency-related HTTP requests
 type CurrencyHandler struct {
-	currencyUseCase *usecase.CurrencyUseCase
-	logger          logger.Logger
+	currencyUseCase     *usecase.CurrencyUseCase
+	exchangeRateUseCase *usecase.ExchangeRateUseCase
+	logger              logger.Logger
 }
 
 // NewCurrencyHandler creates a new CurrencyHandler
-func NewCurrencyHandler(currencyUseCase *usecase.CurrencyUseCase, logger logger.Logger) *CurrencyHandler {
+func NewCurrencyHandler(currencyUseCase *usecase.CurrencyUseCase, exchangeRateUseCase *usecase.ExchangeRateUseCase, logger logger.Logger) *CurrencyHandler {
 	return &CurrencyHandler{
-		currencyUseCase: currencyUseCase,
-		logger:          logger,
+		currencyUseCase:     currencyUseCase,
+		exchangeRateUseCase: exchangeRateUseCase,
+		logger:              logger,
 	}
 }
 
@@ -218,6 +222,7 @@ func (h *CurrencyHandler) ConvertAmount(w http.ResponseWriter, r *http.Request)
 		Amount       float64 `json:"amount"`
 		FromCurrency string  `json:"from_currency"`
 		ToCurrency   string  `json:"to_currency"`
+		Locale       string  `json:"locale"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
@@ -241,29 +246,134 @@ func (h *CurrencyHandler) ConvertAmount(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Convert amount
-	fromCents := money.ToCents(requestBody.Amount)
-	toCents, err := h.currencyUseCase.ConvertPrice(fromCents, requestBody.FromCurrency, requestBody.ToCurrency)
+	// Convert amount in the minor units of each currency
+	from := money.FromDecimal(requestBody.Amount, requestBody.FromCurrency)
+	toAmount, err := h.currencyUseCase.ConvertPrice(from.Amount, from.Currency, strings.ToUpper(requestBody.ToCurrency))
 	if err != nil {
 		h.logger.Error("Failed to convert amount: %v", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	to := money.New(toAmount, requestBody.ToCurrency)
+
+	// Format for the requested locale, falling back to the Accept-Language header
+	locale := money.LocaleFromAcceptLanguage(r.Header.Get("Accept-Language"))
+	if requestBody.Locale != "" {
+		locale = money.ResolveLocale(requestBody.Locale)
+	}
 
 	// Return converted amount
 	response := map[string]interface{}{
 		"from": map[string]interface{}{
-			"currency": requestBody.FromCurrency,
-			"amount":   requestBody.Amount,
-			"cents":    fromCents,
+			"currency":  from.Currency,
+			"amount":    from.Decimal(),
+			"cents":     from.Amount,
+			"exponent":  from.Exponent,
+			"formatted": from.Format(locale),
 		},
 		"to": map[string]interface{}{
-			"currency": requestBody.ToCurrency,
-			"amount":   money.FromCents(toCents),
-			"cents":    toCents,
+			"currency":  to.Currency,
+			"amount":    to.Decimal(),
+			"cents":     to.Amount,
+			"exponent":  to.Exponent,
+			"formatted": to.Format(locale),
 		},
+		"locale": locale,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// SyncExchangeRates handles synchronising exchange rates from the rate provider (admin only)
+func (h *CurrencyHandler) SyncExchangeRates(w http.ResponseWriter, r *http.Request) {
+	result, err := h.exchangeRateUseCase.SyncRates()
+	if err != nil {
+		h.logger.Error("Failed to sync exchange rates: %v", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetExchangeRateHistory handles listing the exchange rate history of a currency (admin only)
+func (h *CurrencyHandler) GetExchangeRateHistory(w http.ResponseWriter, r *http.Request) {
+	// Get currency code from query parameter
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		http.Error(w, "Currency code is required", http.StatusBadRequest)
+		return
+	}
+
+	// Parse pagination parameters
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 30 // Default limit
+	}
+
+	history, err := h.exchangeRateUseCase.GetRateHistory(code, offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to get exchange rate history: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// ListPendingExchangeRates handles listing the exchange rates awaiting confirmation (admin only)
+func (h *CurrencyHandler) ListPendingExchangeRates(w http.ResponseWriter, r *http.Request) {
+	pending, err := h.exchangeRateUseCase.ListPendingRates()
+	if err != nil {
+		h.logger.Error("Failed to list pending exchange rates: %v", err)
+		http.Error(w, "Failed to list pending exchange rates", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pending)
+}
+
+// ConfirmExchangeRate handles applying an exchange rate awaiting confirmation (admin only)
+func (h *CurrencyHandler) ConfirmExchangeRate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["rateId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid rate ID", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.exchangeRateUseCase.ConfirmRate(uint(id))
+	if err != nil {
+		h.logger.Error("Failed to confirm exchange rate: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// RejectExchangeRate handles discarding an exchange rate awaiting confirmation (admin only)
+func (h *CurrencyHandler) RejectExchangeRate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["rateId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid rate ID", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.exchangeRateUseCase.RejectRate(uint(id))
+	if err != nil {
+		h.logger.Error("Failed to reject exchange rate: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
@@ -2,29 +2,35 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 	"github.com/zenfulcode/commercify/internal/domain/common"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/dto"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
 )
 
 // DiscountHandler handles discount-related HTTP requests
 type DiscountHandler struct {
-	discountUseCase *usecase.DiscountUseCase
-	orderUseCase    *usecase.OrderUseCase
-	logger          logger.Logger
+	discountUseCase       *usecase.DiscountUseCase
+	discountReportUseCase *usecase.DiscountReportUseCase
+	orderUseCase          *usecase.OrderUseCase
+	logger                logger.Logger
 }
 
 // NewDiscountHandler creates a new DiscountHandler
-func NewDiscountHandler(discountUseCase *usecase.DiscountUseCase, orderUseCase *usecase.OrderUseCase, logger logger.Logger) *DiscountHandler {
+func NewDiscountHandler(discountUseCase *usecase.DiscountUseCase, discountReportUseCase *usecase.DiscountReportUseCase, orderUseCase *usecase.OrderUseCase, logger logger.Logger) *DiscountHandler {
 	return &DiscountHandler{
-		discountUseCase: discountUseCase,
-		orderUseCase:    orderUseCase,
-		logger:          logger,
+		discountUseCase:       discountUseCase,
+		discountReportUseCase: discountReportUseCase,
+		orderUseCase:          orderUseCase,
+		logger:                logger,
 	}
 }
 
@@ -370,3 +376,128 @@ func (h *DiscountHandler) ValidateDiscountCode(w http.ResponseWriter, r *http.Re
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// GetDiscountReport handles reporting the performance of a discount (admin only)
+func (h *DiscountHandler) GetDiscountReport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["discountId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid discount ID", http.StatusBadRequest)
+		return
+	}
+
+	input, err := parseDiscountReportInput(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.discountReportUseCase.GetDiscountReport(uint(id), input)
+	if err != nil {
+		h.logger.Error("Failed to get discount report: %v", err)
+		if err.Error() == "discount not found" {
+			http.Error(w, "Discount not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertToDiscountReportDTO(report))
+}
+
+// GetCampaignReport handles reporting the combined performance of a campaign's discounts (admin only)
+func (h *DiscountHandler) GetCampaignReport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	campaign := vars["campaign"]
+
+	input, err := parseDiscountReportInput(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.discountReportUseCase.GetCampaignReport(campaign, input)
+	if err != nil {
+		h.logger.Error("Failed to get campaign report: %v", err)
+		if err.Error() == "campaign not found" {
+			http.Error(w, "Campaign not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertToDiscountReportDTO(report))
+}
+
+// parseDiscountReportInput reads the date range and interval of a report from the query string.
+// Dates are formatted as YYYY-MM-DD, both inclusive, and default to the last 30 days.
+func parseDiscountReportInput(r *http.Request) (usecase.DiscountReportInput, error) {
+	query := r.URL.Query()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	input := usecase.DiscountReportInput{
+		StartDate: today.AddDate(0, 0, -29),
+		EndDate:   today.AddDate(0, 0, 1),
+		Interval:  entity.DiscountReportInterval(query.Get("interval")),
+	}
+
+	if startDate := query.Get("start_date"); startDate != "" {
+		parsed, err := time.Parse("2006-01-02", startDate)
+		if err != nil {
+			return input, errors.New("invalid start_date: expected YYYY-MM-DD")
+		}
+		input.StartDate = parsed
+	}
+
+	if endDate := query.Get("end_date"); endDate != "" {
+		parsed, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			return input, errors.New("invalid end_date: expected YYYY-MM-DD")
+		}
+		input.EndDate = parsed.AddDate(0, 0, 1)
+	}
+
+	return input, nil
+}
+
+// convertToDiscountReportDTO converts a discount report to a DTO
+func convertToDiscountReportDTO(report *usecase.DiscountReport) dto.DiscountReportDTO {
+	discountIDs := make([]uint, len(report.Discounts))
+	codes := make([]string, len(report.Discounts))
+	for i, discount := range report.Discounts {
+		discountIDs[i] = discount.ID
+		codes[i] = discount.Code
+	}
+
+	periods := make([]dto.DiscountRedemptionPeriodDTO, len(report.RedemptionsOverTime))
+	for i, period := range report.RedemptionsOverTime {
+		periods[i] = dto.DiscountRedemptionPeriodDTO{
+			PeriodStart:    period.PeriodStart,
+			Redemptions:    period.Redemptions,
+			GrossRevenue:   money.New(period.GrossRevenue, "").Decimal(),
+			DiscountAmount: money.New(period.DiscountAmount, "").Decimal(),
+		}
+	}
+
+	return dto.DiscountReportDTO{
+		DiscountIDs:              discountIDs,
+		Codes:                    codes,
+		Campaign:                 report.Campaign,
+		StartDate:                report.StartDate,
+		EndDate:                  report.EndDate,
+		Interval:                 string(report.Interval),
+		Redemptions:              report.Redemptions,
+		GrossRevenue:             money.New(report.GrossRevenue, "").Decimal(),
+		TotalDiscount:            money.New(report.TotalDiscount, "").Decimal(),
+		AverageOrderValueWith:    money.New(report.AverageOrderValueWith, "").Decimal(),
+		AverageOrderValueWithout: money.New(report.AverageOrderValueWithout, "").Decimal(),
+		CancellationRate:         report.CancellationRate,
+		RefundRate:               report.RefundRate,
+		RefundedAmount:           money.New(report.RefundedAmount, "").Decimal(),
+		RedemptionsOverTime:      periods,
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/dto"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
+)
+
+// InvoiceHandler handles invoice and credit note requests
+type InvoiceHandler struct {
+	invoiceUseCase *usecase.InvoiceUseCase
+	orderUseCase   *usecase.OrderUseCase
+	logger         logger.Logger
+}
+
+// NewInvoiceHandler creates a new InvoiceHandler
+func NewInvoiceHandler(invoiceUseCase *usecase.InvoiceUseCase, orderUseCase *usecase.OrderUseCase, logger logger.Logger) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceUseCase: invoiceUseCase,
+		orderUseCase:   orderUseCase,
+		logger:         logger,
+	}
+}
+
+// ListOrderInvoices handles listing the invoice and credit notes of an order
+func (h *InvoiceHandler) ListOrderInvoices(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orderID, err := strconv.ParseUint(vars["orderId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return
+	}
+
+	if status, ok := h.authorizeOrder(r, uint(orderID)); !ok {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	invoices, err := h.invoiceUseCase.ListOrderInvoices(uint(orderID))
+	if err != nil {
+		h.logger.Error("Failed to list invoices: %v", err)
+		http.Error(w, "Failed to list invoices", http.StatusInternalServerError)
+		return
+	}
+
+	invoiceDTOs := make([]dto.InvoiceDTO, len(invoices))
+	for i, invoice := range invoices {
+		invoiceDTOs[i] = convertToInvoiceDTO(invoice)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invoiceDTOs)
+}
+
+// DownloadInvoice handles downloading the document of an invoice or credit note
+func (h *InvoiceHandler) DownloadInvoice(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	invoiceID, err := strconv.ParseUint(vars["invoiceId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid invoice ID", http.StatusBadRequest)
+		return
+	}
+
+	invoice, err := h.invoiceUseCase.GetInvoice(uint(invoiceID))
+	if err != nil {
+		http.Error(w, "Invoice not found", http.StatusNotFound)
+		return
+	}
+
+	if status, ok := h.authorizeOrder(r, invoice.OrderID); !ok {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	document, err := h.invoiceUseCase.RenderInvoice(invoice)
+	if err != nil {
+		h.logger.Error("Failed to render invoice %s: %v", invoice.Number, err)
+		http.Error(w, "Failed to render invoice", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", h.invoiceUseCase.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", invoice.Filename()))
+	w.Header().Set("Content-Length", strconv.Itoa(len(document)))
+	w.Write(document)
+}
+
+// authorizeOrder checks that the user owns the order or is an admin,
+// returning the status to respond with otherwise
+func (h *InvoiceHandler) authorizeOrder(r *http.Request, orderID uint) (int, bool) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+	if !ok {
+		return http.StatusUnauthorized, false
+	}
+
+	order, err := h.orderUseCase.GetOrderByID(orderID)
+	if err != nil {
+		return http.StatusNotFound, false
+	}
+
+	if order.UserID != userID {
+		role, ok := middleware.RoleFromContext(r.Context())
+		if !ok || role != "admin" {
+			return http.StatusForbidden, false
+		}
+	}
+
+	return http.StatusOK, true
+}
+
+// convertToInvoiceDTO converts an invoice entity to a DTO
+func convertToInvoiceDTO(invoice *entity.Invoice) dto.InvoiceDTO {
+	return dto.InvoiceDTO{
+		ID:            invoice.ID,
+		OrderID:       invoice.OrderID,
+		Type:          string(invoice.Type),
+		Number:        invoice.Number,
+		InvoiceNumber: invoice.InvoiceNumber,
+		Amount:        money.New(invoice.Amount, invoice.Currency).Decimal(),
+		TaxAmount:     money.New(invoice.TaxAmount, invoice.Currency).Decimal(),
+		Currency:      invoice.Currency,
+		ReverseCharge: invoice.ReverseCharge,
+		DownloadURL:   fmt.Sprintf("/api/invoices/%d", invoice.ID),
+		CreatedAt:     invoice.CreatedAt,
+	}
+}
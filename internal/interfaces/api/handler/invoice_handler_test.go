@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/auth"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/infrastructure/pdf"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/handler"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func TestInvoiceHandler_ListOrderInvoices(t *testing.T) {
+	// Setup mocks
+	orderRepo := mock.NewMockOrderRepository(false)
+	invoiceUseCase := usecase.NewInvoiceUseCase(
+		mock.NewMockInvoiceRepository(),
+		orderRepo,
+		mock.NewMockUserRepository(),
+		pdf.NewInvoiceRenderer(pdf.SellerDetails{Name: "Test Store ApS"}, "en-US"),
+		nil,
+		"INV-",
+		"CN-",
+	)
+	orderUseCase := usecase.NewOrderUseCase(
+		orderRepo,
+		mock.NewMockCartRepository(),
+		mock.NewMockProductRepository(),
+		mock.NewMockUserRepository(),
+		nil,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		nil,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	order, _ := entity.NewOrder(1, []entity.OrderItem{
+		{ProductID: 1, Quantity: 1, Price: 5000, Subtotal: 5000},
+	}, entity.Address{Street: "Main St 1", City: "Copenhagen", Country: "DK"}, entity.Address{}, entity.CustomerDetails{
+		Email:    "customer@example.com",
+		FullName: "Customer",
+	})
+	orderRepo.Create(order)
+
+	invoiceHandler := handler.NewInvoiceHandler(invoiceUseCase, orderUseCase, logger.NewLogger())
+	router, protected, jwtService := newProtectedRouter()
+	protected.HandleFunc("/orders/{orderId:[0-9]+}/invoices", invoiceHandler.ListOrderInvoices).Methods(http.MethodGet)
+
+	listInvoices := func(user *entity.User) int {
+		return serveAs(router, jwtService, user, http.MethodGet, "/api/orders/1/invoices").Code
+	}
+
+	t.Run("Owner of the order", func(t *testing.T) {
+		// Execute
+		status := listInvoices(&entity.User{ID: 1, Email: "customer@example.com", Role: "user"})
+
+		// Assert
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("Another user", func(t *testing.T) {
+		// Execute
+		status := listInvoices(&entity.User{ID: 2, Email: "other@example.com", Role: "user"})
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		// Execute
+		status := listInvoices(&entity.User{ID: 3, Email: "admin@example.com", Role: "admin"})
+
+		// Assert
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
+
+// newProtectedRouter creates a router with an /api subrouter behind the authentication middleware like the server's
+func newProtectedRouter() (*mux.Router, *mux.Router, *auth.JWTService) {
+	jwtService := auth.NewJWTService(config.AuthConfig{JWTSecret: "test-secret", TokenDuration: 1})
+	router := mux.NewRouter()
+	protected := router.PathPrefix("/api").Subrouter()
+	protected.Use(middleware.NewAuthMiddleware(jwtService, logger.NewLogger()).Authenticate)
+	return router, protected, jwtService
+}
+
+// serveAs serves a request signed in as the user
+func serveAs(router *mux.Router, jwtService *auth.JWTService, user *entity.User, method, path string) *httptest.ResponseRecorder {
+	token, _ := jwtService.GenerateToken(user)
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/zenfulcode/commercify/internal/domain/service"
 	"github.com/zenfulcode/commercify/internal/dto"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
 )
 
 // OrderHandler handles order-related HTTP requests
@@ -174,6 +175,113 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// authorizeOrderPayment checks that the request may pay for the order, writing an error response if not
+func (h *OrderHandler) authorizeOrderPayment(w http.ResponseWriter, r *http.Request, order *entity.Order) bool {
+	// For registered users, verify authorization
+	if order.UserID > 0 {
+		// Get user ID from context
+		userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+		if !ok || userID == 0 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return false
+		}
+
+		// Check if the user is authorized to process payment for this order
+		if order.UserID != userID {
+			http.Error(w, "Unauthorized", http.StatusForbidden)
+			return false
+		}
+		return true
+	}
+
+	// For guest orders, check the session cookie
+	if !order.IsGuestOrder {
+		http.Error(w, "Invalid order type", http.StatusBadRequest)
+		return false
+	}
+
+	// Only allow payment processing for guest orders if they have a valid cookie
+	cookie, cookieErr := r.Cookie(common.SessionCookieName)
+	if cookieErr != nil || cookie.Value == "" {
+		http.Error(w, "Invalid session", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// customerEmail returns the email of the guest or registered user who placed the order
+func (h *OrderHandler) customerEmail(order *entity.Order) (string, error) {
+	if order.IsGuestOrder {
+		return order.CustomerDetails.Email, nil
+	}
+
+	// For registered users, get email from user repository
+	user, err := h.orderUseCase.GetUserByID(order.UserID)
+	if err != nil {
+		return "", err
+	}
+	return user.Email, nil
+}
+
+// CreatePaymentIntent creates a Stripe payment intent for an order and returns its client secret,
+// so the storefront can confirm the payment with Stripe.js
+func (h *OrderHandler) CreatePaymentIntent(w http.ResponseWriter, r *http.Request) {
+	// Get order ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["orderId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return
+	}
+
+	// Get the order
+	order, err := h.orderUseCase.GetOrderByID(uint(id))
+	if err != nil {
+		h.logger.Error("Failed to get order: %v", err)
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	// The body is optional
+	var request dto.CreatePaymentIntentRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !h.authorizeOrderPayment(w, r, order) {
+		return
+	}
+
+	customerEmail, err := h.customerEmail(order)
+	if err != nil {
+		h.logger.Error("Failed to get user: %v", err)
+		http.Error(w, "Failed to create payment intent", http.StatusInternalServerError)
+		return
+	}
+
+	// Guests have no account to keep a card on
+	intent, err := h.orderUseCase.CreatePaymentIntent(usecase.CreatePaymentIntentInput{
+		OrderID:           order.ID,
+		CustomerEmail:     customerEmail,
+		SavePaymentMethod: request.SavePaymentMethod && !order.IsGuestOrder,
+		IdempotencyKey:    r.Header.Get("Idempotency-Key"),
+	})
+	if err != nil {
+		h.logger.Error("Failed to create payment intent: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.PaymentIntentResponse{
+		ID:           intent.ID,
+		ClientSecret: intent.ClientSecret,
+	})
+}
+
 // ProcessPayment handles payment processing for an order
 func (h *OrderHandler) ProcessPayment(w http.ResponseWriter, r *http.Request) {
 	// Get order ID from URL
@@ -199,46 +307,20 @@ func (h *OrderHandler) ProcessPayment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// For registered users, verify authorization
-	if order.UserID > 0 {
-		// Get user ID from context
-		userID, ok := r.Context().Value("user_id").(uint)
-		if !ok || userID == 0 {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Check if the user is authorized to process payment for this order
-		if order.UserID != userID {
-			http.Error(w, "Unauthorized", http.StatusForbidden)
-			return
-		}
-	} else {
-		// For guest orders, check the session cookie
-		if !order.IsGuestOrder {
-			http.Error(w, "Invalid order type", http.StatusBadRequest)
-			return
-		}
-
-		// Only allow payment processing for guest orders if they have a valid cookie
-		cookie, cookieErr := r.Cookie(common.SessionCookieName)
-		if cookieErr != nil || cookie.Value == "" {
-			http.Error(w, "Invalid session", http.StatusUnauthorized)
-			return
-		}
+	if !h.authorizeOrderPayment(w, r, order) {
+		return
 	}
 
 	// Set up payment method based on input
 	var paymentMethod service.PaymentMethod
 	switch paymentInput.PaymentMethod {
 	case "credit_card":
+		// Card details are optional, providers with hosted payment pages collect them themselves
 		paymentMethod = service.PaymentMethodCreditCard
-		if paymentInput.CardDetails == nil {
-			http.Error(w, "Card details are required for credit card payment", http.StatusBadRequest)
-			return
-		}
 	case "wallet":
 		paymentMethod = service.PaymentMethodWallet
+	case "paypal":
+		paymentMethod = service.PaymentMethodPayPal
 	default:
 		http.Error(w, "Invalid payment method", http.StatusBadRequest)
 		return
@@ -260,19 +342,11 @@ func (h *OrderHandler) ProcessPayment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get customer email based on order type
-	customerEmail := ""
-	if order.IsGuestOrder {
-		customerEmail = order.CustomerDetails.Email
-	} else {
-		// For registered users, get email from user repository
-		user, err := h.orderUseCase.GetUserByID(order.UserID)
-		if err != nil {
-			h.logger.Error("Failed to get user: %v", err)
-			http.Error(w, "Failed to process payment", http.StatusInternalServerError)
-			return
-		}
-		customerEmail = user.Email
+	customerEmail, err := h.customerEmail(order)
+	if err != nil {
+		h.logger.Error("Failed to get user: %v", err)
+		http.Error(w, "Failed to process payment", http.StatusInternalServerError)
+		return
 	}
 
 	// Process payment
@@ -283,6 +357,10 @@ func (h *OrderHandler) ProcessPayment(w http.ResponseWriter, r *http.Request) {
 		CardDetails:     paymentInput.CardDetails,
 		CustomerEmail:   customerEmail,
 		PhoneNumber:     paymentInput.PhoneNumber,
+
+		SavedPaymentMethodID: paymentInput.SavedPaymentMethodID,
+		SavePaymentMethod:    paymentInput.SavePaymentMethod,
+		IdempotencyKey:       r.Header.Get("Idempotency-Key"),
 	}
 
 	updatedOrder, err := h.orderUseCase.ProcessPayment(input)
@@ -394,6 +472,11 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 // Helper functions to convert between entities and DTOs
 
 func convertToOrderDTO(order *entity.Order) dto.OrderDTO {
+	// Amounts are in the minor units of the order currency
+	decimal := func(amount int64) float64 {
+		return money.New(amount, order.Currency).Decimal()
+	}
+
 	// Convert order items to DTOs
 	var items []dto.OrderItemDTO
 	if len(order.Items) > 0 {
@@ -404,8 +487,10 @@ func convertToOrderDTO(order *entity.Order) dto.OrderDTO {
 				OrderID:    order.ID,
 				ProductID:  item.ProductID,
 				Quantity:   item.Quantity,
-				UnitPrice:  money.FromCents(item.Price),
-				TotalPrice: money.FromCents(item.Subtotal),
+				UnitPrice:  decimal(item.Price),
+				TotalPrice: decimal(item.Subtotal),
+				TaxRate:    item.TaxRate,
+				TaxAmount:  decimal(item.TaxAmount),
 				CreatedAt:  order.CreatedAt,
 				UpdatedAt:  order.UpdatedAt,
 			}
@@ -416,6 +501,7 @@ func convertToOrderDTO(order *entity.Order) dto.OrderDTO {
 	var shippingAddr *dto.AddressDTO
 	if order.ShippingAddr.Street != "" {
 		shippingAddr = &dto.AddressDTO{
+			Company:      order.ShippingAddr.Company,
 			AddressLine1: order.ShippingAddr.Street,
 			City:         order.ShippingAddr.City,
 			State:        order.ShippingAddr.State,
@@ -427,6 +513,7 @@ func convertToOrderDTO(order *entity.Order) dto.OrderDTO {
 	var billingAddr *dto.AddressDTO
 	if order.BillingAddr.Street != "" {
 		billingAddr = &dto.AddressDTO{
+			Company:      order.BillingAddr.Company,
 			AddressLine1: order.BillingAddr.Street,
 			City:         order.BillingAddr.City,
 			State:        order.BillingAddr.State,
@@ -436,9 +523,11 @@ func convertToOrderDTO(order *entity.Order) dto.OrderDTO {
 	}
 
 	customerDetails := dto.CustomerDetails{
-		Email:    order.CustomerDetails.Email,
-		Phone:    order.CustomerDetails.Phone,
-		FullName: order.CustomerDetails.FullName,
+		Email:       order.CustomerDetails.Email,
+		Phone:       order.CustomerDetails.Phone,
+		FullName:    order.CustomerDetails.FullName,
+		CompanyName: order.CustomerDetails.CompanyName,
+		VATID:       order.CustomerDetails.VATID,
 	}
 
 	paymentDetails := dto.PaymentDetails{
@@ -447,13 +536,19 @@ func convertToOrderDTO(order *entity.Order) dto.OrderDTO {
 		Method:   dto.PaymentMethod(order.PaymentMethod),
 		Captured: order.IsCaptured(),
 		Refunded: order.IsRefunded(),
+
+		AuthorizedAmount:       decimal(order.AuthorizedAmount),
+		CapturedAmount:         decimal(order.CapturedAmount),
+		RemainingCapturable:    decimal(order.RemainingCapturable()),
+		AuthorizationExpiresAt: order.AuthorizationExpiresAt,
 	}
 
 	var discountDetails dto.DiscountDetails
 	if order.AppliedDiscount != nil {
 		discountDetails = dto.DiscountDetails{
-			Code:   order.AppliedDiscount.DiscountCode,
-			Amount: money.FromCents(order.AppliedDiscount.DiscountAmount),
+			Code:           order.AppliedDiscount.DiscountCode,
+			Amount:         decimal(order.DiscountAmount),
+			ShippingAmount: decimal(order.ShippingDiscountAmount),
 		}
 	}
 
@@ -462,24 +557,70 @@ func convertToOrderDTO(order *entity.Order) dto.OrderDTO {
 		shippingDetails = dto.ShippingDetails{
 			MethodID: order.ShippingMethodID,
 			Method:   order.ShippingMethod.Name,
-			Cost:     money.FromCents(order.ShippingCost),
+			Cost:     decimal(order.ShippingCost),
 		}
 	}
+	if label := order.ShippingLabel; label != nil {
+		shippingDetails.Label = &dto.ShippingLabelDTO{
+			Carrier:        label.Carrier,
+			ServiceCode:    label.ServiceCode,
+			TrackingNumber: label.TrackingNumber,
+			TrackingURL:    label.TrackingURL,
+			LabelURL:       label.LabelURL,
+			Cost:           money.New(label.Cost, label.Currency).Decimal(),
+			Currency:       label.Currency,
+		}
+	}
+	shippingDetails.PickupPoint = toPickupPointDTO(order.PickupPoint)
+	shippingDetails.EstimatedDelivery = toDeliveryEstimateDTO(order.EstimatedDelivery)
+	for _, pkg := range order.Packages {
+		packageItems := make([]dto.OrderPackageItemDTO, len(pkg.Items))
+		for i, item := range pkg.Items {
+			packageItems[i] = dto.OrderPackageItemDTO{
+				ProductID: item.ProductID,
+				VariantID: item.VariantID,
+				SKU:       item.SKU,
+				Name:      item.Name,
+				Quantity:  item.Quantity,
+			}
+		}
+		shippingDetails.Packages = append(shippingDetails.Packages, dto.OrderPackageDTO{
+			PackageID:         pkg.PackageID,
+			Name:              pkg.Name,
+			Dimensions:        toDimensionsDTO(pkg.Dimensions),
+			Weight:            pkg.Weight,
+			DimensionalWeight: pkg.DimensionalWeight,
+			Items:             packageItems,
+		})
+	}
+
+	taxDetails := dto.TaxDetails{
+		Amount:           decimal(order.TaxAmount),
+		ShippingAmount:   decimal(order.ShippingTaxAmount),
+		ShippingRate:     order.ShippingTaxRate,
+		PricesIncludeTax: order.PricesIncludeTax,
+		ReverseCharge:    order.ReverseCharge,
+	}
+	if order.ReverseCharge {
+		taxDetails.ReverseChargeNote = entity.ReverseChargeNote
+	}
 
 	return dto.OrderDTO{
 		ID:              order.ID,
 		OrderNumber:     order.OrderNumber,
 		UserID:          order.UserID,
 		Status:          dto.OrderStatus(order.Status),
-		TotalAmount:     money.FromCents(order.TotalAmount),
-		FinalAmount:     money.FromCents(order.FinalAmount),
-		Currency:        "USD",
+		TotalAmount:     decimal(order.TotalAmount),
+		FinalAmount:     decimal(order.FinalAmount),
+		Currency:        order.Currency,
+		ExchangeRate:    order.ExchangeRate,
 		Items:           items,
 		ShippingAddress: *shippingAddr,
 		BillingAddress:  *billingAddr,
 		PaymentDetails:  paymentDetails,
 		ShippingDetails: shippingDetails,
 		DiscountDetails: discountDetails,
+		TaxDetails:      taxDetails,
 		Customer:        customerDetails,
 		ActionURL:       order.ActionURL,
 		CreatedAt:       order.CreatedAt,
@@ -487,9 +628,41 @@ func convertToOrderDTO(order *entity.Order) dto.OrderDTO {
 	}
 }
 
+// toDeliveryEstimateDTO converts the delivery window of an order, nil if it was not estimated
+func toDeliveryEstimateDTO(estimate *entity.DeliveryEstimate) *dto.DeliveryEstimateDTO {
+	if estimate == nil {
+		return nil
+	}
+	return &dto.DeliveryEstimateDTO{
+		Earliest: estimate.Earliest,
+		Latest:   estimate.Latest,
+	}
+}
+
+// toPickupPointDTO converts the pickup point of an order, nil if it is delivered to the customer's address
+func toPickupPointDTO(point *entity.PickupPoint) *dto.PickupPointDTO {
+	if point == nil {
+		return nil
+	}
+
+	return &dto.PickupPointDTO{
+		ID:   point.ID,
+		Name: point.Name,
+		Address: dto.AddressDTO{
+			AddressLine1: point.Address.Street,
+			City:         point.Address.City,
+			State:        point.Address.State,
+			PostalCode:   point.Address.PostalCode,
+			Country:      point.Address.Country,
+		},
+		Carrier: point.Carrier,
+	}
+}
+
 func convertToCreateOrderInput(input dto.CreateOrderRequest, userID uint, sessionID string) usecase.CreateOrderInput {
 	// Convert addresses
 	shippingAddr := entity.Address{
+		Company:    input.ShippingAddress.Company,
 		Street:     input.ShippingAddress.AddressLine1,
 		City:       input.ShippingAddress.City,
 		State:      input.ShippingAddress.State,
@@ -498,6 +671,7 @@ func convertToCreateOrderInput(input dto.CreateOrderRequest, userID uint, sessio
 	}
 
 	billingAddr := entity.Address{
+		Company:    input.BillingAddress.Company,
 		Street:     input.BillingAddress.AddressLine1,
 		City:       input.BillingAddress.City,
 		State:      input.BillingAddress.State,
@@ -513,6 +687,10 @@ func convertToCreateOrderInput(input dto.CreateOrderRequest, userID uint, sessio
 		Email:            input.Email,
 		PhoneNumber:      input.PhoneNumber,
 		FullName:         input.FirstName + " " + input.LastName,
+		CompanyName:      input.CompanyName,
+		VATID:            input.VATID,
 		ShippingMethodID: input.ShippingMethodID,
+		PickupPointID:    input.PickupPointID,
+		CurrencyCode:     input.Currency,
 	}
 }
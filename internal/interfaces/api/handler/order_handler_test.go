@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/handler"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func TestOrderHandler_CreatePaymentIntent(t *testing.T) {
+	// Setup mocks
+	orderRepo := mock.NewMockOrderRepository(false)
+	userRepo := mock.NewMockUserRepository()
+	owner := &entity.User{Email: "jane@example.com", FirstName: "Jane", LastName: "Doe", Role: "user"}
+	userRepo.Create(owner)
+
+	orderUseCase := usecase.NewOrderUseCase(
+		orderRepo,
+		mock.NewMockCartRepository(),
+		mock.NewMockProductRepository(),
+		userRepo,
+		nil,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		nil,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	order, _ := entity.NewOrder(owner.ID, []entity.OrderItem{
+		{ProductID: 1, Quantity: 1, Price: 5000, Subtotal: 5000},
+	}, entity.Address{Street: "Main St 1", City: "Copenhagen", Country: "DK"}, entity.Address{}, entity.CustomerDetails{
+		Email:    owner.Email,
+		FullName: "Jane Doe",
+	})
+	orderRepo.Create(order)
+
+	orderHandler := handler.NewOrderHandler(orderUseCase, logger.NewLogger())
+	router, protected, jwtService := newProtectedRouter()
+	protected.HandleFunc("/orders/{orderId:[0-9]+}/payment-intent", orderHandler.CreatePaymentIntent).Methods(http.MethodPost)
+
+	t.Run("Owner of the order", func(t *testing.T) {
+		// Execute
+		rec := serveAs(router, jwtService, owner, http.MethodPost, "/api/orders/1/payment-intent")
+
+		// Assert, the request gets past authorization to the payment service, which isn't configured
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid payment service\n", rec.Body.String())
+	})
+
+	t.Run("Another user", func(t *testing.T) {
+		// Execute
+		rec := serveAs(router, jwtService, &entity.User{ID: 2, Email: "john@example.com", Role: "user"}, http.MethodPost, "/api/orders/1/payment-intent")
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
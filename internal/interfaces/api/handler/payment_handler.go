@@ -3,25 +3,31 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
 	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/dto"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
 )
 
 // PaymentHandler handles payment-related HTTP requests
 type PaymentHandler struct {
-	orderUseCase *usecase.OrderUseCase
-	logger       logger.Logger
+	orderUseCase         *usecase.OrderUseCase
+	paymentMethodUseCase *usecase.PaymentMethodUseCase
+	logger               logger.Logger
 }
 
 // NewPaymentHandler creates a new PaymentHandler
-func NewPaymentHandler(orderUseCase *usecase.OrderUseCase, logger logger.Logger) *PaymentHandler {
+func NewPaymentHandler(orderUseCase *usecase.OrderUseCase, paymentMethodUseCase *usecase.PaymentMethodUseCase, logger logger.Logger) *PaymentHandler {
 	return &PaymentHandler{
-		orderUseCase: orderUseCase,
-		logger:       logger,
+		orderUseCase:         orderUseCase,
+		paymentMethodUseCase: paymentMethodUseCase,
+		logger:               logger,
 	}
 }
 
@@ -35,6 +41,105 @@ func (h *PaymentHandler) GetAvailablePaymentProviders(w http.ResponseWriter, r *
 	json.NewEncoder(w).Encode(providers)
 }
 
+// CreateSetupIntent creates a Stripe setup intent for the user and returns its client secret,
+// so the storefront can save a card with Stripe.js without paying
+func (h *PaymentHandler) CreateSetupIntent(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+	if !ok || userID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	intent, err := h.orderUseCase.CreateSetupIntent(userID)
+	if err != nil {
+		h.logger.Error("Failed to create setup intent: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.PaymentIntentResponse{
+		ID:           intent.ID,
+		ClientSecret: intent.ClientSecret,
+	})
+}
+
+// ListPaymentMethods handles listing the user's saved payment methods, the default first
+func (h *PaymentHandler) ListPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+	if !ok || userID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	methods, err := h.paymentMethodUseCase.ListPaymentMethods(userID)
+	if err != nil {
+		h.logger.Error("Failed to list payment methods: %v", err)
+		http.Error(w, "Failed to list payment methods", http.StatusInternalServerError)
+		return
+	}
+
+	methodDTOs := make([]dto.SavedPaymentMethodDTO, len(methods))
+	for i, method := range methods {
+		methodDTOs[i] = convertToSavedPaymentMethodDTO(method)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(methodDTOs)
+}
+
+// SetDefaultPaymentMethod handles making a saved payment method the user's default
+func (h *PaymentHandler) SetDefaultPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+	if !ok || userID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	methodID, err := strconv.ParseUint(mux.Vars(r)["paymentMethodId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid payment method ID", http.StatusBadRequest)
+		return
+	}
+
+	method, err := h.paymentMethodUseCase.SetDefaultPaymentMethod(userID, uint(methodID))
+	if err != nil {
+		h.logger.Error("Failed to set default payment method: %v", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertToSavedPaymentMethodDTO(method))
+}
+
+// DeletePaymentMethod handles deleting a saved payment method, which is also removed at the provider
+func (h *PaymentHandler) DeletePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uint)
+	if !ok || userID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	methodID, err := strconv.ParseUint(mux.Vars(r)["paymentMethodId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid payment method ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.paymentMethodUseCase.DeletePaymentMethod(userID, uint(methodID)); err != nil {
+		h.logger.Error("Failed to delete payment method: %v", err)
+		if err.Error() == "payment method not found" {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // CapturePayment handles capturing an authorized payment
 func (h *PaymentHandler) CapturePayment(w http.ResponseWriter, r *http.Request) {
 	// Get payment ID from URL
@@ -46,9 +151,7 @@ func (h *PaymentHandler) CapturePayment(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Parse request body
-	var input struct {
-		Amount float64 `json:"amount"`
-	}
+	var input dto.CapturePaymentRequest
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
@@ -60,8 +163,20 @@ func (h *PaymentHandler) CapturePayment(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// The amount is in the currency of the order
+	order, err := h.orderUseCase.GetOrderByPaymentID(paymentID)
+	if err != nil {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
 	// Capture payment
-	err := h.orderUseCase.CapturePayment(paymentID, money.ToCents(input.Amount))
+	order, err = h.orderUseCase.CapturePayment(usecase.CapturePaymentInput{
+		PaymentID: paymentID,
+		Amount:    money.FromDecimal(input.Amount, order.Currency).Amount,
+		Final:     input.Final,
+		Shipment:  input.Shipment,
+	})
 	if err != nil {
 		h.logger.Error("Failed to capture payment: %v", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -70,9 +185,11 @@ func (h *PaymentHandler) CapturePayment(w http.ResponseWriter, r *http.Request)
 
 	// Return success
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{
-		"status":  "success",
-		"message": "Payment captured successfully",
+	json.NewEncoder(w).Encode(dto.CapturePaymentResponse{
+		Status:              "success",
+		Message:             "Payment captured successfully",
+		CapturedAmount:      money.New(order.CapturedAmount, order.Currency).Decimal(),
+		RemainingCapturable: money.New(order.RemainingCapturable(), order.Currency).Decimal(),
 	})
 }
 
@@ -127,8 +244,15 @@ func (h *PaymentHandler) RefundPayment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The amount is in the currency of the order
+	order, err := h.orderUseCase.GetOrderByPaymentID(paymentID)
+	if err != nil {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
 	// Refund payment
-	err := h.orderUseCase.RefundPayment(paymentID, money.ToCents(input.Amount))
+	err = h.orderUseCase.RefundPayment(paymentID, money.FromDecimal(input.Amount, order.Currency).Amount)
 	if err != nil {
 		h.logger.Error("Failed to refund payment: %v", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -183,3 +307,17 @@ func (h *PaymentHandler) ForceApproveMobilePayPayment(w http.ResponseWriter, r *
 		"message": "Payment force approved successfully",
 	})
 }
+
+// convertToSavedPaymentMethodDTO converts a saved payment method entity to a DTO
+func convertToSavedPaymentMethodDTO(method *entity.SavedPaymentMethod) dto.SavedPaymentMethodDTO {
+	return dto.SavedPaymentMethodDTO{
+		ID:        method.ID,
+		Provider:  method.Provider,
+		Brand:     method.Brand,
+		Last4:     method.Last4,
+		ExpMonth:  method.ExpMonth,
+		ExpYear:   method.ExpYear,
+		IsDefault: method.IsDefault,
+		CreatedAt: method.CreatedAt,
+	}
+}
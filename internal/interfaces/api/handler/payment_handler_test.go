@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/dto"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/interfaces/api/handler"
+	"github.com/zenfulcode/commercify/testutil/mock"
+)
+
+func TestPaymentHandler_PaymentMethods(t *testing.T) {
+	// Setup mocks
+	methodRepo := mock.NewMockSavedPaymentMethodRepository()
+	paymentMethodUseCase := usecase.NewPaymentMethodUseCase(methodRepo, mock.NewMockUserRepository(), nil)
+	method, _ := entity.NewSavedPaymentMethod(1, "stripe", "pm_1", "visa", "4242", 12, time.Now().Year()+2)
+	methodRepo.Create(method)
+
+	orderUseCase := usecase.NewOrderUseCase(
+		mock.NewMockOrderRepository(false),
+		mock.NewMockCartRepository(),
+		mock.NewMockProductRepository(),
+		mock.NewMockUserRepository(),
+		nil,
+		nil,
+		mock.NewMockPaymentTransactionRepository(),
+		nil,
+		mock.NewMockCurrencyRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		paymentMethodUseCase,
+	)
+	paymentHandler := handler.NewPaymentHandler(orderUseCase, paymentMethodUseCase, logger.NewLogger())
+	router, protected, jwtService := newProtectedRouter()
+	protected.HandleFunc("/payments/setup-intent", paymentHandler.CreateSetupIntent).Methods(http.MethodPost)
+	protected.HandleFunc("/payment-methods", paymentHandler.ListPaymentMethods).Methods(http.MethodGet)
+	protected.HandleFunc("/payment-methods/{paymentMethodId:[0-9]+}/default", paymentHandler.SetDefaultPaymentMethod).Methods(http.MethodPut)
+
+	owner := &entity.User{ID: 1, Email: "jane@example.com", Role: "user"}
+	other := &entity.User{ID: 2, Email: "john@example.com", Role: "user"}
+
+	t.Run("List the user's payment methods", func(t *testing.T) {
+		// Execute
+		rec := serveAs(router, jwtService, owner, http.MethodGet, "/api/payment-methods")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var methods []dto.SavedPaymentMethodDTO
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&methods))
+		assert.Len(t, methods, 1)
+		assert.Equal(t, "4242", methods[0].Last4)
+	})
+
+	t.Run("Payment methods of another user", func(t *testing.T) {
+		// Execute
+		rec := serveAs(router, jwtService, other, http.MethodPut, "/api/payment-methods/1/default")
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Setup intent for the signed in user", func(t *testing.T) {
+		// Execute
+		rec := serveAs(router, jwtService, owner, http.MethodPost, "/api/payments/setup-intent")
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid payment service\n", rec.Body.String())
+	})
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+)
+
+// PriceListHandler handles customer group and price list requests
+type PriceListHandler struct {
+	priceListUseCase *usecase.PriceListUseCase
+	logger           logger.Logger
+}
+
+// NewPriceListHandler creates a new PriceListHandler
+func NewPriceListHandler(priceListUseCase *usecase.PriceListUseCase, logger logger.Logger) *PriceListHandler {
+	return &PriceListHandler{
+		priceListUseCase: priceListUseCase,
+		logger:           logger,
+	}
+}
+
+// ListCustomerGroups handles listing customer groups (admin only)
+func (h *PriceListHandler) ListCustomerGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.priceListUseCase.ListCustomerGroups()
+	if err != nil {
+		h.logger.Error("Failed to list customer groups: %v", err)
+		http.Error(w, "Failed to list customer groups", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
+
+// CreateCustomerGroup handles creating a customer group (admin only)
+func (h *PriceListHandler) CreateCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	var input usecase.CustomerGroupInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.priceListUseCase.CreateCustomerGroup(input)
+	if err != nil {
+		h.logger.Error("Failed to create customer group: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(group)
+}
+
+// UpdateCustomerGroup handles updating a customer group (admin only)
+func (h *PriceListHandler) UpdateCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["groupId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid customer group ID", http.StatusBadRequest)
+		return
+	}
+
+	var input usecase.CustomerGroupInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.priceListUseCase.UpdateCustomerGroup(uint(id), input)
+	if err != nil {
+		h.logger.Error("Failed to update customer group: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(group)
+}
+
+// DeleteCustomerGroup handles deleting a customer group (admin only)
+func (h *PriceListHandler) DeleteCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["groupId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid customer group ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.priceListUseCase.DeleteCustomerGroup(uint(id)); err != nil {
+		h.logger.Error("Failed to delete customer group: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AssignCustomerGroup handles assigning a user to a customer group (admin only).
+// A null customer_group_id removes the user from their group.
+func (h *PriceListHandler) AssignCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["userId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		CustomerGroupID *uint `json:"customer_group_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.priceListUseCase.AssignCustomerGroup(uint(id), input.CustomerGroupID)
+	if err != nil {
+		h.logger.Error("Failed to assign customer group: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// ListPriceLists handles listing price lists (admin only)
+func (h *PriceListHandler) ListPriceLists(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	priceLists, err := h.priceListUseCase.ListPriceLists(offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to list price lists: %v", err)
+		http.Error(w, "Failed to list price lists", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(priceLists)
+}
+
+// CreatePriceList handles creating a price list (admin only)
+func (h *PriceListHandler) CreatePriceList(w http.ResponseWriter, r *http.Request) {
+	var input usecase.PriceListInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	priceList, err := h.priceListUseCase.CreatePriceList(input)
+	if err != nil {
+		h.logger.Error("Failed to create price list: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(priceList)
+}
+
+// GetPriceList handles getting a price list by ID (admin only)
+func (h *PriceListHandler) GetPriceList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["priceListId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid price list ID", http.StatusBadRequest)
+		return
+	}
+
+	priceList, err := h.priceListUseCase.GetPriceList(uint(id))
+	if err != nil {
+		h.logger.Error("Failed to get price list: %v", err)
+		http.Error(w, "Price list not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(priceList)
+}
+
+// UpdatePriceList handles replacing a price list (admin only)
+func (h *PriceListHandler) UpdatePriceList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["priceListId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid price list ID", http.StatusBadRequest)
+		return
+	}
+
+	var input usecase.PriceListInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	priceList, err := h.priceListUseCase.UpdatePriceList(uint(id), input)
+	if err != nil {
+		h.logger.Error("Failed to update price list: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(priceList)
+}
+
+// DeletePriceList handles deleting a price list (admin only)
+func (h *PriceListHandler) DeletePriceList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["priceListId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid price list ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.priceListUseCase.DeletePriceList(uint(id)); err != nil {
+		h.logger.Error("Failed to delete price list: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/config"
@@ -47,18 +48,22 @@ func toVariantDTO(variant *entity.ProductVariant) dto.VariantDTO {
 		}
 	}
 
+	price, compareAt := variant.Resolve(variant.Price, time.Now())
+
 	return dto.VariantDTO{
-		ID:         variant.ID,
-		ProductID:  variant.ProductID,
-		SKU:        variant.SKU,
-		Price:      money.FromCents(variant.Price),
-		Currency:   variant.CurrencyCode,
-		Stock:      variant.Stock,
-		Attributes: attributesDTO,
-		Images:     variant.Images,
-		IsDefault:  variant.IsDefault,
-		CreatedAt:  variant.CreatedAt,
-		UpdatedAt:  variant.UpdatedAt,
+		ID:             variant.ID,
+		ProductID:      variant.ProductID,
+		SKU:            variant.SKU,
+		Price:          money.New(price, variant.CurrencyCode).Decimal(),
+		CompareAtPrice: money.New(compareAt, variant.CurrencyCode).Decimal(),
+		Currency:       variant.CurrencyCode,
+		Stock:          variant.Stock,
+		Attributes:     attributesDTO,
+		Images:         variant.Images,
+		IsDefault:      variant.IsDefault,
+		Dimensions:     toOptionalDimensionsDTO(variant.Dimensions),
+		CreatedAt:      variant.CreatedAt,
+		UpdatedAt:      variant.UpdatedAt,
 	}
 }
 
@@ -71,25 +76,93 @@ func toProductDTO(product *entity.Product) dto.ProductDTO {
 		variantsDTO[i] = toVariantDTO(v)
 	}
 
+	price, compareAt := product.Resolve(product.Price, time.Now())
+
 	return dto.ProductDTO{
-		ID:          product.ID,
-		Name:        product.Name,
-		Description: product.Description,
-		SKU:         product.ProductNumber,
-		Price:       money.FromCents(product.Price),
-		Currency:    product.CurrencyCode,
-		Stock:       product.Stock,
-		Weight:      product.Weight,
-		CategoryID:  product.CategoryID,
-		Images:      product.Images,
-		HasVariants: product.HasVariants,
-		Variants:    variantsDTO,
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
-		Active:      product.Active,
+		ID:                product.ID,
+		Name:              product.Name,
+		Description:       product.Description,
+		SKU:               product.ProductNumber,
+		Price:             money.New(price, product.CurrencyCode).Decimal(),
+		CompareAtPrice:    money.New(compareAt, product.CurrencyCode).Decimal(),
+		Currency:          product.CurrencyCode,
+		Stock:             product.Stock,
+		Weight:            product.Weight,
+		Dimensions:        toDimensionsDTO(product.Dimensions),
+		CategoryID:        product.CategoryID,
+		TaxClassID:        product.TaxClassID,
+		ShippingProfileID: product.ShippingProfileID,
+		Images:            product.Images,
+		HasVariants:       product.HasVariants,
+		Variants:          variantsDTO,
+		CreatedAt:         product.CreatedAt,
+		UpdatedAt:         product.UpdatedAt,
+		Active:            product.Active,
+	}
+}
+
+func toDimensionsDTO(dimensions entity.Dimensions) dto.DimensionsDTO {
+	return dto.DimensionsDTO{
+		Length: dimensions.Length,
+		Width:  dimensions.Width,
+		Height: dimensions.Height,
+	}
+}
+
+func toOptionalDimensionsDTO(dimensions entity.Dimensions) *dto.DimensionsDTO {
+	if dimensions.IsZero() {
+		return nil
+	}
+	dimensionsDTO := toDimensionsDTO(dimensions)
+	return &dimensionsDTO
+}
+
+func toDimensions(request *dto.DimensionsDTO) entity.Dimensions {
+	if request == nil {
+		return entity.Dimensions{}
+	}
+	return entity.Dimensions{
+		Length: request.Length,
+		Width:  request.Width,
+		Height: request.Height,
+	}
+}
+
+// toDimensionsUpdate converts requested dimensions, nil to keep the current ones
+func toDimensionsUpdate(request *dto.DimensionsDTO) *entity.Dimensions {
+	if request == nil {
+		return nil
+	}
+	dimensions := toDimensions(request)
+	return &dimensions
+}
+
+func toSalePriceInput(request *dto.SalePriceRequest) *usecase.SalePriceInput {
+	if request == nil {
+		return nil
+	}
+
+	return &usecase.SalePriceInput{
+		CompareAtPrice: request.CompareAtPrice,
+		SalePrice:      request.SalePrice,
+		SaleStartsAt:   request.SaleStartsAt,
+		SaleEndsAt:     request.SaleEndsAt,
 	}
 }
 
+func toCurrencyPriceInputs(requests []dto.CurrencyPriceRequest) []usecase.CurrencyPriceInput {
+	inputs := make([]usecase.CurrencyPriceInput, len(requests))
+	for i, p := range requests {
+		inputs[i] = usecase.CurrencyPriceInput{
+			CurrencyCode: p.CurrencyCode,
+			Price:        p.Price,
+			Sale:         toSalePriceInput(p.Sale),
+		}
+	}
+
+	return inputs
+}
+
 // --- Handlers --- //
 
 // CreateProduct handles product creation
@@ -132,25 +205,33 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		}
 
 		variantInputs[i] = usecase.CreateVariantInput{
-			SKU:        v.SKU,
-			Price:      v.Price,
-			Stock:      v.Stock,
-			Attributes: attributes,
-			Images:     v.Images,
-			IsDefault:  v.IsDefault,
+			SKU:            v.SKU,
+			Price:          v.Price,
+			Stock:          v.Stock,
+			Attributes:     attributes,
+			Images:         v.Images,
+			IsDefault:      v.IsDefault,
+			Dimensions:     toDimensions(v.Dimensions),
+			CurrencyPrices: toCurrencyPriceInputs(v.CurrencyPrices),
+			Sale:           toSalePriceInput(v.Sale),
 		}
 	}
 
 	// Convert DTO to usecase input
 	input := usecase.CreateProductInput{
-		Name:        request.Name,
-		Description: request.Description,
-		Price:       request.Price,
-		Stock:       request.Stock,
-		Weight:      request.Weight,
-		CategoryID:  request.CategoryID,
-		Images:      request.Images,
-		Variants:    variantInputs,
+		Name:              request.Name,
+		Description:       request.Description,
+		Price:             request.Price,
+		Stock:             request.Stock,
+		Weight:            request.Weight,
+		Dimensions:        toDimensions(request.Dimensions),
+		CategoryID:        request.CategoryID,
+		TaxClassID:        request.TaxClassID,
+		ShippingProfileID: request.ShippingProfileID,
+		Images:            request.Images,
+		Variants:          variantInputs,
+		CurrencyPrices:    toCurrencyPriceInputs(request.CurrencyPrices),
+		Sale:              toSalePriceInput(request.Sale),
 	}
 
 	// Create product
@@ -277,13 +358,18 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 
 	// Convert DTO to usecase input
 	input := usecase.UpdateProductInput{
-		Name:        request.Name,
-		Description: request.Description,
-		Price:       *request.Price,
-		Stock:       *request.StockQuantity,
-		CategoryID:  *request.CategoryID,
-		Images:      request.Images,
-		Active:      request.Active,
+		Name:              request.Name,
+		Description:       request.Description,
+		Price:             *request.Price,
+		Stock:             *request.StockQuantity,
+		CategoryID:        *request.CategoryID,
+		TaxClassID:        request.TaxClassID,
+		ShippingProfileID: request.ShippingProfileID,
+		Images:            request.Images,
+		Dimensions:        toDimensionsUpdate(request.Dimensions),
+		CurrencyPrices:    toCurrencyPriceInputs(request.CurrencyPrices),
+		Sale:              toSalePriceInput(request.Sale),
+		Active:            request.Active,
 	}
 
 	// Update product
@@ -482,11 +568,15 @@ func (h *ProductHandler) SearchProducts(w http.ResponseWriter, r *http.Request)
 
 	offset := (page - 1) * pageSize
 
+	// Signed-in customers see the prices of their customer group
+	userID, _ := r.Context().Value(middleware.UserIDKey).(uint)
+
 	// Convert to usecase input
 	input := usecase.SearchProductsInput{
 		Offset:       offset,
 		Limit:        pageSize,
 		CurrencyCode: currencyCode,
+		UserID:       userID,
 	}
 
 	// Handle optional fields
@@ -618,13 +708,16 @@ func (h *ProductHandler) AddVariant(w http.ResponseWriter, r *http.Request) {
 
 	// Convert DTO to usecase input
 	input := usecase.AddVariantInput{
-		ProductID:  uint(productID),
-		SKU:        request.SKU,
-		Price:      request.Price,
-		Stock:      request.Stock,
-		Attributes: attributesDTO,
-		Images:     request.Images,
-		IsDefault:  request.IsDefault,
+		ProductID:      uint(productID),
+		SKU:            request.SKU,
+		Price:          request.Price,
+		Stock:          request.Stock,
+		Attributes:     attributesDTO,
+		Images:         request.Images,
+		IsDefault:      request.IsDefault,
+		Dimensions:     toDimensions(request.Dimensions),
+		CurrencyPrices: toCurrencyPriceInputs(request.CurrencyPrices),
+		Sale:           toSalePriceInput(request.Sale),
 	}
 
 	// Add variant
@@ -722,12 +815,15 @@ func (h *ProductHandler) UpdateVariant(w http.ResponseWriter, r *http.Request) {
 
 	// Convert DTO to usecase input
 	input := usecase.UpdateVariantInput{
-		SKU:        request.SKU,
-		Price:      request.Price,
-		Stock:      request.Stock,
-		Attributes: attributesDTO,
-		Images:     request.Images,
-		IsDefault:  request.IsDefault,
+		SKU:            request.SKU,
+		Price:          request.Price,
+		Stock:          request.Stock,
+		Attributes:     attributesDTO,
+		Images:         request.Images,
+		IsDefault:      request.IsDefault,
+		Dimensions:     toDimensionsUpdate(request.Dimensions),
+		CurrencyPrices: toCurrencyPriceInputs(request.CurrencyPrices),
+		Sale:           toSalePriceInput(request.Sale),
 	}
 
 	// Update variant
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/internal/application/usecase"
@@ -15,13 +16,15 @@ import (
 // ShippingHandler handles shipping-related HTTP requests
 type ShippingHandler struct {
 	shippingUseCase *usecase.ShippingUseCase
+	taxUseCase      *usecase.TaxUseCase
 	logger          logger.Logger
 }
 
 // NewShippingHandler creates a new ShippingHandler
-func NewShippingHandler(shippingUseCase *usecase.ShippingUseCase, logger logger.Logger) *ShippingHandler {
+func NewShippingHandler(shippingUseCase *usecase.ShippingUseCase, taxUseCase *usecase.TaxUseCase, logger logger.Logger) *ShippingHandler {
 	return &ShippingHandler{
 		shippingUseCase: shippingUseCase,
+		taxUseCase:      taxUseCase,
 		logger:          logger,
 	}
 }
@@ -30,9 +33,10 @@ func NewShippingHandler(shippingUseCase *usecase.ShippingUseCase, logger logger.
 func (h *ShippingHandler) CalculateShippingOptions(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var requestBody struct {
-		Address     entity.Address `json:"address"`
-		OrderValue  float64        `json:"order_value"`
-		OrderWeight float64        `json:"order_weight"`
+		Address      entity.Address `json:"address"`
+		OrderValue   float64        `json:"order_value"`
+		OrderWeight  float64        `json:"order_weight"`
+		DiscountCode string         `json:"discount_code"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
@@ -40,14 +44,27 @@ func (h *ShippingHandler) CalculateShippingOptions(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// Calculate shipping options
+	// Calculate shipping options, without cart items only the methods' weight limits apply
 	options, err := h.shippingUseCase.CalculateShippingOptions(
 		requestBody.Address,
 		money.ToCents(requestBody.OrderValue),
 		requestBody.OrderWeight,
+		requestBody.DiscountCode,
+		nil,
 	)
 	if err != nil {
 		h.logger.Error("Failed to calculate shipping options: %v", err)
+		if err.Error() == "invalid discount code" {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to calculate shipping options", http.StatusInternalServerError)
+		return
+	}
+
+	// Add the tax on each option's shipping cost
+	if err := h.taxUseCase.ApplyShippingOptionsTax(requestBody.Address, options); err != nil {
+		h.logger.Error("Failed to calculate shipping tax: %v", err)
 		http.Error(w, "Failed to calculate shipping options", http.StatusInternalServerError)
 		return
 	}
@@ -57,6 +74,42 @@ func (h *ShippingHandler) CalculateShippingOptions(w http.ResponseWriter, r *htt
 	json.NewEncoder(w).Encode(options)
 }
 
+// ListPickupPoints handles listing the pickup points of a shipping method near a postal code
+func (h *ShippingHandler) ListPickupPoints(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	methodID, err := strconv.ParseUint(query.Get("method"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid shipping method ID", http.StatusBadRequest)
+		return
+	}
+
+	postalCode := query.Get("postal_code")
+	if postalCode == "" {
+		http.Error(w, "Postal code is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get pickup points
+	points, err := h.shippingUseCase.ListPickupPoints(uint(methodID), postalCode, query.Get("country"))
+	if err != nil {
+		h.logger.Error("Failed to list pickup points: %v", err)
+		switch err.Error() {
+		case "shipping method not found":
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case "shipping method does not deliver to pickup points":
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Failed to list pickup points", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return pickup points
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(points)
+}
+
 // GetShippingMethodByID handles retrieving a shipping method by ID
 func (h *ShippingHandler) GetShippingMethodByID(w http.ResponseWriter, r *http.Request) {
 	// Get method ID from URL
@@ -251,6 +304,36 @@ func (h *ShippingHandler) UpdateShippingZone(w http.ResponseWriter, r *http.Requ
 	json.NewEncoder(w).Encode(zone)
 }
 
+// ResolveShippingZones handles explaining which shipping zones an address belongs to (admin only)
+func (h *ShippingHandler) ResolveShippingZones(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var requestBody struct {
+		Address entity.Address `json:"address"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if requestBody.Address.Country == "" {
+		http.Error(w, "Address country is required", http.StatusBadRequest)
+		return
+	}
+
+	// Resolve shipping zones
+	resolution, err := h.shippingUseCase.ResolveShippingZones(requestBody.Address)
+	if err != nil {
+		h.logger.Error("Failed to resolve shipping zones: %v", err)
+		http.Error(w, "Failed to resolve shipping zones", http.StatusInternalServerError)
+		return
+	}
+
+	// Return resolution
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resolution)
+}
+
 // CreateShippingRate handles creating a new shipping rate (admin only)
 func (h *ShippingHandler) CreateShippingRate(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
@@ -416,3 +499,318 @@ func (h *ShippingHandler) GetShippingCost(w http.ResponseWriter, r *http.Request
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// CreateShippingPackage handles creating a new shipping package (admin only)
+func (h *ShippingHandler) CreateShippingPackage(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var input usecase.ShippingPackageInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Create shipping package
+	pkg, err := h.shippingUseCase.CreateShippingPackage(input)
+	if err != nil {
+		h.logger.Error("Failed to create shipping package: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Return created shipping package
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(pkg)
+}
+
+// ListShippingPackages handles listing shipping packages (admin only)
+func (h *ShippingHandler) ListShippingPackages(w http.ResponseWriter, r *http.Request) {
+	// Get active parameter from query string
+	activeOnly := r.URL.Query().Get("active") == "true"
+
+	// Get shipping packages
+	packages, err := h.shippingUseCase.ListShippingPackages(activeOnly)
+	if err != nil {
+		h.logger.Error("Failed to list shipping packages: %v", err)
+		http.Error(w, "Failed to list shipping packages", http.StatusInternalServerError)
+		return
+	}
+
+	// Return shipping packages
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(packages)
+}
+
+// UpdateShippingPackage handles updating a shipping package (admin only)
+func (h *ShippingHandler) UpdateShippingPackage(w http.ResponseWriter, r *http.Request) {
+	// Get package ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["shippingPackageId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid shipping package ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var input usecase.ShippingPackageInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Update shipping package
+	pkg, err := h.shippingUseCase.UpdateShippingPackage(uint(id), input)
+	if err != nil {
+		h.logger.Error("Failed to update shipping package: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Return updated shipping package
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pkg)
+}
+
+// DeleteShippingPackage handles deleting a shipping package (admin only)
+func (h *ShippingHandler) DeleteShippingPackage(w http.ResponseWriter, r *http.Request) {
+	// Get package ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["shippingPackageId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid shipping package ID", http.StatusBadRequest)
+		return
+	}
+
+	// Delete shipping package
+	if err := h.shippingUseCase.DeleteShippingPackage(uint(id)); err != nil {
+		h.logger.Error("Failed to delete shipping package: %v", err)
+		http.Error(w, "Shipping package not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateShippingProfile handles creating a new shipping profile (admin only)
+func (h *ShippingHandler) CreateShippingProfile(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var input usecase.ShippingProfileInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Create shipping profile
+	profile, err := h.shippingUseCase.CreateShippingProfile(input)
+	if err != nil {
+		h.logger.Error("Failed to create shipping profile: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Return created shipping profile
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(profile)
+}
+
+// ListShippingProfiles handles listing shipping profiles (admin only)
+func (h *ShippingHandler) ListShippingProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.shippingUseCase.ListShippingProfiles()
+	if err != nil {
+		h.logger.Error("Failed to list shipping profiles: %v", err)
+		http.Error(w, "Failed to list shipping profiles", http.StatusInternalServerError)
+		return
+	}
+
+	// Return shipping profiles
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profiles)
+}
+
+// GetShippingProfile handles retrieving a shipping profile (admin only)
+func (h *ShippingHandler) GetShippingProfile(w http.ResponseWriter, r *http.Request) {
+	// Get profile ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["shippingProfileId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid shipping profile ID", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.shippingUseCase.GetShippingProfileByID(uint(id))
+	if err != nil {
+		h.logger.Error("Failed to get shipping profile: %v", err)
+		http.Error(w, "Shipping profile not found", http.StatusNotFound)
+		return
+	}
+
+	// Return shipping profile
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+// UpdateShippingProfile handles updating a shipping profile (admin only)
+func (h *ShippingHandler) UpdateShippingProfile(w http.ResponseWriter, r *http.Request) {
+	// Get profile ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["shippingProfileId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid shipping profile ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var input usecase.ShippingProfileInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Update shipping profile
+	profile, err := h.shippingUseCase.UpdateShippingProfile(uint(id), input)
+	if err != nil {
+		h.logger.Error("Failed to update shipping profile: %v", err)
+		if err.Error() == "shipping profile not found" {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Return updated shipping profile
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+// DeleteShippingProfile handles deleting a shipping profile (admin only)
+func (h *ShippingHandler) DeleteShippingProfile(w http.ResponseWriter, r *http.Request) {
+	// Get profile ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["shippingProfileId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid shipping profile ID", http.StatusBadRequest)
+		return
+	}
+
+	// Delete shipping profile
+	if err := h.shippingUseCase.DeleteShippingProfile(uint(id)); err != nil {
+		h.logger.Error("Failed to delete shipping profile: %v", err)
+		if err.Error() == "shipping profile not found" {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err.Error() == "shipping profile is the only profile allowed by a shipping method" {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to delete shipping profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AssignCategoryShippingProfile handles setting the shipping profile of a category (admin only)
+func (h *ShippingHandler) AssignCategoryShippingProfile(w http.ResponseWriter, r *http.Request) {
+	// Get category ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["categoryId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body, a profile ID of 0 removes the category's profile
+	var input struct {
+		ShippingProfileID uint `json:"shipping_profile_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.shippingUseCase.AssignCategoryShippingProfile(uint(id), input.ShippingProfileID)
+	if err != nil {
+		h.logger.Error("Failed to assign shipping profile: %v", err)
+		if err.Error() == "category not found" {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Return updated category
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(category)
+}
+
+// CreateHoliday handles adding a public holiday to a country's business calendar (admin only)
+func (h *ShippingHandler) CreateHoliday(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var input usecase.CreateHolidayInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Create holiday
+	holiday, err := h.shippingUseCase.CreateHoliday(input)
+	if err != nil {
+		h.logger.Error("Failed to create holiday: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Return created holiday
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(holiday)
+}
+
+// ListHolidays handles listing the public holidays of a year, this year by default (admin only)
+func (h *ShippingHandler) ListHolidays(w http.ResponseWriter, r *http.Request) {
+	year := time.Now().Year()
+	if value := r.URL.Query().Get("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid year", http.StatusBadRequest)
+			return
+		}
+		year = parsed
+	}
+
+	// Get holidays
+	holidays, err := h.shippingUseCase.ListHolidays(r.URL.Query().Get("country"), year)
+	if err != nil {
+		h.logger.Error("Failed to list holidays: %v", err)
+		http.Error(w, "Failed to list holidays", http.StatusInternalServerError)
+		return
+	}
+
+	// Return holidays
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(holidays)
+}
+
+// DeleteHoliday handles deleting a public holiday (admin only)
+func (h *ShippingHandler) DeleteHoliday(w http.ResponseWriter, r *http.Request) {
+	// Get holiday ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["holidayId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid holiday ID", http.StatusBadRequest)
+		return
+	}
+
+	// Delete holiday
+	if err := h.shippingUseCase.DeleteHoliday(uint(id)); err != nil {
+		h.logger.Error("Failed to delete holiday: %v", err)
+		http.Error(w, "Holiday not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
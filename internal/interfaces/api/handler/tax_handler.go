@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+)
+
+// TaxHandler handles tax class, tax rate and VAT number requests
+type TaxHandler struct {
+	taxUseCase *usecase.TaxUseCase
+	logger     logger.Logger
+}
+
+// NewTaxHandler creates a new TaxHandler
+func NewTaxHandler(taxUseCase *usecase.TaxUseCase, logger logger.Logger) *TaxHandler {
+	return &TaxHandler{
+		taxUseCase: taxUseCase,
+		logger:     logger,
+	}
+}
+
+// ListTaxClasses handles listing tax classes (admin only)
+func (h *TaxHandler) ListTaxClasses(w http.ResponseWriter, r *http.Request) {
+	taxClasses, err := h.taxUseCase.ListTaxClasses()
+	if err != nil {
+		h.logger.Error("Failed to list tax classes: %v", err)
+		http.Error(w, "Failed to list tax classes", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taxClasses)
+}
+
+// CreateTaxClass handles creating a tax class (admin only)
+func (h *TaxHandler) CreateTaxClass(w http.ResponseWriter, r *http.Request) {
+	var input usecase.TaxClassInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	taxClass, err := h.taxUseCase.CreateTaxClass(input)
+	if err != nil {
+		h.logger.Error("Failed to create tax class: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(taxClass)
+}
+
+// UpdateTaxClass handles updating a tax class (admin only)
+func (h *TaxHandler) UpdateTaxClass(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["taxClassId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid tax class ID", http.StatusBadRequest)
+		return
+	}
+
+	var input usecase.TaxClassInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	taxClass, err := h.taxUseCase.UpdateTaxClass(uint(id), input)
+	if err != nil {
+		h.logger.Error("Failed to update tax class: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taxClass)
+}
+
+// DeleteTaxClass handles deleting a tax class (admin only)
+func (h *TaxHandler) DeleteTaxClass(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["taxClassId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid tax class ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.taxUseCase.DeleteTaxClass(uint(id)); err != nil {
+		h.logger.Error("Failed to delete tax class: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTaxRates handles listing tax rates (admin only)
+func (h *TaxHandler) ListTaxRates(w http.ResponseWriter, r *http.Request) {
+	taxRates, err := h.taxUseCase.ListTaxRates()
+	if err != nil {
+		h.logger.Error("Failed to list tax rates: %v", err)
+		http.Error(w, "Failed to list tax rates", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taxRates)
+}
+
+// CreateTaxRate handles creating a tax rate (admin only)
+func (h *TaxHandler) CreateTaxRate(w http.ResponseWriter, r *http.Request) {
+	var input usecase.TaxRateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	taxRate, err := h.taxUseCase.CreateTaxRate(input)
+	if err != nil {
+		h.logger.Error("Failed to create tax rate: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(taxRate)
+}
+
+// GetTaxRate handles getting a tax rate by ID (admin only)
+func (h *TaxHandler) GetTaxRate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["taxRateId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid tax rate ID", http.StatusBadRequest)
+		return
+	}
+
+	taxRate, err := h.taxUseCase.GetTaxRate(uint(id))
+	if err != nil {
+		h.logger.Error("Failed to get tax rate: %v", err)
+		http.Error(w, "Tax rate not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taxRate)
+}
+
+// UpdateTaxRate handles replacing a tax rate (admin only)
+func (h *TaxHandler) UpdateTaxRate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["taxRateId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid tax rate ID", http.StatusBadRequest)
+		return
+	}
+
+	var input usecase.TaxRateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	taxRate, err := h.taxUseCase.UpdateTaxRate(uint(id), input)
+	if err != nil {
+		h.logger.Error("Failed to update tax rate: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taxRate)
+}
+
+// DeleteTaxRate handles deleting a tax rate (admin only)
+func (h *TaxHandler) DeleteTaxRate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["taxRateId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid tax rate ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.taxUseCase.DeleteTaxRate(uint(id)); err != nil {
+		h.logger.Error("Failed to delete tax rate: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ValidateVATID handles checking a customer's EU VAT number at checkout
+func (h *TaxHandler) ValidateVATID(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		VATID string `json:"vat_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if input.VATID == "" {
+		http.Error(w, "VAT number is required", http.StatusBadRequest)
+		return
+	}
+
+	validation, err := h.taxUseCase.ValidateVATID(input.VATID)
+	if err != nil {
+		h.logger.Error("Failed to validate VAT number: %v", err)
+		http.Error(w, "VAT number could not be validated", http.StatusServiceUnavailable)
+		return
+	}
+
+	response := map[string]interface{}{
+		"vat_id":       validation.VATID,
+		"country_code": validation.CountryCode,
+		"valid":        validation.Valid,
+	}
+	if validation.Name != "" {
+		response["name"] = validation.Name
+	}
+	if validation.Address != "" {
+		response["address"] = validation.Address
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/dto"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+)
+
+// TrackingHandler handles shipment tracking requests
+type TrackingHandler struct {
+	trackingUseCase *usecase.TrackingUseCase
+	webhookSecret   string
+	logger          logger.Logger
+}
+
+// NewTrackingHandler creates a new TrackingHandler.
+// Tracking webhooks must carry the webhook secret in their token query parameter.
+func NewTrackingHandler(trackingUseCase *usecase.TrackingUseCase, webhookSecret string, logger logger.Logger) *TrackingHandler {
+	return &TrackingHandler{
+		trackingUseCase: trackingUseCase,
+		webhookSecret:   webhookSecret,
+		logger:          logger,
+	}
+}
+
+// GetOrderTracking handles showing the tracking of an order to the customer who placed it
+func (h *TrackingHandler) GetOrderTracking(w http.ResponseWriter, r *http.Request) {
+	orderNumber := mux.Vars(r)["orderNumber"]
+	email := r.URL.Query().Get("email")
+	if email == "" {
+		http.Error(w, "Email is required", http.StatusBadRequest)
+		return
+	}
+
+	tracking, err := h.trackingUseCase.GetOrderTracking(orderNumber, email)
+	if err != nil {
+		if err.Error() == "order not found" {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get order tracking: %v", err)
+		http.Error(w, "Failed to get order tracking", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertToOrderTrackingDTO(tracking))
+}
+
+// HandleTrackingWebhook handles tracking updates pushed by the carrier
+func (h *TrackingHandler) HandleTrackingWebhook(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if h.webhookSecret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.webhookSecret)) != 1 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.trackingUseCase.HandleWebhook(payload)
+	if err != nil {
+		// Updates of shipments the store does not know are acknowledged so the carrier stops retrying
+		if err.Error() == "order not found" {
+			h.logger.Warn("Tracking webhook for unknown shipment: %v", err)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		h.logger.Error("Failed to handle tracking webhook: %v", err)
+		http.Error(w, "Failed to handle tracking webhook", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("Tracking updated for order %s, status %s", order.OrderNumber, order.Status)
+	w.WriteHeader(http.StatusOK)
+}
+
+func convertToOrderTrackingDTO(tracking *usecase.OrderTracking) dto.OrderTrackingDTO {
+	order := tracking.Order
+
+	trackingDTO := dto.OrderTrackingDTO{
+		OrderNumber:       order.OrderNumber,
+		Status:            dto.OrderStatus(order.Status),
+		TrackingNumber:    order.TrackingCode,
+		PickupPoint:       toPickupPointDTO(order.PickupPoint),
+		EstimatedDelivery: toDeliveryEstimateDTO(order.EstimatedDelivery),
+		Events:            make([]dto.TrackingEventDTO, len(tracking.Events)),
+	}
+	if label := order.ShippingLabel; label != nil {
+		trackingDTO.Carrier = label.Carrier
+		if label.CarrierCode != "" {
+			trackingDTO.Carrier = label.CarrierCode
+		}
+		trackingDTO.TrackingURL = label.TrackingURL
+	}
+
+	for i, event := range tracking.Events {
+		trackingDTO.Events[i] = dto.TrackingEventDTO{
+			Status:      string(event.Status),
+			Description: event.Description,
+			Location:    event.Location,
+			OccurredAt:  event.OccurredAt,
+		}
+	}
+
+	return trackingDTO
+}
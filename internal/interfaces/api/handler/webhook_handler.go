@@ -15,15 +15,19 @@ import (
 	"github.com/zenfulcode/commercify/internal/application/usecase"
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/money"
+	"github.com/zenfulcode/commercify/internal/domain/service"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/infrastructure/payment"
 )
 
 // WebhookHandler handles webhook requests from payment providers
 type WebhookHandler struct {
-	cfg            *config.Config
-	orderUseCase   *usecase.OrderUseCase
-	webhookUseCase *usecase.WebhookUseCase
-	logger         logger.Logger
+	cfg                  *config.Config
+	orderUseCase         *usecase.OrderUseCase
+	webhookUseCase       *usecase.WebhookUseCase
+	paymentMethodUseCase *usecase.PaymentMethodUseCase
+	payPal               *payment.PayPalPaymentService // nil when PayPal is not enabled
+	logger               logger.Logger
 }
 
 // NewWebhookHandler creates a new WebhookHandler
@@ -31,13 +35,17 @@ func NewWebhookHandler(
 	cfg *config.Config,
 	orderUseCase *usecase.OrderUseCase,
 	webhookUseCase *usecase.WebhookUseCase,
+	paymentMethodUseCase *usecase.PaymentMethodUseCase,
+	payPal *payment.PayPalPaymentService,
 	logger logger.Logger,
 ) *WebhookHandler {
 	return &WebhookHandler{
-		cfg:            cfg,
-		orderUseCase:   orderUseCase,
-		webhookUseCase: webhookUseCase,
-		logger:         logger,
+		cfg:                  cfg,
+		orderUseCase:         orderUseCase,
+		webhookUseCase:       webhookUseCase,
+		paymentMethodUseCase: paymentMethodUseCase,
+		payPal:               payPal,
+		logger:               logger,
 	}
 }
 
@@ -204,12 +212,22 @@ func (h *WebhookHandler) HandleMobilePayCaptured(event *models.WebhookEvent) err
 
 	h.logger.Info("MobilePay payment captured for order %d", orderID)
 
+	// Captures issued through the API have already been recorded, including partial captures of paid orders
+	order, err := h.orderUseCase.GetOrderByID(orderID)
+	if err != nil {
+		h.logger.Error("Failed to get order for MobilePay payment: %v", err)
+		return err
+	}
+	if order.CapturedAmount > 0 {
+		return h.recordMobilePayPaymentTransaction(order, entity.TransactionStatusSuccessful, event)
+	}
+
 	input := usecase.UpdateOrderStatusInput{
 		OrderID: orderID,
 		Status:  entity.OrderStatusCaptured,
 	}
 
-	order, err := h.orderUseCase.UpdateOrderStatus(input)
+	order, err = h.orderUseCase.UpdateOrderStatus(input)
 	if err != nil {
 		h.logger.Error("Failed to update order status for MobilePay payment: %v", err)
 		return err
@@ -399,6 +417,14 @@ func (h *WebhookHandler) HandleStripeWebhook(w http.ResponseWriter, r *http.Requ
 		h.handleDisputeCreated(event)
 	case "charge.dispute.closed":
 		h.handleDisputeClosed(event)
+	case "checkout.session.completed":
+		h.handleCheckoutSessionCompleted(event)
+	case "checkout.session.expired":
+		h.handleCheckoutSessionExpired(event)
+	case "payment_method.attached", "payment_method.updated", "payment_method.automatically_updated":
+		h.handlePaymentMethodAttached(event)
+	case "payment_method.detached":
+		h.handlePaymentMethodDetached(event)
 	default:
 		h.logger.Info("Received unhandled webhook event: %s", event.Type)
 	}
@@ -431,30 +457,37 @@ func (h *WebhookHandler) handlePaymentSucceeded(event stripe.Event) {
 		return
 	}
 
-	// Record the successful payment transaction
-	txn, err := entity.NewPaymentTransaction(
-		uint(orderID),
-		paymentIntent.ID,
-		entity.TransactionTypeAuthorize,
-		entity.TransactionStatusSuccessful,
-		paymentIntent.Amount,
-		string(paymentIntent.Currency),
-		"stripe",
-	)
-
-	if err == nil {
-		// Add raw response for debugging
-		txn.SetRawResponse(string(event.Data.Raw))
-
-		// Add metadata
-		if method, exists := paymentIntent.Metadata["method"]; exists {
-			txn.AddMetadata("payment_method", method)
-		}
-
-		// Record the transaction
-		err = h.orderUseCase.RecordPaymentTransaction(txn)
-		if err != nil {
-			h.logger.Error("Failed to record payment transaction: %v", err)
+	// Complete the pending authorization of payments confirmed by the storefront
+	metadata := map[string]string{}
+	if method, exists := paymentIntent.Metadata["method"]; exists {
+		metadata["payment_method"] = method
+	}
+	if err := h.orderUseCase.UpdatePaymentTransaction(paymentIntent.ID, entity.TransactionStatusSuccessful, metadata); err != nil {
+		// Record the successful payment transaction
+		txn, err := entity.NewPaymentTransaction(
+			uint(orderID),
+			paymentIntent.ID,
+			entity.TransactionTypeAuthorize,
+			entity.TransactionStatusSuccessful,
+			paymentIntent.Amount,
+			string(paymentIntent.Currency),
+			"stripe",
+		)
+
+		if err == nil {
+			// Add raw response for debugging
+			txn.SetRawResponse(string(event.Data.Raw))
+
+			// Add metadata
+			for key, value := range metadata {
+				txn.AddMetadata(key, value)
+			}
+
+			// Record the transaction
+			err = h.orderUseCase.RecordPaymentTransaction(txn)
+			if err != nil {
+				h.logger.Error("Failed to record payment transaction: %v", err)
+			}
 		}
 	}
 
@@ -473,6 +506,79 @@ func (h *WebhookHandler) handlePaymentSucceeded(event stripe.Event) {
 	h.logger.Info("Payment succeeded for order %d", orderID)
 }
 
+// handleCheckoutSessionCompleted handles the checkout.session.completed event
+func (h *WebhookHandler) handleCheckoutSessionCompleted(event stripe.Event) {
+	var checkoutSession stripe.CheckoutSession
+	err := json.Unmarshal(event.Data.Raw, &checkoutSession)
+	if err != nil {
+		h.logger.Error("Failed to parse checkout session: %v", err)
+		return
+	}
+
+	orderID, err := checkoutSessionOrderID(&checkoutSession)
+	if err != nil {
+		h.logger.Error("Invalid order ID in checkout session %s: %v", checkoutSession.ID, err)
+		return
+	}
+
+	// Delayed payment methods complete the session before the payment succeeds
+	if checkoutSession.PaymentStatus == stripe.CheckoutSessionPaymentStatusUnpaid {
+		h.logger.Info("Checkout session completed for order %d, awaiting payment", orderID)
+		return
+	}
+
+	paymentID := checkoutSession.ID
+	if checkoutSession.PaymentIntent != nil && checkoutSession.PaymentIntent.ID != "" {
+		paymentID = checkoutSession.PaymentIntent.ID
+	}
+
+	_, err = h.orderUseCase.CompleteHostedPayment(orderID, checkoutSession.ID, paymentID)
+	if err != nil {
+		h.logger.Error("Failed to complete checkout payment for order %d: %v", orderID, err)
+		return
+	}
+
+	h.logger.Info("Checkout session completed for order %d, payment %s", orderID, paymentID)
+}
+
+// handleCheckoutSessionExpired handles the checkout.session.expired event
+func (h *WebhookHandler) handleCheckoutSessionExpired(event stripe.Event) {
+	var checkoutSession stripe.CheckoutSession
+	err := json.Unmarshal(event.Data.Raw, &checkoutSession)
+	if err != nil {
+		h.logger.Error("Failed to parse checkout session: %v", err)
+		return
+	}
+
+	orderID, err := checkoutSessionOrderID(&checkoutSession)
+	if err != nil {
+		h.logger.Error("Invalid order ID in checkout session %s: %v", checkoutSession.ID, err)
+		return
+	}
+
+	order, err := h.orderUseCase.ExpireHostedPayment(orderID, checkoutSession.ID)
+	if err != nil {
+		h.logger.Error("Failed to expire checkout payment for order %d: %v", orderID, err)
+		return
+	}
+
+	h.logger.Info("Checkout session expired for order %d, order is %s", orderID, order.Status)
+}
+
+// checkoutSessionOrderID returns the order a checkout session was created for
+func checkoutSessionOrderID(checkoutSession *stripe.CheckoutSession) (uint, error) {
+	orderIDStr := checkoutSession.Metadata["order_id"]
+	if orderIDStr == "" {
+		orderIDStr = checkoutSession.ClientReferenceID
+	}
+
+	orderID, err := strconv.ParseUint(orderIDStr, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(orderID), nil
+}
+
 // handlePaymentFailed handles the payment_intent.payment_failed event
 func (h *WebhookHandler) handlePaymentFailed(event stripe.Event) {
 	var paymentIntent stripe.PaymentIntent
@@ -523,6 +629,12 @@ func (h *WebhookHandler) handlePaymentFailed(event stripe.Event) {
 		}
 	}
 
+	// A declined payment can be retried with another payment method, so the order stays open
+	if paymentIntent.Status == stripe.PaymentIntentStatusRequiresPaymentMethod {
+		h.logger.Info("Payment attempt failed for order %d, awaiting another payment method", orderID)
+		return
+	}
+
 	// Update order status to payment_failed
 	input := usecase.UpdateOrderStatusInput{
 		OrderID: uint(orderID),
@@ -790,7 +902,7 @@ func (h *WebhookHandler) handleRefund(event stripe.Event) {
 	h.logger.Info("Refund processed for order %d, payment %s, amount: %v",
 		order.ID,
 		charge.PaymentIntent.ID,
-		money.FromCents(charge.AmountRefunded))
+		money.New(charge.AmountRefunded, order.Currency))
 }
 
 // handleDisputeCreated handles the charge.dispute.created event
@@ -832,3 +944,313 @@ func (h *WebhookHandler) handleDisputeClosed(event stripe.Event) {
 		dispute.PaymentIntent.ID,
 		dispute.Status)
 }
+
+// handlePaymentMethodAttached handles the payment_method.attached and updated events,
+// saving cards attached to the customer of a user
+func (h *WebhookHandler) handlePaymentMethodAttached(event stripe.Event) {
+	var paymentMethod stripe.PaymentMethod
+	err := json.Unmarshal(event.Data.Raw, &paymentMethod)
+	if err != nil {
+		h.logger.Error("Failed to parse payment method: %v", err)
+		return
+	}
+
+	// Only cards are saved
+	if paymentMethod.Card == nil {
+		return
+	}
+
+	customerID := ""
+	if paymentMethod.Customer != nil {
+		customerID = paymentMethod.Customer.ID
+	}
+
+	method, err := h.paymentMethodUseCase.SavePaymentMethod(usecase.SavePaymentMethodInput{
+		Provider:         service.PaymentProviderStripe,
+		CustomerID:       customerID,
+		ProviderMethodID: paymentMethod.ID,
+		Brand:            string(paymentMethod.Card.Brand),
+		Last4:            paymentMethod.Card.Last4,
+		ExpMonth:         int(paymentMethod.Card.ExpMonth),
+		ExpYear:          int(paymentMethod.Card.ExpYear),
+	})
+	if err != nil {
+		h.logger.Error("Failed to save payment method %s: %v", paymentMethod.ID, err)
+		return
+	}
+	if method == nil {
+		h.logger.Info("Payment method %s is not attached to the customer of a user", paymentMethod.ID)
+		return
+	}
+
+	h.logger.Info("Payment method %s saved for user %d", paymentMethod.ID, method.UserID)
+}
+
+// handlePaymentMethodDetached handles the payment_method.detached event
+func (h *WebhookHandler) handlePaymentMethodDetached(event stripe.Event) {
+	var paymentMethod stripe.PaymentMethod
+	err := json.Unmarshal(event.Data.Raw, &paymentMethod)
+	if err != nil {
+		h.logger.Error("Failed to parse payment method: %v", err)
+		return
+	}
+
+	if err := h.paymentMethodUseCase.RemovePaymentMethod(paymentMethod.ID); err != nil {
+		h.logger.Error("Failed to remove payment method %s: %v", paymentMethod.ID, err)
+		return
+	}
+
+	h.logger.Info("Payment method %s detached", paymentMethod.ID)
+}
+
+// HandlePayPalWebhook handles webhook events from PayPal
+func (h *WebhookHandler) HandlePayPalWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.payPal == nil {
+		http.Error(w, "PayPal payments are not enabled", http.StatusNotFound)
+		return
+	}
+
+	// Read the request body
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Error("Failed to read webhook body: %v", err)
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	// Verify the webhook signature with PayPal
+	event, err := h.payPal.VerifyWebhook(r.Header, body)
+	if err != nil {
+		h.logger.Error("Failed to verify PayPal webhook: %v", err)
+		http.Error(w, "Failed to verify webhook signature", http.StatusBadRequest)
+		return
+	}
+
+	resource, err := event.ParseResource()
+	if err != nil {
+		h.logger.Error("Failed to parse PayPal webhook resource: %v", err)
+		http.Error(w, "Invalid webhook resource", http.StatusBadRequest)
+		return
+	}
+
+	// Handle different event types
+	switch event.EventType {
+	case payment.PayPalEventOrderApproved:
+		h.handlePayPalOrderApproved(resource, body)
+	case payment.PayPalEventAuthorizationVoided:
+		h.handlePayPalAuthorizationVoided(resource, body)
+	case payment.PayPalEventCaptureCompleted:
+		h.handlePayPalCaptureCompleted(resource, body)
+	case payment.PayPalEventCaptureDenied:
+		h.handlePayPalCaptureDenied(resource, body)
+	case payment.PayPalEventCaptureRefunded:
+		h.handlePayPalCaptureRefunded(resource, body)
+	default:
+		h.logger.Info("Received unhandled PayPal webhook event: %s", event.EventType)
+	}
+
+	// Return a successful response
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// handlePayPalOrderApproved authorizes an order the buyer approved on PayPal
+func (h *WebhookHandler) handlePayPalOrderApproved(resource *payment.PayPalWebhookResource, raw []byte) {
+	orderID, err := resource.OrderID()
+	if err != nil {
+		h.logger.Error("Failed to get order ID from PayPal order: %v", err)
+		return
+	}
+
+	authorization, err := h.payPal.AuthorizeOrder(resource.ID)
+	if err != nil {
+		h.logger.Error("Failed to authorize PayPal order %s: %v", resource.ID, err)
+
+		err = h.orderUseCase.UpdatePaymentTransaction(resource.ID, entity.TransactionStatusFailed, map[string]string{
+			"error_message": err.Error(),
+		})
+		if err != nil {
+			h.logger.Error("Failed to record payment transaction: %v", err)
+		}
+		return
+	}
+
+	// Update the order status to paid
+	input := usecase.UpdateOrderStatusInput{
+		OrderID: orderID,
+		Status:  entity.OrderStatusPaid,
+	}
+
+	_, err = h.orderUseCase.UpdateOrderStatus(input)
+	if err != nil {
+		h.logger.Error("Failed to update order status for PayPal payment: %v", err)
+		return
+	}
+
+	err = h.orderUseCase.UpdatePaymentTransaction(resource.ID, entity.TransactionStatusSuccessful, map[string]string{
+		"authorization_id": authorization.ID,
+		"raw_event":        string(raw),
+	})
+	if err != nil {
+		h.logger.Error("Failed to record payment transaction: %v", err)
+	}
+
+	h.logger.Info("PayPal payment authorized for order %d", orderID)
+}
+
+// handlePayPalAuthorizationVoided cancels an order whose authorization was voided or expired on PayPal
+func (h *WebhookHandler) handlePayPalAuthorizationVoided(resource *payment.PayPalWebhookResource, raw []byte) {
+	order, err := h.payPalOrder(resource)
+	if err != nil {
+		h.logger.Error("Failed to find order for PayPal authorization %s: %v", resource.ID, err)
+		return
+	}
+
+	// Voids issued through the API have already cancelled the order
+	if order.Status != entity.OrderStatusPendingAction && order.Status != entity.OrderStatusPaid {
+		h.logger.Info("PayPal authorization voided for order %d in status %s", order.ID, order.Status)
+		return
+	}
+
+	h.recordPayPalTransaction(order, resource, entity.TransactionTypeCancel, entity.TransactionStatusSuccessful, raw)
+
+	input := usecase.UpdateOrderStatusInput{
+		OrderID: order.ID,
+		Status:  entity.OrderStatusCancelled,
+	}
+
+	_, err = h.orderUseCase.UpdateOrderStatus(input)
+	if err != nil {
+		h.logger.Error("Failed to cancel order for PayPal payment: %v", err)
+		return
+	}
+
+	h.logger.Info("PayPal payment voided for order %d", order.ID)
+}
+
+// handlePayPalCaptureCompleted marks an order captured when it was captured outside the store
+func (h *WebhookHandler) handlePayPalCaptureCompleted(resource *payment.PayPalWebhookResource, raw []byte) {
+	order, err := h.payPalOrder(resource)
+	if err != nil {
+		h.logger.Error("Failed to find order for PayPal capture %s: %v", resource.ID, err)
+		return
+	}
+
+	// Captures issued through the API have already been recorded, including partial captures of paid orders
+	if order.Status != entity.OrderStatusPaid || order.CapturedAmount > 0 {
+		h.logger.Info("PayPal payment captured for order %d in status %s", order.ID, order.Status)
+		return
+	}
+
+	h.recordPayPalTransaction(order, resource, entity.TransactionTypeCapture, entity.TransactionStatusSuccessful, raw)
+
+	input := usecase.UpdateOrderStatusInput{
+		OrderID: order.ID,
+		Status:  entity.OrderStatusCaptured,
+	}
+
+	_, err = h.orderUseCase.UpdateOrderStatus(input)
+	if err != nil {
+		h.logger.Error("Failed to update order status for PayPal payment: %v", err)
+		return
+	}
+
+	h.logger.Info("PayPal payment captured for order %d", order.ID)
+}
+
+// handlePayPalCaptureDenied records a capture PayPal denied
+func (h *WebhookHandler) handlePayPalCaptureDenied(resource *payment.PayPalWebhookResource, raw []byte) {
+	order, err := h.payPalOrder(resource)
+	if err != nil {
+		h.logger.Error("Failed to find order for PayPal capture %s: %v", resource.ID, err)
+		return
+	}
+
+	h.recordPayPalTransaction(order, resource, entity.TransactionTypeCapture, entity.TransactionStatusFailed, raw)
+
+	h.logger.Warn("PayPal capture denied for order %d", order.ID)
+}
+
+// handlePayPalCaptureRefunded records a refund and marks the order refunded once the whole amount is refunded
+func (h *WebhookHandler) handlePayPalCaptureRefunded(resource *payment.PayPalWebhookResource, raw []byte) {
+	order, err := h.payPalOrder(resource)
+	if err != nil {
+		h.logger.Error("Failed to find order for PayPal refund %s: %v", resource.ID, err)
+		return
+	}
+
+	if order.Status == entity.OrderStatusRefunded {
+		h.logger.Info("PayPal refund received for refunded order %d", order.ID)
+		return
+	}
+
+	h.recordPayPalTransaction(order, resource, entity.TransactionTypeRefund, entity.TransactionStatusSuccessful, raw)
+
+	totalRefunded, err := resource.SellerPayableBreakdown.TotalRefundedAmount.Cents()
+	if err != nil || totalRefunded < order.FinalAmount {
+		h.logger.Info("PayPal payment partially refunded for order %d", order.ID)
+		return
+	}
+
+	input := usecase.UpdateOrderStatusInput{
+		OrderID: order.ID,
+		Status:  entity.OrderStatusRefunded,
+	}
+
+	_, err = h.orderUseCase.UpdateOrderStatus(input)
+	if err != nil {
+		h.logger.Error("Failed to update order status to refunded: %v", err)
+		return
+	}
+
+	h.logger.Info("PayPal payment refunded for order %d", order.ID)
+}
+
+// payPalOrder finds the order a PayPal payment resource belongs to
+func (h *WebhookHandler) payPalOrder(resource *payment.PayPalWebhookResource) (*entity.Order, error) {
+	orderID, err := resource.OrderID()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.orderUseCase.GetOrderByID(orderID)
+}
+
+// recordPayPalTransaction records a transaction for a PayPal authorization, capture or refund
+func (h *WebhookHandler) recordPayPalTransaction(
+	order *entity.Order,
+	resource *payment.PayPalWebhookResource,
+	transactionType entity.TransactionType,
+	status entity.TransactionStatus,
+	raw []byte,
+) {
+	amount, _ := resource.Amount.Cents()
+
+	currency := resource.Amount.CurrencyCode
+	if currency == "" {
+		currency = order.Currency
+	}
+
+	txn, err := entity.NewPaymentTransaction(
+		order.ID,
+		resource.ID,
+		transactionType,
+		status,
+		amount,
+		currency,
+		"paypal",
+	)
+	if err != nil {
+		h.logger.Error("Failed to create payment transaction: %v", err)
+		return
+	}
+
+	txn.SetRawResponse(string(raw))
+	if paypalOrderID := resource.SupplementaryData.RelatedIDs.OrderID; paypalOrderID != "" {
+		txn.AddMetadata("paypal_order_id", paypalOrderID)
+	}
+
+	if err := h.orderUseCase.RecordPaymentTransaction(txn); err != nil {
+		h.logger.Error("Failed to record payment transaction: %v", err)
+	}
+}
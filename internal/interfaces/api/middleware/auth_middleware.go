@@ -68,11 +68,41 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 	})
 }
 
+// OptionalAuthenticate adds the user to the request context when a valid token is present,
+// and otherwise lets the request through anonymously
+func (m *AuthMiddleware) OptionalAuthenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := m.jwtService.ValidateToken(strings.TrimPrefix(authHeader, "Bearer "))
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, emailKey, claims.Email)
+		ctx = context.WithValue(ctx, roleKey, claims.Role)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RoleFromContext returns the role of the authenticated user
+func RoleFromContext(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(roleKey).(string)
+	return role, ok
+}
+
 // AdminOnly middleware ensures the user has admin role
 func AdminOnly(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Get role from context
-		role, ok := r.Context().Value(roleKey).(string)
+		role, ok := RoleFromContext(r.Context())
 		if !ok || role != "admin" {
 			http.Error(w, "Admin access required", http.StatusForbidden)
 			return
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/common"
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+)
+
+// IdempotencyKeyHeader is the header clients send to make a mutating request safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyMiddleware replays the stored response to mutating requests repeated with the same Idempotency-Key
+type IdempotencyMiddleware struct {
+	idempotencyUseCase *usecase.IdempotencyUseCase
+	logger             logger.Logger
+}
+
+// NewIdempotencyMiddleware creates a new IdempotencyMiddleware
+func NewIdempotencyMiddleware(idempotencyUseCase *usecase.IdempotencyUseCase, logger logger.Logger) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		idempotencyUseCase: idempotencyUseCase,
+		logger:             logger,
+	}
+}
+
+// Handle handles POST, PUT, PATCH and DELETE requests sent with an Idempotency-Key header.
+// The first request is handled and its response stored; repeats get the stored response,
+// and reusing the key for a different request is rejected.
+func (m *IdempotencyMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" || !isMutating(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		idempotencyKey, replay, err := m.idempotencyUseCase.BeginRequest(clientScope(r), key, r.Method, r.URL.Path, body)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrIdempotencyKeyReused):
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			case errors.Is(err, usecase.ErrIdempotencyKeyInProgress):
+				http.Error(w, err.Error(), http.StatusConflict)
+			case errors.Is(err, entity.ErrIdempotencyKeyTooLong):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			default:
+				m.logger.Error("Failed to check idempotency key: %v", err)
+				http.Error(w, "Failed to check idempotency key", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		if replay {
+			if idempotencyKey.ContentType != "" {
+				w.Header().Set("Content-Type", idempotencyKey.ContentType)
+			}
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(idempotencyKey.StatusCode)
+			w.Write(idempotencyKey.ResponseBody)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		if err := m.idempotencyUseCase.FinishRequest(idempotencyKey, recorder.statusCode, w.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			m.logger.Error("Failed to store response for idempotency key: %v", err)
+		}
+	})
+}
+
+// isMutating checks if requests of a method change state
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// clientScope identifies the client of a request by its credentials, guest session or address,
+// so clients can't replay each other's responses
+func clientScope(r *http.Request) string {
+	client := "address:" + r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		client = "address:" + host
+	}
+
+	if authHeader := r.Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		client = "token:" + strings.TrimPrefix(authHeader, "Bearer ")
+	} else if cookie, err := r.Cookie(common.SessionCookieName); err == nil && cookie.Value != "" {
+		client = "session:" + cookie.Value
+	}
+
+	hash := sha256.Sum256([]byte(client))
+	return hex.EncodeToString(hash[:])
+}
+
+// responseRecorder passes a response through while keeping a copy of its status and body
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -11,8 +11,11 @@ import (
 	"github.com/gkhaavik/vipps-mobilepay-sdk/pkg/webhooks"
 	"github.com/gorilla/mux"
 	"github.com/zenfulcode/commercify/config"
+	"github.com/zenfulcode/commercify/internal/application/usecase"
+	"github.com/zenfulcode/commercify/internal/domain/money"
 	"github.com/zenfulcode/commercify/internal/infrastructure/container"
 	"github.com/zenfulcode/commercify/internal/infrastructure/logger"
+	"github.com/zenfulcode/commercify/internal/infrastructure/scheduler"
 	"github.com/zenfulcode/commercify/internal/interfaces/api/handler"
 	"github.com/zenfulcode/commercify/internal/interfaces/api/middleware"
 )
@@ -24,6 +27,7 @@ type Server struct {
 	httpServer *http.Server
 	logger     logger.Logger
 	container  container.Container
+	scheduler  *scheduler.Scheduler
 }
 
 // NewServer creates a new API server
@@ -55,6 +59,7 @@ func NewServer(cfg *config.Config, db *sql.DB, logger logger.Logger) *Server {
 	// router.Use(corsMiddleware.ApplyCors)
 
 	server.setupRoutes()
+	server.setupJobs()
 
 	// Create HTTP server
 	server.httpServer = &http.Server{
@@ -79,19 +84,27 @@ func (s *Server) setupRoutes() {
 	discountHandler := s.container.Handlers().DiscountHandler()
 	shippingHandler := s.container.Handlers().ShippingHandler()
 	currencyHandler := s.container.Handlers().CurrencyHandler()
+	priceListHandler := s.container.Handlers().PriceListHandler()
+	taxHandler := s.container.Handlers().TaxHandler()
+	invoiceHandler := s.container.Handlers().InvoiceHandler()
+	trackingHandler := s.container.Handlers().TrackingHandler()
 
 	// Extract middleware from container
 	authMiddleware := s.container.Middlewares().AuthMiddleware()
+	idempotencyMiddleware := s.container.Middlewares().IdempotencyMiddleware()
 
 	// Register routes
 	api := s.router.PathPrefix("/api").Subrouter()
 
+	// Replay the response to mutating requests retried with the same Idempotency-Key header
+	api.Use(idempotencyMiddleware.Handle)
+
 	// Public routes
 	api.HandleFunc("/auth/register", userHandler.Register).Methods(http.MethodPost)
 	api.HandleFunc("/auth/signin", userHandler.Login).Methods(http.MethodPost)
 	api.HandleFunc("/products/{productId:[0-9]+}", productHandler.GetProduct).Methods(http.MethodGet)
 
-	api.HandleFunc("/products/search", productHandler.SearchProducts).Methods(http.MethodGet)
+	api.Handle("/products/search", authMiddleware.OptionalAuthenticate(http.HandlerFunc(productHandler.SearchProducts))).Methods(http.MethodGet)
 	api.HandleFunc("/categories", productHandler.ListCategories).Methods(http.MethodGet)
 	api.HandleFunc("/payment/providers", paymentHandler.GetAvailablePaymentProviders).Methods(http.MethodGet)
 
@@ -104,32 +117,45 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/currencies/default", currencyHandler.GetDefaultCurrency).Methods(http.MethodGet)
 	api.HandleFunc("/currencies/convert", currencyHandler.ConvertAmount).Methods(http.MethodPost)
 
+	// Public tax routes
+	api.HandleFunc("/tax/validate-vat", taxHandler.ValidateVATID).Methods(http.MethodPost)
+
 	// Public shipping routes
 	api.HandleFunc("/shipping/methods", shippingHandler.ListShippingMethods).Methods(http.MethodGet)
 	api.HandleFunc("/shipping/methods/{shippingMethodId:[0-9]+}", shippingHandler.GetShippingMethodByID).Methods(http.MethodGet)
 	api.HandleFunc("/shipping/options", shippingHandler.CalculateShippingOptions).Methods(http.MethodPost)
+	api.HandleFunc("/shipping/pickup-points", shippingHandler.ListPickupPoints).Methods(http.MethodGet)
 	api.HandleFunc("/shipping/rates/{shippingRateId:[0-9]+}/cost", shippingHandler.GetShippingCost).Methods(http.MethodPost)
 
+	// Public shipment tracking, keyed by order number and email
+	api.HandleFunc("/tracking/{orderNumber}", trackingHandler.GetOrderTracking).Methods(http.MethodGet)
+
 	// Guest cart routes (no authentication required)
 	api.HandleFunc("/guest/cart", cartHandler.GetCart).Methods(http.MethodGet)
 	api.HandleFunc("/guest/cart/items", cartHandler.AddToCart).Methods(http.MethodPost)
 	api.HandleFunc("/guest/cart/items/{productId:[0-9]+}", cartHandler.UpdateCartItem).Methods(http.MethodPut)
 	api.HandleFunc("/guest/cart/items/{productId:[0-9]+}", cartHandler.RemoveFromCart).Methods(http.MethodDelete)
 	api.HandleFunc("/guest/cart", cartHandler.ClearCart).Methods(http.MethodDelete)
+	api.HandleFunc("/guest/cart/discount", cartHandler.ApplyDiscount).Methods(http.MethodPost)
+	api.HandleFunc("/guest/cart/discount", cartHandler.RemoveDiscount).Methods(http.MethodDelete)
 
 	// Guest checkout route
 	api.HandleFunc("/guest/orders", orderHandler.CreateOrder).Methods(http.MethodPost)
 	api.HandleFunc("/guest/orders/{orderId:[0-9]+}/payment", orderHandler.ProcessPayment).Methods(http.MethodPost)
+	api.HandleFunc("/guest/orders/{orderId:[0-9]+}/payment-intent", orderHandler.CreatePaymentIntent).Methods(http.MethodPost)
 
 	// Convert guest cart to user cart after login
 	api.HandleFunc("/guest/cart/convert", cartHandler.ConvertGuestCartToUserCart).Methods(http.MethodPost)
 
 	// Webhooks
 	api.HandleFunc("/webhooks/stripe", webhookHandler.HandleStripeWebhook).Methods(http.MethodPost)
+	api.HandleFunc("/webhooks/paypal", webhookHandler.HandlePayPalWebhook).Methods(http.MethodPost)
+	api.HandleFunc("/webhooks/tracking", trackingHandler.HandleTrackingWebhook).Methods(http.MethodPost)
 
 	// Setup payment provider webhooks
 	s.setupMobilePayWebhooks(api, webhookHandler)
 	s.setupStripeWebhooks(api, webhookHandler)
+	s.setupPayPalWebhooks()
 
 	// Protected routes
 	protected := api.PathPrefix("").Subrouter()
@@ -146,12 +172,25 @@ func (s *Server) setupRoutes() {
 	protected.HandleFunc("/cart/items/{productId:[0-9]+}", cartHandler.UpdateCartItem).Methods(http.MethodPut)
 	protected.HandleFunc("/cart/items/{productId:[0-9]+}", cartHandler.RemoveFromCart).Methods(http.MethodDelete)
 	protected.HandleFunc("/cart", cartHandler.ClearCart).Methods(http.MethodDelete)
+	protected.HandleFunc("/cart/discount", cartHandler.ApplyDiscount).Methods(http.MethodPost)
+	protected.HandleFunc("/cart/discount", cartHandler.RemoveDiscount).Methods(http.MethodDelete)
 
 	// Order routes
 	protected.HandleFunc("/orders", orderHandler.CreateOrder).Methods(http.MethodPost)
 	protected.HandleFunc("/orders/{orderId:[0-9]+}", orderHandler.GetOrder).Methods(http.MethodGet)
 	protected.HandleFunc("/orders", orderHandler.ListOrders).Methods(http.MethodGet)
 	protected.HandleFunc("/orders/{orderId:[0-9]+}/payment", orderHandler.ProcessPayment).Methods(http.MethodPost)
+	protected.HandleFunc("/orders/{orderId:[0-9]+}/payment-intent", orderHandler.CreatePaymentIntent).Methods(http.MethodPost)
+	protected.HandleFunc("/payments/setup-intent", paymentHandler.CreateSetupIntent).Methods(http.MethodPost)
+
+	// Saved payment method routes
+	protected.HandleFunc("/payment-methods", paymentHandler.ListPaymentMethods).Methods(http.MethodGet)
+	protected.HandleFunc("/payment-methods/{paymentMethodId:[0-9]+}/default", paymentHandler.SetDefaultPaymentMethod).Methods(http.MethodPut)
+	protected.HandleFunc("/payment-methods/{paymentMethodId:[0-9]+}", paymentHandler.DeletePaymentMethod).Methods(http.MethodDelete)
+
+	// Invoice routes, for the order's customer and admins
+	protected.HandleFunc("/orders/{orderId:[0-9]+}/invoices", invoiceHandler.ListOrderInvoices).Methods(http.MethodGet)
+	protected.HandleFunc("/invoices/{invoiceId:[0-9]+}", invoiceHandler.DownloadInvoice).Methods(http.MethodGet)
 
 	// Discount routes
 	protected.HandleFunc("/discounts", discountHandler.CreateDiscount).Methods(http.MethodPost)
@@ -170,18 +209,51 @@ func (s *Server) setupRoutes() {
 	admin.HandleFunc("/orders", orderHandler.ListAllOrders).Methods(http.MethodGet)
 	admin.HandleFunc("/orders/{orderId:[0-9]+}/status", orderHandler.UpdateOrderStatus).Methods(http.MethodPut)
 
+	// Discount reporting routes (admin only)
+	admin.HandleFunc("/discounts/{discountId:[0-9]+}/report", discountHandler.GetDiscountReport).Methods(http.MethodGet)
+	admin.HandleFunc("/discounts/campaigns/{campaign}/report", discountHandler.GetCampaignReport).Methods(http.MethodGet)
+
 	// Admin currency routes
 	admin.HandleFunc("/currencies/all", currencyHandler.ListCurrencies).Methods(http.MethodGet)
 	admin.HandleFunc("/currencies", currencyHandler.CreateCurrency).Methods(http.MethodPost)
 	admin.HandleFunc("/currencies", currencyHandler.UpdateCurrency).Methods(http.MethodPut)
 	admin.HandleFunc("/currencies", currencyHandler.DeleteCurrency).Methods(http.MethodDelete)
 	admin.HandleFunc("/currencies/default", currencyHandler.SetDefaultCurrency).Methods(http.MethodPut)
+	admin.HandleFunc("/currencies/rates/sync", currencyHandler.SyncExchangeRates).Methods(http.MethodPost)
+	admin.HandleFunc("/currencies/rates/history", currencyHandler.GetExchangeRateHistory).Methods(http.MethodGet)
+	admin.HandleFunc("/currencies/rates/pending", currencyHandler.ListPendingExchangeRates).Methods(http.MethodGet)
+	admin.HandleFunc("/currencies/rates/{rateId:[0-9]+}/confirm", currencyHandler.ConfirmExchangeRate).Methods(http.MethodPost)
+	admin.HandleFunc("/currencies/rates/{rateId:[0-9]+}/reject", currencyHandler.RejectExchangeRate).Methods(http.MethodPost)
+
+	// Customer group and price list routes (admin only)
+	admin.HandleFunc("/customer-groups", priceListHandler.ListCustomerGroups).Methods(http.MethodGet)
+	admin.HandleFunc("/customer-groups", priceListHandler.CreateCustomerGroup).Methods(http.MethodPost)
+	admin.HandleFunc("/customer-groups/{groupId:[0-9]+}", priceListHandler.UpdateCustomerGroup).Methods(http.MethodPut)
+	admin.HandleFunc("/customer-groups/{groupId:[0-9]+}", priceListHandler.DeleteCustomerGroup).Methods(http.MethodDelete)
+	admin.HandleFunc("/users/{userId:[0-9]+}/customer-group", priceListHandler.AssignCustomerGroup).Methods(http.MethodPut)
+	admin.HandleFunc("/price-lists", priceListHandler.ListPriceLists).Methods(http.MethodGet)
+	admin.HandleFunc("/price-lists", priceListHandler.CreatePriceList).Methods(http.MethodPost)
+	admin.HandleFunc("/price-lists/{priceListId:[0-9]+}", priceListHandler.GetPriceList).Methods(http.MethodGet)
+	admin.HandleFunc("/price-lists/{priceListId:[0-9]+}", priceListHandler.UpdatePriceList).Methods(http.MethodPut)
+	admin.HandleFunc("/price-lists/{priceListId:[0-9]+}", priceListHandler.DeletePriceList).Methods(http.MethodDelete)
+
+	// Tax class and tax rate routes (admin only)
+	admin.HandleFunc("/tax-classes", taxHandler.ListTaxClasses).Methods(http.MethodGet)
+	admin.HandleFunc("/tax-classes", taxHandler.CreateTaxClass).Methods(http.MethodPost)
+	admin.HandleFunc("/tax-classes/{taxClassId:[0-9]+}", taxHandler.UpdateTaxClass).Methods(http.MethodPut)
+	admin.HandleFunc("/tax-classes/{taxClassId:[0-9]+}", taxHandler.DeleteTaxClass).Methods(http.MethodDelete)
+	admin.HandleFunc("/tax-rates", taxHandler.ListTaxRates).Methods(http.MethodGet)
+	admin.HandleFunc("/tax-rates", taxHandler.CreateTaxRate).Methods(http.MethodPost)
+	admin.HandleFunc("/tax-rates/{taxRateId:[0-9]+}", taxHandler.GetTaxRate).Methods(http.MethodGet)
+	admin.HandleFunc("/tax-rates/{taxRateId:[0-9]+}", taxHandler.UpdateTaxRate).Methods(http.MethodPut)
+	admin.HandleFunc("/tax-rates/{taxRateId:[0-9]+}", taxHandler.DeleteTaxRate).Methods(http.MethodDelete)
 
 	// Shipping management routes (admin only)
 	admin.HandleFunc("/shipping/methods", shippingHandler.CreateShippingMethod).Methods(http.MethodPost)
 	admin.HandleFunc("/shipping/methods/{shippingMethodId:[0-9]+}", shippingHandler.UpdateShippingMethod).Methods(http.MethodPut)
 	admin.HandleFunc("/shipping/zones", shippingHandler.CreateShippingZone).Methods(http.MethodPost)
 	admin.HandleFunc("/shipping/zones", shippingHandler.ListShippingZones).Methods(http.MethodGet)
+	admin.HandleFunc("/shipping/zones/resolve", shippingHandler.ResolveShippingZones).Methods(http.MethodPost)
 	admin.HandleFunc("/shipping/zones/{shippingZoneId:[0-9]+}", shippingHandler.GetShippingZoneByID).Methods(http.MethodGet)
 	admin.HandleFunc("/shipping/zones/{shippingZoneId:[0-9]+}", shippingHandler.UpdateShippingZone).Methods(http.MethodPut)
 	admin.HandleFunc("/shipping/rates", shippingHandler.CreateShippingRate).Methods(http.MethodPost)
@@ -189,6 +261,19 @@ func (s *Server) setupRoutes() {
 	admin.HandleFunc("/shipping/rates/{shippingRateId:[0-9]+}", shippingHandler.UpdateShippingRate).Methods(http.MethodPut)
 	admin.HandleFunc("/shipping/rates/weight", shippingHandler.CreateWeightBasedRate).Methods(http.MethodPost)
 	admin.HandleFunc("/shipping/rates/value", shippingHandler.CreateValueBasedRate).Methods(http.MethodPost)
+	admin.HandleFunc("/shipping/packages", shippingHandler.CreateShippingPackage).Methods(http.MethodPost)
+	admin.HandleFunc("/shipping/packages", shippingHandler.ListShippingPackages).Methods(http.MethodGet)
+	admin.HandleFunc("/shipping/packages/{shippingPackageId:[0-9]+}", shippingHandler.UpdateShippingPackage).Methods(http.MethodPut)
+	admin.HandleFunc("/shipping/packages/{shippingPackageId:[0-9]+}", shippingHandler.DeleteShippingPackage).Methods(http.MethodDelete)
+	admin.HandleFunc("/shipping/profiles", shippingHandler.CreateShippingProfile).Methods(http.MethodPost)
+	admin.HandleFunc("/shipping/profiles", shippingHandler.ListShippingProfiles).Methods(http.MethodGet)
+	admin.HandleFunc("/shipping/profiles/{shippingProfileId:[0-9]+}", shippingHandler.GetShippingProfile).Methods(http.MethodGet)
+	admin.HandleFunc("/shipping/profiles/{shippingProfileId:[0-9]+}", shippingHandler.UpdateShippingProfile).Methods(http.MethodPut)
+	admin.HandleFunc("/shipping/profiles/{shippingProfileId:[0-9]+}", shippingHandler.DeleteShippingProfile).Methods(http.MethodDelete)
+	admin.HandleFunc("/categories/{categoryId:[0-9]+}/shipping-profile", shippingHandler.AssignCategoryShippingProfile).Methods(http.MethodPut)
+	admin.HandleFunc("/shipping/holidays", shippingHandler.CreateHoliday).Methods(http.MethodPost)
+	admin.HandleFunc("/shipping/holidays", shippingHandler.ListHolidays).Methods(http.MethodGet)
+	admin.HandleFunc("/shipping/holidays/{holidayId:[0-9]+}", shippingHandler.DeleteHoliday).Methods(http.MethodDelete)
 
 	// Payment management routes (admin only)
 	admin.HandleFunc("/payments/{paymentId}/capture", paymentHandler.CapturePayment).Methods(http.MethodPost)
@@ -232,6 +317,20 @@ func (s *Server) setupStripeWebhooks(api *mux.Router, webhookHandler *handler.We
 	// Stripe webhook configuration needs.
 }
 
+// setupPayPalWebhooks checks the PayPal webhook configuration.
+// The webhook is subscribed to in the PayPal developer dashboard, which issues the webhook ID.
+func (s *Server) setupPayPalWebhooks() {
+	if !s.config.PayPal.Enabled {
+		return
+	}
+
+	if s.config.PayPal.WebhookID == "" {
+		s.logger.Warn("PayPal webhook ID is not configured, PayPal webhooks will be rejected")
+	} else {
+		s.logger.Info("PayPal webhook endpoint configured at /api/webhooks/paypal")
+	}
+}
+
 // setupMobilePayWebhooks configures MobilePay webhooks if enabled
 func (s *Server) setupMobilePayWebhooks(api *mux.Router, webhookHandler *handler.WebhookHandler) {
 	if !s.config.MobilePay.Enabled {
@@ -294,10 +393,83 @@ func (s *Server) setupMobilePayWebhooks(api *mux.Router, webhookHandler *handler
 
 // Start starts the server
 func (s *Server) Start() error {
+	s.scheduler.Start()
 	return s.httpServer.ListenAndServe()
 }
 
 // Shutdown gracefully shuts down the server
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.scheduler.Stop()
 	return s.httpServer.Shutdown(ctx)
 }
+
+// setupJobs configures the background jobs
+func (s *Server) setupJobs() {
+	s.scheduler = scheduler.NewScheduler(s.logger)
+
+	idempotencyUseCase := s.container.UseCases().IdempotencyUseCase()
+	s.scheduler.Add("idempotency-key-cleanup", time.Hour, func() error {
+		deleted, err := idempotencyUseCase.DeleteExpiredKeys()
+		s.logger.Debug("Deleted %d expired idempotency key(s)", deleted)
+		return err
+	})
+
+	if s.config.Payment.ReconcileInterval > 0 {
+		reconciliationUseCase := s.container.UseCases().ReconciliationUseCase()
+		s.scheduler.Add("payment-reconciliation", time.Duration(s.config.Payment.ReconcileInterval)*time.Minute, func() error {
+			report, err := reconciliationUseCase.Reconcile(usecase.ReconcileInput{
+				StaleAfter: time.Duration(s.config.Payment.ReconcileStaleAfter) * time.Minute,
+				Lookback:   time.Duration(s.config.Payment.ReconcileLookback) * 24 * time.Hour,
+			})
+			if err != nil {
+				return err
+			}
+			for _, discrepancy := range report.Fixed() {
+				s.logger.Info("Reconciled order %s: %s, %s", discrepancy.OrderNumber, discrepancy.Kind, discrepancy.Detail)
+			}
+			for _, discrepancy := range report.Unresolved() {
+				s.logger.Warn("Payment discrepancy in order %s: %s, %s", discrepancy.OrderNumber, discrepancy.Kind, discrepancy.Detail)
+			}
+			return nil
+		})
+	}
+
+	if s.config.Payment.AuthorizationWarningHours > 0 {
+		orderUseCase := s.container.UseCases().OrderUseCase()
+		warningWindow := time.Duration(s.config.Payment.AuthorizationWarningHours) * time.Hour
+		s.scheduler.Add("authorization-expiry-warning", time.Hour, func() error {
+			orders, err := orderUseCase.WarnExpiringAuthorizations(warningWindow)
+			for _, order := range orders {
+				s.logger.Warn("Authorization of order %s expires at %s with %s uncaptured",
+					order.OrderNumber,
+					order.AuthorizationExpiresAt.Format(time.RFC3339),
+					money.New(order.RemainingCapturable(), order.Currency).String(),
+				)
+			}
+			return err
+		})
+	}
+
+	if s.config.ExchangeRate.SyncEnabled {
+		exchangeRateUseCase := s.container.UseCases().ExchangeRateUseCase()
+		s.scheduler.Add("exchange-rate-sync", time.Duration(s.config.ExchangeRate.SyncInterval)*time.Hour, func() error {
+			result, err := exchangeRateUseCase.SyncRates()
+			if err != nil {
+				return err
+			}
+			if len(result.Pending) > 0 {
+				s.logger.Warn("%d exchange rate(s) changed more than %.0f%% and await confirmation", len(result.Pending), s.config.ExchangeRate.MaxChangePercent)
+			}
+			return nil
+		})
+	}
+
+	if s.config.Shipping.TrackingProvider != "" && s.config.Shipping.TrackingPollInterval > 0 {
+		trackingUseCase := s.container.UseCases().TrackingUseCase()
+		s.scheduler.Add("shipment-tracking", time.Duration(s.config.Shipping.TrackingPollInterval)*time.Minute, func() error {
+			updated, err := trackingUseCase.PollShipments()
+			s.logger.Debug("Updated the tracking of %d shipped order(s)", updated)
+			return err
+		})
+	}
+}
@@ -0,0 +1,6 @@
+-- Remove shipping discount support
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_discount_amount;
+
+ALTER TABLE discounts DROP COLUMN IF EXISTS shipping_zone_ids;
+
+ALTER TABLE discounts DROP COLUMN IF EXISTS shipping_method_ids;
@@ -0,0 +1,12 @@
+-- Add shipping discount support
+
+-- Shipping discounts can be restricted to specific shipping methods and zones
+ALTER TABLE discounts
+ADD COLUMN IF NOT EXISTS shipping_method_ids JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE discounts
+ADD COLUMN IF NOT EXISTS shipping_zone_ids JSONB NOT NULL DEFAULT '[]';
+
+-- Store the shipping discount separately from the item discount
+ALTER TABLE orders
+ADD COLUMN IF NOT EXISTS shipping_discount_amount BIGINT NOT NULL DEFAULT 0;
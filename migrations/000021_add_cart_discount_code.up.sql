@@ -0,0 +1,3 @@
+-- Allow discount codes to be attached to carts before an order exists
+ALTER TABLE carts
+ADD COLUMN IF NOT EXISTS discount_code VARCHAR(50) NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_discount_id_created_at;
+
+DROP INDEX IF EXISTS idx_discounts_campaign;
+
+ALTER TABLE discounts
+DROP COLUMN IF EXISTS campaign;
@@ -0,0 +1,8 @@
+-- Group discount codes into campaigns for reporting
+ALTER TABLE discounts
+ADD COLUMN IF NOT EXISTS campaign VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_discounts_campaign ON discounts (campaign);
+
+-- Speed up discount reports, which filter orders by discount and date
+CREATE INDEX IF NOT EXISTS idx_orders_discount_id_created_at ON orders (discount_id, created_at);
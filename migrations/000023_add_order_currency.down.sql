@@ -0,0 +1,5 @@
+ALTER TABLE orders
+DROP COLUMN IF EXISTS exchange_rate;
+
+ALTER TABLE orders
+DROP COLUMN IF EXISTS currency;
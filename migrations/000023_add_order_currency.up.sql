@@ -0,0 +1,14 @@
+-- Snapshot the currency and exchange rate of each order at order time
+ALTER TABLE orders
+ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+
+ALTER TABLE orders
+ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(16, 6) NOT NULL DEFAULT 1.0;
+
+-- Existing orders were placed in the default currency, which is USD when none is set
+UPDATE orders
+SET currency = COALESCE((SELECT code FROM currencies WHERE is_default = true LIMIT 1), 'USD')
+WHERE currency IS NULL;
+
+ALTER TABLE orders
+ALTER COLUMN currency SET NOT NULL;
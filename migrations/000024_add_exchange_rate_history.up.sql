@@ -0,0 +1,14 @@
+-- History of exchange rate changes, including synchronised rates awaiting confirmation
+CREATE TABLE IF NOT EXISTS exchange_rate_history (
+    id SERIAL PRIMARY KEY,
+    currency_code VARCHAR(3) NOT NULL REFERENCES currencies(code) ON DELETE CASCADE,
+    rate DECIMAL(16, 6) NOT NULL,
+    previous_rate DECIMAL(16, 6) NOT NULL DEFAULT 0,
+    source VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'applied',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rate_history_currency_created_at ON exchange_rate_history (currency_code, created_at);
+CREATE INDEX IF NOT EXISTS idx_exchange_rate_history_status ON exchange_rate_history (status);
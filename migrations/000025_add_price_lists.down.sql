@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS price_list_entries;
+DROP TABLE IF EXISTS price_list_customer_groups;
+DROP TABLE IF EXISTS price_lists;
+ALTER TABLE users DROP COLUMN IF EXISTS customer_group_id;
+DROP TABLE IF EXISTS customer_groups;
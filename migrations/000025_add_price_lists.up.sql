@@ -0,0 +1,43 @@
+-- Customer groups, e.g. wholesale accounts
+CREATE TABLE IF NOT EXISTS customer_groups (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE users ADD COLUMN customer_group_id INTEGER REFERENCES customer_groups(id) ON DELETE SET NULL;
+
+-- Named price lists for customer groups
+CREATE TABLE IF NOT EXISTS price_lists (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    currency_code VARCHAR(3) NOT NULL REFERENCES currencies(code),
+    discount_percentage DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    start_date TIMESTAMP,
+    end_date TIMESTAMP,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS price_list_customer_groups (
+    price_list_id INTEGER NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    customer_group_id INTEGER NOT NULL REFERENCES customer_groups(id) ON DELETE CASCADE,
+    PRIMARY KEY (price_list_id, customer_group_id)
+);
+
+-- Per product or variant prices with quantity breaks
+CREATE TABLE IF NOT EXISTS price_list_entries (
+    id SERIAL PRIMARY KEY,
+    price_list_id INTEGER NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id INTEGER NOT NULL DEFAULT 0,
+    min_quantity INTEGER NOT NULL DEFAULT 1,
+    price BIGINT NOT NULL,
+    UNIQUE (price_list_id, product_id, variant_id, min_quantity)
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_customer_group_id ON users (customer_group_id);
+CREATE INDEX IF NOT EXISTS idx_price_list_customer_groups_group ON price_list_customer_groups (customer_group_id);
@@ -0,0 +1,23 @@
+ALTER TABLE product_variant_prices
+    DROP COLUMN IF EXISTS sale_ends_at,
+    DROP COLUMN IF EXISTS sale_starts_at,
+    DROP COLUMN IF EXISTS sale_price,
+    DROP COLUMN IF EXISTS compare_at_price;
+
+ALTER TABLE product_prices
+    DROP COLUMN IF EXISTS sale_ends_at,
+    DROP COLUMN IF EXISTS sale_starts_at,
+    DROP COLUMN IF EXISTS sale_price,
+    DROP COLUMN IF EXISTS compare_at_price;
+
+ALTER TABLE product_variants
+    DROP COLUMN IF EXISTS sale_ends_at,
+    DROP COLUMN IF EXISTS sale_starts_at,
+    DROP COLUMN IF EXISTS sale_price,
+    DROP COLUMN IF EXISTS compare_at_price;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS sale_ends_at,
+    DROP COLUMN IF EXISTS sale_starts_at,
+    DROP COLUMN IF EXISTS sale_price,
+    DROP COLUMN IF EXISTS compare_at_price;
@@ -0,0 +1,24 @@
+-- Compare-at prices and scheduled sale prices for products, variants and their currency prices
+ALTER TABLE products
+    ADD COLUMN compare_at_price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sale_price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sale_starts_at TIMESTAMP,
+    ADD COLUMN sale_ends_at TIMESTAMP;
+
+ALTER TABLE product_variants
+    ADD COLUMN compare_at_price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sale_price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sale_starts_at TIMESTAMP,
+    ADD COLUMN sale_ends_at TIMESTAMP;
+
+ALTER TABLE product_prices
+    ADD COLUMN compare_at_price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sale_price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sale_starts_at TIMESTAMP,
+    ADD COLUMN sale_ends_at TIMESTAMP;
+
+ALTER TABLE product_variant_prices
+    ADD COLUMN compare_at_price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sale_price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sale_starts_at TIMESTAMP,
+    ADD COLUMN sale_ends_at TIMESTAMP;
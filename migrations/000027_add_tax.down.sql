@@ -0,0 +1,17 @@
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS tax_rate,
+    DROP COLUMN IF EXISTS tax_class_id;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS prices_include_tax,
+    DROP COLUMN IF EXISTS shipping_tax_amount,
+    DROP COLUMN IF EXISTS shipping_tax_rate,
+    DROP COLUMN IF EXISTS tax_amount;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS tax_class_id;
+
+DROP INDEX IF EXISTS idx_tax_rates_country;
+DROP TABLE IF EXISTS tax_rates;
+DROP TABLE IF EXISTS tax_classes;
@@ -0,0 +1,39 @@
+-- Tax classes group products taxed at the same rates
+CREATE TABLE IF NOT EXISTS tax_classes (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Tax rates per country, optionally narrowed to states or postal codes.
+-- A NULL tax class is the standard rate, used for products without a class and for shipping.
+CREATE TABLE IF NOT EXISTS tax_rates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    tax_class_id INT REFERENCES tax_classes(id) ON DELETE CASCADE,
+    country VARCHAR(2) NOT NULL,
+    states JSONB NOT NULL DEFAULT '[]',
+    postal_codes JSONB NOT NULL DEFAULT '[]',
+    rate DECIMAL(7, 4) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tax_rates_country ON tax_rates(country);
+
+ALTER TABLE products
+    ADD COLUMN tax_class_id INT REFERENCES tax_classes(id) ON DELETE SET NULL;
+
+ALTER TABLE orders
+    ADD COLUMN tax_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN shipping_tax_rate DECIMAL(7, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN shipping_tax_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN prices_include_tax BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE order_items
+    ADD COLUMN tax_class_id INT,
+    ADD COLUMN tax_rate DECIMAL(7, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount BIGINT NOT NULL DEFAULT 0;
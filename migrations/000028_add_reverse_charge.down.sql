@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS reverse_charge,
+    DROP COLUMN IF EXISTS customer_vat_id,
+    DROP COLUMN IF EXISTS customer_company_name;
@@ -0,0 +1,5 @@
+-- Business customers and the EU VAT reverse charge
+ALTER TABLE orders
+    ADD COLUMN customer_company_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN customer_vat_id VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN reverse_charge BOOLEAN NOT NULL DEFAULT false;
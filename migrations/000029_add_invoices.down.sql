@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_invoices_order_invoice;
+DROP INDEX IF EXISTS idx_invoices_order_id;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS invoice_sequences;
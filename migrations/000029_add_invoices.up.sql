@@ -0,0 +1,26 @@
+-- Last number issued in each gap-free series, locked while an invoice is numbered
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    type VARCHAR(20) PRIMARY KEY,
+    last_number BIGINT NOT NULL
+);
+
+-- Invoices of paid orders and credit notes of refunds
+CREATE TABLE IF NOT EXISTS invoices (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE RESTRICT,
+    type VARCHAR(20) NOT NULL,
+    number VARCHAR(50) NOT NULL UNIQUE,
+    sequence BIGINT NOT NULL,
+    invoice_id INT REFERENCES invoices(id) ON DELETE RESTRICT,
+    amount BIGINT NOT NULL,
+    tax_amount BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL,
+    reverse_charge BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (type, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_order_id ON invoices(order_id);
+
+-- An order has at most one invoice
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_order_invoice ON invoices(order_id) WHERE type = 'invoice';
@@ -0,0 +1,13 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS packages;
+
+DROP TABLE IF EXISTS shipping_packages;
+
+ALTER TABLE product_variants
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS length;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS length;
@@ -0,0 +1,27 @@
+-- Product dimensions in cm for dimensional weight
+ALTER TABLE products
+    ADD COLUMN length DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN width DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN height DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE product_variants
+    ADD COLUMN length DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN width DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN height DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+-- Boxes orders are packed in
+CREATE TABLE IF NOT EXISTS shipping_packages (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    length DECIMAL(10, 2) NOT NULL,
+    width DECIMAL(10, 2) NOT NULL,
+    height DECIMAL(10, 2) NOT NULL,
+    weight DECIMAL(10, 3) NOT NULL DEFAULT 0,
+    max_weight DECIMAL(10, 3) NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Packages picked for an order
+ALTER TABLE orders ADD COLUMN packages JSONB NOT NULL DEFAULT '[]';
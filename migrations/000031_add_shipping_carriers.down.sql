@@ -0,0 +1,5 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_label;
+
+ALTER TABLE shipping_methods
+    DROP COLUMN IF EXISTS carrier_service_code,
+    DROP COLUMN IF EXISTS carrier;
@@ -0,0 +1,7 @@
+-- Back shipping methods by carriers quoting live rates and creating labels
+ALTER TABLE shipping_methods
+    ADD COLUMN carrier VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN carrier_service_code VARCHAR(100) NOT NULL DEFAULT '';
+
+-- Label bought when an order is shipped with a carrier-backed method
+ALTER TABLE orders ADD COLUMN shipping_label JSONB;
@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS pickup_point;
+
+ALTER TABLE shipping_methods DROP COLUMN IF EXISTS pickup_point;
@@ -0,0 +1,5 @@
+-- Shipping methods delivering to a pickup point the customer chooses
+ALTER TABLE shipping_methods ADD COLUMN pickup_point BOOLEAN NOT NULL DEFAULT false;
+
+-- Pickup point an order is delivered to, its address is the order's shipping address
+ALTER TABLE orders ADD COLUMN pickup_point JSONB;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_tracking_code;
+
+DROP TABLE IF EXISTS tracking_events;
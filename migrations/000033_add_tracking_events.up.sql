@@ -0,0 +1,16 @@
+-- Scans and status changes of order shipments reported by carriers
+CREATE TABLE IF NOT EXISTS tracking_events (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    tracking_number VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    -- Carriers report every event again on each update
+    UNIQUE (order_id, tracking_number, status, occurred_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tracking_events_order_id ON tracking_events(order_id);
+CREATE INDEX IF NOT EXISTS idx_orders_tracking_code ON orders(tracking_code);
@@ -0,0 +1,10 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS estimated_delivery_latest;
+ALTER TABLE orders DROP COLUMN IF EXISTS estimated_delivery_earliest;
+
+ALTER TABLE shipping_rates DROP COLUMN IF EXISTS transit_days_max;
+ALTER TABLE shipping_rates DROP COLUMN IF EXISTS transit_days_min;
+
+ALTER TABLE shipping_methods DROP COLUMN IF EXISTS handling_days;
+ALTER TABLE shipping_methods DROP COLUMN IF EXISTS cutoff_time;
+
+DROP TABLE IF EXISTS holidays;
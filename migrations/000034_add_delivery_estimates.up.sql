@@ -0,0 +1,21 @@
+-- Public holidays on which parcels are neither dispatched nor delivered
+CREATE TABLE IF NOT EXISTS holidays (
+    id SERIAL PRIMARY KEY,
+    country VARCHAR(2) NOT NULL,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT holidays_country_date_key UNIQUE (country, date)
+);
+
+-- Daily order cut-off time and business days until dispatch
+ALTER TABLE shipping_methods ADD COLUMN IF NOT EXISTS cutoff_time VARCHAR(5) NOT NULL DEFAULT '';
+ALTER TABLE shipping_methods ADD COLUMN IF NOT EXISTS handling_days INTEGER NOT NULL DEFAULT 0;
+
+-- Business days in transit to a zone, 0 for both to use the method's estimated delivery days
+ALTER TABLE shipping_rates ADD COLUMN IF NOT EXISTS transit_days_min INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE shipping_rates ADD COLUMN IF NOT EXISTS transit_days_max INTEGER NOT NULL DEFAULT 0;
+
+-- Delivery window promised at checkout
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS estimated_delivery_earliest DATE;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS estimated_delivery_latest DATE;
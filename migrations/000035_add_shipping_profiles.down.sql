@@ -0,0 +1,6 @@
+ALTER TABLE shipping_methods DROP COLUMN IF EXISTS restrictions;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS shipping_profile_id;
+ALTER TABLE products DROP COLUMN IF EXISTS shipping_profile_id;
+
+DROP TABLE IF EXISTS shipping_profiles;
@@ -0,0 +1,16 @@
+-- Groups of products that ship under the same rules, e.g. hazardous goods or oversize items
+CREATE TABLE IF NOT EXISTS shipping_profiles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    shipping_method_ids JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Products without a shipping profile have the profile of their category
+ALTER TABLE products ADD COLUMN IF NOT EXISTS shipping_profile_id INTEGER REFERENCES shipping_profiles(id) ON DELETE SET NULL;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS shipping_profile_id INTEGER REFERENCES shipping_profiles(id) ON DELETE SET NULL;
+
+-- Shipping profiles and cart weight a method is offered for
+ALTER TABLE shipping_methods ADD COLUMN IF NOT EXISTS restrictions JSONB NOT NULL DEFAULT '{}';
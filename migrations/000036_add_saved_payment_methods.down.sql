@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_saved_payment_methods_user_id;
+DROP TABLE IF EXISTS saved_payment_methods;
+DROP TABLE IF EXISTS payment_customers;
@@ -0,0 +1,27 @@
+-- Customer objects of users at payment providers, e.g. Stripe customers
+CREATE TABLE IF NOT EXISTS payment_customers (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    customer_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, provider),
+    UNIQUE (provider, customer_id)
+);
+
+-- Cards saved with a payment provider, only the provider's reference and display details are stored
+CREATE TABLE IF NOT EXISTS saved_payment_methods (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    provider_method_id VARCHAR(255) NOT NULL UNIQUE,
+    brand VARCHAR(50) NOT NULL DEFAULT '',
+    last4 VARCHAR(4) NOT NULL,
+    exp_month INTEGER NOT NULL,
+    exp_year INTEGER NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_payment_methods_user_id ON saved_payment_methods(user_id);
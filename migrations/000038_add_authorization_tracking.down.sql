@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_authorization_expires_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS authorization_warned_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS authorization_expires_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS captured_amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS authorized_amount;
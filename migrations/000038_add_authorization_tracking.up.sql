@@ -0,0 +1,22 @@
+-- Amounts authorized and captured at the payment provider, and when the authorization lapses
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS authorized_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS captured_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS authorization_expires_at TIMESTAMP;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS authorization_warned_at TIMESTAMP;
+
+-- Orders paid before authorizations were tracked hold their final amount,
+-- less what their successful captures took
+UPDATE orders o
+SET authorized_amount = o.final_amount,
+    captured_amount = COALESCE((
+        SELECT SUM(pt.amount) FROM payment_transactions pt
+        WHERE pt.order_id = o.id AND pt.type = 'capture' AND pt.status = 'successful'
+    ), 0)
+WHERE o.status IN ('paid', 'captured', 'shipped', 'delivered');
+
+-- Captured orders were always captured in full, and their authorization has nothing left.
+-- Their expiry is unknown, so existing orders aren't warned about.
+UPDATE orders SET captured_amount = final_amount WHERE status = 'captured' AND captured_amount = 0;
+UPDATE orders SET authorized_amount = captured_amount WHERE status = 'captured' OR captured_amount > authorized_amount;
+
+CREATE INDEX IF NOT EXISTS idx_orders_authorization_expires_at ON orders(authorization_expires_at);
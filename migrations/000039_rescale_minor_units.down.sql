@@ -0,0 +1,62 @@
+-- Store the amounts of currencies with other minor units in hundredths again
+CREATE TEMPORARY TABLE currency_exponents (code VARCHAR(3) PRIMARY KEY, exponent INT NOT NULL);
+INSERT INTO currency_exponents (code, exponent) VALUES
+    ('JPY', 0), ('KRW', 0), ('ISK', 0), ('CLP', 0), ('VND', 0),
+    ('BHD', 3), ('JOD', 3), ('KWD', 3), ('OMR', 3), ('TND', 3);
+
+UPDATE products p
+SET price = ROUND(p.price * POWER(10.0, 2 - ce.exponent)),
+    compare_at_price = ROUND(p.compare_at_price * POWER(10.0, 2 - ce.exponent)),
+    sale_price = ROUND(p.sale_price * POWER(10.0, 2 - ce.exponent))
+FROM currency_exponents ce WHERE ce.code = p.currency_code;
+
+UPDATE product_variants pv
+SET price = ROUND(pv.price * POWER(10.0, 2 - ce.exponent)),
+    compare_at_price = ROUND(pv.compare_at_price * POWER(10.0, 2 - ce.exponent)),
+    sale_price = ROUND(pv.sale_price * POWER(10.0, 2 - ce.exponent))
+FROM currency_exponents ce WHERE ce.code = pv.currency_code;
+
+UPDATE product_prices pp
+SET price = ROUND(pp.price * POWER(10.0, 2 - ce.exponent)),
+    compare_at_price = ROUND(pp.compare_at_price * POWER(10.0, 2 - ce.exponent)),
+    sale_price = ROUND(pp.sale_price * POWER(10.0, 2 - ce.exponent))
+FROM currency_exponents ce WHERE ce.code = pp.currency_code;
+
+UPDATE product_variant_prices pvp
+SET price = ROUND(pvp.price * POWER(10.0, 2 - ce.exponent)),
+    compare_at_price = ROUND(pvp.compare_at_price * POWER(10.0, 2 - ce.exponent)),
+    sale_price = ROUND(pvp.sale_price * POWER(10.0, 2 - ce.exponent))
+FROM currency_exponents ce WHERE ce.code = pvp.currency_code;
+
+UPDATE price_list_entries ple
+SET price = ROUND(ple.price * POWER(10.0, 2 - ce.exponent))
+FROM price_lists pl, currency_exponents ce WHERE pl.id = ple.price_list_id AND ce.code = pl.currency_code;
+
+UPDATE orders o
+SET total_amount = ROUND(o.total_amount * POWER(10.0, 2 - ce.exponent)),
+    final_amount = ROUND(o.final_amount * POWER(10.0, 2 - ce.exponent)),
+    discount_amount = ROUND(o.discount_amount * POWER(10.0, 2 - ce.exponent)),
+    shipping_cost = ROUND(o.shipping_cost * POWER(10.0, 2 - ce.exponent)),
+    shipping_discount_amount = ROUND(o.shipping_discount_amount * POWER(10.0, 2 - ce.exponent)),
+    tax_amount = ROUND(o.tax_amount * POWER(10.0, 2 - ce.exponent)),
+    shipping_tax_amount = ROUND(o.shipping_tax_amount * POWER(10.0, 2 - ce.exponent)),
+    authorized_amount = ROUND(o.authorized_amount * POWER(10.0, 2 - ce.exponent)),
+    captured_amount = ROUND(o.captured_amount * POWER(10.0, 2 - ce.exponent))
+FROM currency_exponents ce WHERE ce.code = o.currency;
+
+UPDATE order_items oi
+SET price = ROUND(oi.price * POWER(10.0, 2 - ce.exponent)),
+    subtotal = ROUND(oi.subtotal * POWER(10.0, 2 - ce.exponent)),
+    tax_amount = ROUND(oi.tax_amount * POWER(10.0, 2 - ce.exponent))
+FROM orders o, currency_exponents ce WHERE o.id = oi.order_id AND ce.code = o.currency;
+
+UPDATE payment_transactions pt
+SET amount = ROUND(pt.amount * POWER(10.0, 2 - ce.exponent))
+FROM currency_exponents ce WHERE ce.code = pt.currency;
+
+UPDATE invoices i
+SET amount = ROUND(i.amount * POWER(10.0, 2 - ce.exponent)),
+    tax_amount = ROUND(i.tax_amount * POWER(10.0, 2 - ce.exponent))
+FROM currency_exponents ce WHERE ce.code = i.currency;
+
+DROP TABLE currency_exponents;
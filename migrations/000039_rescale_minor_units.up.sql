@@ -0,0 +1,64 @@
+-- Amounts used to be stored in hundredths for every currency. Rescale those of
+-- currencies with other minor units, e.g. JPY has none and KWD has thousandths.
+-- Discounts and shipping rates are still stored in hundredths and are left as is.
+CREATE TEMPORARY TABLE currency_exponents (code VARCHAR(3) PRIMARY KEY, exponent INT NOT NULL);
+INSERT INTO currency_exponents (code, exponent) VALUES
+    ('JPY', 0), ('KRW', 0), ('ISK', 0), ('CLP', 0), ('VND', 0),
+    ('BHD', 3), ('JOD', 3), ('KWD', 3), ('OMR', 3), ('TND', 3);
+
+UPDATE products p
+SET price = ROUND(p.price * POWER(10.0, ce.exponent - 2)),
+    compare_at_price = ROUND(p.compare_at_price * POWER(10.0, ce.exponent - 2)),
+    sale_price = ROUND(p.sale_price * POWER(10.0, ce.exponent - 2))
+FROM currency_exponents ce WHERE ce.code = p.currency_code;
+
+UPDATE product_variants pv
+SET price = ROUND(pv.price * POWER(10.0, ce.exponent - 2)),
+    compare_at_price = ROUND(pv.compare_at_price * POWER(10.0, ce.exponent - 2)),
+    sale_price = ROUND(pv.sale_price * POWER(10.0, ce.exponent - 2))
+FROM currency_exponents ce WHERE ce.code = pv.currency_code;
+
+UPDATE product_prices pp
+SET price = ROUND(pp.price * POWER(10.0, ce.exponent - 2)),
+    compare_at_price = ROUND(pp.compare_at_price * POWER(10.0, ce.exponent - 2)),
+    sale_price = ROUND(pp.sale_price * POWER(10.0, ce.exponent - 2))
+FROM currency_exponents ce WHERE ce.code = pp.currency_code;
+
+UPDATE product_variant_prices pvp
+SET price = ROUND(pvp.price * POWER(10.0, ce.exponent - 2)),
+    compare_at_price = ROUND(pvp.compare_at_price * POWER(10.0, ce.exponent - 2)),
+    sale_price = ROUND(pvp.sale_price * POWER(10.0, ce.exponent - 2))
+FROM currency_exponents ce WHERE ce.code = pvp.currency_code;
+
+UPDATE price_list_entries ple
+SET price = ROUND(ple.price * POWER(10.0, ce.exponent - 2))
+FROM price_lists pl, currency_exponents ce WHERE pl.id = ple.price_list_id AND ce.code = pl.currency_code;
+
+UPDATE orders o
+SET total_amount = ROUND(o.total_amount * POWER(10.0, ce.exponent - 2)),
+    final_amount = ROUND(o.final_amount * POWER(10.0, ce.exponent - 2)),
+    discount_amount = ROUND(o.discount_amount * POWER(10.0, ce.exponent - 2)),
+    shipping_cost = ROUND(o.shipping_cost * POWER(10.0, ce.exponent - 2)),
+    shipping_discount_amount = ROUND(o.shipping_discount_amount * POWER(10.0, ce.exponent - 2)),
+    tax_amount = ROUND(o.tax_amount * POWER(10.0, ce.exponent - 2)),
+    shipping_tax_amount = ROUND(o.shipping_tax_amount * POWER(10.0, ce.exponent - 2)),
+    authorized_amount = ROUND(o.authorized_amount * POWER(10.0, ce.exponent - 2)),
+    captured_amount = ROUND(o.captured_amount * POWER(10.0, ce.exponent - 2))
+FROM currency_exponents ce WHERE ce.code = o.currency;
+
+UPDATE order_items oi
+SET price = ROUND(oi.price * POWER(10.0, ce.exponent - 2)),
+    subtotal = ROUND(oi.subtotal * POWER(10.0, ce.exponent - 2)),
+    tax_amount = ROUND(oi.tax_amount * POWER(10.0, ce.exponent - 2))
+FROM orders o, currency_exponents ce WHERE o.id = oi.order_id AND ce.code = o.currency;
+
+UPDATE payment_transactions pt
+SET amount = ROUND(pt.amount * POWER(10.0, ce.exponent - 2))
+FROM currency_exponents ce WHERE ce.code = pt.currency;
+
+UPDATE invoices i
+SET amount = ROUND(i.amount * POWER(10.0, ce.exponent - 2)),
+    tax_amount = ROUND(i.tax_amount * POWER(10.0, ce.exponent - 2))
+FROM currency_exponents ce WHERE ce.code = i.currency;
+
+DROP TABLE currency_exponents;
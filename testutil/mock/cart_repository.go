@@ -180,6 +180,11 @@ func (r *MockCartRepository) ConvertGuestCartToUserCart(sessionID string, userID
 			}
 		}
 
+		// Keep the guest's discount code if the user cart has none
+		if existingCart.DiscountCode == "" {
+			existingCart.DiscountCode = guestCart.DiscountCode
+		}
+
 		// Delete guest cart
 		delete(r.guestCarts, sessionID)
 
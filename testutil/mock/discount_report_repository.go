@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+)
+
+// MockDiscountReportRepository is a mock implementation of the discount report repository.
+// It returns the configured figures and records the discount IDs it was queried with.
+type MockDiscountReportRepository struct {
+	Stats        *entity.DiscountOrderStats
+	StatsWithout *entity.DiscountOrderStats
+	Periods      []entity.DiscountRedemptionPeriod
+	DiscountIDs  []uint
+}
+
+// NewMockDiscountReportRepository creates a new instance of MockDiscountReportRepository
+func NewMockDiscountReportRepository() *MockDiscountReportRepository {
+	return &MockDiscountReportRepository{
+		Stats:        &entity.DiscountOrderStats{},
+		StatsWithout: &entity.DiscountOrderStats{},
+		Periods:      []entity.DiscountRedemptionPeriod{},
+	}
+}
+
+// GetOrderStats returns the configured stats for orders using the discounts
+func (r *MockDiscountReportRepository) GetOrderStats(discountIDs []uint, startDate, endDate time.Time) (*entity.DiscountOrderStats, error) {
+	r.DiscountIDs = discountIDs
+	return r.Stats, nil
+}
+
+// GetOrderStatsWithout returns the configured stats for orders not using the discounts
+func (r *MockDiscountReportRepository) GetOrderStatsWithout(discountIDs []uint, startDate, endDate time.Time) (*entity.DiscountOrderStats, error) {
+	return r.StatsWithout, nil
+}
+
+// GetRedemptionsByPeriod returns the configured redemption periods
+func (r *MockDiscountReportRepository) GetRedemptionsByPeriod(discountIDs []uint, startDate, endDate time.Time, interval entity.DiscountReportInterval) ([]entity.DiscountRedemptionPeriod, error) {
+	return r.Periods, nil
+}
@@ -143,6 +143,20 @@ func (r *MockDiscountRepository) ListActive(offset, limit int) ([]*entity.Discou
 	return discounts[start:end], nil
 }
 
+// ListByCampaign retrieves all discounts belonging to a campaign
+func (r *MockDiscountRepository) ListByCampaign(campaign string) ([]*entity.Discount, error) {
+	discounts := make([]*entity.Discount, 0)
+
+	for id := uint(1); id <= r.lastID; id++ {
+		discount, exists := r.discounts[id]
+		if exists && discount.Campaign == campaign {
+			discounts = append(discounts, discount)
+		}
+	}
+
+	return discounts, nil
+}
+
 // IncrementUsage increments the usage count of a discount
 func (r *MockDiscountRepository) IncrementUsage(id uint) error {
 	discount, exists := r.discounts[id]
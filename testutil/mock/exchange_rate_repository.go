@@ -0,0 +1,94 @@
+package mock
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// MockExchangeRateHistoryRepository is a mock implementation of the exchange rate history repository
+type MockExchangeRateHistoryRepository struct {
+	histories map[uint]*entity.ExchangeRateHistory
+	lastID    uint
+}
+
+// NewMockExchangeRateHistoryRepository creates a new instance of MockExchangeRateHistoryRepository
+func NewMockExchangeRateHistoryRepository() repository.ExchangeRateHistoryRepository {
+	return &MockExchangeRateHistoryRepository{
+		histories: make(map[uint]*entity.ExchangeRateHistory),
+	}
+}
+
+// Create adds an exchange rate history entry
+func (r *MockExchangeRateHistoryRepository) Create(history *entity.ExchangeRateHistory) error {
+	r.lastID++
+	history.ID = r.lastID
+	r.histories[history.ID] = history
+	return nil
+}
+
+// Update updates an exchange rate history entry
+func (r *MockExchangeRateHistoryRepository) Update(history *entity.ExchangeRateHistory) error {
+	if _, exists := r.histories[history.ID]; !exists {
+		return errors.New("exchange rate history not found")
+	}
+	r.histories[history.ID] = history
+	return nil
+}
+
+// GetByID retrieves an exchange rate history entry by ID
+func (r *MockExchangeRateHistoryRepository) GetByID(historyID uint) (*entity.ExchangeRateHistory, error) {
+	history, exists := r.histories[historyID]
+	if !exists {
+		return nil, errors.New("exchange rate history not found")
+	}
+	return history, nil
+}
+
+// ListByCurrency lists the exchange rate history of a currency, newest first
+func (r *MockExchangeRateHistoryRepository) ListByCurrency(currencyCode string, offset, limit int) ([]*entity.ExchangeRateHistory, error) {
+	histories := r.filter(func(history *entity.ExchangeRateHistory) bool {
+		return history.CurrencyCode == currencyCode
+	})
+
+	// Reverse to return the newest entries first
+	for i, j := 0, len(histories)-1; i < j; i, j = i+1, j-1 {
+		histories[i], histories[j] = histories[j], histories[i]
+	}
+
+	if offset >= len(histories) {
+		return []*entity.ExchangeRateHistory{}, nil
+	}
+
+	end := offset + limit
+	if end > len(histories) {
+		end = len(histories)
+	}
+
+	return histories[offset:end], nil
+}
+
+// ListPending lists the exchange rates awaiting confirmation
+func (r *MockExchangeRateHistoryRepository) ListPending() ([]*entity.ExchangeRateHistory, error) {
+	return r.filter(func(history *entity.ExchangeRateHistory) bool {
+		return history.Status == entity.ExchangeRateStatusPending
+	}), nil
+}
+
+// filter returns the matching entries in ID order
+func (r *MockExchangeRateHistoryRepository) filter(match func(*entity.ExchangeRateHistory) bool) []*entity.ExchangeRateHistory {
+	histories := []*entity.ExchangeRateHistory{}
+	for _, history := range r.histories {
+		if match(history) {
+			histories = append(histories, history)
+		}
+	}
+
+	sort.Slice(histories, func(i, j int) bool {
+		return histories[i].ID < histories[j].ID
+	})
+
+	return histories
+}
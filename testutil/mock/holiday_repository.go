@@ -0,0 +1,83 @@
+package mock
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// MockHolidayRepository is a mock implementation of the public holiday repository
+type MockHolidayRepository struct {
+	mu       sync.Mutex
+	holidays map[uint]*entity.Holiday
+	lastID   uint
+}
+
+// NewMockHolidayRepository creates a new instance of MockHolidayRepository
+func NewMockHolidayRepository() repository.HolidayRepository {
+	return &MockHolidayRepository{holidays: make(map[uint]*entity.Holiday)}
+}
+
+// Create adds a public holiday, refusing a second holiday on the same date in a country like the database
+func (r *MockHolidayRepository) Create(holiday *entity.Holiday) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.holidays {
+		if existing.Country == holiday.Country && existing.Date.Equal(holiday.Date) {
+			return errors.New("a holiday on this date already exists for the country")
+		}
+	}
+
+	r.lastID++
+	holiday.ID = r.lastID
+	r.holidays[holiday.ID] = holiday
+	return nil
+}
+
+// GetByID retrieves a public holiday by ID
+func (r *MockHolidayRepository) GetByID(holidayID uint) (*entity.Holiday, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	holiday, ok := r.holidays[holidayID]
+	if !ok {
+		return nil, errors.New("holiday not found")
+	}
+	return holiday, nil
+}
+
+// List lists the public holidays from and to the dates, both included, of a country or of all countries
+func (r *MockHolidayRepository) List(country string, from, to time.Time) ([]*entity.Holiday, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	holidays := []*entity.Holiday{}
+	for _, holiday := range r.holidays {
+		if country != "" && holiday.Country != country {
+			continue
+		}
+		if holiday.Date.Before(from) || holiday.Date.After(to) {
+			continue
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays, nil
+}
+
+// Delete deletes a public holiday
+func (r *MockHolidayRepository) Delete(holidayID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.holidays, holidayID)
+	return nil
+}
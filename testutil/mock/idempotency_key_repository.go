@@ -0,0 +1,87 @@
+package mock
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// MockIdempotencyKeyRepository is a mock implementation of the idempotency key repository.
+// Like the database it creates a key only once under concurrent use.
+type MockIdempotencyKeyRepository struct {
+	mu   sync.Mutex
+	keys map[string]*entity.IdempotencyKey
+}
+
+// NewMockIdempotencyKeyRepository creates a new instance of MockIdempotencyKeyRepository
+func NewMockIdempotencyKeyRepository() repository.IdempotencyKeyRepository {
+	return &MockIdempotencyKeyRepository{
+		keys: make(map[string]*entity.IdempotencyKey),
+	}
+}
+
+// Create saves the key of a new request, taking over an expired key of the same value
+func (r *MockIdempotencyKeyRepository) Create(key *entity.IdempotencyKey) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, exists := r.keys[key.Scope+"/"+key.Key]; exists && !existing.IsExpired(key.CreatedAt) {
+		return false, nil
+	}
+
+	stored := *key
+	r.keys[key.Scope+"/"+key.Key] = &stored
+	return true, nil
+}
+
+// Get retrieves an idempotency key
+func (r *MockIdempotencyKeyRepository) Get(scope, key string) (*entity.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.keys[scope+"/"+key]
+	if !exists {
+		return nil, errors.New("idempotency key not found")
+	}
+
+	stored := *existing
+	return &stored, nil
+}
+
+// Complete stores the response of the key's request
+func (r *MockIdempotencyKeyRepository) Complete(key *entity.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, exists := r.keys[key.Scope+"/"+key.Key]; exists {
+		existing.Complete(key.StatusCode, key.ContentType, key.ResponseBody)
+	}
+	return nil
+}
+
+// Delete deletes an idempotency key
+func (r *MockIdempotencyKeyRepository) Delete(scope, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.keys, scope+"/"+key)
+	return nil
+}
+
+// DeleteExpired deletes the keys expired before the given time
+func (r *MockIdempotencyKeyRepository) DeleteExpired(before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for id, key := range r.keys {
+		if key.IsExpired(before) {
+			delete(r.keys, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
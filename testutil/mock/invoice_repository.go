@@ -0,0 +1,87 @@
+package mock
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// MockInvoiceRepository is a mock implementation of the invoice repository.
+// Like the database it numbers each type's series without gaps and is safe for concurrent use.
+type MockInvoiceRepository struct {
+	mu        sync.Mutex
+	invoices  map[uint]*entity.Invoice
+	sequences map[entity.InvoiceType]int64
+	lastID    uint
+}
+
+// NewMockInvoiceRepository creates a new instance of MockInvoiceRepository
+func NewMockInvoiceRepository() repository.InvoiceRepository {
+	return &MockInvoiceRepository{
+		invoices:  make(map[uint]*entity.Invoice),
+		sequences: make(map[entity.InvoiceType]int64),
+	}
+}
+
+// Create adds an invoice with the next number of its series
+func (r *MockInvoiceRepository) Create(invoice *entity.Invoice, prefix string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if invoice.Type == entity.InvoiceTypeInvoice {
+		for _, existing := range r.invoices {
+			if existing.OrderID == invoice.OrderID && existing.Type == entity.InvoiceTypeInvoice {
+				return errors.New("order already has an invoice")
+			}
+		}
+	}
+
+	r.sequences[invoice.Type]++
+	invoice.AssignNumber(prefix, r.sequences[invoice.Type])
+
+	r.lastID++
+	invoice.ID = r.lastID
+	r.invoices[invoice.ID] = invoice
+	return nil
+}
+
+// GetByID retrieves an invoice by ID
+func (r *MockInvoiceRepository) GetByID(invoiceID uint) (*entity.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invoice, exists := r.invoices[invoiceID]
+	if !exists {
+		return nil, errors.New("invoice not found")
+	}
+	return invoice, nil
+}
+
+// GetInvoiceByOrderID retrieves the invoice of an order
+func (r *MockInvoiceRepository) GetInvoiceByOrderID(orderID uint) (*entity.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, invoice := range r.invoices {
+		if invoice.OrderID == orderID && invoice.Type == entity.InvoiceTypeInvoice {
+			return invoice, nil
+		}
+	}
+	return nil, errors.New("invoice not found")
+}
+
+// ListByOrderID lists the invoice and credit notes of an order in ID order
+func (r *MockInvoiceRepository) ListByOrderID(orderID uint) ([]*entity.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invoices := []*entity.Invoice{}
+	for id := uint(1); id <= r.lastID; id++ {
+		if invoice, exists := r.invoices[id]; exists && invoice.OrderID == orderID {
+			invoices = append(invoices, invoice)
+		}
+	}
+	return invoices, nil
+}
@@ -2,6 +2,8 @@ package mock
 
 import (
 	"errors"
+	"sort"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/repository"
@@ -77,21 +79,21 @@ func (r *OrderRepository) Update(order *entity.Order) error {
 		return errors.New("order not found")
 	}
 
-	// If payment ID has changed, update the index
+	// If payment ID has changed, remove the old index entry
 	existingOrder := r.orders[order.ID]
-	if existingOrder.PaymentID != order.PaymentID {
-		if existingOrder.PaymentID != "" {
-			delete(r.paymentIDIndex, existingOrder.PaymentID)
-		}
-		if order.PaymentID != "" {
-			r.paymentIDIndex[order.PaymentID] = order
-		}
+	if existingOrder.PaymentID != order.PaymentID && existingOrder.PaymentID != "" {
+		delete(r.paymentIDIndex, existingOrder.PaymentID)
 	}
 
 	// Clone the order to prevent unintended modifications
 	clone := *order
 	r.orders[order.ID] = &clone
 
+	// Index the updated order, so lookups by payment ID see the update
+	if order.PaymentID != "" {
+		r.paymentIDIndex[order.PaymentID] = &clone
+	}
+
 	return nil
 }
 
@@ -119,8 +121,7 @@ func (r *OrderRepository) ListByStatus(status entity.OrderStatus, offset, limit
 	var orders []*entity.Order
 	for _, order := range r.orders {
 		if order.Status == status {
-			clone := *order
-			orders = append(orders, &clone)
+			orders = append(orders, listedOrder(order))
 		}
 	}
 
@@ -133,6 +134,31 @@ func (r *OrderRepository) ListByStatus(status entity.OrderStatus, offset, limit
 	return orders[offset:end], nil
 }
 
+// listedOrder returns the columns of an order the PostgreSQL repository reads when listing orders by status.
+// Totals, shipping and payment details are left out, so only orders loaded by ID can be updated safely.
+func listedOrder(order *entity.Order) *entity.Order {
+	return &entity.Order{
+		ID:              order.ID,
+		OrderNumber:     order.OrderNumber,
+		UserID:          order.UserID,
+		Items:           append([]entity.OrderItem(nil), order.Items...),
+		TotalAmount:     order.TotalAmount,
+		Currency:        order.Currency,
+		ExchangeRate:    order.ExchangeRate,
+		Status:          order.Status,
+		ShippingAddr:    order.ShippingAddr,
+		BillingAddr:     order.BillingAddr,
+		PaymentID:       order.PaymentID,
+		PaymentProvider: order.PaymentProvider,
+		TrackingCode:    order.TrackingCode,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+		CompletedAt:     order.CompletedAt,
+		CustomerDetails: order.CustomerDetails,
+		IsGuestOrder:    order.IsGuestOrder,
+	}
+}
+
 func (r *OrderRepository) SetIsDiscountIdUsed(isDiscountIdUsed bool) {
 	r.isDiscountIdUsed = isDiscountIdUsed
 }
@@ -164,6 +190,57 @@ func (r *OrderRepository) GetByPaymentID(paymentID string) (*entity.Order, error
 	return &clone, nil
 }
 
+// GetByOrderNumber retrieves an order by its order number from the mock repository
+func (r *OrderRepository) GetByOrderNumber(orderNumber string) (*entity.Order, error) {
+	for _, order := range r.orders {
+		if order.OrderNumber == orderNumber {
+			clone := *order
+			return &clone, nil
+		}
+	}
+	return nil, errors.New("order not found")
+}
+
+// GetByTrackingCode retrieves the latest order shipped with a tracking code from the mock repository
+func (r *OrderRepository) GetByTrackingCode(trackingCode string) (*entity.Order, error) {
+	var latest *entity.Order
+	for _, order := range r.orders {
+		if trackingCode != "" && order.TrackingCode == trackingCode && (latest == nil || order.ID > latest.ID) {
+			latest = order
+		}
+	}
+	if latest == nil {
+		return nil, errors.New("order not found")
+	}
+
+	clone := *latest
+	return &clone, nil
+}
+
+// ListExpiringAuthorizations retrieves orders with an uncaptured authorization expiring before the given time from the mock repository
+func (r *OrderRepository) ListExpiringAuthorizations(before time.Time) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	for _, order := range r.orders {
+		if order.AuthorizationExpiresAt == nil || order.AuthorizationExpiresAt.After(before) {
+			continue
+		}
+		if order.AuthorizationWarnedAt != nil || order.RemainingCapturable() == 0 {
+			continue
+		}
+		if order.Status == entity.OrderStatusCancelled || order.Status == entity.OrderStatusRefunded {
+			continue
+		}
+		clone := *order
+		orders = append(orders, &clone)
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].AuthorizationExpiresAt.Before(*orders[j].AuthorizationExpiresAt)
+	})
+
+	return orders, nil
+}
+
 // AddMockGetByPaymentID is a helper function to set up mock behavior for GetByPaymentID
 func (r *OrderRepository) AddMockGetByPaymentID(order *entity.Order) {
 	if order != nil && order.PaymentID != "" {
@@ -0,0 +1,166 @@
+package mock
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// MockCustomerGroupRepository is a mock implementation of the customer group repository
+type MockCustomerGroupRepository struct {
+	groups map[uint]*entity.CustomerGroup
+	lastID uint
+}
+
+// NewMockCustomerGroupRepository creates a new instance of MockCustomerGroupRepository
+func NewMockCustomerGroupRepository() repository.CustomerGroupRepository {
+	return &MockCustomerGroupRepository{
+		groups: make(map[uint]*entity.CustomerGroup),
+	}
+}
+
+// Create adds a customer group
+func (r *MockCustomerGroupRepository) Create(group *entity.CustomerGroup) error {
+	r.lastID++
+	group.ID = r.lastID
+	r.groups[group.ID] = group
+	return nil
+}
+
+// Update updates a customer group
+func (r *MockCustomerGroupRepository) Update(group *entity.CustomerGroup) error {
+	if _, exists := r.groups[group.ID]; !exists {
+		return errors.New("customer group not found")
+	}
+	r.groups[group.ID] = group
+	return nil
+}
+
+// Delete removes a customer group
+func (r *MockCustomerGroupRepository) Delete(groupID uint) error {
+	if _, exists := r.groups[groupID]; !exists {
+		return errors.New("customer group not found")
+	}
+	delete(r.groups, groupID)
+	return nil
+}
+
+// GetByID retrieves a customer group by ID
+func (r *MockCustomerGroupRepository) GetByID(groupID uint) (*entity.CustomerGroup, error) {
+	group, exists := r.groups[groupID]
+	if !exists {
+		return nil, errors.New("customer group not found")
+	}
+	return group, nil
+}
+
+// List lists all customer groups in ID order
+func (r *MockCustomerGroupRepository) List() ([]*entity.CustomerGroup, error) {
+	groups := make([]*entity.CustomerGroup, 0, len(r.groups))
+	for _, group := range r.groups {
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ID < groups[j].ID
+	})
+
+	return groups, nil
+}
+
+// MockPriceListRepository is a mock implementation of the price list repository
+type MockPriceListRepository struct {
+	priceLists map[uint]*entity.PriceList
+	lastID     uint
+}
+
+// NewMockPriceListRepository creates a new instance of MockPriceListRepository
+func NewMockPriceListRepository() repository.PriceListRepository {
+	return &MockPriceListRepository{
+		priceLists: make(map[uint]*entity.PriceList),
+	}
+}
+
+// Create adds a price list
+func (r *MockPriceListRepository) Create(priceList *entity.PriceList) error {
+	r.lastID++
+	priceList.ID = r.lastID
+	r.priceLists[priceList.ID] = priceList
+	return nil
+}
+
+// Update updates a price list
+func (r *MockPriceListRepository) Update(priceList *entity.PriceList) error {
+	if _, exists := r.priceLists[priceList.ID]; !exists {
+		return errors.New("price list not found")
+	}
+	r.priceLists[priceList.ID] = priceList
+	return nil
+}
+
+// Delete removes a price list
+func (r *MockPriceListRepository) Delete(priceListID uint) error {
+	if _, exists := r.priceLists[priceListID]; !exists {
+		return errors.New("price list not found")
+	}
+	delete(r.priceLists, priceListID)
+	return nil
+}
+
+// GetByID retrieves a price list by ID
+func (r *MockPriceListRepository) GetByID(priceListID uint) (*entity.PriceList, error) {
+	priceList, exists := r.priceLists[priceListID]
+	if !exists {
+		return nil, errors.New("price list not found")
+	}
+	return priceList, nil
+}
+
+// List lists price lists with pagination
+func (r *MockPriceListRepository) List(offset, limit int) ([]*entity.PriceList, error) {
+	priceLists := r.filter(func(*entity.PriceList) bool { return true })
+
+	if offset >= len(priceLists) {
+		return []*entity.PriceList{}, nil
+	}
+
+	end := offset + limit
+	if end > len(priceLists) {
+		end = len(priceLists)
+	}
+
+	return priceLists[offset:end], nil
+}
+
+// ListByCustomerGroup lists the active price lists assigned to a customer group
+func (r *MockPriceListRepository) ListByCustomerGroup(groupID uint) ([]*entity.PriceList, error) {
+	return r.filter(func(priceList *entity.PriceList) bool {
+		if !priceList.Active {
+			return false
+		}
+		for _, id := range priceList.CustomerGroupIDs {
+			if id == groupID {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// filter returns the matching price lists in ID order
+func (r *MockPriceListRepository) filter(match func(*entity.PriceList) bool) []*entity.PriceList {
+	priceLists := []*entity.PriceList{}
+	for _, priceList := range r.priceLists {
+		if match(priceList) {
+			priceLists = append(priceLists, priceList)
+		}
+	}
+
+	sort.Slice(priceLists, func(i, j int) bool {
+		return priceLists[i].ID < priceLists[j].ID
+	})
+
+	return priceLists
+}
@@ -3,6 +3,7 @@ package mock
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/zenfulcode/commercify/internal/domain/entity"
 	"github.com/zenfulcode/commercify/internal/domain/repository"
@@ -46,13 +47,18 @@ func (r *MockProductRepository) Create(product *entity.Product) error {
 	return nil
 }
 
-// GetByID retrieves a product by ID
+// GetByID retrieves a product by ID without its variants, like the database repository
 func (r *MockProductRepository) GetByID(id uint) (*entity.Product, error) {
 	product, exists := r.products[id]
 	if !exists {
 		return nil, errors.New("product not found")
 	}
-	return product, nil
+
+	// Return a copy of the product to prevent unintended modifications
+	productCopy := *product
+	productCopy.Variants = nil
+
+	return &productCopy, nil
 }
 
 // GetByIDWithVariants retrieves a product by ID including its variants
@@ -131,11 +137,12 @@ func (r *MockProductRepository) Search(query string, categoryID uint, minPrice,
 			continue
 		}
 
-		if minPrice > 0 && product.Price < minPrice {
+		price := product.EffectivePrice(time.Now())
+		if minPrice > 0 && price < minPrice {
 			continue
 		}
 
-		if maxPrice > 0 && product.Price > maxPrice {
+		if maxPrice > 0 && price > maxPrice {
 			continue
 		}
 
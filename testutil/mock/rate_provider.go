@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/zenfulcode/commercify/internal/domain/service"
+)
+
+// MockRateProvider is a mock exchange rate provider returning the configured rates
+type MockRateProvider struct {
+	Rates map[string]float64
+	Err   error
+}
+
+// NewMockRateProvider creates a new instance of MockRateProvider
+func NewMockRateProvider(rates map[string]float64) *MockRateProvider {
+	return &MockRateProvider{Rates: rates}
+}
+
+// Name returns the name of the provider
+func (p *MockRateProvider) Name() string {
+	return "mock"
+}
+
+// GetRates returns the configured rates
+func (p *MockRateProvider) GetRates(base string) (*service.ExchangeRates, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	return &service.ExchangeRates{
+		Base:  base,
+		Date:  time.Now(),
+		Rates: p.Rates,
+	}, nil
+}
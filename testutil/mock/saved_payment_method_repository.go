@@ -0,0 +1,127 @@
+package mock
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// paymentCustomer identifies a user's customer object at a provider
+type paymentCustomer struct {
+	userID   uint
+	provider string
+}
+
+// MockSavedPaymentMethodRepository is a mock implementation of the saved payment method repository
+type MockSavedPaymentMethodRepository struct {
+	methods   map[uint]*entity.SavedPaymentMethod
+	customers map[paymentCustomer]string
+	lastID    uint
+}
+
+// NewMockSavedPaymentMethodRepository creates a new instance of MockSavedPaymentMethodRepository
+func NewMockSavedPaymentMethodRepository() repository.SavedPaymentMethodRepository {
+	return &MockSavedPaymentMethodRepository{
+		methods:   make(map[uint]*entity.SavedPaymentMethod),
+		customers: make(map[paymentCustomer]string),
+	}
+}
+
+// Create adds a saved payment method
+func (r *MockSavedPaymentMethodRepository) Create(method *entity.SavedPaymentMethod) error {
+	for _, existing := range r.methods {
+		if existing.ProviderMethodID == method.ProviderMethodID {
+			return errors.New("payment method already saved")
+		}
+	}
+
+	r.lastID++
+	method.ID = r.lastID
+	r.methods[method.ID] = method
+	return nil
+}
+
+// GetByID retrieves a saved payment method by ID
+func (r *MockSavedPaymentMethodRepository) GetByID(methodID uint) (*entity.SavedPaymentMethod, error) {
+	method, exists := r.methods[methodID]
+	if !exists {
+		return nil, errors.New("payment method not found")
+	}
+	return method, nil
+}
+
+// GetByProviderMethodID retrieves a saved payment method by the provider's reference
+func (r *MockSavedPaymentMethodRepository) GetByProviderMethodID(providerMethodID string) (*entity.SavedPaymentMethod, error) {
+	for _, method := range r.methods {
+		if method.ProviderMethodID == providerMethodID {
+			return method, nil
+		}
+	}
+	return nil, errors.New("payment method not found")
+}
+
+// ListByUser lists a user's saved payment methods, the default first
+func (r *MockSavedPaymentMethodRepository) ListByUser(userID uint) ([]*entity.SavedPaymentMethod, error) {
+	methods := []*entity.SavedPaymentMethod{}
+	for _, method := range r.methods {
+		if method.UserID == userID {
+			methods = append(methods, method)
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		if methods[i].IsDefault != methods[j].IsDefault {
+			return methods[i].IsDefault
+		}
+		return methods[i].ID > methods[j].ID
+	})
+	return methods, nil
+}
+
+// Update updates a saved payment method
+func (r *MockSavedPaymentMethodRepository) Update(method *entity.SavedPaymentMethod) error {
+	if _, exists := r.methods[method.ID]; !exists {
+		return errors.New("payment method not found")
+	}
+	r.methods[method.ID] = method
+	return nil
+}
+
+// Delete deletes a saved payment method
+func (r *MockSavedPaymentMethodRepository) Delete(methodID uint) error {
+	delete(r.methods, methodID)
+	return nil
+}
+
+// SetDefault makes a method the user's default and clears the flag on their other methods
+func (r *MockSavedPaymentMethodRepository) SetDefault(userID, methodID uint) error {
+	for _, method := range r.methods {
+		if method.UserID == userID {
+			method.IsDefault = method.ID == methodID
+		}
+	}
+	return nil
+}
+
+// GetCustomerID returns the user's customer ID at a provider
+func (r *MockSavedPaymentMethodRepository) GetCustomerID(userID uint, provider string) (string, error) {
+	return r.customers[paymentCustomer{userID: userID, provider: provider}], nil
+}
+
+// SetCustomerID saves the user's customer ID at a provider
+func (r *MockSavedPaymentMethodRepository) SetCustomerID(userID uint, provider, customerID string) error {
+	r.customers[paymentCustomer{userID: userID, provider: provider}] = customerID
+	return nil
+}
+
+// GetUserIDByCustomerID returns the user of a provider customer
+func (r *MockSavedPaymentMethodRepository) GetUserIDByCustomerID(provider, customerID string) (uint, error) {
+	for customer, id := range r.customers {
+		if customer.provider == provider && id == customerID {
+			return customer.userID, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,429 @@
+package mock
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// MockShippingMethodRepository is a mock implementation of the shipping method repository
+type MockShippingMethodRepository struct {
+	methods map[uint]*entity.ShippingMethod
+	lastID  uint
+}
+
+// NewMockShippingMethodRepository creates a new instance of MockShippingMethodRepository
+func NewMockShippingMethodRepository() repository.ShippingMethodRepository {
+	return &MockShippingMethodRepository{
+		methods: make(map[uint]*entity.ShippingMethod),
+	}
+}
+
+// Create adds a shipping method
+func (r *MockShippingMethodRepository) Create(method *entity.ShippingMethod) error {
+	r.lastID++
+	method.ID = r.lastID
+	r.methods[method.ID] = method
+	return nil
+}
+
+// GetByID retrieves a shipping method by ID
+func (r *MockShippingMethodRepository) GetByID(methodID uint) (*entity.ShippingMethod, error) {
+	method, exists := r.methods[methodID]
+	if !exists {
+		return nil, errors.New("shipping method not found")
+	}
+	return method, nil
+}
+
+// List lists shipping methods in ID order
+func (r *MockShippingMethodRepository) List(active bool) ([]*entity.ShippingMethod, error) {
+	methods := make([]*entity.ShippingMethod, 0, len(r.methods))
+	for _, method := range r.methods {
+		if !active || method.Active {
+			methods = append(methods, method)
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].ID < methods[j].ID
+	})
+
+	return methods, nil
+}
+
+// Update updates a shipping method
+func (r *MockShippingMethodRepository) Update(method *entity.ShippingMethod) error {
+	if _, exists := r.methods[method.ID]; !exists {
+		return errors.New("shipping method not found")
+	}
+	r.methods[method.ID] = method
+	return nil
+}
+
+// Delete removes a shipping method
+func (r *MockShippingMethodRepository) Delete(methodID uint) error {
+	if _, exists := r.methods[methodID]; !exists {
+		return errors.New("shipping method not found")
+	}
+	delete(r.methods, methodID)
+	return nil
+}
+
+// MockShippingZoneRepository is a mock implementation of the shipping zone repository
+type MockShippingZoneRepository struct {
+	zones  map[uint]*entity.ShippingZone
+	lastID uint
+}
+
+// NewMockShippingZoneRepository creates a new instance of MockShippingZoneRepository
+func NewMockShippingZoneRepository() repository.ShippingZoneRepository {
+	return &MockShippingZoneRepository{
+		zones: make(map[uint]*entity.ShippingZone),
+	}
+}
+
+// Create adds a shipping zone
+func (r *MockShippingZoneRepository) Create(zone *entity.ShippingZone) error {
+	r.lastID++
+	zone.ID = r.lastID
+	r.zones[zone.ID] = zone
+	return nil
+}
+
+// GetByID retrieves a shipping zone by ID
+func (r *MockShippingZoneRepository) GetByID(zoneID uint) (*entity.ShippingZone, error) {
+	zone, exists := r.zones[zoneID]
+	if !exists {
+		return nil, errors.New("shipping zone not found")
+	}
+	return zone, nil
+}
+
+// List lists shipping zones in ID order
+func (r *MockShippingZoneRepository) List(active bool) ([]*entity.ShippingZone, error) {
+	zones := make([]*entity.ShippingZone, 0, len(r.zones))
+	for _, zone := range r.zones {
+		if !active || zone.Active {
+			zones = append(zones, zone)
+		}
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].ID < zones[j].ID
+	})
+
+	return zones, nil
+}
+
+// Update updates a shipping zone
+func (r *MockShippingZoneRepository) Update(zone *entity.ShippingZone) error {
+	if _, exists := r.zones[zone.ID]; !exists {
+		return errors.New("shipping zone not found")
+	}
+	r.zones[zone.ID] = zone
+	return nil
+}
+
+// Delete removes a shipping zone
+func (r *MockShippingZoneRepository) Delete(zoneID uint) error {
+	if _, exists := r.zones[zoneID]; !exists {
+		return errors.New("shipping zone not found")
+	}
+	delete(r.zones, zoneID)
+	return nil
+}
+
+// MockShippingRateRepository is a mock implementation of the shipping rate repository.
+// It matches addresses against the zones of the zone repository like the database does.
+type MockShippingRateRepository struct {
+	rates       map[uint]*entity.ShippingRate
+	weightRates map[uint][]entity.WeightBasedRate
+	valueRates  map[uint][]entity.ValueBasedRate
+	lastID      uint
+	lastTierID  uint
+	zoneRepo    repository.ShippingZoneRepository
+	methodRepo  repository.ShippingMethodRepository
+}
+
+// NewMockShippingRateRepository creates a new instance of MockShippingRateRepository
+func NewMockShippingRateRepository(zoneRepo repository.ShippingZoneRepository, methodRepo repository.ShippingMethodRepository) repository.ShippingRateRepository {
+	return &MockShippingRateRepository{
+		rates:       make(map[uint]*entity.ShippingRate),
+		weightRates: make(map[uint][]entity.WeightBasedRate),
+		valueRates:  make(map[uint][]entity.ValueBasedRate),
+		zoneRepo:    zoneRepo,
+		methodRepo:  methodRepo,
+	}
+}
+
+// Create adds a shipping rate
+func (r *MockShippingRateRepository) Create(rate *entity.ShippingRate) error {
+	r.lastID++
+	rate.ID = r.lastID
+	r.rates[rate.ID] = rate
+	return nil
+}
+
+// GetByID retrieves a shipping rate by ID with its weight and value based rates
+func (r *MockShippingRateRepository) GetByID(rateID uint) (*entity.ShippingRate, error) {
+	rate, exists := r.rates[rateID]
+	if !exists {
+		return nil, errors.New("shipping rate not found")
+	}
+
+	rate.WeightBasedRates = r.weightRates[rateID]
+	rate.ValueBasedRates = r.valueRates[rateID]
+	if method, err := r.methodRepo.GetByID(rate.ShippingMethodID); err == nil {
+		rate.ShippingMethod = method
+	}
+
+	return rate, nil
+}
+
+// GetByMethodID retrieves the shipping rates of a shipping method
+func (r *MockShippingRateRepository) GetByMethodID(methodID uint) ([]*entity.ShippingRate, error) {
+	return r.filter(func(rate *entity.ShippingRate) bool {
+		return rate.ShippingMethodID == methodID
+	}), nil
+}
+
+// GetByZoneID retrieves the shipping rates of a shipping zone
+func (r *MockShippingRateRepository) GetByZoneID(zoneID uint) ([]*entity.ShippingRate, error) {
+	return r.filter(func(rate *entity.ShippingRate) bool {
+		return rate.ShippingZoneID == zoneID
+	}), nil
+}
+
+// GetAvailableRatesForAddress retrieves the active rates of the active zones containing an address
+func (r *MockShippingRateRepository) GetAvailableRatesForAddress(address entity.Address, orderValue int64) ([]*entity.ShippingRate, error) {
+	zones, err := r.zoneRepo.List(true)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneIDs := make(map[uint]bool)
+	for _, zone := range zones {
+		if zone.IsAddressInZone(address) {
+			zoneIDs[zone.ID] = true
+		}
+	}
+
+	if len(zoneIDs) == 0 {
+		return nil, errors.New("no shipping zones available for this address")
+	}
+
+	rates := r.filter(func(rate *entity.ShippingRate) bool {
+		if !zoneIDs[rate.ShippingZoneID] || !rate.Active || rate.MinOrderValue > orderValue {
+			return false
+		}
+		method, err := r.methodRepo.GetByID(rate.ShippingMethodID)
+		if err != nil || !method.Active {
+			return false
+		}
+		rate.ShippingMethod = method
+		rate.WeightBasedRates = r.weightRates[rate.ID]
+		rate.ValueBasedRates = r.valueRates[rate.ID]
+		return true
+	})
+
+	sort.SliceStable(rates, func(i, j int) bool {
+		return rates[i].BaseRate < rates[j].BaseRate
+	})
+
+	return rates, nil
+}
+
+// CreateWeightBasedRate adds a weight-based rate
+func (r *MockShippingRateRepository) CreateWeightBasedRate(weightRate *entity.WeightBasedRate) error {
+	r.lastTierID++
+	weightRate.ID = r.lastTierID
+	r.weightRates[weightRate.ShippingRateID] = append(r.weightRates[weightRate.ShippingRateID], *weightRate)
+	return nil
+}
+
+// CreateValueBasedRate adds a value-based rate
+func (r *MockShippingRateRepository) CreateValueBasedRate(valueRate *entity.ValueBasedRate) error {
+	r.lastTierID++
+	valueRate.ID = r.lastTierID
+	r.valueRates[valueRate.ShippingRateID] = append(r.valueRates[valueRate.ShippingRateID], *valueRate)
+	return nil
+}
+
+// GetWeightBasedRates retrieves the weight-based rates of a shipping rate
+func (r *MockShippingRateRepository) GetWeightBasedRates(rateID uint) ([]entity.WeightBasedRate, error) {
+	return r.weightRates[rateID], nil
+}
+
+// GetValueBasedRates retrieves the value-based rates of a shipping rate
+func (r *MockShippingRateRepository) GetValueBasedRates(rateID uint) ([]entity.ValueBasedRate, error) {
+	return r.valueRates[rateID], nil
+}
+
+// Update updates a shipping rate
+func (r *MockShippingRateRepository) Update(rate *entity.ShippingRate) error {
+	if _, exists := r.rates[rate.ID]; !exists {
+		return errors.New("shipping rate not found")
+	}
+	r.rates[rate.ID] = rate
+	return nil
+}
+
+// Delete removes a shipping rate
+func (r *MockShippingRateRepository) Delete(rateID uint) error {
+	if _, exists := r.rates[rateID]; !exists {
+		return errors.New("shipping rate not found")
+	}
+	delete(r.rates, rateID)
+	delete(r.weightRates, rateID)
+	delete(r.valueRates, rateID)
+	return nil
+}
+
+// filter returns the rates matching a predicate in ID order
+func (r *MockShippingRateRepository) filter(match func(rate *entity.ShippingRate) bool) []*entity.ShippingRate {
+	rates := make([]*entity.ShippingRate, 0)
+	for _, rate := range r.rates {
+		if match(rate) {
+			rates = append(rates, rate)
+		}
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].ID < rates[j].ID
+	})
+
+	return rates
+}
+
+// MockShippingPackageRepository is a mock implementation of the shipping package repository
+type MockShippingPackageRepository struct {
+	packages map[uint]*entity.ShippingPackage
+	lastID   uint
+}
+
+// NewMockShippingPackageRepository creates a new instance of MockShippingPackageRepository
+func NewMockShippingPackageRepository() repository.ShippingPackageRepository {
+	return &MockShippingPackageRepository{
+		packages: make(map[uint]*entity.ShippingPackage),
+	}
+}
+
+// Create adds a shipping package
+func (r *MockShippingPackageRepository) Create(pkg *entity.ShippingPackage) error {
+	r.lastID++
+	pkg.ID = r.lastID
+	r.packages[pkg.ID] = pkg
+	return nil
+}
+
+// GetByID retrieves a shipping package by ID
+func (r *MockShippingPackageRepository) GetByID(packageID uint) (*entity.ShippingPackage, error) {
+	pkg, exists := r.packages[packageID]
+	if !exists {
+		return nil, errors.New("shipping package not found")
+	}
+	return pkg, nil
+}
+
+// List lists shipping packages, smallest first
+func (r *MockShippingPackageRepository) List(active bool) ([]*entity.ShippingPackage, error) {
+	packages := make([]*entity.ShippingPackage, 0, len(r.packages))
+	for _, pkg := range r.packages {
+		if !active || pkg.Active {
+			packages = append(packages, pkg)
+		}
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		vi, vj := packages[i].Dimensions.Volume(), packages[j].Dimensions.Volume()
+		if vi != vj {
+			return vi < vj
+		}
+		return packages[i].ID < packages[j].ID
+	})
+
+	return packages, nil
+}
+
+// Update updates a shipping package
+func (r *MockShippingPackageRepository) Update(pkg *entity.ShippingPackage) error {
+	if _, exists := r.packages[pkg.ID]; !exists {
+		return errors.New("shipping package not found")
+	}
+	r.packages[pkg.ID] = pkg
+	return nil
+}
+
+// Delete removes a shipping package
+func (r *MockShippingPackageRepository) Delete(packageID uint) error {
+	if _, exists := r.packages[packageID]; !exists {
+		return errors.New("shipping package not found")
+	}
+	delete(r.packages, packageID)
+	return nil
+}
+
+// MockShippingProfileRepository is a mock implementation of the shipping profile repository
+type MockShippingProfileRepository struct {
+	profiles map[uint]*entity.ShippingProfile
+	lastID   uint
+}
+
+// NewMockShippingProfileRepository creates a new instance of MockShippingProfileRepository
+func NewMockShippingProfileRepository() repository.ShippingProfileRepository {
+	return &MockShippingProfileRepository{
+		profiles: make(map[uint]*entity.ShippingProfile),
+	}
+}
+
+// Create adds a shipping profile
+func (r *MockShippingProfileRepository) Create(profile *entity.ShippingProfile) error {
+	r.lastID++
+	profile.ID = r.lastID
+	r.profiles[profile.ID] = profile
+	return nil
+}
+
+// GetByID retrieves a shipping profile by ID
+func (r *MockShippingProfileRepository) GetByID(profileID uint) (*entity.ShippingProfile, error) {
+	profile, exists := r.profiles[profileID]
+	if !exists {
+		return nil, errors.New("shipping profile not found")
+	}
+	return profile, nil
+}
+
+// List lists shipping profiles by name
+func (r *MockShippingProfileRepository) List() ([]*entity.ShippingProfile, error) {
+	profiles := make([]*entity.ShippingProfile, 0, len(r.profiles))
+	for _, profile := range r.profiles {
+		profiles = append(profiles, profile)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return profiles, nil
+}
+
+// Update updates a shipping profile
+func (r *MockShippingProfileRepository) Update(profile *entity.ShippingProfile) error {
+	if _, exists := r.profiles[profile.ID]; !exists {
+		return errors.New("shipping profile not found")
+	}
+	r.profiles[profile.ID] = profile
+	return nil
+}
+
+// Delete removes a shipping profile
+func (r *MockShippingProfileRepository) Delete(profileID uint) error {
+	if _, exists := r.profiles[profileID]; !exists {
+		return errors.New("shipping profile not found")
+	}
+	delete(r.profiles, profileID)
+	return nil
+}
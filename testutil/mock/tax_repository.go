@@ -0,0 +1,148 @@
+package mock
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// MockTaxClassRepository is a mock implementation of the tax class repository
+type MockTaxClassRepository struct {
+	taxClasses map[uint]*entity.TaxClass
+	lastID     uint
+}
+
+// NewMockTaxClassRepository creates a new instance of MockTaxClassRepository
+func NewMockTaxClassRepository() repository.TaxClassRepository {
+	return &MockTaxClassRepository{
+		taxClasses: make(map[uint]*entity.TaxClass),
+	}
+}
+
+// Create adds a tax class
+func (r *MockTaxClassRepository) Create(taxClass *entity.TaxClass) error {
+	r.lastID++
+	taxClass.ID = r.lastID
+	r.taxClasses[taxClass.ID] = taxClass
+	return nil
+}
+
+// Update updates a tax class
+func (r *MockTaxClassRepository) Update(taxClass *entity.TaxClass) error {
+	if _, exists := r.taxClasses[taxClass.ID]; !exists {
+		return errors.New("tax class not found")
+	}
+	r.taxClasses[taxClass.ID] = taxClass
+	return nil
+}
+
+// Delete removes a tax class
+func (r *MockTaxClassRepository) Delete(taxClassID uint) error {
+	if _, exists := r.taxClasses[taxClassID]; !exists {
+		return errors.New("tax class not found")
+	}
+	delete(r.taxClasses, taxClassID)
+	return nil
+}
+
+// GetByID retrieves a tax class by ID
+func (r *MockTaxClassRepository) GetByID(taxClassID uint) (*entity.TaxClass, error) {
+	taxClass, exists := r.taxClasses[taxClassID]
+	if !exists {
+		return nil, errors.New("tax class not found")
+	}
+	return taxClass, nil
+}
+
+// List lists all tax classes in ID order
+func (r *MockTaxClassRepository) List() ([]*entity.TaxClass, error) {
+	taxClasses := make([]*entity.TaxClass, 0, len(r.taxClasses))
+	for _, taxClass := range r.taxClasses {
+		taxClasses = append(taxClasses, taxClass)
+	}
+
+	sort.Slice(taxClasses, func(i, j int) bool {
+		return taxClasses[i].ID < taxClasses[j].ID
+	})
+
+	return taxClasses, nil
+}
+
+// MockTaxRateRepository is a mock implementation of the tax rate repository
+type MockTaxRateRepository struct {
+	taxRates map[uint]*entity.TaxRate
+	lastID   uint
+}
+
+// NewMockTaxRateRepository creates a new instance of MockTaxRateRepository
+func NewMockTaxRateRepository() repository.TaxRateRepository {
+	return &MockTaxRateRepository{
+		taxRates: make(map[uint]*entity.TaxRate),
+	}
+}
+
+// Create adds a tax rate
+func (r *MockTaxRateRepository) Create(taxRate *entity.TaxRate) error {
+	r.lastID++
+	taxRate.ID = r.lastID
+	r.taxRates[taxRate.ID] = taxRate
+	return nil
+}
+
+// Update updates a tax rate
+func (r *MockTaxRateRepository) Update(taxRate *entity.TaxRate) error {
+	if _, exists := r.taxRates[taxRate.ID]; !exists {
+		return errors.New("tax rate not found")
+	}
+	r.taxRates[taxRate.ID] = taxRate
+	return nil
+}
+
+// Delete removes a tax rate
+func (r *MockTaxRateRepository) Delete(taxRateID uint) error {
+	if _, exists := r.taxRates[taxRateID]; !exists {
+		return errors.New("tax rate not found")
+	}
+	delete(r.taxRates, taxRateID)
+	return nil
+}
+
+// GetByID retrieves a tax rate by ID
+func (r *MockTaxRateRepository) GetByID(taxRateID uint) (*entity.TaxRate, error) {
+	taxRate, exists := r.taxRates[taxRateID]
+	if !exists {
+		return nil, errors.New("tax rate not found")
+	}
+	return taxRate, nil
+}
+
+// List lists all tax rates in ID order
+func (r *MockTaxRateRepository) List() ([]*entity.TaxRate, error) {
+	return r.filter(func(*entity.TaxRate) bool { return true }), nil
+}
+
+// ListActiveByCountry lists the active tax rates of a country
+func (r *MockTaxRateRepository) ListActiveByCountry(country string) ([]*entity.TaxRate, error) {
+	return r.filter(func(taxRate *entity.TaxRate) bool {
+		return taxRate.Active && taxRate.Country == strings.ToUpper(country)
+	}), nil
+}
+
+// filter returns the matching tax rates in ID order
+func (r *MockTaxRateRepository) filter(match func(*entity.TaxRate) bool) []*entity.TaxRate {
+	taxRates := []*entity.TaxRate{}
+	for _, taxRate := range r.taxRates {
+		if match(taxRate) {
+			taxRates = append(taxRates, taxRate)
+		}
+	}
+
+	sort.Slice(taxRates, func(i, j int) bool {
+		return taxRates[i].ID < taxRates[j].ID
+	})
+
+	return taxRates
+}
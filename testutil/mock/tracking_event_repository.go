@@ -0,0 +1,55 @@
+package mock
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/zenfulcode/commercify/internal/domain/entity"
+	"github.com/zenfulcode/commercify/internal/domain/repository"
+)
+
+// MockTrackingEventRepository is a mock implementation of the tracking event repository
+type MockTrackingEventRepository struct {
+	mu     sync.Mutex
+	events []*entity.TrackingEvent
+	lastID uint
+}
+
+// NewMockTrackingEventRepository creates a new instance of MockTrackingEventRepository
+func NewMockTrackingEventRepository() repository.TrackingEventRepository {
+	return &MockTrackingEventRepository{}
+}
+
+// Create adds a tracking event, ignoring events already recorded for the order like the database
+func (r *MockTrackingEventRepository) Create(event *entity.TrackingEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.events {
+		if existing.OrderID == event.OrderID && existing.IsSameAs(event) {
+			return nil
+		}
+	}
+
+	r.lastID++
+	event.ID = r.lastID
+	r.events = append(r.events, event)
+	return nil
+}
+
+// ListByOrderID lists the tracking events of an order, oldest first
+func (r *MockTrackingEventRepository) ListByOrderID(orderID uint) ([]*entity.TrackingEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := []*entity.TrackingEvent{}
+	for _, event := range r.events {
+		if event.OrderID == orderID {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+	return events, nil
+}
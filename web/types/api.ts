@@ -14,6 +14,10 @@ export interface CartDTO {
   session_id: string;
   items: CartItemDTO[];
   currency: string;
+  subtotal: number /* float64 */;
+  discount_code?: string;
+  discount_amount: number /* float64 */;
+  total: number /* float64 */;
   created_at: string;
   updated_at: string;
 }
@@ -26,6 +30,8 @@ export interface CartItemDTO {
   variant_id?: number /* uint */;
   price: number /* float64 */;
   quantity: number /* int */;
+  subtotal: number /* float64 */;
+  discount_amount: number /* float64 */;
   created_at: string;
   updated_at: string;
 }
@@ -44,6 +50,12 @@ export interface UpdateCartItemRequest {
   quantity: number /* int */;
   variant_id?: number /* uint */;
 }
+/**
+ * ApplyCartDiscountRequest represents the data needed to attach a discount code to the cart
+ */
+export interface ApplyCartDiscountRequest {
+  discount_code: string;
+}
 /**
  * CartListResponse represents a paginated list of carts
  */
@@ -89,6 +101,59 @@ export interface ListResponseDTO<T extends any> {
   error?: string;
 }
 
+//////////
+// source: discount.go
+
+/**
+ * DiscountReportDTO represents the performance of one or more discount codes
+ */
+export interface DiscountReportDTO {
+  discount_ids: number /* uint */[];
+  codes: string[];
+  campaign?: string;
+  start_date: string;
+  end_date: string;
+  interval: string;
+  redemptions: number /* int */;
+  gross_revenue: number /* float64 */;
+  total_discount: number /* float64 */;
+  average_order_value_with: number /* float64 */;
+  average_order_value_without: number /* float64 */;
+  cancellation_rate: number /* float64 */;
+  refund_rate: number /* float64 */;
+  refunded_amount: number /* float64 */;
+  redemptions_over_time: DiscountRedemptionPeriodDTO[];
+}
+/**
+ * DiscountRedemptionPeriodDTO represents the redemptions of a discount within one period
+ */
+export interface DiscountRedemptionPeriodDTO {
+  period_start: string;
+  redemptions: number /* int */;
+  gross_revenue: number /* float64 */;
+  discount_amount: number /* float64 */;
+}
+
+//////////
+// source: invoice.go
+
+/**
+ * InvoiceDTO represents an invoice or credit note of an order
+ */
+export interface InvoiceDTO {
+  id: number /* uint */;
+  order_id: number /* uint */;
+  type: string; // "invoice" or "credit_note"
+  number: string;
+  invoice_number?: string; // credited invoice of a credit note
+  amount: number /* float64 */;
+  tax_amount: number /* float64 */;
+  currency: string;
+  reverse_charge: boolean;
+  download_url: string;
+  created_at: string;
+}
+
 //////////
 // source: order.go
 
@@ -104,11 +169,13 @@ export interface OrderDTO {
   total_amount: number /* float64 */;
   final_amount: number /* float64 */;
   currency: string;
+  exchange_rate: number /* float64 */;
   shipping_address: AddressDTO;
   billing_address: AddressDTO;
   payment_details: PaymentDetails;
   shipping_details: ShippingDetails;
   discount_details: DiscountDetails;
+  tax_details: TaxDetails;
   customer: CustomerDetails;
   action_url?: string;
   created_at: string;
@@ -121,20 +188,94 @@ export interface PaymentDetails {
   status: string;
   captured: boolean;
   refunded: boolean;
+  authorized_amount: number /* float64 */;
+  captured_amount: number /* float64 */;
+  remaining_capturable: number /* float64 */;
+  authorization_expires_at?: string; // when the uncaptured amount is released
 }
 export interface ShippingDetails {
   method_id: number /* uint */;
   method: string;
   cost: number /* float64 */;
+  packages?: OrderPackageDTO[];
+  label?: ShippingLabelDTO;
+  pickup_point?: PickupPointDTO; // its address is the shipping address
+  estimated_delivery?: DeliveryEstimateDTO;
+}
+/**
+ * DeliveryEstimateDTO represents the window of dates the order is expected to be delivered in
+ */
+export interface DeliveryEstimateDTO {
+  earliest: string;
+  latest: string;
+}
+/**
+ * PickupPointDTO represents a parcel shop or locker the customer collects the order at
+ */
+export interface PickupPointDTO {
+  id: string;
+  name: string;
+  address: AddressDTO;
+  carrier?: string;
+}
+/**
+ * ShippingLabelDTO represents the carrier label bought when the order shipped
+ */
+export interface ShippingLabelDTO {
+  carrier: string;
+  service_code: string;
+  tracking_number: string;
+  tracking_url?: string;
+  label_url: string;
+  cost: number /* float64 */;
+  currency: string;
+}
+/**
+ * OrderPackageDTO represents a box the warehouse packs part of an order in.
+ * The shipping cost is based on the greater of the weight and the dimensional weight.
+ */
+export interface OrderPackageDTO {
+  package_id?: number /* uint */; // omitted for items shipped in their own packaging
+  name: string;
+  dimensions: DimensionsDTO;
+  weight: number /* float64 */;
+  dimensional_weight: number /* float64 */;
+  items: OrderPackageItemDTO[];
+}
+/**
+ * OrderPackageItemDTO represents the quantity of an order item in a package
+ */
+export interface OrderPackageItemDTO {
+  product_id: number /* uint */;
+  variant_id?: number /* uint */;
+  sku?: string;
+  name?: string;
+  quantity: number /* int */;
 }
 export interface CustomerDetails {
   email: string;
   phone: string;
   full_name: string;
+  company_name?: string;
+  vat_id?: string;
 }
 export interface DiscountDetails {
   code: string;
   amount: number /* float64 */;
+  shipping_amount: number /* float64 */;
+}
+/**
+ * TaxDetails contains the tax of an order. When prices include tax the amounts
+ * are already part of the item and shipping prices, otherwise they are added to the final amount.
+ * Reverse charge orders are zero-rated and carry the note to print on documents.
+ */
+export interface TaxDetails {
+  amount: number /* float64 */;
+  shipping_amount: number /* float64 */;
+  shipping_rate: number /* float64 */;
+  prices_include_tax: boolean;
+  reverse_charge: boolean;
+  reverse_charge_note?: string;
 }
 /**
  * OrderItemDTO represents an item in an order
@@ -150,6 +291,8 @@ export interface OrderItemDTO {
   quantity: number /* int */;
   unit_price: number /* float64 */;
   total_price: number /* float64 */;
+  tax_rate: number /* float64 */;
+  tax_amount: number /* float64 */;
   created_at: string;
   updated_at: string;
 }
@@ -157,6 +300,7 @@ export interface OrderItemDTO {
  * AddressDTO represents a shipping or billing address
  */
 export interface AddressDTO {
+  company?: string;
   address_line1: string;
   address_line2?: string;
   city: string;
@@ -172,9 +316,13 @@ export interface CreateOrderRequest {
   last_name: string;
   email: string;
   phone_number?: string;
+  company_name?: string;
+  vat_id?: string; // EU VAT number, zero-rates intra-EU business orders
   shipping_address: AddressDTO;
   billing_address: AddressDTO;
   shipping_method_id: number /* uint */;
+  pickup_point_id?: string; // required by pickup point methods, replaces the shipping address
+  currency?: string;
 }
 /**
  * CreateOrderItemRequest represents the data needed to create a new order item
@@ -218,6 +366,51 @@ export interface ProcessPaymentRequest {
   payment_provider: PaymentProvider;
   card_details?: any /* service.CardDetails */;
   phone_number?: string;
+  saved_payment_method_id?: number /* uint */; // pay with a saved card instead
+  save_payment_method?: boolean; // save the card for later payments
+}
+/**
+ * CreatePaymentIntentRequest represents the options for a payment intent confirmed by the storefront
+ */
+export interface CreatePaymentIntentRequest {
+  save_payment_method?: boolean;
+}
+/**
+ * PaymentIntentResponse holds the client secret the storefront confirms a payment or setup intent with
+ */
+export interface PaymentIntentResponse {
+  id: string;
+  client_secret: string;
+}
+/**
+ * CapturePaymentRequest represents a capture of an authorized payment, e.g. for one shipment of an order
+ */
+export interface CapturePaymentRequest {
+  amount: number /* float64 */;
+  final?: boolean; // release the rest of the authorization after this capture
+  shipment?: string; // reference of the shipment the capture pays for
+}
+/**
+ * CapturePaymentResponse reports what is captured of a payment and what is left to capture
+ */
+export interface CapturePaymentResponse {
+  status: string;
+  message: string;
+  captured_amount: number /* float64 */;
+  remaining_capturable: number /* float64 */;
+}
+/**
+ * SavedPaymentMethodDTO represents a card a user saved for later payments
+ */
+export interface SavedPaymentMethodDTO {
+  id: number /* uint */;
+  provider: string;
+  brand: string;
+  last4: string;
+  exp_month: number /* int */;
+  exp_year: number /* int */;
+  is_default: boolean;
+  created_at: string;
 }
 /**
  * OrderStatus represents the status of an order
@@ -237,6 +430,7 @@ export const OrderStatusRefunded: OrderStatus = "refunded";
 export type PaymentMethod = string;
 export const PaymentMethodCard: PaymentMethod = "credit_card";
 export const PaymentMethodWallet: PaymentMethod = "wallet";
+export const PaymentMethodPayPal: PaymentMethod = "paypal";
 /**
  * PaymentProvider represents the payment provider used for an order
  */
@@ -255,11 +449,15 @@ export interface ProductDTO {
   name: string;
   description: string;
   sku: string;
-  price: number /* float64 */;
+  price: number /* float64 */; // effective price, including a running sale
+  compare_at_price?: number /* float64 */; // original price to show struck through
   currency: string;
   stock: number /* int */;
   weight: number /* float64 */;
+  dimensions: DimensionsDTO;
   category_id: number /* uint */;
+  tax_class_id: number /* uint */;
+  shipping_profile_id: number /* uint */;
   created_at: string;
   updated_at: string;
   images: string[];
@@ -274,15 +472,25 @@ export interface VariantDTO {
   id: number /* uint */;
   product_id: number /* uint */;
   sku: string;
-  price: number /* float64 */;
+  price: number /* float64 */; // effective price, including a running sale
+  compare_at_price?: number /* float64 */; // original price to show struck through
   currency: string;
   stock: number /* int */;
   attributes: VariantAttributeDTO[];
   images?: string[];
   is_default: boolean;
+  dimensions?: DimensionsDTO; // omitted when the product's dimensions apply
   created_at: string;
   updated_at: string;
 }
+/**
+ * DimensionsDTO represents the length, width and height of an item or box in cm
+ */
+export interface DimensionsDTO {
+  length: number /* float64 */;
+  width: number /* float64 */;
+  height: number /* float64 */;
+}
 export interface VariantAttributeDTO {
   name: string;
   value: string;
@@ -296,9 +504,14 @@ export interface CreateProductRequest {
   price: number /* float64 */;
   stock: number /* int */;
   weight: number /* float64 */;
+  dimensions?: DimensionsDTO;
   category_id: number /* uint */;
+  tax_class_id?: number /* uint */; // omit for the standard tax class
+  shipping_profile_id?: number /* uint */; // omit for the category's shipping profile
   images: string[];
   variants?: CreateVariantRequest[];
+  currency_prices?: CurrencyPriceRequest[];
+  sale?: SalePriceRequest;
 }
 /**
  * CreateVariantRequest represents the data needed to create a new product variant
@@ -310,6 +523,9 @@ export interface CreateVariantRequest {
   attributes: VariantAttributeDTO[];
   images?: string[];
   is_default?: boolean;
+  dimensions?: DimensionsDTO; // omit to use the product's dimensions
+  currency_prices?: CurrencyPriceRequest[];
+  sale?: SalePriceRequest;
 }
 /**
  * UpdateProductRequest represents the data needed to update an existing product
@@ -320,9 +536,31 @@ export interface UpdateProductRequest {
   price?: number /* float64 */;
   stock?: number /* int */;
   weight?: number /* float64 */;
+  dimensions?: DimensionsDTO; // omit to keep the current dimensions
   category_id?: number /* uint */;
+  tax_class_id?: number /* uint */; // 0 selects the standard tax class
+  shipping_profile_id?: number /* uint */; // 0 selects the category's shipping profile
   images?: string[];
   active?: boolean;
+  currency_prices?: CurrencyPriceRequest[];
+  sale?: SalePriceRequest; // omit to keep the current sale
+}
+/**
+ * CurrencyPriceRequest represents a price in a specific currency
+ */
+export interface CurrencyPriceRequest {
+  currency_code: string;
+  price: number /* float64 */;
+  sale?: SalePriceRequest;
+}
+/**
+ * SalePriceRequest represents the compare-at price and scheduled sale of a price
+ */
+export interface SalePriceRequest {
+  compare_at_price?: number /* float64 */;
+  sale_price?: number /* float64 */;
+  sale_starts_at?: string;
+  sale_ends_at?: string;
 }
 /**
  * ProductListResponse represents a paginated list of products
@@ -331,6 +569,32 @@ export interface ProductListResponse {
   ListResponseDTO: ListResponseDTO<ProductDTO>;
 }
 
+//////////
+// source: tracking.go
+
+/**
+ * OrderTrackingDTO represents the shipment tracking of an order shown to the customer
+ */
+export interface OrderTrackingDTO {
+  order_number: string;
+  status: OrderStatus;
+  carrier?: string;
+  tracking_number?: string;
+  tracking_url?: string;
+  pickup_point?: PickupPointDTO;
+  estimated_delivery?: DeliveryEstimateDTO;
+  events: TrackingEventDTO[]; // oldest first
+}
+/**
+ * TrackingEventDTO represents a scan or status change of a shipment
+ */
+export interface TrackingEventDTO {
+  status: string; // "pre_transit", "in_transit", "out_for_delivery", "delivered" or "exception"
+  description: string;
+  location?: string;
+  occurred_at: string;
+}
+
 //////////
 // source: user.go
 
@@ -343,6 +607,7 @@ export interface UserDTO {
   first_name: string;
   last_name: string;
   role: string;
+  customer_group_id?: number /* uint */;
   created_at: string;
   updated_at: string;
 }